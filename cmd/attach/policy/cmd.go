@@ -0,0 +1,153 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/aws"
+	awscb "github.com/openshift/rosa/pkg/aws/commandbuilder"
+	"github.com/openshift/rosa/pkg/aws/tags"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var args struct {
+	roleName   string
+	policyARNs []string
+}
+
+var Cmd = &cobra.Command{
+	Use:     "policy",
+	Aliases: []string{"policies"},
+	Short:   "Attach policies to a role",
+	Long: "Attach customer managed policies to a role created by rosa, for example to add the " +
+		"permissions required by corporate policies to the account roles.",
+	Example: `  # Attach a policy to the installer account role
+  rosa attach policy --role-name ManagedOpenShift-Installer-Role \
+  --policy-arns arn:aws:iam::123456789012:policy/CorporatePolicy`,
+	Args: cobra.NoArgs,
+	Run:  run,
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
+	flags.StringVar(
+		&args.roleName,
+		"role-name",
+		"",
+		"Name of the role to attach the policies to.",
+	)
+	flags.StringSliceVar(
+		&args.policyARNs,
+		"policy-arns",
+		[]string{},
+		"Comma-separated list of ARNs of the policies to attach.",
+	)
+	aws.AddModeFlag(Cmd)
+	Cmd.MarkFlagRequired("role-name")
+	Cmd.MarkFlagRequired("policy-arns")
+}
+
+func run(_ *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS()
+	defer r.Cleanup()
+
+	mode, err := aws.GetMode()
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+	if mode == "" {
+		mode = aws.ModeAuto
+	}
+
+	err = ValidateRosaRole(r, args.roleName)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+	err = r.AWSClient.ValidateAttachRolePolicies(args.roleName, args.policyARNs)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+
+	switch mode {
+	case aws.ModeAuto:
+		for _, policyARN := range args.policyARNs {
+			attached, err := r.AWSClient.IsRolePolicyAttached(args.roleName, policyARN)
+			if err != nil {
+				r.Reporter.Errorf("Failed to get the policies attached to role '%s': %v", args.roleName, err)
+				os.Exit(1)
+			}
+			if attached {
+				r.Reporter.Infof("Policy '%s' is already attached to role '%s'", policyARN, args.roleName)
+				continue
+			}
+			err = r.AWSClient.AttachRolePolicy(args.roleName, policyARN)
+			if err != nil {
+				r.Reporter.Errorf("Failed to attach policy '%s' to role '%s': %v", policyARN, args.roleName, err)
+				os.Exit(1)
+			}
+			r.Reporter.Infof("Attached policy '%s' to role '%s'", policyARN, args.roleName)
+		}
+	case aws.ModeManual:
+		commands := []string{}
+		for _, policyARN := range args.policyARNs {
+			commands = append(commands, awscb.NewIAMCommandBuilder().
+				SetCommand(awscb.AttachRolePolicy).
+				AddParam(awscb.RoleName, args.roleName).
+				AddParam(awscb.PolicyArn, policyARN).
+				Build())
+		}
+		if r.Reporter.IsTerminal() {
+			r.Reporter.Infof("Run the following commands to attach the policies:\n")
+		}
+		fmt.Println(strings.Join(commands, "\n"))
+	default:
+		r.Reporter.Errorf("Invalid mode. Allowed values are %s", aws.Modes)
+		os.Exit(1)
+	}
+}
+
+// ValidateRosaRole checks that the role exists and that it was created by rosa, so that policies are
+// only attached to or detached from the roles managed through this tool.
+func ValidateRosaRole(r *rosa.Runtime, roleName string) error {
+	exists, roleARN, err := r.AWSClient.CheckRoleExists(roleName)
+	if err != nil {
+		return fmt.Errorf("Failed to get role '%s': %v", roleName, err)
+	}
+	if !exists {
+		return fmt.Errorf("Role '%s' does not exist", roleName)
+	}
+	role, err := r.AWSClient.GetRoleByARN(roleARN)
+	if err != nil {
+		return fmt.Errorf("Failed to get role '%s': %v", roleName, err)
+	}
+	for _, tag := range role.Tags {
+		if *tag.Key == tags.RedHatManaged && *tag.Value == tags.True {
+			return nil
+		}
+	}
+	return fmt.Errorf("Role '%s' was not created by rosa", roleName)
+}
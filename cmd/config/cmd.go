@@ -0,0 +1,43 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/cmd/config/get"
+	"github.com/openshift/rosa/cmd/config/set"
+	"github.com/openshift/rosa/cmd/config/unset"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "config",
+	Short: "Read and write settings of the configuration file",
+	Long: "Read and write individual settings of the configuration file, or of the configuration " +
+		"profile selected with '--config-profile'. The configuration file is removed by 'rosa logout'.",
+	Example: `  # Use us-east-2 as the default region
+  rosa config set region us-east-2
+
+  # Show all the settings
+  rosa config get`,
+}
+
+func init() {
+	Cmd.AddCommand(get.Cmd)
+	Cmd.AddCommand(set.Cmd)
+	Cmd.AddCommand(unset.Cmd)
+}
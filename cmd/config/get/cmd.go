@@ -0,0 +1,71 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package get
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/config"
+	rprtr "github.com/openshift/rosa/pkg/reporter"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "get [SETTING]",
+	Short: "Show settings of the configuration file",
+	Long:  "Show the value of a setting of the configuration file, or of all the settings.",
+	Example: `  # Show the default region
+  rosa config get region
+
+  # Show all the settings
+  rosa config get`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  run,
+}
+
+func run(_ *cobra.Command, argv []string) {
+	reporter := rprtr.CreateReporterOrExit()
+
+	cfg, err := config.Load()
+	if err != nil {
+		reporter.Errorf("Failed to load config file: %v", err)
+		os.Exit(1)
+	}
+	if cfg == nil {
+		cfg = new(config.Config)
+	}
+
+	if len(argv) == 1 {
+		setting, err := config.FindSetting(argv[0])
+		if err != nil {
+			reporter.Errorf("%v", err)
+			os.Exit(1)
+		}
+		fmt.Println(setting.Get(cfg))
+		return
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "SETTING\tVALUE\tDESCRIPTION\n")
+	for _, setting := range config.Settings {
+		fmt.Fprintf(writer, "%s\t%s\t%s\n", setting.Name, setting.Get(cfg), setting.Description)
+	}
+	writer.Flush()
+}
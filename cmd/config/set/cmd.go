@@ -0,0 +1,92 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package set
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/config"
+	"github.com/openshift/rosa/pkg/fedramp"
+	"github.com/openshift/rosa/pkg/ocm"
+	rprtr "github.com/openshift/rosa/pkg/reporter"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "set SETTING VALUE",
+	Short: "Change a setting of the configuration file",
+	Long: "Change a setting of the configuration file. Run 'rosa config get' to see the settings " +
+		"and their description.",
+	Example: `  # Use us-east-2 as the default region
+  rosa config set region us-east-2
+
+  # Print the output of commands as JSON by default
+  rosa config set output json
+
+  # Use the staging environment
+  rosa config set url staging`,
+	Args: cobra.ExactArgs(2),
+	Run:  run,
+}
+
+func run(_ *cobra.Command, argv []string) {
+	reporter := rprtr.CreateReporterOrExit()
+
+	setting, err := config.FindSetting(argv[0])
+	if err != nil {
+		reporter.Errorf("%v", err)
+		os.Exit(1)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		reporter.Errorf("Failed to load config file: %v", err)
+		os.Exit(1)
+	}
+	if cfg == nil {
+		cfg = new(config.Config)
+	}
+
+	value := argv[1]
+	// Accept the same environment aliases as 'rosa login --env':
+	if setting.Name == "url" {
+		aliases := ocm.URLAliases
+		if cfg.FedRAMP {
+			aliases = fedramp.URLAliases
+		}
+		if url, ok := aliases[value]; ok {
+			value = url
+		}
+	}
+
+	loggedOut, err := setting.Set(cfg, value)
+	if err != nil {
+		reporter.Errorf("%v", err)
+		os.Exit(1)
+	}
+	err = config.Save(cfg)
+	if err != nil {
+		reporter.Errorf("Failed to save config file: %v", err)
+		os.Exit(1)
+	}
+	reporter.Infof("Setting '%s' has been set to '%s'", setting.Name, value)
+	if loggedOut {
+		reporter.Warnf("The OCM environment has changed, so the current credentials have been removed. " +
+			"Run 'rosa login' to log in to the new environment")
+	}
+}
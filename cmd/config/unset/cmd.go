@@ -0,0 +1,68 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package unset
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/config"
+	rprtr "github.com/openshift/rosa/pkg/reporter"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "unset SETTING",
+	Short: "Remove a setting from the configuration file",
+	Long:  "Remove a setting from the configuration file, restoring its default.",
+	Example: `  # Stop using a default region
+  rosa config unset region`,
+	Args: cobra.ExactArgs(1),
+	Run:  run,
+}
+
+func run(_ *cobra.Command, argv []string) {
+	reporter := rprtr.CreateReporterOrExit()
+
+	setting, err := config.FindSetting(argv[0])
+	if err != nil {
+		reporter.Errorf("%v", err)
+		os.Exit(1)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		reporter.Errorf("Failed to load config file: %v", err)
+		os.Exit(1)
+	}
+	if cfg == nil {
+		reporter.Infof("Setting '%s' isn't set", setting.Name)
+		return
+	}
+
+	loggedOut := setting.Unset(cfg)
+	err = config.Save(cfg)
+	if err != nil {
+		reporter.Errorf("Failed to save config file: %v", err)
+		os.Exit(1)
+	}
+	reporter.Infof("Setting '%s' has been removed", setting.Name)
+	if loggedOut {
+		reporter.Warnf("The OCM environment has changed, so the current credentials have been removed. " +
+			"Run 'rosa login' to log in to the new environment")
+	}
+}
@@ -42,6 +42,7 @@ var args struct {
 	managed             bool
 	forcePolicyCreation bool
 	hostedCP            bool
+	tags                []string
 }
 
 var Cmd = &cobra.Command{
@@ -127,6 +128,14 @@ func init() {
 	)
 	flags.MarkHidden("hosted-cp")
 
+	flags.StringSliceVar(
+		&args.tags,
+		"tags",
+		nil,
+		"Apply user defined tags to the account roles and policies created by ROSA in AWS. "+
+			"Tags are comma separated, for example: --tags=foo:bar,bar:baz",
+	)
+
 	aws.AddModeFlag(Cmd)
 
 	confirm.AddFlag(flags)
@@ -259,7 +268,7 @@ func run(cmd *cobra.Command, argv []string) {
 			Help:     cmd.Flags().Lookup("permissions-boundary").Usage,
 			Default:  permissionsBoundary,
 			Validators: []interactive.Validator{
-				aws.ARNValidator,
+				aws.PermissionsBoundaryValidator,
 			},
 		})
 		if err != nil {
@@ -268,12 +277,10 @@ func run(cmd *cobra.Command, argv []string) {
 		}
 	}
 
-	if permissionsBoundary != "" {
-		err = aws.ARNValidator(permissionsBoundary)
-		if err != nil {
-			r.Reporter.Errorf("Expected a valid policy ARN for permissions boundary: %s", err)
-			os.Exit(1)
-		}
+	err = aws.ValidatePermissionsBoundary(r.AWSClient, permissionsBoundary)
+	if err != nil {
+		r.Reporter.Errorf("Expected a valid policy ARN for permissions boundary: %s", err)
+		os.Exit(1)
 	}
 
 	path := args.path
@@ -298,6 +305,8 @@ func run(cmd *cobra.Command, argv []string) {
 		os.Exit(1)
 	}
 
+	userTags := interactive.GetUserTags(r, cmd, args.tags)
+
 	if interactive.Enabled() {
 		mode, err = interactive.GetOption(interactive.Input{
 			Question: "Role creation mode",
@@ -325,7 +334,7 @@ func run(cmd *cobra.Command, argv []string) {
 
 	rolesCreator := initCreator(managedPolicies, args.hostedCP)
 	input := buildRolesCreationInput(prefix, permissionsBoundary, r.Creator.AccountID, env, policies,
-		policyVersion, path)
+		policyVersion, path, userTags)
 
 	switch mode {
 	case aws.ModeAuto:
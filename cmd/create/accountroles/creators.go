@@ -38,11 +38,12 @@ type accountRolesCreationInput struct {
 	policies             map[string]*cmv1.AWSSTSPolicy
 	defaultPolicyVersion string
 	path                 string
+	userTags             map[string]string
 }
 
 func buildRolesCreationInput(prefix, permissionsBoundary, accountID, env string,
 	policies map[string]*cmv1.AWSSTSPolicy, defaultPolicyVersion string,
-	path string) *accountRolesCreationInput {
+	path string, userTags map[string]string) *accountRolesCreationInput {
 	return &accountRolesCreationInput{
 		prefix:               prefix,
 		permissionsBoundary:  permissionsBoundary,
@@ -51,6 +52,7 @@ func buildRolesCreationInput(prefix, permissionsBoundary, accountID, env string,
 		policies:             policies,
 		defaultPolicyVersion: defaultPolicyVersion,
 		path:                 path,
+		userTags:             userTags,
 	}
 }
 
@@ -276,12 +278,12 @@ func (hcp *hcpManagedPoliciesCreator) getRoleTags(roleType string, input *accoun
 }
 
 func getBaseRoleTags(roleType string, input *accountRolesCreationInput) map[string]string {
-	return map[string]string{
+	return aws.MergeUserTags(input.userTags, map[string]string{
 		tags.OpenShiftVersion: input.defaultPolicyVersion,
 		tags.RolePrefix:       input.prefix,
 		tags.RoleType:         roleType,
 		tags.RedHatManaged:    tags.True,
-	}
+	})
 }
 
 func buildCreateRoleCommand(accRoleName string, file string, iamTags map[string]string,
@@ -83,7 +83,7 @@ func run(cmd *cobra.Command, _ []string) {
 	passwordArg := args.passwordArg
 	if len(passwordArg) == 0 {
 		r.Reporter.Debugf("Generating random password")
-		password, err = generateRandomPassword(23)
+		password, err = GenerateRandomPassword(23)
 		if err != nil {
 			r.Reporter.Errorf("Failed to generate a random password")
 			os.Exit(1)
@@ -181,14 +181,14 @@ func run(cmd *cobra.Command, _ []string) {
 
 	r.Reporter.Infof("Admin account has been added to cluster '%s'.", clusterKey)
 	r.Reporter.Infof("Please securely store this generated password. " +
-		"If you lose this password you can delete and recreate the cluster admin user.")
+		"If you lose this password you can rotate it with 'rosa edit admin --rotate-password'.")
 	r.Reporter.Infof("To login, run the following command:\n\n"+
 		"   oc login %s --username %s --password %s\n",
 		outputObject["api_url"], outputObject["username"], outputObject["password"])
 	r.Reporter.Infof("It may take several minutes for this access to become active.")
 }
 
-func generateRandomPassword(length int) (string, error) {
+func GenerateRandomPassword(length int) (string, error) {
 	const (
 		lowerLetters = "abcdefghijkmnopqrstuvwxyz"
 		upperLetters = "ABCDEFGHIJKLMNPQRSTUVWXYZ"
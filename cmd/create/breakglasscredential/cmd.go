@@ -0,0 +1,181 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package breakglasscredential
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+	"github.com/openshift/rosa/pkg/wait"
+)
+
+var Cmd = &cobra.Command{
+	Use:     "break-glass-credential",
+	Aliases: []string{"breakglasscredential", "break-glass-credentials"},
+	Short:   "Create a break glass credential for a cluster",
+	Long: "Create a short lived kubeconfig that gives emergency access to a Hosted Control Plane " +
+		"cluster that uses external authentication, for example when the external OIDC provider " +
+		"isn't available.\n\n" + wait.ExitCodesHelp,
+	Example: `  # Create a break glass credential that expires in 2 hours
+  rosa create break-glass-credential -c mycluster --expiration 2h
+
+  # Create a break glass credential and write its kubeconfig to a file once it is issued
+  rosa create break-glass-credential -c mycluster --wait --kubeconfig ./break-glass.kubeconfig`,
+	Run:  run,
+	Args: cobra.NoArgs,
+}
+
+var args struct {
+	username   string
+	expiration time.Duration
+	kubeconfig string
+	wait       *wait.Options
+}
+
+// waitInterval is the time between checks of the credential when '--wait' is used.
+const waitInterval = 5 * time.Second
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
+	ocm.AddClusterFlag(Cmd)
+	flags.StringVar(
+		&args.username,
+		"username",
+		"",
+		"Username of the break glass credential. Generated by the service when not specified.",
+	)
+	flags.DurationVar(
+		&args.expiration,
+		"expiration",
+		ocm.BreakGlassCredentialDefaultExpiration,
+		fmt.Sprintf("Time after which the break glass credential expires, between %s and %s.",
+			ocm.BreakGlassCredentialMinExpiration, ocm.BreakGlassCredentialMaxExpiration),
+	)
+	flags.StringVar(
+		&args.kubeconfig,
+		"kubeconfig",
+		"",
+		"Path of the file where the kubeconfig of the credential is written once it is issued. "+
+			"Requires '--wait'.",
+	)
+	args.wait = wait.AddFlags(flags, "the break glass credential is issued", 10*time.Minute)
+	output.AddFlag(Cmd)
+}
+
+func run(_ *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS().WithOCM()
+	defer r.Cleanup()
+
+	clusterKey := r.GetClusterKey()
+
+	err := ocm.ValidateBreakGlassCredentialUsername(args.username)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+	err = ocm.ValidateBreakGlassCredentialExpiration(args.expiration)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+	if args.kubeconfig != "" && !args.wait.Enabled {
+		r.Reporter.Errorf("The '--kubeconfig' option requires '--wait'")
+		os.Exit(1)
+	}
+
+	cluster := r.FetchCluster()
+	err = r.OCMClient.ValidateExternalAuthCluster(cluster)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+
+	r.Reporter.Debugf("Creating break glass credential for cluster '%s'", clusterKey)
+	credential, err := r.OCMClient.CreateBreakGlassCredential(cluster.ID(), args.username,
+		time.Now().Add(args.expiration).Round(time.Second))
+	if err != nil {
+		r.Reporter.Errorf("Failed to create break glass credential for cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+
+	if args.wait.Enabled {
+		if !output.HasFlag() {
+			r.Reporter.Infof("Waiting up to %s for break glass credential '%s' to be issued",
+				args.wait.Timeout, credential.ID)
+		}
+		err = args.wait.Poll(waitInterval, func() (bool, error) {
+			current, err := r.OCMClient.GetBreakGlassCredential(cluster.ID(), credential.ID)
+			if err != nil {
+				return false, wait.Transient(err)
+			}
+			if current == nil {
+				return false, fmt.Errorf("Break glass credential '%s' no longer exists", credential.ID)
+			}
+			credential = current
+			switch credential.Status {
+			case ocm.BreakGlassCredentialStatusIssued:
+				return true, nil
+			case ocm.BreakGlassCredentialStatusCreated:
+				return false, nil
+			default:
+				return false, fmt.Errorf("Break glass credential '%s' is %s", credential.ID, credential.Status)
+			}
+		})
+		if err != nil {
+			r.Reporter.Errorf("Break glass credential for cluster '%s' wasn't issued: %v", clusterKey, err)
+			os.Exit(wait.ExitCode(err))
+		}
+		if args.kubeconfig != "" {
+			err = os.WriteFile(args.kubeconfig, []byte(credential.Kubeconfig), 0600)
+			if err != nil {
+				r.Reporter.Errorf("Failed to write kubeconfig to '%s': %v", args.kubeconfig, err)
+				os.Exit(1)
+			}
+		}
+	}
+
+	if output.HasFlag() {
+		// The kubeconfig is only written to the file requested by the user:
+		credential.Kubeconfig = ""
+		err = output.Print(credential)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if credential.Status != ocm.BreakGlassCredentialStatusIssued {
+		r.Reporter.Infof("Break glass credential '%s' has been created for cluster '%s'.", credential.ID, clusterKey)
+		r.Reporter.Infof("To get its kubeconfig once it is issued, run "+
+			"'rosa describe break-glass-credential -c %s --id %s --kubeconfig <file>'.", clusterKey, credential.ID)
+		return
+	}
+	r.Reporter.Infof("Break glass credential '%s' has been issued for cluster '%s'.", credential.ID, clusterKey)
+	if args.kubeconfig != "" {
+		r.Reporter.Infof("Kubeconfig written to '%s', it expires at %s.", args.kubeconfig,
+			credential.ExpirationTimestamp.Format(time.RFC3339))
+	}
+}
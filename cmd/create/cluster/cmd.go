@@ -17,7 +17,8 @@ limitations under the License.
 package cluster
 
 import (
-	"errors"
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"net"
 	"os"
@@ -30,6 +31,7 @@ import (
 	v1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	mpHelpers "github.com/openshift/rosa/pkg/helper/machinepools"
 	"github.com/spf13/cobra"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 
 	"github.com/openshift/rosa/cmd/create/oidcprovider"
 	"github.com/openshift/rosa/cmd/create/operatorroles"
@@ -37,8 +39,12 @@ import (
 	installLogs "github.com/openshift/rosa/cmd/logs/install"
 	"github.com/openshift/rosa/pkg/arguments"
 	"github.com/openshift/rosa/pkg/aws"
+	awscb "github.com/openshift/rosa/pkg/aws/commandbuilder"
 	"github.com/openshift/rosa/pkg/fedramp"
 	"github.com/openshift/rosa/pkg/helper"
+	"github.com/openshift/rosa/pkg/helper/clusterspec"
+	"github.com/openshift/rosa/pkg/helper/oidc"
+	"github.com/openshift/rosa/pkg/helper/parallel"
 	"github.com/openshift/rosa/pkg/helper/roles"
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/interactive/confirm"
@@ -46,6 +52,7 @@ import (
 	"github.com/openshift/rosa/pkg/output"
 	"github.com/openshift/rosa/pkg/properties"
 	"github.com/openshift/rosa/pkg/rosa"
+	"github.com/openshift/rosa/pkg/wait"
 )
 
 // nolint
@@ -53,12 +60,18 @@ var kmsArnRE = regexp.MustCompile(
 	`^arn:aws[\w-]*:kms:[\w-]+:\d{12}:key\/mrk-[0-9a-f]{32}$|[0-9a-f]{8}-[0-9a-f]{4}-[1-5][0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`,
 )
 
+// waitInterval is the time between checks of the cluster state when '--wait' is used.
+const waitInterval = 30 * time.Second
+
 const (
 	OidcConfigIdFlag      = "oidc-config-id"
 	ClassicOidcConfigFlag = "classic-oidc-config"
 )
 
 var args struct {
+	// Read cluster options from a YAML or JSON document
+	specFile string
+
 	// Watch logs during cluster installation
 	watch bool
 
@@ -86,6 +99,7 @@ var args struct {
 	channelGroup              string
 	flavour                   string
 	disableWorkloadMonitoring bool
+	ec2MetadataHttpTokens     string
 
 	//Encryption
 	etcdEncryption           bool
@@ -93,6 +107,10 @@ var args struct {
 	enableCustomerManagedKey bool
 	kmsKeyARN                string
 	etcdEncryptionKmsARN     string
+	// Image registry options
+	allowedRegistries  []string
+	blockedRegistries  []string
+	insecureRegistries []string
 	// Scaling options
 	computeMachineType       string
 	computeNodes             int
@@ -113,6 +131,15 @@ var args struct {
 	// unless using PrivateLink, in which case it should only be one private per availability zone
 	subnetIDs []string
 
+	// Shared VPC options
+	sharedVPCRoleARN string
+	baseDomain       string
+
+	// Additional security groups attached to the compute, infra and control plane nodes
+	additionalComputeSecurityGroupIds      []string
+	additionalInfraSecurityGroupIds        []string
+	additionalControlPlaneSecurityGroupIds []string
+
 	// Selecting availability zones for a non-BYOVPC cluster
 	availabilityZones []string
 
@@ -148,18 +175,34 @@ var args struct {
 	tags []string
 
 	// Hypershift options:
-	hostedClusterEnabled bool
+	hostedClusterEnabled         bool
+	billingAccount               string
+	externalAuthProvidersEnabled bool
+	auditLogRoleARN              string
+	wait                         *wait.Options
 }
 
 var Cmd = &cobra.Command{
 	Use:   "cluster",
 	Short: "Create cluster",
-	Long:  "Create cluster.",
+	Long:  "Create cluster.\n\n" + wait.ExitCodesHelp,
 	Example: `  # Create a cluster named "mycluster"
   rosa create cluster --cluster-name=mycluster
 
+  # Create a cluster and wait up to two hours until it is ready
+  rosa create cluster --cluster-name=mycluster --wait --timeout=2h -o json
+
+  # Create a test cluster that is deleted automatically after three days, outside production
+  rosa create cluster --cluster-name=mycluster --expiration=72h
+
+  # Create a hosted cluster billed to another AWS account linked to the organization
+  rosa create cluster --cluster-name=mycluster --hosted-cp --billing-account=123456789012
+
   # Create a cluster in the us-east-2 region
-  rosa create cluster --cluster-name=mycluster --region=us-east-2`,
+  rosa create cluster --cluster-name=mycluster --region=us-east-2
+
+  # Create a cluster described in a spec file, overriding its version
+  rosa create cluster --spec-file=cluster.yaml --version=4.12.10`,
 	Run: run,
 }
 
@@ -184,6 +227,8 @@ func init() {
 		"Name of the cluster. This will be used when generating a sub-domain for your cluster on openshiftapps.com.",
 	)
 
+	clusterspec.AddFlag(flags, &args.specFile)
+
 	flags.BoolVar(
 		&args.sts,
 		"sts",
@@ -374,21 +419,69 @@ func init() {
 			"If set it will override etcd-encryption flag to true. It is a unique, "+
 			"fully qualified identifier for the CMK. A key ARN includes the AWS account, Region, and the key ID.")
 
+	flags.StringSliceVar(
+		&args.allowedRegistries,
+		"registry-config-allowed-registries",
+		nil,
+		"Comma-separated list of the only registries that the cluster is allowed to pull images from. "+
+			"Mutually exclusive with --registry-config-blocked-registries. Only supported for hosted clusters.",
+	)
+	flags.StringSliceVar(
+		&args.blockedRegistries,
+		"registry-config-blocked-registries",
+		nil,
+		"Comma-separated list of registries that the cluster isn't allowed to pull images from. "+
+			"Mutually exclusive with --registry-config-allowed-registries. Only supported for hosted clusters.",
+	)
+	flags.StringSliceVar(
+		&args.insecureRegistries,
+		"registry-config-insecure-registries",
+		nil,
+		"Comma-separated list of registries that don't have a valid TLS certificate or only support HTTP. "+
+			"Only supported for hosted clusters.",
+	)
+
+	flags.StringVar(
+		&args.auditLogRoleARN,
+		"audit-log-arn",
+		"",
+		"ARN of the IAM role used to forward the audit logs of the API server to CloudWatch. The role "+
+			"must trust the OIDC provider of the cluster for the audit log exporter service account. "+
+			"Only supported for hosted clusters.",
+	)
+
+	flags.StringVar(
+		&args.billingAccount,
+		"billing-account",
+		"",
+		"AWS account billed for the usage of the cluster. Defaults to the AWS account the cluster is "+
+			"installed in. Run 'rosa list billing-accounts' to see the accounts linked to your organization. "+
+			"Only supported for hosted clusters.",
+	)
+
+	flags.BoolVar(
+		&args.externalAuthProvidersEnabled,
+		"external-auth-providers-enabled",
+		false,
+		"Authenticate the users of the cluster with external OIDC providers, managed with "+
+			"'rosa create external-auth-provider', instead of the built-in OpenShift OAuth server. "+
+			"Only supported for hosted clusters.",
+	)
+
 	flags.StringVar(
 		&args.expirationTime,
-		"expiration-time",
+		ocm.ExpirationTimeFlag,
 		"",
-		"Specific time when cluster should expire (RFC3339). Only one of expiration-time / expiration may be used.",
+		"Specific time when cluster should expire (RFC3339). Only one of expiration-time / expiration may be used. "+
+			ocm.ExpirationHelp,
 	)
 	flags.DurationVar(
 		&args.expirationDuration,
-		"expiration",
+		ocm.ExpirationFlag,
 		0,
-		"Expire cluster after a relative duration like 2h, 8h, 72h. Only one of expiration-time / expiration may be used.",
+		"Expire cluster after a relative duration like 2h, 8h, 72h. Only one of expiration-time / expiration may be used. "+
+			ocm.ExpirationHelp,
 	)
-	// Cluster expiration is not supported in production
-	flags.MarkHidden("expiration-time")
-	flags.MarkHidden("expiration")
 
 	flags.BoolVar(
 		&args.privateLink,
@@ -407,6 +500,47 @@ func init() {
 			"Leave empty for installer provisioned subnet IDs.",
 	)
 
+	flags.StringSliceVar(
+		&args.additionalComputeSecurityGroupIds,
+		"additional-compute-security-group-ids",
+		nil,
+		"The additional security group IDs to attach to the compute nodes, in the VPC of the subnets. "+
+			"Format should be a comma-separated list.",
+	)
+
+	flags.StringSliceVar(
+		&args.additionalInfraSecurityGroupIds,
+		"additional-infra-security-group-ids",
+		nil,
+		"The additional security group IDs to attach to the infra nodes, in the VPC of the subnets. "+
+			"Format should be a comma-separated list.",
+	)
+
+	flags.StringSliceVar(
+		&args.additionalControlPlaneSecurityGroupIds,
+		"additional-control-plane-security-group-ids",
+		nil,
+		"The additional security group IDs to attach to the control plane nodes, in the VPC of the subnets. "+
+			"Format should be a comma-separated list.",
+	)
+
+	flags.StringVar(
+		&args.sharedVPCRoleARN,
+		"shared-vpc-role-arn",
+		"",
+		"ARN of the role, in the AWS account that owns the VPC of the subnets, that the cluster "+
+			"assumes to manage the records of the private hosted zone shared with the VPC.",
+	)
+
+	flags.StringVar(
+		&args.baseDomain,
+		"base-domain",
+		"",
+		"Base DNS domain of the cluster. Required for clusters installed in a shared VPC, where "+
+			"the private hosted zone of this domain must be associated with the VPC. For Hosted Control "+
+			"Plane clusters it must be a domain reserved with 'rosa create dns-domain'.",
+	)
+
 	flags.StringSliceVar(
 		&args.availabilityZones,
 		"availability-zones",
@@ -523,19 +657,34 @@ func init() {
 			"platform metrics.",
 	)
 
+	flags.StringVar(
+		&args.ec2MetadataHttpTokens,
+		"ec2-metadata-http-tokens",
+		"",
+		fmt.Sprintf("Should cluster nodes use both v1 and v2 endpoints or just v2 endpoint "+
+			"of EC2 Instance Metadata Service (IMDS). Available options: %s. Requiring IMDSv2 needs "+
+			"version %s or greater.", strings.Join(ocm.Ec2MetadataHttpTokensValues, ", "), ocm.MinimumIMDSv2Version),
+	)
+	Cmd.RegisterFlagCompletionFunc("ec2-metadata-http-tokens", ec2MetadataHttpTokensCompletion)
+
 	flags.BoolVarP(
 		&args.watch,
 		"watch",
 		"w",
 		false,
-		"Watch cluster installation logs.",
+		"Watch cluster installation logs and state transitions until the cluster is ready (exit code 0) "+
+			"or fails to install (exit code 1). If the installation fails, the install logs and the errors of "+
+			"the inflight checks are saved to a file in the current directory.",
 	)
 
+	args.wait = wait.AddFlags(flags, "the cluster is ready", 90*time.Minute)
+
 	flags.BoolVar(
 		&args.dryRun,
 		"dry-run",
 		false,
-		"Simulate creating the cluster.",
+		"Simulate creating the cluster. All validations are run, including the server-side ones, "+
+			"and the request that would be sent is printed without creating anything.",
 	)
 
 	flags.BoolVar(
@@ -585,12 +734,33 @@ func init() {
 	confirm.AddFlag(flags)
 }
 
+func ec2MetadataHttpTokensCompletion(cmd *cobra.Command, args []string,
+	toComplete string) ([]string, cobra.ShellCompDirective) {
+	return ocm.Ec2MetadataHttpTokensValues, cobra.ShellCompDirectiveDefault
+}
+
 func networkTypeCompletion(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 	return ocm.NetworkTypes, cobra.ShellCompDirectiveDefault
 }
 
 func run(cmd *cobra.Command, _ []string) {
-	r := rosa.NewRuntime().WithAWS().WithOCM()
+	r := rosa.NewRuntime()
+
+	// The spec file needs to be applied before anything else, as it can also set the AWS region:
+	if args.specFile != "" {
+		spec, err := clusterspec.Load(args.specFile)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+		err = spec.Apply(cmd.Flags())
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+	}
+
+	r = r.WithAWS().WithOCM()
 	defer r.Cleanup()
 
 	supportedRegions, err := r.OCMClient.GetDatabaseRegionList()
@@ -666,6 +836,11 @@ func run(cmd *cobra.Command, _ []string) {
 			"start with a letter, and end with an alphanumeric character.")
 		os.Exit(1)
 	}
+	err = validateClusterNameAvailable(r, clusterName)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
 
 	isHostedCP := args.hostedClusterEnabled
 	if interactive.Enabled() && cmd.Flags().Changed("hosted-cp") {
@@ -693,6 +868,74 @@ func run(cmd *cobra.Command, _ []string) {
 		os.Exit(1)
 	}
 
+	registryConfig, err := ocm.NewRegistryConfig(args.allowedRegistries, args.blockedRegistries,
+		args.insecureRegistries)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+	if registryConfig != nil && !isHostedCP {
+		r.Reporter.Errorf("Image registry configuration is only supported for hosted clusters")
+		os.Exit(1)
+	}
+
+	billingAccount := args.billingAccount
+	if billingAccount != "" && !isHostedCP {
+		r.Reporter.Errorf("Billing account is only supported for hosted clusters")
+		os.Exit(1)
+	}
+	if isHostedCP && (billingAccount != "" || interactive.Enabled()) {
+		billingAccounts, err := r.OCMClient.GetBillingAccounts()
+		if err != nil {
+			r.Reporter.Errorf("Failed to get billing accounts: %v", err)
+			os.Exit(1)
+		}
+		if interactive.Enabled() && len(billingAccounts) > 0 {
+			options := make([]string, len(billingAccounts))
+			for i, account := range billingAccounts {
+				options[i] = account.CloudAccountID
+			}
+			if billingAccount == "" {
+				billingAccount = awsCreator.AccountID
+			}
+			billingAccount, err = interactive.GetOption(interactive.Input{
+				Question: "Billing account",
+				Help:     cmd.Flags().Lookup("billing-account").Usage,
+				Options:  options,
+				Default:  billingAccount,
+				Required: true,
+			})
+			if err != nil {
+				r.Reporter.Errorf("Expected a valid billing account: %s", err)
+				os.Exit(1)
+			}
+		}
+		if billingAccount != "" {
+			err = ocm.ValidateBillingAccount(billingAccounts, billingAccount, time.Now())
+			if err != nil {
+				r.Reporter.Errorf("%s", err)
+				os.Exit(1)
+			}
+		}
+	}
+
+	externalAuthProvidersEnabled := args.externalAuthProvidersEnabled
+	if externalAuthProvidersEnabled && !isHostedCP {
+		r.Reporter.Errorf("External authentication providers are only supported for hosted clusters")
+		os.Exit(1)
+	}
+	if isHostedCP && interactive.Enabled() {
+		externalAuthProvidersEnabled, err = interactive.GetBool(interactive.Input{
+			Question: "Enable external authentication providers",
+			Help:     cmd.Flags().Lookup("external-auth-providers-enabled").Usage,
+			Default:  externalAuthProvidersEnabled,
+		})
+		if err != nil {
+			r.Reporter.Errorf("Expected a valid value: %s", err)
+			os.Exit(1)
+		}
+	}
+
 	// all hosted clusters are sts
 	isSTS := args.sts || args.roleARN != "" || fedramp.Enabled() || isHostedCP
 	isIAM := (cmd.Flags().Changed("sts") && !isSTS) || args.nonSts
@@ -724,27 +967,45 @@ func run(cmd *cobra.Command, _ []string) {
 		r.Reporter.Warnf("--non-sts/--mint-mode flag will be necessary if you do not wish to use STS.")
 	}
 
-	permissionsBoundary := args.operatorRolesPermissionsBoundary
-	if permissionsBoundary != "" {
-		err = aws.ARNValidator(permissionsBoundary)
-		if err != nil {
-			r.Reporter.Errorf("Expected a valid policy ARN for permissions boundary: %s", err)
-			os.Exit(1)
-		}
+	if isIAM && awsCreator.IsSTS {
+		r.Reporter.Errorf("Since your AWS credentials are returning an STS ARN you can only " +
+			"create STS clusters. Otherwise, switch to IAM credentials.")
+		os.Exit(1)
 	}
 
-	if isIAM {
-		if awsCreator.IsSTS {
-			r.Reporter.Errorf("Since your AWS credentials are returning an STS ARN you can only " +
-				"create STS clusters. Otherwise, switch to IAM credentials.")
-			os.Exit(1)
-		}
-		err := awsClient.CheckAdminUserExists(aws.AdminUserName)
-		if err != nil {
-			r.Reporter.Errorf("IAM user '%s' does not exist. Run `rosa init` first", aws.AdminUserName)
-			os.Exit(1)
-		}
-		r.Reporter.Debugf("IAM user is valid!")
+	// The preflight checks and the list of versions don't depend on each other, so they are run
+	// concurrently:
+	permissionsBoundary := args.operatorRolesPermissionsBoundary
+	channelGroup := args.channelGroup
+	var versionList []string
+	err = parallel.FirstError(parallel.DefaultLimit,
+		func() error {
+			err := aws.ValidatePermissionsBoundary(r.AWSClient, permissionsBoundary)
+			if err != nil {
+				return fmt.Errorf("Expected a valid policy ARN for permissions boundary: %s", err)
+			}
+			return nil
+		},
+		func() error {
+			if !isIAM {
+				return nil
+			}
+			err := awsClient.CheckAdminUserExists(aws.AdminUserName)
+			if err != nil {
+				return fmt.Errorf("IAM user '%s' does not exist. Run `rosa init` first", aws.AdminUserName)
+			}
+			r.Reporter.Debugf("IAM user is valid!")
+			return nil
+		},
+		func() error {
+			var err error
+			versionList, err = getVersionList(r, channelGroup, isSTS, isHostedCP)
+			return err
+		},
+	)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
 	}
 
 	// AWS ARN Role
@@ -755,12 +1016,6 @@ func run(cmd *cobra.Command, _ []string) {
 
 	// OpenShift version:
 	version := args.version
-	channelGroup := args.channelGroup
-	versionList, err := getVersionList(r, channelGroup, isSTS, isHostedCP)
-	if err != nil {
-		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
-	}
 	if version == "" {
 		version = versionList[0]
 	}
@@ -1088,9 +1343,30 @@ func run(cmd *cobra.Command, _ []string) {
 		r.Reporter.Errorf("Failed to determine OCM environment: %v", err)
 		os.Exit(1)
 	}
-	managedPolicies, err := awsClient.HasManagedPolicies(roleARN)
+	var managedPolicies, hostedCPPolicies bool
+	err = parallel.FirstError(parallel.DefaultLimit,
+		func() error {
+			var err error
+			managedPolicies, err = awsClient.HasManagedPolicies(roleARN)
+			if err != nil {
+				return fmt.Errorf("Failed to determine if cluster has managed policies: %v", err)
+			}
+			return nil
+		},
+		func() error {
+			if !isHostedCP {
+				return nil
+			}
+			var err error
+			hostedCPPolicies, err = awsClient.HasHostedCPPolicies(roleARN)
+			if err != nil {
+				return fmt.Errorf("Failed to determine if cluster has hosted CP policies: %v", err)
+			}
+			return nil
+		},
+	)
 	if err != nil {
-		r.Reporter.Errorf("Failed to determine if cluster has managed policies: %v", err)
+		r.Reporter.Errorf("%s", err)
 		os.Exit(1)
 	}
 	// TODO: remove once AWS managed policies are in place
@@ -1099,15 +1375,6 @@ func run(cmd *cobra.Command, _ []string) {
 		os.Exit(1)
 	}
 
-	var hostedCPPolicies bool
-	if isHostedCP {
-		hostedCPPolicies, err = awsClient.HasHostedCPPolicies(roleARN)
-		if err != nil {
-			r.Reporter.Errorf("Failed to determine if cluster has hosted CP policies: %v", err)
-			os.Exit(1)
-		}
-	}
-
 	if managedPolicies {
 		var role aws.AccountRole
 		if hostedCPPolicies {
@@ -1240,59 +1507,45 @@ func run(cmd *cobra.Command, _ []string) {
 		}
 		oidcConfig = handleOidcConfigOptions(r, cmd, isSTS, isHostedCP)
 		err = validateOperatorRolesAvailabilityUnderUserAwsAccount(awsClient, operatorIAMRoleList)
+		var existingOperatorRoles []ocm.OperatorIAMRole
 		if err != nil {
 			if !oidcConfig.Reusable() {
 				r.Reporter.Errorf("%v", err)
 				os.Exit(1)
-			} else {
-				err = ocm.ValidateOperatorRolesMatchOidcProvider(awsClient, operatorIAMRoleList, oidcConfig.IssuerUrl(),
-					ocm.GetVersionMinor(version))
-				if err != nil {
-					r.Reporter.Errorf("%v", err)
-					os.Exit(1)
-				}
+			}
+			// Only the operator roles that already exist can be checked, the missing ones are
+			// created after the cluster:
+			existingOperatorRoles, err = getExistingOperatorRoles(awsClient, operatorIAMRoleList)
+			if err != nil {
+				r.Reporter.Errorf("%v", err)
+				os.Exit(1)
+			}
+			err = ocm.ValidateOperatorRolesMatchOidcProvider(awsClient, existingOperatorRoles, oidcConfig.IssuerUrl(),
+				ocm.GetVersionMinor(version))
+			if err != nil {
+				r.Reporter.Errorf("%v", err)
+				os.Exit(1)
+			}
+			if len(existingOperatorRoles) < len(operatorIAMRoleList) {
+				r.Reporter.Warnf("Only %d of the %d operator roles with prefix '%s' exist, the missing ones "+
+					"need to be created after the cluster", len(existingOperatorRoles), len(operatorIAMRoleList),
+					operatorRolesPrefix)
 			}
 		}
-	}
-
-	// Custom tags for AWS resources
-	tags := args.tags
-	tagsList := map[string]string{}
-	if interactive.Enabled() {
-		tagsInput, err := interactive.GetString(interactive.Input{
-			Question: "Tags",
-			Help:     cmd.Flags().Lookup("tags").Usage,
-			Default:  strings.Join(tags, ","),
-			Validators: []interactive.Validator{
-				aws.UserTagValidator,
-				aws.UserTagDuplicateValidator,
-			},
-		})
-		if err != nil {
-			r.Reporter.Errorf("Expected a valid set of tags: %s", err)
-			os.Exit(1)
-		}
-		if len(tagsInput) > 0 {
-			tags = strings.Split(tagsInput, ",")
-		}
-	}
-	if len(tags) > 0 {
-		duplicate, found := aws.HasDuplicateTagKey(tags)
-		if found {
-			r.Reporter.Errorf("Invalid tags, user tag keys must be unique, duplicate key '%s' found", duplicate)
-			os.Exit(1)
-		}
-		for _, tag := range tags {
-			err := aws.UserTagValidator(tag)
+		if oidcConfig != nil {
+			err = verifyOidcConfig(r, awsClient, awsCreator.AccountID, oidcConfig.IssuerUrl(),
+				existingOperatorRoles, credRequests)
 			if err != nil {
-				r.Reporter.Errorf("%s", err)
+				r.Reporter.Errorf("%v. Run 'rosa verify oidc-config --oidc-config-id %s' for details",
+					err, oidcConfig.ID())
 				os.Exit(1)
 			}
-			t := strings.Split(tag, ":")
-			tagsList[t[0]] = strings.TrimSpace(t[1])
 		}
 	}
 
+	// Custom tags for AWS resources
+	tagsList := interactive.GetUserTags(r, cmd, args.tags)
+
 	// Multi-AZ:
 	multiAZ := args.multiAZ
 	if interactive.Enabled() && !isHostedCP {
@@ -1376,6 +1629,41 @@ func run(cmd *cobra.Command, _ []string) {
 		os.Exit(1)
 	}
 
+	// Audit log forwarding:
+	auditLogRoleARN := args.auditLogRoleARN
+	if auditLogRoleARN != "" && !isHostedCP {
+		r.Reporter.Errorf("Audit log forwarding is only supported for hosted clusters")
+		os.Exit(1)
+	}
+	if isHostedCP && interactive.Enabled() {
+		auditLogRoleARN, err = interactive.GetString(interactive.Input{
+			Question: "Audit log forwarding role ARN",
+			Help:     cmd.Flags().Lookup("audit-log-arn").Usage,
+			Default:  auditLogRoleARN,
+			Validators: []interactive.Validator{
+				aws.ARNValidator,
+			},
+		})
+		if err != nil {
+			r.Reporter.Errorf("Expected a valid audit log role ARN: %s", err)
+			os.Exit(1)
+		}
+	}
+	if auditLogRoleARN != "" {
+		err = aws.ValidateAuditLogRoleARN(auditLogRoleARN, region)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+		if oidcConfig != nil {
+			err = awsClient.ValidateAuditLogRole(auditLogRoleARN, oidcConfig.IssuerUrl())
+			if err != nil {
+				r.Reporter.Errorf("%s", err)
+				os.Exit(1)
+			}
+		}
+	}
+
 	// Cluster privacy:
 	useExistingVPC := false
 	private := args.private
@@ -1668,28 +1956,46 @@ func run(cmd *cobra.Command, _ []string) {
 		os.Exit(1)
 	}
 
-	dMachinecidr, dPodcidr, dServicecidr, dhostPrefix, defaultComputeMachineType := r.OCMClient.
-		GetDefaultClusterFlavors(args.flavour)
-	if dMachinecidr == nil || dPodcidr == nil || dServicecidr == nil {
-		r.Reporter.Errorf("Error retrieving default cluster flavors")
+	// The default flavour and the instance types available in the availability zones don't depend
+	// on each other, so they are fetched concurrently:
+	var dMachinecidr, dPodcidr, dServicecidr *net.IPNet
+	var dhostPrefix int
+	var defaultComputeMachineType string
+	var computeMachineTypeList ocm.MachineTypeList
+	err = parallel.FirstError(parallel.DefaultLimit,
+		func() error {
+			dMachinecidr, dPodcidr, dServicecidr, dhostPrefix, defaultComputeMachineType = r.OCMClient.
+				GetDefaultClusterFlavors(args.flavour)
+			if dMachinecidr == nil || dPodcidr == nil || dServicecidr == nil {
+				return fmt.Errorf("Error retrieving default cluster flavors")
+			}
+			return nil
+		},
+		func() error {
+			var err error
+			computeMachineTypeList, err = r.OCMClient.GetAvailableMachineTypesInRegion(region, availabilityZones,
+				roleARN, awsClient)
+			return err
+		},
+	)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
 		os.Exit(1)
 	}
 	// Compute node instance type:
 	computeMachineType := args.computeMachineType
-	computeMachineTypeList, err := r.OCMClient.GetAvailableMachineTypesInRegion(region, availabilityZones, roleARN,
-		awsClient)
-	if err != nil {
-		r.Reporter.Errorf(fmt.Sprintf("%s", err))
-		os.Exit(1)
-	}
 	if computeMachineType == "" {
 		computeMachineType = defaultComputeMachineType
 	}
+	computeMachineTypeOptions := computeMachineTypeList
+	if isHostedCP {
+		computeMachineTypeOptions = computeMachineTypeList.FilterHostedCPSupported()
+	}
 	if interactive.Enabled() {
 		computeMachineType, err = interactive.GetOption(interactive.Input{
 			Question: "Compute nodes instance type",
 			Help:     cmd.Flags().Lookup("compute-machine-type").Usage,
-			Options:  computeMachineTypeList.GetAvailableIDs(multiAZ),
+			Options:  computeMachineTypeOptions.GetAvailableIDs(multiAZ),
 			Default:  computeMachineType,
 		})
 		if err != nil {
@@ -1697,6 +2003,13 @@ func run(cmd *cobra.Command, _ []string) {
 			os.Exit(1)
 		}
 	}
+	if isHostedCP {
+		err = ocm.ValidateHostedCPMachineType(computeMachineType)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+	}
 	err = computeMachineTypeList.ValidateMachineType(computeMachineType, multiAZ)
 	if err != nil {
 		r.Reporter.Errorf("Expected a valid machine type: %s", err)
@@ -1817,6 +2130,10 @@ func run(cmd *cobra.Command, _ []string) {
 			os.Exit(1)
 		}
 	}
+	nodes := computeNodes
+	if autoscaling {
+		nodes = maxReplicas
+	}
 
 	// Default machine pool labels
 	labels := args.defaultMachinePoolLabels
@@ -1841,7 +2158,10 @@ func run(cmd *cobra.Command, _ []string) {
 	}
 
 	// Validate all remaining flags:
-	expiration, err := validateExpiration()
+	expiration, err := ocm.ParseExpiration(args.expirationTime, args.expirationDuration, time.Now())
+	if err == nil && !expiration.IsZero() {
+		err = r.OCMClient.ValidateExpirationSupported()
+	}
 	if err != nil {
 		r.Reporter.Errorf(fmt.Sprintf("%s", err))
 		os.Exit(1)
@@ -1937,6 +2257,179 @@ func run(cmd *cobra.Command, _ []string) {
 		os.Exit(1)
 	}
 
+	additionalComputeSecurityGroupIds := getSecurityGroupIds(r, cmd, "compute",
+		args.additionalComputeSecurityGroupIds, isHostedCP, subnetIDs)
+	additionalInfraSecurityGroupIds := getSecurityGroupIds(r, cmd, "infra",
+		args.additionalInfraSecurityGroupIds, isHostedCP, subnetIDs)
+	additionalControlPlaneSecurityGroupIds := getSecurityGroupIds(r, cmd, "control-plane",
+		args.additionalControlPlaneSecurityGroupIds, isHostedCP, subnetIDs)
+	// The same group may be attached to several kinds of nodes, so check each one once:
+	allSecurityGroupIds := []string{}
+	for _, ids := range [][]string{additionalComputeSecurityGroupIds, additionalInfraSecurityGroupIds,
+		additionalControlPlaneSecurityGroupIds} {
+		for _, id := range ids {
+			if !helper.Contains(allSecurityGroupIds, id) {
+				allSecurityGroupIds = append(allSecurityGroupIds, id)
+			}
+		}
+	}
+	if len(allSecurityGroupIds) > 0 {
+		vpcSubnets, err := awsClient.GetVPCSubnets(subnetIDs[0])
+		if err != nil {
+			r.Reporter.Errorf("Failed to get the VPC of subnet '%s': %v", subnetIDs[0], err)
+			os.Exit(1)
+		}
+		err = awsClient.ValidateSecurityGroups(awssdk.StringValue(vpcSubnets[0].VpcId), allSecurityGroupIds)
+		if err != nil {
+			r.Reporter.Errorf("The additional security groups aren't valid:\n%s", formatAggregateErrors(err))
+			os.Exit(1)
+		}
+	}
+
+	// Clusters installed in a VPC shared by another account need to assume a role in that account
+	// to manage the records of the private hosted zone:
+	sharedVPCRoleARN := args.sharedVPCRoleARN
+	baseDomain := args.baseDomain
+	if interactive.Enabled() && isSTS && !isHostedCP && len(subnetIDs) > 0 {
+		sharedVPCRoleARN, err = interactive.GetString(interactive.Input{
+			Question: "Shared VPC role ARN",
+			Help:     cmd.Flags().Lookup("shared-vpc-role-arn").Usage,
+			Default:  sharedVPCRoleARN,
+			Validators: []interactive.Validator{
+				aws.SharedVPCRoleValidator,
+			},
+		})
+		if err != nil {
+			r.Reporter.Errorf("Expected a valid shared VPC role ARN: %s", err)
+			os.Exit(1)
+		}
+		if sharedVPCRoleARN != "" {
+			baseDomain, err = interactive.GetString(interactive.Input{
+				Question: "Base domain",
+				Help:     cmd.Flags().Lookup("base-domain").Usage,
+				Default:  baseDomain,
+				Required: true,
+				Validators: []interactive.Validator{
+					aws.BaseDomainValidator,
+				},
+			})
+			if err != nil {
+				r.Reporter.Errorf("Expected a valid base domain: %s", err)
+				os.Exit(1)
+			}
+		}
+	}
+	if interactive.Enabled() && isHostedCP {
+		baseDomain, err = interactive.GetString(interactive.Input{
+			Question: "Base domain",
+			Help:     cmd.Flags().Lookup("base-domain").Usage,
+			Default:  baseDomain,
+			Validators: []interactive.Validator{
+				aws.BaseDomainValidator,
+			},
+		})
+		if err != nil {
+			r.Reporter.Errorf("Expected a valid base domain: %s", err)
+			os.Exit(1)
+		}
+	}
+	err = aws.BaseDomainValidator(baseDomain)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+	if isHostedCP && baseDomain != "" {
+		dnsDomain, err := r.OCMClient.GetDNSDomain(baseDomain)
+		if err != nil {
+			r.Reporter.Errorf("Failed to get DNS domain '%s': %v", baseDomain, err)
+			os.Exit(1)
+		}
+		err = ocm.ValidateHostedCPBaseDomain(dnsDomain, baseDomain)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+	}
+	var privateHostedZoneID string
+	if sharedVPCRoleARN != "" {
+		err = aws.SharedVPCRoleValidator(sharedVPCRoleARN)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+		if !isSTS || isHostedCP {
+			r.Reporter.Errorf("Shared VPC is only supported for classic STS clusters")
+			os.Exit(1)
+		}
+		if len(subnetIDs) == 0 {
+			r.Reporter.Errorf("Shared VPC requires the subnets of the shared VPC, use '--subnet-ids'")
+			os.Exit(1)
+		}
+		if baseDomain == "" {
+			r.Reporter.Errorf("Shared VPC requires the base domain of the private hosted zone, use '--base-domain'")
+			os.Exit(1)
+		}
+		vpcSubnets, err := awsClient.GetVPCSubnets(subnetIDs[0])
+		if err != nil {
+			r.Reporter.Errorf("Failed to get the VPC of subnet '%s': %v", subnetIDs[0], err)
+			os.Exit(1)
+		}
+		vpcID := awssdk.StringValue(vpcSubnets[0].VpcId)
+		r.Reporter.Debugf("Validating the private hosted zone of base domain '%s' shared with VPC '%s'",
+			baseDomain, vpcID)
+		privateHostedZoneID, err = awsClient.ValidateSharedVPC(sharedVPCRoleARN, baseDomain, vpcID)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+		r.Reporter.Debugf("Using private hosted zone '%s' shared with VPC '%s'", privateHostedZoneID, vpcID)
+	}
+
+	// Check the vCPU quota of the account and validate the subnets of the existing VPC before
+	// submitting the cluster, as installation would otherwise fail much later. Both take several
+	// requests to AWS and don't depend on each other, so they run concurrently:
+	preflightChecks := []func() error{
+		func() error {
+			return validateInstanceQuota(r.AWSClient, computeMachineTypeList, computeMachineType, nodes, multiAZ,
+				isHostedCP)
+		},
+	}
+	if len(subnetIDs) > 0 {
+		subnetValidationInput := aws.SubnetValidationInput{
+			SubnetIDs:   subnetIDs,
+			MultiAZ:     multiAZ,
+			PrivateLink: privateLink,
+			HostedCP:    isHostedCP,
+			Proxy:       enableProxy,
+			SharedVPC:   sharedVPCRoleARN != "",
+			MachineCIDR: machineCIDR,
+			ServiceCIDR: serviceCIDR,
+			PodCIDR:     podCIDR,
+		}
+		if ocm.IsEmptyCIDR(subnetValidationInput.MachineCIDR) {
+			subnetValidationInput.MachineCIDR = *dMachinecidr
+		}
+		if ocm.IsEmptyCIDR(subnetValidationInput.ServiceCIDR) {
+			subnetValidationInput.ServiceCIDR = *dServicecidr
+		}
+		if ocm.IsEmptyCIDR(subnetValidationInput.PodCIDR) {
+			subnetValidationInput.PodCIDR = *dPodcidr
+		}
+		preflightChecks = append(preflightChecks, func() error {
+			r.Reporter.Debugf("Validating subnets %v", subnetIDs)
+			err := awsClient.ValidateSubnets(subnetValidationInput)
+			if err != nil {
+				return fmt.Errorf("The subnets aren't valid for the cluster:\n%s", formatAggregateErrors(err))
+			}
+			return nil
+		})
+	}
+	err = parallel.FirstError(parallel.DefaultLimit, preflightChecks...)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+
 	fips := args.fips || fedramp.Enabled()
 	if interactive.Enabled() && !fedramp.Enabled() {
 		fips, err = interactive.GetBool(interactive.Input{
@@ -2016,6 +2509,30 @@ func run(cmd *cobra.Command, _ []string) {
 		}
 	}
 
+	ec2MetadataHttpTokens := args.ec2MetadataHttpTokens
+	if interactive.Enabled() {
+		defaultHttpTokens := ec2MetadataHttpTokens
+		if defaultHttpTokens == "" {
+			defaultHttpTokens = ocm.Ec2MetadataHttpTokensOptional
+		}
+		ec2MetadataHttpTokens, err = interactive.GetOption(interactive.Input{
+			Question: "EC2 metadata HTTP tokens",
+			Help:     cmd.Flags().Lookup("ec2-metadata-http-tokens").Usage,
+			Options:  ocm.Ec2MetadataHttpTokensValues,
+			Default:  defaultHttpTokens,
+			Required: true,
+		})
+		if err != nil {
+			r.Reporter.Errorf("Expected a valid EC2 metadata HTTP tokens value: %v", err)
+			os.Exit(1)
+		}
+	}
+	err = ocm.ValidateEc2MetadataHttpTokens(ec2MetadataHttpTokens, version)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+
 	// Cluster-wide proxy configuration
 	if (subnetsProvided || (useExistingVPC && !enableProxy)) && interactive.Enabled() {
 		enableProxy, err = interactive.GetBool(interactive.Input{
@@ -2056,7 +2573,7 @@ func run(cmd *cobra.Command, _ []string) {
 			Help:     cmd.Flags().Lookup("https-proxy").Usage,
 			Default:  httpsProxy,
 			Validators: []interactive.Validator{
-				interactive.IsURL,
+				ocm.ValidateHTTPSProxy,
 			},
 		})
 		if err != nil {
@@ -2064,11 +2581,20 @@ func run(cmd *cobra.Command, _ []string) {
 			os.Exit(1)
 		}
 	}
-	err = interactive.IsURL(httpsProxy)
+	err = ocm.ValidateHTTPSProxy(httpsProxy)
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
 		os.Exit(1)
 	}
+	for _, proxy := range []string{httpProxy, httpsProxy} {
+		if proxy == "" {
+			continue
+		}
+		err = ocm.ValidateProxyReachability(proxy)
+		if err != nil {
+			r.Reporter.Warnf("%s. Make sure that it is reachable from the VPC of the cluster.", err)
+		}
+	}
 
 	if enableProxy && interactive.Enabled() {
 		noProxyInput, err := interactive.GetString(interactive.Input{
@@ -2135,10 +2661,29 @@ func run(cmd *cobra.Command, _ []string) {
 			r.Reporter.Errorf("Failed to read additional trust bundle file: %s", err)
 			os.Exit(1)
 		}
+		for _, warning := range ocm.TrustBundleWarnings(cert) {
+			r.Reporter.Warnf("%s", warning)
+		}
 		additionalTrustBundle = new(string)
 		*additionalTrustBundle = string(cert)
 	}
 
+	// Customer managed keys are only usable by the cluster if their key policies allow its roles to
+	// use them, otherwise the installation fails when creating the volumes or etcd. The policies
+	// can't be fully evaluated, as the IAM policies of the roles and the conditions of the
+	// statements also apply, so problems are only reported as warnings:
+	if isSTS {
+		keys := kmsKeyGrants(kmsKeyARN, etcdEncryptionKmsARN, isHostedCP,
+			[]string{roleARN, controlPlaneRoleARN, workerRoleARN}, operatorIAMRoleList)
+		for keyARN, grants := range keys {
+			r.Reporter.Debugf("Validating the policy of KMS key '%s'", keyARN)
+			err = awsClient.ValidateKMSKeyPolicy(keyARN, grants)
+			if err != nil {
+				r.Reporter.Warnf("The KMS key may not be usable by the cluster:\n%s", formatAggregateErrors(err))
+			}
+		}
+	}
+
 	if enableProxy && httpProxy == "" && httpsProxy == "" && additionalTrustBundleFile == "" {
 		r.Reporter.Errorf("Expected at least one of the following: http-proxy, https-proxy, additional-trust-bundle")
 		os.Exit(1)
@@ -2174,6 +2719,9 @@ func run(cmd *cobra.Command, _ []string) {
 		AvailabilityZones:         availabilityZones,
 		SubnetIds:                 subnetIDs,
 		PrivateLink:               &privateLink,
+		SharedVPCRoleARN:          sharedVPCRoleARN,
+		BaseDomain:                baseDomain,
+		PrivateHostedZoneID:       privateHostedZoneID,
 		IsSTS:                     isSTS,
 		RoleARN:                   roleARN,
 		ExternalID:                externalID,
@@ -2184,7 +2732,12 @@ func run(cmd *cobra.Command, _ []string) {
 		Mode:                      mode,
 		Tags:                      tagsList,
 		KMSKeyArn:                 kmsKeyARN,
+		RegistryConfig:            registryConfig,
+		BillingAccount:            billingAccount,
+		ExternalAuthEnabled:       externalAuthProvidersEnabled,
+		AuditLogRoleARN:           auditLogRoleARN,
 		DisableWorkloadMonitoring: &disableWorkloadMonitoring,
+		Ec2MetadataHttpTokens:     ec2MetadataHttpTokens,
 		Hypershift: ocm.Hypershift{
 			Enabled: isHostedCP,
 		},
@@ -2194,6 +2747,16 @@ func run(cmd *cobra.Command, _ []string) {
 		clusterConfig.OidcConfigId = oidcConfig.ID()
 	}
 
+	if len(additionalComputeSecurityGroupIds) > 0 {
+		clusterConfig.AdditionalComputeSecurityGroupIds = additionalComputeSecurityGroupIds
+	}
+	if len(additionalInfraSecurityGroupIds) > 0 {
+		clusterConfig.AdditionalInfraSecurityGroupIds = additionalInfraSecurityGroupIds
+	}
+	if len(additionalControlPlaneSecurityGroupIds) > 0 {
+		clusterConfig.AdditionalControlPlaneSecurityGroupIds = additionalControlPlaneSecurityGroupIds
+	}
+
 	if httpProxy != "" {
 		clusterConfig.HTTPProxy = &httpProxy
 	}
@@ -2232,6 +2795,24 @@ func run(cmd *cobra.Command, _ []string) {
 		}
 	}
 
+	if interactive.Enabled() {
+		review := &clusterReview{
+			r:                   r,
+			awsClient:           awsClient,
+			spec:                &clusterConfig,
+			machineTypes:        computeMachineTypeOptions,
+			privateSubnetsCount: privateSubnetsCount,
+			labels:              labels,
+			operatorRolesPrefix: operatorRolesPrefix,
+			derivedRolesPrefix: args.operatorRolesPrefix == "" &&
+				strings.HasPrefix(operatorRolesPrefix, clusterName+"-"),
+		}
+		reviewClusterConfig(review)
+		clusterName = clusterConfig.Name
+		labels = review.labels
+		operatorRolesPrefix = review.operatorRolesPrefix
+	}
+
 	if !output.HasFlag() || r.Reporter.IsTerminal() {
 		r.Reporter.Infof("Creating cluster '%s'", clusterName)
 		if interactive.Enabled() {
@@ -2242,6 +2823,10 @@ func run(cmd *cobra.Command, _ []string) {
 		r.Reporter.Infof("To view a list of clusters and their status, run 'rosa list clusters'")
 	}
 
+	if sharedVPCRoleARN != "" && !args.dryRun {
+		ensureSharedVPCInstallerPolicy(r, mode, roleARN, sharedVPCRoleARN)
+	}
+
 	cluster, err := r.OCMClient.CreateCluster(clusterConfig)
 	if err != nil {
 		if args.dryRun {
@@ -2249,13 +2834,26 @@ func run(cmd *cobra.Command, _ []string) {
 		} else {
 			r.Reporter.Errorf("Failed to create cluster: %s", err)
 		}
+		if isSTS {
+			reportRoleOwnerErrors(r)
+		}
 		os.Exit(1)
 	}
 
 	if args.dryRun {
-		r.Reporter.Infof(
-			"Creating cluster '%s' should succeed. Run without the '--dry-run' flag to create the cluster.",
-			clusterName)
+		if output.HasFlag() {
+			err = output.Print(cluster)
+		} else {
+			r.Reporter.Infof(
+				"Creating cluster '%s' should succeed. Run without the '--dry-run' flag to create the cluster.",
+				clusterName)
+			r.Reporter.Infof("The following request would be sent:")
+			err = printClusterRequest(cluster)
+		}
+		if err != nil {
+			r.Reporter.Errorf("Failed to print cluster request: %v", err)
+			os.Exit(1)
+		}
 		os.Exit(0)
 	}
 
@@ -2267,7 +2865,10 @@ func run(cmd *cobra.Command, _ []string) {
 				"for more information.")
 	}
 
-	clusterdescribe.Cmd.Run(clusterdescribe.Cmd, []string{cluster.ID()})
+	// With '--wait' the structured output is the final state of the cluster, printed at the end:
+	if !args.wait.Enabled || !output.HasFlag() {
+		clusterdescribe.Cmd.Run(clusterdescribe.Cmd, []string{cluster.ID()})
+	}
 
 	if isSTS {
 		if mode != "" {
@@ -2309,6 +2910,8 @@ func run(cmd *cobra.Command, _ []string) {
 
 	if args.watch {
 		installLogs.Cmd.Run(installLogs.Cmd, []string{clusterName})
+	} else if args.wait.Enabled {
+		waitForCluster(r, cluster.ID(), clusterName)
 	} else if !output.HasFlag() || r.Reporter.IsTerminal() {
 		r.Reporter.Infof(
 			"To determine when your cluster is Ready, run 'rosa describe cluster -c %s'.",
@@ -2321,6 +2924,80 @@ func run(cmd *cobra.Command, _ []string) {
 	}
 }
 
+// waitForCluster waits until the cluster is ready, prints its final state when an output format is
+// given and exits with the code that corresponds to the outcome.
+func waitForCluster(r *rosa.Runtime, clusterID string, clusterName string) {
+	if !output.HasFlag() || r.Reporter.IsTerminal() {
+		r.Reporter.Infof("Waiting up to %s for cluster '%s' to be ready", args.wait.Timeout, clusterName)
+	}
+	err := r.OCMClient.WaitForClusterState(clusterID, v1.ClusterStateReady, waitInterval, args.wait.Timeout)
+	if output.HasFlag() {
+		cluster, _, getErr := r.OCMClient.FindClusterByID(clusterID)
+		if getErr != nil {
+			r.Reporter.Errorf("Failed to get cluster '%s': %v", clusterName, getErr)
+			os.Exit(wait.ExitFailed)
+		}
+		if printErr := output.Print(cluster); printErr != nil {
+			r.Reporter.Errorf("%s", printErr)
+			os.Exit(wait.ExitFailed)
+		}
+	}
+	if err != nil {
+		r.Reporter.Errorf("Cluster '%s' isn't ready: %v", clusterName, err)
+		os.Exit(wait.ExitCode(err))
+	}
+	if !output.HasFlag() || r.Reporter.IsTerminal() {
+		r.Reporter.Infof("Cluster '%s' is ready", clusterName)
+	}
+}
+
+// printClusterRequest prints the body of the cluster creation request as indented JSON.
+func printClusterRequest(cluster *v1.Cluster) error {
+	var body bytes.Buffer
+	err := v1.MarshalCluster(cluster, &body)
+	if err != nil {
+		return err
+	}
+	var out bytes.Buffer
+	err = json.Indent(&out, body.Bytes(), "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(out.String())
+	return nil
+}
+
+// validateClusterNameAvailable checks that no cluster of the organization already has the given name.
+func validateClusterNameAvailable(r *rosa.Runtime, clusterName string) error {
+	taken, err := r.OCMClient.IsClusterNameTaken(clusterName)
+	if err != nil {
+		return fmt.Errorf("Failed to check if cluster name '%s' is available: %v", clusterName, err)
+	}
+	if taken {
+		return fmt.Errorf("A cluster named '%s' already exists, choose another name", clusterName)
+	}
+	return nil
+}
+
+// validateInstanceQuota checks that the AWS account has enough vCPU quota for the given number of
+// compute nodes and, for classic clusters, for the nodes managed by Red Hat.
+func validateInstanceQuota(awsClient aws.Client, machineTypes ocm.MachineTypeList, computeMachineType string,
+	computeNodes int, multiAZ bool, isHostedCP bool) error {
+	machineType := machineTypes.Find(computeMachineType)
+	if machineType == nil {
+		return nil
+	}
+	vCPUs := map[string]int{computeMachineType: computeNodes * machineType.CPUs()}
+	if !isHostedCP {
+		// The control plane, infra and bootstrap nodes of classic clusters run in the same account
+		// and count against the same vCPU quotas as the compute nodes:
+		for _, managedNodes := range ocm.GetClassicManagedNodes(computeNodes, multiAZ) {
+			vCPUs[managedNodes.MachineType] += managedNodes.Count * managedNodes.CPUs
+		}
+	}
+	return awsClient.ValidateInstanceQuota(vCPUs)
+}
+
 func validateOperatorRolesAvailabilityUnderUserAwsAccount(awsClient aws.Client,
 	operatorIAMRoleList []ocm.OperatorIAMRole) error {
 	for _, role := range operatorIAMRoleList {
@@ -2337,6 +3014,63 @@ func validateOperatorRolesAvailabilityUnderUserAwsAccount(awsClient aws.Client,
 	return nil
 }
 
+// getExistingOperatorRoles returns the operator roles of the list that already exist.
+func getExistingOperatorRoles(awsClient aws.Client,
+	operatorIAMRoleList []ocm.OperatorIAMRole) ([]ocm.OperatorIAMRole, error) {
+	existing := []ocm.OperatorIAMRole{}
+	for _, role := range operatorIAMRoleList {
+		name, err := aws.GetResourceIdFromARN(role.RoleARN)
+		if err != nil {
+			return nil, err
+		}
+		exists, _, err := awsClient.CheckRoleExists(name)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to check if operator role '%s' exists: %v", name, err)
+		}
+		if exists {
+			existing = append(existing, role)
+		}
+	}
+	return existing, nil
+}
+
+// verifyOidcConfig checks, before the cluster is submitted, that the issuer of the OIDC configuration
+// is reachable and that the OIDC provider and the given operator roles, that already exist, trust it.
+// The ones that don't exist yet are created after the cluster, so they aren't checked. A thumbprint
+// of the OIDC provider that doesn't match the issuer certificate is only reported as a warning.
+func verifyOidcConfig(r *rosa.Runtime, awsClient aws.Client, accountID string, issuerURL string,
+	operatorIAMRoles []ocm.OperatorIAMRole, credRequests map[string]*v1.STSOperator) error {
+	err := oidc.VerifyIssuer(issuerURL)
+	if err != nil {
+		return err
+	}
+	provider, err := awsClient.GetOpenIDConnectProvider(issuerURL, accountID)
+	if err != nil {
+		if strings.Contains(err.Error(), "AccessDenied") {
+			r.Reporter.Debugf("Failed to get OIDC provider: %s", err)
+			return nil
+		}
+		return err
+	}
+	if provider == nil {
+		return nil
+	}
+	err = oidc.VerifyProvider(provider, issuerURL)
+	if oidc.IsWarning(err) {
+		r.Reporter.Warnf("%v", err)
+	} else if err != nil {
+		return err
+	}
+	for _, role := range operatorIAMRoles {
+		err = oidc.VerifyOperatorRole(awsClient, provider,
+			oidc.NewOperatorRole(role.Namespace, role.Name, role.RoleARN, credRequests))
+		if err != nil {
+			return fmt.Errorf("Operator role '%s' doesn't trust the OIDC provider: %v", role.RoleARN, err)
+		}
+	}
+	return nil
+}
+
 func handleOidcConfigOptions(r *rosa.Runtime, cmd *cobra.Command, isSTS bool, isHostedCP bool) *v1.OidcConfig {
 	if !isSTS {
 		return nil
@@ -2510,31 +3244,6 @@ func getVersionList(r *rosa.Runtime, channelGroup string, isSTS bool, isHostedCP
 	return
 }
 
-func validateExpiration() (expiration time.Time, err error) {
-	// Validate options
-	if len(args.expirationTime) > 0 && args.expirationDuration != 0 {
-		err = errors.New("At most one of 'expiration-time' or 'expiration' may be specified")
-		return
-	}
-
-	// Parse the expiration options
-	if len(args.expirationTime) > 0 {
-		t, err := parseRFC3339(args.expirationTime)
-		if err != nil {
-			err = fmt.Errorf("Failed to parse expiration-time: %s", err)
-			return expiration, err
-		}
-
-		expiration = t
-	}
-	if args.expirationDuration != 0 {
-		// round up to the nearest second
-		expiration = time.Now().Add(args.expirationDuration).Round(time.Second)
-	}
-
-	return
-}
-
 func selectAvailabilityZonesInteractively(cmd *cobra.Command, optionsAvailabilityZones []string,
 	multiAZ bool) ([]string, error) {
 	var availabilityZones []string
@@ -2570,6 +3279,142 @@ func selectAvailabilityZonesInteractively(cmd *cobra.Command, optionsAvailabilit
 	return availabilityZones, nil
 }
 
+// getSecurityGroupIds returns the additional security groups of the given kind of nodes, asking
+// for them in interactive mode, and checks that the cluster supports them. The caller checks that
+// they belong to the VPC of the subnets.
+func getSecurityGroupIds(r *rosa.Runtime, cmd *cobra.Command, kind string, securityGroupIds []string,
+	isHostedCP bool, subnetIDs []string) []string {
+	flagName := fmt.Sprintf("additional-%s-security-group-ids", kind)
+	if interactive.Enabled() && !isHostedCP && len(subnetIDs) > 0 {
+		securityGroupsInput, err := interactive.GetString(interactive.Input{
+			Question: fmt.Sprintf("Additional %s security group IDs", strings.ReplaceAll(kind, "-", " ")),
+			Help:     cmd.Flags().Lookup(flagName).Usage,
+			Default:  strings.Join(securityGroupIds, ","),
+			Validators: []interactive.Validator{
+				aws.SecurityGroupIDsValidator,
+			},
+		})
+		if err != nil {
+			r.Reporter.Errorf("Expected valid security group IDs: %s", err)
+			os.Exit(1)
+		}
+		securityGroupIds = aws.ParseSecurityGroupIDs(securityGroupsInput)
+	}
+	if len(securityGroupIds) == 0 {
+		return nil
+	}
+	if isHostedCP {
+		r.Reporter.Errorf("Additional security groups are not supported for hosted control plane clusters")
+		os.Exit(1)
+	}
+	if len(subnetIDs) == 0 {
+		r.Reporter.Errorf("Additional security groups require the subnets of an existing VPC, use '--subnet-ids'")
+		os.Exit(1)
+	}
+	err := aws.SecurityGroupIDsValidator(securityGroupIds)
+	if err != nil {
+		r.Reporter.Errorf("Invalid '--%s': %s", flagName, err)
+		os.Exit(1)
+	}
+	return securityGroupIds
+}
+
+// kmsKeyGrants returns the roles that need to use each of the customer managed keys of the cluster,
+// indexed by key ARN. The account roles use the key of the volumes, and the installer role the key
+// of etcd. Hosted clusters also use the key through their operator roles: the volumes are created
+// by the CAPA controller, the EBS CSI driver and the kube controller manager, and etcd is encrypted
+// by the KMS provider.
+func kmsKeyGrants(kmsKeyARN string, etcdEncryptionKmsARN string, isHostedCP bool, accountRoleARNs []string,
+	operatorIAMRoles []ocm.OperatorIAMRole) map[string][]aws.KMSKeyGrant {
+	keys := map[string][]aws.KMSKeyGrant{}
+	add := func(keyARN string, roleARN string, actions []string) {
+		if keyARN == "" || roleARN == "" {
+			return
+		}
+		keys[keyARN] = append(keys[keyARN], aws.KMSKeyGrant{RoleARN: roleARN, Actions: actions})
+	}
+	volumeActions := append(append([]string{}, aws.KMSKeyUsageActions...), aws.KMSKeyGrantActions...)
+	for _, roleARN := range accountRoleARNs {
+		add(kmsKeyARN, roleARN, volumeActions)
+	}
+	if etcdEncryptionKmsARN != kmsKeyARN && len(accountRoleARNs) > 0 {
+		add(etcdEncryptionKmsARN, accountRoleARNs[0], aws.KMSKeyUsageActions)
+	}
+	if isHostedCP {
+		for _, role := range operatorIAMRoles {
+			switch role.Name {
+			case "capa-controller-manager", "ebs-cloud-credentials", "kube-controller-manager":
+				add(kmsKeyARN, role.RoleARN, volumeActions)
+			case "kms-provider":
+				add(etcdEncryptionKmsARN, role.RoleARN, aws.KMSKeyUsageActions)
+			}
+		}
+	}
+	return keys
+}
+
+// ensureSharedVPCInstallerPolicy makes sure that the installer role can assume the shared VPC role,
+// as the installer creates the records of the cluster in the private hosted zone of the shared VPC.
+func ensureSharedVPCInstallerPolicy(r *rosa.Runtime, mode string, roleARN string, sharedVPCRoleARN string) {
+	roleName, err := aws.GetResourceIdFromARN(roleARN)
+	if err != nil {
+		r.Reporter.Errorf("Failed to get the name of installer role '%s': %v", roleARN, err)
+		os.Exit(1)
+	}
+	policyName, err := aws.GetSharedVPCPolicyName(sharedVPCRoleARN)
+	if err != nil {
+		r.Reporter.Errorf("Failed to get the name of the shared VPC policy: %v", err)
+		os.Exit(1)
+	}
+	policy, err := aws.GetSharedVPCPolicy(sharedVPCRoleARN)
+	if err != nil {
+		r.Reporter.Errorf("Failed to generate the shared VPC policy: %v", err)
+		os.Exit(1)
+	}
+	if mode == aws.ModeAuto {
+		r.Reporter.Debugf("Adding policy '%s' to installer role '%s'", policyName, roleName)
+		err = r.AWSClient.PutRolePolicy(roleName, policyName, policy)
+		if err != nil {
+			r.Reporter.Errorf("Failed to add policy '%s' to installer role '%s': %v", policyName, roleName, err)
+			os.Exit(1)
+		}
+		return
+	}
+	_, err = r.AWSClient.IsRolePolicyExists(roleName, policyName)
+	if err == nil {
+		return
+	}
+	filename := aws.GetFormattedFileName("shared_vpc_policy")
+	err = helper.SaveDocument(policy, filename)
+	if err != nil {
+		r.Reporter.Errorf("Failed to save the shared VPC policy: %v", err)
+		os.Exit(1)
+	}
+	command := awscb.NewIAMCommandBuilder().
+		SetCommand(awscb.PutRolePolicy).
+		AddParam(awscb.RoleName, roleName).
+		AddParam(awscb.PolicyName, policyName).
+		AddParam(awscb.PolicyDocument, fmt.Sprintf("file://%s", filename)).
+		Build()
+	r.Reporter.Errorf("Installer role '%s' can't assume shared VPC role '%s'. Run the following command "+
+		"before creating the cluster, or use '--mode auto':\n\n\t%s\n", roleName, sharedVPCRoleARN, command)
+	os.Exit(1)
+}
+
+// formatAggregateErrors lists each of the problems of an aggregate error, like the ones found in
+// the subnets, in its own line.
+func formatAggregateErrors(err error) string {
+	aggregate, ok := err.(utilerrors.Aggregate)
+	if !ok {
+		return err.Error()
+	}
+	lines := []string{}
+	for _, e := range aggregate.Errors() {
+		lines = append(lines, fmt.Sprintf("  - %s", e))
+	}
+	return strings.Join(lines, "\n")
+}
+
 func validateAvailabilityZones(multiAZ bool, availabilityZones []string, awsClient aws.Client) error {
 	err := ocm.ValidateAvailabilityZonesCount(multiAZ, len(availabilityZones))
 	if err != nil {
@@ -2590,12 +3435,17 @@ func validateAvailabilityZones(multiAZ bool, availabilityZones []string, awsClie
 	return nil
 }
 
-// parseRFC3339 parses an RFC3339 date in either RFC3339Nano or RFC3339 format.
-func parseRFC3339(s string) (time.Time, error) {
-	if t, timeErr := time.Parse(time.RFC3339Nano, s); timeErr == nil {
-		return t, nil
+// reportRoleOwnerErrors explains why cluster creation failed when the ocm-role or user-role of the AWS
+// account belong to another OCM organization or Red Hat account.
+func reportRoleOwnerErrors(r *rosa.Runtime) {
+	ownerErrs, err := r.OCMClient.FindRoleOwnerErrors(r.AWSClient, r.Creator.AccountID)
+	if err != nil {
+		r.Reporter.Debugf("Failed to check the owners of the ocm-role and user-role: %v", err)
+		return
+	}
+	for _, ownerErr := range ownerErrs {
+		r.Reporter.Errorf("%s", ownerErr)
 	}
-	return time.Parse(time.RFC3339, s)
 }
 
 func buildCommand(spec ocm.Spec, operatorRolesPrefix string,
@@ -2702,6 +3552,24 @@ func buildCommand(spec ocm.Spec, operatorRolesPrefix string,
 	if len(spec.SubnetIds) > 0 {
 		command += fmt.Sprintf(" --subnet-ids %s", strings.Join(spec.SubnetIds, ","))
 	}
+	if len(spec.AdditionalComputeSecurityGroupIds) > 0 {
+		command += fmt.Sprintf(" --additional-compute-security-group-ids %s",
+			strings.Join(spec.AdditionalComputeSecurityGroupIds, ","))
+	}
+	if len(spec.AdditionalInfraSecurityGroupIds) > 0 {
+		command += fmt.Sprintf(" --additional-infra-security-group-ids %s",
+			strings.Join(spec.AdditionalInfraSecurityGroupIds, ","))
+	}
+	if len(spec.AdditionalControlPlaneSecurityGroupIds) > 0 {
+		command += fmt.Sprintf(" --additional-control-plane-security-group-ids %s",
+			strings.Join(spec.AdditionalControlPlaneSecurityGroupIds, ","))
+	}
+	if spec.SharedVPCRoleARN != "" {
+		command += fmt.Sprintf(" --shared-vpc-role-arn %s", spec.SharedVPCRoleARN)
+	}
+	if spec.BaseDomain != "" {
+		command += fmt.Sprintf(" --base-domain %s", spec.BaseDomain)
+	}
 	if spec.FIPS {
 		command += " --fips"
 	} else if spec.EtcdEncryption {
@@ -2728,6 +3596,33 @@ func buildCommand(spec ocm.Spec, operatorRolesPrefix string,
 	if spec.DisableWorkloadMonitoring != nil && *spec.DisableWorkloadMonitoring {
 		command += " --disable-workload-monitoring"
 	}
+	if spec.Ec2MetadataHttpTokens != "" {
+		command += fmt.Sprintf(" --ec2-metadata-http-tokens %s", spec.Ec2MetadataHttpTokens)
+	}
+	if spec.RegistryConfig != nil && spec.RegistryConfig.RegistrySources != nil {
+		sources := spec.RegistryConfig.RegistrySources
+		if len(sources.AllowedRegistries) > 0 {
+			command += fmt.Sprintf(" --registry-config-allowed-registries %s",
+				strings.Join(sources.AllowedRegistries, ","))
+		}
+		if len(sources.BlockedRegistries) > 0 {
+			command += fmt.Sprintf(" --registry-config-blocked-registries %s",
+				strings.Join(sources.BlockedRegistries, ","))
+		}
+		if len(sources.InsecureRegistries) > 0 {
+			command += fmt.Sprintf(" --registry-config-insecure-registries %s",
+				strings.Join(sources.InsecureRegistries, ","))
+		}
+	}
+	if spec.BillingAccount != "" {
+		command += fmt.Sprintf(" --billing-account %s", spec.BillingAccount)
+	}
+	if spec.ExternalAuthEnabled {
+		command += " --external-auth-providers-enabled"
+	}
+	if spec.AuditLogRoleARN != "" {
+		command += fmt.Sprintf(" --audit-log-arn %s", spec.AuditLogRoleARN)
+	}
 	if userSelectedAvailabilityZones {
 		command += fmt.Sprintf(" --availability-zones %s", strings.Join(spec.AvailabilityZones, ","))
 	}
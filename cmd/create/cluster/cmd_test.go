@@ -5,6 +5,7 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/openshift/rosa/pkg/aws"
 	"github.com/openshift/rosa/pkg/logging"
 	"github.com/openshift/rosa/pkg/ocm"
 )
@@ -80,3 +81,39 @@ var _ = Describe("Validates OCP version", func() {
 		})
 	})
 })
+
+var _ = Describe("KMS key grants", func() {
+	const (
+		kmsKeyARN    = "arn:aws:kms:us-east-1:123456789012:key/volumes"
+		etcdKeyARN   = "arn:aws:kms:us-east-1:123456789012:key/etcd"
+		installerARN = "arn:aws:iam::123456789012:role/Installer-Role"
+		workerARN    = "arn:aws:iam::123456789012:role/Worker-Role"
+	)
+	operatorRoles := []ocm.OperatorIAMRole{
+		{Name: "kube-controller-manager", RoleARN: "arn:aws:iam::123456789012:role/kcm"},
+		{Name: "kms-provider", RoleARN: "arn:aws:iam::123456789012:role/kms"},
+		{Name: "image-registry", RoleARN: "arn:aws:iam::123456789012:role/registry"},
+	}
+	roleARNs := func(grants []aws.KMSKeyGrant) []string {
+		result := []string{}
+		for _, grant := range grants {
+			result = append(result, grant.RoleARN)
+		}
+		return result
+	}
+
+	It("uses the account roles for classic clusters", func() {
+		keys := kmsKeyGrants(kmsKeyARN, etcdKeyARN, false, []string{installerARN, "", workerARN}, operatorRoles)
+		Expect(keys).To(HaveLen(2))
+		Expect(roleARNs(keys[kmsKeyARN])).To(Equal([]string{installerARN, workerARN}))
+		Expect(roleARNs(keys[etcdKeyARN])).To(Equal([]string{installerARN}))
+	})
+
+	It("adds the operator roles of hosted clusters", func() {
+		keys := kmsKeyGrants(kmsKeyARN, etcdKeyARN, true, []string{installerARN, "", workerARN}, operatorRoles)
+		Expect(roleARNs(keys[kmsKeyARN])).To(Equal([]string{installerARN, workerARN,
+			"arn:aws:iam::123456789012:role/kcm"}))
+		Expect(roleARNs(keys[etcdKeyARN])).To(Equal([]string{installerARN,
+			"arn:aws:iam::123456789012:role/kms"}))
+	})
+})
@@ -0,0 +1,355 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/openshift/rosa/pkg/aws"
+	mpHelpers "github.com/openshift/rosa/pkg/helper/machinepools"
+	"github.com/openshift/rosa/pkg/interactive"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+const (
+	reviewCreate = "Create cluster"
+	reviewCancel = "Cancel"
+
+	reviewEditName         = "Cluster name"
+	reviewEditMachineType  = "Compute node instance type"
+	reviewEditComputeNodes = "Compute nodes"
+	reviewEditMinReplicas  = "Min replicas"
+	reviewEditMaxReplicas  = "Max replicas"
+	reviewEditLabels       = "Default machine pool labels"
+	reviewEditMonitoring   = "Disable workload monitoring"
+)
+
+// clusterReview holds the state needed to re-prompt individual answers from the
+// review screen shown at the end of interactive cluster creation.
+type clusterReview struct {
+	r                   *rosa.Runtime
+	awsClient           aws.Client
+	spec                *ocm.Spec
+	machineTypes        ocm.MachineTypeList
+	privateSubnetsCount int
+	labels              string
+
+	// operatorRolesPrefix is renamed with the cluster when derivedRolesPrefix is true, that is when
+	// the user didn't choose it.
+	operatorRolesPrefix string
+	derivedRolesPrefix  bool
+}
+
+// reviewClusterConfig prints a summary of the cluster that is about to be created and
+// lets the user edit individual answers before submitting. The checks that depend on the
+// edited answers are run again before the cluster can be created.
+func reviewClusterConfig(review *clusterReview) {
+	r := review.r
+	for {
+		printReviewSummary(review.spec)
+		options := append([]string{reviewCreate}, review.editableFields()...)
+		options = append(options, reviewCancel)
+		choice, err := interactive.GetOption(interactive.Input{
+			Question: "Review the cluster configuration",
+			Help:     "Select a value to edit it, or create the cluster with the values above.",
+			Options:  options,
+			Default:  reviewCreate,
+			Required: true,
+		})
+		if err != nil {
+			r.Reporter.Errorf("Expected a valid option: %s", err)
+			os.Exit(1)
+		}
+		switch choice {
+		case reviewCreate:
+			err = review.validate()
+			if err == nil {
+				return
+			}
+		case reviewCancel:
+			r.Reporter.Infof("Cluster creation cancelled")
+			os.Exit(0)
+		default:
+			err = review.edit(choice)
+			if err == nil {
+				err = review.validate()
+			}
+		}
+		// Invalid answers can be fixed by editing them again:
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+		}
+	}
+}
+
+// validate runs again the checks of the answers that can be edited on the review screen.
+func (c *clusterReview) validate() error {
+	spec := c.spec
+	if spec.Hypershift.Enabled {
+		err := ocm.ValidateHostedCPMachineType(spec.ComputeMachineType)
+		if err != nil {
+			return err
+		}
+	}
+	// The machine types are those available in the availability zones of the cluster, with their
+	// quota, which matters for accelerated computing instance types:
+	err := c.machineTypes.ValidateMachineType(spec.ComputeMachineType, spec.MultiAZ)
+	if err != nil {
+		return fmt.Errorf("Expected a valid machine type: %s", err)
+	}
+	nodes := spec.ComputeNodes
+	if spec.Autoscaling {
+		nodes = spec.MaxReplicas
+	}
+	return validateInstanceQuota(c.r.AWSClient, c.machineTypes, spec.ComputeMachineType, nodes, spec.MultiAZ,
+		spec.Hypershift.Enabled)
+}
+
+// rename changes the name of the cluster and, if it was derived from it, the prefix of the
+// operator roles.
+func (c *clusterReview) rename(name string) error {
+	spec := c.spec
+	if name == spec.Name {
+		return nil
+	}
+	err := validateClusterNameAvailable(c.r, name)
+	if err != nil {
+		return err
+	}
+	if c.derivedRolesPrefix && len(spec.OperatorIAMRoles) > 0 {
+		prefix := getRolePrefix(name)
+		roles := renameOperatorRoles(spec.OperatorIAMRoles, prefix)
+		err = validateOperatorRolesAvailabilityUnderUserAwsAccount(c.awsClient, roles)
+		if err != nil {
+			return err
+		}
+		c.operatorRolesPrefix = prefix
+		spec.OperatorIAMRoles = roles
+	}
+	spec.Name = name
+	return nil
+}
+
+// renameOperatorRoles returns the given operator roles with their names computed from the new
+// prefix, as done by aws.ComputeOperatorRoleArn, keeping their path.
+func renameOperatorRoles(roles []ocm.OperatorIAMRole, prefix string) []ocm.OperatorIAMRole {
+	renamed := make([]ocm.OperatorIAMRole, len(roles))
+	for i, role := range roles {
+		name := fmt.Sprintf("%s-%s-%s", prefix, role.Namespace, role.Name)
+		if len(name) > 64 {
+			name = name[0:64]
+		}
+		renamed[i] = role
+		renamed[i].RoleARN = role.RoleARN[:strings.LastIndex(role.RoleARN, "/")+1] + name
+	}
+	return renamed
+}
+
+func (c *clusterReview) editableFields() []string {
+	fields := []string{reviewEditName, reviewEditMachineType}
+	if c.spec.Autoscaling {
+		fields = append(fields, reviewEditMinReplicas, reviewEditMaxReplicas)
+	} else {
+		fields = append(fields, reviewEditComputeNodes)
+	}
+	if !c.spec.Hypershift.Enabled {
+		fields = append(fields, reviewEditLabels, reviewEditMonitoring)
+	}
+	return fields
+}
+
+func (c *clusterReview) edit(field string) error {
+	spec := c.spec
+	isHostedCP := spec.Hypershift.Enabled
+	switch field {
+	case reviewEditName:
+		name, err := interactive.GetString(interactive.Input{
+			Question: "Cluster name",
+			Default:  spec.Name,
+			Required: true,
+			Validators: []interactive.Validator{
+				ocm.ClusterNameValidator,
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("Expected a valid cluster name: %s", err)
+		}
+		return c.rename(strings.Trim(name, " \t"))
+	case reviewEditMachineType:
+		machineType, err := interactive.GetOption(interactive.Input{
+			Question: "Compute nodes instance type",
+			Options:  c.machineTypes.GetAvailableIDs(spec.MultiAZ),
+			Default:  spec.ComputeMachineType,
+			Required: true,
+		})
+		if err != nil {
+			return fmt.Errorf("Expected a valid machine type: %s", err)
+		}
+		err = c.machineTypes.ValidateMachineType(machineType, spec.MultiAZ)
+		if err != nil {
+			return err
+		}
+		spec.ComputeMachineType = machineType
+	case reviewEditComputeNodes:
+		computeNodes, err := interactive.GetInt(interactive.Input{
+			Question: "Compute nodes",
+			Default:  spec.ComputeNodes,
+			Required: true,
+			Validators: []interactive.Validator{
+				minReplicaValidator(spec.MultiAZ, isHostedCP, c.privateSubnetsCount),
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("Expected a valid number of compute nodes: %s", err)
+		}
+		spec.ComputeNodes = computeNodes
+	case reviewEditMinReplicas:
+		minReplicas, err := interactive.GetInt(interactive.Input{
+			Question: "Min replicas",
+			Default:  spec.MinReplicas,
+			Required: true,
+			Validators: []interactive.Validator{
+				minReplicaValidator(spec.MultiAZ, isHostedCP, c.privateSubnetsCount),
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("Expected a valid number of min replicas: %s", err)
+		}
+		spec.MinReplicas = minReplicas
+		if spec.MaxReplicas < minReplicas {
+			spec.MaxReplicas = minReplicas
+		}
+	case reviewEditMaxReplicas:
+		maxReplicas, err := interactive.GetInt(interactive.Input{
+			Question: "Max replicas",
+			Default:  spec.MaxReplicas,
+			Required: true,
+			Validators: []interactive.Validator{
+				maxReplicaValidator(spec.MultiAZ, spec.MinReplicas, isHostedCP, c.privateSubnetsCount),
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("Expected a valid number of max replicas: %s", err)
+		}
+		spec.MaxReplicas = maxReplicas
+	case reviewEditLabels:
+		labels, err := interactive.GetString(interactive.Input{
+			Question: "Default machine pool labels",
+			Default:  c.labels,
+			Validators: []interactive.Validator{
+				mpHelpers.LabelValidator,
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("Expected a valid comma-separated list of attributes: %s", err)
+		}
+		labelMap, err := mpHelpers.ParseLabels(labels)
+		if err != nil {
+			return err
+		}
+		c.labels = labels
+		spec.ComputeLabels = labelMap
+	case reviewEditMonitoring:
+		disable := spec.DisableWorkloadMonitoring != nil && *spec.DisableWorkloadMonitoring
+		disable, err := interactive.GetBool(interactive.Input{
+			Question: "Disable Workload monitoring",
+			Default:  disable,
+		})
+		if err != nil {
+			return fmt.Errorf("Expected a valid disable-workload-monitoring value: %v", err)
+		}
+		spec.DisableWorkloadMonitoring = &disable
+	}
+	return nil
+}
+
+func printReviewSummary(spec *ocm.Spec) {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "\nSETTING\tVALUE\n")
+	for _, row := range reviewSummary(spec) {
+		fmt.Fprintf(writer, "%s\t%s\n", row[0], row[1])
+	}
+	fmt.Fprintln(writer)
+	writer.Flush()
+}
+
+// reviewSummary returns the name and value of the settings shown on the review screen.
+func reviewSummary(spec *ocm.Spec) [][2]string {
+	rows := [][2]string{
+		{reviewEditName, spec.Name},
+		{"Region", spec.Region},
+		{"Version", ocm.GetRawVersionId(spec.Version)},
+		{"Channel group", spec.ChannelGroup},
+		{"STS", fmt.Sprintf("%t", spec.IsSTS)},
+		{"Hosted control plane", fmt.Sprintf("%t", spec.Hypershift.Enabled)},
+		{"Multi-AZ", fmt.Sprintf("%t", spec.MultiAZ)},
+		{"Private", fmt.Sprintf("%t", spec.Private != nil && *spec.Private)},
+		{reviewEditMachineType, spec.ComputeMachineType},
+	}
+	if spec.Autoscaling {
+		rows = append(rows,
+			[2]string{reviewEditMinReplicas, fmt.Sprintf("%d", spec.MinReplicas)},
+			[2]string{reviewEditMaxReplicas, fmt.Sprintf("%d", spec.MaxReplicas)},
+		)
+	} else {
+		rows = append(rows, [2]string{reviewEditComputeNodes, fmt.Sprintf("%d", spec.ComputeNodes)})
+	}
+	if !spec.Hypershift.Enabled {
+		rows = append(rows, [2]string{reviewEditLabels, formatLabels(spec.ComputeLabels)})
+	}
+	if len(spec.SubnetIds) > 0 {
+		rows = append(rows, [2]string{"Subnets", strings.Join(spec.SubnetIds, ", ")})
+	}
+	rows = append(rows,
+		[2]string{"Machine CIDR", formatCIDR(spec.MachineCIDR)},
+		[2]string{"Service CIDR", formatCIDR(spec.ServiceCIDR)},
+		[2]string{"Pod CIDR", formatCIDR(spec.PodCIDR)},
+	)
+	if spec.HostPrefix != 0 {
+		rows = append(rows, [2]string{"Host prefix", fmt.Sprintf("%d", spec.HostPrefix)})
+	}
+	if !spec.Hypershift.Enabled {
+		rows = append(rows, [2]string{reviewEditMonitoring,
+			fmt.Sprintf("%t", spec.DisableWorkloadMonitoring != nil && *spec.DisableWorkloadMonitoring)})
+	}
+	return rows
+}
+
+func formatLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return "-"
+	}
+	pairs := make([]string, 0, len(labels))
+	for key, value := range labels {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", key, value))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+func formatCIDR(cidr net.IPNet) string {
+	if ocm.IsEmptyCIDR(cidr) {
+		return "default"
+	}
+	return cidr.String()
+}
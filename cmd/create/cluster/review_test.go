@@ -0,0 +1,81 @@
+package cluster
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/rosa/pkg/ocm"
+)
+
+var _ = Describe("Review cluster configuration", func() {
+	It("Shows compute nodes and labels for a classic cluster", func() {
+		private := false
+		rows := reviewSummary(&ocm.Spec{
+			Name:               "cluster",
+			Region:             "us-east-1",
+			Version:            "openshift-v4.13.0",
+			ComputeMachineType: "m5.xlarge",
+			ComputeNodes:       3,
+			ComputeLabels:      map[string]string{"b": "2", "a": "1"},
+			Private:            &private,
+		})
+		Expect(rows).To(ContainElement([2]string{reviewEditName, "cluster"}))
+		Expect(rows).To(ContainElement([2]string{"Version", "4.13.0"}))
+		Expect(rows).To(ContainElement([2]string{reviewEditComputeNodes, "3"}))
+		Expect(rows).To(ContainElement([2]string{reviewEditLabels, "a=1,b=2"}))
+		Expect(rows).To(ContainElement([2]string{"Machine CIDR", "default"}))
+	})
+	It("Shows replica bounds and hides labels for an autoscaling hosted cluster", func() {
+		rows := reviewSummary(&ocm.Spec{
+			Name:        "cluster",
+			Autoscaling: true,
+			MinReplicas: 2,
+			MaxReplicas: 4,
+			Hypershift:  ocm.Hypershift{Enabled: true},
+		})
+		Expect(rows).To(ContainElement([2]string{reviewEditMinReplicas, "2"}))
+		Expect(rows).To(ContainElement([2]string{reviewEditMaxReplicas, "4"}))
+		for _, row := range rows {
+			Expect(row[0]).NotTo(Equal(reviewEditComputeNodes))
+			Expect(row[0]).NotTo(Equal(reviewEditLabels))
+		}
+	})
+	It("Only offers to edit fields that apply to the cluster", func() {
+		review := &clusterReview{spec: &ocm.Spec{Hypershift: ocm.Hypershift{Enabled: true}}}
+		Expect(review.editableFields()).To(Equal([]string{
+			reviewEditName, reviewEditMachineType, reviewEditComputeNodes,
+		}))
+	})
+	It("Renames the operator roles with a new prefix, keeping their path", func() {
+		roles := renameOperatorRoles([]ocm.OperatorIAMRole{
+			{
+				Name:      "ebs-cloud-credentials",
+				Namespace: "openshift-cluster-csi-drivers",
+				RoleARN: "arn:aws:iam::123456789012:role/rosa/" +
+					"old-abcd-openshift-cluster-csi-drivers-ebs-cloud-credentials",
+			},
+			{
+				Name:      "installer-cloud-credentials",
+				Namespace: "openshift-image-registry",
+				RoleARN: "arn:aws:iam::123456789012:role/" +
+					"old-abcd-openshift-image-registry-installer-cloud-credentials",
+			},
+		}, "new-wxyz")
+		Expect(roles[0].RoleARN).To(Equal("arn:aws:iam::123456789012:role/rosa/" +
+			"new-wxyz-openshift-cluster-csi-drivers-ebs-cloud-credentials"))
+		Expect(roles[1].RoleARN).To(Equal("arn:aws:iam::123456789012:role/" +
+			"new-wxyz-openshift-image-registry-installer-cloud-credentials"))
+		Expect(roles[1].Name).To(Equal("installer-cloud-credentials"))
+	})
+	It("Truncates the renamed operator roles to the maximum role name length", func() {
+		roles := renameOperatorRoles([]ocm.OperatorIAMRole{
+			{
+				Name:      "cloud-network-config-controller-credentials",
+				Namespace: "openshift-cloud-network-config-controller",
+				RoleARN:   "arn:aws:iam::123456789012:role/old-role",
+			},
+		}, "new-wxyz")
+		Expect(roles[0].RoleARN).To(Equal("arn:aws:iam::123456789012:role/" +
+			"new-wxyz-openshift-cloud-network-config-controller-cloud-network"))
+	})
+})
@@ -21,15 +21,22 @@ import (
 
 	"github.com/openshift/rosa/cmd/create/accountroles"
 	"github.com/openshift/rosa/cmd/create/admin"
+	"github.com/openshift/rosa/cmd/create/autoscaler"
+	"github.com/openshift/rosa/cmd/create/breakglasscredential"
 	"github.com/openshift/rosa/cmd/create/cluster"
+	"github.com/openshift/rosa/cmd/create/dnsdomain"
+	"github.com/openshift/rosa/cmd/create/externalauthprovider"
 	"github.com/openshift/rosa/cmd/create/idp"
 	"github.com/openshift/rosa/cmd/create/ingress"
+	"github.com/openshift/rosa/cmd/create/kubeletconfig"
 	"github.com/openshift/rosa/cmd/create/machinepool"
+	"github.com/openshift/rosa/cmd/create/network"
 	"github.com/openshift/rosa/cmd/create/ocmrole"
 	"github.com/openshift/rosa/cmd/create/oidcconfig"
 	"github.com/openshift/rosa/cmd/create/oidcprovider"
 	"github.com/openshift/rosa/cmd/create/operatorroles"
 	"github.com/openshift/rosa/cmd/create/service"
+	"github.com/openshift/rosa/cmd/create/tuningconfig"
 	"github.com/openshift/rosa/cmd/create/userrole"
 
 	"github.com/openshift/rosa/pkg/arguments"
@@ -44,12 +51,19 @@ var Cmd = &cobra.Command{
 }
 
 func init() {
+	Cmd.AddCommand(autoscaler.Cmd)
 	Cmd.AddCommand(accountroles.Cmd)
 	Cmd.AddCommand(admin.Cmd)
+	Cmd.AddCommand(breakglasscredential.Cmd)
 	Cmd.AddCommand(cluster.Cmd)
+	Cmd.AddCommand(dnsdomain.Cmd)
+	Cmd.AddCommand(externalauthprovider.Cmd)
 	Cmd.AddCommand(idp.Cmd)
 	Cmd.AddCommand(ingress.Cmd)
+	Cmd.AddCommand(kubeletconfig.Cmd)
+	Cmd.AddCommand(tuningconfig.Cmd)
 	Cmd.AddCommand(machinepool.Cmd)
+	Cmd.AddCommand(network.Cmd)
 	Cmd.AddCommand(oidcconfig.Cmd)
 	Cmd.AddCommand(oidcprovider.Cmd)
 	Cmd.AddCommand(operatorroles.Cmd)
@@ -65,7 +79,7 @@ func init() {
 	globallyAvailableCommands := []*cobra.Command{
 		accountroles.Cmd, operatorroles.Cmd,
 		userrole.Cmd, ocmrole.Cmd,
-		oidcprovider.Cmd,
+		oidcprovider.Cmd, dnsdomain.Cmd,
 	}
 	arguments.MarkRegionHidden(Cmd, globallyAvailableCommands)
 }
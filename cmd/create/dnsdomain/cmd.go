@@ -0,0 +1,65 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dnsdomain
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var Cmd = &cobra.Command{
+	Use:     "dns-domain",
+	Aliases: []string{"dnsdomain"},
+	Short:   "Reserve a DNS domain",
+	Long: "Reserve a base DNS domain for the organization, that can then be given to a Hosted Control " +
+		"Plane cluster with the '--base-domain' option instead of using a random domain.",
+	Example: `  # Reserve a DNS domain and use it for a new cluster
+  rosa create dns-domain
+  rosa create cluster --cluster-name=mycluster --hosted-cp --base-domain=<domain>`,
+	Run:  run,
+	Args: cobra.NoArgs,
+}
+
+func init() {
+	output.AddFlag(Cmd)
+}
+
+func run(_ *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithOCM()
+	defer r.Cleanup()
+
+	domain, err := r.OCMClient.CreateDNSDomain()
+	if err != nil {
+		r.Reporter.Errorf("Failed to create DNS domain: %v", err)
+		os.Exit(1)
+	}
+
+	if output.HasFlag() {
+		err = output.Print(domain)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+		return
+	}
+	r.Reporter.Infof("DNS domain '%s' has been created.", domain.ID)
+	r.Reporter.Infof("To use it, run 'rosa create cluster --hosted-cp --base-domain %s'.", domain.ID)
+}
@@ -0,0 +1,162 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalauthprovider
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/externalauthprovider"
+	"github.com/openshift/rosa/pkg/helper/oidc"
+	"github.com/openshift/rosa/pkg/interactive"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var Cmd = &cobra.Command{
+	Use:     "external-auth-provider",
+	Aliases: []string{"externalauthprovider", "external-auth-providers"},
+	Short:   "Create an external authentication provider for a cluster",
+	Long: "Add an external OIDC provider that authenticates the users of a Hosted Control Plane " +
+		"cluster created with '--external-auth-providers-enabled'. The discovery document of the " +
+		"issuer is checked before the provider is created.",
+	Example: `  # Add an external authentication provider to cluster 'mycluster'
+  rosa create external-auth-provider -c mycluster --name myprovider \
+  --issuer-url https://login.example.com --issuer-audiences audience1,audience2 \
+  --claim-mapping-username-claim email --claim-mapping-groups-claim groups \
+  --console-client-id console --console-client-secret <secret>
+
+  # Add an external authentication provider following interactive prompts
+  rosa create external-auth-provider -c mycluster --interactive`,
+	Run:  run,
+	Args: cobra.NoArgs,
+}
+
+var name string
+
+var args *externalauthprovider.Args
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
+	ocm.AddClusterFlag(Cmd)
+	flags.StringVar(
+		&name,
+		"name",
+		"",
+		"Name of the external authentication provider.",
+	)
+	args = externalauthprovider.AddFlags(flags)
+	interactive.AddFlag(flags)
+	output.AddFlag(Cmd)
+}
+
+func run(cmd *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS().WithOCM()
+	defer r.Cleanup()
+
+	clusterKey := r.GetClusterKey()
+
+	cluster := r.FetchCluster()
+	err := r.OCMClient.ValidateExternalAuthCluster(cluster)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+
+	flags := cmd.Flags()
+	if !flags.Changed("name") && !externalauthprovider.AnyChanged(flags) {
+		interactive.Enable()
+	}
+	if interactive.Enabled() && !flags.Changed("name") {
+		name, err = interactive.GetString(interactive.Input{
+			Question: "Name",
+			Help:     flags.Lookup("name").Usage,
+			Required: true,
+			Validators: []interactive.Validator{
+				func(val interface{}) error {
+					return externalauthprovider.ValidateName(val.(string))
+				},
+			},
+		})
+		if err != nil {
+			r.Reporter.Errorf("Expected a valid name: %s", err)
+			os.Exit(1)
+		}
+	}
+	err = externalauthprovider.ValidateName(name)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+	if interactive.Enabled() {
+		err = args.Prompt(flags)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+	}
+	err = args.Validate(true)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+
+	existing, err := r.OCMClient.GetExternalAuth(cluster.ID(), name)
+	if err != nil {
+		r.Reporter.Errorf("Failed to get external authentication providers for cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+	if existing != nil {
+		r.Reporter.Errorf("External authentication provider '%s' already exists for cluster '%s'", name, clusterKey)
+		os.Exit(1)
+	}
+
+	externalAuth, err := args.Build(name, true)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+	r.Reporter.Debugf("Checking the discovery document of issuer '%s'", args.IssuerURL)
+	err = oidc.VerifyIssuerWithCA(args.IssuerURL, []byte(externalAuth.Issuer.CA))
+	if err != nil {
+		r.Reporter.Errorf("Issuer '%s' isn't a valid OIDC issuer: %v", args.IssuerURL, err)
+		os.Exit(1)
+	}
+
+	r.Reporter.Debugf("Creating external authentication provider '%s' for cluster '%s'", name, clusterKey)
+	externalAuth, err = r.OCMClient.CreateExternalAuth(cluster.ID(), externalAuth)
+	if err != nil {
+		r.Reporter.Errorf("Failed to create external authentication provider '%s' for cluster '%s': %v",
+			name, clusterKey, err)
+		os.Exit(1)
+	}
+
+	if output.HasFlag() {
+		err = output.Print(externalAuth)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+		return
+	}
+	r.Reporter.Infof("Successfully created external authentication provider '%s' for cluster '%s'",
+		name, clusterKey)
+}
@@ -18,17 +18,22 @@ package idp
 
 import (
 	"fmt"
+	"net/http"
 	"os"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/spf13/cobra"
 
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
+	"github.com/openshift/rosa/pkg/wait"
 )
 
 const HTPasswdIDPName = "htpasswd"
@@ -41,10 +46,11 @@ var args struct {
 	idpType string
 	idpName string
 
-	clientID      string
-	clientSecret  string
-	mappingMethod string
-	caPath        string
+	clientID       string
+	clientSecret   string
+	mappingMethod  string
+	caPath         string
+	testConnection bool
 
 	// GitHub
 	githubHostname      string
@@ -74,10 +80,14 @@ var args struct {
 	openidUsername  string
 	openidGroups    string
 	openidScopes    string
+	openidParams    string
 
 	// HTPasswd
 	htpasswdUsername string
 	htpasswdPassword string
+	htpasswdUsers    []string
+	htpasswdFile     string
+	wait             *wait.Options
 }
 
 var validIdps = []string{"github", "gitlab", "google", "htpasswd", "ldap", "openid"}
@@ -88,10 +98,17 @@ var idRE = regexp.MustCompile(`(?i)^[0-9a-z]+([-_][0-9a-z]+)*$`)
 var Cmd = &cobra.Command{
 	Use:   "idp",
 	Short: "Add IDP for cluster",
-	Long:  "Add an Identity providers to determine how users log into the cluster.",
+	Long: "Add an Identity providers to determine how users log into the cluster.\n\n" +
+		wait.ExitCodesHelp,
 	Example: `  # Add a GitHub identity provider to a cluster named "mycluster"
   rosa create idp --type=github --cluster=mycluster
 
+  # Add an HTPasswd identity provider with the users of an htpasswd file
+  rosa create idp --type=htpasswd --cluster=mycluster --from-file=users.htpasswd
+
+  # Add a GitHub identity provider and wait until the OAuth server of the cluster serves it
+  rosa create idp --type=github --cluster=mycluster --wait --timeout=15m -o json
+
   # Add an identity provider following interactive prompts
   rosa create idp --cluster=mycluster --interactive`,
 	Run: run,
@@ -140,12 +157,20 @@ func init() {
 		"",
 		"Client Secret from the registered application.",
 	)
+	flags.StringVar(
+		&args.caPath,
+		"ca-file",
+		"",
+		"Path to PEM-encoded certificate bundle to use when making requests to the server, "+
+			"required for self-hosted identity providers with certificates signed by a private CA.\n",
+	)
 	flags.StringVar(
 		&args.caPath,
 		"ca",
 		"",
 		"Path to PEM-encoded certificate file to use when making requests to the server.\n",
 	)
+	flags.MarkDeprecated("ca", "use '--ca-file' instead")
 
 	// GitHub
 	flags.StringVar(
@@ -209,6 +234,16 @@ func init() {
 		"",
 		"LDAP: Password to bind with during the search phase.",
 	)
+	flags.BoolVar(
+		&args.testConnection,
+		"test-connection",
+		false,
+		"LDAP: Bind to the LDAP server from this host with the given URL, bind DN and CA before "+
+			"creating the identity provider.\n"+
+			"GitHub: Check with the GitHub API that the organizations or teams exist and that the callback "+
+			"URL of the OAuth application matches the cluster before creating the identity provider. "+
+			"Teams are only checked when a token is set in the GITHUB_TOKEN environment variable.",
+	)
 	flags.StringVar(
 		&args.ldapIDs,
 		"id-attributes",
@@ -272,6 +307,13 @@ func init() {
 		"",
 		"OpenID: List of scopes to request, in addition to the 'openid' scope, during the authorization token request.\n",
 	)
+	flags.StringVar(
+		&args.openidParams,
+		"extra-authorize-parameters",
+		"",
+		"OpenID: List of key=value parameters to add to the authorization token request, for example to ask "+
+			"the provider to include the groups claim.\n",
+	)
 
 	// HTPasswd
 	flags.StringVar(
@@ -290,10 +332,30 @@ func init() {
 			"- Be at least 14 characters (ASCII-standard) without whitespaces\n"+
 			"- Include uppercase letters, lowercase letters, and numbers or symbols (ASCII-standard characters only)",
 	)
+	flags.StringSliceVar(
+		&args.htpasswdUsers,
+		"users",
+		nil,
+		"HTPasswd: Comma-separated list of 'username:password' pairs of the users to add to the IDP, "+
+			"for example 'user1:password1,user2:password2'.",
+	)
+	flags.StringVar(
+		&args.htpasswdFile,
+		"from-file",
+		"",
+		"HTPasswd: Path to an htpasswd file with the users to add to the IDP. "+
+			"Passwords must be hashed with bcrypt, SHA-1 or MD5.\n",
+	)
 
+	args.wait = wait.AddFlags(flags, "the OAuth server of the cluster serves the identity provider",
+		15*time.Minute)
 	interactive.AddFlag(flags)
+	output.AddFlag(Cmd)
 }
 
+// waitInterval is the time between checks of the OAuth server when '--wait' is used.
+const waitInterval = 15 * time.Second
+
 func typeCompletion(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 	return validIdps, cobra.ShellCompDirectiveDefault
 }
@@ -349,6 +411,11 @@ func run(cmd *cobra.Command, _ []string) {
 				isValidIdp = true
 			}
 		}
+		if idpType == "saml" {
+			r.Reporter.Errorf("SAML identity providers are not supported by OpenShift. " +
+				"Most SAML providers also support OpenID Connect, use '--type openid' instead")
+			os.Exit(1)
+		}
 		if !isValidIdp {
 			r.Reporter.Errorf("Expected a valid IDP type. Options are %s", validIdps)
 			os.Exit(1)
@@ -430,6 +497,54 @@ func doCreateIDP(
 		os.Exit(1)
 	}
 
+	reportCreatedIDP(idpName, createdIdp, cluster, r)
+	return createdIdp
+}
+
+func reportCreatedIDP(idpName string, createdIdp *cmv1.IdentityProvider, cluster *cmv1.Cluster,
+	r *rosa.Runtime) {
+	if !output.HasFlag() {
+		printCreatedIDP(idpName, createdIdp, cluster, r)
+	}
+	if !args.wait.Enabled {
+		if output.HasFlag() {
+			printIDP(createdIdp, r)
+		}
+		return
+	}
+
+	if !output.HasFlag() {
+		r.Reporter.Infof("Waiting up to %s for Identity Provider '%s' to be active", args.wait.Timeout,
+			idpName)
+	}
+	client := &http.Client{
+		Timeout: waitInterval,
+	}
+	err := args.wait.Poll(waitInterval, func() (bool, error) {
+		return ocm.IsIdentityProviderActive(client, cluster, createdIdp)
+	})
+	if output.HasFlag() {
+		printIDP(createdIdp, r)
+	}
+	if err != nil {
+		r.Reporter.Errorf("Identity Provider '%s' isn't active: %v", idpName, err)
+		os.Exit(wait.ExitCode(err))
+	}
+	if !output.HasFlag() {
+		r.Reporter.Infof("Identity Provider '%s' is active", idpName)
+	}
+}
+
+func printIDP(idp *cmv1.IdentityProvider, r *rosa.Runtime) {
+	err := output.Print(idp)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+}
+
+func printCreatedIDP(idpName string, createdIdp *cmv1.IdentityProvider, cluster *cmv1.Cluster,
+	r *rosa.Runtime) {
 	r.Reporter.Infof(
 		"Identity Provider '%s' has been created.\n"+
 			"   It may take several minutes for this access to become active.\n"+
@@ -454,7 +569,6 @@ func doCreateIDP(
 					"   nodes are provisioned and ready in your AWS account.", idpName)
 		}
 	}
-	return createdIdp
 }
 
 func GenerateIdpName(idpType string, idps []IdentityProvider) string {
@@ -481,6 +595,23 @@ func GenerateIdpName(idpType string, idps []IdentityProvider) string {
 	return idpType
 }
 
+// ReadCAFile reads a PEM-encoded certificate bundle, checking that it only contains certificates.
+// Certificates that have expired or that aren't valid yet are reported as warnings.
+func ReadCAFile(caPath string) (string, error) {
+	data, err := os.ReadFile(caPath)
+	if err != nil {
+		return "", fmt.Errorf("Expected a valid certificate bundle: %s", err)
+	}
+	err = ocm.ValidateTrustBundle(data)
+	if err != nil {
+		return "", fmt.Errorf("Expected a valid certificate bundle in '%s': %s", caPath, err)
+	}
+	for _, warning := range ocm.TrustBundleWarnings(data) {
+		reporter.CreateReporterOrExit().Warnf("%s", warning)
+	}
+	return string(data), nil
+}
+
 func getMappingMethod(cmd *cobra.Command, mappingMethod string) (string, error) {
 	var err error
 	if interactive.Enabled() {
@@ -1,12 +1,24 @@
 package idp_test
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	"github.com/openshift/rosa/cmd/create/idp"
 	"github.com/openshift/rosa/cmd/create/idp/mocks"
+	"github.com/openshift/rosa/pkg/ocm"
 )
 
 var _ = Describe("Cmd", func() {
@@ -62,6 +74,136 @@ var _ = Describe("Cmd", func() {
 			})
 		})
 	})
+
+	Context("ParseExtraAuthorizeParameters", func() {
+		It("parses key=value pairs", func() {
+			params, err := idp.ParseExtraAuthorizeParameters("resource=api, prompt=consent")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(params).To(Equal(map[string]string{"resource": "api", "prompt": "consent"}))
+		})
+		It("accepts an empty list", func() {
+			params, err := idp.ParseExtraAuthorizeParameters("")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(params).To(BeEmpty())
+		})
+		It("rejects parameters without a value", func() {
+			_, err := idp.ParseExtraAuthorizeParameters("prompt")
+			Expect(err).To(MatchError(ContainSubstring("form key=value")))
+		})
+		It("rejects duplicated parameters", func() {
+			_, err := idp.ParseExtraAuthorizeParameters("prompt=consent,prompt=login")
+			Expect(err).To(MatchError(ContainSubstring("Duplicated")))
+		})
+	})
+
+	Context("ParseHTPasswdUsers", func() {
+		It("parses username:password pairs", func() {
+			users, err := idp.ParseHTPasswdUsers([]string{"user1:Password1234567", "user2:pass:Word1234567"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(users).To(Equal([]ocm.HTPasswdUser{
+				{Username: "user1", Password: "Password1234567"},
+				{Username: "user2", Password: "pass:Word1234567"},
+			}))
+		})
+		It("rejects pairs without a password", func() {
+			_, err := idp.ParseHTPasswdUsers([]string{"user1"})
+			Expect(err).To(MatchError(ContainSubstring("'username:password'")))
+		})
+		It("rejects weak passwords", func() {
+			_, err := idp.ParseHTPasswdUsers([]string{"user1:short"})
+			Expect(err).To(MatchError(ContainSubstring("at least 14 characters")))
+		})
+		It("rejects the cluster-admin user", func() {
+			_, err := idp.ParseHTPasswdUsers([]string{"cluster-admin:Password1234567"})
+			Expect(err).To(MatchError(ContainSubstring("not allowed")))
+		})
+		It("rejects duplicated users", func() {
+			_, err := idp.ParseHTPasswdUsers([]string{"user1:Password1234567", "user1:Password7654321"})
+			Expect(err).To(MatchError(ContainSubstring("more than once")))
+		})
+	})
+
+	Context("ParseHTPasswdFile", func() {
+		const bcryptHash = "$2y$05$Y1S5A6u0tN3cJ4mH9vXQ8uK8dGm0jF7m3rQ2lP6xE4wB1zC9aV5sS"
+		It("parses bcrypt, SHA-1 and MD5 hashes", func() {
+			users, err := idp.ParseHTPasswdFile([]byte(
+				"# Users\n" +
+					"user1:" + bcryptHash + "\n" +
+					"\n" +
+					"user2:{SHA}W6ph5Mm5Pz8GgiULbPgzG37mj9g=\n" +
+					"user3:$apr1$r31.....$HqJZimcKQFAMYayBlzkrA/\n"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(users).To(Equal([]ocm.HTPasswdUser{
+				{Username: "user1", HashedPassword: bcryptHash},
+				{Username: "user2", HashedPassword: "{SHA}W6ph5Mm5Pz8GgiULbPgzG37mj9g="},
+				{Username: "user3", HashedPassword: "$apr1$r31.....$HqJZimcKQFAMYayBlzkrA/"},
+			}))
+		})
+		It("rejects clear text passwords", func() {
+			_, err := idp.ParseHTPasswdFile([]byte("user1:Password1234567\n"))
+			Expect(err).To(MatchError(ContainSubstring("line 1: password of user 'user1' must be hashed")))
+		})
+		It("rejects malformed lines", func() {
+			_, err := idp.ParseHTPasswdFile([]byte("user1:" + bcryptHash + "\nuser2\n"))
+			Expect(err).To(MatchError(ContainSubstring("line 2: expected a 'username:hash' entry")))
+		})
+		It("rejects duplicated users", func() {
+			_, err := idp.ParseHTPasswdFile([]byte("user1:" + bcryptHash + "\nuser1:" + bcryptHash + "\n"))
+			Expect(err).To(MatchError(ContainSubstring("line 2: user 'user1' is listed more than once")))
+		})
+		It("rejects files without users", func() {
+			_, err := idp.ParseHTPasswdFile([]byte("# Nothing here\n"))
+			Expect(err).To(MatchError(ContainSubstring("no users")))
+		})
+	})
+
+	Context("ReadCAFile", func() {
+		var dir string
+		BeforeEach(func() {
+			dir = GinkgoT().TempDir()
+		})
+		write := func(data []byte) string {
+			path := filepath.Join(dir, "ca.pem")
+			Expect(os.WriteFile(path, data, 0600)).To(Succeed())
+			return path
+		}
+
+		certificate := func(notBefore time.Time, notAfter time.Time) []byte {
+			key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			Expect(err).ToNot(HaveOccurred())
+			template := &x509.Certificate{
+				SerialNumber: big.NewInt(1),
+				Subject:      pkix.Name{CommonName: "test-ca"},
+				NotBefore:    notBefore,
+				NotAfter:     notAfter,
+				IsCA:         true,
+			}
+			der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+			Expect(err).ToNot(HaveOccurred())
+			return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+		}
+
+		It("reads bundles of valid certificates", func() {
+			bundle := certificate(time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+			ca, err := idp.ReadCAFile(write(bundle))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ca).To(Equal(string(bundle)))
+		})
+		It("reads bundles that contain expired certificates", func() {
+			bundle := certificate(time.Now().Add(-2*time.Hour), time.Now().Add(-time.Hour))
+			ca, err := idp.ReadCAFile(write(bundle))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ca).To(Equal(string(bundle)))
+		})
+		It("rejects files that aren't PEM-encoded certificates", func() {
+			_, err := idp.ReadCAFile(write([]byte("not a certificate")))
+			Expect(err).To(MatchError(ContainSubstring("Expected a valid certificate bundle in")))
+		})
+		It("rejects missing files", func() {
+			_, err := idp.ReadCAFile(filepath.Join(dir, "missing.pem"))
+			Expect(err).To(MatchError(ContainSubstring("Expected a valid certificate bundle")))
+		})
+	})
 })
 
 func expectUnique(name string, idps []idp.IdentityProvider) {
@@ -26,6 +26,7 @@ import (
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/spf13/cobra"
 
+	"github.com/openshift/rosa/pkg/helper/github"
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/ocm"
 )
@@ -184,6 +185,7 @@ func buildGithubIdp(cmd *cobra.Command,
 		ClientSecret(clientSecret)
 
 	githubHostname := args.githubHostname
+	ca := ""
 	if interactive.Enabled() {
 		githubHostname, err = interactive.GetString(interactive.Input{
 			Question: "GitHub Enterprise Hostname",
@@ -212,7 +214,7 @@ func buildGithubIdp(cmd *cobra.Command,
 		if interactive.Enabled() {
 			caPath, err = interactive.GetCert(interactive.Input{
 				Question: "CA file path",
-				Help:     cmd.Flags().Lookup("ca").Usage,
+				Help:     cmd.Flags().Lookup("ca-file").Usage,
 				Default:  caPath,
 			})
 			if err != nil {
@@ -220,13 +222,11 @@ func buildGithubIdp(cmd *cobra.Command,
 			}
 		}
 		// Get certificate contents
-		ca := ""
 		if caPath != "" {
-			cert, err := os.ReadFile(caPath)
+			ca, err = ReadCAFile(caPath)
 			if err != nil {
-				return idpBuilder, fmt.Errorf("Expected a valid certificate bundle: %s", err)
+				return idpBuilder, err
 			}
-			ca = string(cert)
 		}
 		// Set the CA file, if any
 		if ca != "" {
@@ -234,6 +234,40 @@ func buildGithubIdp(cmd *cobra.Command,
 		}
 	}
 
+	testConnection := args.testConnection
+	if interactive.Enabled() {
+		testConnection, err = interactive.GetBool(interactive.Input{
+			Question: "Test connection",
+			Help:     cmd.Flags().Lookup("test-connection").Usage,
+			Default:  testConnection,
+		})
+		if err != nil {
+			return idpBuilder, fmt.Errorf("Expected a valid test-connection value: %s", err)
+		}
+	}
+	if testConnection {
+		oauthURL, err := ocm.BuildOAuthURL(cluster, idpType)
+		if err != nil {
+			return idpBuilder, fmt.Errorf("Error building OAuth URL: %v", err)
+		}
+		check := &github.Check{
+			Hostname:    githubHostname,
+			CA:          ca,
+			Token:       os.Getenv("GITHUB_TOKEN"),
+			ClientID:    clientID,
+			CallbackURL: oauthURL + "/oauth2callback/" + idpName,
+		}
+		if organizations != "" {
+			check.Organizations = strings.Split(organizations, ",")
+		} else if teams != "" {
+			check.Teams = strings.Split(teams, ",")
+		}
+		err = check.Run()
+		if err != nil {
+			return idpBuilder, err
+		}
+	}
+
 	mappingMethod, err := getMappingMethod(cmd, args.mappingMethod)
 	if err != nil {
 		return idpBuilder, err
@@ -20,7 +20,6 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
-	"os"
 
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/openshift/rosa/pkg/helper"
@@ -113,7 +112,7 @@ func buildGitlabIdp(cmd *cobra.Command,
 	if interactive.Enabled() && gitlabURL != cmd.Flags().Lookup("host-url").DefValue {
 		caPath, err = interactive.GetCert(interactive.Input{
 			Question: "CA file path",
-			Help:     cmd.Flags().Lookup("ca").Usage,
+			Help:     cmd.Flags().Lookup("ca-file").Usage,
 			Default:  caPath,
 		})
 		if err != nil {
@@ -123,11 +122,10 @@ func buildGitlabIdp(cmd *cobra.Command,
 	// Get certificate contents
 	ca := ""
 	if caPath != "" {
-		cert, err := os.ReadFile(caPath)
+		ca, err = ReadCAFile(caPath)
 		if err != nil {
-			return idpBuilder, fmt.Errorf("Expected a valid certificate bundle: %s", err)
+			return idpBuilder, err
 		}
-		ca = string(cert)
 	}
 
 	mappingMethod, err := getMappingMethod(cmd, args.mappingMethod)
@@ -41,6 +41,12 @@ func createHTPasswdIDP(cmd *cobra.Command,
 	username := args.htpasswdUsername
 	password := args.htpasswdPassword
 
+	users, err := getHTPasswdUsers()
+	if err != nil {
+		r.Reporter.Errorf("Failed to create IDP for cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+
 	// Choose which way to create the IDP according to whether it already has an admin or not.
 	htpasswdIDP, userList := FindExistingHTPasswdIDP(cluster, r)
 	if htpasswdIDP != nil {
@@ -70,6 +76,16 @@ func createHTPasswdIDP(cmd *cobra.Command,
 		// Existing IDP contains only admin. Add new user to it
 		r.Reporter.Infof("Cluster already has an HTPasswd IDP named '%s', new users will be added to it.",
 			htpasswdIDP.Name())
+		if len(users) > 0 {
+			err = r.OCMClient.ImportHTPasswdUsers(cluster.ID(), htpasswdIDP.ID(), users)
+			if err != nil {
+				r.Reporter.Errorf(
+					"Failed to add users to the HTPasswd IDP of cluster '%s': %v", clusterKey, err)
+				os.Exit(1)
+			}
+			r.Reporter.Infof("Users %s added", usernameList(users))
+			return
+		}
 		if username == "" || password == "" {
 			r.Reporter.Infof("At least one user is required to create the IDP.")
 			username, password = getUserDetails(cmd, r)
@@ -83,6 +99,16 @@ func createHTPasswdIDP(cmd *cobra.Command,
 		r.Reporter.Infof("User '%s' added", username)
 	} else {
 		// HTPasswd IDP does not exist - create it
+		if len(users) > 0 {
+			r.Reporter.Infof("Configuring IDP for cluster '%s'", clusterKey)
+			htpasswdIDP, err = r.OCMClient.CreateHTPasswdIdentityProvider(cluster.ID(), idpName, users)
+			if err != nil {
+				r.Reporter.Errorf("Failed to add IDP to cluster '%s': %s", clusterKey, err)
+				os.Exit(1)
+			}
+			reportCreatedIDP(idpName, htpasswdIDP, cluster, r)
+			return
+		}
 		if username == "" || password == "" {
 			r.Reporter.Infof("At least one user is required to create the IDP.")
 			username, password = getUserDetails(cmd, r)
@@ -113,6 +139,106 @@ func createHTPasswdIDP(cmd *cobra.Command,
 	}
 }
 
+// getHTPasswdUsers returns the users given with the '--users' or '--from-file' flags, if any.
+func getHTPasswdUsers() ([]ocm.HTPasswdUser, error) {
+	if len(args.htpasswdUsers) == 0 && args.htpasswdFile == "" {
+		return nil, nil
+	}
+	if args.htpasswdUsername != "" || args.htpasswdPassword != "" {
+		return nil, fmt.Errorf("flags '--users' and '--from-file' can't be used with '--username' and '--password'")
+	}
+	if len(args.htpasswdUsers) > 0 && args.htpasswdFile != "" {
+		return nil, fmt.Errorf("only one of '--users' and '--from-file' can be used")
+	}
+	if args.htpasswdFile != "" {
+		data, err := os.ReadFile(args.htpasswdFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read htpasswd file '%s': %v", args.htpasswdFile, err)
+		}
+		return ParseHTPasswdFile(data)
+	}
+	return ParseHTPasswdUsers(args.htpasswdUsers)
+}
+
+// ParseHTPasswdUsers parses a list of 'username:password' pairs.
+func ParseHTPasswdUsers(pairs []string) ([]ocm.HTPasswdUser, error) {
+	users := []ocm.HTPasswdUser{}
+	seen := map[string]bool{}
+	for _, pair := range pairs {
+		username, password, found := strings.Cut(pair, ":")
+		if !found || username == "" || password == "" {
+			return nil, fmt.Errorf("expected a 'username:password' pair, got '%s'", pair)
+		}
+		err := usernameValidator(username)
+		if err != nil {
+			return nil, err
+		}
+		if seen[username] {
+			return nil, fmt.Errorf("user '%s' is listed more than once", username)
+		}
+		seen[username] = true
+		err = PasswordValidator(password)
+		if err != nil {
+			return nil, fmt.Errorf("invalid password for user '%s': %v", username, err)
+		}
+		users = append(users, ocm.HTPasswdUser{
+			Username: username,
+			Password: password,
+		})
+	}
+	return users, nil
+}
+
+// htpasswdHashRE matches the password hashes supported by the HTPasswd IDP: bcrypt, SHA-1 and
+// Apache MD5.
+var htpasswdHashRE = regexp.MustCompile(
+	`^(\$2[aby]\$\d{2}\$[./A-Za-z0-9]{53}|\{SHA\}[A-Za-z0-9+/]{27}=|\$apr1\$[./A-Za-z0-9]{1,8}\$[./A-Za-z0-9]{22})$`)
+
+// ParseHTPasswdFile parses the content of an htpasswd file, made of 'username:hash' lines. Empty lines
+// and lines starting with '#' are ignored.
+func ParseHTPasswdFile(data []byte) ([]ocm.HTPasswdUser, error) {
+	users := []ocm.HTPasswdUser{}
+	seen := map[string]bool{}
+	for i, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		username, hash, found := strings.Cut(line, ":")
+		if !found || username == "" || hash == "" {
+			return nil, fmt.Errorf("line %d: expected a 'username:hash' entry", i+1)
+		}
+		err := usernameValidator(username)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", i+1, err)
+		}
+		if seen[username] {
+			return nil, fmt.Errorf("line %d: user '%s' is listed more than once", i+1, username)
+		}
+		seen[username] = true
+		if !htpasswdHashRE.MatchString(hash) {
+			return nil, fmt.Errorf("line %d: password of user '%s' must be hashed with bcrypt, SHA-1 or MD5",
+				i+1, username)
+		}
+		users = append(users, ocm.HTPasswdUser{
+			Username:       username,
+			HashedPassword: hash,
+		})
+	}
+	if len(users) == 0 {
+		return nil, fmt.Errorf("htpasswd file contains no users")
+	}
+	return users, nil
+}
+
+func usernameList(users []ocm.HTPasswdUser) string {
+	names := make([]string, len(users))
+	for i, user := range users {
+		names[i] = fmt.Sprintf("'%s'", user.Username)
+	}
+	return strings.Join(names, ", ")
+}
+
 func getUserDetails(cmd *cobra.Command, r *rosa.Runtime) (string, string) {
 	username, err := interactive.GetString(interactive.Input{
 		Question: "Username",
@@ -132,7 +258,7 @@ func getUserDetails(cmd *cobra.Command, r *rosa.Runtime) (string, string) {
 		Default:  "",
 		Required: true,
 		Validators: []interactive.Validator{
-			passwordValidator,
+			PasswordValidator,
 		},
 	})
 	if err != nil {
@@ -185,7 +311,7 @@ func usernameValidator(val interface{}) error {
 	return fmt.Errorf("can only validate strings, got '%v'", val)
 }
 
-func passwordValidator(val interface{}) error {
+func PasswordValidator(val interface{}) error {
 	if password, ok := val.(string); ok {
 		notAsciiOnly, _ := regexp.MatchString(`[^\x20-\x7E]`, password)
 		containsSpace := strings.Contains(password, " ")
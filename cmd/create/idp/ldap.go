@@ -20,12 +20,12 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
-	"os"
 	"strings"
 
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/spf13/cobra"
 
+	"github.com/openshift/rosa/pkg/helper/ldap"
 	"github.com/openshift/rosa/pkg/interactive"
 )
 
@@ -96,7 +96,7 @@ func buildLdapIdp(cmd *cobra.Command,
 	if interactive.Enabled() && !ldapInsecure {
 		caPath, err = interactive.GetCert(interactive.Input{
 			Question: "CA file path",
-			Help:     cmd.Flags().Lookup("ca").Usage,
+			Help:     cmd.Flags().Lookup("ca-file").Usage,
 			Default:  caPath,
 		})
 		if err != nil {
@@ -109,11 +109,10 @@ func buildLdapIdp(cmd *cobra.Command,
 		if ldapInsecure {
 			return idpBuilder, fmt.Errorf("Cannot use certificate bundle with an insecure connection")
 		}
-		cert, err := os.ReadFile(caPath)
+		ca, err = ReadCAFile(caPath)
 		if err != nil {
-			return idpBuilder, fmt.Errorf("Expected a valid certificate bundle: %s", err)
+			return idpBuilder, err
 		}
-		ca = string(cert)
 	}
 
 	mappingMethod, err := getMappingMethod(cmd, args.mappingMethod)
@@ -145,6 +144,31 @@ func buildLdapIdp(cmd *cobra.Command,
 		}
 	}
 
+	testConnection := args.testConnection
+	if interactive.Enabled() {
+		testConnection, err = interactive.GetBool(interactive.Input{
+			Question: "Test connection",
+			Help:     cmd.Flags().Lookup("test-connection").Usage,
+			Default:  testConnection,
+		})
+		if err != nil {
+			return idpBuilder, fmt.Errorf("Expected a valid test-connection value: %s", err)
+		}
+	}
+	if testConnection {
+		connection := &ldap.Connection{
+			URL:          ldapURL,
+			Insecure:     ldapInsecure,
+			CA:           ca,
+			BindDN:       ldapBindDN,
+			BindPassword: ldapBindPassword,
+		}
+		err = connection.Test()
+		if err != nil {
+			return idpBuilder, err
+		}
+	}
+
 	if interactive.Enabled() {
 		err = interactive.PrintHelp(interactive.Help{
 			Message: "The following options map LDAP attributes to identities. Enter multiple values separated by commas.",
@@ -20,7 +20,6 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
-	"os"
 	"strings"
 
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
@@ -118,7 +117,7 @@ func buildOpenidIdp(cmd *cobra.Command,
 	if interactive.Enabled() {
 		caPath, err = interactive.GetCert(interactive.Input{
 			Question: "CA file path",
-			Help:     cmd.Flags().Lookup("ca").Usage,
+			Help:     cmd.Flags().Lookup("ca-file").Usage,
 			Default:  caPath,
 		})
 		if err != nil {
@@ -128,11 +127,10 @@ func buildOpenidIdp(cmd *cobra.Command,
 	// Get certificate contents
 	ca := ""
 	if caPath != "" {
-		cert, err := os.ReadFile(caPath)
+		ca, err = ReadCAFile(caPath)
 		if err != nil {
-			return idpBuilder, fmt.Errorf("Expected a valid certificate bundle: %s", err)
+			return idpBuilder, err
 		}
-		ca = string(cert)
 	}
 
 	mappingMethod, err := getMappingMethod(cmd, args.mappingMethod)
@@ -216,6 +214,28 @@ separated by commas.`,
 		}
 	}
 
+	params := args.openidParams
+	if interactive.Enabled() {
+		params, err = interactive.GetString(interactive.Input{
+			Question: "Extra authorize parameters",
+			Help:     cmd.Flags().Lookup("extra-authorize-parameters").Usage,
+			Default:  params,
+			Validators: []interactive.Validator{
+				func(val interface{}) error {
+					_, err := ParseExtraAuthorizeParameters(fmt.Sprintf("%v", val))
+					return err
+				},
+			},
+		})
+		if err != nil {
+			return idpBuilder, fmt.Errorf("Expected a valid comma-separated list of parameters: %s", err)
+		}
+	}
+	extraParams, err := ParseExtraAuthorizeParameters(params)
+	if err != nil {
+		return idpBuilder, err
+	}
+
 	// Create OpenID IDP
 	openIDIDP := cmv1.NewOpenIDIdentityProvider().
 		ClientID(clientID).
@@ -227,6 +247,10 @@ separated by commas.`,
 		openIDIDP = openIDIDP.ExtraScopes(strings.Split(scopes, ",")...)
 	}
 
+	if len(extraParams) > 0 {
+		openIDIDP = openIDIDP.ExtraAuthorizeParameters(extraParams)
+	}
+
 	// Set the CA file, if any
 	if ca != "" {
 		openIDIDP = openIDIDP.CA(ca)
@@ -259,3 +283,22 @@ func validateOpenidIssuerURL(val interface{}) error {
 	}
 	return nil
 }
+
+// ParseExtraAuthorizeParameters parses a comma-separated list of key=value authorize parameters
+func ParseExtraAuthorizeParameters(params string) (map[string]string, error) {
+	result := map[string]string{}
+	if strings.TrimSpace(params) == "" {
+		return result, nil
+	}
+	for _, param := range strings.Split(params, ",") {
+		key, value, found := strings.Cut(strings.TrimSpace(param), "=")
+		if !found || key == "" {
+			return nil, fmt.Errorf("Expected authorize parameter '%s' to have the form key=value", param)
+		}
+		if _, ok := result[key]; ok {
+			return nil, fmt.Errorf("Duplicated authorize parameter '%s'", key)
+		}
+		result[key] = value
+	}
+	return result, nil
+}
@@ -24,14 +24,16 @@ import (
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/spf13/cobra"
 
+	ingressHelpers "github.com/openshift/rosa/pkg/helper/ingress"
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
 var args struct {
-	private    bool
-	labelMatch string
+	private          bool
+	labelMatch       string
+	loadBalancerType string
 }
 
 var Cmd = &cobra.Command{
@@ -46,7 +48,10 @@ var Cmd = &cobra.Command{
   rosa create ingress --cluster=mycluster
 
   # Add an ingress with route selector label match
-  rosa create ingress -c mycluster --label-match="foo=bar,bar=baz"`,
+  rosa create ingress -c mycluster --label-match="foo=bar,bar=baz"
+
+  # Add a private ingress using a network load balancer
+  rosa create ingress -c mycluster --private --lb-type=nlb`,
 	Run: run,
 }
 
@@ -70,6 +75,14 @@ func init() {
 			"If no label is specified, all routes will be exposed on both routers.",
 	)
 
+	flags.StringVar(
+		&args.loadBalancerType,
+		"lb-type",
+		"",
+		fmt.Sprintf("Type of the AWS load balancer of the ingress, one of %s. The default is '%s'.",
+			strings.Join(ocm.LoadBalancerTypes, ", "), ocm.LoadBalancerTypeClassic),
+	)
+
 	interactive.AddFlag(flags)
 }
 
@@ -86,7 +99,7 @@ func run(cmd *cobra.Command, _ []string) {
 			Help:     cmd.Flags().Lookup("label-match").Usage,
 			Default:  labelMatch,
 			Validators: []interactive.Validator{
-				labelValidator,
+				ingressHelpers.LabelMatchValidator,
 			},
 		})
 		if err != nil {
@@ -94,7 +107,34 @@ func run(cmd *cobra.Command, _ []string) {
 			os.Exit(1)
 		}
 	}
-	routeSelectors, err := getRouteSelector(labelMatch)
+	routeSelectors, err := ingressHelpers.GetRouteSelector(labelMatch)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+
+	loadBalancerType := args.loadBalancerType
+	if interactive.Enabled() {
+		defaultLoadBalancerType := loadBalancerType
+		if defaultLoadBalancerType == "" {
+			defaultLoadBalancerType = ocm.LoadBalancerTypeClassic
+		}
+		loadBalancerType, err = interactive.GetOption(interactive.Input{
+			Question: "Load balancer type",
+			Help:     cmd.Flags().Lookup("lb-type").Usage,
+			Options:  ocm.LoadBalancerTypes,
+			Default:  defaultLoadBalancerType,
+			Required: true,
+		})
+		if err != nil {
+			r.Reporter.Errorf("Expected a valid load balancer type: %s", err)
+			os.Exit(1)
+		}
+	}
+	options := &ingressHelpers.Options{
+		LoadBalancerType: loadBalancerType,
+	}
+	err = options.Validate(false)
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
 		os.Exit(1)
@@ -111,6 +151,22 @@ func run(cmd *cobra.Command, _ []string) {
 		os.Exit(1)
 	}
 
+	// Clusters support a single additional ingress next to the default one:
+	r.Reporter.Debugf("Loading ingresses for cluster '%s'", clusterKey)
+	ingresses, err := r.OCMClient.GetIngresses(cluster.ID())
+	if err != nil {
+		r.Reporter.Errorf("Failed to get ingresses for cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+	for _, item := range ingresses {
+		if !item.Default() {
+			r.Reporter.Errorf("Cluster '%s' already has an additional ingress '%s'. "+
+				"Edit it with 'rosa edit ingress -c %s %s' or delete it first",
+				clusterKey, item.ID(), clusterKey, item.ID())
+			os.Exit(1)
+		}
+	}
+
 	ingressBuilder := cmv1.NewIngress()
 
 	if cmd.Flags().Changed("private") {
@@ -145,38 +201,12 @@ func run(cmd *cobra.Command, _ []string) {
 		os.Exit(1)
 	}
 
-	_, err = r.OCMClient.CreateIngress(cluster.ID(), ingress)
+	ingress, err = r.OCMClient.CreateIngressWithFields(cluster.ID(), ingress, options.Fields())
 	if err != nil {
 		r.Reporter.Errorf("Failed to add ingress to cluster '%s': %s", clusterKey, err)
 		os.Exit(1)
 	}
 
-	r.Reporter.Infof("Ingress has been created on cluster '%s'.", clusterKey)
+	r.Reporter.Infof("Ingress '%s' has been created on cluster '%s'.", ingress.ID(), clusterKey)
 	r.Reporter.Infof("To view all ingresses, run 'rosa list ingresses -c %s'", clusterKey)
 }
-
-func labelValidator(val interface{}) error {
-	if labelMatch, ok := val.(string); ok {
-		_, err := getRouteSelector(labelMatch)
-		if err != nil {
-			return err
-		}
-		return nil
-	}
-	return fmt.Errorf("can only validate strings, got %v", val)
-}
-
-func getRouteSelector(labelMatch string) (map[string]string, error) {
-	routeSelectors := make(map[string]string)
-	if labelMatch == "" {
-		return routeSelectors, nil
-	}
-	for _, labelMatch := range strings.Split(labelMatch, ",") {
-		if !strings.Contains(labelMatch, "=") {
-			return nil, fmt.Errorf("Expected key=value format for label-match")
-		}
-		tokens := strings.Split(labelMatch, "=")
-		routeSelectors[strings.TrimSpace(tokens[0])] = strings.TrimSpace(tokens[1])
-	}
-	return routeSelectors, nil
-}
@@ -0,0 +1,124 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeletconfig
+
+import (
+	"os"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/interactive"
+	"github.com/openshift/rosa/pkg/kubeletconfig"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var Cmd = &cobra.Command{
+	Use:     "kubeletconfig",
+	Aliases: []string{"kubelet-config"},
+	Short:   "Create a custom kubelet config for a cluster",
+	Long: "Create a custom kubelet config for a cluster. Classic clusters have a single kubelet config " +
+		"that applies to all their nodes. Hosted Control Plane clusters can have several named kubelet " +
+		"configs, that apply to the machine pools created or edited with '--kubelet-configs'.",
+	Example: `  # Set the pod PIDs limit of the nodes of classic cluster 'mycluster'
+  rosa create kubeletconfig -c mycluster --pod-pids-limit 10000
+
+  # Create kubelet config 'high-pids' on hosted cluster 'mycluster' and use it in a machine pool
+  rosa create kubeletconfig -c mycluster --name high-pids --pod-pids-limit 16000
+  rosa edit machinepool -c mycluster workers --kubelet-configs high-pids`,
+	Run:  run,
+	Args: cobra.NoArgs,
+}
+
+var args struct {
+	name         string
+	podPidsLimit int
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
+	ocm.AddClusterFlag(Cmd)
+	kubeletconfig.AddNameFlag(flags, &args.name)
+	kubeletconfig.AddPodPidsLimitFlag(flags, &args.podPidsLimit)
+	interactive.AddFlag(flags)
+	output.AddFlag(Cmd)
+}
+
+func run(cmd *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS().WithOCM()
+	defer r.Cleanup()
+
+	clusterKey := r.GetClusterKey()
+
+	cluster := r.FetchCluster()
+	if cluster.State() != cmv1.ClusterStateReady {
+		r.Reporter.Errorf("Cluster '%s' is not yet ready", clusterKey)
+		os.Exit(1)
+	}
+
+	err := kubeletconfig.ValidateName(cluster, args.name)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+	existing, err := r.OCMClient.FindKubeletConfig(cluster, args.name)
+	if err != nil {
+		r.Reporter.Errorf("Failed to get kubelet configs for cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+	if existing != nil {
+		r.Reporter.Errorf("Kubelet config already exists for cluster '%s', use 'rosa edit kubeletconfig' "+
+			"to modify it", clusterKey)
+		os.Exit(1)
+	}
+
+	podPidsLimit, err := kubeletconfig.GetPodPidsLimit(cmd.Flags(), args.podPidsLimit)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+
+	r.Reporter.Debugf("Creating kubelet config for cluster '%s'", clusterKey)
+	config, err := r.OCMClient.CreateKubeletConfig(cluster, &ocm.KubeletConfig{
+		Name:         args.name,
+		PodPidsLimit: podPidsLimit,
+	})
+	if err != nil {
+		r.Reporter.Errorf("Failed to create kubelet config for cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+
+	if output.HasFlag() {
+		err = output.Print(config)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+		return
+	}
+	if cluster.Hypershift().Enabled() {
+		r.Reporter.Infof("Successfully created kubelet config '%s' for cluster '%s'. Use it in machine pools "+
+			"with '--kubelet-configs %s'", config.Name, clusterKey, config.Name)
+		return
+	}
+	r.Reporter.Infof("Successfully created kubelet config for cluster '%s'. The nodes are updated "+
+		"progressively and their workloads are restarted.", clusterKey)
+}
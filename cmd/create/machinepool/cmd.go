@@ -17,17 +17,24 @@ limitations under the License.
 package machinepool
 
 import (
+	"fmt"
 	"os"
 	"regexp"
+	"strings"
+	"time"
 
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/openshift/rosa/pkg/aws"
+	mpHelpers "github.com/openshift/rosa/pkg/helper/machinepools"
 	"github.com/openshift/rosa/pkg/output"
 	"github.com/openshift/rosa/pkg/rosa"
+	"github.com/openshift/rosa/pkg/wait"
 	"github.com/spf13/cobra"
 
 	"github.com/openshift/rosa/pkg/interactive"
+	"github.com/openshift/rosa/pkg/kubeletconfig"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/tuningconfig"
 )
 
 // Regular expression to used to make sure that the identifier given by the
@@ -47,16 +54,28 @@ var args struct {
 	spotMaxPrice          string
 	multiAvailabilityZone bool
 	availabilityZone      string
+	availabilityZones     []string
 	subnet                string
+	securityGroupIds      []string
 	version               string
 	autorepair            bool
+	autoupgrade           bool
+	fromFile              string
+	ec2MetadataHttpTokens string
+	operatingSystem       string
+	kubeletConfigs        []string
+	tuningConfigs         []string
+	nodeDrainGracePeriod  string
+	diskSize              string
+	wait                  *wait.Options
 }
 
 var Cmd = &cobra.Command{
 	Use:     "machinepool",
 	Aliases: []string{"machinepools", "machine-pool", "machine-pools"},
 	Short:   "Add machine pool to cluster",
-	Long:    "Add a machine pool to the cluster.",
+	Long: "Add a machine pool to the cluster.\n\n" + wait.ExitCodesHelp + " Waiting is only " +
+		"supported for Hosted Control Plane clusters.",
 	Example: `  # Interactively add a machine pool to a cluster named "mycluster"
   rosa create machinepool --cluster=mycluster --interactive
 
@@ -72,7 +91,24 @@ var Cmd = &cobra.Command{
 
   # Add a machine pool with spot instances to a cluster
   rosa create machinepool -c mycluster --name=mp-1 --replicas=2 --instance-type=r5.2xlarge --use-spot-instances \
-    --spot-max-price=0.5`,
+    --spot-max-price=0.5
+
+  # Add a machine pool to a hosted cluster that automatically follows the control plane z-stream upgrades
+  rosa create machinepool -c mycluster --name=mp-1 --replicas=2 --autoupgrade
+
+  # Add a machine pool for edge workloads in the subnet of a Local Zone or Wavelength Zone of a BYOVPC cluster
+  rosa create machinepool -c mycluster --name=edge-1 --replicas=1 --instance-type=r5.xlarge \
+    --subnet=subnet-0123456789abcdef0
+
+  # Add a machine pool spanning two availability zones of a multi-AZ cluster
+  rosa create machinepool -c mycluster --name=mp-1 --replicas=4 --multi-availability-zone \
+    --availability-zones=us-east-1a,us-east-1b
+
+  # Add a machine pool to a hosted cluster and wait until its nodes are ready
+  rosa create machinepool -c mycluster --name=mp-1 --replicas=2 --wait --timeout=30m -o json
+
+  # Create or update all the machine pools described in a manifest file
+  rosa create machinepool -c mycluster --from-file=pools.yaml`,
 	Run: run,
 }
 
@@ -143,14 +179,15 @@ func init() {
 		&args.useSpotInstances,
 		"use-spot-instances",
 		false,
-		"Use spot instances for the machine pool.",
+		"Use spot instances for the machine pool. Not supported for Hosted Control Plane clusters.",
 	)
 
 	flags.StringVar(
 		&args.spotMaxPrice,
 		"spot-max-price",
 		"on-demand",
-		"Max price for spot instance. If empty use the on-demand price.",
+		"Max price in USD per hour for spot instances, requires '--use-spot-instances'. "+
+			"If empty or 'on-demand' use the on-demand price.",
 	)
 
 	flags.BoolVar(
@@ -165,11 +202,28 @@ func init() {
 		"",
 		"Select availability zone to create a single AZ machine pool for a multi-AZ cluster")
 
+	flags.StringSliceVar(
+		&args.availabilityZones,
+		"availability-zones",
+		nil,
+		"Select the availability zones spanned by a multi-AZ machine pool for a multi-AZ cluster. "+
+			"Format should be a comma-separated list of at least two of the cluster's availability zones. "+
+			"Replicas are balanced across the zones, so they must be a multiple of the number of zones.")
+
 	flags.StringVar(
 		&args.subnet,
 		"subnet",
 		"",
-		"Select subnet to create a single AZ machine pool for BYOVPC cluster")
+		"Select subnet to create a single AZ machine pool for BYOVPC cluster. The subnet can be in an "+
+			"AWS Local Zone or Wavelength Zone of the cluster's region, which must be enabled for the account.")
+
+	flags.StringSliceVar(
+		&args.securityGroupIds,
+		"additional-security-group-ids",
+		nil,
+		"The additional security group IDs to attach to the nodes of the machine pool, in the VPC of a "+
+			"BYOVPC cluster. Format should be a comma-separated list.",
+	)
 
 	flags.StringVar(
 		&args.version,
@@ -186,10 +240,78 @@ func init() {
 		"Select auto-repair behaviour for a machinepool in a hosted cluster.",
 	)
 
+	flags.BoolVar(
+		&args.autoupgrade,
+		"autoupgrade",
+		false,
+		"Select auto-upgrade behaviour for a machinepool in a hosted cluster. When enabled the machine "+
+			"pool automatically follows the z-stream upgrades of the control plane.",
+	)
+
+	flags.StringVar(
+		&args.ec2MetadataHttpTokens,
+		"ec2-metadata-http-tokens",
+		"",
+		fmt.Sprintf("Should the nodes of a machine pool in a hosted cluster use both v1 and v2 endpoints or "+
+			"just v2 endpoint of EC2 Instance Metadata Service (IMDS). Available options: %s. The nodes of "+
+			"classic clusters use the setting of the cluster.", strings.Join(ocm.Ec2MetadataHttpTokensValues, ", ")),
+	)
+
+	flags.StringVar(
+		&args.operatingSystem,
+		"os",
+		ocm.OperatingSystemLinux,
+		fmt.Sprintf("Operating system of the nodes of the machine pool, one of %s. Windows nodes require "+
+			"a classic cluster with the '%s' network type and version %s or later, and get the "+
+			"'os=Windows:NoSchedule' taint so that Linux workloads aren't scheduled on them.",
+			strings.Join(ocm.OperatingSystems, ", "), ocm.WindowsNetworkType, ocm.MinimumWindowsVersion),
+	)
+	Cmd.RegisterFlagCompletionFunc("os", operatingSystemCompletion)
+
+	flags.StringVar(
+		&args.diskSize,
+		"disk-size",
+		"",
+		fmt.Sprintf("Root disk size of the nodes of the machine pool, with a unit, for example '300GiB' or "+
+			"'1TiB'. Accepted units are G, GB, Gi, GiB, T, TB, Ti and TiB, and the size must be between %d GiB "+
+			"and %d GiB. Defaults to the size of the cluster's default machine pool.",
+			mpHelpers.MinRootDiskSize, mpHelpers.MaxRootDiskSize),
+	)
+
+	kubeletconfig.AddKubeletConfigsFlag(flags, &args.kubeletConfigs)
+	tuningconfig.AddTuningConfigsFlag(flags, &args.tuningConfigs)
+
+	flags.StringVar(
+		&args.nodeDrainGracePeriod,
+		"node-drain-grace-period",
+		"",
+		fmt.Sprintf("How long the workloads protected by pod disruption budgets are respected when draining "+
+			"the nodes of a machine pool in a hosted cluster during upgrades, for example '30 minutes' or "+
+			"'2 hours'. After this period the workloads are forcibly evicted. The maximum is %d minutes "+
+			"(1 week) and 0 means that they are never forcibly evicted.", ocm.MaxNodePoolNodeDrainGracePeriod),
+	)
+
+	flags.StringVar(
+		&args.fromFile,
+		"from-file",
+		"",
+		"Path to a YAML or JSON manifest with a 'machine_pools' list. Machine pools that already "+
+			"exist are updated, the others are created. All entries are validated before any change.",
+	)
+
+	args.wait = wait.AddFlags(flags, "the nodes of the machine pool are ready", 30*time.Minute)
 	interactive.AddFlag(flags)
 	output.AddFlag(Cmd)
 }
 
+func operatingSystemCompletion(cmd *cobra.Command, args []string, toComplete string) ([]string,
+	cobra.ShellCompDirective) {
+	return ocm.OperatingSystems, cobra.ShellCompDirectiveDefault
+}
+
+// waitInterval is the time between checks of the machine pool when '--wait' is used.
+const waitInterval = 30 * time.Second
+
 func run(cmd *cobra.Command, _ []string) {
 	r := rosa.NewRuntime().WithOCM()
 	defer r.Cleanup()
@@ -213,7 +335,15 @@ func run(cmd *cobra.Command, _ []string) {
 		os.Exit(1)
 	}
 
-	if cluster.Hypershift().Enabled() {
+	if args.wait.Enabled && (args.fromFile != "" || !cluster.Hypershift().Enabled()) {
+		r.Reporter.Errorf("The '--wait' option is only supported when creating a single machine pool " +
+			"on a Hosted Control Plane cluster")
+		os.Exit(1)
+	}
+
+	if args.fromFile != "" {
+		addMachinePoolsFromFile(cmd, clusterKey, cluster, r)
+	} else if cluster.Hypershift().Enabled() {
 		addNodePool(cmd, clusterKey, cluster, r)
 	} else {
 		addMachinePool(cmd, clusterKey, cluster, r)
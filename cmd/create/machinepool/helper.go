@@ -5,6 +5,7 @@ import (
 
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/openshift/rosa/pkg/aws"
+	mpHelpers "github.com/openshift/rosa/pkg/helper/machinepools"
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/rosa"
 	"github.com/spf13/cobra"
@@ -79,3 +80,38 @@ func getSubnetOptions(r *rosa.Runtime, cluster *cmv1.Cluster) ([]string, error)
 
 	return subnetOptions, nil
 }
+
+// getDiskSize returns the root disk size, in GiB, of the nodes of the machine pool, or zero to use
+// the default size.
+func getDiskSize(cmd *cobra.Command, r *rosa.Runtime) int {
+	var err error
+	diskSize := args.diskSize
+	if interactive.Enabled() {
+		diskSize, err = interactive.GetString(interactive.Input{
+			Question: "Root disk size",
+			Help:     cmd.Flags().Lookup("disk-size").Usage,
+			Default:  diskSize,
+			Validators: []interactive.Validator{
+				mpHelpers.MachinePoolRootDiskSizeValidator,
+			},
+		})
+		if err != nil {
+			r.Reporter.Errorf("Expected a valid root disk size: %s", err)
+			os.Exit(1)
+		}
+	}
+	if diskSize == "" {
+		return 0
+	}
+	size, err := mpHelpers.ParseDiskSizeToGigibyte(diskSize)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+	err = mpHelpers.ValidateRootDiskSize(size)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+	return size
+}
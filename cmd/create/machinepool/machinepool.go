@@ -7,14 +7,19 @@ import (
 	"strings"
 	"time"
 
+	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/briandowns/spinner"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/openshift/rosa/pkg/aws"
 	"github.com/openshift/rosa/pkg/helper"
 	mpHelpers "github.com/openshift/rosa/pkg/helper/machinepools"
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/kubeletconfig"
+	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/output"
 	"github.com/openshift/rosa/pkg/rosa"
+	"github.com/openshift/rosa/pkg/tuningconfig"
 	"github.com/spf13/cobra"
 )
 
@@ -38,6 +43,11 @@ func addMachinePool(cmd *cobra.Command, clusterKey string, cluster *cmv1.Cluster
 		r.Reporter.Errorf("Setting the `availability-zone` flag is only allowed for multi-AZ clusters")
 		os.Exit(1)
 	}
+	isAvailabilityZonesSet := cmd.Flags().Changed("availability-zones")
+	if isAvailabilityZonesSet && !cluster.MultiAZ() {
+		r.Reporter.Errorf("Setting the `availability-zones` flag is only allowed for multi-AZ clusters")
+		os.Exit(1)
+	}
 
 	// Validate flags that are only allowed for BYOVPC cluster
 	isSubnetSet := cmd.Flags().Changed("subnet")
@@ -45,6 +55,11 @@ func addMachinePool(cmd *cobra.Command, clusterKey string, cluster *cmv1.Cluster
 		r.Reporter.Errorf("Setting the `subnet` flag is only allowed for BYOVPC clusters")
 		os.Exit(1)
 	}
+	isSecurityGroupIdsSet := cmd.Flags().Changed("additional-security-group-ids")
+	if !isBYOVPC(cluster) && isSecurityGroupIdsSet {
+		r.Reporter.Errorf("Setting the `additional-security-group-ids` flag is only allowed for BYOVPC clusters")
+		os.Exit(1)
+	}
 
 	if isSubnetSet && isAvailabilityZoneSet {
 		r.Reporter.Errorf("Setting both `subnet` and `availability-zone` flag is not supported." +
@@ -63,12 +78,53 @@ func addMachinePool(cmd *cobra.Command, clusterKey string, cluster *cmv1.Cluster
 		os.Exit(1)
 	}
 
+	// Validate `availability-zones` flag is only set for a multi-AZ machine pool
+	if isAvailabilityZonesSet && (isAvailabilityZoneSet || isSubnetSet) {
+		r.Reporter.Errorf("Setting the `availability-zones` flag is not supported together with the " +
+			"`availability-zone` or `subnet` flags")
+		os.Exit(1)
+	}
+	if isAvailabilityZonesSet && isMultiAvailabilityZoneSet && !args.multiAvailabilityZone {
+		r.Reporter.Errorf("Setting the `availability-zones` flag is only supported for creating a multi-AZ " +
+			"machine pool")
+		os.Exit(1)
+	}
+
 	isAutoRepairSet := cmd.Flags().Changed("autorepair")
 	if isAutoRepairSet {
 		r.Reporter.Errorf("Setting the `autorepair` flag is only supported for hosted clusters")
 		os.Exit(1)
 	}
 
+	if cmd.Flags().Changed("autoupgrade") {
+		r.Reporter.Errorf("Setting the `autoupgrade` flag is only supported for hosted clusters")
+		os.Exit(1)
+	}
+
+	if cmd.Flags().Changed(kubeletconfig.KubeletConfigsFlag) {
+		r.Reporter.Errorf("Setting the `%s` flag is only supported for hosted clusters, the nodes of "+
+			"classic clusters use the kubelet config of the cluster", kubeletconfig.KubeletConfigsFlag)
+		os.Exit(1)
+	}
+
+	if cmd.Flags().Changed(tuningconfig.TuningConfigsFlag) {
+		r.Reporter.Errorf("Setting the `%s` flag is only supported for hosted clusters",
+			tuningconfig.TuningConfigsFlag)
+		os.Exit(1)
+	}
+
+	if cmd.Flags().Changed("node-drain-grace-period") {
+		r.Reporter.Errorf("Setting the `node-drain-grace-period` flag is only supported for hosted clusters, " +
+			"the nodes of classic clusters use the node drain grace period of the cluster")
+		os.Exit(1)
+	}
+
+	if cmd.Flags().Changed("ec2-metadata-http-tokens") {
+		r.Reporter.Errorf("Setting the `ec2-metadata-http-tokens` flag is only supported for hosted clusters, " +
+			"the nodes of classic clusters use the setting given when creating the cluster")
+		os.Exit(1)
+	}
+
 	// Machine pool name:
 	name := strings.Trim(args.name, " \t")
 	if name == "" && !interactive.Enabled() {
@@ -104,6 +160,7 @@ func addMachinePool(cmd *cobra.Command, clusterKey string, cluster *cmv1.Cluster
 	// Single AZ machine pool for a multi-AZ cluster
 	var multiAZMachinePool bool
 	var availabilityZone string
+	var availabilityZones []string
 	if cluster.MultiAZ() {
 		// Choosing a single AZ machine pool implicitly (providing availability zone or subnet)
 		if isAvailabilityZoneSet || isSubnetSet {
@@ -111,6 +168,18 @@ func addMachinePool(cmd *cobra.Command, clusterKey string, cluster *cmv1.Cluster
 			args.multiAvailabilityZone = false
 		}
 
+		// Choosing a multi-AZ machine pool implicitly (providing availability zones)
+		if isAvailabilityZonesSet {
+			isMultiAvailabilityZoneSet = true
+			args.multiAvailabilityZone = true
+			availabilityZones = args.availabilityZones
+			err = validateAvailabilityZones(availabilityZones, cluster.Nodes().AvailabilityZones())
+			if err != nil {
+				r.Reporter.Errorf("%s", err)
+				os.Exit(1)
+			}
+		}
+
 		if !isMultiAvailabilityZoneSet && interactive.Enabled() && !confirm.Yes() {
 			multiAZMachinePool, err = interactive.GetBool(interactive.Input{
 				Question: "Create multi-AZ machine pool",
@@ -160,6 +229,15 @@ func addMachinePool(cmd *cobra.Command, clusterKey string, cluster *cmv1.Cluster
 		}
 	}
 
+	// Number of availability zones the replicas are balanced across
+	zoneCount := 1
+	if multiAZMachinePool {
+		zoneCount = len(cluster.Nodes().AvailabilityZones())
+		if len(availabilityZones) > 0 {
+			zoneCount = len(availabilityZones)
+		}
+	}
+
 	isMinReplicasSet := cmd.Flags().Changed("min-replicas")
 	isMaxReplicasSet := cmd.Flags().Changed("max-replicas")
 	isAutoscalingSet := cmd.Flags().Changed("enable-autoscaling")
@@ -197,7 +275,7 @@ func addMachinePool(cmd *cobra.Command, clusterKey string, cluster *cmv1.Cluster
 				Default:  minReplicas,
 				Required: true,
 				Validators: []interactive.Validator{
-					minReplicaValidator(multiAZMachinePool),
+					minReplicaValidator(zoneCount),
 				},
 			})
 			if err != nil {
@@ -205,7 +283,7 @@ func addMachinePool(cmd *cobra.Command, clusterKey string, cluster *cmv1.Cluster
 				os.Exit(1)
 			}
 		}
-		err = minReplicaValidator(multiAZMachinePool)(minReplicas)
+		err = minReplicaValidator(zoneCount)(minReplicas)
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
 			os.Exit(1)
@@ -218,7 +296,7 @@ func addMachinePool(cmd *cobra.Command, clusterKey string, cluster *cmv1.Cluster
 				Default:  maxReplicas,
 				Required: true,
 				Validators: []interactive.Validator{
-					maxReplicaValidator(minReplicas, multiAZMachinePool),
+					maxReplicaValidator(minReplicas, zoneCount),
 				},
 			})
 			if err != nil {
@@ -226,7 +304,7 @@ func addMachinePool(cmd *cobra.Command, clusterKey string, cluster *cmv1.Cluster
 				os.Exit(1)
 			}
 		}
-		err = maxReplicaValidator(minReplicas, multiAZMachinePool)(maxReplicas)
+		err = maxReplicaValidator(minReplicas, zoneCount)(maxReplicas)
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
 			os.Exit(1)
@@ -244,7 +322,7 @@ func addMachinePool(cmd *cobra.Command, clusterKey string, cluster *cmv1.Cluster
 				Default:  replicas,
 				Required: true,
 				Validators: []interactive.Validator{
-					minReplicaValidator(multiAZMachinePool),
+					minReplicaValidator(zoneCount),
 				},
 			})
 			if err != nil {
@@ -252,7 +330,7 @@ func addMachinePool(cmd *cobra.Command, clusterKey string, cluster *cmv1.Cluster
 				os.Exit(1)
 			}
 		}
-		err = minReplicaValidator(multiAZMachinePool)(replicas)
+		err = minReplicaValidator(zoneCount)(replicas)
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
 			os.Exit(1)
@@ -270,12 +348,25 @@ func addMachinePool(cmd *cobra.Command, clusterKey string, cluster *cmv1.Cluster
 
 	// Determine machine pool availability zones to filter supported machine types
 	availabilityZonesFilter, err := getMachinePoolAvailabilityZones(r, cluster, multiAZMachinePool, availabilityZone,
-		subnet)
+		availabilityZones, subnet)
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
 		os.Exit(1)
 	}
 
+	// Machine pools created in the subnet of a Local Zone or Wavelength Zone
+	var edgeZone *aws.EdgeZone
+	if subnet != "" {
+		edgeZone, err = r.AWSClient.GetEdgeZone(availabilityZonesFilter[0])
+		if err != nil {
+			if spin != nil {
+				spin.Stop()
+			}
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+	}
+
 	// Machine pool instance type:
 	instanceType := args.instanceType
 	instanceTypeList, err := r.OCMClient.GetAvailableMachineTypesInRegion(cluster.Region().ID(), availabilityZonesFilter,
@@ -314,6 +405,62 @@ func addMachinePool(cmd *cobra.Command, clusterKey string, cluster *cmv1.Cluster
 		r.Reporter.Errorf("Expected a valid machine type: %s", err)
 		os.Exit(1)
 	}
+	err = ocm.ValidateMachineTypeArchitecture(cluster, instanceType)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+
+	// Operating system of the nodes:
+	operatingSystem := args.operatingSystem
+	if interactive.Enabled() {
+		operatingSystem, err = interactive.GetOption(interactive.Input{
+			Question: "Operating system",
+			Help:     cmd.Flags().Lookup("os").Usage,
+			Options:  ocm.OperatingSystems,
+			Default:  operatingSystem,
+			Required: true,
+		})
+		if err != nil {
+			r.Reporter.Errorf("Expected a valid operating system: %s", err)
+			os.Exit(1)
+		}
+	}
+	switch operatingSystem {
+	case ocm.OperatingSystemLinux:
+	case ocm.OperatingSystemWindows:
+		err = ocm.ValidateWindowsMachinePool(cluster, instanceType)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+	default:
+		r.Reporter.Errorf("Expected a valid operating system, one of %s, got '%s'",
+			strings.Join(ocm.OperatingSystems, ", "), operatingSystem)
+		os.Exit(1)
+	}
+	if edgeZone != nil {
+		offered, err := r.AWSClient.IsInstanceTypeOfferedInZone(instanceType, edgeZone.Name)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+		if !offered {
+			r.Reporter.Errorf("Instance type '%s' isn't offered in %s", instanceType, edgeZone)
+			os.Exit(1)
+		}
+	}
+	if machineType := instanceTypeList.Find(instanceType); machineType != nil {
+		nodes := replicas
+		if autoscaling {
+			nodes = maxReplicas
+		}
+		err = r.AWSClient.ValidateInstanceQuota(map[string]int{instanceType: nodes * machineType.CPUs()})
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+	}
 
 	existingLabels := make(map[string]string, 0)
 	labelMap := mpHelpers.GetLabelMap(cmd, r, existingLabels, args.labels)
@@ -321,32 +468,33 @@ func addMachinePool(cmd *cobra.Command, clusterKey string, cluster *cmv1.Cluster
 	existingTaints := make([]*cmv1.Taint, 0)
 	taintBuilders := mpHelpers.GetTaints(cmd, r, existingTaints, args.taints)
 
+	// Windows nodes are labelled and tainted so that only Windows workloads are scheduled on them:
+	rawFields := map[string]interface{}{}
+	if operatingSystem == ocm.OperatingSystemWindows {
+		labelMap = ocm.AddWindowsLabel(labelMap)
+		taintBuilders = ocm.AddWindowsTaint(taintBuilders)
+		rawFields = ocm.WindowsMachinePoolFields()
+	}
+
+	diskSize := getDiskSize(cmd, r)
+	if diskSize != 0 {
+		rawFields["root_volume.aws.size"] = diskSize
+	}
+
 	// Spot instances
 	isSpotSet := cmd.Flags().Changed("use-spot-instances")
 	isSpotMaxPriceSet := cmd.Flags().Changed("spot-max-price")
 
 	useSpotInstances := args.useSpotInstances
 	spotMaxPrice := args.spotMaxPrice
-	if isSpotMaxPriceSet && isSpotSet && !useSpotInstances {
-		r.Reporter.Errorf("Can't set max price when not using spot instances")
-		os.Exit(1)
-	}
 
 	// Validate spot instance are supported
-	var isLocalZone bool
-	if subnet != "" {
-		isLocalZone, err = r.AWSClient.IsLocalAvailabilityZone(availabilityZonesFilter[0])
-		if err != nil {
-			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
-		}
-	}
-	if isLocalZone && useSpotInstances {
-		r.Reporter.Errorf("Spot instances are not supported for local zones")
+	if edgeZone != nil && useSpotInstances {
+		r.Reporter.Errorf("Spot instances are not supported for machine pools in %s", edgeZone)
 		os.Exit(1)
 	}
 
-	if !isSpotSet && !isSpotMaxPriceSet && !isLocalZone && interactive.Enabled() {
+	if !isSpotSet && !isSpotMaxPriceSet && edgeZone == nil && interactive.Enabled() {
 		useSpotInstances, err = interactive.GetBool(interactive.Input{
 			Question: "Use spot instances",
 			Help:     cmd.Flags().Lookup("use-spot-instances").Usage,
@@ -366,7 +514,7 @@ func addMachinePool(cmd *cobra.Command, clusterKey string, cluster *cmv1.Cluster
 			Required: false,
 			Default:  spotMaxPrice,
 			Validators: []interactive.Validator{
-				spotMaxPriceValidator,
+				mpHelpers.SpotMaxPriceValidator,
 			},
 		})
 		if err != nil {
@@ -375,16 +523,48 @@ func addMachinePool(cmd *cobra.Command, clusterKey string, cluster *cmv1.Cluster
 		}
 	}
 
-	var maxPrice *float64
+	if isSpotMaxPriceSet && !useSpotInstances {
+		r.Reporter.Errorf("Can't set max price when not using spot instances")
+		os.Exit(1)
+	}
 
-	err = spotMaxPriceValidator(spotMaxPrice)
+	maxPrice, err := mpHelpers.ParseSpotMaxPrice(spotMaxPrice)
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
 		os.Exit(1)
 	}
-	if spotMaxPrice != "on-demand" {
-		price, _ := strconv.ParseFloat(spotMaxPrice, 64)
-		maxPrice = &price
+
+	securityGroupIds := args.securityGroupIds
+	if isBYOVPC(cluster) && !isSecurityGroupIdsSet && interactive.Enabled() {
+		securityGroupsInput, err := interactive.GetString(interactive.Input{
+			Question: "Additional security group IDs",
+			Help:     cmd.Flags().Lookup("additional-security-group-ids").Usage,
+			Validators: []interactive.Validator{
+				aws.SecurityGroupIDsValidator,
+			},
+		})
+		if err != nil {
+			r.Reporter.Errorf("Expected valid security group IDs: %s", err)
+			os.Exit(1)
+		}
+		securityGroupIds = aws.ParseSecurityGroupIDs(securityGroupsInput)
+	}
+	if len(securityGroupIds) > 0 {
+		err = aws.SecurityGroupIDsValidator(securityGroupIds)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+		vpcSubnets, err := r.AWSClient.GetVPCSubnets(cluster.AWS().SubnetIDs()[0])
+		if err != nil {
+			r.Reporter.Errorf("Failed to get the VPC of cluster '%s': %v", clusterKey, err)
+			os.Exit(1)
+		}
+		err = r.AWSClient.ValidateSecurityGroups(awssdk.StringValue(vpcSubnets[0].VpcId), securityGroupIds)
+		if err != nil {
+			r.Reporter.Errorf("The additional security groups aren't valid: %v", err)
+			os.Exit(1)
+		}
 	}
 
 	mpBuilder := cmv1.NewMachinePool().
@@ -416,18 +596,27 @@ func addMachinePool(cmd *cobra.Command, clusterKey string, cluster *cmv1.Cluster
 		mpBuilder.AvailabilityZones(availabilityZone)
 	}
 
+	// Create a multi-AZ machine pool spanning a subset of the zones of a multi-AZ cluster
+	if cluster.MultiAZ() && multiAZMachinePool && len(availabilityZones) > 0 {
+		mpBuilder.AvailabilityZones(availabilityZones...)
+	}
+
 	// Create a single AZ machine pool for a BYOVPC cluster
 	if subnet != "" {
 		mpBuilder.Subnets(subnet)
 	}
 
+	if len(securityGroupIds) > 0 {
+		mpBuilder.SecurityGroupFilters(ocm.SecurityGroupFilters(securityGroupIds)...)
+	}
+
 	machinePool, err := mpBuilder.Build()
 	if err != nil {
 		r.Reporter.Errorf("Failed to create machine pool for cluster '%s': %v", clusterKey, err)
 		os.Exit(1)
 	}
 
-	createdMachinePool, err := r.OCMClient.CreateMachinePool(cluster.ID(), machinePool)
+	createdMachinePool, err := r.OCMClient.CreateMachinePoolWithFields(cluster.ID(), machinePool, rawFields)
 	if err != nil {
 		r.Reporter.Errorf("Failed to add machine pool to cluster '%s': %v", clusterKey, err)
 		os.Exit(1)
@@ -450,12 +639,18 @@ func Split(r rune) bool {
 
 // getMachinePoolAvailabilityZones derives the availability zone from the user input or the cluster spec
 func getMachinePoolAvailabilityZones(r *rosa.Runtime, cluster *cmv1.Cluster, multiAZMachinePool bool,
-	availabilityZoneUserInput string, subnetUserInput string) ([]string, error) {
+	availabilityZoneUserInput string, availabilityZonesUserInput []string,
+	subnetUserInput string) ([]string, error) {
 	// Single AZ machine pool for a multi-AZ cluster
 	if cluster.MultiAZ() && !multiAZMachinePool && availabilityZoneUserInput != "" {
 		return []string{availabilityZoneUserInput}, nil
 	}
 
+	// Multi-AZ machine pool spanning the selected zones of a multi-AZ cluster
+	if cluster.MultiAZ() && multiAZMachinePool && len(availabilityZonesUserInput) > 0 {
+		return availabilityZonesUserInput, nil
+	}
+
 	// Single AZ machine pool for a BYOVPC cluster
 	if subnetUserInput != "" {
 		availabilityZone, err := r.AWSClient.GetSubnetAvailabilityZone(subnetUserInput)
@@ -470,7 +665,27 @@ func getMachinePoolAvailabilityZones(r *rosa.Runtime, cluster *cmv1.Cluster, mul
 	return cluster.Nodes().AvailabilityZones(), nil
 }
 
-func minReplicaValidator(multiAZMachinePool bool) interactive.Validator {
+// validateAvailabilityZones checks that the zones selected for a multi-AZ machine pool are at least
+// two distinct zones of the cluster.
+func validateAvailabilityZones(availabilityZones []string, clusterAvailabilityZones []string) error {
+	if len(availabilityZones) < 2 {
+		return fmt.Errorf("A multi-AZ machine pool requires at least two availability zones")
+	}
+	seen := map[string]bool{}
+	for _, availabilityZone := range availabilityZones {
+		if seen[availabilityZone] {
+			return fmt.Errorf("Availability zone '%s' is duplicated", availabilityZone)
+		}
+		seen[availabilityZone] = true
+		if !helper.Contains(clusterAvailabilityZones, availabilityZone) {
+			return fmt.Errorf("Availability zone '%s' doesn't belong to the cluster's availability zones",
+				availabilityZone)
+		}
+	}
+	return nil
+}
+
+func minReplicaValidator(zoneCount int) interactive.Validator {
 	return func(val interface{}) error {
 		minReplicas, err := strconv.Atoi(fmt.Sprintf("%v", val))
 		if err != nil {
@@ -479,14 +694,11 @@ func minReplicaValidator(multiAZMachinePool bool) interactive.Validator {
 		if minReplicas < 0 {
 			return fmt.Errorf("min-replicas must be a non-negative integer")
 		}
-		if multiAZMachinePool && minReplicas%3 != 0 {
-			return fmt.Errorf("Multi AZ clusters require that the replicas be a multiple of 3")
-		}
-		return nil
+		return validateReplicasZoneBalance(minReplicas, zoneCount)
 	}
 }
 
-func maxReplicaValidator(minReplicas int, multiAZMachinePool bool) interactive.Validator {
+func maxReplicaValidator(minReplicas int, zoneCount int) interactive.Validator {
 	return func(val interface{}) error {
 		maxReplicas, err := strconv.Atoi(fmt.Sprintf("%v", val))
 		if err != nil {
@@ -495,25 +707,16 @@ func maxReplicaValidator(minReplicas int, multiAZMachinePool bool) interactive.V
 		if minReplicas > maxReplicas {
 			return fmt.Errorf("max-replicas must be greater or equal to min-replicas")
 		}
-		if multiAZMachinePool && maxReplicas%3 != 0 {
-			return fmt.Errorf("Multi AZ clusters require that the replicas be a multiple of 3")
-		}
-		return nil
+		return validateReplicasZoneBalance(maxReplicas, zoneCount)
 	}
 }
 
-func spotMaxPriceValidator(val interface{}) error {
-	spotMaxPrice := fmt.Sprintf("%v", val)
-	if spotMaxPrice == "on-demand" {
-		return nil
-	}
-	price, err := strconv.ParseFloat(spotMaxPrice, 64)
-	if err != nil {
-		return fmt.Errorf("Expected a numeric value for spot max price")
-	}
-
-	if price <= 0 {
-		return fmt.Errorf("Spot max price must be positive")
+// validateReplicasZoneBalance checks that the replicas can be spread evenly across the availability
+// zones of a multi-AZ machine pool.
+func validateReplicasZoneBalance(replicas int, zoneCount int) error {
+	if zoneCount > 1 && replicas%zoneCount != 0 {
+		return fmt.Errorf("Multi AZ machine pools require that the replicas be a multiple of %d, "+
+			"the number of availability zones", zoneCount)
 	}
 	return nil
 }
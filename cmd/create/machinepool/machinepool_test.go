@@ -0,0 +1,47 @@
+package machinepool
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Multi-AZ machine pools", func() {
+	clusterZones := []string{"us-east-1a", "us-east-1b", "us-east-1c"}
+
+	Context("validateAvailabilityZones", func() {
+		It("Accepts a subset of the cluster zones", func() {
+			Expect(validateAvailabilityZones([]string{"us-east-1a", "us-east-1c"}, clusterZones)).To(Succeed())
+		})
+
+		It("Rejects a single zone", func() {
+			err := validateAvailabilityZones([]string{"us-east-1a"}, clusterZones)
+			Expect(err).To(MatchError("A multi-AZ machine pool requires at least two availability zones"))
+		})
+
+		It("Rejects duplicated zones", func() {
+			err := validateAvailabilityZones([]string{"us-east-1a", "us-east-1a"}, clusterZones)
+			Expect(err).To(MatchError("Availability zone 'us-east-1a' is duplicated"))
+		})
+
+		It("Rejects zones outside of the cluster", func() {
+			err := validateAvailabilityZones([]string{"us-east-1a", "us-east-1d"}, clusterZones)
+			Expect(err).To(MatchError(
+				"Availability zone 'us-east-1d' doesn't belong to the cluster's availability zones"))
+		})
+	})
+
+	Context("replica validators", func() {
+		It("Requires replicas divisible by the number of zones", func() {
+			Expect(minReplicaValidator(2)(4)).To(Succeed())
+			Expect(minReplicaValidator(2)(3)).To(MatchError(
+				"Multi AZ machine pools require that the replicas be a multiple of 2, the number of availability zones"))
+			Expect(maxReplicaValidator(3, 3)(6)).To(Succeed())
+			Expect(maxReplicaValidator(3, 3)(5)).ToNot(Succeed())
+		})
+
+		It("Doesn't balance replicas of single AZ machine pools", func() {
+			Expect(minReplicaValidator(1)(5)).To(Succeed())
+			Expect(maxReplicaValidator(1, 1)(7)).To(Succeed())
+		})
+	})
+})
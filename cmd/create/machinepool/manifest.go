@@ -0,0 +1,311 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinepool
+
+import (
+	"fmt"
+	"os"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/errors"
+
+	mpHelpers "github.com/openshift/rosa/pkg/helper/machinepools"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+const defaultInstanceType = "m5.xlarge"
+
+// Options that describe a single machine pool and can't be combined with a manifest file.
+var machinePoolFlags = []string{
+	"name", "replicas", "enable-autoscaling", "min-replicas", "max-replicas", "instance-type",
+	"labels", "taints", "use-spot-instances", "spot-max-price", "multi-availability-zone",
+	"availability-zone", "subnet", "version", "autorepair", "autoupgrade",
+}
+
+// addMachinePoolsFromFile creates or updates all the machine pools described in the manifest file.
+// Every entry is validated before any change is submitted.
+func addMachinePoolsFromFile(cmd *cobra.Command, clusterKey string, cluster *cmv1.Cluster, r *rosa.Runtime) {
+	for _, flag := range machinePoolFlags {
+		if cmd.Flags().Changed(flag) {
+			r.Reporter.Errorf("Option '--%s' can't be used together with '--from-file'", flag)
+			os.Exit(1)
+		}
+	}
+
+	manifest, err := mpHelpers.LoadManifest(args.fromFile)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+	hostedCP := cluster.Hypershift().Enabled()
+	err = manifest.Validate(hostedCP, cluster.MultiAZ())
+	if err != nil {
+		r.Reporter.Errorf("Invalid manifest file '%s': %v", args.fromFile, err)
+		os.Exit(1)
+	}
+
+	existing, err := getExistingInstanceTypes(r, cluster)
+	if err != nil {
+		r.Reporter.Errorf("Failed to get machine pools for cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+
+	r.Reporter.Debugf("Fetching instance types")
+	instanceTypeList, err := r.OCMClient.GetAvailableMachineTypesInRegion(cluster.Region().ID(),
+		cluster.Nodes().AvailabilityZones(), cluster.AWS().STS().RoleARN(), r.AWSClient)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+
+	var errs []error
+	for _, entry := range manifest.MachinePools {
+		currentInstanceType, ok := existing[entry.Name]
+		if ok {
+			if entry.InstanceType != "" && entry.InstanceType != currentInstanceType {
+				errs = append(errs, fmt.Errorf("Machine pool '%s': instance type can't be changed from '%s' to '%s'",
+					entry.Name, currentInstanceType, entry.InstanceType))
+			}
+			continue
+		}
+		if entry.InstanceType == "" {
+			entry.InstanceType = defaultInstanceType
+		}
+		err = instanceTypeList.ValidateMachineType(entry.InstanceType, cluster.MultiAZ())
+		if err != nil {
+			errs = append(errs, fmt.Errorf("Machine pool '%s': %v", entry.Name, err))
+		}
+		// Node pools are placed in a subnet, so the availability zone is resolved to its subnet
+		// here, failing before any machine pool is created when there is no such subnet:
+		if hostedCP && entry.AvailabilityZone != "" {
+			entry.Subnet, err = getSubnetForAvailabilityZone(r, cluster, entry.AvailabilityZone)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("Machine pool '%s': %v", entry.Name, err))
+			}
+		}
+	}
+	if len(errs) > 0 {
+		r.Reporter.Errorf("Invalid manifest file '%s': %v", args.fromFile, errors.NewAggregate(errs))
+		os.Exit(1)
+	}
+
+	var machinePools []*cmv1.MachinePool
+	var nodePools []*cmv1.NodePool
+	for _, entry := range manifest.MachinePools {
+		_, update := existing[entry.Name]
+		if update {
+			warnIgnoredOnUpdate(r, entry)
+		}
+		if hostedCP {
+			nodePool, err := applyNodePoolEntry(r, cluster, entry, update)
+			if err != nil {
+				r.Reporter.Errorf("Failed to apply machine pool '%s' to hosted cluster '%s': %v",
+					entry.Name, clusterKey, err)
+				os.Exit(1)
+			}
+			nodePools = append(nodePools, nodePool)
+		} else {
+			machinePool, err := applyMachinePoolEntry(r, cluster, entry, update)
+			if err != nil {
+				r.Reporter.Errorf("Failed to apply machine pool '%s' to cluster '%s': %v",
+					entry.Name, clusterKey, err)
+				os.Exit(1)
+			}
+			machinePools = append(machinePools, machinePool)
+		}
+		if !output.HasFlag() {
+			if update {
+				r.Reporter.Infof("Machine pool '%s' updated successfully on cluster '%s'", entry.Name, clusterKey)
+			} else {
+				r.Reporter.Infof("Machine pool '%s' created successfully on cluster '%s'", entry.Name, clusterKey)
+			}
+		}
+	}
+
+	if output.HasFlag() {
+		if hostedCP {
+			err = output.Print(nodePools)
+		} else {
+			err = output.Print(machinePools)
+		}
+		if err != nil {
+			r.Reporter.Errorf("Unable to print machine pools: %v", err)
+			os.Exit(1)
+		}
+		return
+	}
+	r.Reporter.Infof("To view all machine pools, run 'rosa list machinepools -c %s'", clusterKey)
+}
+
+// getExistingInstanceTypes returns the instance types of the machine pools of the cluster, indexed
+// by machine pool name.
+func getExistingInstanceTypes(r *rosa.Runtime, cluster *cmv1.Cluster) (map[string]string, error) {
+	existing := map[string]string{}
+	if cluster.Hypershift().Enabled() {
+		nodePools, err := r.OCMClient.GetNodePools(cluster.ID())
+		if err != nil {
+			return nil, err
+		}
+		for _, nodePool := range nodePools {
+			existing[nodePool.ID()] = nodePool.AWSNodePool().InstanceType()
+		}
+		return existing, nil
+	}
+	machinePools, err := r.OCMClient.GetMachinePools(cluster.ID())
+	if err != nil {
+		return nil, err
+	}
+	for _, machinePool := range machinePools {
+		existing[machinePool.ID()] = machinePool.InstanceType()
+	}
+	return existing, nil
+}
+
+func warnIgnoredOnUpdate(r *rosa.Runtime, entry *mpHelpers.ManifestEntry) {
+	if entry.AvailabilityZone != "" || entry.Subnet != "" || entry.UseSpotInstances ||
+		entry.Version != "" || entry.Autorepair != nil {
+		r.Reporter.Warnf("Machine pool '%s' already exists, only its replicas, autoscaling, labels "+
+			"and taints will be updated", entry.Name)
+	}
+}
+
+func applyMachinePoolEntry(r *rosa.Runtime, cluster *cmv1.Cluster, entry *mpHelpers.ManifestEntry,
+	update bool) (*cmv1.MachinePool, error) {
+	mpBuilder := cmv1.NewMachinePool().
+		ID(entry.Name)
+	// Labels and taints that the entry doesn't define are kept when updating:
+	if len(entry.Labels) > 0 || !update {
+		mpBuilder = mpBuilder.Labels(entry.Labels)
+	}
+	if len(entry.Taints) > 0 || !update {
+		mpBuilder = mpBuilder.Taints(entry.TaintBuilders()...)
+	}
+	if entry.Autoscaling != nil {
+		mpBuilder = mpBuilder.Autoscaling(
+			cmv1.NewMachinePoolAutoscaling().
+				MinReplicas(entry.Autoscaling.MinReplicas).
+				MaxReplicas(entry.Autoscaling.MaxReplicas))
+	} else {
+		mpBuilder = mpBuilder.Replicas(*entry.Replicas)
+	}
+
+	if !update {
+		mpBuilder = mpBuilder.InstanceType(entry.InstanceType)
+		if entry.UseSpotInstances {
+			spotBuilder := cmv1.NewAWSSpotMarketOptions()
+			// The price was already validated together with the rest of the manifest:
+			maxPrice, _ := mpHelpers.ParseSpotMaxPrice(entry.SpotMaxPrice)
+			if maxPrice != nil {
+				spotBuilder = spotBuilder.MaxPrice(*maxPrice)
+			}
+			mpBuilder = mpBuilder.AWS(cmv1.NewAWSMachinePool().
+				SpotMarketOptions(spotBuilder))
+		}
+		if entry.AvailabilityZone != "" {
+			mpBuilder = mpBuilder.AvailabilityZones(entry.AvailabilityZone)
+		}
+		if entry.Subnet != "" {
+			mpBuilder = mpBuilder.Subnets(entry.Subnet)
+		}
+	}
+
+	machinePool, err := mpBuilder.Build()
+	if err != nil {
+		return nil, err
+	}
+	if update {
+		return r.OCMClient.UpdateMachinePool(cluster.ID(), machinePool)
+	}
+	return r.OCMClient.CreateMachinePool(cluster.ID(), machinePool)
+}
+
+func applyNodePoolEntry(r *rosa.Runtime, cluster *cmv1.Cluster, entry *mpHelpers.ManifestEntry,
+	update bool) (*cmv1.NodePool, error) {
+	npBuilder := cmv1.NewNodePool().
+		ID(entry.Name)
+	// Labels and taints that the entry doesn't define are kept when updating:
+	if len(entry.Labels) > 0 || !update {
+		npBuilder = npBuilder.Labels(entry.Labels)
+	}
+	if len(entry.Taints) > 0 || !update {
+		npBuilder = npBuilder.Taints(entry.TaintBuilders()...)
+	}
+	if entry.Autoscaling != nil {
+		npBuilder = npBuilder.Autoscaling(
+			cmv1.NewNodePoolAutoscaling().
+				MinReplica(entry.Autoscaling.MinReplicas).
+				MaxReplica(entry.Autoscaling.MaxReplicas))
+	} else {
+		npBuilder = npBuilder.Replicas(*entry.Replicas)
+	}
+
+	if !update {
+		npBuilder = npBuilder.AWSNodePool(cmv1.NewAWSNodePool().InstanceType(entry.InstanceType))
+		// The subnet of the availability zone was already resolved when validating the manifest:
+		if entry.Subnet != "" {
+			npBuilder = npBuilder.Subnet(entry.Subnet)
+		}
+		if entry.Version != "" {
+			npBuilder = npBuilder.Version(cmv1.NewVersion().ID(entry.Version))
+		}
+		autorepair := true
+		if entry.Autorepair != nil {
+			autorepair = *entry.Autorepair
+		}
+		npBuilder = npBuilder.AutoRepair(autorepair)
+	}
+
+	nodePool, err := npBuilder.Build()
+	if err != nil {
+		return nil, err
+	}
+	if update {
+		return r.OCMClient.UpdateNodePool(cluster.ID(), nodePool)
+	}
+	return r.OCMClient.CreateNodePool(cluster.ID(), nodePool)
+}
+
+// getSubnetForAvailabilityZone returns the only private subnet of the cluster VPC in the given
+// availability zone. Manifests can't prompt, so several candidates are an error.
+func getSubnetForAvailabilityZone(r *rosa.Runtime, cluster *cmv1.Cluster, availabilityZone string) (string, error) {
+	clusterSubnets := cluster.AWS().SubnetIDs()
+	if len(clusterSubnets) == 0 {
+		return "", fmt.Errorf("Cluster '%s' doesn't use an existing VPC, use 'subnet' to select the "+
+			"subnet for availability zone '%s'", cluster.Name(), availabilityZone)
+	}
+	privateSubnets, err := r.AWSClient.GetVPCPrivateSubnets(clusterSubnets[0])
+	if err != nil {
+		return "", err
+	}
+	var subnets []string
+	for _, privateSubnet := range privateSubnets {
+		if *privateSubnet.AvailabilityZone == availabilityZone {
+			subnets = append(subnets, *privateSubnet.SubnetId)
+		}
+	}
+	switch len(subnets) {
+	case 0:
+		return "", fmt.Errorf("Failed to find a private subnet for '%s' availability zone", availabilityZone)
+	case 1:
+		return subnets[0], nil
+	default:
+		return "", fmt.Errorf("There are several subnets for availability zone '%s', use 'subnet' to "+
+			"select one of %v", availabilityZone, subnets)
+	}
+}
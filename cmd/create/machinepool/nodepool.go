@@ -14,13 +14,25 @@ import (
 	mpHelpers "github.com/openshift/rosa/pkg/helper/machinepools"
 	"github.com/openshift/rosa/pkg/helper/versions"
 	"github.com/openshift/rosa/pkg/interactive"
+	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/output"
 	"github.com/openshift/rosa/pkg/rosa"
+	"github.com/openshift/rosa/pkg/wait"
 )
 
 func addNodePool(cmd *cobra.Command, clusterKey string, cluster *cmv1.Cluster, r *rosa.Runtime) {
 	var err error
 
+	if cmd.Flags().Changed("use-spot-instances") || cmd.Flags().Changed("spot-max-price") {
+		r.Reporter.Errorf("Spot instances are not supported for Hosted Control Plane machine pools")
+		os.Exit(1)
+	}
+
+	if cmd.Flags().Changed("additional-security-group-ids") {
+		r.Reporter.Errorf("Additional security groups are not supported for Hosted Control Plane machine pools")
+		os.Exit(1)
+	}
+
 	isAvailabilityZoneSet := cmd.Flags().Changed("availability-zone")
 	isSubnetSet := cmd.Flags().Changed("subnet")
 	if isSubnetSet && isAvailabilityZoneSet {
@@ -29,6 +41,12 @@ func addNodePool(cmd *cobra.Command, clusterKey string, cluster *cmv1.Cluster, r
 		os.Exit(1)
 	}
 
+	if args.operatingSystem != ocm.OperatingSystemLinux {
+		r.Reporter.Errorf("Only '%s' machine pools are supported on Hosted Control Plane clusters",
+			ocm.OperatingSystemLinux)
+		os.Exit(1)
+	}
+
 	// Machine pool name:
 	name := strings.Trim(args.name, " \t")
 	if name == "" && !interactive.Enabled() {
@@ -263,15 +281,16 @@ func addNodePool(cmd *cobra.Command, clusterKey string, cluster *cmv1.Cluster, r
 	if spin != nil {
 		spin.Stop()
 	}
+	supportedInstanceTypeList := instanceTypeList.FilterHostedCPSupported()
 
 	if interactive.Enabled() {
-		if instanceType == "" {
-			instanceType = instanceTypeList[0].MachineType.ID()
+		if instanceType == "" && len(supportedInstanceTypeList) > 0 {
+			instanceType = supportedInstanceTypeList[0].MachineType.ID()
 		}
 		instanceType, err = interactive.GetOption(interactive.Input{
 			Question: "Instance type",
 			Help:     cmd.Flags().Lookup("instance-type").Usage,
-			Options:  instanceTypeList.GetAvailableIDs(cluster.MultiAZ()),
+			Options:  supportedInstanceTypeList.GetAvailableIDs(cluster.MultiAZ()),
 			Default:  instanceType,
 			Required: true,
 		})
@@ -284,11 +303,32 @@ func addNodePool(cmd *cobra.Command, clusterKey string, cluster *cmv1.Cluster, r
 		r.Reporter.Errorf("Expected a valid machine type")
 		os.Exit(1)
 	}
+	err = ocm.ValidateHostedCPMachineType(instanceType)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
 	err = instanceTypeList.ValidateMachineType(instanceType, cluster.MultiAZ())
 	if err != nil {
 		r.Reporter.Errorf("Expected a valid machine type: %s", err)
 		os.Exit(1)
 	}
+	err = ocm.ValidateMachineTypeArchitecture(cluster, instanceType)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+	if machineType := instanceTypeList.Find(instanceType); machineType != nil {
+		nodes := replicas
+		if autoscaling {
+			nodes = maxReplicas
+		}
+		err = r.AWSClient.ValidateInstanceQuota(map[string]int{instanceType: nodes * machineType.CPUs()})
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+	}
 
 	autorepair := args.autorepair
 	if interactive.Enabled() {
@@ -306,6 +346,112 @@ func addNodePool(cmd *cobra.Command, clusterKey string, cluster *cmv1.Cluster, r
 
 	npBuilder.AutoRepair(autorepair)
 
+	autoupgrade := args.autoupgrade
+	if interactive.Enabled() {
+		autoupgrade, err = interactive.GetBool(interactive.Input{
+			Question: "Autoupgrade",
+			Help:     cmd.Flags().Lookup("autoupgrade").Usage,
+			Default:  autoupgrade,
+			Required: false,
+		})
+		if err != nil {
+			r.Reporter.Errorf("Expected a valid value for autoupgrade: %s", err)
+			os.Exit(1)
+		}
+	}
+
+	// Settings that the typed client of the SDK doesn't support yet:
+	rawFields := map[string]interface{}{}
+
+	ec2MetadataHttpTokens := args.ec2MetadataHttpTokens
+	if interactive.Enabled() {
+		defaultHttpTokens := ec2MetadataHttpTokens
+		if defaultHttpTokens == "" {
+			defaultHttpTokens = ocm.Ec2MetadataHttpTokensOptional
+		}
+		ec2MetadataHttpTokens, err = interactive.GetOption(interactive.Input{
+			Question: "EC2 metadata HTTP tokens",
+			Help:     cmd.Flags().Lookup("ec2-metadata-http-tokens").Usage,
+			Options:  ocm.Ec2MetadataHttpTokensValues,
+			Default:  defaultHttpTokens,
+			Required: true,
+		})
+		if err != nil {
+			r.Reporter.Errorf("Expected a valid EC2 metadata HTTP tokens value: %v", err)
+			os.Exit(1)
+		}
+	}
+	nodePoolVersion := version
+	if nodePoolVersion == "" {
+		nodePoolVersion = cluster.Version().RawID()
+	}
+	err = ocm.ValidateEc2MetadataHttpTokens(ec2MetadataHttpTokens, nodePoolVersion)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+	if ec2MetadataHttpTokens != "" {
+		rawFields["aws_node_pool.ec2_metadata_http_tokens"] = ec2MetadataHttpTokens
+	}
+
+	if len(args.kubeletConfigs) > 0 {
+		configs, err := r.OCMClient.GetKubeletConfigs(cluster)
+		if err != nil {
+			r.Reporter.Errorf("Failed to get kubelet configs for hosted cluster '%s': %v", clusterKey, err)
+			os.Exit(1)
+		}
+		err = ocm.ValidateKubeletConfigNames(configs, args.kubeletConfigs)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+		rawFields["kubelet_configs"] = args.kubeletConfigs
+	}
+
+	if len(args.tuningConfigs) > 0 {
+		configs, err := r.OCMClient.GetTuningConfigs(cluster.ID())
+		if err != nil {
+			r.Reporter.Errorf("Failed to get tuning configs for hosted cluster '%s': %v", clusterKey, err)
+			os.Exit(1)
+		}
+		err = ocm.ValidateTuningConfigNames(configs, args.tuningConfigs)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+		rawFields["tuning_configs"] = args.tuningConfigs
+	}
+
+	diskSize := getDiskSize(cmd, r)
+	if diskSize != 0 {
+		rawFields["aws_node_pool.root_volume.size"] = diskSize
+	}
+
+	nodeDrainGracePeriod := args.nodeDrainGracePeriod
+	if interactive.Enabled() {
+		nodeDrainGracePeriod, err = interactive.GetString(interactive.Input{
+			Question: "Node drain grace period",
+			Help:     cmd.Flags().Lookup("node-drain-grace-period").Usage,
+			Default:  nodeDrainGracePeriod,
+			Validators: []interactive.Validator{
+				ocm.NodePoolNodeDrainGracePeriodValidator,
+			},
+		})
+		if err != nil {
+			r.Reporter.Errorf("Expected a valid node drain grace period: %s", err)
+			os.Exit(1)
+		}
+	}
+	if nodeDrainGracePeriod != "" {
+		minutes, err := ocm.ParseNodePoolNodeDrainGracePeriod(nodeDrainGracePeriod)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+		rawFields["node_drain_grace_period.value"] = minutes
+		rawFields["node_drain_grace_period.unit"] = "minutes"
+	}
+
 	npBuilder.AWSNodePool(cmv1.NewAWSNodePool().InstanceType(instanceType))
 
 	if version != "" {
@@ -318,21 +464,61 @@ func addNodePool(cmd *cobra.Command, clusterKey string, cluster *cmv1.Cluster, r
 		os.Exit(1)
 	}
 
-	createdNodePool, err := r.OCMClient.CreateNodePool(cluster.ID(), nodePool)
+	createdNodePool, err := r.OCMClient.CreateNodePoolWithFields(cluster.ID(), nodePool, rawFields)
 	if err != nil {
 		r.Reporter.Errorf("Failed to add machine pool to hosted cluster '%s': %v", clusterKey, err)
 		os.Exit(1)
 	}
 
+	if autoupgrade {
+		err = r.OCMClient.UpdateNodePoolAutoUpgrade(cluster.ID(), createdNodePool.ID(), true)
+		if err != nil {
+			r.Reporter.Errorf("Failed to enable autoupgrade of machine pool '%s' on hosted cluster '%s': %v",
+				createdNodePool.ID(), clusterKey, err)
+			os.Exit(1)
+		}
+	}
+
+	var waitErr error
+	if args.wait.Enabled {
+		if !output.HasFlag() {
+			r.Reporter.Infof("Waiting up to %s for the nodes of machine pool '%s' to be ready",
+				args.wait.Timeout, createdNodePool.ID())
+		}
+		createdNodePool, waitErr = waitForNodePool(r, cluster.ID(), createdNodePool)
+	}
+
 	if output.HasFlag() {
 		if err = output.Print(createdNodePool); err != nil {
 			r.Reporter.Errorf("Unable to print machine pool: %v", err)
 			os.Exit(1)
 		}
-	} else {
+	} else if waitErr == nil {
 		r.Reporter.Infof("Machine pool '%s' created successfully on hosted cluster '%s'", createdNodePool.ID(), clusterKey)
 		r.Reporter.Infof("To view all machine pools, run 'rosa list machinepools -c %s'", clusterKey)
 	}
+	if waitErr != nil {
+		r.Reporter.Errorf("Machine pool '%s' isn't ready: %v", createdNodePool.ID(), waitErr)
+		os.Exit(wait.ExitCode(waitErr))
+	}
+}
+
+// waitForNodePool waits until the node pool has at least the requested number of replicas, and
+// returns its last known state.
+func waitForNodePool(r *rosa.Runtime, clusterID string, nodePool *cmv1.NodePool) (*cmv1.NodePool, error) {
+	desired := nodePool.Replicas()
+	if nodePool.Autoscaling() != nil {
+		desired = nodePool.Autoscaling().MinReplica()
+	}
+	err := args.wait.Poll(waitInterval, func() (bool, error) {
+		current, err := r.OCMClient.GetNodePool(clusterID, nodePool.ID())
+		if err != nil {
+			return false, wait.Transient(err)
+		}
+		nodePool = current
+		return nodePool.Status().CurrentReplicas() >= desired, nil
+	})
+	return nodePool, err
 }
 
 func getSubnetFromAvailabilityZone(cmd *cobra.Command, r *rosa.Runtime, isAvailabilityZoneSet bool,
@@ -0,0 +1,203 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/briandowns/spinner"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/interactive"
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var Cmd = &cobra.Command{
+	Use:     "network",
+	Aliases: []string{"vpc"},
+	Short:   "Create a VPC ready to install clusters",
+	Long: "Create a VPC with a public and a private subnet in each availability zone, NAT gateways " +
+		"and an S3 endpoint, using a CloudFormation stack. The subnets can be used to install clusters " +
+		"with the '--subnet-ids' option of 'rosa create cluster'.",
+	Example: `  # Create a VPC in a single availability zone
+  rosa create network
+
+  # Create a VPC in three availability zones with a custom CIDR block
+  rosa create network --name my-network --multi-az --vpc-cidr 10.1.0.0/16`,
+	Run: run,
+}
+
+// The default machine CIDR of clusters, so that clusters don't need to set it:
+const defaultVpcCIDR = "10.0.0.0/16"
+
+var args struct {
+	name    string
+	vpcCIDR net.IPNet
+	multiAZ bool
+	tags    []string
+}
+
+func init() {
+	flags := Cmd.Flags()
+
+	flags.StringVar(
+		&args.name,
+		"name",
+		aws.DefaultNetworkName,
+		"Name of the CloudFormation stack that creates the network.",
+	)
+
+	_, vpcCIDR, _ := net.ParseCIDR(defaultVpcCIDR)
+	flags.IPNetVar(
+		&args.vpcCIDR,
+		"vpc-cidr",
+		*vpcCIDR,
+		"CIDR block of the VPC. Use the same value for the '--machine-cidr' option when creating clusters.",
+	)
+
+	flags.BoolVar(
+		&args.multiAZ,
+		"multi-az",
+		false,
+		"Create subnets in three availability zones, as required by multi-AZ clusters.",
+	)
+
+	flags.StringSliceVar(
+		&args.tags,
+		"tags",
+		nil,
+		"Apply user defined tags to the resources of the network created by ROSA in AWS. "+
+			"Tags are comma separated, for example: --tags=foo:bar,bar:baz",
+	)
+
+	output.AddFlag(Cmd)
+	confirm.AddFlag(flags)
+	interactive.AddFlag(flags)
+}
+
+func run(cmd *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS()
+	defer r.Cleanup()
+
+	var err error
+	name := args.name
+	if interactive.Enabled() {
+		name, err = interactive.GetString(interactive.Input{
+			Question: "Network name",
+			Help:     cmd.Flags().Lookup("name").Usage,
+			Default:  name,
+			Required: true,
+		})
+		if err != nil {
+			r.Reporter.Errorf("Expected a valid network name: %s", err)
+			os.Exit(1)
+		}
+	}
+
+	vpcCIDR := args.vpcCIDR
+	if interactive.Enabled() {
+		vpcCIDR, err = interactive.GetIPNet(interactive.Input{
+			Question: "VPC CIDR",
+			Help:     cmd.Flags().Lookup("vpc-cidr").Usage,
+			Default:  vpcCIDR,
+		})
+		if err != nil {
+			r.Reporter.Errorf("Expected a valid CIDR value: %s", err)
+			os.Exit(1)
+		}
+	}
+	_, err = aws.GetNetworkSubnetCidrBits(vpcCIDR)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+
+	multiAZ := args.multiAZ
+	if interactive.Enabled() {
+		multiAZ, err = interactive.GetBool(interactive.Input{
+			Question: "Multiple availability zones",
+			Help:     cmd.Flags().Lookup("multi-az").Usage,
+			Default:  multiAZ,
+		})
+		if err != nil {
+			r.Reporter.Errorf("Expected a valid multi-AZ value: %s", err)
+			os.Exit(1)
+		}
+	}
+
+	userTags := interactive.GetUserTags(r, cmd, args.tags)
+
+	if !confirm.Prompt(true, "Create network '%s' in region '%s'?", name, r.AWSClient.GetRegion()) {
+		os.Exit(0)
+	}
+
+	var spin *spinner.Spinner
+	if r.Reporter.IsTerminal() && !output.HasFlag() {
+		r.Reporter.Infof("Creating network '%s', this usually takes a few minutes", name)
+		spin = spinner.New(spinner.CharSets[9], 100*time.Millisecond)
+		spin.Start()
+	}
+	network, err := r.AWSClient.CreateNetworkStack(aws.NetworkStackInput{
+		Name:     name,
+		VpcCIDR:  vpcCIDR,
+		MultiAZ:  multiAZ,
+		UserTags: userTags,
+	})
+	if spin != nil {
+		spin.Stop()
+	}
+	if err != nil {
+		r.Reporter.Errorf("Failed to create network '%s': %v", name, err)
+		r.Reporter.Infof("To clean up the partially created resources, run 'rosa delete network --name %s'", name)
+		os.Exit(1)
+	}
+
+	if output.HasFlag() {
+		err = output.Print(network)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	r.Reporter.Infof("Network '%s' created successfully", name)
+	fmt.Printf("\n"+
+		"VPC:                        %s\n"+
+		"Public subnets:             %s\n"+
+		"Private subnets:            %s\n"+
+		"\n",
+		network.VpcID,
+		strings.Join(network.PublicSubnetIDs, ", "),
+		strings.Join(network.PrivateSubnetIDs, ", "),
+	)
+	createCluster := fmt.Sprintf("rosa create cluster --subnet-ids %s", strings.Join(network.SubnetIDs(), ","))
+	if multiAZ {
+		createCluster += " --multi-az"
+	}
+	if vpcCIDR.String() != defaultVpcCIDR {
+		createCluster += fmt.Sprintf(" --machine-cidr %s", vpcCIDR.String())
+	}
+	r.Reporter.Infof("To create a cluster in this network, run '%s'", createCluster)
+}
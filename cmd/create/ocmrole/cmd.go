@@ -188,7 +188,7 @@ func run(cmd *cobra.Command, argv []string) {
 			Help:     cmd.Flags().Lookup("permissions-boundary").Usage,
 			Default:  permissionsBoundary,
 			Validators: []interactive.Validator{
-				aws.ARNValidator,
+				aws.PermissionsBoundaryValidator,
 			},
 		})
 		if err != nil {
@@ -197,12 +197,10 @@ func run(cmd *cobra.Command, argv []string) {
 		}
 	}
 
-	if permissionsBoundary != "" {
-		err = aws.ARNValidator(permissionsBoundary)
-		if err != nil {
-			r.Reporter.Errorf("Expected a valid policy ARN for permissions boundary: %s", err)
-			os.Exit(1)
-		}
+	err = aws.ValidatePermissionsBoundary(r.AWSClient, permissionsBoundary)
+	if err != nil {
+		r.Reporter.Errorf("Expected a valid policy ARN for permissions boundary: %s", err)
+		os.Exit(1)
 	}
 
 	path := args.path
@@ -32,6 +32,7 @@ import (
 	"fmt"
 	"os"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 
@@ -59,6 +60,7 @@ var args struct {
 	userPrefix       string
 	managed          bool
 	installerRoleArn string
+	tags             []string
 }
 
 var Cmd = &cobra.Command{
@@ -119,6 +121,14 @@ func init() {
 		"STS Role ARN with get secrets permission.",
 	)
 
+	flags.StringSliceVar(
+		&args.tags,
+		"tags",
+		nil,
+		"Apply user defined tags to the S3 bucket, secret and OIDC provider created by ROSA in AWS. "+
+			"Tags are comma separated, for example: --tags=foo:bar,bar:baz",
+	)
+
 	aws.AddModeFlag(Cmd)
 
 	confirm.AddFlag(flags)
@@ -258,7 +268,10 @@ func run(cmd *cobra.Command, argv []string) {
 		}
 	}
 
+	userTags := interactive.GetUserTags(r, cmd, args.tags)
+
 	oidcConfigInput := buildOidcConfigInput(r)
+	oidcConfigInput.UserTags = userTags
 	oidcConfigStrategy, err := getOidcConfigStrategy(mode, &oidcConfigInput)
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
@@ -266,6 +279,13 @@ func run(cmd *cobra.Command, argv []string) {
 	}
 	oidcConfigStrategy.execute(r)
 	if !args.rawFiles {
+		if len(userTags) > 0 {
+			err = oidcprovider.Cmd.Flags().Set("tags", joinUserTags(userTags))
+			if err != nil {
+				r.Reporter.Errorf("%s", err)
+				os.Exit(1)
+			}
+		}
 		oidcprovider.Cmd.Run(oidcprovider.Cmd, []string{"", mode, oidcConfigInput.IssuerUrl})
 	}
 }
@@ -278,6 +298,7 @@ type OidcConfigInput struct {
 	DiscoveryDocument    string
 	Jwks                 []byte
 	PrivateKeySecretName string
+	UserTags             map[string]string
 }
 
 const (
@@ -393,6 +414,7 @@ func (s *CreateUnmanagedOidcConfigAutoStrategy) execute(r *rosa.Runtime) {
 	privateKey := s.oidcConfig.PrivateKey
 	privateKeySecretName := s.oidcConfig.PrivateKeySecretName
 	installerRoleArn := args.installerRoleArn
+	userTags := s.oidcConfig.UserTags
 	var spin *spinner.Spinner
 	if r.Reporter.IsTerminal() {
 		spin = spinner.New(spinner.CharSets[9], 100*time.Millisecond)
@@ -401,19 +423,25 @@ func (s *CreateUnmanagedOidcConfigAutoStrategy) execute(r *rosa.Runtime) {
 	if spin != nil {
 		spin.Start()
 	}
-	err := r.AWSClient.CreateS3Bucket(bucketName, args.region)
+	err := r.AWSClient.CreateS3Bucket(bucketName, args.region, userTags)
 	if err != nil {
+		if spin != nil {
+			spin.Stop()
+		}
 		r.Reporter.Errorf("There was a problem creating S3 bucket '%s': %s", bucketName, err)
 		os.Exit(1)
 	}
 	err = r.AWSClient.PutPublicReadObjectInS3Bucket(
-		bucketName, strings.NewReader(discoveryDocument), discoveryDocumentKey)
+		bucketName, strings.NewReader(discoveryDocument), discoveryDocumentKey, userTags)
 	if err != nil {
+		if spin != nil {
+			spin.Stop()
+		}
 		r.Reporter.Errorf("There was a problem populating discovery "+
 			"document to S3 bucket '%s': %s", bucketName, err)
 		os.Exit(1)
 	}
-	err = r.AWSClient.PutPublicReadObjectInS3Bucket(bucketName, bytes.NewReader(jwks), jwksKey)
+	err = r.AWSClient.PutPublicReadObjectInS3Bucket(bucketName, bytes.NewReader(jwks), jwksKey, userTags)
 	if err != nil {
 		if spin != nil {
 			spin.Stop()
@@ -422,8 +450,12 @@ func (s *CreateUnmanagedOidcConfigAutoStrategy) execute(r *rosa.Runtime) {
 			"to S3 bucket '%s': %s", bucketName, err)
 		os.Exit(1)
 	}
-	secretARN, err := r.AWSClient.CreateSecretInSecretsManager(privateKeySecretName, string(privateKey[:]))
+	secretARN, err := r.AWSClient.CreateSecretInSecretsManager(privateKeySecretName, string(privateKey[:]),
+		userTags)
 	if err != nil {
+		if spin != nil {
+			spin.Stop()
+		}
 		r.Reporter.Errorf("There was a problem saving private key to secrets manager: %s", err)
 		os.Exit(1)
 	}
@@ -452,6 +484,8 @@ func (s *CreateUnmanagedOidcConfigAutoStrategy) execute(r *rosa.Runtime) {
 		output := "Please run the following command to create a cluster with this oidc config"
 		output = fmt.Sprintf("%s\nrosa create cluster --sts --oidc-config-id %s", output, oidcConfig.ID())
 		r.Reporter.Infof(output)
+		r.Reporter.Infof("To view the bucket, issuer URL and private key secret of this oidc config, run "+
+			"'rosa describe oidc-config --oidc-config-id %s'", oidcConfig.ID())
 	}
 }
 
@@ -467,6 +501,9 @@ func (s *CreateUnmanagedOidcConfigManualStrategy) execute(r *rosa.Runtime) {
 	privateKey := s.oidcConfig.PrivateKey
 	privateKeyFilename := s.oidcConfig.PrivateKeyFilename
 	privateKeySecretName := s.oidcConfig.PrivateKeySecretName
+	resourceTags := aws.MergeUserTags(s.oidcConfig.UserTags, map[string]string{
+		tags.RedHatManaged: tags.True,
+	})
 	err := helper.SaveDocument(string(privateKey), privateKeyFilename)
 	if err != nil {
 		r.Reporter.Errorf("There was a problem saving private key to a file: %s", err)
@@ -487,7 +524,7 @@ func (s *CreateUnmanagedOidcConfigManualStrategy) execute(r *rosa.Runtime) {
 	putBucketTaggingCommand := awscb.NewS3ApiCommandBuilder().
 		SetCommand(awscb.PutBucketTagging).
 		AddParam(awscb.Bucket, bucketName).
-		AddParam(awscb.Tagging, fmt.Sprintf("'TagSet=[{Key=%s,Value=%s}]'", tags.RedHatManaged, tags.True)).
+		AddParam(awscb.Tagging, buildBucketTagSet(resourceTags)).
 		Build()
 	commands = append(commands, putBucketTaggingCommand)
 
@@ -524,7 +561,7 @@ func (s *CreateUnmanagedOidcConfigManualStrategy) execute(r *rosa.Runtime) {
 		AddParam(awscb.Body, fmt.Sprintf("./%s", discoveryDocumentFilename)).
 		AddParam(awscb.Bucket, bucketName).
 		AddParam(awscb.Key, discoveryDocumentKey).
-		AddParam(awscb.Tagging, fmt.Sprintf("'%s=%s'", tags.RedHatManaged, tags.True)).
+		AddParam(awscb.Tagging, fmt.Sprintf("'%s'", aws.GetObjectTagging(resourceTags))).
 		Build()
 	commands = append(commands, putDiscoveryDocumentCommand)
 	commands = append(commands, fmt.Sprintf("rm %s", discoveryDocumentFilename))
@@ -539,7 +576,7 @@ func (s *CreateUnmanagedOidcConfigManualStrategy) execute(r *rosa.Runtime) {
 		AddParam(awscb.Body, fmt.Sprintf("./%s", jwksFilename)).
 		AddParam(awscb.Bucket, bucketName).
 		AddParam(awscb.Key, jwksKey).
-		AddParam(awscb.Tagging, fmt.Sprintf("'%s=%s'", tags.RedHatManaged, tags.True)).
+		AddParam(awscb.Tagging, fmt.Sprintf("'%s'", aws.GetObjectTagging(resourceTags))).
 		Build()
 	commands = append(commands, putJwksCommand)
 	commands = append(commands, fmt.Sprintf("rm %s", jwksFilename))
@@ -549,9 +586,7 @@ func (s *CreateUnmanagedOidcConfigManualStrategy) execute(r *rosa.Runtime) {
 		AddParam(awscb.SecretString, fmt.Sprintf("file://%s", privateKeyFilename)).
 		AddParam(awscb.Description, fmt.Sprintf("\"Secret for %s\"", bucketName)).
 		AddParam(awscb.Region, args.region).
-		AddTags(map[string]string{
-			tags.RedHatManaged: "true",
-		}).
+		AddTags(resourceTags).
 		Build()
 	commands = append(commands, createSecretCommand)
 	commands = append(commands, fmt.Sprintf("rm %s", privateKeyFilename))
@@ -563,6 +598,31 @@ func (s *CreateUnmanagedOidcConfigManualStrategy) execute(r *rosa.Runtime) {
 	}
 }
 
+// joinUserTags returns the tags in the format of the '--tags' flag.
+func joinUserTags(userTags map[string]string) string {
+	tagsList := []string{}
+	for key, value := range userTags {
+		tagsList = append(tagsList, fmt.Sprintf("%s:%s", key, value))
+	}
+	sort.Strings(tagsList)
+	return strings.Join(tagsList, ",")
+}
+
+// buildBucketTagSet returns the value of the '--tagging' parameter of the 'put-bucket-tagging'
+// command for the given tags.
+func buildBucketTagSet(bucketTags map[string]string) string {
+	tagSet := []string{}
+	keys := make([]string, 0, len(bucketTags))
+	for key := range bucketTags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		tagSet = append(tagSet, fmt.Sprintf("{Key=%s,Value=%s}", key, bucketTags[key]))
+	}
+	return fmt.Sprintf("'TagSet=[%s]'", strings.Join(tagSet, ","))
+}
+
 type CreateManagedOidcConfigAutoStrategy struct {
 	oidcConfigInput *OidcConfigInput
 }
@@ -17,13 +17,7 @@ limitations under the License.
 package oidcprovider
 
 import (
-	// nolint:gosec
-	"bytes"
-	"crypto/sha1" //#nosec GSC-G505 -- Import blacklist: crypto/sha1
-	"encoding/hex"
 	"fmt"
-	"net/http"
-	"net/url"
 	"os"
 	"strings"
 
@@ -56,6 +50,7 @@ const (
 
 var args struct {
 	oidcEndpointUrl string
+	tags            []string
 }
 
 func init() {
@@ -68,6 +63,14 @@ func init() {
 		"Endpoint url for reusable OIDC config",
 	)
 
+	flags.StringSliceVar(
+		&args.tags,
+		"tags",
+		nil,
+		"Apply user defined tags to the OIDC provider created by ROSA in AWS. "+
+			"Tags are comma separated, for example: --tags=foo:bar,bar:baz",
+	)
+
 	ocm.AddOptionalClusterFlag(Cmd)
 	aws.AddModeFlag(Cmd)
 
@@ -100,6 +103,12 @@ func run(cmd *cobra.Command, argv []string) {
 		os.Exit(1)
 	}
 
+	userTags, err := aws.ParseUserTags(args.tags)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+
 	// Determine if interactive mode is needed
 	if !interactive.Enabled() && !cmd.Flags().Changed("mode") && !skipInteractive {
 		interactive.Enable()
@@ -180,7 +189,7 @@ func run(cmd *cobra.Command, argv []string) {
 		if !confirm.Prompt(true, confirmPromptMessage) {
 			os.Exit(0)
 		}
-		err = createProvider(r, oidcEndpointURL, clusterId)
+		err = createProvider(r, oidcEndpointURL, clusterId, userTags)
 		if err != nil {
 			r.Reporter.Errorf("There was an error creating the OIDC provider: %s", err)
 			r.OCMClient.LogEvent("ROSACreateOIDCProviderModeAuto", map[string]string{
@@ -194,7 +203,7 @@ func run(cmd *cobra.Command, argv []string) {
 			ocm.Response:  ocm.Success,
 		})
 	case aws.ModeManual:
-		commands, err := buildCommands(r, oidcEndpointURL, clusterId)
+		commands, err := buildCommands(r, oidcEndpointURL, clusterId, userTags)
 		if err != nil {
 			r.Reporter.Errorf("There was an error building the list of resources: %s", err)
 			os.Exit(1)
@@ -216,14 +225,16 @@ func run(cmd *cobra.Command, argv []string) {
 	}
 }
 
-func createProvider(r *rosa.Runtime, oidcEndpointUrl string, clusterId string) error {
-	thumbprint, err := getThumbprint(oidcEndpointUrl)
+func createProvider(r *rosa.Runtime, oidcEndpointUrl string, clusterId string,
+	userTags map[string]string) error {
+	thumbprint, err := aws.GetThumbprint(oidcEndpointUrl)
 	if err != nil {
 		return err
 	}
 	r.Reporter.Debugf("Using thumbprint '%s'", thumbprint)
 
-	oidcProviderARN, err := r.AWSClient.CreateOpenIDConnectProvider(oidcEndpointUrl, thumbprint, clusterId)
+	oidcProviderARN, err := r.AWSClient.CreateOpenIDConnectProvider(oidcEndpointUrl, thumbprint, clusterId,
+		userTags)
 	if err != nil {
 		return err
 	}
@@ -234,21 +245,23 @@ func createProvider(r *rosa.Runtime, oidcEndpointUrl string, clusterId string) e
 	return nil
 }
 
-func buildCommands(r *rosa.Runtime, oidcEndpointUrl string, clusterId string) (string, error) {
+func buildCommands(r *rosa.Runtime, oidcEndpointUrl string, clusterId string,
+	userTags map[string]string) (string, error) {
 	commands := []string{}
 
-	thumbprint, err := getThumbprint(oidcEndpointUrl)
+	thumbprint, err := aws.GetThumbprint(oidcEndpointUrl)
 	if err != nil {
 		return "", err
 	}
 	r.Reporter.Debugf("Using thumbprint '%s'", thumbprint)
 
-	iamTags := map[string]string{
+	rosaTags := map[string]string{
 		tags.RedHatManaged: tags.True,
 	}
 	if clusterId != "" {
-		iamTags[tags.ClusterID] = clusterId
+		rosaTags[tags.ClusterID] = clusterId
 	}
+	iamTags := aws.MergeUserTags(userTags, rosaTags)
 
 	clientIdList := strings.Join([]string{aws.OIDCClientIDOpenShift, aws.OIDCClientIDSTSAWS}, " ")
 
@@ -263,39 +276,3 @@ func buildCommands(r *rosa.Runtime, oidcEndpointUrl string, clusterId string) (s
 
 	return awscb.JoinCommands(commands), nil
 }
-
-func getThumbprint(oidcEndpointURL string) (string, error) {
-	connect, err := url.ParseRequestURI(oidcEndpointURL)
-	if err != nil {
-		return "", err
-	}
-
-	response, err := http.Get(fmt.Sprintf("https://%s:443", connect.Host))
-	if err != nil {
-		return "", err
-	}
-
-	certChain := response.TLS.PeerCertificates
-
-	// Grab the CA in the chain
-	for _, cert := range certChain {
-		if cert.IsCA {
-			if bytes.Equal(cert.RawIssuer, cert.RawSubject) {
-				return sha1Hash(cert.Raw), nil
-			}
-		}
-	}
-
-	// Fall back to using the last certficiate in the chain
-	cert := certChain[len(certChain)-1]
-	return sha1Hash(cert.Raw), nil
-}
-
-// sha1Hash computes the SHA1 of the byte array and returns the hex encoding as a string.
-func sha1Hash(data []byte) string {
-	// nolint:gosec
-	hasher := sha1.New()
-	hasher.Write(data)
-	hashed := hasher.Sum(nil)
-	return hex.EncodeToString(hashed)
-}
@@ -12,13 +12,14 @@ import (
 	"github.com/openshift/rosa/pkg/helper"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/properties"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
 func handleOperatorRoleCreationByClusterKey(r *rosa.Runtime, env string,
 	permissionsBoundary string, mode string,
 	policies map[string]*cmv1.AWSSTSPolicy,
-	defaultPolicyVersion string) error {
+	defaultPolicyVersion string, userTags map[string]string) error {
 	clusterKey := r.GetClusterKey()
 	cluster := r.FetchCluster()
 	if cluster.AWS().STS().RoleARN() == "" {
@@ -102,7 +103,7 @@ func handleOperatorRoleCreationByClusterKey(r *rosa.Runtime, env string,
 			r.Reporter.Infof("Creating roles using '%s'", r.Creator.ARN)
 		}
 		err = createRoles(r, operatorRolePolicyPrefix, permissionsBoundary, cluster,
-			accountRoleVersion, policies, defaultPolicyVersion, credRequests, managedPolicies, hostedCPPolicies)
+			accountRoleVersion, policies, defaultPolicyVersion, credRequests, managedPolicies, hostedCPPolicies, userTags)
 		if err != nil {
 			r.Reporter.Errorf("There was an error creating the operator roles: %s", err)
 			isThrottle := "false"
@@ -122,7 +123,7 @@ func handleOperatorRoleCreationByClusterKey(r *rosa.Runtime, env string,
 		})
 	case aws.ModeManual:
 		commands, err := buildCommands(r, env, operatorRolePolicyPrefix, permissionsBoundary, defaultPolicyVersion,
-			cluster, policies, credRequests, managedPolicies, hostedCPPolicies)
+			cluster, policies, credRequests, managedPolicies, hostedCPPolicies, userTags)
 		if err != nil {
 			r.Reporter.Errorf("There was an error building the list of resources: %s", err)
 			os.Exit(1)
@@ -150,7 +151,8 @@ func handleOperatorRoleCreationByClusterKey(r *rosa.Runtime, env string,
 func createRoles(r *rosa.Runtime,
 	prefix string, permissionsBoundary string,
 	cluster *cmv1.Cluster, accountRoleVersion string, policies map[string]*cmv1.AWSSTSPolicy,
-	defaultVersion string, credRequests map[string]*cmv1.STSOperator, managedPolicies bool, hostedCPPolicies bool) error {
+	defaultVersion string, credRequests map[string]*cmv1.STSOperator, managedPolicies bool, hostedCPPolicies bool,
+	userTags map[string]string) error {
 	for credrequest, operator := range credRequests {
 		ver := cluster.Version()
 		if ver != nil && operator.MinVersion() != "" {
@@ -185,13 +187,13 @@ func createRoles(r *rosa.Runtime,
 				operator.Name(), path)
 			policyDetails := aws.GetPolicyDetails(policies, filename)
 
-			operatorPolicyTags := map[string]string{
+			operatorPolicyTags := aws.MergeUserTags(userTags, map[string]string{
 				tags.OpenShiftVersion:  accountRoleVersion,
 				tags.RolePrefix:        prefix,
 				tags.RedHatManaged:     helper.True,
 				tags.OperatorNamespace: operator.Namespace(),
 				tags.OperatorName:      operator.Name(),
-			}
+			})
 
 			if args.forcePolicyCreation {
 				policyARN, err = r.AWSClient.ForceEnsurePolicy(policyARN, policyDetails,
@@ -215,11 +217,11 @@ func createRoles(r *rosa.Runtime,
 		}
 
 		r.Reporter.Debugf("Creating role '%s'", roleName)
-		tagsList := map[string]string{
+		tagsList := aws.MergeUserTags(userTags, map[string]string{
 			tags.OperatorNamespace: operator.Namespace(),
 			tags.OperatorName:      operator.Name(),
 			tags.RedHatManaged:     helper.True,
-		}
+		})
 		if !isOidcConfigReusable(cluster) {
 			tagsList[tags.ClusterID] = cluster.ID()
 		}
@@ -244,6 +246,23 @@ func createRoles(r *rosa.Runtime,
 		if err != nil {
 			return err
 		}
+
+		sharedVPCRoleARN := cluster.Properties()[properties.SharedVPCRoleARN]
+		if sharedVPCRoleARN != "" && aws.IsSharedVPCOperator(operator) {
+			sharedVPCPolicyName, err := aws.GetSharedVPCPolicyName(sharedVPCRoleARN)
+			if err != nil {
+				return err
+			}
+			sharedVPCPolicy, err := aws.GetSharedVPCPolicy(sharedVPCRoleARN)
+			if err != nil {
+				return err
+			}
+			r.Reporter.Debugf("Adding shared VPC policy '%s' to role '%s'", sharedVPCPolicyName, roleName)
+			err = r.AWSClient.PutRolePolicy(roleName, sharedVPCPolicyName, sharedVPCPolicy)
+			if err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
@@ -252,7 +271,7 @@ func createRoles(r *rosa.Runtime,
 func buildCommands(r *rosa.Runtime, env string,
 	prefix string, permissionsBoundary string, defaultPolicyVersion string, cluster *cmv1.Cluster,
 	policies map[string]*cmv1.AWSSTSPolicy, credRequests map[string]*cmv1.STSOperator,
-	managedPolicies bool, hostedCPPolicies bool) (string, error) {
+	managedPolicies bool, hostedCPPolicies bool, userTags map[string]string) (string, error) {
 	err := aws.GeneratePolicyFiles(r.Reporter, env, false,
 		true, policies, credRequests, managedPolicies)
 	if err != nil {
@@ -291,13 +310,13 @@ func buildCommands(r *rosa.Runtime, env string,
 			name := aws.GetOperatorPolicyName(prefix, operator.Namespace(), operator.Name())
 			_, err = r.AWSClient.IsPolicyExists(policyARN)
 			if err != nil {
-				iamTags := map[string]string{
+				iamTags := aws.MergeUserTags(userTags, map[string]string{
 					tags.OpenShiftVersion:  defaultPolicyVersion,
 					tags.RolePrefix:        prefix,
 					tags.OperatorNamespace: operator.Namespace(),
 					tags.OperatorName:      operator.Name(),
 					tags.RedHatManaged:     helper.True,
-				}
+				})
 				createPolicy := awscb.NewIAMCommandBuilder().
 					SetCommand(awscb.CreatePolicy).
 					AddParam(awscb.PolicyName, name).
@@ -322,11 +341,11 @@ func buildCommands(r *rosa.Runtime, env string,
 		if err != nil {
 			return "", err
 		}
-		iamTags := map[string]string{
+		iamTags := aws.MergeUserTags(userTags, map[string]string{
 			tags.OperatorNamespace: operator.Namespace(),
 			tags.OperatorName:      operator.Name(),
 			tags.RedHatManaged:     helper.True,
-		}
+		})
 		if !isOidcConfigReusable(cluster) {
 			iamTags[tags.ClusterID] = cluster.ID()
 		}
@@ -351,6 +370,31 @@ func buildCommands(r *rosa.Runtime, env string,
 			AddParam(awscb.PolicyArn, policyARN).
 			Build()
 		commands = append(commands, createRole, attachRolePolicy)
+
+		sharedVPCRoleARN := cluster.Properties()[properties.SharedVPCRoleARN]
+		if sharedVPCRoleARN != "" && aws.IsSharedVPCOperator(operator) {
+			sharedVPCPolicyName, err := aws.GetSharedVPCPolicyName(sharedVPCRoleARN)
+			if err != nil {
+				return "", err
+			}
+			sharedVPCPolicy, err := aws.GetSharedVPCPolicy(sharedVPCRoleARN)
+			if err != nil {
+				return "", err
+			}
+			sharedVPCFilename := aws.GetFormattedFileName(fmt.Sprintf("operator_%s_shared_vpc_policy", credrequest))
+			r.Reporter.Debugf("Saving '%s' to the current directory", sharedVPCFilename)
+			err = helper.SaveDocument(sharedVPCPolicy, sharedVPCFilename)
+			if err != nil {
+				return "", err
+			}
+			putRolePolicy := awscb.NewIAMCommandBuilder().
+				SetCommand(awscb.PutRolePolicy).
+				AddParam(awscb.RoleName, roleName).
+				AddParam(awscb.PolicyName, sharedVPCPolicyName).
+				AddParam(awscb.PolicyDocument, fmt.Sprintf("file://%s", sharedVPCFilename)).
+				Build()
+			commands = append(commands, putRolePolicy)
+		}
 	}
 	return awscb.JoinCommands(commands), nil
 }
@@ -69,7 +69,7 @@ func handleOperatorRolesPrefixOptions(r *rosa.Runtime, cmd *cobra.Command) {
 func handleOperatorRoleCreationByPrefix(r *rosa.Runtime, env string,
 	permissionsBoundary string, mode string,
 	policies map[string]*cmv1.AWSSTSPolicy,
-	defaultPolicyVersion string) error {
+	defaultPolicyVersion string, userTags map[string]string) error {
 	includeHostedCpSet := args.hostedCp
 	operatorRolesPrefix := args.prefix
 	oidcEndpointUrl := args.oidcEndpointUrl
@@ -146,7 +146,7 @@ func handleOperatorRoleCreationByPrefix(r *rosa.Runtime, env string,
 			defaultPolicyVersion, policies,
 			credRequests, managedPolicies,
 			path, operatorIAMRoleList,
-			oidcEndpointUrl, hostedCPPolicies)
+			oidcEndpointUrl, hostedCPPolicies, userTags)
 		if err != nil {
 			r.Reporter.Errorf("There was an error creating the operator roles: %s", err)
 			isThrottle := "false"
@@ -170,7 +170,7 @@ func handleOperatorRoleCreationByPrefix(r *rosa.Runtime, env string,
 			defaultPolicyVersion, policies,
 			credRequests, managedPolicies,
 			path, operatorIAMRoleList,
-			oidcEndpointUrl, hostedCPPolicies)
+			oidcEndpointUrl, hostedCPPolicies, userTags)
 		if err != nil {
 			r.Reporter.Errorf("There was an error building the list of resources: %s", err)
 			os.Exit(1)
@@ -258,7 +258,7 @@ func createRolesByPrefix(r *rosa.Runtime, prefix string, permissionsBoundary str
 	policies map[string]*cmv1.AWSSTSPolicy, credRequests map[string]*cmv1.STSOperator,
 	managedPolicies bool, path string,
 	operatorIAMRoleList []*cmv1.OperatorIAMRole,
-	oidcEndpointUrl string, hostedCPPolicies bool) error {
+	oidcEndpointUrl string, hostedCPPolicies bool, userTags map[string]string) error {
 	for credrequest, operator := range credRequests {
 		roleArn := aws.FindOperatorRoleBySTSOperator(operatorIAMRoleList, operator)
 		roleName, err := aws.GetResourceIdFromARN(roleArn)
@@ -281,13 +281,13 @@ func createRolesByPrefix(r *rosa.Runtime, prefix string, permissionsBoundary str
 				operator.Name(), path)
 			policyDetails := aws.GetPolicyDetails(policies, filename)
 
-			operatorPolicyTags := map[string]string{
+			operatorPolicyTags := aws.MergeUserTags(userTags, map[string]string{
 				tags.OpenShiftVersion:  defaultPolicyVersion,
 				tags.RolePrefix:        prefix,
 				tags.RedHatManaged:     helper.True,
 				tags.OperatorNamespace: operator.Namespace(),
 				tags.OperatorName:      operator.Name(),
-			}
+			})
 
 			if args.forcePolicyCreation {
 				_, err := r.AWSClient.ForceEnsurePolicy(policyArn, policyDetails,
@@ -312,11 +312,11 @@ func createRolesByPrefix(r *rosa.Runtime, prefix string, permissionsBoundary str
 		}
 
 		r.Reporter.Debugf("Creating role '%s'", roleName)
-		tagsList := map[string]string{
+		tagsList := aws.MergeUserTags(userTags, map[string]string{
 			tags.OperatorNamespace: operator.Namespace(),
 			tags.OperatorName:      operator.Name(),
 			tags.RedHatManaged:     helper.True,
-		}
+		})
 		if managedPolicies {
 			tagsList[tags.ManagedPolicies] = helper.True
 		}
@@ -348,7 +348,7 @@ func buildCommandsFromPrefix(r *rosa.Runtime, env string,
 	policies map[string]*cmv1.AWSSTSPolicy, credRequests map[string]*cmv1.STSOperator,
 	managedPolicies bool, path string,
 	operatorIAMRoleList []*cmv1.OperatorIAMRole,
-	oidcEndpointUrl string, hostedCPPolicies bool) (string, error) {
+	oidcEndpointUrl string, hostedCPPolicies bool, userTags map[string]string) (string, error) {
 	err := aws.GeneratePolicyFiles(r.Reporter, env, false,
 		true, policies, credRequests, managedPolicies)
 	if err != nil {
@@ -376,13 +376,13 @@ func buildCommandsFromPrefix(r *rosa.Runtime, env string,
 			name := aws.GetOperatorPolicyName(prefix, operator.Namespace(), operator.Name())
 			_, err = r.AWSClient.IsPolicyExists(policyARN)
 			if err != nil {
-				iamTags := map[string]string{
+				iamTags := aws.MergeUserTags(userTags, map[string]string{
 					tags.OpenShiftVersion:  defaultPolicyVersion,
 					tags.RolePrefix:        prefix,
 					tags.OperatorNamespace: operator.Namespace(),
 					tags.OperatorName:      operator.Name(),
 					tags.RedHatManaged:     helper.True,
-				}
+				})
 				createPolicy := awscb.NewIAMCommandBuilder().
 					SetCommand(awscb.CreatePolicy).
 					AddParam(awscb.PolicyName, name).
@@ -408,11 +408,11 @@ func buildCommandsFromPrefix(r *rosa.Runtime, env string,
 		if err != nil {
 			return "", err
 		}
-		iamTags := map[string]string{
+		iamTags := aws.MergeUserTags(userTags, map[string]string{
 			tags.OperatorNamespace: operator.Namespace(),
 			tags.OperatorName:      operator.Name(),
 			tags.RedHatManaged:     helper.True,
-		}
+		})
 		if managedPolicies {
 			iamTags[tags.ManagedPolicies] = helper.True
 		}
@@ -43,6 +43,7 @@ var args struct {
 	permissionsBoundary string
 	forcePolicyCreation bool
 	oidcEndpointUrl     string
+	tags                []string
 }
 
 var Cmd = &cobra.Command{
@@ -107,6 +108,14 @@ func init() {
 		"Forces creation of policies skipping compatibility check",
 	)
 
+	flags.StringSliceVar(
+		&args.tags,
+		"tags",
+		nil,
+		"Apply user defined tags to the operator roles and policies created by ROSA in AWS. "+
+			"Tags are comma separated, for example: --tags=foo:bar,bar:baz",
+	)
+
 	aws.AddModeFlag(Cmd)
 	confirm.AddFlag(flags)
 	interactive.AddFlag(flags)
@@ -197,7 +206,7 @@ func run(cmd *cobra.Command, argv []string) error {
 			Help:     cmd.Flags().Lookup("permissions-boundary").Usage,
 			Default:  permissionsBoundary,
 			Validators: []interactive.Validator{
-				aws.ARNValidator,
+				aws.PermissionsBoundaryValidator,
 			},
 		})
 		if err != nil {
@@ -206,12 +215,21 @@ func run(cmd *cobra.Command, argv []string) error {
 		}
 	}
 
-	if permissionsBoundary != "" {
-		err = aws.ARNValidator(permissionsBoundary)
+	err = aws.ValidatePermissionsBoundary(r.AWSClient, permissionsBoundary)
+	if err != nil {
+		r.Reporter.Errorf("Expected a valid policy ARN for permissions boundary: %s", err)
+		os.Exit(1)
+	}
+
+	var userTags map[string]string
+	if isProgmaticallyCalled {
+		userTags, err = aws.ParseUserTags(args.tags)
 		if err != nil {
-			r.Reporter.Errorf("Expected a valid policy ARN for permissions boundary: %s", err)
+			r.Reporter.Errorf("%s", err)
 			os.Exit(1)
 		}
+	} else {
+		userTags = interactive.GetUserTags(r, cmd, args.tags)
 	}
 
 	policies, err := r.OCMClient.GetPolicies("OperatorRole")
@@ -237,8 +255,8 @@ func run(cmd *cobra.Command, argv []string) error {
 			os.Exit(1)
 		}
 		return handleOperatorRoleCreationByPrefix(r, env, permissionsBoundary,
-			mode, policies, defaultPolicyVersion)
+			mode, policies, defaultPolicyVersion, userTags)
 	}
 	return handleOperatorRoleCreationByClusterKey(r, env, permissionsBoundary,
-		mode, policies, defaultPolicyVersion)
+		mode, policies, defaultPolicyVersion, userTags)
 }
@@ -0,0 +1,139 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tuningconfig
+
+import (
+	"os"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/interactive"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+	"github.com/openshift/rosa/pkg/tuningconfig"
+)
+
+var Cmd = &cobra.Command{
+	Use:     "tuning-config",
+	Aliases: []string{"tuningconfig", "tuning-configs", "tuningconfigs"},
+	Short:   "Create a tuning config for a cluster",
+	Long: "Create a tuning config for a Hosted Control Plane cluster. The tuning config contains the " +
+		"spec of a TuneD object, and is applied to the nodes of the machine pools created or edited " +
+		"with '--tuning-configs'.",
+	Example: `  # Create tuning config 'hugepages' on cluster 'mycluster' and use it in a machine pool
+  rosa create tuning-config -c mycluster --name hugepages --spec-path tuned.json
+  rosa edit machinepool -c mycluster workers --tuning-configs hugepages`,
+	Run:  run,
+	Args: cobra.NoArgs,
+}
+
+var args struct {
+	name     string
+	specPath string
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
+	ocm.AddClusterFlag(Cmd)
+	tuningconfig.AddNameFlag(flags, &args.name)
+	tuningconfig.AddSpecPathFlag(flags, &args.specPath)
+	interactive.AddFlag(flags)
+	output.AddFlag(Cmd)
+}
+
+func run(cmd *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS().WithOCM()
+	defer r.Cleanup()
+
+	clusterKey := r.GetClusterKey()
+
+	cluster := r.FetchCluster()
+	err := tuningconfig.ValidateCluster(cluster)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+	if cluster.State() != cmv1.ClusterStateReady {
+		r.Reporter.Errorf("Cluster '%s' is not yet ready", clusterKey)
+		os.Exit(1)
+	}
+
+	name := args.name
+	if interactive.Enabled() || name == "" {
+		name, err = interactive.GetString(interactive.Input{
+			Question: "Name",
+			Help:     cmd.Flags().Lookup(tuningconfig.NameFlag).Usage,
+			Default:  name,
+			Required: true,
+			Validators: []interactive.Validator{
+				func(val interface{}) error {
+					return ocm.ValidateTuningConfigName(val.(string))
+				},
+			},
+		})
+		if err != nil {
+			r.Reporter.Errorf("Expected a valid name: %v", err)
+			os.Exit(1)
+		}
+	}
+	err = ocm.ValidateTuningConfigName(name)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+	existing, err := r.OCMClient.FindTuningConfig(cluster.ID(), name)
+	if err != nil {
+		r.Reporter.Errorf("Failed to get tuning configs for cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+	if existing != nil {
+		r.Reporter.Errorf("Tuning config '%s' already exists for cluster '%s', use 'rosa edit tuning-config' "+
+			"to modify it", name, clusterKey)
+		os.Exit(1)
+	}
+
+	spec, err := tuningconfig.GetSpec(cmd.Flags(), args.specPath)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+
+	r.Reporter.Debugf("Creating tuning config '%s' for cluster '%s'", name, clusterKey)
+	config, err := r.OCMClient.CreateTuningConfig(cluster.ID(), &ocm.TuningConfig{
+		Name: name,
+		Spec: spec,
+	})
+	if err != nil {
+		r.Reporter.Errorf("Failed to create tuning config '%s' for cluster '%s': %v", name, clusterKey, err)
+		os.Exit(1)
+	}
+
+	if output.HasFlag() {
+		err = output.Print(config)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+		return
+	}
+	r.Reporter.Infof("Successfully created tuning config '%s' for cluster '%s'. Use it in machine pools "+
+		"with '--tuning-configs %s'", config.Name, clusterKey, config.Name)
+}
@@ -40,6 +40,7 @@ var args struct {
 	prefix              string
 	permissionsBoundary string
 	path                string
+	tags                []string
 }
 
 var Cmd = &cobra.Command{
@@ -77,6 +78,13 @@ func init() {
 		"",
 		"The arn path for the user role and policies.",
 	)
+	flags.StringSliceVar(
+		&args.tags,
+		"tags",
+		nil,
+		"Apply user defined tags to the user role created by ROSA in AWS. "+
+			"Tags are comma separated, for example: --tags=foo:bar,bar:baz",
+	)
 
 	aws.AddModeFlag(Cmd)
 	confirm.AddFlag(flags)
@@ -140,7 +148,7 @@ func run(cmd *cobra.Command, argv []string) {
 			Help:     cmd.Flags().Lookup("permissions-boundary").Usage,
 			Default:  permissionsBoundary,
 			Validators: []interactive.Validator{
-				aws.ARNValidator,
+				aws.PermissionsBoundaryValidator,
 			},
 		})
 		if err != nil {
@@ -149,12 +157,10 @@ func run(cmd *cobra.Command, argv []string) {
 		}
 	}
 
-	if permissionsBoundary != "" {
-		err = aws.ARNValidator(permissionsBoundary)
-		if err != nil {
-			r.Reporter.Errorf("Expected a valid policy ARN for permissions boundary: %s", err)
-			os.Exit(1)
-		}
+	err = aws.ValidatePermissionsBoundary(r.AWSClient, permissionsBoundary)
+	if err != nil {
+		r.Reporter.Errorf("Expected a valid policy ARN for permissions boundary: %s", err)
+		os.Exit(1)
 	}
 
 	path := args.path
@@ -179,6 +185,8 @@ func run(cmd *cobra.Command, argv []string) {
 		os.Exit(1)
 	}
 
+	userTags := interactive.GetUserTags(r, cmd, args.tags)
+
 	if interactive.Enabled() {
 		mode, err = interactive.GetOption(interactive.Input{
 			Question: "Role creation mode",
@@ -210,7 +218,7 @@ func run(cmd *cobra.Command, argv []string) {
 	case aws.ModeAuto:
 		r.Reporter.Infof("Creating ocm user role using '%s'", r.Creator.ARN)
 		roleARN, err := createRoles(r, prefix, path, currentAccount.Username(), env,
-			currentAccount.ID(), permissionsBoundary, policies)
+			currentAccount.ID(), permissionsBoundary, policies, userTags)
 		if err != nil {
 			r.Reporter.Errorf("There was an error creating the ocm user role: %s", err)
 			r.OCMClient.LogEvent("ROSACreateUserRoleModeAuto", map[string]string{
@@ -245,6 +253,7 @@ func run(cmd *cobra.Command, argv []string) {
 			r.Creator.AccountID,
 			env,
 			permissionsBoundary,
+			userTags,
 		)
 		fmt.Println(commands)
 
@@ -255,17 +264,17 @@ func run(cmd *cobra.Command, argv []string) {
 }
 
 func buildCommands(prefix string, path string, userName string,
-	accountID string, env string, permissionsBoundary string) string {
+	accountID string, env string, permissionsBoundary string, userTags map[string]string) string {
 	commands := []string{}
 	roleName := aws.GetUserRoleName(prefix, aws.OCMUserRole, userName)
 
 	roleARN := aws.GetRoleARN(accountID, roleName, path)
-	iamTags := map[string]string{
+	iamTags := aws.MergeUserTags(userTags, map[string]string{
 		tags.RolePrefix:    prefix,
 		tags.RoleType:      aws.OCMUserRole,
 		tags.Environment:   env,
 		tags.RedHatManaged: "true",
-	}
+	})
 	createRole := awscb.NewIAMCommandBuilder().
 		SetCommand(awscb.CreateRole).
 		AddParam(awscb.RoleName, roleName).
@@ -282,7 +291,7 @@ func buildCommands(prefix string, path string, userName string,
 
 func createRoles(r *rosa.Runtime,
 	prefix string, path string, userName string, env string, accountID string, permissionsBoundary string,
-	policies map[string]*cmv1.AWSSTSPolicy) (string, error) {
+	policies map[string]*cmv1.AWSSTSPolicy, userTags map[string]string) (string, error) {
 	roleName := aws.GetUserRoleName(prefix, aws.OCMUserRole, userName)
 	if !confirm.Prompt(true, "Create the '%s' role?", roleName) {
 		os.Exit(0)
@@ -306,12 +315,12 @@ func createRoles(r *rosa.Runtime,
 	}
 	r.Reporter.Debugf("Creating role '%s'", roleName)
 	roleARN, err = r.AWSClient.EnsureRole(roleName, policy, permissionsBoundary,
-		"", map[string]string{
+		"", aws.MergeUserTags(userTags, map[string]string{
 			tags.RolePrefix:    prefix,
 			tags.RoleType:      aws.OCMUserRole,
 			tags.Environment:   env,
 			tags.RedHatManaged: "true",
-		}, path, false)
+		}), path, false)
 	if err != nil {
 		return "", err
 	}
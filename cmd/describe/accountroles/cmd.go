@@ -0,0 +1,282 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package accountroles
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/aws/tags"
+	"github.com/openshift/rosa/pkg/helper/roles"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var args struct {
+	prefix   string
+	hostedCP bool
+	version  string
+	drift    bool
+}
+
+var Cmd = &cobra.Command{
+	Use:     "account-roles",
+	Aliases: []string{"accountrole", "account-role", "accountroles"},
+	Short:   "Show details of account roles",
+	Long: "Show details of the account roles with the given prefix, and optionally check if their trust " +
+		"policies, attached policies and tags have drifted from the expected ones.",
+	Example: `  # Describe the account roles with the default prefix
+  rosa describe account-roles
+
+  # Check if the account roles used by cluster "mycluster" have drifted from the
+  # configuration expected for the version of the cluster
+  rosa describe account-roles -c mycluster --drift`,
+	Run: run,
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
+	flags.StringVar(
+		&args.prefix,
+		"prefix",
+		aws.DefaultPrefix,
+		"User-defined prefix of the account roles.",
+	)
+	flags.BoolVar(
+		&args.hostedCP,
+		"hosted-cp",
+		false,
+		"Describe the account roles used by Hosted Control Plane clusters.",
+	)
+	flags.StringVar(
+		&args.version,
+		"version",
+		"",
+		"OpenShift version that the account roles are expected to support. Defaults to the version "+
+			"of the cluster, or to the latest version.",
+	)
+	flags.BoolVar(
+		&args.drift,
+		"drift",
+		false,
+		"Compare the trust policies, attached policies and tags of the roles with the expected ones, "+
+			"and show the commands that fix the differences.",
+	)
+	ocm.AddOptionalClusterFlag(Cmd)
+	output.AddFlag(Cmd)
+}
+
+func run(cmd *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS().WithOCM()
+	defer r.Cleanup()
+
+	prefix := args.prefix
+	hostedCP := args.hostedCP
+	version := args.version
+	if cmd.Flags().Changed("cluster") {
+		cluster := r.FetchCluster()
+		if cluster.AWS().STS().RoleARN() == "" {
+			r.Reporter.Errorf("Cluster '%s' is not an STS cluster", r.ClusterKey)
+			os.Exit(1)
+		}
+		if !cmd.Flags().Changed("prefix") {
+			var err error
+			prefix, err = aws.GetPrefixFromInstallerAccountRole(cluster)
+			if err != nil {
+				r.Reporter.Errorf("Failed to get the prefix of the account roles of cluster '%s': %v",
+					r.ClusterKey, err)
+				os.Exit(1)
+			}
+		}
+		hostedCP = aws.IsHostedCPManagedPolicies(cluster)
+		if version == "" {
+			version = ocm.GetVersionMinor(cluster.Version().RawID())
+		}
+	}
+
+	roleDefinitions := aws.AccountRoles
+	if hostedCP {
+		roleDefinitions = aws.HCPAccountRoles
+	}
+	roleTypes := make([]string, 0, len(roleDefinitions))
+	for roleType := range roleDefinitions {
+		roleTypes = append(roleTypes, roleType)
+	}
+	sort.Strings(roleTypes)
+
+	r.Reporter.Debugf("Loading account roles with prefix '%s'", prefix)
+	accountRoles, err := r.AWSClient.ListAccountRoles("")
+	if err != nil {
+		r.Reporter.Errorf("Failed to get account roles: %v", err)
+		os.Exit(1)
+	}
+	existingRoles := map[string]aws.Role{}
+	for _, accountRole := range accountRoles {
+		existingRoles[accountRole.RoleName] = accountRole
+	}
+
+	if !args.drift {
+		describeRoles(r, prefix, roleTypes, roleDefinitions, existingRoles)
+		return
+	}
+
+	env, err := ocm.GetEnv()
+	if err != nil {
+		r.Reporter.Errorf("Failed to determine OCM environment: %v", err)
+		os.Exit(1)
+	}
+	if version == "" {
+		version, err = r.OCMClient.GetPolicyVersion("", ocm.DefaultChannelGroup)
+		if err != nil {
+			r.Reporter.Errorf("Failed to get the latest OpenShift version: %v", err)
+			os.Exit(1)
+		}
+	}
+	policies, err := r.OCMClient.GetPolicies("AccountRole")
+	if err != nil {
+		r.Reporter.Errorf("Failed to get the expected policies of the account roles: %v", err)
+		os.Exit(1)
+	}
+
+	expectedRoles := []*aws.ExpectedRole{}
+	for _, roleType := range roleTypes {
+		roleName := aws.GetRoleName(prefix, roleDefinitions[roleType].Name)
+		expectedRole, err := buildExpectedRole(r, env, prefix, version, hostedCP, roleType, roleName,
+			existingRoles[roleName], policies)
+		if err != nil {
+			r.Reporter.Errorf("Failed to get the expected configuration of role '%s': %v", roleName, err)
+			os.Exit(1)
+		}
+		expectedRoles = append(expectedRoles, expectedRole)
+	}
+	roles.DetectDrift(r, expectedRoles, fmt.Sprintf("Run 'rosa create account-roles --prefix %s' to create it.",
+		prefix))
+}
+
+func describeRoles(r *rosa.Runtime, prefix string, roleTypes []string, roleDefinitions map[string]aws.AccountRole,
+	existingRoles map[string]aws.Role) {
+	accountRoles := []aws.Role{}
+	for _, roleType := range roleTypes {
+		accountRole, ok := existingRoles[aws.GetRoleName(prefix, roleDefinitions[roleType].Name)]
+		if ok {
+			accountRoles = append(accountRoles, accountRole)
+		}
+	}
+
+	if output.HasFlag() {
+		err := output.Print(accountRoles)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+		return
+	}
+	if len(accountRoles) == 0 {
+		r.Reporter.Infof("There are no account roles with prefix '%s'", prefix)
+		return
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "ROLE NAME\tROLE TYPE\tROLE ARN\tOPENSHIFT VERSION\tAWS Managed\n")
+	for _, accountRole := range accountRoles {
+		awsManaged := "No"
+		if accountRole.ManagedPolicy {
+			awsManaged = "Yes"
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\n",
+			accountRole.RoleName,
+			accountRole.RoleType,
+			accountRole.RoleARN,
+			accountRole.Version,
+			awsManaged,
+		)
+	}
+	writer.Flush()
+}
+
+// buildExpectedRole returns the configuration that the account role would have if it was created
+// now with 'rosa create account-roles'.
+func buildExpectedRole(r *rosa.Runtime, env string, prefix string, version string, hostedCP bool,
+	roleType string, roleName string, existingRole aws.Role, policies map[string]*cmv1.AWSSTSPolicy,
+) (*aws.ExpectedRole, error) {
+	trustPolicy := aws.InterpolatePolicyDocument(
+		aws.GetPolicyDetails(policies, fmt.Sprintf("sts_%s_trust_policy", roleType)),
+		map[string]string{
+			"partition":      aws.GetPartition(),
+			"aws_account_id": aws.GetJumpAccount(env),
+		})
+
+	roleTags := map[string]string{
+		tags.RolePrefix:    prefix,
+		tags.RoleType:      roleType,
+		tags.RedHatManaged: tags.True,
+	}
+	managedPolicies := existingRole.ManagedPolicy || hostedCP
+	if managedPolicies {
+		roleTags[tags.ManagedPolicies] = tags.True
+	}
+	if hostedCP {
+		roleTags[tags.HypershiftPolicies] = tags.True
+	}
+
+	expectedPolicies := map[string]string{}
+	switch {
+	case hostedCP:
+		policyARN, err := aws.GetManagedPolicyARN(policies, fmt.Sprintf("sts_hcp_%s_permission_policy", roleType))
+		if err != nil {
+			return nil, err
+		}
+		expectedPolicies[policyARN] = ""
+	case managedPolicies:
+		for _, policyKey := range aws.GetAccountRolePolicyKeys(roleType) {
+			policyARN, err := aws.GetManagedPolicyARN(policies, policyKey)
+			if err != nil {
+				return nil, err
+			}
+			expectedPolicies[policyARN] = ""
+		}
+	default:
+		path := ""
+		if existingRole.RoleARN != "" {
+			var err error
+			path, err = aws.GetPathFromARN(existingRole.RoleARN)
+			if err != nil {
+				return nil, err
+			}
+		}
+		policyARN := aws.GetPolicyARN(r.Creator.AccountID, roleName, path)
+		expectedPolicies[policyARN] = aws.GetPolicyDetails(policies,
+			fmt.Sprintf("sts_%s_permission_policy", roleType))
+	}
+
+	return &aws.ExpectedRole{
+		RoleName:    roleName,
+		TrustPolicy: trustPolicy,
+		Policies:    expectedPolicies,
+		Tags:        roleTags,
+		Version:     version,
+	}, nil
+}
@@ -0,0 +1,111 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package autoscaler
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/clusterautoscaler"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var Cmd = &cobra.Command{
+	Use:     "autoscaler",
+	Aliases: []string{"cluster-autoscaler"},
+	Short:   "Show details of the autoscaler of a cluster",
+	Long:    "Show details of the cluster-wide autoscaler of a classic cluster.",
+	Example: `  # Describe the autoscaler of cluster 'mycluster'
+  rosa describe autoscaler --cluster=mycluster`,
+	Run:  run,
+	Args: cobra.NoArgs,
+}
+
+func init() {
+	ocm.AddClusterFlag(Cmd)
+	output.AddFlag(Cmd)
+}
+
+func run(_ *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS().WithOCM()
+	defer r.Cleanup()
+
+	clusterKey := r.GetClusterKey()
+
+	cluster := r.FetchCluster()
+	if cluster.Hypershift().Enabled() {
+		r.Reporter.Errorf("Hosted Control Plane clusters do not support cluster-autoscaler configuration")
+		os.Exit(1)
+	}
+
+	r.Reporter.Debugf("Fetching autoscaler for cluster '%s'", clusterKey)
+	autoscaler, err := r.OCMClient.GetClusterAutoscaler(cluster.ID())
+	if err != nil {
+		r.Reporter.Errorf("Failed to get autoscaler for cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+	if autoscaler == nil {
+		r.Reporter.Warnf("Cluster '%s' has no autoscaler", clusterKey)
+		os.Exit(0)
+	}
+
+	if output.HasFlag() {
+		err = output.Print(autoscaler)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	limits := autoscaler.ResourceLimits
+	scaleDown := autoscaler.ScaleDown
+	fmt.Printf("%-40s%t\n", "Balance Similar Node Groups:", autoscaler.BalanceSimilarNodeGroups)
+	fmt.Printf("%-40s%t\n", "Skip Nodes With Local Storage:", autoscaler.SkipNodesWithLocalStorage)
+	fmt.Printf("%-40s%d\n", "Log Verbosity:", autoscaler.LogVerbosity)
+	fmt.Printf("%-40s%s\n", "Labels Ignored For Node Balancing:",
+		orNone(strings.Join(autoscaler.BalancingIgnoredLabels, ", ")))
+	fmt.Printf("%-40s%t\n", "Ignore DaemonSets Utilization:", autoscaler.IgnoreDaemonsetsUtilization)
+	fmt.Printf("%-40s%s\n", "Maximum Node Provision Time:", orNone(autoscaler.MaxNodeProvisionTime))
+	fmt.Printf("%-40s%d\n", "Maximum Pod Grace Period:", autoscaler.MaxPodGracePeriod)
+	fmt.Printf("%-40s%d\n", "Pod Priority Threshold:", autoscaler.PodPriorityThreshold)
+	fmt.Printf("Resource Limits:\n")
+	fmt.Printf("  %-38s%d\n", "Maximum Nodes:", limits.MaxNodesTotal)
+	fmt.Printf("  %-38s%d-%d\n", "Number of Cores:", limits.Cores.Min, limits.Cores.Max)
+	fmt.Printf("  %-38s%d-%d\n", "Memory (GiB):", limits.Memory.Min, limits.Memory.Max)
+	fmt.Printf("  %-38s%s\n", "GPU Limits:",
+		orNone(strings.Join(clusterautoscaler.FormatGPULimits(limits.GPUS), ", ")))
+	fmt.Printf("Scale Down:\n")
+	fmt.Printf("  %-38s%t\n", "Enabled:", scaleDown.Enabled)
+	fmt.Printf("  %-38s%s\n", "Unneeded Time:", orNone(scaleDown.UnneededTime))
+	fmt.Printf("  %-38s%s\n", "Utilization Threshold:", orNone(scaleDown.UtilizationThreshold))
+	fmt.Printf("  %-38s%s\n", "Delay After Add:", orNone(scaleDown.DelayAfterAdd))
+	fmt.Printf("  %-38s%s\n", "Delay After Delete:", orNone(scaleDown.DelayAfterDelete))
+	fmt.Printf("  %-38s%s\n", "Delay After Failure:", orNone(scaleDown.DelayAfterFailure))
+}
+
+func orNone(value string) string {
+	if value == "" {
+		return "<none>"
+	}
+	return value
+}
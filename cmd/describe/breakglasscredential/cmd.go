@@ -0,0 +1,135 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package breakglasscredential
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var Cmd = &cobra.Command{
+	Use:     "break-glass-credential",
+	Aliases: []string{"breakglasscredential"},
+	Short:   "Show details of a break glass credential",
+	Long: "Show details of a break glass credential of a Hosted Control Plane cluster that uses external " +
+		"authentication, and optionally write its kubeconfig to a file.",
+	Example: `  # Describe break glass credential 'abc' of cluster 'mycluster'
+  rosa describe break-glass-credential -c mycluster --id abc
+
+  # Write the kubeconfig of break glass credential 'abc' to a file
+  rosa describe break-glass-credential -c mycluster --id abc --kubeconfig ./break-glass.kubeconfig`,
+	Run:  run,
+	Args: cobra.NoArgs,
+}
+
+var args struct {
+	id         string
+	kubeconfig string
+}
+
+func init() {
+	flags := Cmd.Flags()
+	ocm.AddClusterFlag(Cmd)
+	flags.StringVar(
+		&args.id,
+		"id",
+		"",
+		"Identifier of the break glass credential (required).",
+	)
+	Cmd.MarkFlagRequired("id")
+	flags.StringVar(
+		&args.kubeconfig,
+		"kubeconfig",
+		"",
+		"Path of the file where the kubeconfig of the credential is written. The credential must be issued.",
+	)
+	output.AddFlag(Cmd)
+}
+
+func run(_ *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS().WithOCM()
+	defer r.Cleanup()
+
+	clusterKey := r.GetClusterKey()
+
+	cluster := r.FetchCluster()
+	err := r.OCMClient.ValidateExternalAuthCluster(cluster)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+
+	r.Reporter.Debugf("Loading break glass credential '%s' for cluster '%s'", args.id, clusterKey)
+	credential, err := r.OCMClient.GetBreakGlassCredential(cluster.ID(), args.id)
+	if err != nil {
+		r.Reporter.Errorf("Failed to get break glass credential '%s' for cluster '%s': %v",
+			args.id, clusterKey, err)
+		os.Exit(1)
+	}
+	if credential == nil {
+		r.Reporter.Errorf("Break glass credential '%s' doesn't exist for cluster '%s'", args.id, clusterKey)
+		os.Exit(1)
+	}
+
+	if args.kubeconfig != "" {
+		if credential.Status != ocm.BreakGlassCredentialStatusIssued || credential.Kubeconfig == "" {
+			r.Reporter.Errorf("Break glass credential '%s' is %s, its kubeconfig is only available once it is issued",
+				args.id, credential.Status)
+			os.Exit(1)
+		}
+		err = os.WriteFile(args.kubeconfig, []byte(credential.Kubeconfig), 0600)
+		if err != nil {
+			r.Reporter.Errorf("Failed to write kubeconfig to '%s': %v", args.kubeconfig, err)
+			os.Exit(1)
+		}
+	}
+	credential.Kubeconfig = ""
+
+	if output.HasFlag() {
+		err = output.Print(credential)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	fmt.Printf("%-24s%s\n", "ID:", credential.ID)
+	fmt.Printf("%-24s%s\n", "Username:", credential.Username)
+	fmt.Printf("%-24s%s\n", "Expire at:", formatTime(credential.ExpirationTimestamp))
+	if credential.RevocationTimestamp != nil {
+		fmt.Printf("%-24s%s\n", "Revoked at:", formatTime(credential.RevocationTimestamp))
+	}
+	fmt.Printf("%-24s%s\n", "Status:", credential.Status)
+	if args.kubeconfig != "" {
+		r.Reporter.Infof("Kubeconfig written to '%s'", args.kubeconfig)
+	}
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
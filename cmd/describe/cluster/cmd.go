@@ -21,10 +21,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/spf13/cobra"
+	errors "github.com/zgalor/weberr"
 
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/openshift/rosa/pkg/ocm"
@@ -45,11 +48,32 @@ var Cmd = &cobra.Command{
 	Short: "Show details of a cluster",
 	Long:  "Show details of a cluster",
 	Example: `  # Describe a cluster named "mycluster"
-  rosa describe cluster --cluster=mycluster`,
+  rosa describe cluster --cluster=mycluster
+
+  # Describe a cluster along with the policies attached to its account and operator roles
+  rosa describe cluster --cluster=mycluster --get-role-policies --policy-documents`,
 	Run: run,
 }
 
+var args struct {
+	getRolePolicies bool
+	policyDocuments bool
+}
+
 func init() {
+	flags := Cmd.Flags()
+	flags.BoolVar(
+		&args.getRolePolicies,
+		"get-role-policies",
+		false,
+		"Show the policies attached to the account and operator roles of the cluster.",
+	)
+	flags.BoolVar(
+		&args.policyDocuments,
+		"policy-documents",
+		false,
+		"Include the policy documents when showing the role policies.",
+	)
 	output.AddFlag(Cmd)
 	ocm.AddClusterFlag(Cmd)
 }
@@ -66,9 +90,36 @@ func run(cmd *cobra.Command, argv []string) {
 	}
 	clusterKey := r.GetClusterKey()
 
+	if args.policyDocuments && !args.getRolePolicies {
+		r.Reporter.Errorf("Option --policy-documents requires --get-role-policies")
+		os.Exit(1)
+	}
+
 	cluster := r.FetchCluster()
 	isHypershift := cluster.Hypershift().Enabled()
 
+	var rolePolicies []*clusterRole
+	if args.getRolePolicies {
+		rolePolicies, err = getClusterRolePolicies(r, cluster, args.policyDocuments)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+	}
+
+	limitedSupportReasons, err := r.OCMClient.GetLimitedSupportReasons(cluster.ID())
+	if err != nil {
+		r.Reporter.Errorf("Failed to get limited support reasons for cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+	// Clusters that didn't run the inflight checks don't have them:
+	inflightChecks, err := r.OCMClient.GetInflightChecks(cluster.ID())
+	if err != nil && errors.GetType(err) != errors.NotFound {
+		r.Reporter.Errorf("Failed to get inflight checks for cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+	failedInflightChecks := ocm.FailedInflightChecks(inflightChecks)
+
 	var scheduledUpgrade *cmv1.UpgradePolicy
 	var upgradeState *cmv1.UpgradePolicyState
 	var controlPlaneScheduledUpgrade *cmv1.ControlPlaneUpgradePolicy
@@ -86,6 +137,14 @@ func run(cmd *cobra.Command, argv []string) {
 				r.Reporter.Errorf("%s", err)
 				os.Exit(1)
 			}
+			if rolePolicies != nil {
+				f["rolePolicies"] = rolePolicies
+			}
+			err = addSupportDetails(f, cluster, limitedSupportReasons, failedInflightChecks)
+			if err != nil {
+				r.Reporter.Errorf("%s", err)
+				os.Exit(1)
+			}
 			err = output.Print(f)
 			if err != nil {
 				r.Reporter.Errorf("%s", err)
@@ -106,6 +165,14 @@ func run(cmd *cobra.Command, argv []string) {
 				r.Reporter.Errorf("%s", err)
 				os.Exit(1)
 			}
+			if rolePolicies != nil {
+				f["rolePolicies"] = rolePolicies
+			}
+			err = addSupportDetails(f, cluster, limitedSupportReasons, failedInflightChecks)
+			if err != nil {
+				r.Reporter.Errorf("%s", err)
+				os.Exit(1)
+			}
 			err = output.Print(f)
 			if err != nil {
 				r.Reporter.Errorf("%s", err)
@@ -224,6 +291,11 @@ func run(cmd *cobra.Command, argv []string) {
 		str = fmt.Sprintf("%s"+"Infra ID:                   %s\n", str, cluster.InfraID())
 	}
 
+	if !cluster.ExpirationTimestamp().IsZero() {
+		str = fmt.Sprintf("%s"+"Expiration:                 %s\n", str,
+			cluster.ExpirationTimestamp().UTC().Format(time.RFC3339))
+	}
+
 	if cluster.Proxy() != nil && (cluster.Proxy().HTTPProxy() != "" || cluster.Proxy().HTTPSProxy() != "") {
 		str = fmt.Sprintf("%s"+"Proxy:\n", str)
 		if cluster.Proxy().HTTPProxy() != "" {
@@ -292,6 +364,18 @@ func run(cmd *cobra.Command, argv []string) {
 		str = fmt.Sprintf("%sManaged Policies:           %s\n", str, awsManaged)
 	}
 
+	if len(cluster.AWS().Tags()) > 0 {
+		str = fmt.Sprintf("%sTags:\n", str)
+		keys := make([]string, 0, len(cluster.AWS().Tags()))
+		for key := range cluster.AWS().Tags() {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			str = fmt.Sprintf("%s - %s: %s\n", str, key, cluster.AWS().Tags()[key])
+		}
+	}
+
 	str = fmt.Sprintf("%s"+
 		"State:                      %s %s\n"+
 		"Private:                    %s\n"+
@@ -300,6 +384,11 @@ func run(cmd *cobra.Command, argv []string) {
 		isPrivate,
 		cluster.CreationTimestamp().Format("Jan _2 2006 15:04:05 MST"))
 
+	if cluster.DeleteProtection().Enabled() {
+		str = fmt.Sprintf("%s"+
+			"Delete Protection:          Enabled\n", str)
+	}
+
 	if cluster.DisableUserWorkloadMonitoring() {
 		str = fmt.Sprintf("%s"+
 			"User Workload Monitoring:   %s\n",
@@ -351,7 +440,7 @@ func run(cmd *cobra.Command, argv []string) {
 		}
 	}
 
-	if cluster.Status().State() == cmv1.ClusterStateError {
+	if cluster.Status().ProvisionErrorMessage() != "" {
 		str = fmt.Sprintf("%s"+
 			"Provisioning Error Code:    %s\n"+
 			"Provisioning Error Message: %s\n",
@@ -361,10 +450,14 @@ func run(cmd *cobra.Command, argv []string) {
 		)
 	}
 
-	limitedSupportReasons, err := r.OCMClient.GetLimitedSupportReasons(cluster.ID())
-	if err != nil {
-		r.Reporter.Errorf("Failed to get limited support reasons for cluster '%s': %v", cluster.ID(), err)
-		os.Exit(1)
+	if len(failedInflightChecks) > 0 {
+		str = fmt.Sprintf("%s"+"Failed Inflight Checks:\n", str)
+	}
+	for _, check := range failedInflightChecks {
+		str = fmt.Sprintf("%s"+
+			" - Name:                    %s\n"+
+			" - Details:                 %s\n",
+			str, check.Name(), formatInflightCheckDetails(check.Details()))
 	}
 	if len(limitedSupportReasons) > 0 {
 		str = fmt.Sprintf("%s"+"Limited Support:\n", str)
@@ -372,8 +465,14 @@ func run(cmd *cobra.Command, argv []string) {
 	for _, reason := range limitedSupportReasons {
 		str = fmt.Sprintf("%s"+
 			" - Summary:                 %s\n"+
-			" - Details:                 %s\n",
-			str, reason.Summary(), reason.Details())
+			" - Details:                 %s\n"+
+			" - Detection Type:          %s\n"+
+			" - Created:                 %s\n",
+			str, reason.Summary(), reason.Details(), reason.DetectionType(),
+			reason.CreationTimestamp().Format("2006-01-02 15:04 MST"))
+	}
+	if rolePolicies != nil {
+		str = fmt.Sprintf("%s%s", str, formatClusterRolePolicies(rolePolicies))
 	}
 	str = fmt.Sprintf("%s\n", str)
 
@@ -568,3 +667,47 @@ func formatClusterHypershift(cluster *cmv1.Cluster,
 
 	return ret, nil
 }
+
+// addSupportDetails adds to the JSON description of the cluster the details that explain why it
+// failed to install or is in limited support.
+func addSupportDetails(f map[string]interface{}, cluster *cmv1.Cluster,
+	limitedSupportReasons []*cmv1.LimitedSupportReason, failedInflightChecks []*cmv1.InflightCheck) error {
+	var b bytes.Buffer
+	err := cmv1.MarshalLimitedSupportReasonList(limitedSupportReasons, &b)
+	if err != nil {
+		return err
+	}
+	reasons := []interface{}{}
+	err = json.Unmarshal(b.Bytes(), &reasons)
+	if err != nil {
+		return err
+	}
+	f["limitedSupportReasons"] = reasons
+	b.Reset()
+	err = cmv1.MarshalInflightCheckList(failedInflightChecks, &b)
+	if err != nil {
+		return err
+	}
+	checks := []interface{}{}
+	err = json.Unmarshal(b.Bytes(), &checks)
+	if err != nil {
+		return err
+	}
+	f["failedInflightChecks"] = checks
+	if cluster.Status().ProvisionErrorMessage() != "" {
+		f["provisionError"] = map[string]interface{}{
+			"code":    cluster.Status().ProvisionErrorCode(),
+			"message": cluster.Status().ProvisionErrorMessage(),
+		}
+	}
+	return nil
+}
+
+// formatInflightCheckDetails returns the error payload of an inflight check on a single line.
+func formatInflightCheckDetails(details interface{}) string {
+	data, err := json.Marshal(details)
+	if err != nil {
+		return fmt.Sprintf("%v", details)
+	}
+	return string(data)
+}
@@ -101,6 +101,46 @@ var _ = Describe("Cluster description", Ordered, func() {
 				func() *cmv1.UpgradePolicyState { return nil }, expectClusterWithNameAndIDValue, nil),
 		)
 	})
+
+	Context("when adding support details to the output json", func() {
+		It("Adds the limited support reasons, failed inflight checks and provision error", func() {
+			cluster, err := cmv1.NewCluster().ID("bar").Status(cmv1.NewClusterStatus().
+				ProvisionErrorCode("OCM3999").ProvisionErrorMessage("Inflight checks failed")).Build()
+			Expect(err).NotTo(HaveOccurred())
+			reason, err := cmv1.NewLimitedSupportReason().Summary("Egress blocked").Build()
+			Expect(err).NotTo(HaveOccurred())
+			check, err := cmv1.NewInflightCheck().Name("egress").State(cmv1.InflightCheckStateFailed).
+				Details(map[string]interface{}{"url": "quay.io"}).Build()
+			Expect(err).NotTo(HaveOccurred())
+			checks := []*cmv1.InflightCheck{check}
+
+			f := map[string]interface{}{}
+			err = addSupportDetails(f, cluster, []*cmv1.LimitedSupportReason{reason}, checks)
+			Expect(err).NotTo(HaveOccurred())
+			v, err := json.Marshal(f)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(v)).To(Equal(`{"failedInflightChecks":[{"details":{"url":"quay.io"},"kind":"InflightCheck",` +
+				`"name":"egress","state":"failed"}],` +
+				`"limitedSupportReasons":[{"kind":"LimitedSupportReason","summary":"Egress blocked"}],` +
+				`"provisionError":{"code":"OCM3999","message":"Inflight checks failed"}}`))
+		})
+
+		It("Adds empty lists and no provision error when the cluster is healthy", func() {
+			f := map[string]interface{}{}
+			err := addSupportDetails(f, emptyCluster, nil, []*cmv1.InflightCheck{})
+			Expect(err).NotTo(HaveOccurred())
+			v, err := json.Marshal(f)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(v)).To(Equal(`{"failedInflightChecks":[],"limitedSupportReasons":[]}`))
+		})
+	})
+
+	Context("when formatting inflight check details", func() {
+		It("Compacts the JSON payload", func() {
+			Expect(formatInflightCheckDetails(map[string]interface{}{"url": "quay.io"})).
+				To(Equal(`{"url":"quay.io"}`))
+		})
+	})
 })
 
 func printJson(cluster func() *cmv1.Cluster,
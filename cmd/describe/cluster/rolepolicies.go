@@ -0,0 +1,101 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+// clusterRole is an IAM role used by a cluster, along with the policies attached to it.
+type clusterRole struct {
+	Type     string           `json:"type"`
+	RoleARN  string           `json:"role_arn"`
+	Policies []aws.RolePolicy `json:"policies"`
+}
+
+// getClusterRoles returns the account and operator roles of an STS cluster.
+func getClusterRoles(cluster *cmv1.Cluster) []*clusterRole {
+	sts := cluster.AWS().STS()
+	roles := []*clusterRole{}
+	add := func(roleType string, roleARN string) {
+		if roleARN != "" {
+			roles = append(roles, &clusterRole{Type: roleType, RoleARN: roleARN})
+		}
+	}
+	add("Installer", sts.RoleARN())
+	add("Support", sts.SupportRoleARN())
+	add("Control plane", sts.InstanceIAMRoles().MasterRoleARN())
+	add("Worker", sts.InstanceIAMRoles().WorkerRoleARN())
+	for _, operatorRole := range sts.OperatorIAMRoles() {
+		add(fmt.Sprintf("Operator (%s/%s)", operatorRole.Namespace(), operatorRole.Name()), operatorRole.RoleARN())
+	}
+	return roles
+}
+
+// getClusterRolePolicies fetches from IAM the policies attached to the roles of the cluster.
+func getClusterRolePolicies(r *rosa.Runtime, cluster *cmv1.Cluster, withDocuments bool) ([]*clusterRole, error) {
+	if !cluster.AWS().STS().Enabled() {
+		return nil, fmt.Errorf("Cluster '%s' is not using STS, it has no account or operator roles",
+			cluster.Name())
+	}
+	roles := getClusterRoles(cluster)
+	for _, role := range roles {
+		roleName, err := aws.GetResourceIdFromARN(role.RoleARN)
+		if err != nil {
+			return nil, err
+		}
+		role.Policies, err = r.AWSClient.GetRolePolicies(roleName, withDocuments)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to get policies of role '%s': %v", role.RoleARN, err)
+		}
+	}
+	return roles, nil
+}
+
+func formatClusterRolePolicies(roles []*clusterRole) string {
+	str := "Role Policies:\n"
+	for _, role := range roles {
+		str = fmt.Sprintf("%s - %s role: %s\n", str, role.Type, role.RoleARN)
+		if len(role.Policies) == 0 {
+			str = fmt.Sprintf("%s   - No policies attached\n", str)
+		}
+		for _, policy := range role.Policies {
+			details := policy.PolicyType
+			if policy.Version != "" {
+				details = fmt.Sprintf("%s, %s", details, policy.Version)
+			}
+			str = fmt.Sprintf("%s   - %s (%s)\n", str, policy.PolicyName, details)
+			if policy.Document != "" {
+				document := strings.TrimSpace(policy.Document)
+				var indented bytes.Buffer
+				if json.Indent(&indented, []byte(document), "", "  ") == nil {
+					document = indented.String()
+				}
+				str = fmt.Sprintf("%s     %s\n", str, strings.ReplaceAll(document, "\n", "\n     "))
+			}
+		}
+	}
+	return str
+}
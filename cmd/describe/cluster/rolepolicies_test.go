@@ -0,0 +1,54 @@
+package cluster
+
+import (
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/aws"
+)
+
+var _ = Describe("Role policies", func() {
+	It("Returns the account and operator roles of the cluster", func() {
+		cluster, err := cmv1.NewCluster().AWS(cmv1.NewAWS().STS(cmv1.NewSTS().
+			RoleARN("arn:aws:iam::123:role/Installer").
+			SupportRoleARN("arn:aws:iam::123:role/Support").
+			InstanceIAMRoles(cmv1.NewInstanceIAMRoles().
+				MasterRoleARN("arn:aws:iam::123:role/ControlPlane").
+				WorkerRoleARN("arn:aws:iam::123:role/Worker")).
+			OperatorIAMRoles(cmv1.NewOperatorIAMRole().
+				Namespace("openshift-ingress-operator").
+				Name("cloud-credentials").
+				RoleARN("arn:aws:iam::123:role/Ingress")))).
+			Build()
+		Expect(err).NotTo(HaveOccurred())
+		roles := getClusterRoles(cluster)
+		Expect(roles).To(HaveLen(5))
+		Expect(roles[0].Type).To(Equal("Installer"))
+		Expect(roles[4].Type).To(Equal("Operator (openshift-ingress-operator/cloud-credentials)"))
+		Expect(roles[4].RoleARN).To(Equal("arn:aws:iam::123:role/Ingress"))
+	})
+
+	It("Formats the policies and indents their documents", func() {
+		str := formatClusterRolePolicies([]*clusterRole{
+			{
+				Type:    "Installer",
+				RoleARN: "arn:aws:iam::123:role/Installer",
+				Policies: []aws.RolePolicy{{
+					PolicyName: "Installer-Policy",
+					PolicyType: aws.Attached,
+					Version:    "v2",
+					Document:   `{"Version":"2012-10-17"}`,
+				}},
+			},
+			{Type: "Worker", RoleARN: "arn:aws:iam::123:role/Worker"},
+		})
+		Expect(str).To(Equal("Role Policies:\n" +
+			" - Installer role: arn:aws:iam::123:role/Installer\n" +
+			"   - Installer-Policy (attached, v2)\n" +
+			"     {\n       \"Version\": \"2012-10-17\"\n     }\n" +
+			" - Worker role: arn:aws:iam::123:role/Worker\n" +
+			"   - No policies attached\n"))
+	})
+})
@@ -19,10 +19,17 @@ package describe
 import (
 	"github.com/spf13/cobra"
 
+	"github.com/openshift/rosa/cmd/describe/accountroles"
 	"github.com/openshift/rosa/cmd/describe/addon"
 	"github.com/openshift/rosa/cmd/describe/admin"
+	"github.com/openshift/rosa/cmd/describe/autoscaler"
+	"github.com/openshift/rosa/cmd/describe/breakglasscredential"
 	"github.com/openshift/rosa/cmd/describe/cluster"
 	"github.com/openshift/rosa/cmd/describe/installation"
+	"github.com/openshift/rosa/cmd/describe/kubeletconfig"
+	"github.com/openshift/rosa/cmd/describe/machinepool"
+	"github.com/openshift/rosa/cmd/describe/oidcconfig"
+	"github.com/openshift/rosa/cmd/describe/operatorroles"
 	"github.com/openshift/rosa/cmd/describe/service"
 	"github.com/openshift/rosa/cmd/describe/upgrade"
 	"github.com/openshift/rosa/pkg/arguments"
@@ -35,11 +42,18 @@ var Cmd = &cobra.Command{
 }
 
 func init() {
+	Cmd.AddCommand(accountroles.Cmd)
+	Cmd.AddCommand(autoscaler.Cmd)
 	Cmd.AddCommand(addon.Cmd)
 	Cmd.AddCommand(admin.Cmd)
+	Cmd.AddCommand(breakglasscredential.Cmd)
 	Cmd.AddCommand(cluster.Cmd)
 	Cmd.AddCommand(service.Cmd)
 	Cmd.AddCommand(installation.Cmd)
+	Cmd.AddCommand(kubeletconfig.Cmd)
+	Cmd.AddCommand(machinepool.Cmd)
+	Cmd.AddCommand(oidcconfig.Cmd)
+	Cmd.AddCommand(operatorroles.Cmd)
 	Cmd.AddCommand(upgrade.Cmd)
 
 	flags := Cmd.PersistentFlags()
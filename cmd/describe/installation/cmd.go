@@ -24,6 +24,7 @@ import (
 
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -59,6 +60,8 @@ func init() {
 		"",
 		"Name or ID of the addon installation (required).",
 	)
+
+	output.AddFlag(Cmd)
 }
 
 func run(_ *cobra.Command, argv []string) {
@@ -92,6 +95,10 @@ func describeAddonInstallation(r *rosa.Runtime, installationKey string) error {
 		return err
 	}
 
+	if output.HasFlag() {
+		return output.Print(installation)
+	}
+
 	fmt.Printf(`%-28s %s
 %-28s %s
 %-28s %s
@@ -100,6 +107,21 @@ func describeAddonInstallation(r *rosa.Runtime, installationKey string) error {
 		"Href:", installation.HREF(),
 		"Addon state:", installation.State(),
 	)
+	if installation.StateDescription() != "" {
+		fmt.Printf("%-28s %s\n", "State description:", installation.StateDescription())
+	}
+	if installation.AddonVersion().ID() != "" {
+		fmt.Printf("%-28s %s\n", "Version:", installation.AddonVersion().ID())
+	}
+	if installation.OperatorVersion() != "" {
+		fmt.Printf("%-28s %s\n", "Operator version:", installation.OperatorVersion())
+	}
+	if installation.Billing().BillingModel() != "" {
+		fmt.Printf("%-28s %s\n", "Billing model:", installation.Billing().BillingModel())
+	}
+	if installation.Billing().BillingMarketplaceAccount() != "" {
+		fmt.Printf("%-28s %s\n", "Billing account:", installation.Billing().BillingMarketplaceAccount())
+	}
 
 	parameters := installation.Parameters()
 	if parameters.Len() > 0 {
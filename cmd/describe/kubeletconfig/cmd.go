@@ -0,0 +1,116 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeletconfig
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var Cmd = &cobra.Command{
+	Use:     "kubeletconfig",
+	Aliases: []string{"kubelet-config", "kubeletconfigs", "kubelet-configs"},
+	Short:   "Show details of the custom kubelet configs of a cluster",
+	Long: "Show details of the custom kubelet config of a classic cluster, or of the named kubelet " +
+		"configs of a Hosted Control Plane cluster.",
+	Example: `  # Describe the kubelet config of cluster 'mycluster'
+  rosa describe kubeletconfig -c mycluster
+
+  # Describe kubelet config 'high-pids' of hosted cluster 'mycluster'
+  rosa describe kubeletconfig -c mycluster --name high-pids`,
+	Run:  run,
+	Args: cobra.NoArgs,
+}
+
+var args struct {
+	name string
+}
+
+func init() {
+	flags := Cmd.Flags()
+	ocm.AddClusterFlag(Cmd)
+	flags.StringVar(
+		&args.name,
+		"name",
+		"",
+		"Name of the kubelet config of a Hosted Control Plane cluster. All the kubelet configs are "+
+			"shown when not specified.",
+	)
+	output.AddFlag(Cmd)
+}
+
+func run(_ *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS().WithOCM()
+	defer r.Cleanup()
+
+	clusterKey := r.GetClusterKey()
+
+	cluster := r.FetchCluster()
+	if args.name != "" && !cluster.Hypershift().Enabled() {
+		r.Reporter.Errorf("The '--name' option is only supported for Hosted Control Plane clusters")
+		os.Exit(1)
+	}
+
+	r.Reporter.Debugf("Loading kubelet configs for cluster '%s'", clusterKey)
+	configs, err := r.OCMClient.GetKubeletConfigs(cluster)
+	if err != nil {
+		r.Reporter.Errorf("Failed to get kubelet configs for cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+	if args.name != "" {
+		var selected []*ocm.KubeletConfig
+		for _, config := range configs {
+			if config.Name == args.name {
+				selected = append(selected, config)
+			}
+		}
+		configs = selected
+	}
+	if len(configs) == 0 {
+		r.Reporter.Warnf("Cluster '%s' has no matching kubelet config", clusterKey)
+		os.Exit(0)
+	}
+
+	if output.HasFlag() {
+		var object interface{} = configs
+		if len(configs) == 1 {
+			object = configs[0]
+		}
+		err = output.Print(object)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	for i, config := range configs {
+		if i > 0 {
+			fmt.Println()
+		}
+		if config.Name != "" {
+			fmt.Printf("%-20s%s\n", "Name:", config.Name)
+		}
+		fmt.Printf("%-20s%d\n", "Pod PIDs Limit:", config.PodPidsLimit)
+	}
+}
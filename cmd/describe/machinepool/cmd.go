@@ -0,0 +1,218 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinepool
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var Cmd = &cobra.Command{
+	Use:     "machinepool ID",
+	Aliases: []string{"machinepools", "machine-pool", "machine-pools"},
+	Short:   "Show details of a machine pool",
+	Long:    "Show details of a machine pool on a cluster.",
+	Example: `  # Describe machine pool 'mp1' on cluster 'mycluster'
+  rosa describe machinepool --cluster=mycluster mp1
+
+  # Show the full machine pool object as JSON
+  rosa describe machinepool --cluster=mycluster mp1 -o json`,
+	Run: run,
+	Args: func(_ *cobra.Command, argv []string) error {
+		if len(argv) != 1 {
+			return fmt.Errorf(
+				"Expected exactly one command line parameter containing the id of the machine pool",
+			)
+		}
+		return nil
+	},
+}
+
+func init() {
+	ocm.AddClusterFlag(Cmd)
+	output.AddFlag(Cmd)
+}
+
+func run(_ *cobra.Command, argv []string) {
+	r := rosa.NewRuntime().WithAWS().WithOCM()
+	defer r.Cleanup()
+
+	machinePoolID := argv[0]
+	clusterKey := r.GetClusterKey()
+
+	cluster := r.FetchCluster()
+	if cluster.State() != cmv1.ClusterStateReady {
+		r.Reporter.Errorf("Cluster '%s' is not yet ready", clusterKey)
+		os.Exit(1)
+	}
+
+	if cluster.Hypershift().Enabled() {
+		describeNodePool(r, cluster, clusterKey, machinePoolID)
+	} else {
+		describeMachinePool(r, cluster, clusterKey, machinePoolID)
+	}
+}
+
+func describeMachinePool(r *rosa.Runtime, cluster *cmv1.Cluster, clusterKey string, machinePoolID string) {
+	r.Reporter.Debugf("Fetching machine pool '%s' for cluster '%s'", machinePoolID, clusterKey)
+	machinePool, err := r.OCMClient.GetMachinePool(cluster.ID(), machinePoolID)
+	if err != nil {
+		r.Reporter.Errorf("Failed to get machine pool '%s' for cluster '%s': %v",
+			machinePoolID, clusterKey, err)
+		os.Exit(1)
+	}
+
+	if output.HasFlag() {
+		printOutput(r, machinePool)
+		return
+	}
+
+	replicas := fmt.Sprintf("%d", machinePool.Replicas())
+	autoscaling := "No"
+	if machinePool.Autoscaling() != nil {
+		autoscaling = "Yes"
+		replicas = fmt.Sprintf("%d-%d",
+			machinePool.Autoscaling().MinReplicas(),
+			machinePool.Autoscaling().MaxReplicas())
+	}
+
+	fmt.Printf(`%-28s%s
+%-28s%s
+%-28s%s
+%-28s%s
+%-28s%s
+%-28s%s
+%-28s%s
+%-28s%s
+%-28s%s
+%-28s%s
+`,
+		"ID:", machinePool.ID(),
+		"Cluster ID:", cluster.ID(),
+		"Autoscaling:", autoscaling,
+		"Replicas:", replicas,
+		"Instance type:", machinePool.InstanceType(),
+		"Labels:", printLabels(machinePool.Labels()),
+		"Taints:", printTaints(machinePool.Taints()),
+		"Availability zones:", strings.Join(machinePool.AvailabilityZones(), ", "),
+		"Subnets:", strings.Join(machinePool.Subnets(), ", "),
+		"Additional security groups:", strings.Join(ocm.GetSecurityGroupIDs(machinePool.SecurityGroupFilters()), ", "),
+	)
+}
+
+func describeNodePool(r *rosa.Runtime, cluster *cmv1.Cluster, clusterKey string, nodePoolID string) {
+	r.Reporter.Debugf("Fetching machine pool '%s' for cluster '%s'", nodePoolID, clusterKey)
+	nodePool, err := r.OCMClient.GetNodePool(cluster.ID(), nodePoolID)
+	if err != nil {
+		r.Reporter.Errorf("Failed to get machine pool '%s' for cluster '%s': %v",
+			nodePoolID, clusterKey, err)
+		os.Exit(1)
+	}
+
+	if output.HasFlag() {
+		printOutput(r, nodePool)
+		return
+	}
+
+	replicas := fmt.Sprintf("%d", nodePool.Replicas())
+	autoscaling := "No"
+	if nodePool.Autoscaling() != nil {
+		autoscaling = "Yes"
+		replicas = fmt.Sprintf("%d-%d",
+			nodePool.Autoscaling().MinReplica(),
+			nodePool.Autoscaling().MaxReplica())
+	}
+	autorepair := "No"
+	if nodePool.AutoRepair() {
+		autorepair = "Yes"
+	}
+	autoUpgradeEnabled, err := r.OCMClient.GetNodePoolAutoUpgrade(cluster.ID(), nodePoolID)
+	if err != nil {
+		r.Reporter.Errorf("Failed to get upgrade policies of machine pool '%s' for cluster '%s': %v",
+			nodePoolID, clusterKey, err)
+		os.Exit(1)
+	}
+	autoupgrade := "No"
+	if autoUpgradeEnabled {
+		autoupgrade = "Yes"
+	}
+
+	fmt.Printf(`%-28s%s
+%-28s%s
+%-28s%s
+%-28s%s
+%-28s%d
+%-28s%s
+%-28s%s
+%-28s%s
+%-28s%s
+%-28s%s
+%-28s%s
+%-28s%s
+%-28s%s
+%-28s%s
+`,
+		"ID:", nodePool.ID(),
+		"Cluster ID:", cluster.ID(),
+		"Autoscaling:", autoscaling,
+		"Desired replicas:", replicas,
+		"Current replicas:", nodePool.Status().CurrentReplicas(),
+		"Instance type:", nodePool.AWSNodePool().InstanceType(),
+		"Labels:", printLabels(nodePool.Labels()),
+		"Taints:", printTaints(nodePool.Taints()),
+		"Availability zone:", nodePool.AvailabilityZone(),
+		"Subnet:", nodePool.Subnet(),
+		"Version:", ocm.GetRawVersionId(nodePool.Version().ID()),
+		"Autoupgrade:", autoupgrade,
+		"Autorepair:", autorepair,
+		"Message:", nodePool.Status().Message(),
+	)
+}
+
+func printOutput(r *rosa.Runtime, resource interface{}) {
+	err := output.Print(resource)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+}
+
+func printLabels(labels map[string]string) string {
+	output := []string{}
+	for k, v := range labels {
+		output = append(output, fmt.Sprintf("%s=%s", k, v))
+	}
+	sort.Strings(output)
+	return strings.Join(output, ", ")
+}
+
+func printTaints(taints []*cmv1.Taint) string {
+	output := []string{}
+	for _, taint := range taints {
+		output = append(output, fmt.Sprintf("%s=%s:%s", taint.Key(), taint.Value(), taint.Effect()))
+	}
+	return strings.Join(output, ", ")
+}
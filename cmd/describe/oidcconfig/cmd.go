@@ -0,0 +1,126 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oidcconfig
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/interactive"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var Cmd = &cobra.Command{
+	Use:     "oidc-config",
+	Aliases: []string{"oidcconfig"},
+	Short:   "Show details of an OIDC Configuration",
+	Long: "Show details of an OIDC Configuration, including the S3 bucket, issuer URL and private key " +
+		"secret of unmanaged configurations.",
+	Example: `  # Describe the OIDC Configuration with ID "2ab3c4d5e6f7"
+  rosa describe oidc-config --oidc-config-id 2ab3c4d5e6f7`,
+	Run: run,
+}
+
+var args struct {
+	oidcConfigId string
+}
+
+func init() {
+	flags := Cmd.Flags()
+
+	flags.StringVar(
+		&args.oidcConfigId,
+		"oidc-config-id",
+		"",
+		"Registered ID for identification of OIDC config",
+	)
+
+	output.AddFlag(Cmd)
+}
+
+func run(cmd *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS().WithOCM()
+	defer r.Cleanup()
+
+	if args.oidcConfigId == "" {
+		args.oidcConfigId = interactive.GetOidcConfigID(r, cmd)
+	}
+	if args.oidcConfigId == "" {
+		r.Reporter.Errorf("Expected a valid OIDC Config ID")
+		os.Exit(1)
+	}
+
+	r.Reporter.Debugf("Loading OIDC Config '%s'", args.oidcConfigId)
+	oidcConfig, err := r.OCMClient.GetOidcConfig(args.oidcConfigId)
+	if err != nil {
+		r.Reporter.Errorf("There was a problem retrieving the OIDC Config '%s': %v", args.oidcConfigId, err)
+		os.Exit(1)
+	}
+
+	if output.HasFlag() {
+		err = output.Print(oidcConfig)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	str := fmt.Sprintf("\n"+
+		"ID:                         %s\n"+
+		"Managed:                    %v\n"+
+		"Issuer URL:                 %s\n",
+		oidcConfig.ID(),
+		oidcConfig.Managed(),
+		oidcConfig.IssuerUrl(),
+	)
+	if !oidcConfig.Managed() {
+		bucketName := aws.GetBucketNameFromIssuerUrl(oidcConfig.IssuerUrl())
+		if bucketName == "" {
+			bucketName = "N/A"
+		}
+		secretRegion := ""
+		parsedSecretArn, err := arn.Parse(oidcConfig.SecretArn())
+		if err == nil {
+			secretRegion = parsedSecretArn.Region
+		}
+		str = fmt.Sprintf("%s"+
+			"S3 Bucket:                  %s\n"+
+			"Private Key Secret ARN:     %s\n"+
+			"Private Key Secret Region:  %s\n"+
+			"Installer Role ARN:         %s\n",
+			str,
+			bucketName,
+			oidcConfig.SecretArn(),
+			secretRegion,
+			oidcConfig.InstallerRoleArn(),
+		)
+	}
+	if !oidcConfig.CreationTimestamp().IsZero() {
+		str = fmt.Sprintf("%s"+
+			"Created:                    %s\n",
+			str,
+			oidcConfig.CreationTimestamp().Format("Jan 02 2006 15:04:05 MST"),
+		)
+	}
+	fmt.Print(str)
+}
@@ -0,0 +1,203 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operatorroles
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/aws/tags"
+	"github.com/openshift/rosa/pkg/helper/roles"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var args struct {
+	drift bool
+}
+
+var Cmd = &cobra.Command{
+	Use:     "operator-roles",
+	Aliases: []string{"operatorrole", "operator-role", "operatorroles"},
+	Short:   "Show details of the operator roles of a cluster",
+	Long: "Show details of the operator roles of a cluster, and optionally check if their trust " +
+		"policies, attached policies and tags have drifted from the expected ones.",
+	Example: `  # Describe the operator roles of cluster "mycluster"
+  rosa describe operator-roles -c mycluster
+
+  # Check if the operator roles of cluster "mycluster" have drifted from the expected configuration
+  rosa describe operator-roles -c mycluster --drift`,
+	Run: run,
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
+	ocm.AddClusterFlag(Cmd)
+	flags.BoolVar(
+		&args.drift,
+		"drift",
+		false,
+		"Compare the trust policies, attached policies and tags of the roles with the expected ones, "+
+			"and show the commands that fix the differences.",
+	)
+	output.AddFlag(Cmd)
+}
+
+func run(_ *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS().WithOCM()
+	defer r.Cleanup()
+
+	clusterKey := r.GetClusterKey()
+	cluster := r.FetchCluster()
+	if cluster.AWS().STS().RoleARN() == "" {
+		r.Reporter.Errorf("Cluster '%s' is not an STS cluster", clusterKey)
+		os.Exit(1)
+	}
+
+	if !args.drift {
+		describeRoles(r, cluster)
+		return
+	}
+
+	credRequests, err := r.OCMClient.GetCredRequests(cluster.Hypershift().Enabled())
+	if err != nil {
+		r.Reporter.Errorf("Error getting operator credential request from OCM %s", err)
+		os.Exit(1)
+	}
+	policies, err := r.OCMClient.GetPolicies("OperatorRole")
+	if err != nil {
+		r.Reporter.Errorf("Failed to get the expected policies of the operator roles: %v", err)
+		os.Exit(1)
+	}
+	prefix, err := aws.GetOperatorRolePolicyPrefixFromCluster(cluster, r.AWSClient)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+	path, err := aws.GetPathFromAccountRole(cluster, aws.AccountRoles[aws.InstallerAccountRole].Name)
+	if err != nil {
+		r.Reporter.Errorf("Expected a valid path for '%s': %v", cluster.AWS().STS().RoleARN(), err)
+		os.Exit(1)
+	}
+
+	credRequestNames := make([]string, 0, len(credRequests))
+	for credRequestName := range credRequests {
+		credRequestNames = append(credRequestNames, credRequestName)
+	}
+	sort.Strings(credRequestNames)
+
+	expectedRoles := []*aws.ExpectedRole{}
+	for _, credRequestName := range credRequestNames {
+		operator := credRequests[credRequestName]
+		roleName, found := aws.FindOperatorRoleNameBySTSOperator(cluster, operator)
+		if !found {
+			continue
+		}
+		expectedRole, err := buildExpectedRole(r, cluster, prefix, path, credRequestName, operator,
+			roleName, policies)
+		if err != nil {
+			r.Reporter.Errorf("Failed to get the expected configuration of role '%s': %v", roleName, err)
+			os.Exit(1)
+		}
+		expectedRoles = append(expectedRoles, expectedRole)
+	}
+	roles.DetectDrift(r, expectedRoles, fmt.Sprintf("Run 'rosa create operator-roles -c %s' to create it.",
+		clusterKey))
+}
+
+func describeRoles(r *rosa.Runtime, cluster *cmv1.Cluster) {
+	operatorRoles := cluster.AWS().STS().OperatorIAMRoles()
+	if output.HasFlag() {
+		err := output.Print(operatorRoles)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "OPERATOR NAME\tOPERATOR NAMESPACE\tROLE ARN\n")
+	for _, operatorRole := range operatorRoles {
+		fmt.Fprintf(writer, "%s\t%s\t%s\n",
+			operatorRole.Name(),
+			operatorRole.Namespace(),
+			operatorRole.RoleARN(),
+		)
+	}
+	writer.Flush()
+}
+
+// buildExpectedRole returns the configuration that the operator role would have if it was created
+// now with 'rosa create operator-roles'.
+func buildExpectedRole(r *rosa.Runtime, cluster *cmv1.Cluster, prefix string, path string,
+	credRequestName string, operator *cmv1.STSOperator, roleName string,
+	policies map[string]*cmv1.AWSSTSPolicy) (*aws.ExpectedRole, error) {
+	trustPolicy, err := aws.GenerateOperatorRolePolicyDoc(cluster, r.Creator.AccountID, operator,
+		aws.GetPolicyDetails(policies, "operator_iam_role_policy"))
+	if err != nil {
+		return nil, err
+	}
+
+	managedPolicies := cluster.AWS().STS().ManagedPolicies()
+	hostedCPPolicies := aws.IsHostedCPManagedPolicies(cluster)
+	roleTags := map[string]string{
+		tags.OperatorNamespace: operator.Namespace(),
+		tags.OperatorName:      operator.Name(),
+		tags.RedHatManaged:     tags.True,
+	}
+	oidcConfig := cluster.AWS().STS().OidcConfig()
+	if oidcConfig == nil || !oidcConfig.Reusable() {
+		roleTags[tags.ClusterID] = cluster.ID()
+	}
+	if managedPolicies {
+		roleTags[tags.ManagedPolicies] = tags.True
+	}
+	if hostedCPPolicies {
+		roleTags[tags.HypershiftPolicies] = tags.True
+	}
+
+	policyKey := aws.GetOperatorPolicyKey(credRequestName, hostedCPPolicies)
+	expectedPolicies := map[string]string{}
+	if managedPolicies {
+		policyARN, err := aws.GetManagedPolicyARN(policies, policyKey)
+		if err != nil {
+			return nil, err
+		}
+		expectedPolicies[policyARN] = ""
+	} else {
+		policyARN := aws.GetOperatorPolicyARN(r.Creator.AccountID, prefix, operator.Namespace(),
+			operator.Name(), path)
+		expectedPolicies[policyARN] = aws.GetPolicyDetails(policies, policyKey)
+	}
+
+	return &aws.ExpectedRole{
+		RoleName:    roleName,
+		TrustPolicy: trustPolicy,
+		Policies:    expectedPolicies,
+		Tags:        roleTags,
+	}, nil
+}
@@ -19,10 +19,13 @@ package upgrade
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/openshift/rosa/pkg/helper/upgrades"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -39,6 +42,7 @@ var Cmd = &cobra.Command{
 
 func init() {
 	ocm.AddClusterFlag(Cmd)
+	output.AddFlag(Cmd)
 }
 
 func run(cmd *cobra.Command, argv []string) {
@@ -61,6 +65,10 @@ func returnHypershiftUpgrades(r *rosa.Runtime, clusterID string) {
 		r.Reporter.Errorf("Failed to get upgrade with cluster id '%s': %v", clusterID, err)
 		os.Exit(1)
 	}
+	if output.HasFlag() {
+		printUpgrades(r, upgrades)
+		return
+	}
 	if len(upgrades) < 1 {
 		r.Reporter.Warnf("No scheduled upgrades for cluster id '%s'", clusterID)
 		os.Exit(1)
@@ -77,8 +85,7 @@ func returnHypershiftUpgrades(r *rosa.Runtime, clusterID string) {
 			"Next Run:", upgrade.NextRun(),
 			"Upgrade State:", upgrade.State().Value())
 		if upgrade.Schedule() != "" {
-			fmt.Printf(`                %-28s%s
-`, "Schedule At:", upgrade.Schedule())
+			printSchedule(upgrade.ScheduleType(), upgrade.Schedule())
 		}
 		if upgrade.Version() != "" {
 			fmt.Printf(`                %-28s%s
@@ -93,6 +100,10 @@ func returnClassicUpgrades(r *rosa.Runtime, clusterID string) {
 		r.Reporter.Errorf("Failed to get upgrade with cluster id '%s': %v", clusterID, err)
 		os.Exit(1)
 	}
+	if output.HasFlag() {
+		printUpgrades(r, upgrades)
+		return
+	}
 	_, upgradeState, err := r.OCMClient.GetScheduledUpgrade(clusterID)
 	if err != nil {
 		r.Reporter.Errorf("Failed to get scheduled upgrades for cluster '%s': %v", clusterID, err)
@@ -114,8 +125,7 @@ func returnClassicUpgrades(r *rosa.Runtime, clusterID string) {
 			"Next Run:", upgrade.NextRun(),
 			"Upgrade State:", upgradeState.Value())
 		if upgrade.Schedule() != "" {
-			fmt.Printf(`                %-28s%s
-`, "Schedule At:", upgrade.Schedule())
+			printSchedule(upgrade.ScheduleType(), upgrade.Schedule())
 		}
 		if upgrade.Version() != "" {
 			fmt.Printf(`                %-28s%s
@@ -123,3 +133,27 @@ func returnClassicUpgrades(r *rosa.Runtime, clusterID string) {
 		}
 	}
 }
+
+// printSchedule prints the cron schedule of a recurring upgrade policy and when it will run next.
+func printSchedule(scheduleType string, schedule string) {
+	fmt.Printf(`                %-28s%s
+                %-28s%s
+`, "Schedule Type:", scheduleType, "Schedule At:", schedule)
+	parsed, err := upgrades.ParseSchedule(schedule)
+	if err != nil {
+		return
+	}
+	nextOccurrence := parsed.Next(time.Now().UTC())
+	if !nextOccurrence.IsZero() {
+		fmt.Printf(`                %-28s%s
+`, "Next Occurrence:", nextOccurrence.Format("2006-01-02 15:04 MST"))
+	}
+}
+
+func printUpgrades(r *rosa.Runtime, upgrades interface{}) {
+	err := output.Print(upgrades)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,131 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	attachpolicy "github.com/openshift/rosa/cmd/attach/policy"
+	"github.com/openshift/rosa/pkg/aws"
+	awscb "github.com/openshift/rosa/pkg/aws/commandbuilder"
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var args struct {
+	roleName   string
+	policyARNs []string
+}
+
+var Cmd = &cobra.Command{
+	Use:     "policy",
+	Aliases: []string{"policies"},
+	Short:   "Detach policies from a role",
+	Long:    "Detach customer managed policies previously attached to a role created by rosa.",
+	Example: `  # Detach a policy from the installer account role
+  rosa detach policy --role-name ManagedOpenShift-Installer-Role \
+  --policy-arns arn:aws:iam::123456789012:policy/CorporatePolicy`,
+	Args: cobra.NoArgs,
+	Run:  run,
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
+	flags.StringVar(
+		&args.roleName,
+		"role-name",
+		"",
+		"Name of the role to detach the policies from.",
+	)
+	flags.StringSliceVar(
+		&args.policyARNs,
+		"policy-arns",
+		[]string{},
+		"Comma-separated list of ARNs of the policies to detach.",
+	)
+	aws.AddModeFlag(Cmd)
+	Cmd.MarkFlagRequired("role-name")
+	Cmd.MarkFlagRequired("policy-arns")
+}
+
+func run(_ *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS()
+	defer r.Cleanup()
+
+	mode, err := aws.GetMode()
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+	if mode == "" {
+		mode = aws.ModeAuto
+	}
+
+	err = attachpolicy.ValidateRosaRole(r, args.roleName)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+	for _, policyARN := range args.policyARNs {
+		attached, err := r.AWSClient.IsRolePolicyAttached(args.roleName, policyARN)
+		if err != nil {
+			r.Reporter.Errorf("Failed to get the policies attached to role '%s': %v", args.roleName, err)
+			os.Exit(1)
+		}
+		if !attached {
+			r.Reporter.Errorf("Policy '%s' is not attached to role '%s'", policyARN, args.roleName)
+			os.Exit(1)
+		}
+	}
+
+	switch mode {
+	case aws.ModeAuto:
+		for _, policyARN := range args.policyARNs {
+			if !confirm.Prompt(true, "Detach policy '%s' from role '%s'?", policyARN, args.roleName) {
+				continue
+			}
+			err = r.AWSClient.DetachRolePolicy(args.roleName, policyARN)
+			if err != nil {
+				r.Reporter.Errorf("Failed to detach policy '%s' from role '%s': %v", policyARN, args.roleName, err)
+				os.Exit(1)
+			}
+			r.Reporter.Infof("Detached policy '%s' from role '%s'", policyARN, args.roleName)
+		}
+	case aws.ModeManual:
+		commands := []string{}
+		for _, policyARN := range args.policyARNs {
+			commands = append(commands, awscb.NewIAMCommandBuilder().
+				SetCommand(awscb.DetachRolePolicy).
+				AddParam(awscb.RoleName, args.roleName).
+				AddParam(awscb.PolicyArn, policyARN).
+				Build())
+		}
+		if r.Reporter.IsTerminal() {
+			r.Reporter.Infof("Run the following commands to detach the policies:\n")
+		}
+		fmt.Println(strings.Join(commands, "\n"))
+	default:
+		r.Reporter.Errorf("Invalid mode. Allowed values are %s", aws.Modes)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,123 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnose
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/openshift/rosa/pkg/config"
+)
+
+const redacted = "***"
+
+// bundle writes the files collected for a support case to a compressed tarball. Failures to
+// collect individual pieces of information don't abort the collection, instead they are recorded
+// and written to the 'errors.txt' file of the bundle.
+type bundle struct {
+	gzipWriter *gzip.Writer
+	tarWriter  *tar.Writer
+	dir        string
+	now        time.Time
+	errors     []string
+}
+
+func newBundle(out io.Writer, dir string, now time.Time) *bundle {
+	gzipWriter := gzip.NewWriter(out)
+	return &bundle{
+		gzipWriter: gzipWriter,
+		tarWriter:  tar.NewWriter(gzipWriter),
+		dir:        dir,
+		now:        now,
+	}
+}
+
+// addFile adds a file with the given name and content to the bundle.
+func (b *bundle) addFile(name string, data []byte) error {
+	header := &tar.Header{
+		Name:    b.dir + "/" + name,
+		Mode:    0600,
+		Size:    int64(len(data)),
+		ModTime: b.now,
+	}
+	err := b.tarWriter.WriteHeader(header)
+	if err != nil {
+		return err
+	}
+	_, err = b.tarWriter.Write(data)
+	return err
+}
+
+// addJSON adds a file containing the indented JSON representation of the given value.
+func (b *bundle) addJSON(name string, value interface{}) error {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return err
+	}
+	return b.addFile(name, append(data, '\n'))
+}
+
+// addError records that the given piece of information couldn't be collected.
+func (b *bundle) addError(what string, err error) {
+	b.errors = append(b.errors, fmt.Sprintf("%s: %v", what, err))
+}
+
+// close writes the collection errors, if any, and flushes the bundle.
+func (b *bundle) close() error {
+	if len(b.errors) > 0 {
+		err := b.addFile("errors.txt", []byte(strings.Join(b.errors, "\n")+"\n"))
+		if err != nil {
+			return err
+		}
+	}
+	err := b.tarWriter.Close()
+	if err != nil {
+		return err
+	}
+	return b.gzipWriter.Close()
+}
+
+// redactConfig returns a copy of the configuration where the tokens and the client secret are
+// replaced, so that the bundle can be shared without giving access to the account.
+func redactConfig(cfg *config.Config) *config.Config {
+	result := *cfg
+	if result.AccessToken != "" {
+		result.AccessToken = redacted
+	}
+	if result.RefreshToken != "" {
+		result.RefreshToken = redacted
+	}
+	if result.ClientSecret != "" {
+		result.ClientSecret = redacted
+	}
+	return &result
+}
+
+// versionInfo returns the content of the 'version.txt' file of the bundle.
+func versionInfo(version, goVersion, goos, goarch string) []byte {
+	var buffer bytes.Buffer
+	fmt.Fprintf(&buffer, "Version: %s\n", version)
+	fmt.Fprintf(&buffer, "Go version: %s\n", goVersion)
+	fmt.Fprintf(&buffer, "Platform: %s/%s\n", goos, goarch)
+	return buffer.Bytes()
+}
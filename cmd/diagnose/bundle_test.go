@@ -0,0 +1,99 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnose
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/rosa/pkg/config"
+)
+
+func readBundle(data []byte) map[string]string {
+	gzipReader, err := gzip.NewReader(bytes.NewReader(data))
+	Expect(err).ToNot(HaveOccurred())
+	tarReader := tar.NewReader(gzipReader)
+	files := map[string]string{}
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		Expect(err).ToNot(HaveOccurred())
+		content, err := io.ReadAll(tarReader)
+		Expect(err).ToNot(HaveOccurred())
+		files[header.Name] = string(content)
+	}
+	return files
+}
+
+var _ = Describe("Bundle", func() {
+	It("Writes the files and the collection errors", func() {
+		var buffer bytes.Buffer
+		b := newBundle(&buffer, "rosa-diagnose", time.Now())
+		Expect(b.addFile("version.txt", []byte("Version: 1.2.3\n"))).To(Succeed())
+		Expect(b.addJSON("checks.json", []string{"a"})).To(Succeed())
+		b.addError("install logs", errors.New("not found"))
+		Expect(b.close()).To(Succeed())
+
+		files := readBundle(buffer.Bytes())
+		Expect(files).To(HaveLen(3))
+		Expect(files).To(HaveKeyWithValue("rosa-diagnose/version.txt", "Version: 1.2.3\n"))
+		Expect(files).To(HaveKeyWithValue("rosa-diagnose/checks.json", "[\n  \"a\"\n]\n"))
+		Expect(files).To(HaveKeyWithValue("rosa-diagnose/errors.txt", "install logs: not found\n"))
+	})
+
+	It("Doesn't write the errors file when there are no errors", func() {
+		var buffer bytes.Buffer
+		b := newBundle(&buffer, "rosa-diagnose", time.Now())
+		Expect(b.addFile("version.txt", []byte("Version: 1.2.3\n"))).To(Succeed())
+		Expect(b.close()).To(Succeed())
+		Expect(readBundle(buffer.Bytes())).ToNot(HaveKey("rosa-diagnose/errors.txt"))
+	})
+})
+
+var _ = Describe("Redact config", func() {
+	It("Replaces the tokens and the client secret", func() {
+		cfg := &config.Config{
+			AccessToken:  "access",
+			RefreshToken: "refresh",
+			ClientID:     "my-client",
+			ClientSecret: "secret",
+			URL:          "https://api.openshift.com",
+		}
+		result := redactConfig(cfg)
+		Expect(result.AccessToken).To(Equal(redacted))
+		Expect(result.RefreshToken).To(Equal(redacted))
+		Expect(result.ClientSecret).To(Equal(redacted))
+		Expect(result.ClientID).To(Equal("my-client"))
+		Expect(result.URL).To(Equal("https://api.openshift.com"))
+		Expect(cfg.AccessToken).To(Equal("access"))
+	})
+
+	It("Leaves empty values empty", func() {
+		result := redactConfig(&config.Config{AccessToken: "access"})
+		Expect(result.RefreshToken).To(BeEmpty())
+		Expect(result.ClientSecret).To(BeEmpty())
+	})
+})
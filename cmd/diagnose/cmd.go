@@ -0,0 +1,213 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnose
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"runtime"
+	"time"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/config"
+	"github.com/openshift/rosa/pkg/info"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+// installLogsTail is the number of lines of the install logs included in the bundle.
+const installLogsTail = 2000
+
+var args struct {
+	outputFile string
+}
+
+var Cmd = &cobra.Command{
+	Use:   "diagnose",
+	Short: "Collect diagnostic information about a cluster",
+	Long: "Collect the version and configuration of the CLI, the account information, the description " +
+		"of the cluster, its recent install logs, the status of its operator roles and the errors of " +
+		"its inflight checks into a single tarball that can be attached to a Red Hat support case. " +
+		"Tokens and secrets are redacted from the configuration.",
+	Example: `  # Collect diagnostic information about a cluster named "mycluster"
+  rosa diagnose --cluster=mycluster
+
+  # Write the bundle to a specific file
+  rosa diagnose --cluster=mycluster --output-file=/tmp/mycluster.tar.gz`,
+	Run:  run,
+	Args: cobra.NoArgs,
+}
+
+func init() {
+	flags := Cmd.Flags()
+
+	ocm.AddClusterFlag(Cmd)
+
+	flags.StringVar(
+		&args.outputFile,
+		"output-file",
+		"",
+		"Name of the file where the bundle will be written. Defaults to "+
+			"'rosa-diagnose-<cluster id>-<timestamp>.tar.gz' in the current directory.",
+	)
+}
+
+func run(_ *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS().WithOCM()
+	defer r.Cleanup()
+
+	clusterKey := r.GetClusterKey()
+	cluster := r.FetchCluster()
+
+	now := time.Now().UTC()
+	name := fmt.Sprintf("rosa-diagnose-%s-%s", cluster.ID(), now.Format("20060102150405"))
+	outputFile := args.outputFile
+	if outputFile == "" {
+		outputFile = name + ".tar.gz"
+	}
+
+	file, err := os.OpenFile(outputFile, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		r.Reporter.Errorf("Failed to create file '%s': %v", outputFile, err)
+		os.Exit(1)
+	}
+	defer file.Close()
+
+	r.Reporter.Infof("Collecting diagnostic information for cluster '%s'", clusterKey)
+	b := newBundle(file, name, now)
+	collect(r, b, cluster)
+	err = b.close()
+	if err != nil {
+		r.Reporter.Errorf("Failed to write file '%s': %v", outputFile, err)
+		os.Exit(1)
+	}
+
+	for _, collectErr := range b.errors {
+		r.Reporter.Warnf("Failed to collect %s", collectErr)
+	}
+	r.Reporter.Infof("Diagnostic information written to '%s'. Attach it to your support case.", outputFile)
+}
+
+// collect adds the diagnostic information to the bundle. Information that can't be collected is
+// recorded in the bundle instead of aborting, as the rest is still useful for support.
+func collect(r *rosa.Runtime, b *bundle, cluster *cmv1.Cluster) {
+	step := func(what string, err error) {
+		if err != nil {
+			b.addError(what, err)
+		}
+	}
+
+	step("version", b.addFile("version.txt",
+		versionInfo(info.Version, runtime.Version(), runtime.GOOS, runtime.GOARCH)))
+
+	cfg, err := config.Load()
+	if err == nil && cfg != nil {
+		err = b.addJSON("config.json", redactConfig(cfg))
+	}
+	step("configuration", err)
+
+	whoami, err := getWhoami(r, cfg)
+	if err == nil {
+		err = b.addJSON("whoami.json", whoami)
+	}
+	step("account information", err)
+
+	var buffer bytes.Buffer
+	err = cmv1.MarshalCluster(cluster, &buffer)
+	if err == nil {
+		err = b.addFile("cluster.json", buffer.Bytes())
+	}
+	step("cluster description", err)
+
+	logs, err := r.OCMClient.GetInstallLogs(cluster.ID(), installLogsTail)
+	if err == nil {
+		err = b.addFile("install-logs.txt", []byte(logs.Content()))
+	}
+	step("install logs", err)
+
+	if cluster.AWS().STS().RoleARN() != "" {
+		step("operator roles", b.addJSON("operator-roles.json", getOperatorRoles(r, cluster)))
+	}
+
+	checks, err := r.OCMClient.GetInflightChecks(cluster.ID())
+	if err == nil {
+		buffer.Reset()
+		err = cmv1.MarshalInflightCheckList(checks, &buffer)
+	}
+	if err == nil {
+		err = b.addFile("inflight-checks.json", buffer.Bytes())
+	}
+	step("inflight checks", err)
+}
+
+func getWhoami(r *rosa.Runtime, cfg *config.Config) (map[string]string, error) {
+	result := map[string]string{
+		"AWS Account ID": r.Creator.AccountID,
+		"AWS ARN":        r.Creator.ARN,
+	}
+	awsRegion, err := aws.GetRegion("")
+	if err != nil {
+		return nil, err
+	}
+	result["AWS Region"] = awsRegion
+	if cfg != nil {
+		result["OCM API"] = cfg.URL
+	}
+	account, err := r.OCMClient.GetCurrentAccount()
+	if err != nil {
+		return nil, err
+	}
+	if account != nil {
+		result["OCM Account ID"] = account.ID()
+		result["OCM Account Username"] = account.Username()
+		result["OCM Organization ID"] = account.Organization().ID()
+		result["OCM Organization External ID"] = account.Organization().ExternalID()
+	}
+	return result, nil
+}
+
+type operatorRole struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	RoleARN   string `json:"role_arn"`
+	Exists    bool   `json:"exists"`
+	Error     string `json:"error,omitempty"`
+}
+
+func getOperatorRoles(r *rosa.Runtime, cluster *cmv1.Cluster) []operatorRole {
+	result := []operatorRole{}
+	for _, role := range cluster.AWS().STS().OperatorIAMRoles() {
+		status := operatorRole{
+			Namespace: role.Namespace(),
+			Name:      role.Name(),
+			RoleARN:   role.RoleARN(),
+		}
+		roleName, err := aws.GetResourceIdFromARN(role.RoleARN())
+		if err == nil {
+			status.Exists, _, err = r.AWSClient.CheckRoleExists(roleName)
+		}
+		if err != nil {
+			status.Error = err.Error()
+		}
+		result = append(result, status)
+	}
+	return result
+}
@@ -0,0 +1,75 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package autoscaler
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var Cmd = &cobra.Command{
+	Use:     "autoscaler",
+	Aliases: []string{"cluster-autoscaler"},
+	Short:   "Delete the autoscaler of a cluster",
+	Long:    "Delete the cluster-wide autoscaler of a classic cluster.",
+	Example: `  # Delete the autoscaler of cluster 'mycluster'
+  rosa delete autoscaler --cluster=mycluster`,
+	Run:  run,
+	Args: cobra.NoArgs,
+}
+
+func init() {
+	ocm.AddClusterFlag(Cmd)
+}
+
+func run(_ *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS().WithOCM()
+	defer r.Cleanup()
+
+	clusterKey := r.GetClusterKey()
+
+	cluster := r.FetchCluster()
+	if cluster.Hypershift().Enabled() {
+		r.Reporter.Errorf("Hosted Control Plane clusters do not support cluster-autoscaler configuration")
+		os.Exit(1)
+	}
+
+	autoscaler, err := r.OCMClient.GetClusterAutoscaler(cluster.ID())
+	if err != nil {
+		r.Reporter.Errorf("Failed to get autoscaler for cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+	if autoscaler == nil {
+		r.Reporter.Warnf("Cluster '%s' has no autoscaler", clusterKey)
+		os.Exit(0)
+	}
+
+	if confirm.Confirm("delete the autoscaler of cluster %s", clusterKey) {
+		r.Reporter.Debugf("Deleting autoscaler for cluster '%s'", clusterKey)
+		err = r.OCMClient.DeleteClusterAutoscaler(cluster.ID())
+		if err != nil {
+			r.Reporter.Errorf("Failed to delete autoscaler for cluster '%s': %v", clusterKey, err)
+			os.Exit(1)
+		}
+		r.Reporter.Infof("Successfully deleted autoscaler for cluster '%s'", clusterKey)
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/spf13/cobra"
@@ -30,21 +31,27 @@ import (
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/interactive/confirm"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
 	"github.com/openshift/rosa/pkg/rosa"
+	"github.com/openshift/rosa/pkg/wait"
 )
 
 var args struct {
 	// Watch logs during cluster uninstallation
 	watch bool
 	mode  string
+	wait  *wait.Options
 }
 
 var Cmd = &cobra.Command{
 	Use:   "cluster",
 	Short: "Delete cluster",
-	Long:  "Delete cluster.",
+	Long:  "Delete cluster.\n\n" + wait.ExitCodesHelp,
 	Example: `  # Delete a cluster named "mycluster"
-  rosa delete cluster --cluster=mycluster`,
+  rosa delete cluster --cluster=mycluster
+
+  # Delete a cluster and wait until it is uninstalled
+  rosa delete cluster --cluster=mycluster --yes --wait --timeout=1h`,
 	Run: run,
 }
 
@@ -60,14 +67,26 @@ func init() {
 		false,
 		"Watch cluster uninstallation logs.",
 	)
+
+	args.wait = wait.AddFlags(flags, "the cluster is uninstalled", 60*time.Minute)
+	output.AddFlag(Cmd)
 }
 
+// waitInterval is the time between checks of the cluster when '--wait' is used.
+const waitInterval = 30 * time.Second
+
 func run(cmd *cobra.Command, _ []string) {
 	r := rosa.NewRuntime().WithAWS().WithOCM()
 	defer r.Cleanup()
 
 	clusterKey := r.GetClusterKey()
 
+	if r.FetchCluster().DeleteProtection().Enabled() {
+		r.Reporter.Errorf("Cluster '%s' is protected against deletion. To delete it, first disable the "+
+			"protection with 'rosa edit cluster -c %s --disable-delete-protection'", clusterKey, clusterKey)
+		os.Exit(1)
+	}
+
 	if !confirm.Confirm("delete cluster %s", clusterKey) {
 		os.Exit(0)
 	}
@@ -78,9 +97,12 @@ func run(cmd *cobra.Command, _ []string) {
 		r.Reporter.Errorf("%s", err)
 		os.Exit(1)
 	}
-	r.Reporter.Infof("Cluster '%s' will start uninstalling now", clusterKey)
+	verbose := !output.HasFlag() || r.Reporter.IsTerminal()
+	if verbose {
+		r.Reporter.Infof("Cluster '%s' will start uninstalling now", clusterKey)
+	}
 
-	if cluster.AWS().STS().RoleARN() != "" {
+	if verbose && cluster.AWS().STS().RoleARN() != "" {
 		interactive.Enable()
 		r.Reporter.Infof(
 			"Your cluster '%s' will be deleted but the following objects may remain",
@@ -103,6 +125,14 @@ func run(cmd *cobra.Command, _ []string) {
 	}
 	if args.watch {
 		uninstallLogs.Cmd.Run(uninstallLogs.Cmd, []string{clusterKey})
+	} else if args.wait.Enabled {
+		waitForUninstall(r, cluster, clusterKey)
+	} else if output.HasFlag() {
+		err = output.Print(cluster)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
 	} else {
 		r.Reporter.Infof("To watch your cluster uninstallation logs, run 'rosa logs uninstall -c %s --watch'",
 			clusterKey,
@@ -110,6 +140,43 @@ func run(cmd *cobra.Command, _ []string) {
 	}
 }
 
+// waitForUninstall waits until the cluster no longer exists, prints its last known state when an
+// output format is given and exits with the code that corresponds to the outcome.
+func waitForUninstall(r *rosa.Runtime, cluster *cmv1.Cluster, clusterKey string) {
+	if !output.HasFlag() || r.Reporter.IsTerminal() {
+		r.Reporter.Infof("Waiting up to %s for cluster '%s' to be uninstalled", args.wait.Timeout,
+			clusterKey)
+	}
+	last := cluster
+	err := args.wait.Poll(waitInterval, func() (bool, error) {
+		current, found, err := r.OCMClient.FindClusterByID(cluster.ID())
+		if err != nil {
+			return false, wait.Transient(err)
+		}
+		if !found {
+			return true, nil
+		}
+		last = current
+		if current.State() == cmv1.ClusterStateError {
+			return false, fmt.Errorf("Cluster is in '%s' state", current.State())
+		}
+		return false, nil
+	})
+	if output.HasFlag() {
+		if printErr := output.Print(last); printErr != nil {
+			r.Reporter.Errorf("%s", printErr)
+			os.Exit(wait.ExitFailed)
+		}
+	}
+	if err != nil {
+		r.Reporter.Errorf("Cluster '%s' isn't uninstalled: %v", clusterKey, err)
+		os.Exit(wait.ExitCode(err))
+	}
+	if !output.HasFlag() || r.Reporter.IsTerminal() {
+		r.Reporter.Infof("Cluster '%s' has been uninstalled", clusterKey)
+	}
+}
+
 func buildCommands(cluster *cmv1.Cluster) string {
 	commands := []string{}
 	deleteOperatorRole := fmt.Sprintf("\trosa delete operator-roles -c %s", cluster.ID())
@@ -21,15 +21,22 @@ import (
 
 	"github.com/openshift/rosa/cmd/dlt/accountroles"
 	"github.com/openshift/rosa/cmd/dlt/admin"
+	"github.com/openshift/rosa/cmd/dlt/autoscaler"
 	"github.com/openshift/rosa/cmd/dlt/cluster"
+	"github.com/openshift/rosa/cmd/dlt/dnsdomain"
+	"github.com/openshift/rosa/cmd/dlt/externalauthprovider"
 	"github.com/openshift/rosa/cmd/dlt/idp"
 	"github.com/openshift/rosa/cmd/dlt/ingress"
+	"github.com/openshift/rosa/cmd/dlt/kubeletconfig"
 	"github.com/openshift/rosa/cmd/dlt/machinepool"
+	"github.com/openshift/rosa/cmd/dlt/network"
 	"github.com/openshift/rosa/cmd/dlt/ocmrole"
 	"github.com/openshift/rosa/cmd/dlt/oidcconfig"
 	"github.com/openshift/rosa/cmd/dlt/oidcprovider"
 	"github.com/openshift/rosa/cmd/dlt/operatorrole"
+	"github.com/openshift/rosa/cmd/dlt/orphanedresources"
 	"github.com/openshift/rosa/cmd/dlt/service"
+	"github.com/openshift/rosa/cmd/dlt/tuningconfig"
 	"github.com/openshift/rosa/cmd/dlt/upgrade"
 	"github.com/openshift/rosa/cmd/dlt/userrole"
 	"github.com/openshift/rosa/pkg/arguments"
@@ -44,15 +51,22 @@ var Cmd = &cobra.Command{
 }
 
 func init() {
+	Cmd.AddCommand(autoscaler.Cmd)
 	Cmd.AddCommand(admin.Cmd)
 	Cmd.AddCommand(cluster.Cmd)
+	Cmd.AddCommand(dnsdomain.Cmd)
+	Cmd.AddCommand(externalauthprovider.Cmd)
 	Cmd.AddCommand(idp.Cmd)
 	Cmd.AddCommand(ingress.Cmd)
+	Cmd.AddCommand(kubeletconfig.Cmd)
+	Cmd.AddCommand(tuningconfig.Cmd)
 	Cmd.AddCommand(machinepool.Cmd)
+	Cmd.AddCommand(network.Cmd)
 	Cmd.AddCommand(upgrade.Cmd)
 	Cmd.AddCommand(oidcconfig.Cmd)
 	Cmd.AddCommand(oidcprovider.Cmd)
 	Cmd.AddCommand(operatorrole.Cmd)
+	Cmd.AddCommand(orphanedresources.Cmd)
 	Cmd.AddCommand(accountroles.Cmd)
 	Cmd.AddCommand(ocmrole.Cmd)
 	Cmd.AddCommand(userrole.Cmd)
@@ -66,7 +80,7 @@ func init() {
 	globallyAvailableCommands := []*cobra.Command{
 		accountroles.Cmd, operatorrole.Cmd,
 		userrole.Cmd, ocmrole.Cmd,
-		oidcprovider.Cmd,
+		oidcprovider.Cmd, orphanedresources.Cmd, dnsdomain.Cmd,
 	}
 	arguments.MarkRegionHidden(Cmd, globallyAvailableCommands)
 }
@@ -0,0 +1,75 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dnsdomain
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var Cmd = &cobra.Command{
+	Use:     "dns-domain ID",
+	Aliases: []string{"dnsdomain"},
+	Short:   "Delete a DNS domain",
+	Long:    "Release a base DNS domain reserved by the organization. Domains used by a cluster can't be deleted.",
+	Example: `  # Delete a DNS domain
+  rosa delete dns-domain <domain>`,
+	Run: run,
+	Args: func(_ *cobra.Command, argv []string) error {
+		if len(argv) != 1 {
+			return fmt.Errorf(
+				"Expected exactly one command line parameter containing the id of the DNS domain",
+			)
+		}
+		return nil
+	},
+}
+
+func run(_ *cobra.Command, argv []string) {
+	r := rosa.NewRuntime().WithOCM()
+	defer r.Cleanup()
+
+	id := argv[0]
+	domain, err := r.OCMClient.GetDNSDomain(id)
+	if err != nil {
+		r.Reporter.Errorf("Failed to get DNS domain '%s': %v", id, err)
+		os.Exit(1)
+	}
+	if domain == nil {
+		r.Reporter.Errorf("DNS domain '%s' doesn't exist", id)
+		os.Exit(1)
+	}
+	if domain.ClusterID() != "" {
+		r.Reporter.Errorf("DNS domain '%s' is used by cluster '%s' and can't be deleted", id, domain.ClusterID())
+		os.Exit(1)
+	}
+
+	if !confirm.Confirm("delete DNS domain '%s'", id) {
+		os.Exit(0)
+	}
+	err = r.OCMClient.DeleteDNSDomain(id)
+	if err != nil {
+		r.Reporter.Errorf("Failed to delete DNS domain '%s': %v", id, err)
+		os.Exit(1)
+	}
+	r.Reporter.Infof("Successfully deleted DNS domain '%s'", id)
+}
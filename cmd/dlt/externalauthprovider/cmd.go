@@ -0,0 +1,80 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalauthprovider
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var Cmd = &cobra.Command{
+	Use:     "external-auth-provider NAME",
+	Aliases: []string{"externalauthprovider"},
+	Short:   "Delete an external authentication provider of a cluster",
+	Long:    "Delete an external authentication provider of a Hosted Control Plane cluster.",
+	Example: `  # Delete external authentication provider 'myprovider' of cluster 'mycluster'
+  rosa delete external-auth-provider myprovider -c mycluster`,
+	Run:  run,
+	Args: cobra.ExactArgs(1),
+}
+
+func init() {
+	ocm.AddClusterFlag(Cmd)
+}
+
+func run(_ *cobra.Command, argv []string) {
+	r := rosa.NewRuntime().WithAWS().WithOCM()
+	defer r.Cleanup()
+
+	clusterKey := r.GetClusterKey()
+	name := argv[0]
+
+	cluster := r.FetchCluster()
+	err := r.OCMClient.ValidateExternalAuthCluster(cluster)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+
+	externalAuth, err := r.OCMClient.GetExternalAuth(cluster.ID(), name)
+	if err != nil {
+		r.Reporter.Errorf("Failed to get external authentication provider '%s' for cluster '%s': %v",
+			name, clusterKey, err)
+		os.Exit(1)
+	}
+	if externalAuth == nil {
+		r.Reporter.Errorf("External authentication provider '%s' doesn't exist for cluster '%s'", name, clusterKey)
+		os.Exit(1)
+	}
+
+	if confirm.Confirm("delete external authentication provider %s of cluster %s", name, clusterKey) {
+		r.Reporter.Debugf("Deleting external authentication provider '%s' of cluster '%s'", name, clusterKey)
+		err = r.OCMClient.DeleteExternalAuth(cluster.ID(), name)
+		if err != nil {
+			r.Reporter.Errorf("Failed to delete external authentication provider '%s' of cluster '%s': %v",
+				name, clusterKey, err)
+			os.Exit(1)
+		}
+		r.Reporter.Infof("Successfully deleted external authentication provider '%s' of cluster '%s'",
+			name, clusterKey)
+	}
+}
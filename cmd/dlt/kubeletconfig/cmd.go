@@ -0,0 +1,85 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeletconfig
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/kubeletconfig"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var Cmd = &cobra.Command{
+	Use:     "kubeletconfig",
+	Aliases: []string{"kubelet-config"},
+	Short:   "Delete the custom kubelet config of a cluster",
+	Long: "Delete the custom kubelet config of a classic cluster, or one of the named kubelet configs of " +
+		"a Hosted Control Plane cluster that isn't used by any machine pool.",
+	Example: `  # Delete the kubelet config of classic cluster 'mycluster'
+  rosa delete kubeletconfig -c mycluster
+
+  # Delete kubelet config 'high-pids' of hosted cluster 'mycluster'
+  rosa delete kubeletconfig -c mycluster --name high-pids`,
+	Run:  run,
+	Args: cobra.NoArgs,
+}
+
+var args struct {
+	name string
+}
+
+func init() {
+	ocm.AddClusterFlag(Cmd)
+	kubeletconfig.AddNameFlag(Cmd.Flags(), &args.name)
+}
+
+func run(_ *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS().WithOCM()
+	defer r.Cleanup()
+
+	clusterKey := r.GetClusterKey()
+
+	cluster := r.FetchCluster()
+	err := kubeletconfig.ValidateName(cluster, args.name)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+	config, err := r.OCMClient.FindKubeletConfig(cluster, args.name)
+	if err != nil {
+		r.Reporter.Errorf("Failed to get kubelet configs for cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+	if config == nil {
+		r.Reporter.Warnf("Cluster '%s' has no matching kubelet config", clusterKey)
+		os.Exit(0)
+	}
+
+	if confirm.Confirm("delete the kubelet config of cluster %s", clusterKey) {
+		r.Reporter.Debugf("Deleting kubelet config of cluster '%s'", clusterKey)
+		err = r.OCMClient.DeleteKubeletConfig(cluster, config)
+		if err != nil {
+			r.Reporter.Errorf("Failed to delete kubelet config of cluster '%s': %v", clusterKey, err)
+			os.Exit(1)
+		}
+		r.Reporter.Infof("Successfully deleted kubelet config of cluster '%s'", clusterKey)
+	}
+}
@@ -0,0 +1,88 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"os"
+	"time"
+
+	"github.com/briandowns/spinner"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var Cmd = &cobra.Command{
+	Use:     "network",
+	Aliases: []string{"vpc"},
+	Short:   "Delete a network created by rosa",
+	Long: "Delete the CloudFormation stack created by 'rosa create network', together with the VPC, " +
+		"subnets and gateways it contains. The network must not be used by any cluster.",
+	Example: `  # Delete the network named "my-network"
+  rosa delete network --name my-network`,
+	Run: run,
+}
+
+var args struct {
+	name string
+}
+
+func init() {
+	flags := Cmd.Flags()
+
+	flags.StringVar(
+		&args.name,
+		"name",
+		aws.DefaultNetworkName,
+		"Name of the CloudFormation stack that created the network.",
+	)
+
+	confirm.AddFlag(flags)
+}
+
+func run(_ *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS()
+	defer r.Cleanup()
+
+	network, err := r.AWSClient.GetNetworkStack(args.name)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+
+	if !confirm.Confirm("delete network '%s' with VPC '%s'", network.Name, network.VpcID) {
+		os.Exit(0)
+	}
+
+	var spin *spinner.Spinner
+	if r.Reporter.IsTerminal() {
+		r.Reporter.Infof("Deleting network '%s', this usually takes a few minutes", network.Name)
+		spin = spinner.New(spinner.CharSets[9], 100*time.Millisecond)
+		spin.Start()
+	}
+	err = r.AWSClient.DeleteNetworkStack(network.Name)
+	if spin != nil {
+		spin.Stop()
+	}
+	if err != nil {
+		r.Reporter.Errorf("Failed to delete network '%s': %v", network.Name, err)
+		os.Exit(1)
+	}
+	r.Reporter.Infof("Network '%s' deleted successfully", network.Name)
+}
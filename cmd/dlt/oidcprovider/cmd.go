@@ -19,8 +19,8 @@ package oidcprovider
 import (
 	"fmt"
 	"net/url"
-
 	"os"
+	"strings"
 
 	"github.com/openshift/rosa/pkg/aws"
 	awscb "github.com/openshift/rosa/pkg/aws/commandbuilder"
@@ -141,6 +141,8 @@ func run(cmd *cobra.Command, argv []string) {
 				clusterKey, OidcEndpointUrlFlag)
 			return
 		}
+		checkProviderNotInUse(r, fmt.Sprintf("%s://%s", helper.ProtocolHttps,
+			aws.GetOIDCProviderURLFromARN(providerArn)))
 	} else {
 		oidcEndpointUrl := args.oidcEndpointUrl
 		parsedURI, _ := url.ParseRequestURI(oidcEndpointUrl)
@@ -153,16 +155,7 @@ func run(cmd *cobra.Command, argv []string) {
 			r.Reporter.Errorf("Failed to get the OIDC provider for endpoint URL '%s': %v", oidcEndpointUrl, err)
 			os.Exit(1)
 		}
-		hasClusterUsingOidcProvider, err := r.OCMClient.HasAClusterUsingOidcEndpointUrl(oidcEndpointUrl)
-		if err != nil {
-			r.Reporter.Errorf("There was a problem checking if any clusters are using OIDC provider '%s' : %v",
-				oidcEndpointUrl, err)
-			os.Exit(1)
-		}
-		if hasClusterUsingOidcProvider {
-			r.Reporter.Errorf("There are clusters using OIDC config '%s', can't delete the provider", oidcEndpointUrl)
-			os.Exit(1)
-		}
+		checkProviderNotInUse(r, oidcEndpointUrl)
 		if providerArn == "" {
 			r.Reporter.Infof("Provider '%s' not found.", oidcEndpointUrl)
 			return
@@ -211,6 +204,26 @@ func run(cmd *cobra.Command, argv []string) {
 	}
 }
 
+// checkProviderNotInUse exits with an error if there are clusters that still use the OIDC endpoint
+// URL of the provider, as deleting it would break their operators.
+func checkProviderNotInUse(r *rosa.Runtime, oidcEndpointUrl string) {
+	clusters, err := r.OCMClient.GetClustersUsingOidcEndpointUrls([]string{oidcEndpointUrl})
+	if err != nil {
+		r.Reporter.Errorf("There was a problem checking if any clusters are using OIDC provider '%s' : %v",
+			oidcEndpointUrl, err)
+		os.Exit(1)
+	}
+	if len(clusters[oidcEndpointUrl]) > 0 {
+		clusterIDs := []string{}
+		for _, cluster := range clusters[oidcEndpointUrl] {
+			clusterIDs = append(clusterIDs, cluster.ID())
+		}
+		r.Reporter.Errorf("There are clusters using OIDC config '%s', can't delete the provider: %s",
+			oidcEndpointUrl, strings.Join(clusterIDs, ", "))
+		os.Exit(1)
+	}
+}
+
 func buildCommand(providerARN string) string {
 	return awscb.NewIAMCommandBuilder().
 		SetCommand(awscb.DeleteOpenIdConnectProvider).
@@ -0,0 +1,254 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package orphanedresources
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/briandowns/spinner"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/aws"
+	awscb "github.com/openshift/rosa/pkg/aws/commandbuilder"
+	"github.com/openshift/rosa/pkg/interactive"
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var Cmd = &cobra.Command{
+	Use:     "orphaned-resources",
+	Aliases: []string{"orphanedresources", "orphaned-resource"},
+	Short:   "Delete IAM resources left behind by deleted clusters",
+	Long: "Scans the AWS account for operator roles and OIDC providers tagged with the identifier " +
+		"of a deleted cluster and deletes them. In auto mode the operator role policies " +
+		"that are no longer attached to any role are deleted as well. A cluster is only considered deleted " +
+		"when its subscription in the current OCM environment and organization is deprovisioned or archived. " +
+		"Resources of clusters that belong to other environments or organizations are never listed.",
+	Example: `  # Delete the orphaned operator roles and OIDC providers, asking for confirmation
+  rosa delete orphaned-resources --mode auto
+
+  # Print the AWS CLI commands needed to delete the orphaned resources
+  rosa delete orphaned-resources --mode manual`,
+	Args: cobra.NoArgs,
+	Run:  run,
+}
+
+func init() {
+	aws.AddModeFlag(Cmd)
+}
+
+func run(cmd *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS().WithOCM()
+	defer r.Cleanup()
+
+	mode, err := aws.GetMode()
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+
+	// Determine if interactive mode is needed
+	if !interactive.Enabled() && !cmd.Flags().Changed("mode") {
+		interactive.Enable()
+	}
+
+	var spin *spinner.Spinner
+	if r.Reporter.IsTerminal() {
+		spin = spinner.New(spinner.CharSets[9], 100*time.Millisecond)
+		r.Reporter.Infof("Looking for IAM resources of deleted clusters")
+		spin.Start()
+	}
+	orphans, unknown, err := findOrphanedResources(r)
+	if spin != nil {
+		spin.Stop()
+	}
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+
+	r.Reporter.Warnf("Only the clusters of the current OCM environment and organization are checked. " +
+		"IAM resources of clusters that belong to other environments or organizations are skipped.")
+	if len(unknown) > 0 {
+		r.Reporter.Warnf("Skipping the IAM resources of %d cluster(s) that aren't known to be deleted: %s",
+			len(unknown), strings.Join(unknown, ", "))
+	}
+
+	if len(orphans) == 0 {
+		r.Reporter.Infof("There are no orphaned IAM resources to delete")
+		return
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "CLUSTER ID\tTYPE\tRESOURCE\n")
+	for _, orphan := range orphans {
+		for _, role := range orphan.OperatorRoles {
+			fmt.Fprintf(writer, "%s\t%s\t%s\n", orphan.ClusterID, "Operator role", role)
+		}
+		for _, provider := range orphan.OIDCProviders {
+			fmt.Fprintf(writer, "%s\t%s\t%s\n", orphan.ClusterID, "OIDC provider", provider)
+		}
+	}
+	writer.Flush()
+
+	if interactive.Enabled() {
+		mode, err = interactive.GetOption(interactive.Input{
+			Question: "Orphaned resources deletion mode",
+			Help:     cmd.Flags().Lookup("mode").Usage,
+			Default:  aws.ModeAuto,
+			Options:  aws.Modes,
+			Required: true,
+		})
+		if err != nil {
+			r.Reporter.Errorf("Expected a valid orphaned resources deletion mode: %s", err)
+			os.Exit(1)
+		}
+	}
+
+	switch mode {
+	case aws.ModeAuto:
+		r.OCMClient.LogEvent("ROSADeleteOrphanedResourcesModeAuto", nil)
+		for _, orphan := range orphans {
+			deleteOrphanedResources(r, orphan)
+		}
+		r.Reporter.Infof("Finished deleting the orphaned IAM resources")
+	case aws.ModeManual:
+		r.OCMClient.LogEvent("ROSADeleteOrphanedResourcesModeManual", nil)
+		commands := []string{}
+		for _, orphan := range orphans {
+			policyMap, err := r.AWSClient.GetPolicies(orphan.OperatorRoles)
+			if err != nil {
+				r.Reporter.Errorf("There was an error getting the policies: %v", err)
+				os.Exit(1)
+			}
+			commands = append(commands, buildCommands(orphan, policyMap)...)
+		}
+		if r.Reporter.IsTerminal() {
+			r.Reporter.Infof("Run the following commands to delete the orphaned IAM resources:\n")
+		}
+		fmt.Println(strings.Join(commands, "\n"))
+	default:
+		r.Reporter.Errorf("Invalid mode. Allowed values are %s", aws.Modes)
+		os.Exit(1)
+	}
+}
+
+// findOrphanedResources returns the IAM resources tagged with the identifier of a deleted cluster,
+// and the identifiers of the clusters that still exist or aren't known to the current environment
+// and organization.
+func findOrphanedResources(r *rosa.Runtime) ([]*aws.ClusterResources, []string, error) {
+	credRequests := map[string]*cmv1.STSOperator{}
+	for _, isHypershift := range []bool{false, true} {
+		requests, err := r.OCMClient.GetCredRequests(isHypershift)
+		if err != nil {
+			return nil, nil, fmt.Errorf("Error getting operator credential request from OCM: %v", err)
+		}
+		for name, operator := range requests {
+			credRequests[fmt.Sprintf("%t-%s", isHypershift, name)] = operator
+		}
+	}
+	resources, err := r.AWSClient.GetClusterTaggedResources(credRequests)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Failed to get IAM resources: %v", err)
+	}
+	clusterIDs := make([]string, len(resources))
+	for i, resource := range resources {
+		clusterIDs[i] = resource.ClusterID
+	}
+	deleted, err := r.OCMClient.GetDeletedClusterIDs(clusterIDs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Failed to get deleted clusters: %v", err)
+	}
+	orphans, unknown := filterOrphans(resources, deleted)
+	return orphans, unknown, nil
+}
+
+func filterOrphans(resources []*aws.ClusterResources,
+	deleted map[string]bool) (orphans []*aws.ClusterResources, unknown []string) {
+	orphans = []*aws.ClusterResources{}
+	for _, resource := range resources {
+		if deleted[resource.ClusterID] {
+			orphans = append(orphans, resource)
+		} else {
+			unknown = append(unknown, resource.ClusterID)
+		}
+	}
+	return orphans, unknown
+}
+
+func deleteOrphanedResources(r *rosa.Runtime, orphan *aws.ClusterResources) {
+	for _, role := range orphan.OperatorRoles {
+		if !confirm.Prompt(false, "Delete the operator role '%s' of deleted cluster '%s'?", role, orphan.ClusterID) {
+			continue
+		}
+		_, roleARN, err := r.AWSClient.CheckRoleExists(role)
+		if err != nil {
+			r.Reporter.Warnf("Failed to get '%s' role ARN: %v", role, err)
+			continue
+		}
+		managedPolicies, err := r.AWSClient.HasManagedPolicies(roleARN)
+		if err != nil {
+			r.Reporter.Warnf("Failed to determine if role '%s' has managed policies: %v", role, err)
+			continue
+		}
+		r.Reporter.Infof("Deleting operator role '%s'", role)
+		err = r.AWSClient.DeleteOperatorRole(role, managedPolicies)
+		if err != nil {
+			r.Reporter.Warnf("There was an error deleting the operator role or its policies: %v", err)
+		}
+	}
+	for _, provider := range orphan.OIDCProviders {
+		if !confirm.Prompt(false, "Delete the OIDC provider '%s' of deleted cluster '%s'?",
+			provider, orphan.ClusterID) {
+			continue
+		}
+		r.Reporter.Infof("Deleting OIDC provider '%s'", provider)
+		err := r.AWSClient.DeleteOpenIDConnectProvider(provider)
+		if err != nil {
+			r.Reporter.Warnf("There was an error deleting the OIDC provider: %v", err)
+		}
+	}
+}
+
+func buildCommands(orphan *aws.ClusterResources, policyMap map[string][]string) []string {
+	commands := []string{}
+	for _, role := range orphan.OperatorRoles {
+		for _, policyARN := range policyMap[role] {
+			commands = append(commands, awscb.NewIAMCommandBuilder().
+				SetCommand(awscb.DetachRolePolicy).
+				AddParam(awscb.RoleName, role).
+				AddParam(awscb.PolicyArn, policyARN).
+				Build())
+		}
+		commands = append(commands, awscb.NewIAMCommandBuilder().
+			SetCommand(awscb.DeleteRole).
+			AddParam(awscb.RoleName, role).
+			Build())
+	}
+	for _, provider := range orphan.OIDCProviders {
+		commands = append(commands, awscb.NewIAMCommandBuilder().
+			SetCommand(awscb.DeleteOpenIdConnectProvider).
+			AddParam(awscb.OpenIdConnectProviderArn, provider).
+			Build())
+	}
+	return commands
+}
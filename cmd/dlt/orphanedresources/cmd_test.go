@@ -0,0 +1,21 @@
+package orphanedresources
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/rosa/pkg/aws"
+)
+
+var _ = Describe("Filter orphaned resources", func() {
+	It("Only returns the resources of the clusters known to be deleted", func() {
+		resources := []*aws.ClusterResources{
+			{ClusterID: "deleted", OperatorRoles: []string{"deleted-role"}},
+			{ClusterID: "unknown", OperatorRoles: []string{"unknown-role"}},
+		}
+		orphans, unknown := filterOrphans(resources, map[string]bool{"deleted": true})
+		Expect(orphans).To(HaveLen(1))
+		Expect(orphans[0].ClusterID).To(Equal("deleted"))
+		Expect(unknown).To(Equal([]string{"unknown"}))
+	})
+})
@@ -0,0 +1,13 @@
+package orphanedresources_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestOrphanedResources(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Orphaned Resources Suite")
+}
@@ -0,0 +1,110 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tuningconfig
+
+import (
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+	"github.com/openshift/rosa/pkg/tuningconfig"
+)
+
+var Cmd = &cobra.Command{
+	Use:     "tuning-config",
+	Aliases: []string{"tuningconfig"},
+	Short:   "Delete a tuning config of a cluster",
+	Long: "Delete a tuning config of a Hosted Control Plane cluster. Tuning configs that are used by " +
+		"machine pools can't be deleted.",
+	Example: `  # Delete tuning config 'hugepages' of cluster 'mycluster'
+  rosa delete tuning-config -c mycluster --name hugepages`,
+	Run:  run,
+	Args: cobra.NoArgs,
+}
+
+var args struct {
+	name string
+}
+
+func init() {
+	ocm.AddClusterFlag(Cmd)
+	tuningconfig.AddNameFlag(Cmd.Flags(), &args.name)
+}
+
+func run(_ *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS().WithOCM()
+	defer r.Cleanup()
+
+	clusterKey := r.GetClusterKey()
+
+	if args.name == "" {
+		r.Reporter.Errorf("The '--%s' option is required", tuningconfig.NameFlag)
+		os.Exit(1)
+	}
+
+	cluster := r.FetchCluster()
+	err := tuningconfig.ValidateCluster(cluster)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+	config, err := r.OCMClient.FindTuningConfig(cluster.ID(), args.name)
+	if err != nil {
+		r.Reporter.Errorf("Failed to get tuning configs for cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+	if config == nil {
+		r.Reporter.Warnf("Tuning config '%s' doesn't exist for cluster '%s'", args.name, clusterKey)
+		os.Exit(0)
+	}
+
+	settings, err := r.OCMClient.GetNodePoolsSettings(cluster.ID())
+	if err != nil {
+		r.Reporter.Errorf("Failed to get machine pools for cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+	users := []string{}
+	for nodePoolID, nodePoolSettings := range settings {
+		for _, name := range nodePoolSettings.TuningConfigs {
+			if name == config.Name {
+				users = append(users, nodePoolID)
+			}
+		}
+	}
+	if len(users) > 0 {
+		sort.Strings(users)
+		r.Reporter.Errorf("Tuning config '%s' is used by machine pools '%s', remove it from them with "+
+			"'rosa edit machinepool --tuning-configs' first", config.Name, strings.Join(users, "', '"))
+		os.Exit(1)
+	}
+
+	if confirm.Confirm("delete tuning config %s of cluster %s", config.Name, clusterKey) {
+		r.Reporter.Debugf("Deleting tuning config '%s' of cluster '%s'", config.Name, clusterKey)
+		err = r.OCMClient.DeleteTuningConfig(cluster.ID(), config)
+		if err != nil {
+			r.Reporter.Errorf("Failed to delete tuning config '%s' of cluster '%s': %v",
+				config.Name, clusterKey, err)
+			os.Exit(1)
+		}
+		r.Reporter.Infof("Successfully deleted tuning config '%s' of cluster '%s'", config.Name, clusterKey)
+	}
+}
@@ -21,6 +21,7 @@ import (
 
 	"github.com/openshift/rosa/cmd/download/oc"
 	"github.com/openshift/rosa/cmd/download/rosa"
+	"github.com/openshift/rosa/cmd/download/terraform"
 )
 
 var Cmd = &cobra.Command{
@@ -32,4 +33,5 @@ var Cmd = &cobra.Command{
 func init() {
 	Cmd.AddCommand(oc.Cmd)
 	Cmd.AddCommand(rosa.Cmd)
+	Cmd.AddCommand(terraform.Cmd)
 }
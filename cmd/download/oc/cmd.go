@@ -17,7 +17,6 @@ limitations under the License.
 package oc
 
 import (
-	"fmt"
 	"os"
 	"runtime"
 
@@ -45,27 +44,29 @@ func run(cmd *cobra.Command, argv []string) {
 	// Verify whether `oc` is installed
 	oc.Cmd.Run(cmd, argv)
 
-	platform := getPlatform()
-	extension := helper.GetExtension()
+	filename := helper.GetOCArchiveName(runtime.GOOS, runtime.GOARCH)
+	downloadURL := helper.OCLatestMirrorFolder + filename
 
-	filename := fmt.Sprintf("openshift-client-%s.%s", platform, extension)
-	downloadURL := fmt.Sprintf("https://mirror.openshift.com/pub/openshift-v4/clients/ocp/latest/%s", filename)
+	checksum, err := helper.GetOCChecksum(filename)
+	if err != nil {
+		reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
 
 	reporter.Infof("Downloading %s", downloadURL)
 
-	err := helper.Download(downloadURL, filename)
+	err = helper.Download(downloadURL, filename)
 	if err != nil {
 		reporter.Errorf("%s", err)
 		os.Exit(1)
 	}
 
-	reporter.Infof("Successfully downloaded %s", filename)
-}
-
-// Get the platform name used on the oc tarball filename
-func getPlatform() string {
-	if runtime.GOOS == "darwin" {
-		return "mac"
+	err = helper.VerifyChecksum(filename, checksum)
+	if err != nil {
+		os.Remove(filename)
+		reporter.Errorf("%s", err)
+		os.Exit(1)
 	}
-	return runtime.GOOS
+
+	reporter.Infof("Successfully downloaded %s", filename)
 }
@@ -41,10 +41,7 @@ var Cmd = &cobra.Command{
 func run(cmd *cobra.Command, argv []string) {
 	reporter := rprtr.CreateReporterOrExit()
 
-	platform := getPlatform()
-	extension := helper.GetExtension()
-
-	filename := fmt.Sprintf("rosa-%s.%s", platform, extension)
+	filename := helper.GetROSAArchiveName(runtime.GOOS, runtime.GOARCH)
 
 	downloadURL := fmt.Sprintf("%s%s", rosa.DownloadLatestMirrorFolder, filename)
 
@@ -58,11 +55,3 @@ func run(cmd *cobra.Command, argv []string) {
 
 	reporter.Infof("Successfully downloaded %s", filename)
 }
-
-// Get the platform name used on the oc tarball filename
-func getPlatform() string {
-	if runtime.GOOS == "darwin" {
-		return "macosx"
-	}
-	return runtime.GOOS
-}
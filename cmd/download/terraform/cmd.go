@@ -0,0 +1,128 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package terraform
+
+import (
+	"fmt"
+	"os"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/helper/terraform"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var args struct {
+	file string
+}
+
+var Cmd = &cobra.Command{
+	Use:   "terraform",
+	Short: "Export a cluster as terraform configuration",
+	Long: "Renders the configuration of an existing cluster, its machine pools, identity providers " +
+		"and IAM roles as terraform configuration of the Red Hat Cloud Services provider, including " +
+		"the import blocks needed to adopt the existing resources.",
+	Example: `  # Export the cluster named "mycluster" to the file "mycluster.tf"
+  rosa download terraform --cluster=mycluster
+
+  # Print the configuration of the cluster named "mycluster" to the standard output
+  rosa download terraform --cluster=mycluster --file=-`,
+	Run: run,
+}
+
+func init() {
+	flags := Cmd.Flags()
+	ocm.AddClusterFlag(Cmd)
+	flags.StringVar(
+		&args.file,
+		"file",
+		"",
+		"File where the configuration will be written. Defaults to '<cluster-name>.tf'. "+
+			"Use '-' to write to the standard output.",
+	)
+}
+
+func run(_ *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithOCM()
+	defer r.Cleanup()
+
+	clusterKey := r.GetClusterKey()
+	cluster := r.FetchCluster()
+	if cluster.State() != cmv1.ClusterStateReady {
+		r.Reporter.Errorf("Cluster '%s' is not yet ready", clusterKey)
+		os.Exit(1)
+	}
+
+	export := &terraform.ClusterExport{
+		Cluster:       cluster,
+		HTPasswdUsers: map[string][]string{},
+	}
+	var err error
+	if cluster.Hypershift().Enabled() {
+		export.NodePools, err = r.OCMClient.GetNodePools(cluster.ID())
+	} else {
+		export.MachinePools, err = r.OCMClient.GetMachinePools(cluster.ID())
+	}
+	if err != nil {
+		r.Reporter.Errorf("Failed to get machine pools for cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+	export.IdentityProviders, err = r.OCMClient.GetIdentityProviders(cluster.ID())
+	if err != nil {
+		r.Reporter.Errorf("Failed to get identity providers for cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+	for _, idp := range export.IdentityProviders {
+		if idp.Type() != cmv1.IdentityProviderTypeHtpasswd {
+			continue
+		}
+		users, err := r.OCMClient.GetHTPasswdUserList(cluster.ID(), idp.ID())
+		if err != nil {
+			r.Reporter.Errorf("Failed to get users of identity provider '%s': %v", idp.Name(), err)
+			os.Exit(1)
+		}
+		if users == nil {
+			continue
+		}
+		for _, user := range users.Slice() {
+			export.HTPasswdUsers[idp.ID()] = append(export.HTPasswdUsers[idp.ID()], user.Username())
+		}
+	}
+
+	configuration := export.Render()
+	if args.file == "-" {
+		fmt.Print(configuration)
+		return
+	}
+	file := args.file
+	if file == "" {
+		file = fmt.Sprintf("%s.tf", cluster.Name())
+	}
+	if _, err := os.Stat(file); err == nil {
+		r.Reporter.Errorf("File '%s' already exists", file)
+		os.Exit(1)
+	}
+	err = os.WriteFile(file, []byte(configuration), 0600)
+	if err != nil {
+		r.Reporter.Errorf("Failed to write file '%s': %v", file, err)
+		os.Exit(1)
+	}
+	r.Reporter.Infof("Terraform configuration of cluster '%s' written to '%s'", clusterKey, file)
+	r.Reporter.Infof("Set the sensitive variables and run 'terraform plan' to import the existing resources")
+}
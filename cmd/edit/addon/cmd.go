@@ -22,11 +22,13 @@ import (
 	"regexp"
 	"strings"
 
+	amsv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/spf13/cobra"
 
 	"github.com/openshift/rosa/pkg/arguments"
 	"github.com/openshift/rosa/pkg/helper"
+	"github.com/openshift/rosa/pkg/helper/addons"
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/rosa"
@@ -36,9 +38,13 @@ var Cmd = &cobra.Command{
 	Use:     "addon ID",
 	Aliases: []string{"addons", "add-on", "add-ons"},
 	Short:   "Edit add-on installation parameters on cluster",
-	Long:    "Edit the parameters on installed Red Hat managed add-ons on a cluster",
+	Long:    "Edit the parameters and the billing model of installed Red Hat managed add-ons on a cluster",
 	Example: `  # Edit the parameters of the Red Hat OpenShift logging operator add-on installation
-  rosa edit addon --cluster=mycluster cluster-logging-operator`,
+  rosa edit addon --cluster=mycluster cluster-logging-operator
+
+  # Bill the add-on installation through the AWS marketplace
+  rosa edit addon --cluster=mycluster cluster-logging-operator --billing-model marketplace-aws \
+  --billing-model-account-id 123456789012`,
 	Run:                run,
 	DisableFlagParsing: true,
 	Args: func(cmd *cobra.Command, argv []string) error {
@@ -54,7 +60,28 @@ var Cmd = &cobra.Command{
 	},
 }
 
+var args struct {
+	billingModel          string
+	billingModelAccountID string
+}
+
 func init() {
+	flags := Cmd.Flags()
+
+	flags.StringVar(
+		&args.billingModel,
+		addons.BillingModelFlag,
+		"",
+		"Change the billing model of the addon installation resource",
+	)
+
+	flags.StringVar(
+		&args.billingModelAccountID,
+		addons.BillingModelAccountIDFlag,
+		"",
+		"Account ID of associated billing model for the addon installation resource",
+	)
+
 	ocm.AddClusterFlag(Cmd)
 }
 
@@ -87,15 +114,13 @@ func run(cmd *cobra.Command, argv []string) {
 		os.Exit(1)
 	}
 
-	if addonParameters.Len() == 0 {
-		r.Reporter.Errorf("Add-on '%s' has no parameters to edit", addOnID)
-		os.Exit(1)
-	}
+	billingChanged := cmd.Flags().Changed(addons.BillingModelFlag) ||
+		cmd.Flags().Changed(addons.BillingModelAccountIDFlag)
 
 	// Determine if all required parameters have already been set as flags and ensure
 	// that interactive mode is enabled if they have not. If there are no parameters
-	// set as flags, then we also ensure that interactive mode is enabled so that the
-	// user gets prompted.
+	// or billing settings set as flags, then we also ensure that interactive mode is
+	// enabled so that the user gets prompted.
 	if arguments.HasUnknownFlags() {
 		addonParameters.Each(func(param *cmv1.AddOnParameter) bool {
 			flag := cmd.Flags().Lookup(param.ID())
@@ -105,7 +130,7 @@ func run(cmd *cobra.Command, argv []string) {
 			}
 			return true
 		})
-	} else {
+	} else if !billingChanged {
 		interactive.Enable()
 	}
 
@@ -174,8 +199,59 @@ func run(cmd *cobra.Command, argv []string) {
 		return true
 	})
 
+	changeBilling := billingChanged
+	if !changeBilling && interactive.Enabled() {
+		changeBilling, err = interactive.GetBool(interactive.Input{
+			Question: "Change billing",
+			Help:     "Whether to change the billing model or the billing account of the add-on.",
+			Default:  false,
+			Required: false,
+		})
+		if err != nil {
+			r.Reporter.Errorf("Expected a valid value: %s", err)
+			os.Exit(1)
+		}
+	}
+
+	var billing *ocm.AddOnBilling
+	if changeBilling {
+		current := ocm.AddOnBilling{
+			BillingModel:     string(addOnInstallation.Billing().BillingModel()),
+			BillingAccountID: addOnInstallation.Billing().BillingMarketplaceAccount(),
+		}
+		requested := current
+		if cmd.Flags().Changed(addons.BillingModelFlag) {
+			requested.BillingModel = args.billingModel
+		}
+		if cmd.Flags().Changed(addons.BillingModelAccountIDFlag) {
+			requested.BillingAccountID = args.billingModelAccountID
+		}
+		addOn, err := r.OCMClient.GetAddOn(addOnID)
+		if err != nil {
+			r.Reporter.Errorf("Failed to get add-on '%s': %v", addOnID, err)
+			os.Exit(1)
+		}
+		installedBillingModel := current.BillingModel
+		if installedBillingModel == "" {
+			installedBillingModel = string(amsv1.BillingModelStandard)
+		}
+		requested, err = addons.GetBilling(r, cmd, addOn, requested, installedBillingModel)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+		if requested != current {
+			billing = &requested
+		}
+	}
+
+	if len(addonArguments) == 0 && billing == nil {
+		r.Reporter.Infof("Add-on '%s' has no parameters to edit and its billing didn't change", addOnID)
+		return
+	}
+
 	r.Reporter.Debugf("Updating add-on parameters for '%s' on cluster '%s'", addOnID, clusterKey)
-	err = r.OCMClient.UpdateAddOnInstallation(cluster.ID(), addOnID, addonArguments)
+	err = r.OCMClient.UpdateAddOnInstallation(cluster.ID(), addOnID, addonArguments, billing)
 	if err != nil {
 		r.Reporter.Errorf("Failed to update add-on installation '%s' for cluster '%s': %v", addOnID, clusterKey, err)
 		os.Exit(1)
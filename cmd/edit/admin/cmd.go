@@ -0,0 +1,169 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admin
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/cmd/create/admin"
+	"github.com/openshift/rosa/cmd/create/idp"
+	"github.com/openshift/rosa/pkg/object"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "admin",
+	Short: "Edit the cluster-admin user",
+	Long:  "Edit the cluster-admin user created with 'rosa create admin', for example to rotate its password.",
+	Example: `  # Rotate the password of the admin user with a generated one
+  rosa edit admin -c mycluster --rotate-password
+
+  # Rotate the password of the admin user with one read from stdin
+  rosa edit admin -c mycluster --rotate-password --password-stdin < password.txt`,
+	Run:  run,
+	Args: cobra.NoArgs,
+}
+
+var args struct {
+	rotatePassword bool
+	passwordStdin  bool
+}
+
+func init() {
+	ocm.AddClusterFlag(Cmd)
+	flags := Cmd.Flags()
+	flags.BoolVar(
+		&args.rotatePassword,
+		"rotate-password",
+		false,
+		"Replace the password of the admin user with a newly generated one.",
+	)
+	flags.BoolVar(
+		&args.passwordStdin,
+		"password-stdin",
+		false,
+		"Read the new password of the admin user from stdin instead of generating it.",
+	)
+	output.AddFlag(Cmd)
+}
+
+func run(cmd *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS().WithOCM()
+	defer r.Cleanup()
+
+	clusterKey := r.GetClusterKey()
+
+	if args.passwordStdin && !args.rotatePassword {
+		r.Reporter.Errorf("The '--password-stdin' flag can only be used with '--rotate-password'")
+		os.Exit(1)
+	}
+	if !args.rotatePassword {
+		r.Reporter.Errorf("Nothing to edit, use '--rotate-password' to rotate the admin password")
+		os.Exit(1)
+	}
+
+	cluster := r.FetchCluster()
+	if cluster.State() != cmv1.ClusterStateReady {
+		r.Reporter.Errorf("Cluster '%s' is not yet ready", clusterKey)
+		os.Exit(1)
+	}
+
+	htpasswdIDP, userList := idp.FindExistingHTPasswdIDP(cluster, r)
+	if htpasswdIDP == nil || !idp.HasClusterAdmin(userList) {
+		r.Reporter.Errorf("Cluster '%s' has no admin, create one with 'rosa create admin'", clusterKey)
+		os.Exit(1)
+	}
+
+	var password string
+	var err error
+	if args.passwordStdin {
+		password, err = readPassword(bufio.NewReader(os.Stdin))
+		if err != nil {
+			r.Reporter.Errorf("Failed to read password from stdin: %s", err)
+			os.Exit(1)
+		}
+		err = idp.PasswordValidator(password)
+		if err != nil {
+			r.Reporter.Errorf("Invalid password: %s", err)
+			os.Exit(1)
+		}
+	} else {
+		r.Reporter.Debugf("Generating random password")
+		password, err = admin.GenerateRandomPassword(23)
+		if err != nil {
+			r.Reporter.Errorf("Failed to generate a random password")
+			os.Exit(1)
+		}
+	}
+
+	r.Reporter.Debugf("Updating password of user '%s' on cluster '%s'", idp.ClusterAdminUsername, clusterKey)
+	err = r.OCMClient.UpdateHTPasswdUserPassword(idp.ClusterAdminUsername, password, cluster.ID(),
+		htpasswdIDP.ID())
+	if err != nil {
+		r.Reporter.Errorf("Failed to update the password of user '%s' on cluster '%s': %s",
+			idp.ClusterAdminUsername, clusterKey, err)
+		os.Exit(1)
+	}
+
+	outputObject := object.Object{
+		"api_url":  cluster.API().URL(),
+		"username": idp.ClusterAdminUsername,
+		"password": password,
+	}
+
+	if output.HasFlag() {
+		if args.passwordStdin {
+			delete(outputObject, "password")
+		}
+		err = output.Print(outputObject)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	r.Reporter.Infof("Admin password has been rotated on cluster '%s'.", clusterKey)
+	if args.passwordStdin {
+		r.Reporter.Infof("To login, run the following command:\n\n"+
+			"   oc login %s --username %s\n", outputObject["api_url"], outputObject["username"])
+	} else {
+		r.Reporter.Infof("Please securely store this generated password. " +
+			"If you lose this password you can rotate it again.")
+		r.Reporter.Infof("To login, run the following command:\n\n"+
+			"   oc login %s --username %s --password %s\n",
+			outputObject["api_url"], outputObject["username"], outputObject["password"])
+	}
+	r.Reporter.Infof("It may take several minutes for the new password to become active.")
+}
+
+// readPassword reads the first line of the reader, without the line terminator.
+func readPassword(reader *bufio.Reader) (string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil && line == "" {
+		return "", fmt.Errorf("no password provided")
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
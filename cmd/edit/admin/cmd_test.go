@@ -0,0 +1,28 @@
+package admin
+
+import (
+	"bufio"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Read password", func() {
+	DescribeTable("reads the first line of the input",
+		func(input string, expected string) {
+			password, err := readPassword(bufio.NewReader(strings.NewReader(input)))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(password).To(Equal(expected))
+		},
+		Entry("with a line terminator", "secret\n", "secret"),
+		Entry("with a windows line terminator", "secret\r\n", "secret"),
+		Entry("without a line terminator", "secret", "secret"),
+		Entry("with several lines", "secret\nother\n", "secret"),
+	)
+
+	It("fails on empty input", func() {
+		_, err := readPassword(bufio.NewReader(strings.NewReader("")))
+		Expect(err).To(MatchError("no password provided"))
+	})
+})
@@ -0,0 +1,135 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package autoscaler
+
+import (
+	"os"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/clusterautoscaler"
+	"github.com/openshift/rosa/pkg/interactive"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var Cmd = &cobra.Command{
+	Use:     "autoscaler",
+	Aliases: []string{"cluster-autoscaler"},
+	Short:   "Edit the autoscaler of a cluster",
+	Long: "Edit the cluster-wide autoscaler of a classic cluster. Only the options given in the " +
+		"command line are changed.",
+	Example: `  # Interactively edit the autoscaler of cluster 'mycluster'
+  rosa edit autoscaler --cluster=mycluster --interactive
+
+  # Disable scale down
+  rosa edit autoscaler --cluster=mycluster --scale-down-enabled=false
+
+  # Replace the GPU limits
+  rosa edit autoscaler --cluster=mycluster --gpu-limit=nvidia.com/gpu,0,20`,
+	Run:  run,
+	Args: cobra.NoArgs,
+}
+
+var args *clusterautoscaler.AutoscalerArgs
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
+	ocm.AddClusterFlag(Cmd)
+	args = clusterautoscaler.AddFlags(flags)
+	output.AddFlag(Cmd)
+}
+
+func run(cmd *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS().WithOCM()
+	defer r.Cleanup()
+
+	clusterKey := r.GetClusterKey()
+
+	cluster := r.FetchCluster()
+	if cluster.Hypershift().Enabled() {
+		r.Reporter.Errorf("Hosted Control Plane clusters do not support cluster-autoscaler configuration")
+		os.Exit(1)
+	}
+	if cluster.State() != cmv1.ClusterStateReady {
+		r.Reporter.Errorf("Cluster '%s' is not yet ready", clusterKey)
+		os.Exit(1)
+	}
+
+	autoscaler, err := r.OCMClient.GetClusterAutoscaler(cluster.ID())
+	if err != nil {
+		r.Reporter.Errorf("Failed to get autoscaler for cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+	if autoscaler == nil {
+		r.Reporter.Errorf("Cluster '%s' has no autoscaler, use 'rosa create autoscaler' to add one",
+			clusterKey)
+		os.Exit(1)
+	}
+
+	flags := cmd.Flags()
+	if !clusterautoscaler.AnyChanged(flags) {
+		interactive.Enable()
+	}
+	args.SetDefaultsFrom(flags, autoscaler)
+	instanceTypes, err := r.OCMClient.GetMachinePoolInstanceTypes(cluster.ID())
+	if err != nil {
+		r.Reporter.Errorf("Failed to get machine pools for cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+
+	if interactive.Enabled() {
+		err = args.Prompt(flags, clusterautoscaler.GPUTypes(instanceTypes))
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+	}
+	err = args.Validate()
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+	// Existing limits are kept even if the machine pools providing their GPUs were removed:
+	if interactive.Enabled() || clusterautoscaler.GPULimitsChanged(flags) {
+		err = args.ValidateGPUTypes(instanceTypes)
+		if err != nil {
+			r.Reporter.Warnf("%s", err)
+		}
+	}
+
+	r.Reporter.Debugf("Updating autoscaler for cluster '%s'", clusterKey)
+	autoscaler, err = r.OCMClient.UpdateClusterAutoscaler(cluster.ID(), args.Build())
+	if err != nil {
+		r.Reporter.Errorf("Failed to update autoscaler for cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+
+	if output.HasFlag() {
+		err = output.Print(autoscaler)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+		return
+	}
+	r.Reporter.Infof("Successfully updated autoscaler for cluster '%s'", clusterKey)
+}
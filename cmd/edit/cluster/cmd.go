@@ -17,15 +17,18 @@ limitations under the License.
 package cluster
 
 import (
-	"errors"
 	"fmt"
 	"os"
+	"reflect"
+	"sort"
 	"strings"
+	"text/tabwriter"
 	"time"
 
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/openshift/rosa/pkg/aws"
 	"github.com/openshift/rosa/pkg/helper"
+	"github.com/openshift/rosa/pkg/helper/clusterspec"
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/interactive/confirm"
 	"github.com/openshift/rosa/pkg/ocm"
@@ -36,6 +39,9 @@ import (
 const doubleQuotesToRemove = "\"\""
 
 var args struct {
+	// Read cluster options from a YAML or JSON document
+	specFile string
+
 	// Basic options
 	expirationTime     string
 	expirationDuration time.Duration
@@ -47,6 +53,25 @@ var args struct {
 	httpsProxy                string
 	noProxySlice              []string
 	additionalTrustBundleFile string
+	tags                      []string
+
+	// Delete protection options
+	enableDeleteProtection  bool
+	disableDeleteProtection bool
+
+	// Image registry options
+	allowedRegistries  []string
+	blockedRegistries  []string
+	insecureRegistries []string
+
+	// Billing options
+	billingAccount string
+
+	// Audit log options
+	auditLogRoleARN string
+
+	// Upgrade options
+	nodeDrainGracePeriod string
 }
 
 var Cmd = &cobra.Command{
@@ -56,8 +81,29 @@ var Cmd = &cobra.Command{
 	Example: `  # Edit a cluster named "mycluster" to make it private
   rosa edit cluster mycluster --private
 
+  # Replace the tags applied to the AWS resources of a cluster
+  rosa edit cluster -c mycluster --tags=team:payments,env:prod
+
+  # Protect a cluster against deletion
+  rosa edit cluster -c mycluster --enable-delete-protection
+
+  # Extend the life of an ephemeral cluster, outside production, until three days from now
+  rosa edit cluster -c mycluster --expiration=72h
+
+  # Only allow a hosted cluster to pull images from the given registries
+  rosa edit cluster -c mycluster --registry-config-allowed-registries=quay.io,*.example.com
+
+  # Bill the usage of a hosted cluster to another AWS account linked to the organization
+  rosa edit cluster -c mycluster --billing-account=123456789012
+
+  # Stop forwarding the audit logs of a hosted cluster to CloudWatch
+  rosa edit cluster -c mycluster --audit-log-arn=""
+
   # Edit all options interactively
-  rosa edit cluster -c mycluster --interactive`,
+  rosa edit cluster -c mycluster --interactive
+
+  # Apply the changes described in a spec file, previewing them first
+  rosa edit cluster -c mycluster --spec-file=cluster.yaml`,
 	Run: run,
 }
 
@@ -66,23 +112,23 @@ func init() {
 	flags.SortFlags = false
 
 	ocm.AddClusterFlag(Cmd)
+	clusterspec.AddFlag(flags, &args.specFile)
 
 	// Basic options
 	flags.StringVar(
 		&args.expirationTime,
-		"expiration-time",
+		ocm.ExpirationTimeFlag,
 		"",
-		"Specific time when cluster should expire (RFC3339). Only one of expiration-time / expiration may be used.",
+		"Specific time when cluster should expire (RFC3339). Only one of expiration-time / expiration may be used. "+
+			ocm.ExpirationHelp,
 	)
 	flags.DurationVar(
 		&args.expirationDuration,
-		"expiration",
+		ocm.ExpirationFlag,
 		0,
-		"Expire cluster after a relative duration like 2h, 8h, 72h. Only one of expiration-time / expiration may be used.",
+		"Expire cluster after a relative duration from now like 2h, 8h, 72h, replacing the current "+
+			"expiration. Only one of expiration-time / expiration may be used. "+ocm.ExpirationHelp,
 	)
-	// Cluster expiration is not supported in production
-	flags.MarkHidden("expiration-time")
-	flags.MarkHidden("expiration")
 
 	// Networking options
 	flags.BoolVar(
@@ -102,14 +148,16 @@ func init() {
 		&args.httpProxy,
 		"http-proxy",
 		"",
-		"A proxy URL to use for creating HTTP connections outside the cluster. The URL scheme must be http.",
+		"A proxy URL to use for creating HTTP connections outside the cluster. The URL scheme must be http. "+
+			"Set it to an empty string to remove the existing value.",
 	)
 
 	flags.StringVar(
 		&args.httpsProxy,
 		"https-proxy",
 		"",
-		"A proxy URL to use for creating HTTPS connections outside the cluster.",
+		"A proxy URL to use for creating HTTPS connections outside the cluster. "+
+			"Set it to an empty string to remove the existing value.",
 	)
 
 	flags.StringSliceVar(
@@ -117,7 +165,7 @@ func init() {
 		"no-proxy",
 		nil,
 		"A comma-separated list of destination domain names, domains, IP addresses or "+
-			"other network CIDRs to exclude proxying.",
+			"other network CIDRs to exclude proxying. Set it to an empty string to remove the existing value.",
 	)
 
 	flags.StringVar(
@@ -125,7 +173,86 @@ func init() {
 		"additional-trust-bundle-file",
 		"",
 		"A file contains a PEM-encoded X.509 certificate bundle that will be "+
-			"added to the nodes' trusted certificate store.")
+			"added to the nodes' trusted certificate store. "+
+			"Set it to an empty string to remove the existing bundle.")
+
+	flags.StringSliceVar(
+		&args.tags,
+		"tags",
+		nil,
+		"Replace the user defined tags applied to the AWS resources of the cluster. "+
+			"Tags are comma separated, for example: --tags=foo:bar,bar:baz. "+
+			"Set it to an empty string to remove all the tags.",
+	)
+
+	// Delete protection options
+	flags.BoolVar(
+		&args.enableDeleteProtection,
+		"enable-delete-protection",
+		false,
+		"Protect the cluster against deletion until the protection is disabled.",
+	)
+	flags.BoolVar(
+		&args.disableDeleteProtection,
+		"disable-delete-protection",
+		false,
+		"Remove the protection of the cluster against deletion.",
+	)
+
+	// Image registry options
+	flags.StringSliceVar(
+		&args.allowedRegistries,
+		"registry-config-allowed-registries",
+		nil,
+		"Comma-separated list of the only registries that the cluster is allowed to pull images from. "+
+			"Mutually exclusive with --registry-config-blocked-registries. Only supported for hosted clusters.",
+	)
+	flags.StringSliceVar(
+		&args.blockedRegistries,
+		"registry-config-blocked-registries",
+		nil,
+		"Comma-separated list of registries that the cluster isn't allowed to pull images from. "+
+			"Mutually exclusive with --registry-config-allowed-registries. Only supported for hosted clusters.",
+	)
+	flags.StringSliceVar(
+		&args.insecureRegistries,
+		"registry-config-insecure-registries",
+		nil,
+		"Comma-separated list of registries that don't have a valid TLS certificate or only support HTTP. "+
+			"Only supported for hosted clusters.",
+	)
+
+	// Billing options
+	flags.StringVar(
+		&args.billingAccount,
+		"billing-account",
+		"",
+		"AWS account billed for the usage of the cluster. Run 'rosa list billing-accounts' to see the "+
+			"accounts linked to your organization. Only supported for hosted clusters.",
+	)
+
+	// Audit log options
+	flags.StringVar(
+		&args.auditLogRoleARN,
+		"audit-log-arn",
+		"",
+		"ARN of the IAM role used to forward the audit logs of the API server to CloudWatch. The role "+
+			"must trust the OIDC provider of the cluster for the audit log exporter service account. "+
+			"Set it to an empty value to stop forwarding the audit logs. Only supported for hosted clusters.",
+	)
+
+	// Upgrade options
+	flags.StringVar(
+		&args.nodeDrainGracePeriod,
+		"node-drain-grace-period",
+		"",
+		fmt.Sprintf("You may set a grace period for how long Pod Disruption Budget-protected workloads will be "+
+			"respected during upgrades.\nAfter this grace period, any workloads protected by Pod Disruption "+
+			"Budgets that have not been successfully drained from a node will be forcibly evicted.\nValid "+
+			"options are ['%s']", strings.Join(ocm.NodeDrainGracePeriodOptions, "','")),
+	)
+
+	confirm.AddFlag(flags)
 }
 
 func run(cmd *cobra.Command, _ []string) {
@@ -134,11 +261,19 @@ func run(cmd *cobra.Command, _ []string) {
 
 	clusterKey := r.GetClusterKey()
 
+	if args.specFile != "" {
+		applySpecFile(r, cmd, clusterKey)
+	}
+
 	// Enable interactive mode if no flags have been set
 	if !interactive.Enabled() {
 		changedFlags := false
 		for _, flag := range []string{"expiration-time", "expiration", "private",
-			"disable-workload-monitoring", "http-proxy", "https-proxy", "no-proxy", "additional-trust-bundle-file"} {
+			"disable-workload-monitoring", "http-proxy", "https-proxy", "no-proxy", "additional-trust-bundle-file",
+			"node-drain-grace-period", "tags", "enable-delete-protection", "disable-delete-protection",
+			"registry-config-allowed-registries",
+			"registry-config-blocked-registries", "registry-config-insecure-registries", "billing-account",
+			"audit-log-arn"} {
 			if cmd.Flags().Changed(flag) {
 				changedFlags = true
 			}
@@ -151,7 +286,10 @@ func run(cmd *cobra.Command, _ []string) {
 	cluster := r.FetchCluster()
 
 	// Validate flags:
-	expiration, err := validateExpiration()
+	expiration, err := ocm.ParseExpiration(args.expirationTime, args.expirationDuration, time.Now())
+	if err == nil && !expiration.IsZero() {
+		err = r.OCMClient.ValidateExpirationSupported()
+	}
 	if err != nil {
 		r.Reporter.Errorf(fmt.Sprintf("%s", err))
 		os.Exit(1)
@@ -367,12 +505,21 @@ func run(cmd *cobra.Command, _ []string) {
 		}
 	}
 	if httpsProxy != nil && *httpsProxy != doubleQuotesToRemove {
-		err = interactive.IsURL(*httpsProxy)
+		err = ocm.ValidateHTTPSProxy(*httpsProxy)
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
 			os.Exit(1)
 		}
 	}
+	for _, proxy := range []*string{httpProxy, httpsProxy} {
+		if proxy == nil || *proxy == "" || *proxy == doubleQuotesToRemove {
+			continue
+		}
+		err = ocm.ValidateProxyReachability(*proxy)
+		if err != nil {
+			r.Reporter.Warnf("%s. Make sure that it is reachable from the VPC of the cluster.", err)
+		}
+	}
 
 	///******* NoProxy *******/
 	if enableProxy && interactive.Enabled() {
@@ -415,6 +562,19 @@ func run(cmd *cobra.Command, _ []string) {
 		}
 	}
 
+	var noProxy *string
+	if noProxySlice != nil {
+		noProxyValue := strings.Join(noProxySlice, ",")
+		noProxy = &noProxyValue
+	}
+	if proxyValueAfterEdit(noProxy, cluster.Proxy().NoProxy()) != "" &&
+		proxyValueAfterEdit(httpProxy, cluster.Proxy().HTTPProxy()) == "" &&
+		proxyValueAfterEdit(httpsProxy, cluster.Proxy().HTTPSProxy()) == "" {
+		r.Reporter.Errorf("The no-proxy list requires at least one of the following: http-proxy, https-proxy. " +
+			"Remove the no-proxy list as well to disable the cluster-wide proxy")
+		os.Exit(1)
+	}
+
 	/*******  AdditionalTrustBundle *******/
 	updateAdditionalTrustBundle := false
 	if additionalTrustBundleFile != nil {
@@ -473,12 +633,61 @@ func run(cmd *cobra.Command, _ []string) {
 		}
 	}
 
+	var nodeDrainGracePeriod float64
+	if cluster.Hypershift().Enabled() {
+		if cmd.Flags().Changed("node-drain-grace-period") {
+			r.Reporter.Errorf("Setting the node drain grace period is not supported for Hosted Control Plane clusters")
+			os.Exit(1)
+		}
+	} else if cmd.Flags().Changed("node-drain-grace-period") || interactive.Enabled() {
+		nodeDrainGracePeriodValue := args.nodeDrainGracePeriod
+		if !cmd.Flags().Changed("node-drain-grace-period") {
+			nodeDrainGracePeriodValue = ocm.FormatNodeDrainGracePeriod(cluster)
+			if nodeDrainGracePeriodValue == "" {
+				nodeDrainGracePeriodValue = "1 hour"
+			}
+		}
+		if interactive.Enabled() {
+			nodeDrainGracePeriodValue, err = interactive.GetOption(interactive.Input{
+				Question: "Node draining",
+				Help:     cmd.Flags().Lookup("node-drain-grace-period").Usage,
+				Options:  ocm.NodeDrainGracePeriodOptions,
+				Default:  nodeDrainGracePeriodValue,
+			})
+			if err != nil {
+				r.Reporter.Errorf("Expected a valid node drain grace period: %s", err)
+				os.Exit(1)
+			}
+		}
+		if nodeDrainGracePeriodValue != "" {
+			nodeDrainGracePeriod, err = ocm.ParseNodeDrainGracePeriod(nodeDrainGracePeriodValue)
+			if err != nil {
+				r.Reporter.Errorf("%s", err)
+				os.Exit(1)
+			}
+		}
+	}
+
+	var tags map[string]string
+	if cmd.Flags().Changed("tags") || interactive.Enabled() {
+		userTags := args.tags
+		if !cmd.Flags().Changed("tags") {
+			userTags = formatTags(cluster.AWS().Tags())
+		}
+		tags = interactive.GetUserTags(r, cmd, userTags)
+		if !cmd.Flags().Changed("tags") && sameTags(tags, cluster.AWS().Tags()) {
+			tags = nil
+		}
+	}
+
 	clusterConfig := ocm.Spec{
 		Expiration:                expiration,
 		Private:                   private,
 		DisableWorkloadMonitoring: disableWorkloadMonitoring,
+		Tags:                      tags,
 	}
 
+	clusterConfig.NodeDrainGracePeriodInMinutes = nodeDrainGracePeriod
 	if httpProxy != nil {
 		clusterConfig.HTTPProxy = httpProxy
 	}
@@ -501,51 +710,264 @@ func run(cmd *cobra.Command, _ []string) {
 					r.Reporter.Errorf("Failed to read additional trust bundle file: %s", err)
 					os.Exit(1)
 				}
+				for _, warning := range ocm.TrustBundleWarnings(cert) {
+					r.Reporter.Warnf("%s", warning)
+				}
 				*clusterConfig.AdditionalTrustBundle = string(cert)
 			}
 		}
 	}
 
+	if args.enableDeleteProtection && args.disableDeleteProtection {
+		r.Reporter.Errorf("Options --enable-delete-protection and --disable-delete-protection are mutually exclusive")
+		os.Exit(1)
+	}
+	var deleteProtection *bool
+	if cmd.Flags().Changed("enable-delete-protection") {
+		deleteProtection = &args.enableDeleteProtection
+	} else if cmd.Flags().Changed("disable-delete-protection") {
+		enabled := !args.disableDeleteProtection
+		deleteProtection = &enabled
+	} else if interactive.Enabled() {
+		enabled, err := interactive.GetBool(interactive.Input{
+			Question: "Enable delete protection",
+			Help:     cmd.Flags().Lookup("enable-delete-protection").Usage,
+			Default:  cluster.DeleteProtection().Enabled(),
+		})
+		if err != nil {
+			r.Reporter.Errorf("Expected a valid delete protection value: %s", err)
+			os.Exit(1)
+		}
+		if enabled != cluster.DeleteProtection().Enabled() {
+			deleteProtection = &enabled
+		}
+	}
+
+	var registryConfig *ocm.RegistryConfig
+	if cmd.Flags().Changed("registry-config-allowed-registries") ||
+		cmd.Flags().Changed("registry-config-blocked-registries") ||
+		cmd.Flags().Changed("registry-config-insecure-registries") {
+		if !cluster.Hypershift().Enabled() {
+			r.Reporter.Errorf("Image registry configuration is only supported for hosted clusters")
+			os.Exit(1)
+		}
+		registryConfig, err = ocm.NewRegistryConfig(args.allowedRegistries, args.blockedRegistries,
+			args.insecureRegistries)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+		if registryConfig == nil {
+			registryConfig = &ocm.RegistryConfig{RegistrySources: &ocm.RegistrySources{}}
+		}
+	}
+
+	var billingAccount string
+	if cmd.Flags().Changed("billing-account") || (interactive.Enabled() && cluster.Hypershift().Enabled()) {
+		if !cluster.Hypershift().Enabled() {
+			r.Reporter.Errorf("Billing account is only supported for hosted clusters")
+			os.Exit(1)
+		}
+		currentBillingAccount, err := r.OCMClient.GetClusterBillingAccount(cluster.ID())
+		if err != nil {
+			r.Reporter.Errorf("Failed to get billing account of cluster '%s': %v", clusterKey, err)
+			os.Exit(1)
+		}
+		billingAccounts, err := r.OCMClient.GetBillingAccounts()
+		if err != nil {
+			r.Reporter.Errorf("Failed to get billing accounts: %v", err)
+			os.Exit(1)
+		}
+		billingAccount = args.billingAccount
+		if !cmd.Flags().Changed("billing-account") {
+			billingAccount = currentBillingAccount
+		}
+		if interactive.Enabled() && len(billingAccounts) > 0 {
+			options := make([]string, len(billingAccounts))
+			for i, account := range billingAccounts {
+				options[i] = account.CloudAccountID
+			}
+			billingAccount, err = interactive.GetOption(interactive.Input{
+				Question: "Billing account",
+				Help:     cmd.Flags().Lookup("billing-account").Usage,
+				Options:  options,
+				Default:  billingAccount,
+				Required: true,
+			})
+			if err != nil {
+				r.Reporter.Errorf("Expected a valid billing account: %s", err)
+				os.Exit(1)
+			}
+		}
+		if billingAccount == currentBillingAccount {
+			billingAccount = ""
+		} else {
+			err = ocm.ValidateBillingAccount(billingAccounts, billingAccount, time.Now())
+			if err != nil {
+				r.Reporter.Errorf("%s", err)
+				os.Exit(1)
+			}
+		}
+	}
+
+	var auditLogRoleARN *string
+	if cmd.Flags().Changed("audit-log-arn") || (interactive.Enabled() && cluster.Hypershift().Enabled()) {
+		if !cluster.Hypershift().Enabled() {
+			r.Reporter.Errorf("Audit log forwarding is only supported for hosted clusters")
+			os.Exit(1)
+		}
+		currentAuditLogRoleARN, err := r.OCMClient.GetClusterAuditLogRoleARN(cluster.ID())
+		if err != nil {
+			r.Reporter.Errorf("Failed to get audit log forwarding role of cluster '%s': %v", clusterKey, err)
+			os.Exit(1)
+		}
+		roleARN := args.auditLogRoleARN
+		if !cmd.Flags().Changed("audit-log-arn") {
+			roleARN = currentAuditLogRoleARN
+		}
+		if interactive.Enabled() {
+			roleARN, err = interactive.GetString(interactive.Input{
+				Question: "Audit log forwarding role ARN",
+				Help:     cmd.Flags().Lookup("audit-log-arn").Usage,
+				Default:  roleARN,
+				Validators: []interactive.Validator{
+					aws.ARNValidator,
+				},
+			})
+			if err != nil {
+				r.Reporter.Errorf("Expected a valid audit log role ARN: %s", err)
+				os.Exit(1)
+			}
+		}
+		if roleARN != currentAuditLogRoleARN {
+			if roleARN != "" {
+				err = aws.ValidateAuditLogRoleARN(roleARN, cluster.Region().ID())
+				if err != nil {
+					r.Reporter.Errorf("%s", err)
+					os.Exit(1)
+				}
+				err = r.AWSClient.ValidateAuditLogRole(roleARN, cluster.AWS().STS().OIDCEndpointURL())
+				if err != nil {
+					r.Reporter.Errorf("%s", err)
+					os.Exit(1)
+				}
+			}
+			auditLogRoleARN = &roleARN
+		}
+	}
+
 	r.Reporter.Debugf("Updating cluster '%s'", clusterKey)
 	err = r.OCMClient.UpdateCluster(clusterKey, r.Creator, clusterConfig)
 	if err != nil {
 		r.Reporter.Errorf("Failed to update cluster: %v", err)
 		os.Exit(1)
 	}
+	if deleteProtection != nil {
+		r.Reporter.Debugf("Updating delete protection of cluster '%s'", clusterKey)
+		err = r.OCMClient.UpdateDeleteProtection(cluster.ID(), *deleteProtection)
+		if err != nil {
+			r.Reporter.Errorf("Failed to update delete protection: %v", err)
+			os.Exit(1)
+		}
+	}
+	if registryConfig != nil {
+		r.Reporter.Debugf("Updating image registry configuration of cluster '%s'", clusterKey)
+		err = r.OCMClient.UpdateClusterRegistryConfig(cluster.ID(), registryConfig)
+		if err != nil {
+			r.Reporter.Errorf("Failed to update image registry configuration: %v", err)
+			os.Exit(1)
+		}
+	}
+	if billingAccount != "" {
+		r.Reporter.Debugf("Updating billing account of cluster '%s'", clusterKey)
+		err = r.OCMClient.UpdateClusterBillingAccount(cluster.ID(), billingAccount)
+		if err != nil {
+			r.Reporter.Errorf("Failed to update billing account: %v", err)
+			os.Exit(1)
+		}
+	}
+	if auditLogRoleARN != nil {
+		r.Reporter.Debugf("Updating audit log forwarding role of cluster '%s'", clusterKey)
+		err = r.OCMClient.UpdateClusterAuditLogRoleARN(cluster.ID(), *auditLogRoleARN)
+		if err != nil {
+			r.Reporter.Errorf("Failed to update audit log forwarding: %v", err)
+			os.Exit(1)
+		}
+	}
 	r.Reporter.Infof("Updated cluster '%s'", clusterKey)
 }
 
-func validateExpiration() (expiration time.Time, err error) {
-	// Validate options
-	if len(args.expirationTime) > 0 && args.expirationDuration != 0 {
-		err = errors.New("At most one of 'expiration-time' or 'expiration' may be specified")
-		return
+// sameTags checks if the given sets of tags are equal, treating a missing set as an empty one.
+func sameTags(a, b map[string]string) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
 	}
+	return reflect.DeepEqual(a, b)
+}
 
-	// Parse the expiration options
-	if len(args.expirationTime) > 0 {
-		t, err := parseRFC3339(args.expirationTime)
-		if err != nil {
-			err = fmt.Errorf("Failed to parse expiration-time: %s", err)
-			return expiration, err
-		}
+// formatTags returns the tags in the format used by the --tags option, sorted by key.
+func formatTags(tags map[string]string) []string {
+	result := make([]string, 0, len(tags))
+	for key, value := range tags {
+		result = append(result, fmt.Sprintf("%s:%s", key, value))
+	}
+	sort.Strings(result)
+	return result
+}
 
-		expiration = t
+// applySpecFile compares the spec file with the cluster, shows the differences and sets the
+// command line options that correspond to the ones that can be changed.
+func applySpecFile(r *rosa.Runtime, cmd *cobra.Command, clusterKey string) {
+	spec, err := clusterspec.Load(args.specFile)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
 	}
-	if args.expirationDuration != 0 {
-		// round up to the nearest second
-		expiration = time.Now().Add(args.expirationDuration).Round(time.Second)
+
+	cluster := r.FetchCluster()
+	changes := spec.Diff(cluster, cmd.Flags())
+	values := map[string]string{}
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, change := range changes {
+		if !change.Mutable {
+			r.Reporter.Warnf("Option '%s' can't be changed on an existing cluster, ignoring it "+
+				"(current: '%s', desired: '%s')", change.Option, change.Current, change.Desired)
+			continue
+		}
+		if len(values) == 0 {
+			fmt.Fprintf(writer, "OPTION\tCURRENT\tDESIRED\n")
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\n", change.Option, change.Current, change.Desired)
+		values[change.Option] = change.Desired
+	}
+	if len(values) == 0 {
+		r.Reporter.Infof("Cluster '%s' already matches spec file '%s', nothing to do", clusterKey, args.specFile)
+		os.Exit(0)
+	}
+
+	r.Reporter.Infof("The following changes will be applied to cluster '%s':", clusterKey)
+	writer.Flush()
+	if !confirm.Confirm("apply the changes to cluster '%s'", clusterKey) {
+		os.Exit(0)
 	}
 
-	return
+	err = clusterspec.ApplyValues(cmd.Flags(), values)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
 }
 
-// parseRFC3339 parses an RFC3339 date in either RFC3339Nano or RFC3339 format.
-func parseRFC3339(s string) (time.Time, error) {
-	if t, timeErr := time.Parse(time.RFC3339Nano, s); timeErr == nil {
-		return t, nil
+// proxyValueAfterEdit returns the value that a proxy setting will have once the changes are applied
+// to the cluster.
+func proxyValueAfterEdit(value *string, current string) string {
+	if value == nil {
+		return current
+	}
+	if *value == doubleQuotesToRemove {
+		return ""
 	}
-	return time.Parse(time.RFC3339, s)
+	return *value
 }
 
 func isExpectedHTTPProxyOrHTTPSProxy(httpProxy, httpsProxy *string, noProxySlice []string, cluster *cmv1.Cluster) bool {
@@ -0,0 +1,137 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalauthprovider
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/externalauthprovider"
+	"github.com/openshift/rosa/pkg/helper/oidc"
+	"github.com/openshift/rosa/pkg/interactive"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var Cmd = &cobra.Command{
+	Use:     "external-auth-provider NAME",
+	Aliases: []string{"externalauthprovider"},
+	Short:   "Edit an external authentication provider of a cluster",
+	Long: "Edit an external authentication provider of a Hosted Control Plane cluster. Only the options " +
+		"given in the command line are changed. The OIDC clients are replaced when any of the client " +
+		"options is given.",
+	Example: `  # Change the audiences of external authentication provider 'myprovider'
+  rosa edit external-auth-provider myprovider -c mycluster --issuer-audiences audience1,audience3
+
+  # Interactively edit external authentication provider 'myprovider'
+  rosa edit external-auth-provider myprovider -c mycluster --interactive`,
+	Run:  run,
+	Args: cobra.ExactArgs(1),
+}
+
+var args *externalauthprovider.Args
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
+	ocm.AddClusterFlag(Cmd)
+	args = externalauthprovider.AddFlags(flags)
+	interactive.AddFlag(flags)
+	output.AddFlag(Cmd)
+}
+
+func run(cmd *cobra.Command, argv []string) {
+	r := rosa.NewRuntime().WithAWS().WithOCM()
+	defer r.Cleanup()
+
+	clusterKey := r.GetClusterKey()
+	name := argv[0]
+
+	cluster := r.FetchCluster()
+	err := r.OCMClient.ValidateExternalAuthCluster(cluster)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+
+	externalAuth, err := r.OCMClient.GetExternalAuth(cluster.ID(), name)
+	if err != nil {
+		r.Reporter.Errorf("Failed to get external authentication provider '%s' for cluster '%s': %v",
+			name, clusterKey, err)
+		os.Exit(1)
+	}
+	if externalAuth == nil {
+		r.Reporter.Errorf("External authentication provider '%s' doesn't exist for cluster '%s'", name, clusterKey)
+		os.Exit(1)
+	}
+
+	flags := cmd.Flags()
+	if !externalauthprovider.AnyChanged(flags) {
+		interactive.Enable()
+	}
+	args.SetDefaultsFrom(flags, externalAuth)
+	if interactive.Enabled() {
+		err = args.Prompt(flags)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+	}
+	withClients := interactive.Enabled() || externalauthprovider.ClientsChanged(flags)
+	err = args.Validate(withClients)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+
+	update, err := args.Build(name, withClients)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+	if update.Issuer.CA == "" && externalAuth.Issuer != nil {
+		update.Issuer.CA = externalAuth.Issuer.CA
+	}
+	r.Reporter.Debugf("Checking the discovery document of issuer '%s'", args.IssuerURL)
+	err = oidc.VerifyIssuerWithCA(args.IssuerURL, []byte(update.Issuer.CA))
+	if err != nil {
+		r.Reporter.Errorf("Issuer '%s' isn't a valid OIDC issuer: %v", args.IssuerURL, err)
+		os.Exit(1)
+	}
+
+	r.Reporter.Debugf("Updating external authentication provider '%s' for cluster '%s'", name, clusterKey)
+	externalAuth, err = r.OCMClient.UpdateExternalAuth(cluster.ID(), update)
+	if err != nil {
+		r.Reporter.Errorf("Failed to update external authentication provider '%s' for cluster '%s': %v",
+			name, clusterKey, err)
+		os.Exit(1)
+	}
+
+	if output.HasFlag() {
+		err = output.Print(externalAuth)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+		return
+	}
+	r.Reporter.Infof("Successfully updated external authentication provider '%s' for cluster '%s'",
+		name, clusterKey)
+}
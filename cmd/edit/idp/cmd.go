@@ -0,0 +1,219 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package idp
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	idpPack "github.com/openshift/rosa/cmd/create/idp"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "idp NAME",
+	Short: "Edit the users of an HTPasswd IDP",
+	Long:  "Add, remove or change the password of users of a multi user HTPasswd identity provider.",
+	Example: `  # Add two users to the HTPasswd identity provider named htpasswd
+  rosa edit idp htpasswd -c mycluster --add-user user1:password1,user2:password2
+
+  # Remove a user and change the password of another one
+  rosa edit idp htpasswd -c mycluster --remove-user user1 --change-password user2:password2`,
+	Run: run,
+	Args: func(_ *cobra.Command, argv []string) error {
+		if len(argv) != 1 {
+			return fmt.Errorf(
+				"Expected exactly one command line parameter containing the name of the identity provider",
+			)
+		}
+		return nil
+	},
+}
+
+var args struct {
+	addUsers        []string
+	removeUsers     []string
+	changePasswords []string
+}
+
+func init() {
+	ocm.AddClusterFlag(Cmd)
+	flags := Cmd.Flags()
+	flags.StringSliceVar(
+		&args.addUsers,
+		"add-user",
+		nil,
+		"Comma-separated list of 'username:password' pairs of the users to add to the IDP.",
+	)
+	flags.StringSliceVar(
+		&args.removeUsers,
+		"remove-user",
+		nil,
+		"Comma-separated list of the names of the users to remove from the IDP.",
+	)
+	flags.StringSliceVar(
+		&args.changePasswords,
+		"change-password",
+		nil,
+		"Comma-separated list of 'username:password' pairs of the users whose password should be changed.",
+	)
+}
+
+func run(_ *cobra.Command, argv []string) {
+	r := rosa.NewRuntime().WithAWS().WithOCM()
+	defer r.Cleanup()
+
+	idpName := argv[0]
+
+	clusterKey := r.GetClusterKey()
+
+	if len(args.addUsers) == 0 && len(args.removeUsers) == 0 && len(args.changePasswords) == 0 {
+		r.Reporter.Errorf("Nothing to edit, use '--add-user', '--remove-user' or '--change-password'")
+		os.Exit(1)
+	}
+	for _, pair := range args.changePasswords {
+		if strings.HasPrefix(pair, idpPack.ClusterAdminUsername+":") {
+			r.Reporter.Errorf("The password of user '%s' can't be changed here, use "+
+				"'rosa edit admin --rotate-password' instead", idpPack.ClusterAdminUsername)
+			os.Exit(1)
+		}
+	}
+	addUsers, err := idpPack.ParseHTPasswdUsers(args.addUsers)
+	if err != nil {
+		r.Reporter.Errorf("Invalid '--add-user' value: %v", err)
+		os.Exit(1)
+	}
+	changePasswords, err := idpPack.ParseHTPasswdUsers(args.changePasswords)
+	if err != nil {
+		r.Reporter.Errorf("Invalid '--change-password' value: %v", err)
+		os.Exit(1)
+	}
+	for _, username := range args.removeUsers {
+		if username == idpPack.ClusterAdminUsername {
+			r.Reporter.Errorf("User '%s' can't be removed, use 'rosa delete admin' instead",
+				idpPack.ClusterAdminUsername)
+			os.Exit(1)
+		}
+	}
+
+	cluster := r.FetchCluster()
+	if cluster.State() != cmv1.ClusterStateReady {
+		r.Reporter.Errorf("Cluster '%s' is not yet ready", clusterKey)
+		os.Exit(1)
+	}
+
+	r.Reporter.Debugf("Loading identity provider '%s'", idpName)
+	idps, err := r.OCMClient.GetIdentityProviders(cluster.ID())
+	if err != nil {
+		r.Reporter.Errorf("Failed to get identity providers for cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+	var idp *cmv1.IdentityProvider
+	for _, item := range idps {
+		if item.Name() == idpName {
+			idp = item
+		}
+	}
+	if idp == nil {
+		r.Reporter.Errorf("Failed to get identity provider '%s' for cluster '%s'", idpName, clusterKey)
+		os.Exit(1)
+	}
+	if ocm.IdentityProviderType(idp) != ocm.HTPasswdIDPType {
+		r.Reporter.Errorf("Identity provider '%s' is not an HTPasswd IDP, only the users of HTPasswd IDPs "+
+			"can be edited", idpName)
+		os.Exit(1)
+	}
+	if idp.Htpasswd().Username() != "" {
+		r.Reporter.Errorf("Users can't be edited in a single user HTPasswd IDP. Delete the IDP and recreate " +
+			"it as a multi user HTPasswd IDP")
+		os.Exit(1)
+	}
+
+	userList, err := r.OCMClient.GetHTPasswdUserList(cluster.ID(), idp.ID())
+	if err != nil {
+		r.Reporter.Errorf("Failed to get user list of the HTPasswd IDP of '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+	existing := map[string]bool{}
+	userList.Each(func(user *cmv1.HTPasswdUser) bool {
+		existing[user.Username()] = true
+		return true
+	})
+
+	// Check all the changes before applying any of them, so that the IDP isn't left half edited:
+	for _, user := range addUsers {
+		if existing[user.Username] {
+			r.Reporter.Errorf("User '%s' already exists in identity provider '%s'", user.Username, idpName)
+			os.Exit(1)
+		}
+	}
+	for _, username := range args.removeUsers {
+		if !existing[username] {
+			r.Reporter.Errorf("User '%s' doesn't exist in identity provider '%s'", username, idpName)
+			os.Exit(1)
+		}
+	}
+	for _, user := range changePasswords {
+		if !existing[user.Username] {
+			r.Reporter.Errorf("User '%s' doesn't exist in identity provider '%s'", user.Username, idpName)
+			os.Exit(1)
+		}
+	}
+	if len(addUsers) == 0 && len(args.removeUsers) >= len(existing) {
+		r.Reporter.Errorf("Can't remove all the users of identity provider '%s', delete it with "+
+			"'rosa delete idp %s' instead", idpName, idpName)
+		os.Exit(1)
+	}
+
+	if len(addUsers) > 0 {
+		r.Reporter.Debugf("Adding users to identity provider '%s' on cluster '%s'", idpName, clusterKey)
+		err = r.OCMClient.ImportHTPasswdUsers(cluster.ID(), idp.ID(), addUsers)
+		if err != nil {
+			r.Reporter.Errorf("Failed to add users to identity provider '%s' on cluster '%s': %v",
+				idpName, clusterKey, err)
+			os.Exit(1)
+		}
+		for _, user := range addUsers {
+			r.Reporter.Infof("User '%s' added", user.Username)
+		}
+	}
+	for _, user := range changePasswords {
+		r.Reporter.Debugf("Updating password of user '%s' on cluster '%s'", user.Username, clusterKey)
+		err = r.OCMClient.UpdateHTPasswdUserPassword(user.Username, user.Password, cluster.ID(), idp.ID())
+		if err != nil {
+			r.Reporter.Errorf("Failed to update the password of user '%s' on cluster '%s': %v",
+				user.Username, clusterKey, err)
+			os.Exit(1)
+		}
+		r.Reporter.Infof("Password of user '%s' changed", user.Username)
+	}
+	for _, username := range args.removeUsers {
+		r.Reporter.Debugf("Removing user '%s' from cluster '%s'", username, clusterKey)
+		err = r.OCMClient.DeleteHTPasswdUser(username, cluster.ID(), idp)
+		if err != nil {
+			r.Reporter.Errorf("Failed to remove user '%s' from cluster '%s': %v", username, clusterKey, err)
+			os.Exit(1)
+		}
+		r.Reporter.Infof("User '%s' removed", username)
+	}
+	r.Reporter.Infof("It may take several minutes for the changes to become active.")
+}
@@ -21,12 +21,14 @@ import (
 	"os"
 	"reflect"
 	"regexp"
+	"sort"
 	"strings"
 
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
+	ingressHelpers "github.com/openshift/rosa/pkg/helper/ingress"
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/rosa"
@@ -37,8 +39,13 @@ import (
 var ingressKeyRE = regexp.MustCompile(`^[a-z0-9]{3,5}$`)
 
 var args struct {
-	private    bool
-	labelMatch string
+	private                  bool
+	labelMatch               string
+	excludedNamespaces       string
+	namespaceOwnershipPolicy string
+	wildcardPolicy           string
+	loadBalancerType         string
+	componentRoutes          string
 }
 
 var Cmd = &cobra.Command{
@@ -53,7 +60,14 @@ var Cmd = &cobra.Command{
   rosa edit ingress --label-match=foo=bar --cluster=mycluster a1b2
 
   # Update the default ingress using the sub-domain identifier
-  rosa edit ingress --private=false --cluster=mycluster apps`,
+  rosa edit ingress --private=false --cluster=mycluster apps
+
+  # Exclude namespaces and use a network load balancer for the default ingress
+  rosa edit ingress --excluded-namespaces=stage,dev --lb-type=nlb --cluster=mycluster apps
+
+  # Use a custom hostname for the console route of the default ingress
+  rosa edit ingress --component-routes="console: hostname=console.example.com;tlsSecretRef=console-tls" \
+    --cluster=mycluster apps`,
 	Run: run,
 	Args: func(_ *cobra.Command, argv []string) error {
 		if len(argv) != 1 {
@@ -74,10 +88,27 @@ func shouldEnableInteractive(flagSet *pflag.FlagSet, params []string) bool {
 }
 
 const (
-	privateFlag    = "private"
-	labelMatchFlag = "label-match"
+	privateFlag                  = "private"
+	labelMatchFlag               = "label-match"
+	excludedNamespacesFlag       = "excluded-namespaces"
+	namespaceOwnershipPolicyFlag = "namespace-ownership-policy"
+	wildcardPolicyFlag           = "wildcard-policy"
+	lbTypeFlag                   = "lb-type"
+	componentRoutesFlag          = "component-routes"
 )
 
+// ingressFlags are the options that change the ingresses, the API endpoint only supports the
+// first one.
+var ingressFlags = []string{
+	privateFlag,
+	labelMatchFlag,
+	excludedNamespacesFlag,
+	namespaceOwnershipPolicyFlag,
+	wildcardPolicyFlag,
+	lbTypeFlag,
+	componentRoutesFlag,
+}
+
 func init() {
 	flags := Cmd.Flags()
 
@@ -97,6 +128,49 @@ func init() {
 		"Label match for ingress. Format should be a comma-separated list of 'key=value'. "+
 			"If no label is specified, all routes will be exposed on both routers.",
 	)
+
+	flags.StringVar(
+		&args.excludedNamespaces,
+		excludedNamespacesFlag,
+		"",
+		"Namespaces whose routes aren't exposed by the ingress. Format should be a comma-separated "+
+			"list of namespaces. An empty value removes the exclusions.",
+	)
+
+	flags.StringVar(
+		&args.namespaceOwnershipPolicy,
+		namespaceOwnershipPolicyFlag,
+		"",
+		fmt.Sprintf("Policy that decides if routes of different namespaces can claim the same host name, "+
+			"one of %s.", strings.Join(ocm.NamespaceOwnershipPolicies, ", ")),
+	)
+
+	flags.StringVar(
+		&args.wildcardPolicy,
+		wildcardPolicyFlag,
+		"",
+		fmt.Sprintf("Policy that decides if routes with wildcard host names are admitted, one of %s.",
+			strings.Join(ocm.WildcardPolicies, ", ")),
+	)
+
+	flags.StringVar(
+		&args.loadBalancerType,
+		lbTypeFlag,
+		"",
+		fmt.Sprintf("Type of the AWS load balancer of the ingress, one of %s.",
+			strings.Join(ocm.LoadBalancerTypes, ", ")),
+	)
+
+	flags.StringVar(
+		&args.componentRoutes,
+		componentRoutesFlag,
+		"",
+		fmt.Sprintf("Custom host names of the routes of the cluster components in the default ingress. "+
+			"Format should be a comma-separated list of 'name: hostname=value;tlsSecretRef=value', where "+
+			"name is one of %s and tlsSecretRef is a secret of the 'openshift-config' namespace. Empty "+
+			"values restore the default route of the component.",
+			strings.Join(ocm.ComponentRouteNames, ", ")),
+	)
 }
 
 func run(cmd *cobra.Command, argv []string) {
@@ -113,43 +187,12 @@ func run(cmd *cobra.Command, argv []string) {
 	}
 
 	clusterKey := r.GetClusterKey()
+	flags := cmd.Flags()
 
-	if !interactive.Enabled() && shouldEnableInteractive(cmd.Flags(), []string{labelMatchFlag, privateFlag}) {
+	if !interactive.Enabled() && shouldEnableInteractive(flags, ingressFlags) {
 		interactive.Enable()
 	}
 
-	var labelMatch *string
-	if cmd.Flags().Changed(labelMatchFlag) {
-		labelMatch = &args.labelMatch
-	} else if interactive.Enabled() {
-		labelMatchArg, err := interactive.GetString(interactive.Input{
-			Question: "Label match for ingress",
-			Help:     cmd.Flags().Lookup(labelMatchFlag).Usage,
-			Default:  labelMatch,
-		})
-		if err != nil {
-			r.Reporter.Errorf("Expected a valid comma-separated list of attributes: %s", err)
-			os.Exit(1)
-		}
-		labelMatch = &labelMatchArg
-	}
-
-	var private *bool
-	if cmd.Flags().Changed(privateFlag) {
-		private = &args.private
-	} else if interactive.Enabled() {
-		privArg, err := interactive.GetBool(interactive.Input{
-			Question: "Private ingress",
-			Help:     cmd.Flags().Lookup(privateFlag).Usage,
-			Default:  args.private,
-		})
-		if err != nil {
-			r.Reporter.Errorf("Expected a valid private value: %s", err)
-			os.Exit(1)
-		}
-		private = &privArg
-	}
-
 	cluster := r.FetchCluster()
 	if cluster.AWS().PrivateLink() {
 		r.Reporter.Errorf("Cluster '%s' is PrivateLink and does not support updating ingresses", clusterKey)
@@ -158,6 +201,14 @@ func run(cmd *cobra.Command, argv []string) {
 
 	// Edit API endpoint instead of ingresses
 	if ingressID == "api" {
+		for _, flag := range ingressFlags[1:] {
+			if flags.Changed(flag) {
+				r.Reporter.Errorf("Option '--%s' isn't supported for the API endpoint, only '--%s' is",
+					flag, privateFlag)
+				os.Exit(1)
+			}
+		}
+		private := getPrivate(cmd, r, cluster.API().Listening() == cmv1.ListeningMethodInternal)
 		clusterConfig := ocm.Spec{
 			Private: private,
 		}
@@ -173,7 +224,7 @@ func run(cmd *cobra.Command, argv []string) {
 
 	// Try to find the ingress:
 	r.Reporter.Debugf("Loading ingresses for cluster '%s'", clusterKey)
-	ingresses, err := r.OCMClient.GetIngresses(cluster.ID())
+	ingresses, settings, err := r.OCMClient.GetIngressesWithSettings(cluster.ID())
 	if err != nil {
 		r.Reporter.Errorf("Failed to get ingresses for cluster '%s': %v", clusterKey, err)
 		os.Exit(1)
@@ -195,6 +246,56 @@ func run(cmd *cobra.Command, argv []string) {
 		r.Reporter.Errorf("Failed to get ingress '%s' for cluster '%s'", ingressID, clusterKey)
 		os.Exit(1)
 	}
+	current := settings[ingress.ID()]
+	if current == nil {
+		current = &ocm.IngressSettings{}
+	}
+
+	private := getPrivate(cmd, r, ingress.Listening() == cmv1.ListeningMethodInternal)
+
+	options := &ingressHelpers.Options{}
+	labelMatch := getString(cmd, r, labelMatchFlag, "Label match for ingress",
+		formatRouteSelectors(ingress.RouteSelectors()), ingressHelpers.LabelMatchValidator)
+	if labelMatch != nil {
+		options.RouteSelectors, err = ingressHelpers.GetRouteSelector(*labelMatch)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+	}
+	excludedNamespaces := getString(cmd, r, excludedNamespacesFlag, "Excluded namespaces",
+		strings.Join(current.ExcludedNamespaces, ","), ingressHelpers.ExcludedNamespacesValidator)
+	if excludedNamespaces != nil {
+		options.ExcludedNamespaces, err = ingressHelpers.GetExcludedNamespaces(*excludedNamespaces)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+	}
+	options.NamespaceOwnershipPolicy = getOption(cmd, r, namespaceOwnershipPolicyFlag,
+		"Namespace ownership policy", current.NamespaceOwnershipPolicy, ocm.NamespaceOwnershipPolicies)
+	options.WildcardPolicy = getOption(cmd, r, wildcardPolicyFlag, "Wildcard policy",
+		current.WildcardPolicy, ocm.WildcardPolicies)
+	options.LoadBalancerType = getOption(cmd, r, lbTypeFlag, "Load balancer type",
+		current.LoadBalancerType, ocm.LoadBalancerTypes)
+	if flags.Changed(componentRoutesFlag) || (interactive.Enabled() && ingress.Default()) {
+		componentRoutes := getString(cmd, r, componentRoutesFlag, "Component routes",
+			ingressHelpers.FormatComponentRoutes(current.ComponentRoutes), func(val interface{}) error {
+				_, err := ingressHelpers.GetComponentRoutes(fmt.Sprintf("%v", val))
+				return err
+			})
+		options.ComponentRoutes, err = ingressHelpers.GetComponentRoutes(*componentRoutes)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+	}
+
+	err = options.Validate(ingress.Default())
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
 
 	curListening := ingress.Listening()
 	curRouteSelectors := ingress.RouteSelectors()
@@ -209,16 +310,8 @@ func run(cmd *cobra.Command, argv []string) {
 			ingressBuilder = ingressBuilder.Listening(cmv1.ListeningMethodExternal)
 		}
 	}
-	if labelMatch != nil {
-		routeSelectors := map[string]string{}
-		if *labelMatch != "" {
-			routeSelectors, err = getRouteSelector(*labelMatch)
-			if err != nil {
-				r.Reporter.Errorf("%s", err)
-				os.Exit(1)
-			}
-		}
-		ingressBuilder = ingressBuilder.RouteSelectors(routeSelectors)
+	if options.RouteSelectors != nil {
+		ingressBuilder = ingressBuilder.RouteSelectors(options.RouteSelectors)
 	}
 
 	ingress, err = ingressBuilder.Build()
@@ -227,17 +320,19 @@ func run(cmd *cobra.Command, argv []string) {
 		os.Exit(1)
 	}
 
-	sameRouteSelectors := labelMatch == nil || reflect.DeepEqual(curRouteSelectors, ingress.RouteSelectors())
+	sameRouteSelectors := options.RouteSelectors == nil ||
+		reflect.DeepEqual(curRouteSelectors, ingress.RouteSelectors())
 	// If private arg is nil no change to listening method will be made anyway
 	sameListeningMethod := private == nil || curListening == ingress.Listening()
+	fields := changedFields(options, current)
 
-	if sameListeningMethod && sameRouteSelectors {
+	if sameListeningMethod && sameRouteSelectors && len(fields) == 0 {
 		r.Reporter.Warnf("No need to update ingress as there are no changes")
 		os.Exit(0)
 	}
 
 	r.Reporter.Debugf("Updating ingress '%s' on cluster '%s'", ingress.ID(), clusterKey)
-	_, err = r.OCMClient.UpdateIngress(cluster.ID(), ingress)
+	_, err = r.OCMClient.UpdateIngressWithFields(cluster.ID(), ingress, fields)
 	if err != nil {
 		r.Reporter.Errorf("Failed to update ingress '%s' on cluster '%s': %s",
 			ingress.ID(), clusterKey, err)
@@ -246,16 +341,110 @@ func run(cmd *cobra.Command, argv []string) {
 	r.Reporter.Infof("Updated ingress '%s' on cluster '%s'", ingress.ID(), clusterKey)
 }
 
-func getRouteSelector(labelMatches string) (map[string]string, error) {
-	routeSelectors := make(map[string]string)
+// getPrivate returns the private setting given in the command line or in interactive mode, or nil
+// if it isn't changed.
+func getPrivate(cmd *cobra.Command, r *rosa.Runtime, current bool) *bool {
+	if cmd.Flags().Changed(privateFlag) {
+		return &args.private
+	}
+	if !interactive.Enabled() {
+		return nil
+	}
+	private, err := interactive.GetBool(interactive.Input{
+		Question: "Private ingress",
+		Help:     cmd.Flags().Lookup(privateFlag).Usage,
+		Default:  current,
+	})
+	if err != nil {
+		r.Reporter.Errorf("Expected a valid private value: %s", err)
+		os.Exit(1)
+	}
+	return &private
+}
+
+// getString returns the value of a string option given in the command line or in interactive mode,
+// or nil if it isn't changed.
+func getString(cmd *cobra.Command, r *rosa.Runtime, flag string, question string, current string,
+	validator interactive.Validator) *string {
+	flags := cmd.Flags()
+	if flags.Changed(flag) {
+		value, _ := flags.GetString(flag)
+		return &value
+	}
+	if !interactive.Enabled() {
+		return nil
+	}
+	value, err := interactive.GetString(interactive.Input{
+		Question:   question,
+		Help:       flags.Lookup(flag).Usage,
+		Default:    current,
+		Validators: []interactive.Validator{validator},
+	})
+	if err != nil {
+		r.Reporter.Errorf("Expected a valid value for '--%s': %s", flag, err)
+		os.Exit(1)
+	}
+	return &value
+}
 
-	for _, labelMatch := range strings.Split(labelMatches, ",") {
-		if !strings.Contains(labelMatch, "=") {
-			return nil, fmt.Errorf("Expected key=value format for label-match")
-		}
-		tokens := strings.Split(labelMatch, "=")
-		routeSelectors[strings.TrimSpace(tokens[0])] = strings.TrimSpace(tokens[1])
+// getOption returns the value of an option with a fixed set of values given in the command line or
+// in interactive mode, or an empty string if it isn't changed.
+func getOption(cmd *cobra.Command, r *rosa.Runtime, flag string, question string, current string,
+	options []string) string {
+	flags := cmd.Flags()
+	if flags.Changed(flag) {
+		value, _ := flags.GetString(flag)
+		return value
 	}
+	if !interactive.Enabled() {
+		return ""
+	}
+	if current == "" {
+		current = options[0]
+	}
+	value, err := interactive.GetOption(interactive.Input{
+		Question: question,
+		Help:     flags.Lookup(flag).Usage,
+		Options:  options,
+		Default:  current,
+		Required: true,
+	})
+	if err != nil {
+		r.Reporter.Errorf("Expected a valid value for '--%s': %s", flag, err)
+		os.Exit(1)
+	}
+	return value
+}
 
-	return routeSelectors, nil
+// changedFields returns the raw fields of the options that are different from the current settings
+// of the ingress.
+func changedFields(options *ingressHelpers.Options, current *ocm.IngressSettings) map[string]interface{} {
+	fields := options.Fields()
+	if options.ExcludedNamespaces != nil &&
+		strings.Join(options.ExcludedNamespaces, ",") == strings.Join(current.ExcludedNamespaces, ",") {
+		delete(fields, "excluded_namespaces")
+	}
+	if options.NamespaceOwnershipPolicy == current.NamespaceOwnershipPolicy {
+		delete(fields, "route_namespace_ownership_policy")
+	}
+	if options.WildcardPolicy == current.WildcardPolicy {
+		delete(fields, "route_wildcard_policy")
+	}
+	if options.LoadBalancerType == current.LoadBalancerType {
+		delete(fields, "load_balancer_type")
+	}
+	if ingressHelpers.FormatComponentRoutes(options.ComponentRoutes) ==
+		ingressHelpers.FormatComponentRoutes(current.ComponentRoutes) {
+		delete(fields, "component_routes")
+	}
+	return fields
+}
+
+func formatRouteSelectors(routeSelectors map[string]string) string {
+	items := []string{}
+	for key, value := range routeSelectors {
+		items = append(items, fmt.Sprintf("%s=%s", key, value))
+	}
+	sort.Strings(items)
+	return strings.Join(items, ",")
 }
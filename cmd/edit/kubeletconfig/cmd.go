@@ -0,0 +1,125 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeletconfig
+
+import (
+	"os"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/interactive"
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/kubeletconfig"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var Cmd = &cobra.Command{
+	Use:     "kubeletconfig",
+	Aliases: []string{"kubelet-config"},
+	Short:   "Edit the custom kubelet config of a cluster",
+	Long: "Edit the custom kubelet config of a classic cluster, or one of the named kubelet configs of a " +
+		"Hosted Control Plane cluster. The nodes that use it are updated progressively and their " +
+		"workloads are restarted.",
+	Example: `  # Change the pod PIDs limit of the nodes of classic cluster 'mycluster'
+  rosa edit kubeletconfig -c mycluster --pod-pids-limit 12000
+
+  # Change the pod PIDs limit of kubelet config 'high-pids' of hosted cluster 'mycluster'
+  rosa edit kubeletconfig -c mycluster --name high-pids --pod-pids-limit 16384`,
+	Run:  run,
+	Args: cobra.NoArgs,
+}
+
+var args struct {
+	name         string
+	podPidsLimit int
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
+	ocm.AddClusterFlag(Cmd)
+	kubeletconfig.AddNameFlag(flags, &args.name)
+	kubeletconfig.AddPodPidsLimitFlag(flags, &args.podPidsLimit)
+	interactive.AddFlag(flags)
+	confirm.AddFlag(flags)
+	output.AddFlag(Cmd)
+}
+
+func run(cmd *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS().WithOCM()
+	defer r.Cleanup()
+
+	clusterKey := r.GetClusterKey()
+
+	cluster := r.FetchCluster()
+	if cluster.State() != cmv1.ClusterStateReady {
+		r.Reporter.Errorf("Cluster '%s' is not yet ready", clusterKey)
+		os.Exit(1)
+	}
+
+	err := kubeletconfig.ValidateName(cluster, args.name)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+	config, err := r.OCMClient.FindKubeletConfig(cluster, args.name)
+	if err != nil {
+		r.Reporter.Errorf("Failed to get kubelet configs for cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+	if config == nil {
+		r.Reporter.Errorf("Kubelet config doesn't exist for cluster '%s', use 'rosa create kubeletconfig' "+
+			"to create it", clusterKey)
+		os.Exit(1)
+	}
+
+	limit := args.podPidsLimit
+	if !cmd.Flags().Changed(kubeletconfig.PodPidsLimitFlag) {
+		limit = config.PodPidsLimit
+	}
+	config.PodPidsLimit, err = kubeletconfig.GetPodPidsLimit(cmd.Flags(), limit)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+
+	if !confirm.Confirm("update the kubelet config of cluster %s, which restarts the workloads of "+
+		"the nodes that use it", clusterKey) {
+		os.Exit(0)
+	}
+
+	r.Reporter.Debugf("Updating kubelet config for cluster '%s'", clusterKey)
+	config, err = r.OCMClient.UpdateKubeletConfig(cluster, config)
+	if err != nil {
+		r.Reporter.Errorf("Failed to update kubelet config for cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+
+	if output.HasFlag() {
+		err = output.Print(config)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+		return
+	}
+	r.Reporter.Infof("Successfully updated kubelet config for cluster '%s'", clusterKey)
+}
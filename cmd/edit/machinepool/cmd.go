@@ -21,19 +21,27 @@ import (
 
 	"github.com/spf13/cobra"
 
+	mpHelpers "github.com/openshift/rosa/pkg/helper/machinepools"
+	"github.com/openshift/rosa/pkg/kubeletconfig"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/rosa"
+	"github.com/openshift/rosa/pkg/tuningconfig"
 )
 
 var args struct {
-	replicas           int
-	autoscalingEnabled bool
-	minReplicas        int
-	maxReplicas        int
-	labels             string
-	taints             string
-	version            string
-	autorepair         bool
+	replicas             int
+	autoscalingEnabled   bool
+	minReplicas          int
+	maxReplicas          int
+	labels               string
+	taints               string
+	version              string
+	autorepair           bool
+	autoupgrade          bool
+	kubeletConfigs       []string
+	tuningConfigs        []string
+	nodeDrainGracePeriod string
+	diskSize             string
 }
 
 var Cmd = &cobra.Command{
@@ -44,7 +52,13 @@ var Cmd = &cobra.Command{
 	Example: `  # Set 4 replicas on machine pool 'mp1' on cluster 'mycluster'
   rosa edit machinepool --replicas=4 --cluster=mycluster mp1
   # Enable autoscaling and Set 3-5 replicas on machine pool 'mp1' on cluster 'mycluster'
-  rosa edit machinepool --enable-autoscaling --min-replicas=3 --max-replicas=5 --cluster=mycluster mp1`,
+  rosa edit machinepool --enable-autoscaling --min-replicas=3 --max-replicas=5 --cluster=mycluster mp1
+  # Make machine pool 'mp1' of hosted cluster 'mycluster' follow the control plane z-stream upgrades
+  rosa edit machinepool --autoupgrade=true --cluster=mycluster mp1
+  # Grow the root disks of the nodes of machine pool 'mp1' of hosted cluster 'mycluster'
+  rosa edit machinepool --disk-size=300GiB --cluster=mycluster mp1
+  # Forcibly evict the workloads of the nodes of machine pool 'mp1' after 2 hours during upgrades
+  rosa edit machinepool --node-drain-grace-period="2 hours" --cluster=mycluster mp1`,
 	Run: run,
 	Args: func(_ *cobra.Command, argv []string) error {
 		if len(argv) != 1 {
@@ -119,6 +133,38 @@ func init() {
 		true,
 		"Select auto-repair behaviour for a machinepool in a hosted cluster.",
 	)
+
+	flags.BoolVar(
+		&args.autoupgrade,
+		"autoupgrade",
+		false,
+		"Select auto-upgrade behaviour for a machinepool in a hosted cluster. When enabled the machine "+
+			"pool automatically follows the z-stream upgrades of the control plane.",
+	)
+
+	flags.StringVar(
+		&args.diskSize,
+		"disk-size",
+		"",
+		fmt.Sprintf("Root disk size of the nodes of a machine pool in a hosted cluster, with a unit, for "+
+			"example '300GiB' or '1TiB'. Accepted units are G, GB, Gi, GiB, T, TB, Ti and TiB, and the size "+
+			"must be between %d GiB and %d GiB. The nodes are replaced to apply the new size. The disk size "+
+			"of the machine pools of classic clusters can only be set when they are created.",
+			mpHelpers.MinRootDiskSize, mpHelpers.MaxRootDiskSize),
+	)
+
+	kubeletconfig.AddKubeletConfigsFlag(flags, &args.kubeletConfigs)
+	tuningconfig.AddTuningConfigsFlag(flags, &args.tuningConfigs)
+
+	flags.StringVar(
+		&args.nodeDrainGracePeriod,
+		"node-drain-grace-period",
+		"",
+		fmt.Sprintf("How long the workloads protected by pod disruption budgets are respected when draining "+
+			"the nodes of a machine pool in a hosted cluster during upgrades, for example '30 minutes' or "+
+			"'2 hours'. After this period the workloads are forcibly evicted. The maximum is %d minutes "+
+			"(1 week) and 0 means that they are never forcibly evicted.", ocm.MaxNodePoolNodeDrainGracePeriod),
+	)
 }
 
 func run(cmd *cobra.Command, argv []string) {
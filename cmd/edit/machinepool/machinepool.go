@@ -7,9 +7,11 @@ import (
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	mpHelpers "github.com/openshift/rosa/pkg/helper/machinepools"
 	"github.com/openshift/rosa/pkg/interactive"
+	"github.com/openshift/rosa/pkg/kubeletconfig"
 	"github.com/openshift/rosa/pkg/ocm"
 	rprtr "github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
+	"github.com/openshift/rosa/pkg/tuningconfig"
 	"github.com/spf13/cobra"
 )
 
@@ -37,6 +39,35 @@ func editMachinePool(cmd *cobra.Command, machinePoolID string, clusterKey string
 		os.Exit(1)
 	}
 
+	if cmd.Flags().Changed("autoupgrade") {
+		r.Reporter.Errorf("Setting the `autoupgrade` flag is only supported for hosted clusters")
+		os.Exit(1)
+	}
+
+	if cmd.Flags().Changed(kubeletconfig.KubeletConfigsFlag) {
+		r.Reporter.Errorf("Setting the `%s` flag is only supported for hosted clusters, the nodes of "+
+			"classic clusters use the kubelet config of the cluster", kubeletconfig.KubeletConfigsFlag)
+		os.Exit(1)
+	}
+
+	if cmd.Flags().Changed(tuningconfig.TuningConfigsFlag) {
+		r.Reporter.Errorf("Setting the `%s` flag is only supported for hosted clusters",
+			tuningconfig.TuningConfigsFlag)
+		os.Exit(1)
+	}
+
+	if cmd.Flags().Changed("disk-size") {
+		r.Reporter.Errorf("The disk size of the machine pools of classic clusters can only be set when they " +
+			"are created. Create a new machine pool with the `disk-size` flag and delete this one instead")
+		os.Exit(1)
+	}
+
+	if cmd.Flags().Changed("node-drain-grace-period") {
+		r.Reporter.Errorf("Setting the `node-drain-grace-period` flag is only supported for hosted clusters, " +
+			"the nodes of classic clusters use the node drain grace period of the cluster")
+		os.Exit(1)
+	}
+
 	isMinReplicasSet := cmd.Flags().Changed("min-replicas")
 	isMaxReplicasSet := cmd.Flags().Changed("max-replicas")
 	isReplicasSet := cmd.Flags().Changed("replicas")
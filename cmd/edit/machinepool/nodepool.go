@@ -1,6 +1,7 @@
 package machinepool
 
 import (
+	"fmt"
 	"os"
 
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
@@ -8,9 +9,11 @@ import (
 	mpHelpers "github.com/openshift/rosa/pkg/helper/machinepools"
 	"github.com/openshift/rosa/pkg/helper/versions"
 	"github.com/openshift/rosa/pkg/interactive"
+	"github.com/openshift/rosa/pkg/kubeletconfig"
 	"github.com/openshift/rosa/pkg/ocm"
 	rprtr "github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
+	"github.com/openshift/rosa/pkg/tuningconfig"
 	"github.com/spf13/cobra"
 )
 
@@ -26,10 +29,16 @@ func editNodePool(cmd *cobra.Command, nodePoolID string, clusterKey string, clus
 	isLabelOrTaintSet := isLabelsSet || isTaintsSet
 	isVersionSet := cmd.Flags().Changed("version")
 	isAutorepairSet := cmd.Flags().Changed("autorepair")
+	isAutoupgradeSet := cmd.Flags().Changed("autoupgrade")
+	isKubeletConfigsSet := cmd.Flags().Changed(kubeletconfig.KubeletConfigsFlag)
+	isTuningConfigsSet := cmd.Flags().Changed(tuningconfig.TuningConfigsFlag)
+	isNodeDrainGracePeriodSet := cmd.Flags().Changed("node-drain-grace-period")
+	isDiskSizeSet := cmd.Flags().Changed("disk-size")
 
 	// if no value set enter interactive mode
 	if !(isMinReplicasSet || isMaxReplicasSet || isReplicasSet || isAutoscalingSet || isLabelsSet || isTaintsSet ||
-		isAutorepairSet) {
+		isAutorepairSet || isAutoupgradeSet || isKubeletConfigsSet ||
+		isTuningConfigsSet || isNodeDrainGracePeriodSet || isDiskSizeSet) {
 		interactive.Enable()
 	}
 
@@ -136,6 +145,147 @@ func editNodePool(cmd *cobra.Command, nodePoolID string, clusterKey string, clus
 		npBuilder.AutoRepair(autorepair)
 	}
 
+	// The current values of the settings that the typed client of the SDK doesn't support yet are
+	// only needed as the defaults of the interactive mode:
+	settings := &ocm.NodePoolSettings{}
+	if interactive.Enabled() {
+		settings, err = r.OCMClient.GetNodePoolSettings(cluster.ID(), nodePoolID)
+		if err != nil {
+			r.Reporter.Errorf("Failed to get settings of machine pool '%s' for hosted cluster '%s': %v",
+				nodePoolID, clusterKey, err)
+			os.Exit(1)
+		}
+	}
+
+	var autoupgrade bool
+	if isAutoupgradeSet || interactive.Enabled() {
+		autoupgrade = args.autoupgrade
+		if !isAutoupgradeSet {
+			autoupgrade, err = r.OCMClient.GetNodePoolAutoUpgrade(cluster.ID(), nodePoolID)
+			if err != nil {
+				r.Reporter.Errorf("Failed to get upgrade policies of machine pool '%s' for hosted cluster '%s': %v",
+					nodePoolID, clusterKey, err)
+				os.Exit(1)
+			}
+		}
+		autoupgrade, err = interactive.GetBool(interactive.Input{
+			Question: "Autoupgrade",
+			Help:     cmd.Flags().Lookup("autoupgrade").Usage,
+			Default:  autoupgrade,
+			Required: false,
+		})
+		if err != nil {
+			r.Reporter.Errorf("Expected a valid value for autoupgrade: %s", err)
+			os.Exit(1)
+		}
+	}
+
+	// Settings that the typed client of the SDK doesn't support yet:
+	rawFields := map[string]interface{}{}
+
+	if isKubeletConfigsSet {
+		configs, err := r.OCMClient.GetKubeletConfigs(cluster)
+		if err != nil {
+			r.Reporter.Errorf("Failed to get kubelet configs for hosted cluster '%s': %v", clusterKey, err)
+			os.Exit(1)
+		}
+		err = ocm.ValidateKubeletConfigNames(configs, args.kubeletConfigs)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+		kubeletConfigs := args.kubeletConfigs
+		if kubeletConfigs == nil {
+			kubeletConfigs = []string{}
+		}
+		rawFields["kubelet_configs"] = kubeletConfigs
+	}
+
+	if isTuningConfigsSet {
+		configs, err := r.OCMClient.GetTuningConfigs(cluster.ID())
+		if err != nil {
+			r.Reporter.Errorf("Failed to get tuning configs for hosted cluster '%s': %v", clusterKey, err)
+			os.Exit(1)
+		}
+		err = ocm.ValidateTuningConfigNames(configs, args.tuningConfigs)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+		tuningConfigs := args.tuningConfigs
+		if tuningConfigs == nil {
+			tuningConfigs = []string{}
+		}
+		rawFields["tuning_configs"] = tuningConfigs
+	}
+
+	if isDiskSizeSet || interactive.Enabled() {
+		diskSize := args.diskSize
+		if !isDiskSizeSet && settings.DiskSize != 0 {
+			diskSize = fmt.Sprintf("%dGiB", settings.DiskSize)
+		}
+		if interactive.Enabled() {
+			diskSize, err = interactive.GetString(interactive.Input{
+				Question: "Root disk size",
+				Help:     cmd.Flags().Lookup("disk-size").Usage,
+				Default:  diskSize,
+				Validators: []interactive.Validator{
+					mpHelpers.MachinePoolRootDiskSizeValidator,
+				},
+			})
+			if err != nil {
+				r.Reporter.Errorf("Expected a valid root disk size: %s", err)
+				os.Exit(1)
+			}
+		}
+		size, err := mpHelpers.ParseDiskSizeToGigibyte(diskSize)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+		if size != 0 && (isDiskSizeSet || size != settings.DiskSize) {
+			err = mpHelpers.ValidateRootDiskSize(size)
+			if err != nil {
+				r.Reporter.Errorf("%s", err)
+				os.Exit(1)
+			}
+			rawFields["aws_node_pool.root_volume.size"] = size
+		} else if isDiskSizeSet {
+			r.Reporter.Errorf("Expected a valid root disk size, for example '300GiB'")
+			os.Exit(1)
+		}
+	}
+
+	if isNodeDrainGracePeriodSet || interactive.Enabled() {
+		nodeDrainGracePeriod := args.nodeDrainGracePeriod
+		if !isNodeDrainGracePeriodSet && settings.NodeDrainGracePeriod != nil {
+			nodeDrainGracePeriod = ocm.FormatNodePoolNodeDrainGracePeriod(*settings.NodeDrainGracePeriod)
+		}
+		if interactive.Enabled() {
+			nodeDrainGracePeriod, err = interactive.GetString(interactive.Input{
+				Question: "Node drain grace period",
+				Help:     cmd.Flags().Lookup("node-drain-grace-period").Usage,
+				Default:  nodeDrainGracePeriod,
+				Validators: []interactive.Validator{
+					ocm.NodePoolNodeDrainGracePeriodValidator,
+				},
+			})
+			if err != nil {
+				r.Reporter.Errorf("Expected a valid node drain grace period: %s", err)
+				os.Exit(1)
+			}
+		}
+		if nodeDrainGracePeriod != "" {
+			minutes, err := ocm.ParseNodePoolNodeDrainGracePeriod(nodeDrainGracePeriod)
+			if err != nil {
+				r.Reporter.Errorf("%s", err)
+				os.Exit(1)
+			}
+			rawFields["node_drain_grace_period.value"] = minutes
+			rawFields["node_drain_grace_period.unit"] = "minutes"
+		}
+	}
+
 	nodePool, err = npBuilder.Build()
 	if err != nil {
 		r.Reporter.Errorf("Failed to create machine pool for hosted cluster '%s': %v", clusterKey, err)
@@ -143,12 +293,20 @@ func editNodePool(cmd *cobra.Command, nodePoolID string, clusterKey string, clus
 	}
 
 	r.Reporter.Debugf("Updating machine pool '%s' on hosted cluster '%s'", nodePool.ID(), clusterKey)
-	_, err = r.OCMClient.UpdateNodePool(cluster.ID(), nodePool)
+	_, err = r.OCMClient.UpdateNodePoolWithFields(cluster.ID(), nodePool, rawFields)
 	if err != nil {
 		r.Reporter.Errorf("Failed to update machine pool '%s' on hosted cluster '%s': %s",
 			nodePool.ID(), clusterKey, err)
 		os.Exit(1)
 	}
+	if isAutoupgradeSet || interactive.Enabled() {
+		err = r.OCMClient.UpdateNodePoolAutoUpgrade(cluster.ID(), nodePool.ID(), autoupgrade)
+		if err != nil {
+			r.Reporter.Errorf("Failed to update autoupgrade of machine pool '%s' on hosted cluster '%s': %s",
+				nodePool.ID(), clusterKey, err)
+			os.Exit(1)
+		}
+	}
 	r.Reporter.Infof("Updated machine pool '%s' on hosted cluster '%s'", nodePool.ID(), clusterKey)
 }
 
@@ -0,0 +1,108 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tuningconfig
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/interactive"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+	"github.com/openshift/rosa/pkg/tuningconfig"
+)
+
+var Cmd = &cobra.Command{
+	Use:     "tuning-config",
+	Aliases: []string{"tuningconfig"},
+	Short:   "Edit a tuning config of a cluster",
+	Long: "Replace the TuneD spec of a tuning config of a Hosted Control Plane cluster. The nodes of " +
+		"the machine pools that use it are updated.",
+	Example: `  # Replace the spec of tuning config 'hugepages' of cluster 'mycluster'
+  rosa edit tuning-config -c mycluster --name hugepages --spec-path tuned.json`,
+	Run:  run,
+	Args: cobra.NoArgs,
+}
+
+var args struct {
+	name     string
+	specPath string
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
+	ocm.AddClusterFlag(Cmd)
+	tuningconfig.AddNameFlag(flags, &args.name)
+	tuningconfig.AddSpecPathFlag(flags, &args.specPath)
+	interactive.AddFlag(flags)
+	output.AddFlag(Cmd)
+}
+
+func run(cmd *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS().WithOCM()
+	defer r.Cleanup()
+
+	clusterKey := r.GetClusterKey()
+
+	if args.name == "" {
+		r.Reporter.Errorf("The '--%s' option is required", tuningconfig.NameFlag)
+		os.Exit(1)
+	}
+
+	cluster := r.FetchCluster()
+	err := tuningconfig.ValidateCluster(cluster)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+	config, err := r.OCMClient.FindTuningConfig(cluster.ID(), args.name)
+	if err != nil {
+		r.Reporter.Errorf("Failed to get tuning configs for cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+	if config == nil {
+		r.Reporter.Errorf("Tuning config '%s' doesn't exist for cluster '%s'", args.name, clusterKey)
+		os.Exit(1)
+	}
+
+	config.Spec, err = tuningconfig.GetSpec(cmd.Flags(), args.specPath)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+
+	r.Reporter.Debugf("Updating tuning config '%s' for cluster '%s'", config.Name, clusterKey)
+	config, err = r.OCMClient.UpdateTuningConfig(cluster.ID(), config)
+	if err != nil {
+		r.Reporter.Errorf("Failed to update tuning config '%s' for cluster '%s': %v", args.name, clusterKey, err)
+		os.Exit(1)
+	}
+
+	if output.HasFlag() {
+		err = output.Print(config)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+		return
+	}
+	r.Reporter.Infof("Successfully updated tuning config '%s' for cluster '%s'", args.name, clusterKey)
+}
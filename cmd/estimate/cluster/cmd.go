@@ -0,0 +1,233 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/helper/clusterspec"
+	"github.com/openshift/rosa/pkg/helper/estimate"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "cluster",
+	Short: "Estimate the monthly cost of a cluster",
+	Long: "Estimate the monthly cost of a cluster from the on-demand prices of the AWS Pricing API and " +
+		"the ROSA service fees. It accepts the same sizing options and spec file as 'rosa create cluster'.",
+	Example: `  # Estimate the cost of a multi-AZ cluster with 6 m5.2xlarge compute nodes
+  rosa estimate cluster --region us-east-1 --multi-az --compute-machine-type m5.2xlarge --replicas 6
+
+  # Estimate the cost of the cluster described in a spec file
+  rosa estimate cluster --spec-file cluster.yaml`,
+	Run: run,
+}
+
+var args struct {
+	specFile           string
+	multiAZ            bool
+	hostedCP           bool
+	computeMachineType string
+	computeNodes       int
+	autoscalingEnabled bool
+	minReplicas        int
+	maxReplicas        int
+	subnetIDs          []string
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
+	clusterspec.AddFlag(flags, &args.specFile)
+	flags.BoolVar(
+		&args.multiAZ,
+		"multi-az",
+		false,
+		"Deploy to multiple data centers.",
+	)
+	flags.BoolVar(
+		&args.hostedCP,
+		"hosted-cp",
+		false,
+		"Estimate a cluster with a hosted control plane.",
+	)
+	flags.StringVar(
+		&args.computeMachineType,
+		"compute-machine-type",
+		"m5.xlarge",
+		"Instance type for the compute nodes.",
+	)
+	flags.IntVar(
+		&args.computeNodes,
+		"replicas",
+		2,
+		"Number of worker nodes. For multi-AZ clusters the default is 3.",
+	)
+	flags.BoolVar(
+		&args.autoscalingEnabled,
+		"enable-autoscaling",
+		false,
+		"Enable autoscaling of compute nodes.",
+	)
+	flags.IntVar(
+		&args.minReplicas,
+		"min-replicas",
+		2,
+		"Minimum number of compute nodes.",
+	)
+	flags.IntVar(
+		&args.maxReplicas,
+		"max-replicas",
+		2,
+		"Maximum number of compute nodes.",
+	)
+	flags.StringSliceVar(
+		&args.subnetIDs,
+		"subnet-ids",
+		nil,
+		"The subnet IDs of an existing VPC, in which case no NAT gateways are created by the installer.",
+	)
+	output.AddFlag(Cmd)
+}
+
+func run(cmd *cobra.Command, _ []string) {
+	r := rosa.NewRuntime()
+
+	// The spec file needs to be applied before anything else, as it can also set the AWS region:
+	if args.specFile != "" {
+		spec, err := clusterspec.Load(args.specFile)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+		err = spec.Apply(cmd.Flags())
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+	}
+
+	r = r.WithAWS().WithOCM()
+	defer r.Cleanup()
+
+	computeNodes := args.computeNodes
+	if !cmd.Flags().Changed("replicas") && args.multiAZ && !args.hostedCP {
+		computeNodes = 3
+	}
+	if args.autoscalingEnabled {
+		if args.minReplicas < 1 || args.maxReplicas < args.minReplicas {
+			r.Reporter.Errorf("Expected max replicas to be greater than or equal to min replicas, " +
+				"which must be at least 1")
+			os.Exit(1)
+		}
+		computeNodes = args.minReplicas
+	}
+	if computeNodes < 1 {
+		r.Reporter.Errorf("Expected a positive number of compute nodes")
+		os.Exit(1)
+	}
+
+	machineTypes, err := r.OCMClient.GetMachineTypes()
+	if err != nil {
+		r.Reporter.Errorf("Failed to fetch instance types: %v", err)
+		os.Exit(1)
+	}
+	machineType := machineTypes.Find(args.computeMachineType)
+	if machineType == nil {
+		r.Reporter.Errorf("Expected a valid instance type, '%s' isn't supported", args.computeMachineType)
+		os.Exit(1)
+	}
+
+	r.Reporter.Debugf("Fetching prices for region '%s'", r.AWSClient.GetRegion())
+	var prices estimate.Prices
+	prices.Instances, err = r.AWSClient.GetOnDemandPrices()
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+	prices.VolumePerGBMonth, err = r.AWSClient.GetVolumePrice("gp3")
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+	prices.NATGatewayPerHour, err = r.AWSClient.GetNATGatewayPrice()
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+
+	cluster := estimate.Cluster{
+		MultiAZ:            args.multiAZ,
+		HostedCP:           args.hostedCP,
+		BYOVPC:             len(args.subnetIDs) > 0,
+		ComputeMachineType: args.computeMachineType,
+		ComputeVCPUs:       machineType.CPUs(),
+		ComputeNodes:       computeNodes,
+	}
+	result, err := estimate.Compute(cluster, prices)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+
+	// With autoscaling the cost varies between the minimum and the maximum number of nodes:
+	var maximum *estimate.Estimate
+	if args.autoscalingEnabled && args.maxReplicas > args.minReplicas {
+		cluster.ComputeNodes = args.maxReplicas
+		maximum, err = estimate.Compute(cluster, prices)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+	}
+
+	if output.HasFlag() {
+		summary := &estimate.Summary{Estimate: result}
+		if maximum != nil {
+			summary.MaxReplicas = args.maxReplicas
+			summary.Maximum = maximum
+		}
+		err = output.Print(summary)
+
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "ITEM\tQUANTITY\tMONTHLY_COST\n")
+	for _, item := range result.Items {
+		fmt.Fprintf(writer, "%s\t%d\t$%.2f\n", item.Name, item.Quantity, item.Monthly)
+	}
+	fmt.Fprintf(writer, "%s\t\t$%.2f\n", "Total", result.Total)
+	writer.Flush()
+
+	if maximum != nil {
+		r.Reporter.Infof("With autoscaling up to %d compute nodes the monthly cost can reach $%.2f",
+			args.maxReplicas, maximum.Total)
+	}
+	r.Reporter.Infof("Estimated on-demand cost in USD for region '%s'. It doesn't include data transfer, "+
+		"load balancers, taxes or discounts.", r.AWSClient.GetRegion())
+}
@@ -41,7 +41,10 @@ var Cmd = &cobra.Command{
   rosa grant user cluster-admin --user=myusername --cluster=mycluster
 
   # Grant dedicated-admins role to a user
-  rosa grant user dedicated-admin --user=myusername --cluster=mycluster`,
+  rosa grant user dedicated-admin --user=myusername --cluster=mycluster
+
+  # Grant read-only access to a user, when supported by the cluster
+  rosa grant user dedicated-readers --user=myusername --cluster=mycluster`,
 	Run: run,
 	Args: func(_ *cobra.Command, argv []string) error {
 		if len(argv) != 1 {
@@ -54,9 +57,6 @@ var Cmd = &cobra.Command{
 	},
 }
 
-var validRoles = []string{"cluster-admins", "dedicated-admins"}
-var validRolesAliases = []string{"cluster-admin", "dedicated-admin"}
-
 func init() {
 	flags := Cmd.Flags()
 
@@ -91,31 +91,19 @@ func run(_ *cobra.Command, argv []string) {
 		os.Exit(1)
 	}
 
-	role := argv[0]
-	// Allow role aliases
-	for _, validAlias := range validRolesAliases {
-		if role == validAlias {
-			role = fmt.Sprintf("%ss", role)
-		}
-	}
-	isRoleValid := false
-	// Determine if role is valid
-	for _, validRole := range validRoles {
-		if role == validRole {
-			isRoleValid = true
-		}
-	}
-	if !isRoleValid {
-		r.Reporter.Errorf("Expected at least one of %s", validRoles)
-		os.Exit(1)
-	}
-
 	cluster := r.FetchCluster()
 	if cluster.State() != cmv1.ClusterStateReady {
 		r.Reporter.Errorf("Cluster '%s' is not yet ready", clusterKey)
 		os.Exit(1)
 	}
 
+	r.Reporter.Debugf("Loading groups of cluster '%s'", clusterKey)
+	role, err := r.OCMClient.GetGroupID(cluster.ID(), argv[0])
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+
 	user, err := cmv1.NewUser().ID(username).Build()
 	if err != nil {
 		r.Reporter.Errorf("Failed to create user '%s' for cluster '%s'", username, clusterKey)
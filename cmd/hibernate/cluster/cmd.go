@@ -18,6 +18,7 @@ package cluster
 
 import (
 	"os"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -32,12 +33,30 @@ var Cmd = &cobra.Command{
 	Short: "Hibernate cluster",
 	Long:  "Hibernate cluster.",
 	Example: `  # Hibernate the cluster
-  rosa hibernate cluster -c mycluster`,
+  rosa hibernate cluster -c mycluster
+
+  # Hibernate the cluster and wait until it is hibernating
+  rosa hibernate cluster -c mycluster --wait`,
 	Run: run,
 }
 
+var args struct {
+	wait bool
+}
+
+const (
+	waitInterval = 30 * time.Second
+	waitTimeout  = 60 * time.Minute
+)
+
 func init() {
 	ocm.AddClusterFlag(Cmd)
+	Cmd.Flags().BoolVar(
+		&args.wait,
+		"wait",
+		false,
+		"Wait until the cluster is hibernating.",
+	)
 }
 
 func run(cmd *cobra.Command, _ []string) {
@@ -48,10 +67,14 @@ func run(cmd *cobra.Command, _ []string) {
 
 	cluster := r.FetchCluster()
 
-	if cluster.State() != cmv1.ClusterStateReady {
-		r.Reporter.Errorf("Hibernating a cluster is only supported for 'Ready' clusters."+
-			" Cluster '%s' is in '%s' state",
-			clusterKey, cluster.State())
+	err := ocm.ValidateHibernation(cluster, cmv1.ClusterStateReady)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+	err = r.OCMClient.ValidateHibernationEntitlement()
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
 		os.Exit(1)
 	}
 
@@ -59,10 +82,21 @@ func run(cmd *cobra.Command, _ []string) {
 		os.Exit(1)
 	}
 
-	err := r.OCMClient.HibernateCluster(cluster.ID())
+	err = r.OCMClient.HibernateCluster(cluster.ID())
 	if err != nil {
 		r.Reporter.Errorf("Failed to update cluster: %v", err)
 		os.Exit(1)
 	}
-	r.Reporter.Infof("Cluster '%s' is hibernating.", clusterKey)
+
+	if !args.wait {
+		r.Reporter.Infof("Cluster '%s' is hibernating.", clusterKey)
+		return
+	}
+	r.Reporter.Infof("Waiting for cluster '%s' to hibernate", clusterKey)
+	err = r.OCMClient.WaitForClusterState(cluster.ID(), cmv1.ClusterStateHibernating, waitInterval, waitTimeout)
+	if err != nil {
+		r.Reporter.Errorf("Failed to wait for cluster '%s' to hibernate: %v", clusterKey, err)
+		os.Exit(1)
+	}
+	r.Reporter.Infof("Cluster '%s' is hibernated.", clusterKey)
 }
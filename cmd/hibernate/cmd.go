@@ -24,10 +24,9 @@ import (
 )
 
 var Cmd = &cobra.Command{
-	Use:    "hibernate",
-	Short:  "Hibernate cluster",
-	Long:   "Hibernate Ready cluster",
-	Hidden: true,
+	Use:   "hibernate",
+	Short: "Hibernate cluster",
+	Long:  "Hibernate Ready cluster",
 }
 
 func init() {
@@ -83,11 +83,9 @@ func init() {
 		"Indicates if cloud permission checks are disabled when attempting installation of the cluster.",
 	)
 
-	// Force-load all flags from `login` into `init`
+	// Force-load all flags from `login` into `init`, this includes the '--profile' flag:
 	flags.AddFlagSet(login.Cmd.Flags())
 
-	arguments.AddProfileFlag(flags)
-
 	confirm.AddFlag(flags)
 }
 
@@ -33,17 +33,13 @@ import (
 	"github.com/openshift/rosa/pkg/aws"
 	"github.com/openshift/rosa/pkg/aws/tags"
 	"github.com/openshift/rosa/pkg/helper"
+	"github.com/openshift/rosa/pkg/helper/addons"
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/interactive/confirm"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
-const (
-	billingModelFlag          = "billing-model"
-	billingModelAccountIDFlag = "billing-model-account-id"
-)
-
 var args struct {
 	billingModel          string
 	billingModelAccountID string
@@ -76,14 +72,14 @@ func init() {
 
 	flags.StringVar(
 		&args.billingModel,
-		billingModelFlag,
+		addons.BillingModelFlag,
 		string(amv1.BillingModelStandard),
 		"Set the billing model to be used for the addon installation resource",
 	)
 
 	flags.StringVar(
 		&args.billingModelAccountID,
-		billingModelAccountIDFlag,
+		addons.BillingModelAccountIDFlag,
 		"",
 		"Account ID of associated billing model for the addon installation resource",
 	)
@@ -204,12 +200,14 @@ func run(cmd *cobra.Command, argv []string) {
 				values = append(values, opt.Value())
 			}
 
-			// If value is already set in the CLI, ignore interactive prompt
+			// If value is already set in the CLI, ignore interactive prompt, otherwise use the
+			// default value of the add-on
+			val = param.DefaultValue()
 			flag := cmd.Flags().Lookup(param.ID())
 			if flag != nil {
 				val = flag.Value.String()
 			}
-			if interactive.Enabled() {
+			if interactive.Enabled() && flag == nil {
 				val, err = interactive.GetAddonArgument(*param, param.DefaultValue())
 				if err != nil {
 					r.Reporter.Errorf("%s", err)
@@ -235,42 +233,17 @@ func run(cmd *cobra.Command, argv []string) {
 		})
 	}
 
-	billingModel := args.billingModel
-	billingModelAccountID := args.billingModelAccountID
-
-	if !cmd.Flags().Changed(billingModelFlag) {
-		if !interactive.Enabled() {
-			interactive.Enable()
-			r.Reporter.Infof("Enabling interactive mode")
-		}
-		billingModel, err = interactive.GetOption(interactive.Input{
-			Question: "Billing Model",
-			Help:     cmd.Flags().Lookup(billingModelFlag).Usage,
-			Default:  string(amv1.BillingModelStandard),
-			Options:  ocm.BillingOptions,
-			Required: true,
-		})
-		if err != nil {
-			r.Reporter.Errorf("Expected a valid billing model: %s", err)
-			os.Exit(1)
-		}
-	}
-
-	if billingModel != string(amv1.BillingModelStandard) && !cmd.Flags().Changed(billingModelAccountIDFlag) {
-		billingModelAccountID, err = interactive.GetString(interactive.Input{
-			Question: "Billing Account ID",
-			Help:     cmd.Flags().Lookup(billingModelAccountIDFlag).Usage,
-			Required: true,
-		})
-		if err != nil {
-			r.Reporter.Errorf("Expected a valid account id: %s", err)
-			os.Exit(1)
-		}
+	if !cmd.Flags().Changed(addons.BillingModelFlag) && !interactive.Enabled() {
+		interactive.Enable()
+		r.Reporter.Infof("Enabling interactive mode")
 	}
-
-	billing := ocm.AddOnBilling{
-		BillingModel:     billingModel,
-		BillingAccountID: billingModelAccountID,
+	billing, err := addons.GetBilling(r, cmd, addOn, ocm.AddOnBilling{
+		BillingModel:     args.billingModel,
+		BillingAccountID: args.billingModelAccountID,
+	}, "")
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
 	}
 
 	r.Reporter.Debugf("Installing add-on '%s' on cluster '%s'", addOnID, clusterKey)
@@ -348,9 +321,9 @@ func buildCommand(
 		}
 	}
 
-	command += fmt.Sprintf(" --%s %s", billingModelFlag, billing.BillingModel)
+	command += fmt.Sprintf(" --%s %s", addons.BillingModelFlag, billing.BillingModel)
 	if billing.BillingAccountID != "" {
-		command += fmt.Sprintf(" --%s %s", billingModelAccountIDFlag, billing.BillingAccountID)
+		command += fmt.Sprintf(" --%s %s", addons.BillingModelAccountIDFlag, billing.BillingAccountID)
 	}
 
 	return command
@@ -114,6 +114,19 @@ func run(cmd *cobra.Command, argv []string) (err error) {
 			os.Exit(1)
 		}
 	}
+	// The role can only be used by the organization that it trusts, so check it upfront when the AWS
+	// credentials allow reading the role:
+	awsClient, err := aws.NewClient().Logger(r.Logger).Build()
+	if err == nil {
+		ownerErr, err := r.OCMClient.CheckOCMRoleOwner(awsClient, roleArn, orgAccount)
+		if err != nil {
+			r.Reporter.Debugf("Failed to check the organization of role '%s': %v", roleArn, err)
+		}
+		if ownerErr != nil {
+			r.Reporter.Errorf("%s", ownerErr)
+			return ownerErr
+		}
+	}
 	if !confirm.Prompt(true, "Link the '%s' role with organization '%s'?", roleArn, orgAccount) {
 		os.Exit(0)
 	}
@@ -115,6 +115,19 @@ func run(cmd *cobra.Command, argv []string) (err error) {
 		}
 	}
 
+	// The role can only be used by the account that it trusts, so check it upfront when the AWS
+	// credentials allow reading the role:
+	awsClient, err := aws.NewClient().Logger(r.Logger).Build()
+	if err == nil {
+		ownerErr, err := r.OCMClient.CheckUserRoleOwner(awsClient, roleArn, accountID)
+		if err != nil {
+			r.Reporter.Debugf("Failed to check the account of role '%s': %v", roleArn, err)
+		}
+		if ownerErr != nil {
+			r.Reporter.Errorf("%s", ownerErr)
+			return ownerErr
+		}
+	}
 	if !confirm.Prompt(true, "Link the '%s' role with account '%s'?", roleArn, accountID) {
 		os.Exit(0)
 	}
@@ -0,0 +1,83 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package billingaccount
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var Cmd = &cobra.Command{
+	Use:     "billing-accounts",
+	Aliases: []string{"billingaccounts", "billing-account", "billingaccount"},
+	Short:   "List billing accounts",
+	Long: "List the AWS accounts linked to the organization that can be billed for the usage of " +
+		"Hosted Control Plane clusters, and the status of their ROSA with HCP contracts.",
+	Example: `  # List all billing accounts of the organization
+  rosa list billing-accounts`,
+	Run:  run,
+	Args: cobra.NoArgs,
+}
+
+func init() {
+	output.AddFlag(Cmd)
+}
+
+func run(_ *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithOCM()
+	defer r.Cleanup()
+
+	r.Reporter.Debugf("Loading billing accounts")
+	accounts, err := r.OCMClient.GetBillingAccounts()
+	if err != nil {
+		r.Reporter.Errorf("Failed to list billing accounts: %v", err)
+		os.Exit(1)
+	}
+
+	if output.HasFlag() {
+		err = output.Print(accounts)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if len(accounts) == 0 {
+		r.Reporter.Infof("There are no billing accounts linked to your organization. Enable ROSA with " +
+			"HCP in the AWS console to link an AWS account")
+		return
+	}
+
+	now := time.Now()
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "ACCOUNT ID\tCONTRACT\n")
+	for _, account := range accounts {
+		fmt.Fprintf(writer, "%s\t%s\n",
+			account.CloudAccountID,
+			account.ContractStatus(now),
+		)
+	}
+	writer.Flush()
+}
@@ -0,0 +1,103 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package breakglasscredential
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var Cmd = &cobra.Command{
+	Use:     "break-glass-credentials",
+	Aliases: []string{"breakglasscredentials", "break-glass-credential", "breakglasscredential"},
+	Short:   "List break glass credentials",
+	Long:    "List the break glass credentials of a Hosted Control Plane cluster that uses external authentication.",
+	Example: `  # List the break glass credentials of cluster 'mycluster'
+  rosa list break-glass-credentials -c mycluster`,
+	Run:  run,
+	Args: cobra.NoArgs,
+}
+
+func init() {
+	ocm.AddClusterFlag(Cmd)
+	output.AddFlag(Cmd)
+}
+
+func run(_ *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS().WithOCM()
+	defer r.Cleanup()
+
+	clusterKey := r.GetClusterKey()
+
+	cluster := r.FetchCluster()
+	err := r.OCMClient.ValidateExternalAuthCluster(cluster)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+
+	r.Reporter.Debugf("Loading break glass credentials for cluster '%s'", clusterKey)
+	credentials, err := r.OCMClient.GetBreakGlassCredentials(cluster.ID())
+	if err != nil {
+		r.Reporter.Errorf("Failed to get break glass credentials for cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+	for _, credential := range credentials {
+		credential.Kubeconfig = ""
+	}
+
+	if output.HasFlag() {
+		err = output.Print(credentials)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if len(credentials) == 0 {
+		r.Reporter.Infof("There are no break glass credentials for cluster '%s'", clusterKey)
+		return
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "ID\tUSERNAME\tEXPIRES\tSTATUS\n")
+	for _, credential := range credentials {
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n",
+			credential.ID,
+			credential.Username,
+			formatTime(credential.ExpirationTimestamp),
+			credential.Status,
+		)
+	}
+	writer.Flush()
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
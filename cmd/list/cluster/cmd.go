@@ -23,6 +23,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/output"
 	"github.com/openshift/rosa/pkg/rosa"
 )
@@ -33,24 +34,97 @@ var Cmd = &cobra.Command{
 	Short:   "List clusters",
 	Long:    "List clusters.",
 	Example: `  # List all clusters
-  rosa list clusters`,
+  rosa list clusters
+
+  # List the ready clusters in a region
+  rosa list clusters --state ready --cluster-region us-east-1
+
+  # List the clusters whose name starts with 'prod-', 20 at a time
+  rosa list clusters --search "name like 'prod-%'" --limit 20 --page 2`,
 	Args: cobra.NoArgs,
 	Run:  run,
 }
 
+var args struct {
+	state         string
+	clusterRegion string
+	product       string
+	search        string
+	limit         int
+	page          int
+}
+
 func init() {
 	flags := Cmd.Flags()
 	flags.SortFlags = false
 
+	flags.StringVar(
+		&args.state,
+		"state",
+		"",
+		"List only the clusters in the given state, for example 'ready' or 'installing'.",
+	)
+	flags.StringVar(
+		&args.clusterRegion,
+		"cluster-region",
+		"",
+		"List only the clusters in the given AWS region. The global '--region' flag only selects the "+
+			"region of the AWS client.",
+	)
+	flags.StringVar(
+		&args.product,
+		"product",
+		"rosa",
+		"List only the clusters of the given product.",
+	)
+	flags.StringVar(
+		&args.search,
+		"search",
+		"",
+		"Additional search query to filter the clusters, for example \"name like 'prod-%'\".",
+	)
+	flags.IntVar(
+		&args.limit,
+		"limit",
+		0,
+		"Maximum number of clusters to list. By default all the matching clusters are listed.",
+	)
+	flags.IntVar(
+		&args.page,
+		"page",
+		1,
+		"Page of results to list when using --limit.",
+	)
+
 	output.AddFlag(Cmd)
 }
 
-func run(_ *cobra.Command, _ []string) {
+func run(cmd *cobra.Command, _ []string) {
 	r := rosa.NewRuntime().WithAWS().WithOCM()
 	defer r.Cleanup()
 
+	if args.limit < 0 {
+		r.Reporter.Errorf("Expected a positive value for --limit")
+		os.Exit(1)
+	}
+	if args.page < 1 {
+		r.Reporter.Errorf("Expected a value greater than zero for --page")
+		os.Exit(1)
+	}
+	if cmd.Flags().Changed("page") && args.limit == 0 {
+		r.Reporter.Errorf("Option --page requires --limit")
+		os.Exit(1)
+	}
+
 	// Retrieve the list of clusters:
-	clusters, err := r.OCMClient.GetClusters(r.Creator, 1000)
+	clusters, total, err := r.OCMClient.ListClusters(r.Creator, ocm.ClusterListOptions{
+		State:   args.state,
+		Region:  args.clusterRegion,
+		Product: args.product,
+		Search:  args.search,
+		Page:    args.page,
+		Size:    args.limit,
+	})
 	if err != nil {
 		r.Reporter.Errorf("Failed to get clusters: %v", err)
 		os.Exit(1)
@@ -91,4 +165,10 @@ func run(_ *cobra.Command, _ []string) {
 		)
 	}
 	writer.Flush()
+
+	if args.limit > 0 && total > args.page*args.limit {
+		first := (args.page-1)*args.limit + 1
+		r.Reporter.Infof("Showing clusters %d-%d of %d, use '--page %d' to list more",
+			first, first+len(clusters)-1, total, args.page+1)
+	}
 }
@@ -21,7 +21,12 @@ import (
 
 	"github.com/openshift/rosa/cmd/list/accountroles"
 	"github.com/openshift/rosa/cmd/list/addon"
+	"github.com/openshift/rosa/cmd/list/billingaccount"
+	"github.com/openshift/rosa/cmd/list/breakglasscredential"
 	"github.com/openshift/rosa/cmd/list/cluster"
+	"github.com/openshift/rosa/cmd/list/dnsdomain"
+	"github.com/openshift/rosa/cmd/list/egressendpoints"
+	"github.com/openshift/rosa/cmd/list/externalauthprovider"
 	"github.com/openshift/rosa/cmd/list/gates"
 	"github.com/openshift/rosa/cmd/list/idp"
 	"github.com/openshift/rosa/cmd/list/ingress"
@@ -29,9 +34,11 @@ import (
 	"github.com/openshift/rosa/cmd/list/machinepool"
 	"github.com/openshift/rosa/cmd/list/ocmroles"
 	"github.com/openshift/rosa/cmd/list/oidcconfig"
+	"github.com/openshift/rosa/cmd/list/oidcprovider"
 	"github.com/openshift/rosa/cmd/list/operatorroles"
 	"github.com/openshift/rosa/cmd/list/region"
 	"github.com/openshift/rosa/cmd/list/service"
+	"github.com/openshift/rosa/cmd/list/tuningconfig"
 	"github.com/openshift/rosa/cmd/list/upgrade"
 	"github.com/openshift/rosa/cmd/list/user"
 	"github.com/openshift/rosa/cmd/list/userroles"
@@ -47,7 +54,10 @@ var Cmd = &cobra.Command{
 
 func init() {
 	Cmd.AddCommand(addon.Cmd)
+	Cmd.AddCommand(breakglasscredential.Cmd)
 	Cmd.AddCommand(cluster.Cmd)
+	Cmd.AddCommand(dnsdomain.Cmd)
+	Cmd.AddCommand(externalauthprovider.Cmd)
 	Cmd.AddCommand(gates.Cmd)
 	Cmd.AddCommand(idp.Cmd)
 	Cmd.AddCommand(ingress.Cmd)
@@ -63,13 +73,17 @@ func init() {
 	Cmd.AddCommand(userroles.Cmd)
 	Cmd.AddCommand(service.Cmd)
 	Cmd.AddCommand(oidcconfig.Cmd)
+	Cmd.AddCommand(oidcprovider.Cmd)
+	Cmd.AddCommand(billingaccount.Cmd)
+	Cmd.AddCommand(egressendpoints.Cmd)
+	Cmd.AddCommand(tuningconfig.Cmd)
 	flags := Cmd.PersistentFlags()
 	arguments.AddProfileFlag(flags)
 	arguments.AddRegionFlag(flags)
 
 	globallyAvailableCommands := []*cobra.Command{
 		accountroles.Cmd, userroles.Cmd,
-		ocmroles.Cmd, oidcconfig.Cmd,
+		ocmroles.Cmd, oidcconfig.Cmd, oidcprovider.Cmd, dnsdomain.Cmd, billingaccount.Cmd,
 	}
 	arguments.MarkRegionHidden(Cmd, globallyAvailableCommands)
 }
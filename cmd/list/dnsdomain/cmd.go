@@ -0,0 +1,81 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dnsdomain
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var Cmd = &cobra.Command{
+	Use:     "dns-domains",
+	Aliases: []string{"dnsdomain", "dns-domain", "dnsdomains"},
+	Short:   "List DNS domains",
+	Long:    "List the base DNS domains reserved by the organization, and the clusters that use them.",
+	Example: `  # List all DNS domains reserved by the organization
+  rosa list dns-domains`,
+	Run:  run,
+	Args: cobra.NoArgs,
+}
+
+func init() {
+	output.AddFlag(Cmd)
+}
+
+func run(_ *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithOCM()
+	defer r.Cleanup()
+
+	r.Reporter.Debugf("Loading DNS domains")
+	domains, err := r.OCMClient.ListDNSDomains()
+	if err != nil {
+		r.Reporter.Errorf("Failed to list DNS domains: %v", err)
+		os.Exit(1)
+	}
+
+	if output.HasFlag() {
+		err = output.Print(domains)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if len(domains) == 0 {
+		r.Reporter.Infof("There are no DNS domains reserved by your organization")
+		return
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "ID\tCLUSTER ID\tRESERVED TIME\n")
+	for _, domain := range domains {
+		fmt.Fprintf(writer, "%s\t%s\t%s\n",
+			domain.ID,
+			domain.ClusterID(),
+			domain.ReservedAt.Format(time.RFC3339),
+		)
+	}
+	writer.Flush()
+}
@@ -0,0 +1,126 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package egressendpoints
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/arguments"
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/helper/network"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var args struct {
+	hostedCP bool
+	csv      bool
+}
+
+var Cmd = &cobra.Command{
+	Use:     "egress-endpoints",
+	Aliases: []string{"egressendpoints", "egress-endpoint", "egressendpoint"},
+	Short:   "List egress endpoints",
+	Long: "List the hosts and ports that the subnets of a cluster in the given region must be able to " +
+		"reach, so that they can be allowed in a firewall. They are a static copy, included in this " +
+		"version of rosa, of the endpoints that 'rosa verify network' checks, and only cover the " +
+		"commercial AWS regions, not GovCloud.",
+	Example: `  # List the endpoints needed by classic clusters in the us-east-1 region
+  rosa list egress-endpoints --region us-east-1
+
+  # Generate the firewall rules needed by hosted clusters as CSV
+  rosa list egress-endpoints --region us-east-2 --hosted-cp --csv > rules.csv`,
+	Run:  run,
+	Args: cobra.NoArgs,
+}
+
+func init() {
+	flags := Cmd.Flags()
+
+	flags.BoolVar(
+		&args.hostedCP,
+		"hosted-cp",
+		false,
+		"List the endpoints needed by hosted clusters instead of classic clusters.",
+	)
+	flags.BoolVar(
+		&args.csv,
+		"csv",
+		false,
+		"Print the endpoints as CSV, one firewall rule per line. Can't be used with '--output'.",
+	)
+	output.AddFlag(Cmd)
+}
+
+func run(_ *cobra.Command, _ []string) {
+	r := rosa.NewRuntime()
+
+	if args.csv && output.HasFlag() {
+		r.Reporter.Errorf("The '--csv' and '--output' options can't be used together")
+		os.Exit(1)
+	}
+
+	region, err := aws.GetRegion(arguments.GetRegion())
+	if err != nil {
+		r.Reporter.Errorf("Error getting region: %v", err)
+		os.Exit(1)
+	}
+	if region == "" {
+		r.Reporter.Errorf("Region is not set. Use --region to set the region")
+		os.Exit(1)
+	}
+
+	endpoints, err := network.EgressEndpoints(region, args.hostedCP)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+
+	if args.csv {
+		err = network.WriteEgressCSV(os.Stdout, endpoints)
+		if err != nil {
+			r.Reporter.Errorf("Failed to write endpoints: %v", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if output.HasFlag() {
+		err = output.Print(endpoints)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "HOST\tPORT\tPROTOCOL\tCATEGORY\n")
+	for _, endpoint := range endpoints {
+		fmt.Fprintf(writer, "%s\t%d\t%s\t%s\n",
+			endpoint.Host,
+			endpoint.Port,
+			endpoint.Protocol,
+			endpoint.Category,
+		)
+	}
+	writer.Flush()
+}
@@ -0,0 +1,103 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalauthprovider
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var Cmd = &cobra.Command{
+	Use:     "external-auth-providers",
+	Aliases: []string{"externalauthproviders", "external-auth-provider", "externalauthprovider"},
+	Short:   "List external authentication providers",
+	Long:    "List the external authentication providers of a Hosted Control Plane cluster.",
+	Example: `  # List the external authentication providers of cluster 'mycluster'
+  rosa list external-auth-providers -c mycluster`,
+	Run:  run,
+	Args: cobra.NoArgs,
+}
+
+func init() {
+	ocm.AddClusterFlag(Cmd)
+	output.AddFlag(Cmd)
+}
+
+func run(_ *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS().WithOCM()
+	defer r.Cleanup()
+
+	clusterKey := r.GetClusterKey()
+
+	cluster := r.FetchCluster()
+	err := r.OCMClient.ValidateExternalAuthCluster(cluster)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+
+	r.Reporter.Debugf("Loading external authentication providers for cluster '%s'", clusterKey)
+	externalAuths, err := r.OCMClient.GetExternalAuths(cluster.ID())
+	if err != nil {
+		r.Reporter.Errorf("Failed to get external authentication providers for cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+
+	if output.HasFlag() {
+		err = output.Print(externalAuths)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if len(externalAuths) == 0 {
+		r.Reporter.Infof("There are no external authentication providers for cluster '%s'", clusterKey)
+		return
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "NAME\tISSUER URL\tAUDIENCES\tCLIENTS\n")
+	for _, externalAuth := range externalAuths {
+		var issuerURL string
+		var audiences []string
+		if externalAuth.Issuer != nil {
+			issuerURL = externalAuth.Issuer.URL
+			audiences = externalAuth.Issuer.Audiences
+		}
+		clients := []string{}
+		for _, client := range externalAuth.Clients {
+			clients = append(clients, fmt.Sprintf("%s (%s)", client.ID, client.Component.Name))
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n",
+			externalAuth.ID,
+			issuerURL,
+			strings.Join(audiences, ", "),
+			strings.Join(clients, ", "),
+		)
+	}
+	writer.Flush()
+}
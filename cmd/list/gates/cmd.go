@@ -186,7 +186,7 @@ func run(cmd *cobra.Command, _ []string) {
 	cols, _ := consolesize.GetConsoleSize()
 	descriptionSize := float64(cols) * 0.30
 	writer := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
-	fmt.Fprintln(writer, "Gate Description\tSTS\tOCP Version\tDocumentation URL\t")
+	fmt.Fprintln(writer, "ID\tGate Description\tSTS\tOCP Version\tDocumentation URL\t")
 
 	for _, gate := range versionGates {
 		wrappedDescription := wordWrap(strings.TrimSuffix(gate.Description(), "\n"), int(descriptionSize))
@@ -194,7 +194,8 @@ func run(cmd *cobra.Command, _ []string) {
 		for i, line := range strings.Split(wrappedDescription, "\n") {
 			if i == 0 {
 				fmt.Fprintf(writer,
-					"%s\t%t\t%s\t%s\t\n",
+					"%s\t%s\t%t\t%s\t%s\t\n",
+					gate.ID(),
 					line,
 					gate.STSOnly(),
 					gate.VersionRawIDPrefix(),
@@ -202,7 +203,7 @@ func run(cmd *cobra.Command, _ []string) {
 				)
 			} else {
 				fmt.Fprintf(writer,
-					"%s\t \t \t \t\n",
+					" \t%s\t \t \t \t\n",
 					line,
 				)
 			}
@@ -59,7 +59,7 @@ func run(_ *cobra.Command, _ []string) {
 
 	// Load any existing ingresses for this cluster
 	r.Reporter.Debugf("Loading ingresses for cluster '%s'", clusterKey)
-	ingresses, err := r.OCMClient.GetIngresses(cluster.ID())
+	ingresses, settings, err := r.OCMClient.GetIngressesWithSettings(cluster.ID())
 	if err != nil {
 		r.Reporter.Errorf("Failed to get ingresses for cluster '%s': %v", clusterKey, err)
 		os.Exit(1)
@@ -82,14 +82,21 @@ func run(_ *cobra.Command, _ []string) {
 	// Create the writer that will be used to print the tabulated results:
 	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
 
-	fmt.Fprintf(writer, "ID\tAPPLICATION ROUTER\t\t\tPRIVATE\t\tDEFAULT\t\tROUTE SELECTORS\n")
+	fmt.Fprintf(writer, "ID\tAPPLICATION ROUTER\t\t\tPRIVATE\t\tDEFAULT\t\tROUTE SELECTORS\t\t"+
+		"LB TYPE\t\tEXCLUDED NAMESPACES\n")
 	for _, ingress := range ingresses {
-		fmt.Fprintf(writer, "%s\thttps://%s\t\t\t%s\t\t%s\t\t%s\n",
+		ingressSettings := settings[ingress.ID()]
+		if ingressSettings == nil {
+			ingressSettings = &ocm.IngressSettings{}
+		}
+		fmt.Fprintf(writer, "%s\thttps://%s\t\t\t%s\t\t%s\t\t%s\t\t%s\t\t%s\n",
 			ingress.ID(),
 			ingress.DNSName(),
 			isPrivate(ingress.Listening()),
 			isDefault(ingress),
 			printRouteSelectors(ingress),
+			ingressSettings.LoadBalancerType,
+			strings.Join(ingressSettings.ExcludedNamespaces, ", "),
 		)
 	}
 	writer.Flush()
@@ -17,13 +17,19 @@ limitations under the License.
 package instancetypes
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
 	"text/tabwriter"
 
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/spf13/cobra"
 
+	"github.com/openshift/rosa/pkg/arguments"
+	"github.com/openshift/rosa/pkg/helper"
+	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/output"
 	"github.com/openshift/rosa/pkg/rosa"
 )
@@ -34,11 +40,41 @@ var Cmd = &cobra.Command{
 	Short:   "List Instance types",
 	Long:    "List Instance types that are available for use with ROSA.",
 	Example: `  # List all instance types
-  rosa list instance-types`,
+  rosa list instance-types
+
+  # List the instance types with ARM64 (AWS Graviton) processors
+  rosa list instance-types --arch arm64
+
+  # List the GPU instance types with at least 8 vCPUs offered in us-east-1, with their prices
+  rosa list instance-types --region us-east-1 --min-vcpu 8 --gpu`,
 	Run: run,
 }
 
+var args struct {
+	arch    string
+	minVCPU int
+	gpu     bool
+}
+
 func init() {
+	Cmd.Flags().StringVar(
+		&args.arch,
+		"arch",
+		"",
+		fmt.Sprintf("List only the instance types of the given CPU architecture, one of %v.", ocm.Architectures),
+	)
+	Cmd.Flags().IntVar(
+		&args.minVCPU,
+		"min-vcpu",
+		0,
+		"List only the instance types with at least the given number of vCPUs.",
+	)
+	Cmd.Flags().BoolVar(
+		&args.gpu,
+		"gpu",
+		false,
+		"List only the accelerated computing instance types, which have GPUs or other accelerators.",
+	)
 	output.AddFlag(Cmd)
 }
 
@@ -46,6 +82,11 @@ func run(cmd *cobra.Command, _ []string) {
 	r := rosa.NewRuntime().WithOCM()
 	defer r.Cleanup()
 
+	if args.arch != "" && !helper.Contains(ocm.Architectures, args.arch) {
+		r.Reporter.Errorf("Expected a valid architecture, one of %v", ocm.Architectures)
+		os.Exit(1)
+	}
+
 	r.Reporter.Debugf("Fetching instance types")
 
 	machineTypes, err := r.OCMClient.GetAvailableMachineTypes()
@@ -59,6 +100,56 @@ func run(cmd *cobra.Command, _ []string) {
 		os.Exit(1)
 	}
 
+	if args.arch != "" {
+		machineTypes = machineTypes.FilterByArchitecture(args.arch)
+		if len(machineTypes) == 0 {
+			r.Reporter.Warnf("There are no %s machine types supported for your account.", args.arch)
+			os.Exit(0)
+		}
+	}
+
+	machineTypes = machineTypes.Filter(func(mt *ocm.MachineType) bool {
+		return mt.CPUs() >= args.minVCPU && (!args.gpu || mt.MachineType.Category() == ocm.AcceleratedComputing)
+	})
+
+	// When a region is given, add the availability zones and prices of the region:
+	var zones map[string][]string
+	var prices map[string]float64
+	if arguments.GetRegion() != "" {
+		r.WithAWS()
+		zones, err = r.AWSClient.GetInstanceTypeZones()
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+		machineTypes = machineTypes.Filter(func(mt *ocm.MachineType) bool {
+			return len(zones[mt.MachineType.ID()]) > 0
+		})
+		prices, err = r.AWSClient.GetOnDemandPrices()
+		if err != nil {
+			r.Reporter.Warnf("%s", err)
+		}
+	}
+
+	if len(machineTypes) == 0 {
+		r.Reporter.Warnf("There are no machine types matching the given criteria.")
+		os.Exit(0)
+	}
+
+	if output.HasFlag() && zones != nil {
+		instanceTypes, err := regionalInstanceTypes(machineTypes, zones, prices)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+		err = output.Print(instanceTypes)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	if output.HasFlag() {
 		var instanceTypes []*cmv1.MachineType
 		for _, machine := range machineTypes {
@@ -74,13 +165,31 @@ func run(cmd *cobra.Command, _ []string) {
 
 	// Create the writer that will be used to print the tabulated results:
 	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintf(writer, "ID\tCATEGORY\tCPU_CORES\tMEMORY\t\n")
+	if zones != nil {
+		fmt.Fprintf(writer, "ID\tCATEGORY\tCPU_CORES\tMEMORY\tPRICE_PER_HOUR\tAVAILABILITY_ZONES\t\n")
+	} else {
+		fmt.Fprintf(writer, "ID\tCATEGORY\tCPU_CORES\tMEMORY\t\n")
+	}
 
 	for _, machine := range machineTypes {
 		if !machine.Available {
 			continue
 		}
 		availableMachine := machine.MachineType
+		if zones != nil {
+			price := "N/A"
+			if value, ok := prices[availableMachine.ID()]; ok {
+				price = fmt.Sprintf("$%.4f", value)
+			}
+			fmt.Fprintf(writer,
+				"%s\t%s\t%d\t%s\t%s\t%s\n",
+				availableMachine.ID(), availableMachine.Category(), int(availableMachine.CPU().Value()),
+				ByteCountIEC(int(availableMachine.Memory().Value()),
+					availableMachine.Memory().Unit()),
+				price, strings.Join(zones[availableMachine.ID()], ","),
+			)
+			continue
+		}
 		fmt.Fprintf(writer,
 			"%s\t%s\t%d\t%s\n",
 			availableMachine.ID(), availableMachine.Category(), int(availableMachine.CPU().Value()),
@@ -91,6 +200,31 @@ func run(cmd *cobra.Command, _ []string) {
 	writer.Flush()
 }
 
+// regionalInstanceTypes returns the machine types with their availability zones and on-demand price
+// in the region.
+func regionalInstanceTypes(machineTypes ocm.MachineTypeList, zones map[string][]string,
+	prices map[string]float64) ([]map[string]interface{}, error) {
+	var result []map[string]interface{}
+	for _, machine := range machineTypes {
+		var buffer bytes.Buffer
+		err := cmv1.MarshalMachineType(machine.MachineType, &buffer)
+		if err != nil {
+			return nil, err
+		}
+		instanceType := map[string]interface{}{}
+		err = json.Unmarshal(buffer.Bytes(), &instanceType)
+		if err != nil {
+			return nil, err
+		}
+		instanceType["availability_zones"] = zones[machine.MachineType.ID()]
+		if price, ok := prices[machine.MachineType.ID()]; ok {
+			instanceType["on_demand_price_per_hour_usd"] = price
+		}
+		result = append(result, instanceType)
+	}
+	return result, nil
+}
+
 func ByteCountIEC(b int, uValue string) string {
 	var unit int
 	if uValue == "B" {
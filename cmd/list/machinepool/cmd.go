@@ -31,9 +31,13 @@ var Cmd = &cobra.Command{
 	Use:     "machinepools",
 	Aliases: []string{"machinepool", "machine-pools", "machine-pool"},
 	Short:   "List cluster machine pools",
-	Long:    "List machine pools configured on a cluster.",
+	Long: "List machine pools configured on a cluster. Use '--output wide' to also show the " +
+		"minimum and maximum replicas, disk size and taint count of each machine pool.",
 	Example: `  # List all machine pools on a cluster named "mycluster"
-  rosa list machinepools --cluster=mycluster`,
+  rosa list machinepools --cluster=mycluster
+
+  # List all machine pools on a cluster named "mycluster" with additional columns
+  rosa list machinepools --cluster=mycluster -o wide`,
 	Run: run,
 }
 
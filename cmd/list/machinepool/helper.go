@@ -42,3 +42,12 @@ func printTaints(taints []*cmv1.Taint) string {
 
 	return strings.Join(output, ", ")
 }
+
+// printDiskSize returns the given root volume size, or 'default' if the pool doesn't set an
+// explicit size.
+func printDiskSize(size int) string {
+	if size > 0 {
+		return fmt.Sprintf("%d GiB", size)
+	}
+	return "default"
+}
@@ -2,6 +2,7 @@ package machinepool
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"text/tabwriter"
 
@@ -52,6 +53,17 @@ func listMachinePools(r *rosa.Runtime, clusterKey string, cluster *cmv1.Cluster)
 		os.Exit(0)
 	}
 
+	if output.Wide() {
+		r.Reporter.Debugf("Loading disk sizes of machine pools for cluster '%s'", clusterKey)
+		diskSizes, err := r.OCMClient.GetMachinePoolDiskSizes(cluster.ID())
+		if err != nil {
+			r.Reporter.Errorf("Failed to get disk sizes of machine pools for cluster '%s': %v", clusterKey, err)
+			os.Exit(1)
+		}
+		writeMachinePoolsWide(os.Stdout, machinePools, diskSizes)
+		return
+	}
+
 	// Create the writer that will be used to print the tabulated results:
 	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
 
@@ -73,6 +85,41 @@ func listMachinePools(r *rosa.Runtime, clusterKey string, cluster *cmv1.Cluster)
 	writer.Flush()
 }
 
+// writeMachinePoolsWide writes the table of machine pools with the additional columns of the
+// 'wide' output format.
+func writeMachinePoolsWide(out io.Writer, machinePools []*cmv1.MachinePool, diskSizes map[string]int) {
+	writer := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(writer, "ID\tAUTOSCALING\tMIN REPLICAS\tMAX REPLICAS\tREPLICAS\tINSTANCE TYPE\tDISK SIZE\t"+
+		"SPOT INSTANCES\tAVAILABILITY ZONES\tSUBNETS\tTAINT COUNT\tTAINTS\tLABELS\n")
+	for _, machinePool := range machinePools {
+		minReplicas, maxReplicas := printMachinePoolMinMaxReplicas(machinePool.Autoscaling())
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%d\t%s\t%s\n",
+			machinePool.ID(),
+			printMachinePoolAutoscaling(machinePool.Autoscaling()),
+			minReplicas,
+			maxReplicas,
+			printMachinePoolReplicas(machinePool.Autoscaling(), machinePool.Replicas()),
+			machinePool.InstanceType(),
+			printDiskSize(diskSizes[machinePool.ID()]),
+			printSpot(machinePool),
+			printStringSlice(machinePool.AvailabilityZones()),
+			printStringSlice(machinePool.Subnets()),
+			len(machinePool.Taints()),
+			printTaints(machinePool.Taints()),
+			printLabels(machinePool.Labels()),
+		)
+	}
+	writer.Flush()
+}
+
+func printMachinePoolMinMaxReplicas(autoscaling *cmv1.MachinePoolAutoscaling) (string, string) {
+	if autoscaling == nil {
+		return "", ""
+	}
+	return fmt.Sprintf("%d", autoscaling.MinReplicas()), fmt.Sprintf("%d", autoscaling.MaxReplicas())
+}
+
 func printMachinePoolAutoscaling(autoscaling *cmv1.MachinePoolAutoscaling) string {
 	if autoscaling != nil {
 		return Yes
@@ -2,11 +2,13 @@ package machinepool
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"text/tabwriter"
 
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -19,13 +21,44 @@ func listNodePools(r *rosa.Runtime, clusterKey string, cluster *cmv1.Cluster) {
 		os.Exit(1)
 	}
 
+	if output.HasFlag() {
+		err = output.Print(nodePools)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	r.Reporter.Debugf("Loading upgrade policies of machine pools for cluster '%s'", clusterKey)
+	nodePoolIDs := []string{}
+	for _, nodePool := range nodePools {
+		nodePoolIDs = append(nodePoolIDs, nodePool.ID())
+	}
+	autoUpgrade, err := r.OCMClient.GetNodePoolsAutoUpgrade(cluster.ID(), nodePoolIDs)
+	if err != nil {
+		r.Reporter.Errorf("Failed to get upgrade policies of machine pools for cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+
+	if output.Wide() {
+		r.Reporter.Debugf("Loading settings of machine pools for cluster '%s'", clusterKey)
+		settings, err := r.OCMClient.GetNodePoolsSettings(cluster.ID())
+		if err != nil {
+			r.Reporter.Errorf("Failed to get settings of machine pools for cluster '%s': %v", clusterKey, err)
+			os.Exit(1)
+		}
+		writeNodePoolsWide(os.Stdout, nodePools, settings, autoUpgrade)
+		return
+	}
+
 	// Create the writer that will be used to print the tabulated results:
 	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
 
 	fmt.Fprintf(writer, "ID\tAUTOSCALING\tDESIRED REPLICAS\tCURRENT REPLICAS\t"+
-		"INSTANCE TYPE\tLABELS\t\tTAINTS\t\tAVAILABILITY ZONE\tSUBNET\tVERSION\tAUTOREPAIR\tMESSAGE\t\n")
+		"INSTANCE TYPE\tLABELS\t\tTAINTS\t\tAVAILABILITY ZONE\tSUBNET\tVERSION\tAUTOUPGRADE\tAUTOREPAIR\tMESSAGE\t\n")
 	for _, nodePool := range nodePools {
-		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\t\t%s\t\t%s\t%s\t%s\t%s\t%s\t\n",
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\t\t%s\t\t%s\t%s\t%s\t%s\t%s\t%s\t\n",
 			nodePool.ID(),
 			printNodePoolAutoscaling(nodePool.Autoscaling()),
 			printNodePoolReplicas(nodePool.Autoscaling(), nodePool.Replicas()),
@@ -36,13 +69,55 @@ func listNodePools(r *rosa.Runtime, clusterKey string, cluster *cmv1.Cluster) {
 			nodePool.AvailabilityZone(),
 			nodePool.Subnet(),
 			printNodePoolVersion(nodePool.Version()),
+			printNodePoolAutoUpgrade(autoUpgrade[nodePool.ID()]),
+			printNodePoolAutorepair(nodePool.AutoRepair()),
+			printNodePoolMessage(nodePool.Status()),
+		)
+	}
+	writer.Flush()
+}
+
+// writeNodePoolsWide writes the table of node pools with the additional columns of the 'wide'
+// output format.
+func writeNodePoolsWide(out io.Writer, nodePools []*cmv1.NodePool, settings map[string]*ocm.NodePoolSettings,
+	autoUpgrade map[string]bool) {
+	writer := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(writer, "ID\tAUTOSCALING\tMIN REPLICAS\tMAX REPLICAS\tDESIRED REPLICAS\tCURRENT REPLICAS\t"+
+		"INSTANCE TYPE\tDISK SIZE\tAVAILABILITY ZONE\tSUBNET\tVERSION\tAUTOUPGRADE\tAUTOREPAIR\tTAINT COUNT\tTAINTS\t"+
+		"LABELS\tMESSAGE\n")
+	for _, nodePool := range nodePools {
+		minReplicas, maxReplicas := printNodePoolMinMaxReplicas(nodePool.Autoscaling())
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%d\t%s\t%s\t%s\n",
+			nodePool.ID(),
+			printNodePoolAutoscaling(nodePool.Autoscaling()),
+			minReplicas,
+			maxReplicas,
+			printNodePoolReplicas(nodePool.Autoscaling(), nodePool.Replicas()),
+			printNodePoolCurrentReplicas(nodePool.Status()),
+			printNodePoolInstanceType(nodePool.AWSNodePool()),
+			printNodePoolDiskSize(settings[nodePool.ID()]),
+			nodePool.AvailabilityZone(),
+			nodePool.Subnet(),
+			printNodePoolVersion(nodePool.Version()),
+			printNodePoolAutoUpgrade(autoUpgrade[nodePool.ID()]),
 			printNodePoolAutorepair(nodePool.AutoRepair()),
+			len(nodePool.Taints()),
+			printTaints(nodePool.Taints()),
+			printLabels(nodePool.Labels()),
 			printNodePoolMessage(nodePool.Status()),
 		)
 	}
 	writer.Flush()
 }
 
+func printNodePoolMinMaxReplicas(autoscaling *cmv1.NodePoolAutoscaling) (string, string) {
+	if autoscaling == nil {
+		return "", ""
+	}
+	return fmt.Sprintf("%d", autoscaling.MinReplica()), fmt.Sprintf("%d", autoscaling.MaxReplica())
+}
+
 func printNodePoolAutoscaling(autoscaling *cmv1.NodePoolAutoscaling) string {
 	if autoscaling != nil {
 		return "Yes"
@@ -84,6 +159,20 @@ func printNodePoolVersion(version *cmv1.Version) string {
 	return ocm.GetRawVersionId(version.ID())
 }
 
+func printNodePoolDiskSize(settings *ocm.NodePoolSettings) string {
+	if settings == nil {
+		return printDiskSize(0)
+	}
+	return printDiskSize(settings.DiskSize)
+}
+
+func printNodePoolAutoUpgrade(autoUpgrade bool) string {
+	if autoUpgrade {
+		return Yes
+	}
+	return No
+}
+
 func printNodePoolAutorepair(autorepair bool) string {
 	if autorepair {
 		return Yes
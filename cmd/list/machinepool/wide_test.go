@@ -0,0 +1,51 @@
+package machinepool
+
+import (
+	"bytes"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/ocm"
+)
+
+var _ = Describe("Wide output", func() {
+	It("Prints the additional columns of machine pools", func() {
+		machinePool, err := cmv1.NewMachinePool().
+			ID("mp-1").
+			InstanceType("m5.xlarge").
+			Autoscaling(cmv1.NewMachinePoolAutoscaling().MinReplicas(2).MaxReplicas(4)).
+			Taints(cmv1.NewTaint().Key("k").Value("v").Effect("NoSchedule")).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		var out bytes.Buffer
+		writeMachinePoolsWide(&out, []*cmv1.MachinePool{machinePool}, map[string]int{"mp-1": 200})
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		Expect(lines).To(HaveLen(2))
+		Expect(lines[0]).To(ContainSubstring("MIN REPLICAS"))
+		Expect(lines[0]).To(ContainSubstring("DISK SIZE"))
+		Expect(strings.Fields(lines[1])).To(ContainElements("mp-1", "Yes", "2", "4", "2-4", "200", "1"))
+	})
+
+	It("Prints the additional columns of node pools", func() {
+		nodePool, err := cmv1.NewNodePool().
+			ID("np-1").
+			Replicas(3).
+			AWSNodePool(cmv1.NewAWSNodePool().InstanceType("m5.xlarge")).
+			Status(cmv1.NewNodePoolStatus().CurrentReplicas(1)).
+			Version(cmv1.NewVersion().ID("openshift-v4.12.0")).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		var out bytes.Buffer
+		writeNodePoolsWide(&out, []*cmv1.NodePool{nodePool},
+			map[string]*ocm.NodePoolSettings{"np-1": {DiskSize: 300}}, map[string]bool{"np-1": true})
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		Expect(lines).To(HaveLen(2))
+		Expect(lines[0]).To(ContainSubstring("CURRENT REPLICAS"))
+		Expect(lines[0]).To(ContainSubstring("AUTOUPGRADE"))
+		Expect(strings.Fields(lines[1])).To(ContainElements("np-1", "No", "3", "1", "300", "GiB", "4.12.0", "Yes", "0"))
+	})
+})
@@ -0,0 +1,184 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oidcprovider
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/helper"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var args struct {
+	dangling bool
+}
+
+var Cmd = &cobra.Command{
+	Use:     "oidc-providers",
+	Aliases: []string{"oidcprovider", "oidc-provider", "oidcproviders"},
+	Short:   "List OIDC providers",
+	Long: "List the IAM OIDC providers created by ROSA in the AWS account, with the clusters and the " +
+		"registered OIDC configurations that use each of them. Providers that aren't used by any cluster " +
+		"or OIDC configuration are flagged as dangling. Only the clusters and OIDC configurations visible " +
+		"to the current OCM environment and organization are considered, so a dangling provider may still " +
+		"be used by clusters of other environments or organizations.",
+	Example: `  # List all OIDC providers created by ROSA
+  rosa list oidc-providers
+
+  # List only the OIDC providers that aren't used by any cluster
+  rosa list oidc-providers --dangling`,
+	Run:  run,
+	Args: cobra.NoArgs,
+}
+
+func init() {
+	flags := Cmd.Flags()
+
+	flags.BoolVar(
+		&args.dangling,
+		"dangling",
+		false,
+		"List only the OIDC providers that aren't used by any cluster or OIDC configuration of the "+
+			"current OCM environment and organization.",
+	)
+
+	output.AddFlag(Cmd)
+}
+
+// providerUsage is an OIDC provider with the clusters and OIDC configurations that use its issuer URL
+type providerUsage struct {
+	*aws.OIDCProvider
+	Clusters    []string `json:"clusters"`
+	OidcConfigs []string `json:"oidc_configs"`
+	Dangling    bool     `json:"dangling"`
+}
+
+func run(_ *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS().WithOCM()
+	defer r.Cleanup()
+
+	r.Reporter.Debugf("Loading OIDC providers")
+	providers, err := r.AWSClient.ListROSAOpenIDConnectProviders()
+	if err != nil {
+		r.Reporter.Errorf("Failed to list OIDC providers: %v", err)
+		os.Exit(1)
+	}
+
+	issuerUrls := make([]string, len(providers))
+	for i, provider := range providers {
+		issuerUrls[i] = issuerUrl(provider)
+	}
+	clusters, err := r.OCMClient.GetClustersUsingOidcEndpointUrls(issuerUrls)
+	if err != nil {
+		r.Reporter.Errorf("Failed to get the clusters using the OIDC providers: %v", err)
+		os.Exit(1)
+	}
+	r.Reporter.Debugf("Loading OIDC configurations")
+	oidcConfigs, err := r.OCMClient.ListOidcConfigs(r.Creator.AccountID)
+	if err != nil {
+		r.Reporter.Errorf("Failed to get the OIDC configurations: %v", err)
+		os.Exit(1)
+	}
+	usages := getUsages(providers, clusters, oidcConfigs)
+	if args.dangling {
+		usages = filterDangling(usages)
+	}
+
+	if output.HasFlag() {
+		err = output.Print(usages)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if len(usages) == 0 {
+		r.Reporter.Infof("There are no OIDC providers to list")
+		return
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "ISSUER URL\tCLUSTER ID TAG\tCLUSTERS\tOIDC CONFIGS\tDANGLING\n")
+	for _, usage := range usages {
+		dangling := "No"
+		if usage.Dangling {
+			dangling = "Yes"
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\n",
+			issuerUrl(usage.OIDCProvider),
+			usage.ClusterID,
+			strings.Join(usage.Clusters, ", "),
+			strings.Join(usage.OidcConfigs, ", "),
+			dangling,
+		)
+	}
+	writer.Flush()
+
+	if len(filterDangling(usages)) > 0 {
+		r.Reporter.Infof("Only the clusters and OIDC configurations of the current OCM environment and " +
+			"organization were checked. Dangling OIDC providers that aren't used by other environments or " +
+			"organizations can be deleted with 'rosa delete oidc-provider --oidc-endpoint-url <issuer url>'")
+	}
+}
+
+func issuerUrl(provider *aws.OIDCProvider) string {
+	return fmt.Sprintf("%s://%s", helper.ProtocolHttps, provider.URL)
+}
+
+// getUsages matches the providers with the clusters and the registered OIDC configurations that use
+// them
+func getUsages(providers []*aws.OIDCProvider, clusters map[string][]*cmv1.Cluster,
+	oidcConfigs []*cmv1.OidcConfig) []*providerUsage {
+	usages := make([]*providerUsage, len(providers))
+	for i, provider := range providers {
+		usage := &providerUsage{
+			OIDCProvider: provider,
+			Clusters:     []string{},
+			OidcConfigs:  []string{},
+		}
+		for _, cluster := range clusters[issuerUrl(provider)] {
+			usage.Clusters = append(usage.Clusters, cluster.ID())
+		}
+		for _, oidcConfig := range oidcConfigs {
+			if strings.TrimSuffix(oidcConfig.IssuerUrl(), "/") == issuerUrl(provider) {
+				usage.OidcConfigs = append(usage.OidcConfigs, oidcConfig.ID())
+			}
+		}
+		usage.Dangling = len(usage.Clusters) == 0 && len(usage.OidcConfigs) == 0
+		usages[i] = usage
+	}
+	return usages
+}
+
+func filterDangling(usages []*providerUsage) []*providerUsage {
+	result := []*providerUsage{}
+	for _, usage := range usages {
+		if usage.Dangling {
+			result = append(result, usage)
+		}
+	}
+	return result
+}
@@ -0,0 +1,61 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oidcprovider
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/aws"
+)
+
+var _ = Describe("OIDC provider usage", func() {
+	It("matches the providers with the clusters that use them", func() {
+		cluster, err := cmv1.NewCluster().
+			ID("cluster-1").
+			AWS(cmv1.NewAWS().STS(cmv1.NewSTS().OIDCEndpointURL("https://oidc.example.com/abc"))).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		oidcConfig, err := cmv1.NewOidcConfig().
+			ID("config-1").
+			IssuerUrl("https://oidc.example.com/ghi").
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		providers := []*aws.OIDCProvider{
+			{URL: "oidc.example.com/abc"},
+			{URL: "oidc.example.com/def", ClusterID: "cluster-2"},
+			{URL: "oidc.example.com/ghi"},
+		}
+		clusters := map[string][]*cmv1.Cluster{
+			"https://oidc.example.com/abc": {cluster},
+		}
+
+		usages := getUsages(providers, clusters, []*cmv1.OidcConfig{oidcConfig})
+		Expect(usages).To(HaveLen(3))
+		Expect(usages[0].Clusters).To(Equal([]string{"cluster-1"}))
+		Expect(usages[0].Dangling).To(BeFalse())
+		Expect(usages[1].Clusters).To(BeEmpty())
+		Expect(usages[1].Dangling).To(BeTrue())
+		Expect(usages[2].OidcConfigs).To(Equal([]string{"config-1"}))
+		Expect(usages[2].Dangling).To(BeFalse())
+
+		dangling := filterDangling(usages)
+		Expect(dangling).To(HaveLen(1))
+		Expect(dangling[0].URL).To(Equal("oidc.example.com/def"))
+	})
+})
@@ -17,11 +17,16 @@ limitations under the License.
 package region
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 	"text/tabwriter"
 
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/openshift/rosa/pkg/aws"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/output"
 	"github.com/openshift/rosa/pkg/rosa"
@@ -33,15 +38,25 @@ var args struct {
 	roleARN       string
 	externalID    string
 	hostedCluster bool
+	localZones    bool
 }
 
 var Cmd = &cobra.Command{
 	Use:     "regions",
 	Aliases: []string{"region"},
 	Short:   "List available regions",
-	Long:    "List regions that are available for the current AWS account.",
+	Long: "List regions that are available for the current AWS account, with their support for " +
+		"multiple availability zones and hosted control planes and whether they need to be enabled " +
+		"for the account. The Local Zones of each region are only listed with the '--local-zones' " +
+		"flag, as that needs a request to each region.",
 	Example: `  # List all available regions
-  rosa list regions`,
+  rosa list regions
+
+  # List the regions that support hosted control planes
+  rosa list regions --hosted-cp
+
+  # List all available regions with their Local Zones
+  rosa list regions --local-zones`,
 	Run: run,
 }
 
@@ -71,7 +86,13 @@ func init() {
 		false,
 		"List only regions with support for hosted control planes (HyperShift)",
 	)
-	flags.MarkHidden("hosted-cp")
+	flags.BoolVar(
+		&args.localZones,
+		"local-zones",
+		false,
+		"List the Local Zones of each region. This needs a request to each region, and the AWS "+
+			"credentials of the account.",
+	)
 
 	output.AddFlag(Cmd)
 }
@@ -122,8 +143,31 @@ func run(cmd *cobra.Command, _ []string) {
 		os.Exit(1)
 	}
 
+	// The details that depend on the account are fetched with the local AWS credentials, which
+	// aren't used when the regions are fetched with a role:
+	var regionDetails map[string]*aws.RegionDetails
+	if args.roleARN == "" {
+		var localZoneRegions []string
+		if args.localZones {
+			for _, region := range availableRegions {
+				localZoneRegions = append(localZoneRegions, region.ID())
+			}
+		}
+		r.WithAWS()
+		r.Reporter.Debugf("Fetching region details")
+		regionDetails, err = r.AWSClient.GetRegionDetails(localZoneRegions)
+		if err != nil {
+			r.Reporter.Warnf("Failed to fetch the opt-in status and Local Zones of regions: %v", err)
+		}
+	} else if args.localZones {
+		r.Reporter.Warnf("Local Zones can't be listed when the regions are fetched with '--role-arn'")
+	}
+
 	if output.HasFlag() {
-		err = output.Print(availableRegions)
+		result, err := regionsOutput(availableRegions, regionDetails)
+		if err == nil {
+			err = output.Print(result)
+		}
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
 			os.Exit(1)
@@ -133,29 +177,67 @@ func run(cmd *cobra.Command, _ []string) {
 
 	// Create the writer that will be used to print the tabulated results:
 	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	headerFormat := "ID\t\tNAME\t\tMULTI-AZ SUPPORT\n"
+	headers := []string{"ID", "NAME", "MULTI-AZ SUPPORT"}
 	if hypershiftEnabled {
-		headerFormat = "ID\t\tNAME\t\tMULTI-AZ SUPPORT\t\tHOSTED-CP SUPPORT\n"
+		headers = append(headers, "HOSTED-CP SUPPORT")
 	}
-	fmt.Fprint(writer, headerFormat)
+	if regionDetails != nil {
+		headers = append(headers, "OPT-IN STATUS")
+		if args.localZones {
+			headers = append(headers, "LOCAL ZONES")
+		}
+	}
+	fmt.Fprintf(writer, "%s\n", strings.Join(headers, "\t\t"))
 
 	for _, region := range availableRegions {
+		columns := []string{
+			region.ID(),
+			region.DisplayName(),
+			strconv.FormatBool(region.SupportsMultiAZ()),
+		}
 		if hypershiftEnabled {
-			fmt.Fprintf(writer,
-				"%s\t\t%s\t\t%t\t\t%t\n",
-				region.ID(),
-				region.DisplayName(),
-				region.SupportsMultiAZ(),
-				region.SupportsHypershift(),
-			)
-		} else {
-			fmt.Fprintf(writer,
-				"%s\t\t%s\t\t%t\n",
-				region.ID(),
-				region.DisplayName(),
-				region.SupportsMultiAZ(),
-			)
+			columns = append(columns, strconv.FormatBool(region.SupportsHypershift()))
 		}
+		if regionDetails != nil {
+			details, ok := regionDetails[region.ID()]
+			if !ok {
+				details = &aws.RegionDetails{}
+			}
+			columns = append(columns, details.OptInStatus)
+			if args.localZones {
+				columns = append(columns, strings.Join(details.LocalZones, ","))
+			}
+		}
+		fmt.Fprintf(writer, "%s\n", strings.Join(columns, "\t\t"))
 	}
 	writer.Flush()
 }
+
+// regionsOutput returns the regions in the format of the OCM API, with the opt-in status and the
+// Local Zones of each region added when they are known.
+func regionsOutput(regions []*cmv1.CloudRegion,
+	regionDetails map[string]*aws.RegionDetails) ([]map[string]interface{}, error) {
+	var b bytes.Buffer
+	err := cmv1.MarshalCloudRegionList(regions, &b)
+	if err != nil {
+		return nil, err
+	}
+	result := []map[string]interface{}{}
+	err = json.Unmarshal(b.Bytes(), &result)
+	if err != nil {
+		return nil, err
+	}
+	for i, region := range regions {
+		details, ok := regionDetails[region.ID()]
+		if !ok {
+			continue
+		}
+		if details.OptInStatus != "" {
+			result[i]["opt_in_status"] = details.OptInStatus
+		}
+		if args.localZones {
+			result[i]["local_zones"] = details.LocalZones
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,52 @@
+package region
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/aws"
+)
+
+var _ = Describe("Regions output", func() {
+	var regions []*cmv1.CloudRegion
+
+	BeforeEach(func() {
+		usEast1, err := cmv1.NewCloudRegion().ID("us-east-1").SupportsMultiAZ(true).Build()
+		Expect(err).ToNot(HaveOccurred())
+		afSouth1, err := cmv1.NewCloudRegion().ID("af-south-1").Build()
+		Expect(err).ToNot(HaveOccurred())
+		regions = []*cmv1.CloudRegion{usEast1, afSouth1}
+	})
+
+	AfterEach(func() {
+		args.localZones = false
+	})
+
+	It("adds the opt-in status and the Local Zones of the regions", func() {
+		args.localZones = true
+		result, err := regionsOutput(regions, map[string]*aws.RegionDetails{
+			"us-east-1": {
+				OptInStatus: "opt-in-not-required",
+				LocalZones:  []string{"us-east-1-bos-1a"},
+			},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(HaveLen(2))
+		Expect(result[0]).To(HaveKeyWithValue("id", "us-east-1"))
+		Expect(result[0]).To(HaveKeyWithValue("supports_multi_az", true))
+		Expect(result[0]).To(HaveKeyWithValue("opt_in_status", "opt-in-not-required"))
+		Expect(result[0]).To(HaveKeyWithValue("local_zones", []string{"us-east-1-bos-1a"}))
+		Expect(result[1]).To(HaveKeyWithValue("id", "af-south-1"))
+		Expect(result[1]).ToNot(HaveKey("opt_in_status"))
+	})
+
+	It("omits the Local Zones when they aren't requested", func() {
+		result, err := regionsOutput(regions, map[string]*aws.RegionDetails{
+			"us-east-1": {OptInStatus: "opt-in-not-required"},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result[0]).To(HaveKeyWithValue("opt_in_status", "opt-in-not-required"))
+		Expect(result[0]).ToNot(HaveKey("local_zones"))
+	})
+})
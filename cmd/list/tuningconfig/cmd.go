@@ -0,0 +1,96 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tuningconfig
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+	"github.com/openshift/rosa/pkg/tuningconfig"
+)
+
+var Cmd = &cobra.Command{
+	Use:     "tuning-configs",
+	Aliases: []string{"tuningconfigs", "tuning-config", "tuningconfig"},
+	Short:   "List tuning configs",
+	Long:    "List the tuning configs of a Hosted Control Plane cluster.",
+	Example: `  # List the tuning configs of cluster 'mycluster'
+  rosa list tuning-configs -c mycluster`,
+	Run:  run,
+	Args: cobra.NoArgs,
+}
+
+func init() {
+	ocm.AddClusterFlag(Cmd)
+	output.AddFlag(Cmd)
+}
+
+func run(_ *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS().WithOCM()
+	defer r.Cleanup()
+
+	clusterKey := r.GetClusterKey()
+
+	cluster := r.FetchCluster()
+	err := tuningconfig.ValidateCluster(cluster)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+
+	r.Reporter.Debugf("Loading tuning configs for cluster '%s'", clusterKey)
+	configs, err := r.OCMClient.GetTuningConfigs(cluster.ID())
+	if err != nil {
+		r.Reporter.Errorf("Failed to get tuning configs for cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+
+	if output.HasFlag() {
+		err = output.Print(configs)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if len(configs) == 0 {
+		r.Reporter.Infof("There are no tuning configs for cluster '%s'", clusterKey)
+		return
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "NAME\tID\tPROFILES\n")
+	for _, config := range configs {
+		profiles := []string{}
+		items, _ := config.Spec["profile"].([]interface{})
+		for _, item := range items {
+			if profile, ok := item.(map[string]interface{}); ok {
+				profiles = append(profiles, fmt.Sprintf("%v", profile["name"]))
+			}
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\n", config.Name, config.ID, strings.Join(profiles, ", "))
+	}
+	writer.Flush()
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -34,12 +35,19 @@ var Cmd = &cobra.Command{
 	Use:     "upgrades",
 	Aliases: []string{"upgrade"},
 	Short:   "List available cluster upgrades",
-	Long:    "List available and scheduled cluster version upgrades",
-	Run:     run,
+	Long: "List available and scheduled cluster version upgrades, including the versions that can " +
+		"only be reached through intermediate upgrades and the gates that block them",
+	Example: `  # List the upgrades of the cluster named "mycluster"
+  rosa list upgrades -c mycluster
+
+  # List the upgrades and the edges between them, to plan multi-step upgrades
+  rosa list upgrades -c mycluster -o json`,
+	Run: run,
 }
 
 func init() {
 	ocm.AddClusterFlag(Cmd)
+	output.AddFlag(Cmd)
 }
 
 func run(_ *cobra.Command, _ []string) {
@@ -56,18 +64,24 @@ func run(_ *cobra.Command, _ []string) {
 
 	// Load available upgrades for this cluster
 	r.Reporter.Debugf("Loading available upgrades for cluster '%s'", clusterKey)
-	availableUpgrades, err := r.OCMClient.GetAvailableUpgrades(ocm.GetVersionID(cluster))
+	currentVersion := ocm.GetRawVersionId(ocm.GetVersionID(cluster))
+	graph, err := r.OCMClient.GetUpgradeGraph(currentVersion, cluster.Version().ChannelGroup())
 	if err != nil {
 		r.Reporter.Errorf("Failed to get available upgrades for cluster '%s': %v", clusterKey, err)
 		os.Exit(1)
 	}
 
-	if len(availableUpgrades) == 0 {
+	if len(graph.Versions) == 0 && !output.HasFlag() {
 		r.Reporter.Infof("There are no available upgrades for cluster '%s'", clusterKey)
 		os.Exit(0)
 	}
 
-	latestRev := latestInCurrentMinor(ocm.GetVersionID(cluster), availableUpgrades)
+	r.Reporter.Debugf("Loading version gates for cluster '%s'", clusterKey)
+	err = addGates(r, cluster, graph)
+	if err != nil {
+		r.Reporter.Errorf("Failed to get version gates for cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
 
 	r.Reporter.Debugf("Loading scheduled upgrades for cluster '%s'", clusterKey)
 	scheduledUpgrade, upgradeState, err := r.OCMClient.GetScheduledUpgrade(cluster.ID())
@@ -76,21 +90,137 @@ func run(_ *cobra.Command, _ []string) {
 		os.Exit(1)
 	}
 
+	directUpgrades := []string{}
+	for _, node := range graph.Versions {
+		if node.Direct {
+			directUpgrades = append(directUpgrades, node.Version)
+		}
+	}
+	latestRev := latestInCurrentMinor(ocm.GetVersionID(cluster), directUpgrades)
+	for _, node := range graph.Versions {
+		if node.Version == latestRev {
+			node.Recommended = true
+		}
+		if scheduledUpgrade != nil && node.Version == scheduledUpgrade.Version() {
+			node.Scheduled = fmt.Sprintf("%s for %s", upgradeState.Value(),
+				scheduledUpgrade.NextRun().Format("2006-01-02 15:04 MST"))
+		}
+	}
+
+	if output.HasFlag() {
+		err = output.Print(graph)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	// Create the writer that will be used to print the tabulated results:
 	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
 	fmt.Fprintf(writer, "VERSION\tNOTES\n")
-	for i, availableUpgrade := range availableUpgrades {
-		notes := ""
-		if notes == "" && (i == 0 || availableUpgrade == latestRev) {
-			notes = "recommended"
+	for _, node := range graph.Versions {
+		notes := []string{}
+		if node.Recommended {
+			notes = append(notes, "recommended")
 		}
-		if availableUpgrade == scheduledUpgrade.Version() {
-			notes = fmt.Sprintf("%s for %s", upgradeState.Value(),
-				scheduledUpgrade.NextRun().Format("2006-01-02 15:04 MST"))
+		if !node.Direct {
+			notes = append(notes, fmt.Sprintf("via %s", strings.Join(pathTo(graph, node.Version), " -> ")))
 		}
-		fmt.Fprintf(writer, "%s\t%s\n", availableUpgrade, notes)
+		if len(node.Gates) > 0 {
+			notes = append(notes, fmt.Sprintf("blocked by %d gate(s) to acknowledge", len(node.Gates)))
+		}
+		if node.Scheduled != "" {
+			notes = append(notes, node.Scheduled)
+		}
+		fmt.Fprintf(writer, "%s\t%s\n", node.Version, strings.Join(notes, ", "))
 	}
 	writer.Flush()
+
+	if len(graph.RecommendedPath) > 2 {
+		fmt.Printf("\nRecommended upgrade path: %s\n", strings.Join(graph.RecommendedPath, " -> "))
+	}
+}
+
+// pathTo returns the intermediate versions of the recommended path that lead to the given version.
+func pathTo(graph *ocm.UpgradeGraph, version string) []string {
+	for _, edge := range graph.Edges {
+		if edge.To != version {
+			continue
+		}
+		for i, step := range graph.RecommendedPath {
+			if step == edge.From && i > 0 {
+				return graph.RecommendedPath[1 : i+1]
+			}
+		}
+	}
+	return []string{}
+}
+
+// addGates adds to the versions of the graph the gates that need to be acknowledged before upgrading
+// to them. Gates apply to minor versions, and only the ones that can't be acknowledged automatically
+// block the upgrade. For direct upgrades the gates that were already acknowledged are skipped.
+func addGates(r *rosa.Runtime, cluster *cmv1.Cluster, graph *ocm.UpgradeGraph) error {
+	currentMinor := minorOf(graph.Current)
+	minors := map[string][]*ocm.UpgradeGraphNode{}
+	for _, node := range graph.Versions {
+		minor := minorOf(node.Version)
+		if minor != currentMinor {
+			minors[minor] = append(minors[minor], node)
+		}
+	}
+	for minor, nodes := range minors {
+		var gates []*cmv1.VersionGate
+		var err error
+		direct := ""
+		for _, node := range nodes {
+			if node.Direct {
+				direct = node.Version
+				break
+			}
+		}
+		switch {
+		case direct != "" && cluster.Hypershift().Enabled():
+			var upgradePolicy *cmv1.ControlPlaneUpgradePolicy
+			upgradePolicy, err = cmv1.NewControlPlaneUpgradePolicy().ScheduleType("manual").
+				UpgradeType("ControlPlane").Version(direct).Build()
+			if err == nil {
+				gates, err = r.OCMClient.GetMissingGateAgreementsHypershift(cluster.ID(), upgradePolicy)
+			}
+		case direct != "":
+			var upgradePolicy *cmv1.UpgradePolicy
+			upgradePolicy, err = cmv1.NewUpgradePolicy().ScheduleType("manual").Version(direct).Build()
+			if err == nil {
+				gates, err = r.OCMClient.GetMissingGateAgreementsClassic(cluster.ID(), upgradePolicy)
+			}
+		default:
+			gates, err = r.OCMClient.ListOcpGates(minor)
+		}
+		if err != nil {
+			return err
+		}
+		for _, gate := range gates {
+			if gate.STSOnly() {
+				continue
+			}
+			for _, node := range nodes {
+				node.Gates = append(node.Gates, &ocm.UpgradeGraphGate{
+					ID:               gate.ID(),
+					Description:      strings.TrimSpace(gate.Description()),
+					DocumentationURL: gate.DocumentationURL(),
+				})
+			}
+		}
+	}
+	return nil
+}
+
+func minorOf(version string) string {
+	parts := strings.SplitN(version, ".", 3)
+	if len(parts) < 2 {
+		return version
+	}
+	return parts[0] + "." + parts[1]
 }
 
 func latestInCurrentMinor(current string, versions []string) string {
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"math"
 	"os"
+	"sort"
 	"strings"
 	"text/tabwriter"
 
@@ -37,12 +38,25 @@ var Cmd = &cobra.Command{
 	Short:   "List cluster users",
 	Long:    "List administrative cluster users.",
 	Example: `  # List all users on a cluster named "mycluster"
-  rosa list users --cluster=mycluster`,
+  rosa list users --cluster=mycluster
+
+  # List the users with the dedicated-admins role on a cluster named "mycluster"
+  rosa list users --cluster=mycluster --role=dedicated-admins`,
 	Run: run,
 }
 
+var args struct {
+	role string
+}
+
 func init() {
 	ocm.AddClusterFlag(Cmd)
+	Cmd.Flags().StringVar(
+		&args.role,
+		"role",
+		"",
+		"Only list the users with the given role, for example 'dedicated-admins'.",
+	)
 }
 
 func run(_ *cobra.Command, _ []string) {
@@ -57,55 +71,60 @@ func run(_ *cobra.Command, _ []string) {
 		os.Exit(1)
 	}
 
-	var clusterAdmins []*cmv1.User
-	var err error
-	r.Reporter.Debugf("Loading users for cluster '%s'", clusterKey)
-	// Load cluster-admins for this cluster
-	clusterAdmins, err = r.OCMClient.GetUsers(cluster.ID(), "cluster-admins")
-	if err != nil {
-		r.Reporter.Errorf("Failed to get cluster-admins for cluster '%s': %v", clusterKey, err)
-		os.Exit(1)
-	}
-	// Remove cluster-admin user
-	for i, user := range clusterAdmins {
-		if user.ID() == idp.ClusterAdminUsername {
-			clusterAdmins = append(clusterAdmins[:i], clusterAdmins[i+1:]...)
+	r.Reporter.Debugf("Loading groups of cluster '%s'", clusterKey)
+	var groupIDs []string
+	if args.role != "" {
+		groupID, err := r.OCMClient.GetGroupID(cluster.ID(), args.role)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+		groupIDs = []string{groupID}
+	} else {
+		clusterGroups, err := r.OCMClient.GetGroups(cluster.ID())
+		if err != nil {
+			r.Reporter.Errorf("Failed to get groups for cluster '%s': %v", clusterKey, err)
+			os.Exit(1)
 		}
+		groupIDs = ocm.GroupIDs(clusterGroups)
 	}
 
-	// Load dedicated-admins for this cluster
-	dedicatedAdmins, err := r.OCMClient.GetUsers(cluster.ID(), "dedicated-admins")
-	if err != nil {
-		r.Reporter.Errorf("Failed to get dedicated-admins for cluster '%s': %v", clusterKey, err)
-		os.Exit(1)
+	r.Reporter.Debugf("Loading users for cluster '%s'", clusterKey)
+	longestUserId := 0.0
+	groups := make(map[string][]string)
+	for _, groupID := range groupIDs {
+		users, err := r.OCMClient.GetUsers(cluster.ID(), groupID)
+		if err != nil {
+			r.Reporter.Errorf("Failed to get %s for cluster '%s': %v", groupID, clusterKey, err)
+			os.Exit(1)
+		}
+		for _, user := range users {
+			// Skip the cluster-admin user created with 'rosa create admin'
+			if user.ID() == idp.ClusterAdminUsername {
+				continue
+			}
+			longestUserId = math.Max(longestUserId, float64(len(user.ID())))
+			groups[user.ID()] = append(groups[user.ID()], groupID)
+		}
 	}
 
-	if len(clusterAdmins) == 0 && len(dedicatedAdmins) == 0 {
+	if len(groups) == 0 {
 		r.Reporter.Warnf("There are no users configured for cluster '%s'", clusterKey)
 		os.Exit(1)
 	}
 
-	longestUserId := 0.0
-	groups := make(map[string][]string)
-	for _, user := range clusterAdmins {
-		longestUserId = math.Max(longestUserId, float64(len(user.ID())))
-		groups[user.ID()] = []string{"cluster-admins"}
-	}
-	for _, user := range dedicatedAdmins {
-		longestUserId = math.Max(longestUserId, float64(len(user.ID())))
-		if _, ok := groups[user.ID()]; ok {
-			groups[user.ID()] = []string{"cluster-admins", "dedicated-admins"}
-		} else {
-			groups[user.ID()] = []string{"dedicated-admins"}
-		}
+	userIDs := make([]string, 0, len(groups))
+	for userID := range groups {
+		userIDs = append(userIDs, userID)
 	}
+	sort.Strings(userIDs)
 
 	// Create the writer that will be used to print the tabulated results:
 	writer := tabwriter.NewWriter(os.Stdout, int(longestUserId)+2, 4, 2, ' ', 0)
 	fmt.Fprintf(writer, "ID\tGROUPS\t\n")
 
-	for u, r := range groups {
-		fmt.Fprintf(writer, "%s\t%s\t\n", u, strings.Join(r, ", "))
+	for _, userID := range userIDs {
+		fmt.Fprintf(writer, "%s\t%s\t\n", userID, strings.Join(groups[userID], ", "))
 		writer.Flush()
 	}
 }
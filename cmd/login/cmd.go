@@ -17,6 +17,7 @@ limitations under the License.
 package login
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strings"
@@ -29,6 +30,7 @@ import (
 	"github.com/openshift/rosa/pkg/arguments"
 	"github.com/openshift/rosa/pkg/config"
 	"github.com/openshift/rosa/pkg/fedramp"
+	"github.com/openshift/rosa/pkg/helper/devicecode"
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/ocm"
 	rprtr "github.com/openshift/rosa/pkg/reporter"
@@ -48,13 +50,16 @@ var args struct {
 	env          string
 	token        string
 	insecure     bool
+	deviceCode   bool
 }
 
 var Cmd = &cobra.Command{
 	Use:   "login",
 	Short: "Log in to your Red Hat account",
 	Long: fmt.Sprintf("Log in to your Red Hat account, saving the credentials to the configuration file.\n"+
-		"The supported mechanism is by using a token, which can be obtained at: %s\n\n"+
+		"The supported mechanisms are a token, which can be obtained at: %s, and the client "+
+		"identifier and secret of a service account. On hosts without a browser the device code "+
+		"flow can be used instead.\n\n"+
 		"The application looks for the token in the following order, stopping when it finds it:\n"+
 		"\t1. Command-line flags\n"+
 		"\t2. Environment variable (ROSA_TOKEN)\n"+
@@ -62,7 +67,19 @@ var Cmd = &cobra.Command{
 		"\t4. Configuration file\n"+
 		"\t5. Command-line prompt\n", uiTokenPage),
 	Example: fmt.Sprintf(`  # Login to the OpenShift API with an existing token generated from %s
-  rosa login --token=$OFFLINE_ACCESS_TOKEN`, uiTokenPage),
+  rosa login --token=$OFFLINE_ACCESS_TOKEN
+
+  # Login with the credentials of a service account, for example for automation
+  rosa login --client-id=$CLIENT_ID --client-secret=$CLIENT_SECRET
+
+  # Login from a host without a browser by approving the login from another device
+  rosa login --use-device-code
+
+  # Login to the staging environment in a separate configuration profile
+  rosa login --config-profile staging --env staging --profile aws-staging --token=$STAGING_TOKEN
+
+  # Use the staging profile for a single command
+  rosa --config-profile staging list clusters`, uiTokenPage),
 	Run: run,
 }
 
@@ -90,7 +107,8 @@ func init() {
 		&args.clientSecret,
 		"client-secret",
 		"",
-		"OpenID client secret.",
+		"OpenID client secret. When used with '--client-id' the client credentials grant of the "+
+			"service account is used to log in instead of a token.",
 	)
 	flags.StringSliceVar(
 		&args.scopes,
@@ -121,6 +139,14 @@ func init() {
 		"Enables insecure communication with the server. This disables verification of TLS "+
 			"certificates and host names.",
 	)
+	flags.BoolVar(
+		&args.deviceCode,
+		"use-device-code",
+		false,
+		"Log in by entering a code in a browser on another device, instead of using a token. "+
+			"Useful on hosts without a browser.",
+	)
+	arguments.AddProfileFlag(flags)
 	arguments.AddRegionFlag(flags)
 	fedramp.AddFlag(flags)
 }
@@ -147,6 +173,19 @@ func run(cmd *cobra.Command, argv []string) {
 
 	token := args.token
 
+	// Service accounts log in with the client credentials grant, which doesn't need a token:
+	useClientCredentials := args.clientSecret != ""
+	if useClientCredentials {
+		if args.clientID == "" {
+			r.Reporter.Errorf("Option '--client-id' is mandatory when using '--client-secret'")
+			os.Exit(1)
+		}
+		if token != "" {
+			r.Reporter.Errorf("Options '--token' and '--client-secret' are mutually exclusive")
+			os.Exit(1)
+		}
+	}
+
 	// Determine if we should be using the FedRAMP environment:
 	if fedramp.HasFlag(cmd) ||
 		(cfg.FedRAMP && token == "") ||
@@ -162,7 +201,18 @@ func run(cmd *cobra.Command, argv []string) {
 		fedramp.Disable()
 	}
 
-	haveReqs := token != ""
+	if args.deviceCode {
+		if token != "" || useClientCredentials {
+			r.Reporter.Errorf("Option '--use-device-code' can't be used with '--token' or '--client-secret'")
+			os.Exit(1)
+		}
+		if fedramp.Enabled() {
+			r.Reporter.Errorf("Option '--use-device-code' isn't supported in the FedRAMP environment")
+			os.Exit(1)
+		}
+	}
+
+	haveReqs := token != "" || useClientCredentials || args.deviceCode
 
 	// Verify environment variables:
 	if !haveReqs && !reAttempt && !fedramp.Enabled() {
@@ -231,9 +281,11 @@ func run(cmd *cobra.Command, argv []string) {
 		if !ok {
 			tokenURL = args.tokenURL
 		}
-		clientID, ok = fedramp.ClientIDs[env]
-		if !ok {
-			clientID = args.clientID
+		if !useClientCredentials {
+			clientID, ok = fedramp.ClientIDs[env]
+			if !ok {
+				clientID = args.clientID
+			}
 		}
 	}
 
@@ -245,6 +297,41 @@ func run(cmd *cobra.Command, argv []string) {
 	cfg.URL = gatewayURL
 	cfg.Insecure = args.insecure
 	cfg.FedRAMP = fedramp.Enabled()
+	cfg.AuthMethod = config.AuthMethodToken
+	if useClientCredentials {
+		// Tokens of previous logins would otherwise be used instead of the client credentials:
+		cfg.AuthMethod = config.AuthMethodClientCredentials
+		cfg.AccessToken = ""
+		cfg.RefreshToken = ""
+	}
+
+	// Named profiles also remember the AWS settings, so that switching profiles is enough to
+	// switch between environments:
+	if config.Profile() != "" {
+		if cmd.Flags().Changed("profile") {
+			cfg.AWSProfile = arguments.GetProfile()
+		}
+		if cmd.Flags().Changed("region") {
+			cfg.AWSRegion = arguments.GetRegion()
+		}
+	}
+
+	if args.deviceCode {
+		if fedramp.Enabled() {
+			r.Reporter.Errorf("Logging in with a device code isn't supported for FedRAMP, as its SSO " +
+				"doesn't implement the device authorization grant. Use the '--token' option instead")
+			os.Exit(1)
+		}
+		cfg.AuthMethod = config.AuthMethodDeviceCode
+		if args.clientID == "" {
+			cfg.ClientID = devicecode.DefaultClientID
+		}
+		cfg.AccessToken, cfg.RefreshToken, err = loginWithDeviceCode(cfg)
+		if err != nil {
+			r.Reporter.Errorf("%v", err)
+			os.Exit(1)
+		}
+	}
 
 	if token != "" {
 		if config.IsEncryptedToken(token) {
@@ -310,7 +397,7 @@ func run(cmd *cobra.Command, argv []string) {
 		os.Exit(1)
 	}
 
-	username, err := cfg.GetData("username")
+	username, err := cfg.Username()
 	if err != nil {
 		r.Reporter.Errorf("Failed to get username: %v", err)
 		os.Exit(1)
@@ -324,6 +411,30 @@ func run(cmd *cobra.Command, argv []string) {
 	})
 }
 
+// loginWithDeviceCode asks the user to approve the login from another device, and returns the
+// tokens issued once it is approved.
+func loginWithDeviceCode(cfg *config.Config) (access, refresh string, err error) {
+	flow := &devicecode.Flow{
+		TokenURL: cfg.TokenURL,
+		ClientID: cfg.ClientID,
+		Scopes:   cfg.Scopes,
+		Client:   ocm.NewHTTPClient(cfg.Insecure),
+	}
+	ctx := context.Background()
+	authorization, err := flow.Authorize(ctx)
+	if err != nil {
+		return
+	}
+	verificationURI := authorization.VerificationURIComplete
+	if verificationURI == "" {
+		verificationURI = authorization.VerificationURI
+	}
+	fmt.Printf("To login to your Red Hat account, open %s in a browser and enter the code %s\n",
+		verificationURI, authorization.UserCode)
+	fmt.Println("Waiting for the login to be approved...")
+	return flow.Wait(ctx, authorization)
+}
+
 func reattemptLogin(cmd *cobra.Command, argv []string) {
 	logout.Cmd.Run(cmd, argv)
 	reAttempt = true
@@ -352,7 +463,8 @@ func tokenType(jwtToken *jwt.Token) (typ string, err error) {
 }
 
 func Call(cmd *cobra.Command, argv []string, reporter *rprtr.Object) error {
-	loginFlags := []string{"token-url", "client-id", "client-secret", "scope", "env", "token", "insecure"}
+	loginFlags := []string{"token-url", "client-id", "client-secret", "scope", "env", "token", "insecure",
+		"use-device-code"}
 	hasLoginFlags := false
 	// Check if the user set login flags
 	for _, loginFlag := range loginFlags {
@@ -382,7 +494,7 @@ func Call(cmd *cobra.Command, argv []string, reporter *rprtr.Object) error {
 	}
 
 	if isLoggedIn {
-		username, err := cfg.GetData("username")
+		username, err := cfg.Username()
 		if err != nil {
 			return fmt.Errorf("Failed to get username: %v", err)
 		}
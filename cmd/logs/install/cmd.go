@@ -17,7 +17,9 @@ limitations under the License.
 package install
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 	"time"
@@ -27,13 +29,19 @@ import (
 	"github.com/spf13/cobra"
 	errors "github.com/zgalor/weberr"
 
+	"github.com/openshift/rosa/pkg/helper/logs"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
+const pollInterval = 15 * time.Second
+
 var args struct {
-	tail  int
-	watch bool
+	tail       int
+	watch      bool
+	since      string
+	grep       string
+	outputFile string
 }
 
 var Cmd = &cobra.Command{
@@ -65,7 +73,31 @@ func init() {
 		"watch",
 		"w",
 		false,
-		"After getting the logs, watch for changes.",
+		"After getting the logs, watch for changes until the cluster is ready or fails to install. "+
+			"If the installation fails, the install logs and the errors of the inflight checks are saved "+
+			"to a file in the current directory.",
+	)
+
+	flags.StringVar(
+		&args.since,
+		"since",
+		"",
+		"Only show log lines written since the given time, either a duration like '30m' or an "+
+			"RFC 3339 timestamp like '2006-01-02T15:04:05Z'.",
+	)
+
+	flags.StringVar(
+		&args.grep,
+		"grep",
+		"",
+		"Only show log lines matching the given regular expression.",
+	)
+
+	flags.StringVar(
+		&args.outputFile,
+		"output-file",
+		"",
+		"Write the logs to the given file instead of the standard output.",
 	)
 }
 
@@ -86,6 +118,21 @@ func run(cmd *cobra.Command, argv []string) {
 	}
 	clusterKey := r.GetClusterKey()
 
+	logFilter, err = logs.NewFilter(args.since, args.grep, time.Now())
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+	if args.outputFile != "" {
+		file, err := os.Create(args.outputFile)
+		if err != nil {
+			r.Reporter.Errorf("Failed to create log file '%s': %v", args.outputFile, err)
+			os.Exit(1)
+		}
+		defer file.Close()
+		logOutput = file
+	}
+
 	cluster := r.FetchCluster()
 	if cluster.State() == cmv1.ClusterStateReady {
 		r.Reporter.Infof("Cluster '%s' has been successfully installed", clusterKey)
@@ -116,7 +163,7 @@ func run(cmd *cobra.Command, argv []string) {
 	}
 
 	// Get logs from Hive
-	logs, err := r.OCMClient.GetInstallLogs(cluster.ID(), args.tail)
+	clusterLogs, err := r.OCMClient.GetInstallLogs(cluster.ID(), args.tail)
 	if err != nil {
 		if errors.GetType(err) == errors.NotFound {
 			r.Reporter.Infof(pendingMessage)
@@ -125,7 +172,7 @@ func run(cmd *cobra.Command, argv []string) {
 			os.Exit(1)
 		}
 	}
-	printLog(logs, nil)
+	printLog(clusterLogs, nil)
 
 	if watch {
 		if cluster.State() == cmv1.ClusterStateReady {
@@ -141,37 +188,152 @@ func run(cmd *cobra.Command, argv []string) {
 			spin.Start()
 		}
 
-		// Poll for changing logs:
-		response, err := r.OCMClient.PollInstallLogs(cluster.ID(), func(logResponse *cmv1.LogGetResponse) bool {
-			state, _ := r.OCMClient.GetClusterState(cluster.ID())
-			if state == cmv1.ClusterStateError {
-				r.Reporter.Errorf("There was an error installing cluster '%s'", clusterKey)
-				os.Exit(1)
+		// Report every state transition and exit as soon as the installation finishes:
+		lastState := cluster.State()
+		checkState := func(state cmv1.ClusterState) {
+			changed := state != "" && state != lastState
+			if changed {
+				if spin != nil {
+					spin.Stop()
+				}
+				r.Reporter.Infof("Cluster '%s' is now in '%s' state", clusterKey, state)
+				lastState = state
 			}
-			if state == cmv1.ClusterStateReady {
+			switch state {
+			case cmv1.ClusterStateError:
+				if spin != nil {
+					spin.Stop()
+				}
+				reportFile, err := captureFailure(r, cluster.ID(), time.Now().UTC())
+				if err != nil {
+					r.Reporter.Warnf("Failed to save the install logs of cluster '%s': %v", clusterKey, err)
+				} else {
+					r.Reporter.Infof("Install logs and inflight check errors saved to '%s'", reportFile)
+				}
+				r.Reporter.Errorf("There was an error installing cluster '%s'. "+
+					"Run 'rosa describe cluster -c %s' for more details", clusterKey, clusterKey)
+				os.Exit(1)
+			case cmv1.ClusterStateUninstalling:
+				r.Reporter.Errorf("Cluster '%s' is being uninstalled", clusterKey)
+				os.Exit(1)
+			case cmv1.ClusterStateReady:
 				r.Reporter.Infof("Cluster '%s' is now ready", clusterKey)
 				os.Exit(0)
 			}
-			printLog(logResponse.Body(), spin)
-			return false
-		})
-		if err != nil {
+			// The installation continues, so show the spinner again while waiting for more logs:
+			if changed && spin != nil {
+				spin.Restart()
+			}
+		}
+
+		for {
+			// Poll for changing logs:
+			response, err := r.OCMClient.PollInstallLogs(cluster.ID(), func(logResponse *cmv1.LogGetResponse) bool {
+				state, _ := r.OCMClient.GetClusterState(cluster.ID())
+				checkState(state)
+				printLog(logResponse.Body(), spin)
+				return false
+			})
+			if err == nil {
+				printLog(response, spin)
+				break
+			}
 			if errors.GetType(err) != errors.NotFound {
 				r.Reporter.Errorf(fmt.Sprintf("Failed to watch logs for cluster '%s': %v", clusterKey, err))
 				os.Exit(1)
 			}
+			// Logs are not available until the installation begins, so keep waiting for it:
+			state, _ := r.OCMClient.GetClusterState(cluster.ID())
+			checkState(state)
+			time.Sleep(pollInterval)
 		}
-		printLog(response, spin)
 	}
 }
 
+// captureFailure saves the install logs, the provision error and the failed inflight checks of the
+// cluster to a file in the current directory, so that they are available even after the cluster is
+// removed, and returns the name of the file.
+func captureFailure(r *rosa.Runtime, clusterID string, now time.Time) (string, error) {
+	cluster, _, err := r.OCMClient.FindClusterByID(clusterID)
+	if err != nil {
+		return "", err
+	}
+	// The logs don't exist if the installation failed before starting, for example in the
+	// inflight checks:
+	clusterLogs, err := r.OCMClient.GetInstallLogs(clusterID, failureLogsTail)
+	if err != nil && errors.GetType(err) != errors.NotFound {
+		return "", err
+	}
+	checks, err := r.OCMClient.GetInflightChecks(clusterID)
+	if err != nil {
+		return "", err
+	}
+
+	name := fmt.Sprintf("rosa-install-failure-%s-%s.log", clusterID, now.Format("20060102150405"))
+	file, err := os.OpenFile(name, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	err = writeFailureReport(file, cluster, clusterLogs, checks, now)
+	if err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+// writeFailureReport writes the information collected about a failed installation.
+func writeFailureReport(w io.Writer, cluster *cmv1.Cluster, clusterLogs *cmv1.Log,
+	checks []*cmv1.InflightCheck, now time.Time) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Cluster:         %s (%s)\n", cluster.Name(), cluster.ID())
+	fmt.Fprintf(&b, "State:           %s\n", cluster.State())
+	if cluster.Status().ProvisionErrorMessage() != "" {
+		fmt.Fprintf(&b, "Provision error: %s - %s\n",
+			cluster.Status().ProvisionErrorCode(), cluster.Status().ProvisionErrorMessage())
+	}
+	fmt.Fprintf(&b, "Captured at:     %s\n", now.Format(time.RFC3339))
+
+	b.WriteString("\n== Failed inflight checks ==\n\n")
+	failed := 0
+	for _, check := range checks {
+		if check.State() != cmv1.InflightCheckStateFailed {
+			continue
+		}
+		failed++
+		fmt.Fprintf(&b, "%s:\n", check.Name())
+		if check.Details() != nil {
+			details, err := json.MarshalIndent(check.Details(), "  ", "  ")
+			if err == nil {
+				fmt.Fprintf(&b, "  %s\n", details)
+			}
+		}
+	}
+	if failed == 0 {
+		b.WriteString("None\n")
+	}
+
+	b.WriteString("\n== Install logs ==\n\n")
+	b.WriteString(clusterLogs.Content())
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// failureLogsTail is the number of lines of the install logs saved when the installation fails.
+const failureLogsTail = 10000
+
 var lastLine string
 
+var logFilter *logs.Filter
+
+var logOutput io.Writer = os.Stdout
+
 // Print next log lines
 func printLog(logs *cmv1.Log, spin *spinner.Spinner) {
-	lines := findNextLines(logs)
+	lines := logFilter.Apply(findNextLines(logs))
 	if lines != "" {
-		fmt.Printf("%s\n", lines)
+		fmt.Fprintf(logOutput, "%s\n", lines)
 		if spin != nil {
 			spin.Stop()
 		}
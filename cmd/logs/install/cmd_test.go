@@ -0,0 +1,49 @@
+package install
+
+import (
+	"bytes"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+var _ = Describe("Failure report", func() {
+	now := time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	It("includes the provision error, the failed checks and the logs", func() {
+		cluster, err := cmv1.NewCluster().ID("123").Name("mycluster").State(cmv1.ClusterStateError).
+			Status(cmv1.NewClusterStatus().ProvisionErrorCode("OCM3055").
+				ProvisionErrorMessage("Egress blocked")).Build()
+		Expect(err).ToNot(HaveOccurred())
+		logs, err := cmv1.NewLog().Content("level=info msg=starting\nlevel=error msg=failed\n").Build()
+		Expect(err).ToNot(HaveOccurred())
+		egress, err := cmv1.NewInflightCheck().Name("egress").State(cmv1.InflightCheckStateFailed).
+			Details(map[string]interface{}{"url": "quay.io"}).Build()
+		Expect(err).ToNot(HaveOccurred())
+		dns, err := cmv1.NewInflightCheck().Name("dns").State(cmv1.InflightCheckStatePassed).Build()
+		Expect(err).ToNot(HaveOccurred())
+		checks := []*cmv1.InflightCheck{egress, dns}
+
+		var b bytes.Buffer
+		Expect(writeFailureReport(&b, cluster, logs, checks, now)).To(Succeed())
+		report := b.String()
+		Expect(report).To(ContainSubstring("Cluster:         mycluster (123)"))
+		Expect(report).To(ContainSubstring("Provision error: OCM3055 - Egress blocked"))
+		Expect(report).To(ContainSubstring("Captured at:     2023-05-01T10:00:00Z"))
+		Expect(report).To(ContainSubstring("egress:\n  {\n    \"url\": \"quay.io\"\n  }"))
+		Expect(report).ToNot(ContainSubstring("dns:"))
+		Expect(report).To(HaveSuffix("level=error msg=failed\n"))
+	})
+
+	It("works without logs nor failed checks", func() {
+		cluster, err := cmv1.NewCluster().ID("123").Name("mycluster").Build()
+		Expect(err).ToNot(HaveOccurred())
+
+		var b bytes.Buffer
+		Expect(writeFailureReport(&b, cluster, nil, nil, now)).To(Succeed())
+		Expect(b.String()).To(ContainSubstring("== Failed inflight checks ==\n\nNone\n"))
+		Expect(b.String()).ToNot(ContainSubstring("Provision error"))
+	})
+})
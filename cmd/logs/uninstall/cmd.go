@@ -18,6 +18,7 @@ package uninstall
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"strings"
 	"time"
@@ -27,13 +28,17 @@ import (
 	"github.com/spf13/cobra"
 	errors "github.com/zgalor/weberr"
 
+	"github.com/openshift/rosa/pkg/helper/logs"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
 var args struct {
-	tail  int
-	watch bool
+	tail       int
+	watch      bool
+	since      string
+	grep       string
+	outputFile string
 }
 
 var Cmd = &cobra.Command{
@@ -67,6 +72,28 @@ func init() {
 		false,
 		"After getting the logs, watch for changes.",
 	)
+
+	flags.StringVar(
+		&args.since,
+		"since",
+		"",
+		"Only show log lines written since the given time, either a duration like '30m' or an "+
+			"RFC 3339 timestamp like '2006-01-02T15:04:05Z'.",
+	)
+
+	flags.StringVar(
+		&args.grep,
+		"grep",
+		"",
+		"Only show log lines matching the given regular expression.",
+	)
+
+	flags.StringVar(
+		&args.outputFile,
+		"output-file",
+		"",
+		"Write the logs to the given file instead of the standard output.",
+	)
 }
 
 func run(cmd *cobra.Command, argv []string) {
@@ -86,6 +113,21 @@ func run(cmd *cobra.Command, argv []string) {
 	}
 	clusterKey := r.GetClusterKey()
 
+	logFilter, err = logs.NewFilter(args.since, args.grep, time.Now())
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+	if args.outputFile != "" {
+		file, err := os.Create(args.outputFile)
+		if err != nil {
+			r.Reporter.Errorf("Failed to create log file '%s': %v", args.outputFile, err)
+			os.Exit(1)
+		}
+		defer file.Close()
+		logOutput = file
+	}
+
 	cluster := r.FetchCluster()
 	if cluster.State() != cmv1.ClusterStateUninstalling && !watch {
 		r.Reporter.Warnf("Cluster '%s' is not currently uninstalling", clusterKey)
@@ -102,7 +144,7 @@ func run(cmd *cobra.Command, argv []string) {
 	}
 
 	// Get logs from Hive
-	logs, err := r.OCMClient.GetUninstallLogs(cluster.ID(), args.tail)
+	clusterLogs, err := r.OCMClient.GetUninstallLogs(cluster.ID(), args.tail)
 	if err != nil {
 		if errors.GetType(err) == errors.NotFound {
 			r.Reporter.Warnf("Logs for cluster '%s' are not available", clusterKey)
@@ -111,7 +153,7 @@ func run(cmd *cobra.Command, argv []string) {
 			os.Exit(1)
 		}
 	}
-	printLog(logs, nil)
+	printLog(clusterLogs, nil)
 
 	if watch {
 		var spin *spinner.Spinner
@@ -144,11 +186,15 @@ func run(cmd *cobra.Command, argv []string) {
 
 var lastLine string
 
+var logFilter *logs.Filter
+
+var logOutput io.Writer = os.Stdout
+
 // Print next log lines
 func printLog(logs *cmv1.Log, spin *spinner.Spinner) {
-	lines := findNextLines(logs)
+	lines := logFilter.Apply(findNextLines(logs))
 	if lines != "" {
-		fmt.Printf("%s\n", lines)
+		fmt.Fprintf(logOutput, "%s\n", lines)
 		if spin != nil {
 			spin.Stop()
 		}
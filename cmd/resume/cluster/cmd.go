@@ -18,6 +18,7 @@ package cluster
 
 import (
 	"os"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -32,12 +33,30 @@ var Cmd = &cobra.Command{
 	Short: "Resume cluster",
 	Long:  "Resume cluster.",
 	Example: `  # Resume the cluster
-  rosa resume cluster -c mycluster`,
+  rosa resume cluster -c mycluster
+
+  # Resume the cluster and wait until it is ready
+  rosa resume cluster -c mycluster --wait`,
 	Run: run,
 }
 
+var args struct {
+	wait bool
+}
+
+const (
+	waitInterval = 30 * time.Second
+	waitTimeout  = 60 * time.Minute
+)
+
 func init() {
 	ocm.AddClusterFlag(Cmd)
+	Cmd.Flags().BoolVar(
+		&args.wait,
+		"wait",
+		false,
+		"Wait until the cluster is ready.",
+	)
 }
 
 func run(cmd *cobra.Command, _ []string) {
@@ -47,19 +66,34 @@ func run(cmd *cobra.Command, _ []string) {
 	clusterKey := r.GetClusterKey()
 	cluster := r.FetchCluster()
 
-	if cluster.State() != cmv1.ClusterStateHibernating {
-		r.Reporter.Errorf("Resuming a cluster from hibernation is only supported for clusters in "+
-			"'Hibernating' state. Cluster '%s' is in '%s' state",
-			clusterKey, cluster.State())
+	err := ocm.ValidateHibernation(cluster, cmv1.ClusterStateHibernating)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+	err = r.OCMClient.ValidateHibernationEntitlement()
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
 		os.Exit(1)
 	}
 	if !confirm.Confirm("resume cluster %s", clusterKey) {
 		os.Exit(1)
 	}
-	err := r.OCMClient.ResumeCluster(cluster.ID())
+	err = r.OCMClient.ResumeCluster(cluster.ID())
 	if err != nil {
 		r.Reporter.Errorf("Failed to update cluster: %v", err)
 		os.Exit(1)
 	}
-	r.Reporter.Infof("Cluster '%s' is resuming.", clusterKey)
+
+	if !args.wait {
+		r.Reporter.Infof("Cluster '%s' is resuming.", clusterKey)
+		return
+	}
+	r.Reporter.Infof("Waiting for cluster '%s' to resume", clusterKey)
+	err = r.OCMClient.WaitForClusterState(cluster.ID(), cmv1.ClusterStateReady, waitInterval, waitTimeout)
+	if err != nil {
+		r.Reporter.Errorf("Failed to wait for cluster '%s' to resume: %v", clusterKey, err)
+		os.Exit(1)
+	}
+	r.Reporter.Infof("Cluster '%s' is ready.", clusterKey)
 }
@@ -24,10 +24,9 @@ import (
 )
 
 var Cmd = &cobra.Command{
-	Use:    "resume",
-	Short:  "Resume cluster",
-	Long:   "Resume Hibernate cluster",
-	Hidden: true,
+	Use:   "resume",
+	Short: "Resume cluster",
+	Long:  "Resume Hibernate cluster",
 }
 
 func init() {
@@ -0,0 +1,76 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inflightchecks
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var Cmd = &cobra.Command{
+	Use:     "inflight-checks",
+	Aliases: []string{"inflightchecks", "inflight-check"},
+	Short:   "Run the failed inflight checks of a cluster again",
+	Long: "Run the inflight checks of a cluster again after fixing the problems they found, for " +
+		"example after opening the firewall to the required egress endpoints. The installation " +
+		"continues once the checks pass.",
+	Example: `  # Run the inflight checks of cluster "mycluster" again
+  rosa retry inflight-checks -c mycluster`,
+	Run:  run,
+	Args: cobra.NoArgs,
+}
+
+func init() {
+	ocm.AddClusterFlag(Cmd)
+}
+
+func run(_ *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithOCM()
+	defer r.Cleanup()
+
+	clusterKey := r.GetClusterKey()
+	cluster := r.FetchCluster()
+
+	checks, err := r.OCMClient.GetInflightChecks(cluster.ID())
+	if err != nil {
+		r.Reporter.Errorf("Failed to get inflight checks for cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+	failed := ocm.FailedInflightChecks(checks)
+	if len(failed) == 0 {
+		r.Reporter.Infof("Cluster '%s' has no failed inflight checks", clusterKey)
+		return
+	}
+	for _, check := range failed {
+		r.Reporter.Debugf("Inflight check '%s' failed with details: %v", check.Name(), check.Details())
+	}
+
+	checks, err = r.OCMClient.RerunInflightChecks(cluster.ID())
+	if err != nil {
+		r.Reporter.Errorf("Failed to run the inflight checks of cluster '%s' again: %v", clusterKey, err)
+		os.Exit(1)
+	}
+	for _, check := range checks {
+		r.Reporter.Infof("Inflight check '%s' is %s", check.Name(), check.State())
+	}
+	r.Reporter.Infof("The inflight checks of cluster '%s' are running again. "+
+		"To see the results run 'rosa describe cluster -c %s'", clusterKey, clusterKey)
+}
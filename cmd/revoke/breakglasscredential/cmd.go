@@ -0,0 +1,70 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package breakglasscredential
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var Cmd = &cobra.Command{
+	Use:     "break-glass-credentials",
+	Aliases: []string{"breakglasscredentials", "break-glass-credential", "breakglasscredential"},
+	Short:   "Revoke the break glass credentials of a cluster",
+	Long: "Revoke all the break glass credentials of a Hosted Control Plane cluster that uses external " +
+		"authentication. The service doesn't support revoking a single credential.",
+	Example: `  # Revoke the break glass credentials of cluster 'mycluster'
+  rosa revoke break-glass-credentials -c mycluster`,
+	Run:  run,
+	Args: cobra.NoArgs,
+}
+
+func init() {
+	ocm.AddClusterFlag(Cmd)
+}
+
+func run(_ *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS().WithOCM()
+	defer r.Cleanup()
+
+	clusterKey := r.GetClusterKey()
+
+	cluster := r.FetchCluster()
+	err := r.OCMClient.ValidateExternalAuthCluster(cluster)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+
+	if !confirm.Confirm("revoke all the break glass credentials of cluster %s", clusterKey) {
+		os.Exit(0)
+	}
+
+	r.Reporter.Debugf("Revoking break glass credentials of cluster '%s'", clusterKey)
+	err = r.OCMClient.RevokeBreakGlassCredentials(cluster.ID())
+	if err != nil {
+		r.Reporter.Errorf("Failed to revoke break glass credentials of cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+	r.Reporter.Infof("Break glass credentials of cluster '%s' are being revoked. "+
+		"Run 'rosa list break-glass-credentials -c %s' to check their status.", clusterKey, clusterKey)
+}
@@ -19,6 +19,7 @@ package revoke
 import (
 	"github.com/spf13/cobra"
 
+	"github.com/openshift/rosa/cmd/revoke/breakglasscredential"
 	"github.com/openshift/rosa/cmd/revoke/user"
 	"github.com/openshift/rosa/pkg/arguments"
 	"github.com/openshift/rosa/pkg/interactive/confirm"
@@ -32,6 +33,7 @@ var Cmd = &cobra.Command{
 
 func init() {
 	Cmd.AddCommand(user.Cmd)
+	Cmd.AddCommand(breakglasscredential.Cmd)
 
 	flags := Cmd.PersistentFlags()
 	arguments.AddProfileFlag(flags)
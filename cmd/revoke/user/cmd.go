@@ -54,9 +54,6 @@ var Cmd = &cobra.Command{
 	},
 }
 
-var validRoles = []string{"cluster-admins", "dedicated-admins"}
-var validRolesAliases = []string{"cluster-admin", "dedicated-admin"}
-
 func init() {
 	flags := Cmd.Flags()
 
@@ -91,26 +88,15 @@ func run(_ *cobra.Command, argv []string) {
 		os.Exit(1)
 	}
 
-	role := argv[0]
-	// Allow role aliases
-	for _, validAlias := range validRolesAliases {
-		if role == validAlias {
-			role = fmt.Sprintf("%ss", role)
-		}
-	}
-	isRoleValid := false
-	// Determine if role is valid
-	for _, validRole := range validRoles {
-		if role == validRole {
-			isRoleValid = true
-		}
-	}
-	if !isRoleValid {
-		r.Reporter.Errorf("Expected at least one of %s", validRoles)
+	cluster := r.FetchCluster()
+
+	r.Reporter.Debugf("Loading groups of cluster '%s'", clusterKey)
+	role, err := r.OCMClient.GetGroupID(cluster.ID(), argv[0])
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
 		os.Exit(1)
 	}
 
-	cluster := r.FetchCluster()
 	// Try to find the user:
 	r.Reporter.Debugf("Loading '%s' users for cluster '%s'", role, clusterKey)
 	user, err := r.OCMClient.GetUser(cluster.ID(), role, username)
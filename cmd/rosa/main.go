@@ -17,19 +17,26 @@ limitations under the License.
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"os/exec"
 	"strings"
 
 	"github.com/spf13/cobra"
 
+	"github.com/openshift/rosa/cmd/attach"
 	"github.com/openshift/rosa/cmd/completion"
+	configcmd "github.com/openshift/rosa/cmd/config"
 	"github.com/openshift/rosa/cmd/create"
 	"github.com/openshift/rosa/cmd/describe"
+	"github.com/openshift/rosa/cmd/detach"
+	"github.com/openshift/rosa/cmd/diagnose"
 	"github.com/openshift/rosa/cmd/dlt"
 	"github.com/openshift/rosa/cmd/docs"
 	"github.com/openshift/rosa/cmd/download"
 	"github.com/openshift/rosa/cmd/edit"
+	"github.com/openshift/rosa/cmd/estimate"
 	"github.com/openshift/rosa/cmd/grant"
 	"github.com/openshift/rosa/cmd/hibernate"
 	"github.com/openshift/rosa/cmd/initialize"
@@ -40,7 +47,9 @@ import (
 	"github.com/openshift/rosa/cmd/logout"
 	"github.com/openshift/rosa/cmd/logs"
 	"github.com/openshift/rosa/cmd/resume"
+	retrycmd "github.com/openshift/rosa/cmd/retry"
 	"github.com/openshift/rosa/cmd/revoke"
+	"github.com/openshift/rosa/cmd/token"
 	"github.com/openshift/rosa/cmd/uninstall"
 	"github.com/openshift/rosa/cmd/unlink"
 	"github.com/openshift/rosa/cmd/upgrade"
@@ -48,7 +57,14 @@ import (
 	"github.com/openshift/rosa/cmd/version"
 	"github.com/openshift/rosa/cmd/whoami"
 	"github.com/openshift/rosa/pkg/arguments"
+	"github.com/openshift/rosa/pkg/aws/profile"
 	"github.com/openshift/rosa/pkg/color"
+	"github.com/openshift/rosa/pkg/config"
+	"github.com/openshift/rosa/pkg/logging"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/plugin"
+	"github.com/openshift/rosa/pkg/reporter"
+	"github.com/openshift/rosa/pkg/retry"
 )
 
 var root = &cobra.Command{
@@ -56,7 +72,47 @@ var root = &cobra.Command{
 	Short: "Command line tool for ROSA.",
 	Long: "Command line tool for Red Hat OpenShift Service on AWS.\n" +
 		"For further documentation visit " +
-		"https://access.redhat.com/documentation/en-us/red_hat_openshift_service_on_aws\n",
+		"https://access.redhat.com/documentation/en-us/red_hat_openshift_service_on_aws\n\n" +
+		"Executables named 'rosa-<name>' found in the PATH can be run as 'rosa <name>' plugins.\n",
+	PersistentPreRun: func(cmd *cobra.Command, _ []string) {
+		err := logging.Validate()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+		logging.SetField(logging.CommandField, cmd.CommandPath())
+		err = logging.OpenTrace()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+		// The configuration is loaded only once, and shared by all the defaults it provides. A corrupt
+		// configuration file shouldn't prevent fixing it with 'rosa login' or 'rosa config set', so
+		// only the failures to use an explicitly selected profile are fatal:
+		cfg, err := config.Load()
+		if err != nil {
+			if config.Profile() != "" {
+				fmt.Fprintf(os.Stderr, "Failed to load configuration profile '%s': %v\n", config.Profile(), err)
+				os.Exit(1)
+			}
+			reporter.CreateReporterOrExit().Warnf("Using the default settings, as the configuration "+
+				"can't be loaded: %v", err)
+		} else {
+			config.ApplyProfile(cfg)
+			err = config.ApplyOutputFormat(cfg, cmd.Flags())
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to apply default output format: %v\n", err)
+				os.Exit(1)
+			}
+			err = config.ApplyRetryPolicy(cfg)
+			if err != nil {
+				reporter.CreateReporterOrExit().Warnf("Using the default retry policy, as the one of the "+
+					"configuration can't be used: %v", err)
+			}
+		}
+		// Wrappers asking for JSON output also need to be able to parse the failures:
+		reporter.SetJSONErrors(output.Output() == "json")
+	},
 }
 
 func init() {
@@ -64,15 +120,24 @@ func init() {
 	fs := root.PersistentFlags()
 	color.AddFlag(root)
 	arguments.AddDebugFlag(fs)
+	logging.AddFlags(fs)
+	logging.AddTraceFlag(fs)
+	config.AddProfileFlag(fs)
+	retry.AddFlags(fs)
 
 	// Register the subcommands:
+	root.AddCommand(attach.Cmd)
 	root.AddCommand(completion.Cmd)
+	root.AddCommand(configcmd.Cmd)
 	root.AddCommand(create.Cmd)
 	root.AddCommand(describe.Cmd)
+	root.AddCommand(detach.Cmd)
+	root.AddCommand(diagnose.Cmd)
 	root.AddCommand(dlt.Cmd)
 	root.AddCommand(docs.Cmd)
 	root.AddCommand(download.Cmd)
 	root.AddCommand(edit.Cmd)
+	root.AddCommand(estimate.Cmd)
 	root.AddCommand(grant.Cmd)
 	root.AddCommand(list.Cmd)
 	root.AddCommand(initialize.Cmd)
@@ -86,16 +151,40 @@ func init() {
 	root.AddCommand(verify.Cmd)
 	root.AddCommand(version.Cmd)
 	root.AddCommand(whoami.Cmd)
+	root.AddCommand(token.Cmd)
 	root.AddCommand(hibernate.Cmd)
 	root.AddCommand(resume.Cmd)
+	root.AddCommand(retrycmd.Cmd)
 	root.AddCommand(link.Cmd)
 	root.AddCommand(unlink.Cmd)
+
+	// Accept the aliases of the flags in all the subcommands:
+	root.SetGlobalNormalizationFunc(profile.NormalizeFlagName)
 }
 
 func main() {
+	// Dispatch unknown subcommands to plugins:
+	p, err := plugin.Find(root, os.Args[1:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to find plugin: %v\n", err)
+		os.Exit(1)
+	}
+	if p != nil {
+		err = p.Execute()
+		if err != nil {
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) {
+				os.Exit(exitErr.ExitCode())
+			}
+			fmt.Fprintf(os.Stderr, "Failed to execute plugin '%s': %v\n", p.Name, err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	// Execute the root command:
 	root.SetArgs(os.Args[1:])
-	err := root.Execute()
+	err = root.Execute()
 	if err != nil {
 		if !strings.Contains(err.Error(), "Did you mean this?") {
 			fmt.Fprintf(os.Stderr, "Failed to execute root command: %s\n", err)
@@ -0,0 +1,132 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package token
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/config"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var args struct {
+	refresh bool
+	payload bool
+}
+
+var Cmd = &cobra.Command{
+	Use:   "token",
+	Short: "Generates a token",
+	Long: "Uses the stored credentials to generate an OCM access token that can be used to call the " +
+		"OCM API directly.",
+	Example: `  # Call the OCM API with the credentials of the CLI
+  curl -H "Authorization: Bearer $(rosa token)" https://api.openshift.com/api/clusters_mgmt/v1/clusters
+
+  # Display the claims of the access token
+  rosa token --payload`,
+	Args: cobra.NoArgs,
+	Run:  run,
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.BoolVar(
+		&args.refresh,
+		"refresh",
+		false,
+		"Request a new access token even if the current one hasn't expired yet.",
+	)
+	flags.BoolVar(
+		&args.payload,
+		"payload",
+		false,
+		"Print the claims of the access token as JSON instead of the token itself.",
+	)
+}
+
+// refreshExpiry is longer than the lifetime of any access token, so that asking for tokens that
+// don't expire before it always results in new tokens.
+const refreshExpiry = 24 * time.Hour
+
+func run(_ *cobra.Command, _ []string) {
+	r := rosa.NewRuntime()
+
+	// Load the configuration file:
+	cfg, err := config.Load()
+	if err != nil {
+		r.Reporter.Errorf("Failed to load config file: %v", err)
+		os.Exit(1)
+	}
+	if cfg == nil {
+		r.Reporter.Errorf("Not logged in, run the 'rosa login' command")
+		os.Exit(1)
+	}
+
+	// Create a connection to OCM, which renews the access token if it is about to expire:
+	r.OCMClient, err = ocm.NewClient().
+		Config(cfg).
+		Logger(r.Logger).
+		Build()
+	if err != nil {
+		r.Reporter.Errorf("Failed to create OCM connection: %v", err)
+		os.Exit(1)
+	}
+	defer r.Cleanup()
+
+	var expiresIn []time.Duration
+	if args.refresh {
+		expiresIn = append(expiresIn, refreshExpiry)
+	}
+	accessToken, refreshToken, err := r.OCMClient.GetConnectionTokens(expiresIn...)
+	if err != nil {
+		r.Reporter.Errorf("Failed to get token. Your session might be expired: %v", err)
+		os.Exit(1)
+	}
+
+	// Save the tokens so that other commands use them instead of requesting new ones:
+	if accessToken != cfg.AccessToken || refreshToken != cfg.RefreshToken {
+		cfg.AccessToken = accessToken
+		cfg.RefreshToken = refreshToken
+		err = config.Save(cfg)
+		if err != nil {
+			r.Reporter.Errorf("Failed to save config file: %v", err)
+			os.Exit(1)
+		}
+	}
+
+	if !args.payload {
+		fmt.Println(accessToken)
+		return
+	}
+	claims, err := config.TokenClaims(accessToken)
+	if err != nil {
+		r.Reporter.Errorf("%v", err)
+		os.Exit(1)
+	}
+	data, err := json.MarshalIndent(claims, "", "  ")
+	if err != nil {
+		r.Reporter.Errorf("Failed to format token claims: %v", err)
+		os.Exit(1)
+	}
+	fmt.Println(string(data))
+}
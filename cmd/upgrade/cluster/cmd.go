@@ -19,7 +19,6 @@ package cluster
 import (
 	"fmt"
 	"os"
-	"strconv"
 	"strings"
 	"time"
 
@@ -28,39 +27,46 @@ import (
 
 	"github.com/openshift/rosa/cmd/upgrade/roles"
 	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/helper"
+	"github.com/openshift/rosa/pkg/helper/upgrades"
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/interactive/confirm"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
 	"github.com/openshift/rosa/pkg/rosa"
+	"github.com/openshift/rosa/pkg/wait"
 )
 
 var args struct {
 	version              string
 	scheduleDate         string
 	scheduleTime         string
+	schedule             string
 	nodeDrainGracePeriod string
 	controlPlane         bool
-}
-
-var nodeDrainOptions = []string{
-	"15 minutes",
-	"30 minutes",
-	"45 minutes",
-	"1 hour",
-	"2 hours",
-	"4 hours",
-	"8 hours",
+	yesToGates           bool
+	acknowledgedGates    []string
+	wait                 *wait.Options
 }
 
 var Cmd = &cobra.Command{
 	Use:   "cluster",
 	Short: "Upgrade cluster",
-	Long:  "Upgrade cluster to a new available version",
+	Long:  "Upgrade cluster to a new available version.\n\n" + wait.ExitCodesHelp,
 	Example: `  # Interactively schedule an upgrade on the cluster named "mycluster"
   rosa upgrade cluster --cluster=mycluster --interactive
 
   # Schedule a cluster upgrade within the hour
-  rosa upgrade cluster -c mycluster --version 4.5.20`,
+  rosa upgrade cluster -c mycluster --version 4.5.20
+
+  # Upgrade the cluster now and wait up to three hours until it runs the new version
+  rosa upgrade cluster -c mycluster --version 4.5.20 --yes --wait --timeout=3h -o json
+
+  # Schedule a cluster upgrade acknowledging the administrative gates listed by 'rosa list gates'
+  rosa upgrade cluster -c mycluster --version 4.16.2 --acknowledge-gate <gate_id> --yes
+
+  # Upgrade the cluster to the latest patch version every Sunday at 2:00 UTC
+  rosa upgrade cluster -c mycluster --schedule "0 2 * * 0"`,
 	Run: run,
 }
 
@@ -92,6 +98,15 @@ func init() {
 		"Next UTC time that the upgrade should run on the specified date. Format should be 'HH:mm'",
 	)
 
+	flags.StringVar(
+		&args.schedule,
+		"schedule",
+		"",
+		"Cron expression in UTC that sets a recurring schedule for automatic upgrades to the latest "+
+			"patch version, for example '0 2 * * 0' for every Sunday at 2:00. "+
+			"Can't be combined with '--version', '--schedule-date' or '--schedule-time'",
+	)
+
 	flags.StringVar(
 		&args.nodeDrainGracePeriod,
 		"node-drain-grace-period",
@@ -99,7 +114,7 @@ func init() {
 		fmt.Sprintf("You may set a grace period for how long Pod Disruption Budget-protected workloads will be "+
 			"respected during upgrades.\nAfter this grace period, any workloads protected by Pod Disruption "+
 			"Budgets that have not been successfully drained from a node will be forcibly evicted.\nValid "+
-			"options are ['%s']", strings.Join(nodeDrainOptions, "','")),
+			"options are ['%s']", strings.Join(ocm.NodeDrainGracePeriodOptions, "','")),
 	)
 
 	flags.BoolVar(
@@ -109,9 +124,30 @@ func init() {
 		"For Hosted Control Plane, whether the upgrade should cover only the control plane",
 	)
 
+	flags.BoolVar(
+		&args.yesToGates,
+		"yes-to-gates",
+		false,
+		"Acknowledge all the administrative gates required by the upgrade without prompting",
+	)
+
+	flags.StringSliceVar(
+		&args.acknowledgedGates,
+		"acknowledge-gate",
+		nil,
+		"ID of an administrative gate required by the upgrade to acknowledge without prompting. "+
+			"Can be repeated. Use 'rosa list gates' to find the IDs of the gates. "+
+			"The upgrade fails if any other gate needs to be acknowledged",
+	)
+
+	args.wait = wait.AddFlags(flags, "the cluster runs the new version", 2*time.Hour)
+	output.AddFlag(Cmd)
 	confirm.AddFlag(flags)
 }
 
+// waitInterval is the time between checks of the cluster when '--wait' is used.
+const waitInterval = time.Minute
+
 func run(cmd *cobra.Command, _ []string) {
 	r := rosa.NewRuntime().WithAWS().WithOCM()
 	defer r.Cleanup()
@@ -151,6 +187,21 @@ func run(cmd *cobra.Command, _ []string) {
 
 	checkExistingScheduledUpgrade(r, cluster, clusterKey)
 
+	if args.schedule != "" {
+		if args.version != "" || scheduleDate != "" || scheduleTime != "" || mode != "" {
+			r.Reporter.Errorf("The '--schedule' option can't be combined with '--version', " +
+				"'--schedule-date', '--schedule-time' or '--mode'")
+			os.Exit(1)
+		}
+		// There is no single upgrade to wait for, the recurring ones run when new versions appear:
+		if args.wait.Enabled {
+			r.Reporter.Errorf("The '--wait' option can't be combined with '--schedule'")
+			os.Exit(1)
+		}
+		scheduleRecurringUpgrades(r, cmd, clusterKey, cluster)
+		return
+	}
+
 	availableUpgrades, version := buildVersion(r, cmd, cluster, args.version)
 	err = r.OCMClient.CheckUpgradeClusterVersion(availableUpgrades, version, cluster)
 	if err != nil {
@@ -198,7 +249,122 @@ func run(cmd *cobra.Command, _ []string) {
 		os.Exit(1)
 	}
 
-	r.Reporter.Infof("Upgrade successfully scheduled for cluster '%s'", clusterKey)
+	if !output.HasFlag() || r.Reporter.IsTerminal() {
+		r.Reporter.Infof("Upgrade successfully scheduled for cluster '%s'", clusterKey)
+	}
+
+	if args.wait.Enabled {
+		waitForUpgrade(r, cluster, clusterKey, version)
+	}
+}
+
+// waitForUpgrade waits until the cluster runs the given version, prints its final state when an
+// output format is given and exits with the code that corresponds to the outcome.
+func waitForUpgrade(r *rosa.Runtime, cluster *cmv1.Cluster, clusterKey string, version string) {
+	if !output.HasFlag() || r.Reporter.IsTerminal() {
+		r.Reporter.Infof("Waiting up to %s for cluster '%s' to be upgraded to version '%s'",
+			args.wait.Timeout, clusterKey, version)
+	}
+	err := args.wait.Poll(waitInterval, func() (bool, error) {
+		current, found, err := r.OCMClient.FindClusterByID(cluster.ID())
+		if err != nil {
+			return false, wait.Transient(err)
+		}
+		if !found {
+			return false, fmt.Errorf("Cluster no longer exists")
+		}
+		cluster = current
+		if cluster.State() == cmv1.ClusterStateError {
+			return false, fmt.Errorf("Cluster is in '%s' state", cluster.State())
+		}
+		if cluster.OpenshiftVersion() == version && cluster.State() == cmv1.ClusterStateReady {
+			return true, nil
+		}
+		var state *cmv1.UpgradePolicyState
+		if cluster.Hypershift().Enabled() {
+			policy, err := r.OCMClient.GetControlPlaneScheduledUpgrade(cluster.ID())
+			if err != nil {
+				return false, wait.Transient(err)
+			}
+			state = policy.State()
+		} else {
+			_, state, err = r.OCMClient.GetScheduledUpgrade(cluster.ID())
+			if err != nil {
+				return false, wait.Transient(err)
+			}
+		}
+		if state.Value() == cmv1.UpgradePolicyStateValueFailed {
+			return false, fmt.Errorf("Upgrade failed: %s", state.Description())
+		}
+		return false, nil
+	})
+	if output.HasFlag() {
+		if printErr := output.Print(cluster); printErr != nil {
+			r.Reporter.Errorf("%s", printErr)
+			os.Exit(wait.ExitFailed)
+		}
+	}
+	if err != nil {
+		r.Reporter.Errorf("Cluster '%s' wasn't upgraded: %v", clusterKey, err)
+		os.Exit(wait.ExitCode(err))
+	}
+	if !output.HasFlag() || r.Reporter.IsTerminal() {
+		r.Reporter.Infof("Cluster '%s' has been upgraded to version '%s'", clusterKey, version)
+	}
+}
+
+// scheduleRecurringUpgrades creates an automatic upgrade policy, which upgrades the cluster to the
+// latest patch version following the given cron schedule.
+func scheduleRecurringUpgrades(r *rosa.Runtime, cmd *cobra.Command, clusterKey string, cluster *cmv1.Cluster) {
+	schedule, err := upgrades.ParseSchedule(args.schedule)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+	nextRun := schedule.Next(time.Now().UTC())
+	if nextRun.IsZero() {
+		r.Reporter.Errorf("Schedule '%s' never runs", args.schedule)
+		os.Exit(1)
+	}
+	if !confirm.Confirm("schedule recurring upgrades for cluster '%s' at '%s'", clusterKey, args.schedule) {
+		os.Exit(0)
+	}
+
+	if cluster.Hypershift().Enabled() {
+		var upgradePolicy *cmv1.ControlPlaneUpgradePolicy
+		upgradePolicy, err = cmv1.NewControlPlaneUpgradePolicy().
+			ScheduleType("automatic").
+			UpgradeType("ControlPlane").
+			Schedule(args.schedule).
+			Build()
+		if err == nil {
+			err = r.OCMClient.ScheduleHypershiftControlPlaneUpgrade(cluster.ID(), upgradePolicy)
+		}
+	} else {
+		var upgradePolicy *cmv1.UpgradePolicy
+		upgradePolicy, err = cmv1.NewUpgradePolicy().
+			ScheduleType("automatic").
+			UpgradeType("OSD").
+			Schedule(args.schedule).
+			Build()
+		if err == nil {
+			err = r.OCMClient.ScheduleUpgrade(cluster.ID(), upgradePolicy)
+		}
+	}
+	if err != nil {
+		r.Reporter.Errorf("Failed to schedule recurring upgrades for cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+
+	clusterSpec := buildNodeDrainGracePeriod(r, cmd, cluster)
+	err = r.OCMClient.UpdateCluster(cluster.ID(), r.Creator, clusterSpec)
+	if err != nil {
+		r.Reporter.Errorf("Failed to update cluster '%s': %v", clusterKey, err)
+		os.Exit(1)
+	}
+
+	r.Reporter.Infof("Recurring upgrades successfully scheduled for cluster '%s', the next one will "+
+		"run on %s", clusterKey, nextRun.Format("2006-01-02 15:04 MST"))
 }
 
 func createUpgradePolicyHypershift(r *rosa.Runtime, cmd *cobra.Command, clusterKey string,
@@ -302,6 +468,13 @@ func checkExistingScheduledUpgrade(r *rosa.Runtime, cluster *cmv1.Cluster, clust
 		r.Reporter.Errorf("Failed to get scheduled upgrades for cluster '%s': %v", clusterKey, err)
 		os.Exit(1)
 	}
+	if scheduledUpgrade != nil && scheduledUpgrade.ScheduleType() == "automatic" {
+		r.Reporter.Warnf("There are already recurring upgrades scheduled at '%s', next one on %s",
+			scheduledUpgrade.Schedule(),
+			scheduledUpgrade.NextRun().Format("2006-01-02 15:04 MST"),
+		)
+		os.Exit(0)
+	}
 	if scheduledUpgrade != nil {
 		r.Reporter.Warnf("There is already a %s upgrade to version %s on %s",
 			upgradeState.Value(),
@@ -318,7 +491,7 @@ func checkSTSRolesCompatibility(r *rosa.Runtime, cluster *cmv1.Cluster, mode str
 		" are compatible with upgrade.", cluster.ID())
 	err := roles.Cmd.RunE(roles.Cmd, []string{mode, cluster.ID(), version, cluster.Version().ChannelGroup()})
 	if err != nil {
-		rolesStr := fmt.Sprintf("rosa upgrade roles -c %s --cluster-version=%s --mode=%s", clusterKey, version, mode)
+		rolesStr := fmt.Sprintf("rosa upgrade roles -c %s --version=%s --mode=%s", clusterKey, version, mode)
 		upgradeClusterStr := fmt.Sprintf("rosa upgrade cluster -c %s", clusterKey)
 
 		r.Reporter.Infof("Account/Operator Role policies are not valid with upgrade version %s. "+
@@ -399,23 +572,8 @@ func buildUpgradeSchedule(r *rosa.Runtime, cmd *cobra.Command, scheduleDate stri
 }
 
 func buildNodeDrainGracePeriod(r *rosa.Runtime, cmd *cobra.Command, cluster *cmv1.Cluster) ocm.Spec {
-	nodeDrainGracePeriod := ""
 	// Determine if the cluster already has a node drain grace period set and use that as the default
-	nd := cluster.NodeDrainGracePeriod()
-	if _, ok := nd.GetValue(); ok {
-		// Convert larger times to hours, since the API only stores minutes
-		val := int(nd.Value())
-		unit := nd.Unit()
-		if val >= 60 {
-			val = val / 60
-			if val == 1 {
-				unit = "hour"
-			} else {
-				unit = "hours"
-			}
-		}
-		nodeDrainGracePeriod = fmt.Sprintf("%d %s", val, unit)
-	}
+	nodeDrainGracePeriod := ocm.FormatNodeDrainGracePeriod(cluster)
 	// If node drain grace period is not set, or the user sent it as a CLI argument, use that instead
 	if nodeDrainGracePeriod == "" || cmd.Flags().Changed("node-drain-grace-period") {
 		nodeDrainGracePeriod = args.nodeDrainGracePeriod
@@ -425,7 +583,7 @@ func buildNodeDrainGracePeriod(r *rosa.Runtime, cmd *cobra.Command, cluster *cmv
 		nodeDrainGracePeriod, err = interactive.GetOption(interactive.Input{
 			Question: "Node draining",
 			Help:     cmd.Flags().Lookup("node-drain-grace-period").Usage,
-			Options:  nodeDrainOptions,
+			Options:  ocm.NodeDrainGracePeriodOptions,
 			Default:  nodeDrainGracePeriod,
 			Required: true,
 		})
@@ -434,27 +592,11 @@ func buildNodeDrainGracePeriod(r *rosa.Runtime, cmd *cobra.Command, cluster *cmv
 			os.Exit(1)
 		}
 	}
-	isValidNodeDrainGracePeriod := false
-	for _, nodeDrainOption := range nodeDrainOptions {
-		if nodeDrainGracePeriod == nodeDrainOption {
-			isValidNodeDrainGracePeriod = true
-			break
-		}
-	}
-	if !isValidNodeDrainGracePeriod {
-		r.Reporter.Errorf("Expected a valid node drain grace period. Options are [%s]",
-			strings.Join(nodeDrainOptions, ", "))
-		os.Exit(1)
-	}
-	nodeDrainParsed := strings.Split(nodeDrainGracePeriod, " ")
-	nodeDrainValue, err := strconv.ParseFloat(nodeDrainParsed[0], 64)
+	nodeDrainValue, err := ocm.ParseNodeDrainGracePeriod(nodeDrainGracePeriod)
 	if err != nil {
-		r.Reporter.Errorf("Expected a valid node drain grace period: %s", err)
+		r.Reporter.Errorf("%s", err)
 		os.Exit(1)
 	}
-	if nodeDrainParsed[1] == "hours" || nodeDrainParsed[1] == "hour" {
-		nodeDrainValue = nodeDrainValue * 60
-	}
 	clusterSpec := ocm.Spec{
 		NodeDrainGracePeriodInMinutes: nodeDrainValue,
 	}
@@ -491,26 +633,13 @@ func checkGates(r *rosa.Runtime, cluster *cmv1.Cluster, gates []*cmv1.VersionGat
 				r.Reporter.Warnf("Missing required acknowledgements to schedule upgrade. \n")
 				isWarningDisplayed = true
 			}
-			str := fmt.Sprintf("Description: %s\n", gate.Description())
-
-			if gate.WarningMessage() != "" {
-				str = fmt.Sprintf("%s"+
-					"    Warning:     %s\n", str, gate.WarningMessage())
-			}
-			str = fmt.Sprintf("%s"+
-				"    URL:         %s\n", str, gate.DocumentationURL())
-
-			err := interactive.PrintHelp(interactive.Help{
-				Message: "Read the below description and acknowledge to proceed with upgrade",
-				Steps:   []string{str},
-			})
-			if err != nil {
-				return fmt.Errorf("failed to get version gate '%s' for cluster '%s': %v",
-					gate.ID(), clusterKey, err)
-			}
-			// for non sts gates we require user agreement
-			if !confirm.Prompt(true, "I acknowledge") {
-				os.Exit(0)
+			if args.yesToGates || helper.Contains(args.acknowledgedGates, gate.ID()) {
+				r.Reporter.Infof("Acknowledging version gate '%s'", gate.ID())
+			} else if len(args.acknowledgedGates) > 0 {
+				return fmt.Errorf("version gate '%s' for cluster '%s' hasn't been acknowledged: %s",
+					gate.ID(), clusterKey, gate.Description())
+			} else if err := promptGate(gate, clusterKey); err != nil {
+				return err
 			}
 		}
 		err := r.OCMClient.AckVersionGate(cluster.ID(), gate.ID())
@@ -521,3 +650,30 @@ func checkGates(r *rosa.Runtime, cluster *cmv1.Cluster, gates []*cmv1.VersionGat
 	}
 	return nil
 }
+
+func promptGate(gate *cmv1.VersionGate, clusterKey string) error {
+	str := fmt.Sprintf("ID:          %s\n", gate.ID())
+	str = fmt.Sprintf("%s"+
+		"    Description: %s\n", str, gate.Description())
+
+	if gate.WarningMessage() != "" {
+		str = fmt.Sprintf("%s"+
+			"    Warning:     %s\n", str, gate.WarningMessage())
+	}
+	str = fmt.Sprintf("%s"+
+		"    URL:         %s\n", str, gate.DocumentationURL())
+
+	err := interactive.PrintHelp(interactive.Help{
+		Message: "Read the below description and acknowledge to proceed with upgrade",
+		Steps:   []string{str},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get version gate '%s' for cluster '%s': %v",
+			gate.ID(), clusterKey, err)
+	}
+	// for non sts gates we require user agreement
+	if !confirm.Prompt(true, "I acknowledge") {
+		os.Exit(0)
+	}
+	return nil
+}
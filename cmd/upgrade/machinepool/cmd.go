@@ -0,0 +1,270 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinepool
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/interactive"
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var args struct {
+	version      string
+	scheduleDate string
+	scheduleTime string
+}
+
+var Cmd = &cobra.Command{
+	Use:     "machinepool ID",
+	Aliases: []string{"machinepools", "machine-pool", "machine-pools"},
+	Short:   "Upgrade machine pool",
+	Long:    "Upgrade a machine pool of a Hosted Control Plane cluster to a new available version",
+	Example: `  # Interactively schedule an upgrade of machine pool "mp1" on the cluster named "mycluster"
+  rosa upgrade machinepool mp1 --cluster=mycluster --interactive
+
+  # Upgrade machine pool "mp1" right away
+  rosa upgrade machinepool mp1 -c mycluster --version 4.12.20
+
+  # Schedule an upgrade of machine pool "mp1"
+  rosa upgrade machinepool mp1 -c mycluster --version 4.12.20 --schedule-date 2023-06-01 --schedule-time 02:00`,
+	Run: run,
+	Args: func(_ *cobra.Command, argv []string) error {
+		if len(argv) != 1 {
+			return fmt.Errorf(
+				"Expected exactly one command line parameter containing the id of the machine pool",
+			)
+		}
+		return nil
+	},
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
+	ocm.AddClusterFlag(Cmd)
+
+	flags.StringVar(
+		&args.version,
+		"version",
+		"",
+		"Version of OpenShift that the machine pool will be upgraded to. It can't be newer than the "+
+			"version of the control plane",
+	)
+
+	flags.StringVar(
+		&args.scheduleDate,
+		"schedule-date",
+		"",
+		"Next date the upgrade should run at the specified UTC time. Format should be 'yyyy-mm-dd'. "+
+			"If not set the upgrade runs right away",
+	)
+
+	flags.StringVar(
+		&args.scheduleTime,
+		"schedule-time",
+		"",
+		"Next UTC time that the upgrade should run on the specified date. Format should be 'HH:mm'",
+	)
+
+	confirm.AddFlag(flags)
+}
+
+func run(cmd *cobra.Command, argv []string) {
+	r := rosa.NewRuntime().WithAWS().WithOCM()
+	defer r.Cleanup()
+
+	machinePoolID := argv[0]
+	clusterKey := r.GetClusterKey()
+
+	cluster := r.FetchCluster()
+	if !cluster.Hypershift().Enabled() {
+		r.Reporter.Errorf("Upgrading machine pools is only supported for Hosted Control Plane clusters")
+		os.Exit(1)
+	}
+	if cluster.State() != cmv1.ClusterStateReady {
+		r.Reporter.Errorf("Cluster '%s' is not yet ready", clusterKey)
+		os.Exit(1)
+	}
+	if (args.scheduleDate == "") != (args.scheduleTime == "") {
+		r.Reporter.Errorf("The '--schedule-date' and '--schedule-time' options must be used together")
+		os.Exit(1)
+	}
+
+	nodePool, err := r.OCMClient.GetNodePool(cluster.ID(), machinePoolID)
+	if err != nil {
+		r.Reporter.Errorf("Failed to get machine pool '%s' for cluster '%s': %v",
+			machinePoolID, clusterKey, err)
+		os.Exit(1)
+	}
+
+	checkExistingScheduledUpgrade(r, cluster, machinePoolID)
+
+	currentVersion := nodePool.Version().RawID()
+	controlPlaneVersion := cluster.Version().RawID()
+	availableUpgrades, err := getAvailableUpgrades(r, nodePool, controlPlaneVersion)
+	if err != nil {
+		r.Reporter.Errorf("Failed to find available upgrades: %v", err)
+		os.Exit(1)
+	}
+	if len(availableUpgrades) == 0 {
+		r.Reporter.Warnf("There are no available upgrades for machine pool '%s' with version '%s' "+
+			"and control plane version '%s'", machinePoolID, currentVersion, controlPlaneVersion)
+		os.Exit(0)
+	}
+
+	version := args.version
+	if version == "" || interactive.Enabled() {
+		if version == "" {
+			version = availableUpgrades[0]
+		}
+		version, err = interactive.GetOption(interactive.Input{
+			Question: "Version",
+			Help:     cmd.Flags().Lookup("version").Usage,
+			Options:  availableUpgrades,
+			Default:  version,
+			Required: true,
+		})
+		if err != nil {
+			r.Reporter.Errorf("Expected a valid version to upgrade to: %s", err)
+			os.Exit(1)
+		}
+	}
+	err = ocm.ValidateNodePoolUpgradeVersion(currentVersion, version, controlPlaneVersion)
+	if err != nil {
+		r.Reporter.Errorf("Can't upgrade machine pool '%s': %v", machinePoolID, err)
+		os.Exit(1)
+	}
+
+	nextRun := buildUpgradeSchedule(r, cmd)
+
+	if !confirm.Confirm("upgrade machine pool '%s' to version '%s'", machinePoolID, version) {
+		os.Exit(0)
+	}
+
+	upgradePolicy := &ocm.NodePoolUpgradePolicy{
+		Kind:         "NodePoolUpgradePolicy",
+		ScheduleType: "manual",
+		UpgradeType:  "NodePool",
+		Version:      version,
+		NextRun:      nextRun,
+	}
+	_, err = r.OCMClient.ScheduleNodePoolUpgrade(cluster.ID(), machinePoolID, upgradePolicy)
+	if err != nil {
+		r.Reporter.Errorf("Failed to schedule upgrade for machine pool '%s' in cluster '%s': %v",
+			machinePoolID, clusterKey, err)
+		os.Exit(1)
+	}
+
+	r.Reporter.Infof("Upgrade successfully scheduled for machine pool '%s' on cluster '%s'",
+		machinePoolID, clusterKey)
+}
+
+func checkExistingScheduledUpgrade(r *rosa.Runtime, cluster *cmv1.Cluster, machinePoolID string) {
+	upgradePolicies, err := r.OCMClient.GetNodePoolUpgradePolicies(cluster.ID(), machinePoolID)
+	if err != nil {
+		r.Reporter.Errorf("Failed to get scheduled upgrades for machine pool '%s': %v", machinePoolID, err)
+		os.Exit(1)
+	}
+	for _, upgradePolicy := range upgradePolicies {
+		if upgradePolicy.State != nil && upgradePolicy.State.Value == "completed" {
+			continue
+		}
+		// The automatic upgrade policy isn't a scheduled upgrade, it only makes the machine pool
+		// follow the control plane:
+		if upgradePolicy.ScheduleType == ocm.AutomaticUpgradeScheduleType {
+			continue
+		}
+		r.Reporter.Warnf("There is already an upgrade of machine pool '%s' to version %s on %s",
+			machinePoolID,
+			upgradePolicy.Version,
+			upgradePolicy.NextRun.Format("2006-01-02 15:04 MST"),
+		)
+		os.Exit(0)
+	}
+}
+
+// getAvailableUpgrades returns the versions that the machine pool can be upgraded to, which are the
+// upgrades of its current version that respect the version skew with the control plane.
+func getAvailableUpgrades(r *rosa.Runtime, nodePool *cmv1.NodePool, controlPlaneVersion string) ([]string, error) {
+	upgrades, err := r.OCMClient.GetAvailableUpgrades(nodePool.Version().ID())
+	if err != nil {
+		return nil, err
+	}
+	availableUpgrades := []string{}
+	for _, upgrade := range upgrades {
+		if ocm.ValidateNodePoolUpgradeVersion(nodePool.Version().RawID(), upgrade, controlPlaneVersion) == nil {
+			availableUpgrades = append(availableUpgrades, upgrade)
+		}
+	}
+	return availableUpgrades, nil
+}
+
+// buildUpgradeSchedule returns the time of the upgrade, which is right away unless a date and time
+// were given in the command line or interactively.
+func buildUpgradeSchedule(r *rosa.Runtime, cmd *cobra.Command) time.Time {
+	scheduleDate := args.scheduleDate
+	scheduleTime := args.scheduleTime
+	if scheduleDate == "" && !interactive.Enabled() {
+		return time.Now().UTC()
+	}
+
+	if interactive.Enabled() {
+		now := time.Now().UTC()
+		if scheduleDate == "" {
+			scheduleDate = now.Format("2006-01-02")
+			scheduleTime = now.Format("15:04")
+		}
+		var err error
+		scheduleDate, err = interactive.GetString(interactive.Input{
+			Question: "Please input desired date in format yyyy-mm-dd",
+			Help:     cmd.Flags().Lookup("schedule-date").Usage,
+			Default:  scheduleDate,
+			Required: true,
+		})
+		if err != nil {
+			r.Reporter.Errorf("Expected a valid date: %s", err)
+			os.Exit(1)
+		}
+		scheduleTime, err = interactive.GetString(interactive.Input{
+			Question: "Please input desired UTC time in format HH:mm",
+			Help:     cmd.Flags().Lookup("schedule-time").Usage,
+			Default:  scheduleTime,
+			Required: true,
+		})
+		if err != nil {
+			r.Reporter.Errorf("Expected a valid time: %s", err)
+			os.Exit(1)
+		}
+	}
+
+	nextRun, err := time.Parse("2006-01-02 15:04", fmt.Sprintf("%s %s", scheduleDate, scheduleTime))
+	if err != nil {
+		r.Reporter.Errorf("Schedule date should use the format 'yyyy-mm-dd'\n" +
+			"   Schedule time should use the format 'HH:mm'")
+		os.Exit(1)
+	}
+	return nextRun
+}
@@ -52,14 +52,19 @@ var args struct {
 var Cmd = &cobra.Command{
 	Use:     "roles",
 	Aliases: []string{},
-	Short:   "Upgrade account-wide IAM roles to the latest version.",
-	Long:    "Upgrade account-wide IAM roles to the latest version before upgrading your cluster.",
-	Example: `  # Upgrade account/operator roles for ROSA STS clusters 
-		rosa upgrade roles -c <cluster_key>`,
+	Short:   "Upgrade account and operator IAM roles for a cluster upgrade.",
+	Long: "Upgrade the account roles, the operator roles and their policies to the versions required " +
+		"by the OpenShift version that the cluster will be upgraded to, before upgrading your cluster.",
+	Example: `  # Upgrade account/operator roles for ROSA STS clusters before upgrading them to 4.16
+  rosa upgrade roles -c <cluster_key> --version 4.16
+
+  # Generate the commands to upgrade the roles and policies instead of running them
+  rosa upgrade roles -c <cluster_key> --version 4.16 --mode manual`,
 	RunE: run,
 }
 
 const (
+	versionFlag        = "version"
 	clusterVersionFlag = "cluster-version"
 	policyVersionFlag  = "policy-version"
 	channelGroupFlag   = "channel-group"
@@ -72,13 +77,20 @@ func init() {
 
 	aws.AddModeFlag(Cmd)
 
+	flags.StringVar(
+		&args.clusterUpgradeVersion,
+		versionFlag,
+		"",
+		"Version of OpenShift that the cluster will be upgraded to, for example \"4.16\" or \"4.16.2\"",
+	)
+
 	flags.StringVar(
 		&args.clusterUpgradeVersion,
 		clusterVersionFlag,
 		"",
 		"Version of OpenShift that the cluster will be upgraded to",
 	)
-	Cmd.MarkFlagRequired(clusterVersionFlag)
+	flags.MarkDeprecated(clusterVersionFlag, fmt.Sprintf("use '--%s' instead", versionFlag))
 
 	flags.StringVar(
 		&args.policyUpgradeversion,
@@ -130,6 +142,11 @@ func run(cmd *cobra.Command, argv []string) error {
 	}
 
 	clusterUpgradeVersion := args.clusterUpgradeVersion
+	if clusterUpgradeVersion == "" {
+		reporter.Errorf("Expected the version that the cluster will be upgraded to, use the '--%s' flag",
+			versionFlag)
+		os.Exit(1)
+	}
 
 	availableUpgrades, err := r.OCMClient.GetAvailableUpgrades(ocm.GetVersionID(cluster))
 	if err != nil {
@@ -0,0 +1,203 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rosa
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/hashicorp/go-version"
+	"github.com/spf13/cobra"
+
+	verify "github.com/openshift/rosa/cmd/verify/rosa"
+	helper "github.com/openshift/rosa/pkg/helper/download"
+	"github.com/openshift/rosa/pkg/info"
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	rprtr "github.com/openshift/rosa/pkg/reporter"
+)
+
+var args struct {
+	check                     bool
+	skipSignatureVerification bool
+}
+
+var Cmd = &cobra.Command{
+	Use:     "rosa-client",
+	Aliases: []string{"rosa"},
+	Short:   "Upgrade the ROSA CLI",
+	Long: "Upgrade the ROSA CLI to the latest version released in the mirror. The release for the " +
+		"current operating system and architecture is downloaded, its checksum and the signature of the " +
+		"checksums with the Red Hat release key are verified, and then it replaces the running binary. " +
+		"Verifying the signature requires 'gpg'.",
+	Example: `  # Upgrade the ROSA CLI to the latest version
+  rosa upgrade rosa
+
+  # Only check if there is a newer version of the ROSA CLI
+  rosa upgrade rosa --check`,
+	Run:  run,
+	Args: cobra.NoArgs,
+}
+
+func init() {
+	flags := Cmd.Flags()
+
+	flags.BoolVar(
+		&args.check,
+		"check",
+		false,
+		"Only report whether a newer version of the ROSA CLI is available, without upgrading.",
+	)
+
+	flags.BoolVar(
+		&args.skipSignatureVerification,
+		"skip-signature-verification",
+		false,
+		"Don't verify that the checksums published in the mirror are signed with the Red Hat release key. "+
+			"The checksum of the downloaded release is still verified.",
+	)
+
+	confirm.AddFlag(flags)
+}
+
+func run(_ *cobra.Command, _ []string) {
+	reporter := rprtr.CreateReporterOrExit()
+
+	currentVersion, err := version.NewVersion(info.Version)
+	if err != nil {
+		reporter.Errorf("There was a problem retrieving current version: %s", err)
+		os.Exit(1)
+	}
+	latestVersion, err := verify.RetrieveLatestVersionFromMirror()
+	if err != nil {
+		reporter.Errorf("There was a problem retrieving latest version from mirror: %s", err)
+		os.Exit(1)
+	}
+	if !currentVersion.LessThan(latestVersion) {
+		reporter.Infof("Your ROSA CLI is up to date.")
+		return
+	}
+	if args.check {
+		reporter.Infof("There is a newer release version '%s' of the ROSA CLI, the current version is '%s'. "+
+			"Run 'rosa upgrade rosa' to upgrade.", latestVersion, currentVersion)
+		return
+	}
+
+	executable, err := getExecutable()
+	if err != nil {
+		reporter.Errorf("Failed to find the path of the ROSA CLI: %v", err)
+		os.Exit(1)
+	}
+
+	if !confirm.Confirm("upgrade the ROSA CLI at '%s' from version '%s' to '%s'",
+		executable, currentVersion, latestVersion) {
+		os.Exit(0)
+	}
+
+	folder := verify.DownloadLatestMirrorFolder
+	filename := helper.GetROSAArchiveName(runtime.GOOS, runtime.GOARCH)
+
+	checksums, err := helper.GetChecksums(folder)
+	if err != nil {
+		reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+	if !args.skipSignatureVerification {
+		verifySignature(reporter, folder, checksums)
+	}
+	checksum, err := helper.FindChecksum(checksums, filename)
+	if err != nil {
+		reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+
+	downloadURL := folder + filename
+	reporter.Infof("Downloading %s", downloadURL)
+	dir, err := os.MkdirTemp("", "rosa-upgrade")
+	if err != nil {
+		reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+	defer os.RemoveAll(dir)
+	archive := filepath.Join(dir, filename)
+	err = helper.Download(downloadURL, archive)
+	if err != nil {
+		reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+	err = helper.VerifyChecksum(archive, checksum)
+	if err != nil {
+		reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+	reporter.Infof("Verified checksum of %s", filename)
+
+	err = replaceExecutable(archive, executable)
+	if err != nil {
+		reporter.Errorf("Failed to replace the ROSA CLI at '%s': %v", executable, err)
+		os.Exit(1)
+	}
+	reporter.Infof("Successfully upgraded the ROSA CLI to version '%s'", latestVersion)
+}
+
+// verifySignature checks that the checksums are signed with the Red Hat release key.
+func verifySignature(reporter *rprtr.Object, folder string, checksums string) {
+	signature, err := helper.GetChecksumsSignature(folder)
+	if err != nil {
+		reporter.Errorf("%s. To only verify the checksum of the release use "+
+			"'--skip-signature-verification'", err)
+		os.Exit(1)
+	}
+	err = helper.VerifySignature(checksums, signature)
+	if err != nil {
+		reporter.Errorf("%s. To only verify the checksum of the release use "+
+			"'--skip-signature-verification'", err)
+		os.Exit(1)
+	}
+	reporter.Infof("Verified signature of the checksums")
+}
+
+// getExecutable returns the path of the running binary, resolving symbolic links so that the
+// binary is replaced instead of the link.
+func getExecutable() (string, error) {
+	executable, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+	return filepath.EvalSymlinks(executable)
+}
+
+// replaceExecutable extracts the new binary next to the running one and renames it over it, so
+// that the binary is never left half written. Windows doesn't allow replacing a running binary, so
+// it is moved aside first.
+func replaceExecutable(archive string, executable string) error {
+	binary := "rosa"
+	if runtime.GOOS == "windows" {
+		binary = "rosa.exe"
+		old := executable + ".old"
+		os.Remove(old)
+		if err := os.Rename(executable, old); err != nil {
+			return err
+		}
+		err := helper.ExtractBinary(archive, binary, executable)
+		if err != nil {
+			os.Rename(old, executable)
+		}
+		return err
+	}
+	return helper.ExtractBinary(archive, binary, executable)
+}
@@ -19,7 +19,9 @@ package verify
 import (
 	"github.com/spf13/cobra"
 
+	"github.com/openshift/rosa/cmd/verify/network"
 	"github.com/openshift/rosa/cmd/verify/oc"
+	"github.com/openshift/rosa/cmd/verify/oidcconfig"
 	"github.com/openshift/rosa/cmd/verify/permissions"
 	"github.com/openshift/rosa/cmd/verify/quota"
 	"github.com/openshift/rosa/cmd/verify/rosa"
@@ -32,7 +34,9 @@ var Cmd = &cobra.Command{
 }
 
 func init() {
+	Cmd.AddCommand(network.Cmd)
 	Cmd.AddCommand(oc.Cmd)
+	Cmd.AddCommand(oidcconfig.Cmd)
 	Cmd.AddCommand(permissions.Cmd)
 	Cmd.AddCommand(quota.Cmd)
 	Cmd.AddCommand(rosa.Cmd)
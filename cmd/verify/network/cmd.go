@@ -0,0 +1,203 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/arguments"
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/helper/network"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+const pollInterval = 5 * time.Second
+
+var args struct {
+	subnetIDs   []string
+	roleARN     string
+	concurrency int
+	timeout     time.Duration
+}
+
+var Cmd = &cobra.Command{
+	Use:   "network",
+	Short: "Verify VPC subnets are configured correctly",
+	Long: "Verify that the VPC subnets of a cluster, or the given ones, can reach the endpoints " +
+		"needed to install and run a cluster. Subnets are verified concurrently.",
+	Example: `  # Verify the subnets of the cluster named "mycluster"
+  rosa verify network -c mycluster
+
+  # Verify two subnets before creating a cluster, using the installer role to access the account
+  rosa verify network --subnet-ids subnet-03046a9b92b5014fb,subnet-03046a9c92b5014fb \
+    --role-arn arn:aws:iam::123456789012:role/ManagedOpenShift-Installer-Role --region us-east-1
+
+  # Verify up to ten subnets at a time and print the results as JSON
+  rosa verify network -c mycluster --concurrency 10 -o json`,
+	Run:  run,
+	Args: cobra.NoArgs,
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
+	ocm.AddOptionalClusterFlag(Cmd)
+
+	flags.StringSliceVar(
+		&args.subnetIDs,
+		"subnet-ids",
+		nil,
+		"Comma-separated list of the IDs of the subnets to verify. Can't be used with '--cluster'.",
+	)
+
+	flags.StringVar(
+		&args.roleARN,
+		"role-arn",
+		"",
+		"ARN of the installer role used to access the AWS account of the subnets. "+
+			"Required with '--subnet-ids'.",
+	)
+
+	flags.IntVar(
+		&args.concurrency,
+		"concurrency",
+		5,
+		"Maximum number of subnets verified at the same time.",
+	)
+
+	flags.DurationVar(
+		&args.timeout,
+		"timeout",
+		10*time.Minute,
+		"Maximum time to wait for the verification of each subnet.",
+	)
+
+	arguments.AddRegionFlag(flags)
+	arguments.AddProfileFlag(flags)
+	output.AddFlag(Cmd)
+}
+
+func run(cmd *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithOCM()
+	defer r.Cleanup()
+
+	if args.concurrency < 1 {
+		r.Reporter.Errorf("Concurrency must be a positive integer")
+		os.Exit(1)
+	}
+
+	var verifications []*ocm.SubnetNetworkVerification
+	var err error
+	if cmd.Flags().Changed("cluster") {
+		if len(args.subnetIDs) > 0 || args.roleARN != "" {
+			r.Reporter.Errorf("The '--subnet-ids' and '--role-arn' options can't be used with '--cluster'")
+			os.Exit(1)
+		}
+		clusterKey := r.GetClusterKey()
+		cluster := r.FetchCluster()
+		r.Reporter.Debugf("Starting network verification for cluster '%s'", clusterKey)
+		verifications, err = r.OCMClient.VerifyNetworkCluster(cluster.ID())
+	} else {
+		if len(args.subnetIDs) == 0 || args.roleARN == "" {
+			r.Reporter.Errorf("Either '--cluster' or both '--subnet-ids' and '--role-arn' are required")
+			os.Exit(1)
+		}
+		var region string
+		region, err = aws.GetRegion(arguments.GetRegion())
+		if err != nil {
+			r.Reporter.Errorf("Error getting region: %v", err)
+			os.Exit(1)
+		}
+		r.Reporter.Debugf("Starting network verification for subnets %v", args.subnetIDs)
+		verifications, err = r.OCMClient.VerifyNetworkSubnets(args.roleARN, region, args.subnetIDs)
+	}
+	if err != nil {
+		r.Reporter.Errorf("Failed to start network verification: %v", err)
+		os.Exit(1)
+	}
+	subnetIDs := []string{}
+	for _, verification := range verifications {
+		subnetIDs = append(subnetIDs, verification.ID)
+	}
+	if len(subnetIDs) == 0 {
+		r.Reporter.Warnf("There are no subnets to verify")
+		os.Exit(0)
+	}
+
+	if r.Reporter.IsTerminal() && !output.HasFlag() {
+		r.Reporter.Infof("Verifying %d subnets, up to %d at a time...", len(subnetIDs), args.concurrency)
+	}
+	var report func(*network.SubnetResult)
+	if !output.HasFlag() {
+		report = func(result *network.SubnetResult) {
+			printResult(r, result)
+		}
+	}
+	results := network.Run(subnetIDs, args.concurrency,
+		network.Poller(r.OCMClient.GetVerifyNetworkSubnet, pollInterval, args.timeout), report)
+
+	if output.HasFlag() {
+		err = output.Print(results)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+	}
+
+	failed := 0
+	for _, result := range results {
+		if !result.Passed() {
+			failed++
+		}
+	}
+	if failed > 0 {
+		if !output.HasFlag() {
+			r.Reporter.Errorf("Network verification failed for %d of %d subnets", failed, len(results))
+			r.Reporter.Infof("Run 'rosa list egress-endpoints' to get the endpoints that the subnets " +
+				"must be able to reach")
+		}
+		os.Exit(1)
+	}
+	if !output.HasFlag() {
+		r.Reporter.Infof("Network verification passed for all %d subnets", len(results))
+	}
+}
+
+func printResult(r *rosa.Runtime, result *network.SubnetResult) {
+	switch {
+	case result.Passed():
+		r.Reporter.Infof("%s: %s (%s)", result.SubnetID, result.State, result.Latency)
+	case result.Error != "":
+		r.Reporter.Errorf("%s: %s (%s): %s", result.SubnetID, result.State, result.Latency, result.Error)
+	default:
+		r.Reporter.Errorf("%s: %s (%s)", result.SubnetID, result.State, result.Latency)
+	}
+	for _, failure := range result.Failures {
+		if failure.Endpoint == "" {
+			fmt.Printf("  %s\n", failure.Reason)
+		} else {
+			fmt.Printf("  %-50s%s\n", failure.Endpoint, failure.Reason)
+		}
+	}
+}
@@ -17,25 +17,66 @@ limitations under the License.
 package oc
 
 import (
+	"fmt"
+	"os"
 	"os/exec"
+	"path/filepath"
 	"regexp"
+	"runtime"
 	"strings"
 
+	"github.com/hashicorp/go-version"
 	"github.com/spf13/cobra"
 
+	helper "github.com/openshift/rosa/pkg/helper/download"
+	"github.com/openshift/rosa/pkg/interactive/confirm"
 	rprtr "github.com/openshift/rosa/pkg/reporter"
 )
 
+var args struct {
+	download   bool
+	installDir string
+}
+
 var Cmd = &cobra.Command{
 	Use:     "openshift-client",
 	Aliases: []string{"oc", "openshift"},
 	Short:   "Verify OpenShift client tools",
-	Long:    "Verify that the OpenShift client tools is installed and compatible.",
+	Long: "Verify that the OpenShift client tools is installed and compatible. With '--download' it " +
+		"is also compared with the latest version published in the mirror, and installed if missing or outdated.",
 	Example: `  # Verify oc client tools
-  rosa verify oc`,
+  rosa verify oc
+
+  # Verify oc client tools and install the latest version if it is missing or outdated
+  rosa verify oc --download`,
 	Run: run,
 }
 
+func init() {
+	flags := Cmd.Flags()
+
+	flags.BoolVar(
+		&args.download,
+		"download",
+		false,
+		"Download the latest OpenShift client tools for this operating system and architecture, "+
+			"verify its checksum and install it if the current one is missing or outdated.",
+	)
+
+	flags.StringVar(
+		&args.installDir,
+		"install-dir",
+		"",
+		"Directory where the OpenShift client tools is installed by '--download'. Defaults to the "+
+			"directory of the current 'oc' binary, or the current directory if it isn't installed.",
+	)
+
+	confirm.AddFlag(flags)
+}
+
+// clientVersionRE matches the version in the output of 'oc version --client'.
+var clientVersionRE = regexp.MustCompile(`Client Version:\s*v?(\d+\.\d+\.\d+\S*)`)
+
 func run(_ *cobra.Command, _ []string) {
 	reporter := rprtr.CreateReporterOrExit()
 
@@ -46,6 +87,10 @@ func run(_ *cobra.Command, _ []string) {
 
 	output, err := exec.Command("oc", "version", "--client").Output()
 	if output == nil && err != nil {
+		if args.download {
+			install(reporter, "")
+			return
+		}
 		reporter.Warnf("OpenShift command-line tool is not installed.\n" +
 			"Run 'rosa download oc' to download the latest version, then add it to your PATH.")
 		return
@@ -61,6 +106,10 @@ func run(_ *cobra.Command, _ []string) {
 
 	if !isCorrectVersion {
 		reporter.Warnf("Current OpenShift %s", version)
+		if args.download {
+			install(reporter, parseClientVersion(string(output)))
+			return
+		}
 		reporter.Warnf("Your version of the OpenShift command-line tool is not supported.\n" +
 			"Run 'rosa download oc' to download the latest version, then add it to your PATH.")
 		return
@@ -69,4 +118,110 @@ func run(_ *cobra.Command, _ []string) {
 	if reporter.IsTerminal() {
 		reporter.Infof("Current OpenShift %s", version)
 	}
+
+	// The mirror is only checked when an installation was requested, as this command also runs as
+	// part of other commands:
+	if !args.download {
+		return
+	}
+
+	current := parseClientVersion(string(output))
+	latest, err := helper.GetLatestOCVersion()
+	if err != nil {
+		reporter.Warnf("Failed to get the latest version of the OpenShift command-line tool: %v", err)
+		return
+	}
+	if !isOutdated(current, latest) {
+		if reporter.IsTerminal() {
+			reporter.Infof("Your OpenShift command-line tool is up to date.")
+		}
+		return
+	}
+	reporter.Infof("There is a newer version '%s' of the OpenShift command-line tool", latest)
+	install(reporter, current)
+}
+
+// parseClientVersion returns the version from the output of 'oc version --client', or an empty
+// string if it can't be found.
+func parseClientVersion(output string) string {
+	matches := clientVersionRE.FindStringSubmatch(output)
+	if len(matches) < 2 {
+		return ""
+	}
+	return matches[1]
+}
+
+// isOutdated checks if the current version is older than the latest one. Versions that can't be
+// parsed are considered outdated.
+func isOutdated(current string, latest string) bool {
+	currentVersion, err := version.NewVersion(current)
+	if err != nil {
+		return true
+	}
+	latestVersion, err := version.NewVersion(latest)
+	if err != nil {
+		return false
+	}
+	return currentVersion.LessThan(latestVersion)
+}
+
+// install downloads the latest OpenShift client tools for the host, verifies its checksum and
+// replaces the current binary.
+func install(reporter *rprtr.Object, current string) {
+	filename := helper.GetOCArchiveName(runtime.GOOS, runtime.GOARCH)
+	binary := helper.GetOCBinaryName(runtime.GOOS)
+	destination := filepath.Join(getInstallDir(binary), binary)
+
+	question := fmt.Sprintf("install the latest OpenShift command-line tool to '%s'", destination)
+	if current != "" {
+		question = fmt.Sprintf("replace OpenShift command-line tool '%s' at '%s' with the latest version",
+			current, destination)
+	}
+	if !confirm.Confirm(question) {
+		return
+	}
+
+	checksum, err := helper.GetOCChecksum(filename)
+	if err != nil {
+		reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+
+	downloadURL := helper.OCLatestMirrorFolder + filename
+	reporter.Infof("Downloading %s", downloadURL)
+	archive, cleanup, err := helper.DownloadToTempDir(downloadURL, filename)
+	if err != nil {
+		reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+	defer cleanup()
+
+	err = helper.VerifyChecksum(archive, checksum)
+	if err != nil {
+		reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+	reporter.Infof("Verified checksum of %s", filename)
+
+	err = helper.ExtractBinary(archive, binary, destination)
+	if err != nil {
+		reporter.Errorf("Failed to install OpenShift command-line tool: %v", err)
+		os.Exit(1)
+	}
+	reporter.Infof("Successfully installed OpenShift command-line tool to '%s'", destination)
+}
+
+// getInstallDir returns the directory where the binary is installed: the one given by the user, the
+// one of the binary found in the PATH, or the current directory.
+func getInstallDir(binary string) string {
+	if args.installDir != "" {
+		return args.installDir
+	}
+	if path, err := exec.LookPath(binary); err == nil {
+		if resolved, err := filepath.EvalSymlinks(path); err == nil {
+			path = resolved
+		}
+		return filepath.Dir(path)
+	}
+	return "."
 }
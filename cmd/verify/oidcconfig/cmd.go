@@ -0,0 +1,173 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oidcconfig
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/helper/oidc"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var args struct {
+	oidcConfigID        string
+	operatorRolesPrefix string
+	hostedCP            bool
+}
+
+var Cmd = &cobra.Command{
+	Use:     "oidc-config",
+	Aliases: []string{"oidcconfig"},
+	Short:   "Verify the OIDC provider and the trust of the operator roles",
+	Long: "Verify that the issuer URL of an OIDC configuration is reachable, that the IAM OIDC provider " +
+		"of the account has the expected client IDs and thumbprint, and that the trust policy of each " +
+		"operator role references the provider and the service accounts of the operator.",
+	Example: `  # Verify the OIDC provider and operator roles of a cluster named "mycluster"
+  rosa verify oidc-config --cluster=mycluster
+
+  # Verify a reusable OIDC configuration and the operator roles created for it before creating a cluster
+  rosa verify oidc-config --oidc-config-id=<id> --operator-roles-prefix=mycluster --hosted-cp`,
+	Run:  run,
+	Args: cobra.NoArgs,
+}
+
+func init() {
+	flags := Cmd.Flags()
+
+	ocm.AddOptionalClusterFlag(Cmd)
+
+	flags.StringVar(
+		&args.oidcConfigID,
+		"oidc-config-id",
+		"",
+		"ID of the OIDC configuration to verify, instead of the one of a cluster.",
+	)
+
+	flags.StringVar(
+		&args.operatorRolesPrefix,
+		"operator-roles-prefix",
+		"",
+		"Prefix of the operator roles to verify along with the OIDC configuration.",
+	)
+
+	flags.BoolVar(
+		&args.hostedCP,
+		"hosted-cp",
+		false,
+		"Verify the operator roles of a Hosted Control Plane cluster.",
+	)
+
+	output.AddFlag(Cmd)
+}
+
+func run(cmd *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS().WithOCM()
+	defer r.Cleanup()
+
+	if args.oidcConfigID == "" && !cmd.Flags().Changed("cluster") {
+		r.Reporter.Errorf("Either a cluster or an OIDC configuration ID must be specified")
+		os.Exit(1)
+	}
+	if args.oidcConfigID != "" && cmd.Flags().Changed("cluster") {
+		r.Reporter.Errorf("A cluster and an OIDC configuration ID can't be specified together")
+		os.Exit(1)
+	}
+
+	var issuerURL string
+	var roles []oidc.OperatorRole
+	if args.oidcConfigID != "" {
+		oidcConfig, err := r.OCMClient.GetOidcConfig(args.oidcConfigID)
+		if err != nil {
+			r.Reporter.Errorf("Failed to get OIDC configuration '%s': %v", args.oidcConfigID, err)
+			os.Exit(1)
+		}
+		issuerURL = oidcConfig.IssuerUrl()
+		if args.operatorRolesPrefix != "" {
+			credRequests := getCredRequests(r, args.hostedCP)
+			for _, operator := range credRequests {
+				roles = append(roles, oidc.NewOperatorRole(operator.Namespace(), operator.Name(),
+					aws.ComputeOperatorRoleArn(args.operatorRolesPrefix, operator, r.Creator, ""), credRequests))
+			}
+		}
+	} else {
+		if args.operatorRolesPrefix != "" || args.hostedCP {
+			r.Reporter.Errorf("The operator roles of a cluster are verified without a prefix")
+			os.Exit(1)
+		}
+		clusterKey := r.GetClusterKey()
+		cluster := r.FetchCluster()
+		if cluster.AWS().STS().RoleARN() == "" {
+			r.Reporter.Errorf("Cluster '%s' is not an STS cluster", clusterKey)
+			os.Exit(1)
+		}
+		issuerURL = cluster.AWS().STS().OIDCEndpointURL()
+		credRequests := getCredRequests(r, cluster.Hypershift().Enabled())
+		for _, role := range cluster.AWS().STS().OperatorIAMRoles() {
+			roles = append(roles, oidc.NewOperatorRole(role.Namespace(), role.Name(), role.RoleARN(),
+				credRequests))
+		}
+	}
+
+	results := oidc.Verify(r.AWSClient, r.Creator.AccountID, issuerURL, roles)
+	failed := oidc.Failed(results)
+	if output.HasFlag() {
+		err := output.Print(results)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+		if len(failed) > 0 {
+			os.Exit(1)
+		}
+		return
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "CHECK\tTARGET\tRESULT\n")
+	for _, result := range results {
+		status := "ok"
+		if result.Error != "" {
+			status = result.Error
+		} else if result.Warning != "" {
+			status = "warning: " + result.Warning
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\n", result.Check, result.Target, status)
+	}
+	writer.Flush()
+	if len(failed) > 0 {
+		r.Reporter.Errorf("%d of %d OIDC checks failed", len(failed), len(results))
+		os.Exit(1)
+	}
+	r.Reporter.Infof("OIDC configuration of '%s' is valid", issuerURL)
+}
+
+func getCredRequests(r *rosa.Runtime, hostedCP bool) map[string]*cmv1.STSOperator {
+	credRequests, err := r.OCMClient.GetCredRequests(hostedCP)
+	if err != nil {
+		r.Reporter.Errorf("Failed to get operator credential requests: %v", err)
+		os.Exit(1)
+	}
+	return credRequests
+}
@@ -20,11 +20,14 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"text/tabwriter"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/openshift/rosa/pkg/arguments"
 	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/output"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -32,12 +35,17 @@ var Cmd = &cobra.Command{
 	Use:     "permissions",
 	Aliases: []string{"scp"},
 	Short:   "Verify AWS permissions are ok for non-STS cluster install",
-	Long:    "Verify AWS permissions needed to create a non-STS cluster are configured as expected",
+	Long: "Verify AWS permissions needed to create a non-STS cluster are configured as expected. " +
+		"The verification takes into account the service control policies of the organization and " +
+		"the permissions boundary of the principal, and reports what denies each action.",
 	Example: `  # Verify AWS permissions are configured correctly
   rosa verify permissions
 
   # Verify AWS permissions in a different region
-  rosa verify permissions --region=us-west-2`,
+  rosa verify permissions --region=us-west-2
+
+  # List the denied actions as JSON
+  rosa verify permissions -o json`,
 	Run: run,
 }
 
@@ -46,6 +54,7 @@ func init() {
 
 	arguments.AddProfileFlag(flags)
 	arguments.AddRegionFlag(flags)
+	output.AddFlag(Cmd)
 }
 
 func run(cmd *cobra.Command, _ []string) {
@@ -73,6 +82,20 @@ func run(cmd *cobra.Command, _ []string) {
 		os.Exit(1)
 	}
 
+	credentialsInfo, err := r.AWSClient.GetCredentialsInfo()
+	if err != nil {
+		r.Reporter.Errorf("Failed to get AWS credentials: %v", err)
+		os.Exit(1)
+	}
+	if !output.HasFlag() || r.Reporter.IsTerminal() {
+		if credentialsInfo.Expiration.IsZero() {
+			r.Reporter.Infof("Using AWS credentials from %s", credentialsInfo.Source)
+		} else {
+			r.Reporter.Infof("Using AWS credentials from %s, which expire at %s", credentialsInfo.Source,
+				credentialsInfo.Expiration.Format(time.RFC3339))
+		}
+	}
+
 	r.Reporter.Infof("Verifying permissions for non-STS clusters")
 	r.Reporter.Infof("Validating SCP policies...")
 	policies, err := r.OCMClient.GetPolicies("OSDSCPPolicy")
@@ -80,7 +103,7 @@ func run(cmd *cobra.Command, _ []string) {
 		r.Reporter.Errorf("Failed to get 'osdscppolicy' for '%s': %v", aws.AdminUserName, err)
 		os.Exit(1)
 	}
-	ok, err := r.AWSClient.ValidateSCP(nil, policies)
+	denials, err := r.AWSClient.VerifySCP(nil, policies)
 	if err != nil {
 		r.OCMClient.LogEvent("ROSAVerifyPermissionsSCPFailed", nil)
 		r.Reporter.Errorf("Unable to validate SCP policies. Make sure that an organizational " +
@@ -92,9 +115,27 @@ func run(cmd *cobra.Command, _ []string) {
 		r.Reporter.Errorf("%v", err)
 		os.Exit(1)
 	}
-	if !ok {
-		r.OCMClient.LogEvent("ROSAVerifyPermissionsSCPInvalid", nil)
-		r.Reporter.Warnf("Failed to validate SCP policies. Will try to continue anyway...")
+	if output.HasFlag() {
+		err = output.Print(denials)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+		if len(denials) > 0 {
+			os.Exit(1)
+		}
+		return
+	}
+	if len(denials) > 0 {
+		r.OCMClient.LogEvent("ROSAVerifyPermissionsSCPFailed", nil)
+		r.Reporter.Errorf("%d actions are not allowed with the tested credentials:", len(denials))
+		writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(writer, "ACTION\tRESOURCE\tDENIED BY\n")
+		for _, denial := range denials {
+			fmt.Fprintf(writer, "%s\t%s\t%s\n", denial.Action, denial.Resource, denial.DeniedBy)
+		}
+		writer.Flush()
+		os.Exit(1)
 	}
 	r.Reporter.Infof("AWS SCP policies ok")
 }
@@ -52,15 +52,15 @@ func run(_ *cobra.Command, _ []string) {
 		rprtr.Errorf("There was a problem retrieving current version: %s", err)
 		os.Exit(1)
 	}
-	latestVersionFromMirror, err := retrieveLatestVersionFromMirror()
+	latestVersionFromMirror, err := RetrieveLatestVersionFromMirror()
 	if err != nil {
 		rprtr.Errorf("There was a problem retrieving latest version from mirror: %s", err)
 		os.Exit(1)
 	}
 	if currVersion.LessThan(latestVersionFromMirror) {
 		rprtr.Warnf(
-			"There is a newer release version '%s', please consider updating: %s",
-			latestVersionFromMirror, DownloadLatestMirrorFolder,
+			"There is a newer release version '%s', please consider updating with 'rosa upgrade rosa' "+
+				"or from %s", latestVersionFromMirror, DownloadLatestMirrorFolder,
 		)
 	} else if rprtr.IsTerminal() {
 		rprtr.Infof("Your ROSA CLI is up to date.")
@@ -96,7 +96,8 @@ func retrievePossibleVersionsFromMirror() ([]string, error) {
 	return possibleVersions, nil
 }
 
-func retrieveLatestVersionFromMirror() (*version.Version, error) {
+// RetrieveLatestVersionFromMirror returns the latest version of the ROSA CLI released in the mirror.
+func RetrieveLatestVersionFromMirror() (*version.Version, error) {
 	possibleVersions, err := retrievePossibleVersionsFromMirror()
 	if err != nil {
 		return nil, weberr.Wrapf(err, "There was a problem retrieving possible versions from mirror.")
@@ -20,6 +20,8 @@ import (
 	"fmt"
 	"os"
 	"sort"
+	"text/tabwriter"
+	"time"
 
 	amsv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
 	"github.com/spf13/cobra"
@@ -38,14 +40,27 @@ var Cmd = &cobra.Command{
 	Short: "Displays user account information",
 	Long:  "Displays information about your AWS and Red Hat accounts",
 	Example: `  # Displays user information
-  rosa whoami`,
+  rosa whoami
+
+  # Displays user information and the ROSA quota of the organization as JSON
+  rosa whoami --with-quota --output json`,
 	Run: run,
 }
 
+var args struct {
+	withQuota bool
+}
+
 func init() {
 	flags := Cmd.PersistentFlags()
 	arguments.AddProfileFlag(flags)
 	arguments.AddRegionFlag(flags)
+	Cmd.Flags().BoolVar(
+		&args.withQuota,
+		"with-quota",
+		false,
+		"Display the consumption of the ROSA cluster, compute node and add-on quotas of the organization.",
+	)
 	output.AddFlag(Cmd)
 }
 
@@ -121,8 +136,29 @@ func run(_ *cobra.Command, _ []string) {
 	if account.Organization().ExternalID() != "" {
 		outputObject["OCM Organization External ID"] = account.Organization().ExternalID()
 	}
+	credentialsInfo, err := r.AWSClient.GetCredentialsInfo()
+	if err != nil {
+		r.Reporter.Errorf("Failed to get AWS credentials: %v", err)
+		os.Exit(1)
+	}
+	outputObject["AWS Credentials Source"] = credentialsInfo.Source
+	if !credentialsInfo.Expiration.IsZero() {
+		outputObject["AWS Credentials Expiration"] = credentialsInfo.Expiration.Format(time.RFC3339)
+	}
+
+	var quota []*ocm.QuotaSummary
+	if args.withQuota {
+		quota, err = r.OCMClient.GetROSAQuotaSummary()
+		if err != nil {
+			r.Reporter.Errorf("Failed to get organization quota: %v", err)
+			os.Exit(1)
+		}
+	}
 
 	if output.HasFlag() {
+		if args.withQuota {
+			outputObject["OCM Organization Quota"] = quota
+		}
 		err = output.Print(outputObject)
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
@@ -138,6 +174,21 @@ func run(_ *cobra.Command, _ []string) {
 	for _, key := range keys {
 		fmt.Printf("%-30s%v\n", key+":", outputObject[key])
 	}
+
+	if args.withQuota {
+		fmt.Println()
+		if len(quota) == 0 {
+			fmt.Println("The organization has no ROSA quota")
+			return
+		}
+		writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(writer, "QUOTA ID\tRESOURCE TYPE\tALLOWED\tCONSUMED\tREMAINING\n")
+		for _, q := range quota {
+			fmt.Fprintf(writer, "%s\t%s\t%d\t%d\t%d\n",
+				q.QuotaID, q.ResourceType, q.Allowed, q.Consumed, q.Remaining)
+		}
+		writer.Flush()
+	}
 	fmt.Println()
 }
 
@@ -150,7 +201,7 @@ func getAccountDataFromToken(cfg *config.Config) (*amsv1.Account, error) {
 	if err != nil {
 		return nil, err
 	}
-	username, err := cfg.GetData("username")
+	username, err := cfg.Username()
 	if err != nil {
 		return nil, err
 	}
@@ -25,6 +25,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
+	"github.com/openshift/rosa/pkg/aws/assumerole"
 	"github.com/openshift/rosa/pkg/aws/profile"
 	"github.com/openshift/rosa/pkg/aws/region"
 	"github.com/openshift/rosa/pkg/debug"
@@ -201,9 +202,11 @@ func AddDebugFlag(fs *pflag.FlagSet) {
 	debug.AddFlag(fs)
 }
 
-// AddProfileFlag adds the '--profile' flag to the given set of command line flags.
+// AddProfileFlag adds the '--profile' flag, and the flags that select the AWS role to assume with its
+// credentials, to the given set of command line flags.
 func AddProfileFlag(fs *pflag.FlagSet) {
 	profile.AddFlag(fs)
+	assumerole.AddFlag(fs)
 }
 
 func GetProfile() string {
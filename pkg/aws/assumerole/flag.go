@@ -0,0 +1,68 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains functions used to implement the '--aws-role-arn' and '--aws-role-external-id'
+// command line options.
+
+package assumerole
+
+import (
+	"fmt"
+
+	"github.com/spf13/pflag"
+)
+
+// AddFlag adds the assume role flags to the given set of command line flags.
+func AddFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&roleARN,
+		"aws-role-arn",
+		"",
+		"Assume this AWS role, using the credentials of the selected AWS profile, before calling AWS. "+
+			"Use it to act on another AWS account without changing the environment.",
+	)
+	flags.StringVar(
+		&externalID,
+		"aws-role-external-id",
+		"",
+		"External ID required by the trust policy of the role given with --aws-role-arn.",
+	)
+}
+
+// Validate checks that the external ID is only given together with the role.
+func Validate() error {
+	if externalID != "" && roleARN == "" {
+		return fmt.Errorf("The '--aws-role-external-id' option requires '--aws-role-arn'")
+	}
+	return nil
+}
+
+// RoleARN returns the ARN of the AWS role to assume, or an empty string if the credentials of the AWS
+// profile are used directly.
+func RoleARN() string {
+	return roleARN
+}
+
+// ExternalID returns the external ID to pass when assuming the AWS role.
+func ExternalID() string {
+	return externalID
+}
+
+// roleARN is a string flag that indicates the AWS role to assume.
+var roleARN string
+
+// externalID is a string flag that indicates the external ID to pass when assuming the AWS role.
+var externalID string
@@ -0,0 +1,102 @@
+package aws
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sirupsen/logrus"
+)
+
+const assumeRoleResponse = `<AssumeRoleResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/">
+  <AssumeRoleResult>
+    <Credentials>
+      <AccessKeyId>AKIAROLE</AccessKeyId>
+      <SecretAccessKey>role-secret</SecretAccessKey>
+      <SessionToken>role-session</SessionToken>
+      <Expiration>2099-01-01T00:00:00Z</Expiration>
+    </Credentials>
+    <AssumedRoleUser>
+      <Arn>arn:aws:sts::123456789012:assumed-role/target/rosa-cli</Arn>
+      <AssumedRoleId>AROAEXAMPLE:rosa-cli</AssumedRoleId>
+    </AssumedRoleUser>
+  </AssumeRoleResult>
+  <ResponseMetadata>
+    <RequestId>c6104cbe-af31-11e0-8154-cbc7ccf896c7</RequestId>
+  </ResponseMetadata>
+</AssumeRoleResponse>`
+
+var _ = Describe("Assume role", func() {
+	var (
+		server   *httptest.Server
+		requests []url.Values
+		builder  *ClientBuilder
+		sess     *session.Session
+	)
+
+	BeforeEach(func() {
+		requests = nil
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer GinkgoRecover()
+			Expect(r.ParseForm()).To(Succeed())
+			requests = append(requests, r.PostForm)
+			fmt.Fprint(w, assumeRoleResponse)
+		}))
+		builder = &ClientBuilder{logger: logrus.New()}
+		var err error
+		sess, err = session.NewSession(&aws.Config{
+			Region:      aws.String("us-east-1"),
+			Endpoint:    aws.String(server.URL),
+			Credentials: credentials.NewStaticCredentials("AKIAPROFILE", "profile-secret", ""),
+		})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("uses the credentials of the role obtained with the ones of the session", func() {
+		roleSess, err := builder.assumeRole(sess, "arn:aws:iam::123456789012:role/target", "")
+		Expect(err).ToNot(HaveOccurred())
+		value, err := roleSess.Config.Credentials.Get()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(value.AccessKeyID).To(Equal("AKIAROLE"))
+		Expect(value.SessionToken).To(Equal("role-session"))
+		Expect(requests).To(HaveLen(1))
+		Expect(requests[0].Get("Action")).To(Equal("AssumeRole"))
+		Expect(requests[0].Get("RoleArn")).To(Equal("arn:aws:iam::123456789012:role/target"))
+		Expect(requests[0].Get("RoleSessionName")).To(Equal("rosa-cli"))
+		Expect(requests[0]).ToNot(HaveKey("ExternalId"))
+
+		// The original session keeps using the credentials of the profile:
+		value, err = sess.Config.Credentials.Get()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(value.AccessKeyID).To(Equal("AKIAPROFILE"))
+	})
+
+	It("passes the external ID", func() {
+		roleSess, err := builder.assumeRole(sess, "arn:aws:iam::123456789012:role/target", "my-external-id")
+		Expect(err).ToNot(HaveOccurred())
+		_, err = roleSess.Config.Credentials.Get()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(requests).To(HaveLen(1))
+		Expect(requests[0].Get("ExternalId")).To(Equal("my-external-id"))
+	})
+
+	DescribeTable("rejects invalid role ARNs",
+		func(roleARN string) {
+			_, err := builder.assumeRole(sess, roleARN, "")
+			Expect(err).To(MatchError(ContainSubstring("to be a valid IAM role ARN")))
+			Expect(requests).To(BeEmpty())
+		},
+		Entry("not an ARN", "target"),
+		Entry("user ARN", "arn:aws:iam::123456789012:user/target"),
+	)
+})
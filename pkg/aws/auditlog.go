@@ -0,0 +1,72 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+)
+
+// AuditLogServiceAccount is the service account of the control plane of hosted clusters that
+// forwards the audit logs of the API server to the CloudWatch of the customer.
+const AuditLogServiceAccount = "system:serviceaccount:openshift-config-managed:cloudwatch-audit-exporter"
+
+// ValidateAuditLogRoleARN checks that the audit log forwarding role is an IAM role of the partition of
+// the region of the cluster, as the logs are sent to the CloudWatch of that region.
+func ValidateAuditLogRoleARN(roleARN string, region string) error {
+	parsed, err := arn.Parse(roleARN)
+	if err != nil {
+		return fmt.Errorf("Expected a valid audit log role ARN: %v", err)
+	}
+	if parsed.Service != "iam" || !strings.HasPrefix(parsed.Resource, "role/") {
+		return fmt.Errorf("Expected audit log role ARN '%s' to be an IAM role", roleARN)
+	}
+	partition, ok := endpoints.PartitionForRegion(endpoints.DefaultPartitions(), region)
+	if !ok {
+		return fmt.Errorf("Unknown region '%s' for the audit log forwarding", region)
+	}
+	if parsed.Partition != partition.ID() {
+		return fmt.Errorf("Audit log role '%s' is in partition '%s', but the logs are forwarded to "+
+			"region '%s' of partition '%s'", roleARN, parsed.Partition, region, partition.ID())
+	}
+	return nil
+}
+
+// ValidateAuditLogRole checks that the trust policy of the audit log forwarding role allows the
+// control plane of the cluster, identified by the issuer of its OIDC provider, to assume it.
+func (c *awsClient) ValidateAuditLogRole(roleARN string, issuerURL string) error {
+	parsed, err := arn.Parse(roleARN)
+	if err != nil {
+		return fmt.Errorf("Expected a valid audit log role ARN: %v", err)
+	}
+	role, err := c.GetRoleByARN(roleARN)
+	if err != nil {
+		return fmt.Errorf("Failed to get audit log role '%s': %v", roleARN, err)
+	}
+	providerARN := fmt.Sprintf("arn:%s:iam::%s:oidc-provider/%s", parsed.Partition, parsed.AccountID,
+		strings.TrimPrefix(issuerURL, "https://"))
+	err = VerifyOperatorRoleTrustPolicy(aws.StringValue(role.AssumeRolePolicyDocument), providerARN,
+		[]string{AuditLogServiceAccount})
+	if err != nil {
+		return fmt.Errorf("Audit log role '%s' can't be used by the cluster: %v", roleARN, err)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package aws_test
+
+import (
+	"net/url"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/aws/mocks"
+)
+
+var _ = Describe("Audit log role", func() {
+	const roleARN = "arn:aws:iam::123456789012:role/audit-log"
+
+	DescribeTable("ValidateAuditLogRoleARN",
+		func(roleARN string, region string, message string) {
+			err := aws.ValidateAuditLogRoleARN(roleARN, region)
+			if message == "" {
+				Expect(err).ToNot(HaveOccurred())
+			} else {
+				Expect(err).To(MatchError(ContainSubstring(message)))
+			}
+		},
+		Entry("role in the partition of the region", roleARN, "us-east-1", ""),
+		Entry("GovCloud role in a GovCloud region", "arn:aws-us-gov:iam::123456789012:role/audit-log",
+			"us-gov-west-1", ""),
+		Entry("not an ARN", "audit-log", "us-east-1", "Expected a valid audit log role ARN"),
+		Entry("policy", "arn:aws:iam::123456789012:policy/audit-log", "us-east-1", "to be an IAM role"),
+		Entry("commercial role in a GovCloud region", roleARN, "us-gov-west-1",
+			"is in partition 'aws', but the logs are forwarded to region 'us-gov-west-1' of partition 'aws-us-gov'"),
+		Entry("unknown region", roleARN, "moon-1", "Unknown region 'moon-1'"),
+	)
+
+	Context("Trust policy", func() {
+		const issuerURL = "https://oidc.example.com/abc"
+
+		var (
+			client     aws.Client
+			mockCtrl   *gomock.Controller
+			mockIamAPI *mocks.MockIAMAPI
+		)
+
+		BeforeEach(func() {
+			mockCtrl = gomock.NewController(GinkgoT())
+			mockIamAPI = mocks.NewMockIAMAPI(mockCtrl)
+			client = aws.New(
+				logrus.New(),
+				mockIamAPI,
+				mocks.NewMockEC2API(mockCtrl),
+				mocks.NewMockOrganizationsAPI(mockCtrl),
+				mocks.NewMockS3API(mockCtrl),
+				mocks.NewMockSecretsManagerAPI(mockCtrl),
+				mocks.NewMockSTSAPI(mockCtrl),
+				mocks.NewMockCloudFormationAPI(mockCtrl),
+				mocks.NewMockServiceQuotasAPI(mockCtrl),
+				&session.Session{},
+				&aws.AccessKey{},
+			)
+		})
+
+		AfterEach(func() {
+			mockCtrl.Finish()
+		})
+
+		returnTrustPolicy := func(subject string) {
+			policy := `{
+				"Version": "2012-10-17",
+				"Statement": [{
+					"Effect": "Allow",
+					"Principal": {
+						"Federated": "arn:aws:iam::123456789012:oidc-provider/oidc.example.com/abc"
+					},
+					"Action": "sts:AssumeRoleWithWebIdentity",
+					"Condition": {
+						"StringEquals": {"oidc.example.com/abc:sub": "` + subject + `"}
+					}
+				}]
+			}`
+			mockIamAPI.EXPECT().GetRole(gomock.Any()).Return(&iam.GetRoleOutput{
+				Role: &iam.Role{
+					Arn:                      awssdk.String(roleARN),
+					AssumeRolePolicyDocument: awssdk.String(url.QueryEscape(policy)),
+				},
+			}, nil)
+		}
+
+		It("Accepts a role trusted by the audit log exporter of the cluster", func() {
+			returnTrustPolicy(aws.AuditLogServiceAccount)
+			Expect(client.ValidateAuditLogRole(roleARN, issuerURL)).To(Succeed())
+		})
+
+		It("Rejects a role trusted by another service account", func() {
+			returnTrustPolicy("system:serviceaccount:openshift-ingress-operator:ingress-operator")
+			err := client.ValidateAuditLogRole(roleARN, issuerURL)
+			Expect(err).To(MatchError(ContainSubstring("can't be used by the cluster")))
+		})
+
+		It("Rejects a role trusted by the OIDC provider of another cluster", func() {
+			returnTrustPolicy(aws.AuditLogServiceAccount)
+			err := client.ValidateAuditLogRole(roleARN, "https://oidc.example.com/other")
+			Expect(err).To(MatchError(ContainSubstring("oidc-provider/oidc.example.com/other")))
+		})
+	})
+})
@@ -30,6 +30,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/client"
 	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/cloudformation"
@@ -46,6 +47,8 @@ import (
 	"github.com/aws/aws-sdk-go/service/secretsmanager/secretsmanageriface"
 	"github.com/aws/aws-sdk-go/service/servicequotas"
 	"github.com/aws/aws-sdk-go/service/servicequotas/servicequotasiface"
+	"github.com/aws/aws-sdk-go/service/sso"
+	"github.com/aws/aws-sdk-go/service/ssooidc"
 	"github.com/aws/aws-sdk-go/service/sts"
 	"github.com/aws/aws-sdk-go/service/sts/stsiface"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
@@ -54,11 +57,13 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/zgalor/weberr"
 
+	"github.com/openshift/rosa/pkg/aws/assumerole"
 	"github.com/openshift/rosa/pkg/aws/profile"
 	regionflag "github.com/openshift/rosa/pkg/aws/region"
 	"github.com/openshift/rosa/pkg/aws/tags"
 	"github.com/openshift/rosa/pkg/info"
 	"github.com/openshift/rosa/pkg/logging"
+	"github.com/openshift/rosa/pkg/retry"
 )
 
 // Name of the AWS user that will be used to create all the resources of the cluster:
@@ -92,16 +97,30 @@ type Client interface {
 	ValidateCredentials() (isValid bool, err error)
 	EnsureOsdCcsAdminUser(stackName string, adminUserName string, awsRegion string) (bool, error)
 	DeleteOsdCcsAdminUser(stackName string) error
+	CreateNetworkStack(input NetworkStackInput) (*NetworkStack, error)
+	GetNetworkStack(name string) (*NetworkStack, error)
+	DeleteNetworkStack(name string) error
 	GetAWSAccessKeys() (*AccessKey, error)
 	GetLocalAWSAccessKeys() (*AccessKey, error)
 	GetCreator() (*Creator, error)
+	GetCredentialsInfo() (*CredentialsInfo, error)
 	ValidateSCP(*string, map[string]*cmv1.AWSSTSPolicy) (bool, error)
+	VerifySCP(*string, map[string]*cmv1.AWSSTSPolicy) ([]*PermissionDenial, error)
 	GetSubnetIDs() ([]*ec2.Subnet, error)
 	GetSubnetAvailabilityZone(subnetID string) (string, error)
 	GetVPCSubnets(subnetID string) ([]*ec2.Subnet, error)
+	ValidateSubnets(input SubnetValidationInput) error
+	ValidateSharedVPC(sharedVPCRoleARN string, baseDomain string, vpcID string) (string, error)
+	ValidateKMSKeyPolicy(keyARN string, grants []KMSKeyGrant) error
+	ValidateSecurityGroups(vpcID string, ids []string) error
 	GetVPCPrivateSubnets(subnetID string) ([]*ec2.Subnet, error)
 	FilterVPCsPrivateSubnets(subnets []*ec2.Subnet) ([]*ec2.Subnet, error)
 	ValidateQuota() (bool, error)
+	ValidateInstanceQuota(vCPUs map[string]int) error
+	GetInstanceTypeZones() (map[string][]string, error)
+	GetOnDemandPrices() (map[string]float64, error)
+	GetVolumePrice(volumeType string) (float64, error)
+	GetNATGatewayPrice() (float64, error)
 	TagUserRegion(username string, region string) error
 	GetClusterRegionTagForUser(username string) (string, error)
 	EnsureRole(name string, policy string, permissionsBoundary string,
@@ -113,7 +132,13 @@ type Client interface {
 	EnsurePolicy(policyArn string, document string, version string, tagList map[string]string,
 		path string) (string, error)
 	AttachRolePolicy(roleName string, policyARN string) error
-	CreateOpenIDConnectProvider(issuerURL string, thumbprint string, clusterID string) (string, error)
+	DetachRolePolicy(roleName string, policyARN string) error
+	IsRolePolicyAttached(roleName string, policyARN string) (bool, error)
+	ValidateAttachRolePolicies(roleName string, policyARNs []string) error
+	GetOpenIDConnectProvider(issuerURL string, accountID string) (*OIDCProvider, error)
+	ListROSAOpenIDConnectProviders() ([]*OIDCProvider, error)
+	CreateOpenIDConnectProvider(issuerURL string, thumbprint string, clusterID string,
+		userTags map[string]string) (string, error)
 	DeleteOpenIDConnectProvider(providerURL string) error
 	HasOpenIDConnectProvider(issuerURL string, accountID string) (bool, error)
 	FindRoleARNs(roleType string, version string) ([]string, error)
@@ -123,6 +148,8 @@ type Client interface {
 	ListAccountRoles(version string) ([]Role, error)
 	ListOperatorRoles(version string) (map[string][]Role, error)
 	GetRoleByARN(roleARN string) (*iam.Role, error)
+	ValidateAuditLogRole(roleARN string, issuerURL string) error
+	DetectRoleDrift(expected *ExpectedRole) (*RoleDrift, error)
 	HasCompatibleVersionTags(iamTags []*iam.Tag, version string) (bool, error)
 	DeleteOperatorRole(roles string, managedPolicies bool) error
 	GetOperatorRolesFromAccountByClusterID(clusterID string, credRequests map[string]*cmv1.STSOperator) ([]string, error)
@@ -137,6 +164,8 @@ type Client interface {
 	DeleteUserRole(roleName string) error
 	GetAccountRolePolicies(roles []string) (map[string][]PolicyDetail, error)
 	GetAttachedPolicy(role *string) ([]PolicyDetail, error)
+	GetRolePolicies(roleName string, withDocuments bool) ([]RolePolicy, error)
+	GetClusterTaggedResources(credRequests map[string]*cmv1.STSOperator) ([]*ClusterResources, error)
 	HasPermissionsBoundary(roleName string) (bool, error)
 	GetOpenIDConnectProviderByClusterIdTag(clusterID string) (string, error)
 	GetOpenIDConnectProviderByOidcEndpointUrl(oidcEndpointUrl string) (string, error)
@@ -169,6 +198,9 @@ type Client interface {
 	GetRoleARNPath(prefix string) (string, error)
 	DescribeAvailabilityZones() ([]string, error)
 	IsLocalAvailabilityZone(availabilityZoneName string) (bool, error)
+	GetEdgeZone(zoneName string) (*EdgeZone, error)
+	GetRegionDetails(localZoneRegions []string) (map[string]*RegionDetails, error)
+	IsInstanceTypeOfferedInZone(instanceType string, zoneName string) (bool, error)
 	DetachRolePolicies(roleName string) error
 	HasManagedPolicies(roleARN string) (bool, error)
 	HasHostedCPPolicies(roleARN string) (bool, error)
@@ -177,10 +209,10 @@ type Client interface {
 	ValidateHCPAccountRolesManagedPolicies(prefix string, policies map[string]*cmv1.AWSSTSPolicy) error
 	ValidateOperatorRolesManagedPolicies(cluster *cmv1.Cluster, operatorRoles map[string]*cmv1.STSOperator,
 		policies map[string]*cmv1.AWSSTSPolicy, hostedCPPolicies bool) error
-	CreateS3Bucket(bucketName string, region string) error
+	CreateS3Bucket(bucketName string, region string, userTags map[string]string) error
 	DeleteS3Bucket(bucketName string) error
-	PutPublicReadObjectInS3Bucket(bucketName string, body io.ReadSeeker, key string) error
-	CreateSecretInSecretsManager(name string, secret string) (string, error)
+	PutPublicReadObjectInS3Bucket(bucketName string, body io.ReadSeeker, key string, userTags map[string]string) error
+	CreateSecretInSecretsManager(name string, secret string, userTags map[string]string) (string, error)
 	DeleteSecretInSecretsManager(secretArn string) error
 }
 
@@ -294,6 +326,54 @@ func (b *ClientBuilder) BuildSessionWithOptions() (*session.Session, error) {
 	})
 }
 
+// BuildSessionWithSSOSession creates an AWS session that obtains the credentials of the given profile
+// from its SSO session.
+func (b *ClientBuilder) BuildSessionWithSSOSession(config *ssoProfile) (*session.Session, error) {
+	tokenFile, err := ssoTokenFile(config.SessionName)
+	if err != nil {
+		return nil, err
+	}
+	sess, err := session.NewSessionWithOptions(session.Options{
+		SharedConfigState: session.SharedConfigDisable,
+		Config: aws.Config{
+			CredentialsChainVerboseErrors: aws.Bool(true),
+			Region:                        b.region,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	b.logger.Debugf("Using AWS SSO session: %s", config.SessionName)
+	ssoRegion := aws.NewConfig().WithRegion(config.SSORegion)
+	sess.Config.Credentials = credentials.NewCredentials(&ssoSessionProvider{
+		profile:    config,
+		tokenFile:  tokenFile,
+		ssoClient:  sso.New(sess, ssoRegion),
+		oidcClient: ssooidc.New(sess, ssoRegion),
+		now:        time.Now,
+	})
+	return sess, nil
+}
+
+// assumeRole returns a copy of the session that uses the credentials of the given role, obtained
+// with the credentials of the session. As the AWS profile may itself assume a role, this allows to
+// chain roles to reach other AWS accounts.
+func (b *ClientBuilder) assumeRole(sess *session.Session, roleARN string,
+	externalID string) (*session.Session, error) {
+	parsedARN, err := arn.Parse(roleARN)
+	if err != nil || !strings.HasPrefix(parsedARN.Resource, "role/") {
+		return nil, fmt.Errorf("Expected '%s' to be a valid IAM role ARN", roleARN)
+	}
+	b.logger.Debugf("Assuming AWS role: %s", roleARN)
+	creds := stscreds.NewCredentials(sess, roleARN, func(provider *stscreds.AssumeRoleProvider) {
+		provider.RoleSessionName = "rosa-cli"
+		if externalID != "" {
+			provider.ExternalID = aws.String(externalID)
+		}
+	})
+	return sess.Copy(&aws.Config{Credentials: creds}), nil
+}
+
 // Build uses the information stored in the builder to build a new AWS client.
 func (b *ClientBuilder) Build() (Client, error) {
 	// Check parameters:
@@ -311,6 +391,19 @@ func (b *ClientBuilder) Build() (Client, error) {
 
 	var sess *session.Session
 
+	// Profiles that use an SSO session can't be loaded by the AWS SDK, so they are handled
+	// separately:
+	var ssoConfig *ssoProfile
+	if b.credentials == nil {
+		ssoConfig, err = loadSSOProfile(sharedConfigFile(), profile.Profile())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if (b.region == nil || *b.region == "") && ssoConfig != nil && regionflag.Region() == "" {
+		b.region = aws.String(ssoConfig.Region)
+	}
 	if b.region == nil || *b.region == "" {
 		region, err := GetRegion(regionflag.Region())
 		if err != nil {
@@ -326,10 +419,21 @@ func (b *ClientBuilder) Build() (Client, error) {
 	}
 
 	// Create the AWS session:
+	err = assumerole.Validate()
+	if err != nil {
+		return nil, err
+	}
 	if b.credentials != nil {
 		sess, err = b.BuildSessionWithOptionsCredentials(b.credentials)
 	} else {
-		sess, err = b.BuildSessionWithOptions()
+		if ssoConfig != nil {
+			sess, err = b.BuildSessionWithSSOSession(ssoConfig)
+		} else {
+			sess, err = b.BuildSessionWithOptions()
+		}
+		if err == nil && assumerole.RoleARN() != "" {
+			sess, err = b.assumeRole(sess, assumerole.RoleARN(), assumerole.ExternalID())
+		}
 	}
 	if err != nil {
 		return nil, err
@@ -347,6 +451,24 @@ func (b *ClientBuilder) Build() (Client, error) {
 	// same thing in getClientDetails()
 	// We should implement getClientDetails() here or a new validation func
 	_, err = sess.Config.Credentials.Get()
+	if err != nil && b.credentials == nil && isSSOLoginError(err) {
+		b.logger.Debugf("Failed to get SSO credentials: %v", err)
+		return nil, ssoLoginError(profile.Profile())
+	}
+	if err != nil && b.credentials == nil {
+		profileName := profile.Profile()
+		if profileName == "" {
+			profileName = "default"
+		}
+		processErr := credentialProcessError(profileName, err)
+		if processErr != nil {
+			b.logger.Debugf("Failed to run credential_process: %v", err)
+			return nil, processErr
+		}
+	}
+	if err != nil && b.credentials == nil && assumerole.RoleARN() != "" {
+		return nil, fmt.Errorf("Failed to assume AWS role '%s': %v", assumerole.RoleARN(), err)
+	}
 	if err != nil {
 		b.logger.Debugf("Failed to find credentials: %v", err)
 		return nil, fmt.Errorf("Failed to find credentials. Check your AWS configuration and try again")
@@ -364,6 +486,7 @@ func (b *ClientBuilder) Build() (Client, error) {
 		Logger:  logger,
 		HTTPClient: &http.Client{
 			Transport: http.DefaultTransport,
+			Timeout:   retry.RequestTimeout(),
 		},
 	})
 
@@ -378,6 +501,7 @@ func (b *ClientBuilder) Build() (Client, error) {
 		}
 		sess.Config.HTTPClient.Transport = dumper
 	}
+	sess.Config.HTTPClient.Transport = logging.TraceTransport(sess.Config.HTTPClient.Transport)
 
 	// Create and populate the object:
 	c := &awsClient{
@@ -967,7 +1091,7 @@ const ReadOnlyAnonUserPolicyTemplate = `{
 	]
 }`
 
-func (c *awsClient) CreateS3Bucket(bucketName string, region string) error {
+func (c *awsClient) CreateS3Bucket(bucketName string, region string, userTags map[string]string) error {
 	_, err := c.s3Client.HeadBucket(&s3.HeadBucketInput{
 		Bucket: aws.String(bucketName),
 	})
@@ -1008,15 +1132,20 @@ func (c *awsClient) CreateS3Bucket(bucketName string, region string) error {
 		return err
 	}
 
+	bucketTags := MergeUserTags(userTags, map[string]string{
+		tags.RedHatManaged: tags.True,
+	})
+	tagSet := []*s3.Tag{}
+	for _, key := range sortedKeys(bucketTags) {
+		tagSet = append(tagSet, &s3.Tag{
+			Key:   aws.String(key),
+			Value: aws.String(bucketTags[key]),
+		})
+	}
 	_, err = c.s3Client.PutBucketTagging(&s3.PutBucketTaggingInput{
 		Bucket: aws.String(bucketName),
 		Tagging: &s3.Tagging{
-			TagSet: []*s3.Tag{
-				{
-					Key:   aws.String(tags.RedHatManaged),
-					Value: aws.String(tags.True),
-				},
-			},
+			TagSet: tagSet,
 		},
 	})
 	if err != nil {
@@ -1067,12 +1196,15 @@ func (c *awsClient) emptyS3Bucket(bucketName string) error {
 	return nil
 }
 
-func (c *awsClient) PutPublicReadObjectInS3Bucket(bucketName string, body io.ReadSeeker, key string) error {
+func (c *awsClient) PutPublicReadObjectInS3Bucket(bucketName string, body io.ReadSeeker, key string,
+	userTags map[string]string) error {
 	_, err := c.s3Client.PutObject(&s3.PutObjectInput{
-		Body:    body,
-		Bucket:  aws.String(bucketName),
-		Key:     aws.String(key),
-		Tagging: aws.String(fmt.Sprintf("%s=%s", tags.RedHatManaged, tags.True)),
+		Body:   body,
+		Bucket: aws.String(bucketName),
+		Key:    aws.String(key),
+		Tagging: aws.String(GetObjectTagging(MergeUserTags(userTags, map[string]string{
+			tags.RedHatManaged: tags.True,
+		}))),
 	})
 	if err != nil {
 		return err
@@ -1080,16 +1212,24 @@ func (c *awsClient) PutPublicReadObjectInS3Bucket(bucketName string, body io.Rea
 	return nil
 }
 
-func (c *awsClient) CreateSecretInSecretsManager(name string, secret string) (string, error) {
+func (c *awsClient) CreateSecretInSecretsManager(name string, secret string,
+	userTags map[string]string) (string, error) {
+	secretTags := MergeUserTags(userTags, map[string]string{
+		tags.RedHatManaged: tags.True,
+	})
+	smTags := []*secretsmanager.Tag{}
+	for _, key := range sortedKeys(secretTags) {
+		smTags = append(smTags, &secretsmanager.Tag{
+			Key:   aws.String(key),
+			Value: aws.String(secretTags[key]),
+		})
+	}
 	createSecretResponse, err := c.smClient.CreateSecret(
 		&secretsmanager.CreateSecretInput{
 			Description:  aws.String(fmt.Sprintf("Secret for %s", name)),
 			Name:         aws.String(name),
 			SecretString: aws.String(secret),
-			Tags: []*secretsmanager.Tag{{
-				Key:   aws.String(tags.RedHatManaged),
-				Value: aws.String("true"),
-			}},
+			Tags:         smTags,
 		})
 	if err != nil {
 		return "", err
@@ -1140,7 +1280,7 @@ func (r CustomRetryer) ShouldRetry(req *request.Request) bool {
 func buildCustomRetryer() CustomRetryer {
 	return CustomRetryer{
 		DefaultRetryer: client.DefaultRetryer{
-			NumMaxRetries:    12,
+			NumMaxRetries:    retry.MaxRetries(12),
 			MinRetryDelay:    1 * time.Second,
 			MinThrottleDelay: 5 * time.Second,
 			MaxThrottleDelay: 5 * time.Second,
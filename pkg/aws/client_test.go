@@ -1,6 +1,7 @@
 package aws_test
 
 import (
+	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/cloudformation"
@@ -195,4 +196,45 @@ var _ = Describe("Client", func() {
 			})
 		})
 	})
+
+	Context("GetNetworkStack", func() {
+		It("returns the VPC and subnets from the outputs of the stack", func() {
+			mockCfAPI.EXPECT().DescribeStacks(gomock.Any()).Return(&cloudformation.DescribeStacksOutput{
+				Stacks: []*cloudformation.Stack{
+					{
+						Outputs: []*cloudformation.Output{
+							{OutputKey: awssdk.String("VpcId"), OutputValue: awssdk.String("vpc-1")},
+							{OutputKey: awssdk.String("PublicSubnetIds"), OutputValue: awssdk.String("subnet-1,subnet-2")},
+							{OutputKey: awssdk.String("PrivateSubnetIds"), OutputValue: awssdk.String("subnet-3,subnet-4")},
+						},
+					},
+				},
+			}, nil)
+
+			network, err := client.GetNetworkStack("my-network")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(network.VpcID).To(Equal("vpc-1"))
+			Expect(network.SubnetIDs()).To(Equal([]string{"subnet-1", "subnet-2", "subnet-3", "subnet-4"}))
+		})
+
+		It("rejects stacks that weren't created for a network", func() {
+			mockCfAPI.EXPECT().DescribeStacks(gomock.Any()).Return(&cloudformation.DescribeStacksOutput{
+				Stacks: []*cloudformation.Stack{{}},
+			}, nil)
+
+			_, err := client.GetNetworkStack(aws.OsdCcsAdminStackName)
+
+			Expect(err).To(MatchError(ContainSubstring("isn't a network stack")))
+		})
+
+		It("reports missing stacks", func() {
+			mockCfAPI.EXPECT().DescribeStacks(gomock.Any()).Return(nil,
+				awserr.New("ValidationError", "Stack with id my-network does not exist", nil))
+
+			_, err := client.GetNetworkStack("my-network")
+
+			Expect(err).To(MatchError("Network stack 'my-network' doesn't exist"))
+		})
+	})
 })
@@ -34,6 +34,8 @@ const (
 	CreateOpenIdConnectProvider   Command = "create-open-id-connect-provider"
 	DeleteOpenIdConnectProvider   Command = "delete-open-id-connect-provider"
 	DeleteRolePermissionsBoundary Command = "delete-role-permissions-boundary"
+	UpdateAssumeRolePolicy        Command = "update-assume-role-policy"
+	PutRolePolicy                 Command = "put-role-policy"
 	//S3Api
 	CreateBucket         Command = "create-bucket"
 	PutObject            Command = "put-object"
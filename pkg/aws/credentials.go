@@ -0,0 +1,106 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains functions that describe the AWS credentials in use, and explain the failures of
+// the external programs that AWS profiles can use to obtain them.
+
+package aws
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/ec2rolecreds"
+	"github.com/aws/aws-sdk-go/aws/credentials/processcreds"
+	"github.com/aws/aws-sdk-go/aws/credentials/ssocreds"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/session"
+)
+
+// CredentialsInfo describes where the AWS credentials in use come from and when they expire.
+type CredentialsInfo struct {
+	Source string
+	// Expiration is zero if the credentials don't expire
+	Expiration time.Time
+}
+
+// credentialsSources are the descriptions of the providers of the AWS SDK, indexed by name.
+var credentialsSources = map[string]string{
+	credentials.EnvProviderName:         "environment variables",
+	session.EnvProviderName:             "environment variables",
+	credentials.SharedCredsProviderName: "credentials file",
+	credentials.StaticProviderName:      "access keys",
+	processcreds.ProviderName:           "credential_process",
+	ssocreds.ProviderName:               "SSO",
+	ssoSessionProviderName:              "SSO session",
+	stscreds.ProviderName:               "assumed role",
+	stscreds.WebIdentityProviderName:    "web identity",
+	ec2rolecreds.ProviderName:           "instance profile",
+}
+
+// credentialsSource returns the description of the provider with the given name.
+func credentialsSource(providerName string) string {
+	if strings.HasPrefix(providerName, "SharedConfigCredentials") {
+		return "config file"
+	}
+	if source, ok := credentialsSources[providerName]; ok {
+		return source
+	}
+	return providerName
+}
+
+func (c *awsClient) GetCredentialsInfo() (*CredentialsInfo, error) {
+	creds := c.awsSession.Config.Credentials
+	value, err := creds.Get()
+	if err != nil {
+		return nil, err
+	}
+	info := &CredentialsInfo{
+		Source: credentialsSource(value.ProviderName),
+	}
+	expiration, err := creds.ExpiresAt()
+	if err == nil {
+		info.Expiration = expiration
+	}
+	return info, nil
+}
+
+// credentialProcessError explains why the credential_process of the AWS profile failed, or returns
+// nil if the error wasn't caused by it.
+func credentialProcessError(profileName string, err error) error {
+	awsErr, ok := err.(awserr.Error)
+	if !ok {
+		return nil
+	}
+	switch awsErr.Code() {
+	case processcreds.ErrCodeProcessProviderExecution:
+		message := awsErr.Message()
+		if awsErr.OrigErr() != nil {
+			message = fmt.Sprintf("%s: %v", message, awsErr.OrigErr())
+		}
+		return fmt.Errorf("The credential_process of AWS profile '%s' failed, %s. Check that the "+
+			"program runs correctly outside of rosa", profileName, message)
+	case processcreds.ErrCodeProcessProviderParse, processcreds.ErrCodeProcessProviderVersion,
+		processcreds.ErrCodeProcessProviderRequired:
+		return fmt.Errorf("The credential_process of AWS profile '%s' returned invalid credentials, %s. "+
+			"It must print a JSON object with 'Version' 1, 'AccessKeyId', 'SecretAccessKey' and optionally "+
+			"'SessionToken' and 'Expiration'", profileName, awsErr.Message())
+	}
+	return nil
+}
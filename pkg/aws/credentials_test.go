@@ -0,0 +1,47 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"errors"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials/processcreds"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Credentials", func() {
+	It("Describes the credentials providers", func() {
+		Expect(credentialsSource(processcreds.ProviderName)).To(Equal("credential_process"))
+		Expect(credentialsSource("SharedConfigCredentials: /home/user/.aws/config")).To(Equal("config file"))
+		Expect(credentialsSource("CustomProvider")).To(Equal("CustomProvider"))
+	})
+	It("Explains credential_process failures", func() {
+		err := credentialProcessError("dev", awserr.New(processcreds.ErrCodeProcessProviderExecution,
+			"error in credential_process", errors.New("exit status 2")))
+		Expect(err).To(MatchError("The credential_process of AWS profile 'dev' failed, error in " +
+			"credential_process: exit status 2. Check that the program runs correctly outside of rosa"))
+		err = credentialProcessError("dev", awserr.New(processcreds.ErrCodeProcessProviderVersion,
+			"wrong version in process output (not 1)", nil))
+		Expect(err).To(MatchError(ContainSubstring("returned invalid credentials, wrong version")))
+	})
+	It("Ignores other errors", func() {
+		Expect(credentialProcessError("dev", errors.New("other"))).To(BeNil())
+		Expect(credentialProcessError("dev", awserr.New("NoCredentialProviders", "no providers", nil))).To(BeNil())
+	})
+})
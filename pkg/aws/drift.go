@@ -0,0 +1,397 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions used to detect differences between the IAM roles created by
+// rosa and the trust policies, attached policies and tags that they are expected to have.
+
+package aws
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/iam"
+
+	awscb "github.com/openshift/rosa/pkg/aws/commandbuilder"
+	"github.com/openshift/rosa/pkg/aws/tags"
+)
+
+const (
+	DriftTrustPolicy = "Trust policy"
+	DriftPolicy      = "Policy"
+	DriftTag         = "Tag"
+)
+
+// Number of unchanged lines shown around the changes of policy documents.
+const driftDiffContext = 3
+
+// ExpectedRole describes the trust policy, attached policies and tags that a role created by rosa
+// is expected to have.
+type ExpectedRole struct {
+	RoleName    string
+	TrustPolicy string
+	// Policies contains the documents of the policies expected to be attached to the role, indexed
+	// by policy ARN. The document is empty for AWS managed policies, as only the attachment of
+	// those is checked.
+	Policies map[string]string
+	Tags     map[string]string
+	// Version is the OpenShift version that the role is expected to support, if any.
+	Version string
+}
+
+// RoleDrift contains the differences found between a role and its expected configuration.
+type RoleDrift struct {
+	RoleName string   `json:"role_name"`
+	RoleARN  string   `json:"role_arn,omitempty"`
+	Missing  bool     `json:"missing,omitempty"`
+	Drifts   []*Drift `json:"drifts,omitempty"`
+}
+
+// Drift is a single difference between a role and its expected configuration, together with the
+// command that fixes it.
+type Drift struct {
+	Kind        string `json:"kind"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Diff        string `json:"diff,omitempty"`
+	Remediation string `json:"remediation,omitempty"`
+}
+
+// DetectRoleDrift compares the role with its expected configuration.
+func (c *awsClient) DetectRoleDrift(expected *ExpectedRole) (*RoleDrift, error) {
+	result := &RoleDrift{
+		RoleName: expected.RoleName,
+	}
+	roleOutput, err := c.iamClient.GetRole(&iam.GetRoleInput{
+		RoleName: aws.String(expected.RoleName),
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == iam.ErrCodeNoSuchEntityException {
+			result.Missing = true
+			return result, nil
+		}
+		return nil, err
+	}
+	role := roleOutput.Role
+	result.RoleARN = aws.StringValue(role.Arn)
+
+	// Trust policy:
+	trustPolicy, err := url.QueryUnescape(aws.StringValue(role.AssumeRolePolicyDocument))
+	if err != nil {
+		return nil, err
+	}
+	drift, err := comparePolicyDocuments(DriftTrustPolicy, expected.RoleName, expected.TrustPolicy, trustPolicy,
+		awscb.NewIAMCommandBuilder().
+			SetCommand(awscb.UpdateAssumeRolePolicy).
+			AddParam(awscb.RoleName, expected.RoleName))
+	if err != nil {
+		return nil, fmt.Errorf("Failed to compare trust policy of role '%s': %v", expected.RoleName, err)
+	}
+	if drift != nil {
+		result.Drifts = append(result.Drifts, drift)
+	}
+
+	// Attached policies:
+	attachedPolicies, err := c.listRoleAttachedPolicies(expected.RoleName)
+	if err != nil {
+		return nil, err
+	}
+	attached := map[string]bool{}
+	for _, attachedPolicy := range attachedPolicies {
+		attached[aws.StringValue(attachedPolicy.PolicyArn)] = true
+	}
+	for _, policyARN := range sortedKeys(expected.Policies) {
+		if !attached[policyARN] {
+			result.Drifts = append(result.Drifts, &Drift{
+				Kind:        DriftPolicy,
+				Name:        policyARN,
+				Description: "Policy is not attached to the role",
+				Remediation: awscb.NewIAMCommandBuilder().
+					SetCommand(awscb.AttachRolePolicy).
+					AddParam(awscb.RoleName, expected.RoleName).
+					AddParam(awscb.PolicyArn, policyARN).
+					Build(),
+			})
+			continue
+		}
+		if expected.Policies[policyARN] == "" {
+			continue
+		}
+		document, err := c.getPolicyDefaultDocument(policyARN)
+		if err != nil {
+			return nil, err
+		}
+		drift, err = comparePolicyDocuments(DriftPolicy, policyARN, expected.Policies[policyARN], document,
+			awscb.NewIAMCommandBuilder().
+				SetCommand(awscb.CreatePolicyVersion).
+				AddParam(awscb.PolicyArn, policyARN).
+				AddParamNoValue(awscb.SetAsDefault))
+		if err != nil {
+			return nil, fmt.Errorf("Failed to compare policy '%s': %v", policyARN, err)
+		}
+		if drift != nil {
+			result.Drifts = append(result.Drifts, drift)
+		}
+	}
+	for _, attachedPolicy := range attachedPolicies {
+		policyARN := aws.StringValue(attachedPolicy.PolicyArn)
+		if _, ok := expected.Policies[policyARN]; ok {
+			continue
+		}
+		result.Drifts = append(result.Drifts, &Drift{
+			Kind:        DriftPolicy,
+			Name:        policyARN,
+			Description: "Policy is attached to the role but isn't expected",
+			Remediation: awscb.NewIAMCommandBuilder().
+				SetCommand(awscb.DetachRolePolicy).
+				AddParam(awscb.RoleName, expected.RoleName).
+				AddParam(awscb.PolicyArn, policyARN).
+				Build(),
+		})
+	}
+
+	// Tags:
+	tagsOutput, err := c.iamClient.ListRoleTags(&iam.ListRoleTagsInput{
+		RoleName: aws.String(expected.RoleName),
+	})
+	if err != nil {
+		return nil, err
+	}
+	actualTags := map[string]string{}
+	for _, tag := range tagsOutput.Tags {
+		actualTags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+	}
+	for _, key := range sortedKeys(expected.Tags) {
+		value, ok := actualTags[key]
+		if ok && value == expected.Tags[key] {
+			continue
+		}
+		description := fmt.Sprintf("Tag is missing, expected '%s'", expected.Tags[key])
+		if ok {
+			description = fmt.Sprintf("Tag has value '%s', expected '%s'", value, expected.Tags[key])
+		}
+		result.Drifts = append(result.Drifts, tagDrift(expected.RoleName, key, expected.Tags[key], description))
+	}
+	if expected.Version != "" {
+		isCompatible, err := c.HasCompatibleVersionTags(tagsOutput.Tags, expected.Version)
+		if err != nil {
+			return nil, err
+		}
+		if !isCompatible {
+			description := fmt.Sprintf("Tag is missing, expected version '%s' or later", expected.Version)
+			if value, ok := actualTags[tags.OpenShiftVersion]; ok {
+				description = fmt.Sprintf("Role supports version '%s', expected '%s' or later",
+					value, expected.Version)
+			}
+			result.Drifts = append(result.Drifts,
+				tagDrift(expected.RoleName, tags.OpenShiftVersion, expected.Version, description))
+		}
+	}
+
+	return result, nil
+}
+
+// getPolicyDefaultDocument returns the document of the default version of the policy.
+func (c *awsClient) getPolicyDefaultDocument(policyARN string) (string, error) {
+	policyOutput, err := c.IsPolicyExists(policyARN)
+	if err != nil {
+		return "", err
+	}
+	versionOutput, err := c.iamClient.GetPolicyVersion(&iam.GetPolicyVersionInput{
+		PolicyArn: aws.String(policyARN),
+		VersionId: policyOutput.Policy.DefaultVersionId,
+	})
+	if err != nil {
+		return "", err
+	}
+	return url.QueryUnescape(aws.StringValue(versionOutput.PolicyVersion.Document))
+}
+
+func tagDrift(roleName string, key string, value string, description string) *Drift {
+	return &Drift{
+		Kind:        DriftTag,
+		Name:        key,
+		Description: description,
+		Remediation: awscb.NewIAMCommandBuilder().
+			SetCommand(awscb.TagRole).
+			AddParam(awscb.RoleName, roleName).
+			AddTags(map[string]string{key: value}).
+			Build(),
+	}
+}
+
+// comparePolicyDocuments returns the drift between the expected and actual policy documents, or nil
+// if they are equivalent. The remediation command is completed with the expected document.
+func comparePolicyDocuments(kind string, name string, expected string, actual string,
+	remediation *awscb.CommandBuilder) (*Drift, error) {
+	expected = InterpolatePolicyDocument(expected, nil)
+	normalizedExpected, err := normalizePolicyDocument(expected)
+	if err != nil {
+		return nil, fmt.Errorf("invalid expected document: %v", err)
+	}
+	normalizedActual, err := normalizePolicyDocument(actual)
+	if err != nil {
+		return nil, fmt.Errorf("invalid document: %v", err)
+	}
+	if normalizedExpected == normalizedActual {
+		return nil, nil
+	}
+	compacted := new(bytes.Buffer)
+	err = json.Compact(compacted, []byte(expected))
+	if err != nil {
+		return nil, err
+	}
+	return &Drift{
+		Kind:        kind,
+		Name:        name,
+		Description: "Document differs from the expected one",
+		Diff:        diffLines(normalizedActual, normalizedExpected),
+		Remediation: remediation.
+			AddParam(awscb.PolicyDocument, fmt.Sprintf("'%s'", compacted.String())).
+			Build(),
+	}, nil
+}
+
+// normalizePolicyDocument returns an indented representation of the policy document that doesn't
+// depend on the order of keys, statements and values, nor on whether single values are written as
+// lists, so that equivalent documents have the same representation.
+func normalizePolicyDocument(document string) (string, error) {
+	var value interface{}
+	err := json.Unmarshal([]byte(document), &value)
+	if err != nil {
+		return "", err
+	}
+	data, err := json.MarshalIndent(normalizePolicyValue(value), "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func normalizePolicyValue(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		for key, item := range typed {
+			typed[key] = normalizePolicyValue(item)
+		}
+		return typed
+	case []interface{}:
+		if len(typed) == 1 {
+			return normalizePolicyValue(typed[0])
+		}
+		keys := make([]string, len(typed))
+		for i, item := range typed {
+			typed[i] = normalizePolicyValue(item)
+			data, _ := json.Marshal(typed[i])
+			keys[i] = string(data)
+		}
+		sort.Sort(byKey{keys: keys, items: typed})
+		return typed
+	default:
+		return value
+	}
+}
+
+type byKey struct {
+	keys  []string
+	items []interface{}
+}
+
+func (s byKey) Len() int           { return len(s.keys) }
+func (s byKey) Less(i, j int) bool { return s.keys[i] < s.keys[j] }
+func (s byKey) Swap(i, j int) {
+	s.keys[i], s.keys[j] = s.keys[j], s.keys[i]
+	s.items[i], s.items[j] = s.items[j], s.items[i]
+}
+
+// diffLines returns the lines that need to be removed from the old text, prefixed with '-', and
+// added to it, prefixed with '+', to obtain the new text. Only a few unchanged lines are kept around
+// each change.
+func diffLines(oldText string, newText string) string {
+	a := strings.Split(oldText, "\n")
+	b := strings.Split(newText, "\n")
+
+	// Lengths of the longest common subsequences of the suffixes of both texts:
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	lines := []string{}
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			lines = append(lines, "  "+a[i])
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			lines = append(lines, "- "+a[i])
+			i++
+		default:
+			lines = append(lines, "+ "+b[j])
+			j++
+		}
+	}
+
+	// Keep only the context of the changes:
+	keep := make([]bool, len(lines))
+	for k, line := range lines {
+		if strings.HasPrefix(line, "  ") {
+			continue
+		}
+		for l := k - driftDiffContext; l <= k+driftDiffContext; l++ {
+			if l >= 0 && l < len(lines) {
+				keep[l] = true
+			}
+		}
+	}
+	result := []string{}
+	for k, line := range lines {
+		if keep[k] {
+			result = append(result, line)
+		} else if k > 0 && keep[k-1] {
+			result = append(result, "  ...")
+		}
+	}
+	return strings.Join(result, "\n")
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
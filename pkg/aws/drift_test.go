@@ -0,0 +1,77 @@
+package aws
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	awscb "github.com/openshift/rosa/pkg/aws/commandbuilder"
+)
+
+var _ = Describe("Role drift", func() {
+	Context("normalizePolicyDocument", func() {
+		It("Ignores the order of keys, statements and values", func() {
+			first, err := normalizePolicyDocument(`{"Version": "2012-10-17", "Statement": [
+				{"Effect": "Allow", "Action": ["s3:GetObject", "ec2:RunInstances"], "Resource": "*"},
+				{"Effect": "Deny", "Action": "iam:DeleteRole", "Resource": "*"}
+			]}`)
+			Expect(err).NotTo(HaveOccurred())
+			second, err := normalizePolicyDocument(`{"Statement": [
+				{"Resource": "*", "Action": "iam:DeleteRole", "Effect": "Deny"},
+				{"Resource": ["*"], "Action": ["ec2:RunInstances", "s3:GetObject"], "Effect": "Allow"}
+			], "Version": "2012-10-17"}`)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(first).To(Equal(second))
+		})
+
+		It("Detects different values", func() {
+			first, err := normalizePolicyDocument(`{"Statement": [{"Effect": "Allow", "Action": "s3:GetObject"}]}`)
+			Expect(err).NotTo(HaveOccurred())
+			second, err := normalizePolicyDocument(`{"Statement": [{"Effect": "Allow", "Action": "s3:PutObject"}]}`)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(first).NotTo(Equal(second))
+		})
+
+		It("Fails for invalid documents", func() {
+			_, err := normalizePolicyDocument(`{"Statement": `)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("diffLines", func() {
+		It("Shows removed and added lines with their context", func() {
+			diff := diffLines("a\nb\nc\nd\ne\nf\ng\nh\ni", "a\nb\nc\nd\nE\nf\ng\nh\ni")
+			Expect(diff).To(Equal("  b\n  c\n  d\n- e\n+ E\n  f\n  g\n  h\n  ..."))
+		})
+
+		It("Shows added lines at the end", func() {
+			Expect(diffLines("a", "a\nb")).To(Equal("  a\n+ b"))
+		})
+	})
+
+	Context("comparePolicyDocuments", func() {
+		It("Returns nothing for equivalent documents", func() {
+			drift, err := comparePolicyDocuments(DriftTrustPolicy, "role",
+				`{"Statement": [{"Effect": "Allow", "Action": ["sts:AssumeRole"]}]}`,
+				`{"Statement": {"Effect": "Allow", "Action": "sts:AssumeRole"}}`,
+				awscb.NewIAMCommandBuilder().SetCommand(awscb.UpdateAssumeRolePolicy))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(drift).To(BeNil())
+		})
+
+		It("Returns the diff and the remediation with the expected document", func() {
+			drift, err := comparePolicyDocuments(DriftTrustPolicy, "role",
+				`{"Statement": [{"Effect": "Allow", "Action": "sts:AssumeRole"}]}`,
+				`{"Statement": [{"Effect": "Deny", "Action": "sts:AssumeRole"}]}`,
+				awscb.NewIAMCommandBuilder().
+					SetCommand(awscb.UpdateAssumeRolePolicy).
+					AddParam(awscb.RoleName, "role"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(drift).NotTo(BeNil())
+			Expect(drift.Diff).To(ContainSubstring(`-     "Effect": "Deny"`))
+			Expect(drift.Diff).To(ContainSubstring(`+     "Effect": "Allow"`))
+			Expect(drift.Remediation).To(ContainSubstring("update-assume-role-policy"))
+			Expect(drift.Remediation).To(ContainSubstring(
+				`--policy-document '{"Statement":[{"Effect":"Allow","Action":"sts:AssumeRole"}]}'`))
+		})
+	})
+})
@@ -0,0 +1,114 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions used to validate the AWS Local Zones and Wavelength Zones where
+// machine pools for edge workloads are created.
+
+package aws
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+const (
+	LocalZoneType      = "local-zone"
+	WavelengthZoneType = "wavelength-zone"
+)
+
+// EdgeZone is a Local Zone or a Wavelength Zone, an extension of a region closer to end users.
+type EdgeZone struct {
+	Name           string
+	Type           string
+	GroupName      string
+	ParentZoneName string
+}
+
+// String returns a description of the kind of zone, as used in messages.
+func (z *EdgeZone) String() string {
+	if z.Type == WavelengthZoneType {
+		return fmt.Sprintf("Wavelength Zone '%s'", z.Name)
+	}
+	return fmt.Sprintf("Local Zone '%s'", z.Name)
+}
+
+// GetEdgeZone returns the Local Zone or Wavelength Zone with the given name, or nil if it is a
+// regular availability zone. It fails if the zone can't be used by machine pools of the region of
+// the client.
+func (c *awsClient) GetEdgeZone(zoneName string) (*EdgeZone, error) {
+	output, err := c.ec2Client.DescribeAvailabilityZones(&ec2.DescribeAvailabilityZonesInput{
+		AllAvailabilityZones: aws.Bool(true),
+		ZoneNames:            []*string{aws.String(zoneName)},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to describe availability zone '%s': %v", zoneName, err)
+	}
+	if len(output.AvailabilityZones) < 1 {
+		return nil, fmt.Errorf("Failed to find availability zone '%s'", zoneName)
+	}
+	return validateEdgeZone(output.AvailabilityZones[0], c.GetRegion())
+}
+
+// validateEdgeZone converts the zone into an edge zone, checking that it is enabled for the
+// account in the given region.
+func validateEdgeZone(zone *ec2.AvailabilityZone, region string) (*EdgeZone, error) {
+	zoneType := aws.StringValue(zone.ZoneType)
+	if zoneType != LocalZoneType && zoneType != WavelengthZoneType {
+		return nil, nil
+	}
+	edgeZone := &EdgeZone{
+		Name:           aws.StringValue(zone.ZoneName),
+		Type:           zoneType,
+		GroupName:      aws.StringValue(zone.GroupName),
+		ParentZoneName: aws.StringValue(zone.ParentZoneName),
+	}
+	if aws.StringValue(zone.RegionName) != region {
+		return nil, fmt.Errorf("%s doesn't belong to region '%s'", edgeZone, region)
+	}
+	if aws.StringValue(zone.OptInStatus) == ec2.AvailabilityZoneOptInStatusNotOptedIn {
+		return nil, fmt.Errorf("%s isn't enabled for the account, to enable it run "+
+			"'aws ec2 modify-availability-zone-group --region %s --group-name %s --opt-in-status opted-in'",
+			edgeZone, region, edgeZone.GroupName)
+	}
+	if aws.StringValue(zone.State) != ec2.AvailabilityZoneStateAvailable {
+		return nil, fmt.Errorf("%s isn't available, its state is '%s'", edgeZone, aws.StringValue(zone.State))
+	}
+	return edgeZone, nil
+}
+
+// IsInstanceTypeOfferedInZone checks if the instance type can be launched in the given zone. Local
+// Zones and Wavelength Zones only offer a small subset of the instance types of their region.
+func (c *awsClient) IsInstanceTypeOfferedInZone(instanceType string, zoneName string) (bool, error) {
+	output, err := c.ec2Client.DescribeInstanceTypeOfferings(&ec2.DescribeInstanceTypeOfferingsInput{
+		LocationType: aws.String(ec2.LocationTypeAvailabilityZone),
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("location"),
+				Values: []*string{aws.String(zoneName)},
+			},
+			{
+				Name:   aws.String("instance-type"),
+				Values: []*string{aws.String(instanceType)},
+			},
+		},
+	})
+	if err != nil {
+		return false, fmt.Errorf("Failed to describe instance type offerings of zone '%s': %v", zoneName, err)
+	}
+	return len(output.InstanceTypeOfferings) > 0, nil
+}
@@ -0,0 +1,58 @@
+package aws
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Edge zone validation", func() {
+	zone := func(name string, zoneType string, optIn string, state string) *ec2.AvailabilityZone {
+		return &ec2.AvailabilityZone{
+			ZoneName:       aws.String(name),
+			ZoneType:       aws.String(zoneType),
+			GroupName:      aws.String("us-east-1-bos-1"),
+			ParentZoneName: aws.String("us-east-1a"),
+			RegionName:     aws.String("us-east-1"),
+			OptInStatus:    aws.String(optIn),
+			State:          aws.String(state),
+		}
+	}
+
+	It("Returns nil for regular availability zones", func() {
+		edgeZone, err := validateEdgeZone(
+			zone("us-east-1a", "availability-zone", "opt-in-not-required", "available"), "us-east-1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(edgeZone).To(BeNil())
+	})
+
+	It("Accepts an enabled Local Zone", func() {
+		edgeZone, err := validateEdgeZone(
+			zone("us-east-1-bos-1a", LocalZoneType, "opted-in", "available"), "us-east-1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(edgeZone.Type).To(Equal(LocalZoneType))
+		Expect(edgeZone.ParentZoneName).To(Equal("us-east-1a"))
+		Expect(edgeZone.String()).To(Equal("Local Zone 'us-east-1-bos-1a'"))
+	})
+
+	It("Rejects a zone that isn't enabled", func() {
+		_, err := validateEdgeZone(
+			zone("us-east-1-wl1-bos-wlz-1", WavelengthZoneType, "not-opted-in", "available"), "us-east-1")
+		Expect(err).To(MatchError(ContainSubstring(
+			"Wavelength Zone 'us-east-1-wl1-bos-wlz-1' isn't enabled for the account")))
+		Expect(err).To(MatchError(ContainSubstring("--group-name us-east-1-bos-1 --opt-in-status opted-in")))
+	})
+
+	It("Rejects a zone of another region", func() {
+		_, err := validateEdgeZone(
+			zone("us-east-1-bos-1a", LocalZoneType, "opted-in", "available"), "us-west-2")
+		Expect(err).To(MatchError("Local Zone 'us-east-1-bos-1a' doesn't belong to region 'us-west-2'"))
+	})
+
+	It("Rejects a zone that isn't available", func() {
+		_, err := validateEdgeZone(
+			zone("us-east-1-bos-1a", LocalZoneType, "opted-in", "impaired"), "us-east-1")
+		Expect(err).To(MatchError("Local Zone 'us-east-1-bos-1a' isn't available, its state is 'impaired'"))
+	})
+})
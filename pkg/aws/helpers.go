@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -14,6 +15,7 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/endpoints"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/iam"
@@ -82,6 +84,46 @@ func ARNValidator(input interface{}) error {
 	return fmt.Errorf("can only validate strings, got %v", input)
 }
 
+// PermissionsBoundaryValidator checks that the input is the ARN of an IAM policy, as that is the only
+// kind of resource that can be used as the permissions boundary of a role.
+func PermissionsBoundaryValidator(input interface{}) error {
+	str, ok := input.(string)
+	if !ok {
+		return fmt.Errorf("can only validate strings, got %v", input)
+	}
+	if str == "" {
+		return nil
+	}
+	parsedARN, err := arn.Parse(str)
+	if err != nil {
+		return fmt.Errorf("Invalid ARN: %s", err)
+	}
+	if parsedARN.Service != iam.ServiceName || !strings.HasPrefix(parsedARN.Resource, "policy/") {
+		return fmt.Errorf("Expected the ARN of an IAM policy, got '%s'", str)
+	}
+	return nil
+}
+
+// ValidatePermissionsBoundary checks that the permissions boundary is the ARN of an IAM policy that
+// exists, so that no role is created before finding out that the boundary can't be applied.
+func ValidatePermissionsBoundary(awsClient Client, permissionsBoundary string) error {
+	if permissionsBoundary == "" {
+		return nil
+	}
+	err := PermissionsBoundaryValidator(permissionsBoundary)
+	if err != nil {
+		return err
+	}
+	_, err = awsClient.IsPolicyExists(permissionsBoundary)
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == iam.ErrCodeNoSuchEntityException {
+			return fmt.Errorf("Permissions boundary policy '%s' doesn't exist", permissionsBoundary)
+		}
+		return fmt.Errorf("Failed to get permissions boundary policy '%s': %v", permissionsBoundary, err)
+	}
+	return nil
+}
+
 func ARNPathValidator(input interface{}) error {
 	if str, ok := input.(string); ok {
 		if str == "" {
@@ -291,6 +333,51 @@ func HasDuplicateTagKey(tags []string) (string, bool) {
 	return "", false
 }
 
+// ParseUserTags validates the user defined tags, in the 'key:value' format used by the '--tags' flag
+// of the commands, and returns them indexed by key.
+func ParseUserTags(userTags []string) (map[string]string, error) {
+	result := map[string]string{}
+	if len(userTags) == 0 {
+		return result, nil
+	}
+	duplicate, found := HasDuplicateTagKey(userTags)
+	if found {
+		return nil, fmt.Errorf("Invalid tags, user tag keys must be unique, duplicate key '%s' found", duplicate)
+	}
+	for _, tag := range userTags {
+		err := UserTagValidator(tag)
+		if err != nil {
+			return nil, err
+		}
+		t := strings.Split(tag, ":")
+		result[t[0]] = strings.TrimSpace(t[1])
+	}
+	return result, nil
+}
+
+// MergeUserTags returns a new map containing the user defined tags and the tags that rosa uses to
+// identify its resources. The latter take precedence, as rosa relies on their values.
+func MergeUserTags(userTags map[string]string, rosaTags map[string]string) map[string]string {
+	result := make(map[string]string, len(userTags)+len(rosaTags))
+	for key, value := range userTags {
+		result[key] = value
+	}
+	for key, value := range rosaTags {
+		result[key] = value
+	}
+	return result
+}
+
+// GetObjectTagging encodes the tags in the query parameter format expected by the 'Tagging' field
+// of the S3 objects.
+func GetObjectTagging(objectTags map[string]string) string {
+	values := url.Values{}
+	for key, value := range objectTags {
+		values.Set(key, value)
+	}
+	return values.Encode()
+}
+
 func UserNoProxyValidator(input interface{}) error {
 	if str, ok := input.(string); ok {
 		if str == "" {
@@ -458,6 +545,16 @@ func GetOIDCProviderARN(accountID string, providerURL string) string {
 	return fmt.Sprintf("arn:%s:iam::%s:oidc-provider/%s", partition, accountID, providerURL)
 }
 
+// GetOIDCProviderURLFromARN returns the URL, without scheme, of the issuer of an OIDC provider
+func GetOIDCProviderURLFromARN(providerARN string) string {
+	const resourcePrefix = ":oidc-provider/"
+	index := strings.Index(providerARN, resourcePrefix)
+	if index == -1 {
+		return ""
+	}
+	return providerARN[index+len(resourcePrefix):]
+}
+
 func GetPartition() string {
 	region, err := GetRegion(arguments.GetRegion())
 	if err != nil || region == "" {
@@ -796,6 +893,21 @@ func GetResourceIdFromSecretArn(secretArn string) (string, error) {
 	return parsedARN.Resource[index+1:], nil
 }
 
+// GetBucketNameFromIssuerUrl returns the name of the S3 bucket that serves the documents of an
+// unmanaged OIDC configuration, for issuer URLs of the form 'https://<bucket>.s3.<region>.amazonaws.com'.
+// An empty string is returned for issuer URLs that aren't backed by an S3 bucket.
+func GetBucketNameFromIssuerUrl(issuerUrl string) string {
+	parsedUrl, err := url.Parse(issuerUrl)
+	if err != nil {
+		return ""
+	}
+	index := strings.Index(parsedUrl.Hostname(), ".s3.")
+	if index <= 0 || !strings.HasSuffix(parsedUrl.Hostname(), ".amazonaws.com") {
+		return ""
+	}
+	return parsedUrl.Hostname()[:index]
+}
+
 func FindOperatorRoleNameBySTSOperator(cluster *cmv1.Cluster, operator *cmv1.STSOperator) (string, bool) {
 	for _, role := range cluster.AWS().STS().OperatorIAMRoles() {
 		if role.Namespace() == operator.Namespace() && role.Name() == operator.Name() {
@@ -0,0 +1,112 @@
+package aws_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/rosa/pkg/aws"
+)
+
+var _ = Describe("GetBucketNameFromIssuerUrl", func() {
+	DescribeTable("extracts the bucket name",
+		func(issuerUrl string, expected string) {
+			Expect(aws.GetBucketNameFromIssuerUrl(issuerUrl)).To(Equal(expected))
+		},
+		Entry("regional bucket URL", "https://my-prefix-oidc-a1b2.s3.us-east-1.amazonaws.com", "my-prefix-oidc-a1b2"),
+		Entry("URL with a path", "https://oidc-a1b2.s3.eu-west-1.amazonaws.com/cluster", "oidc-a1b2"),
+		Entry("host outside of amazonaws.com", "https://rh-oidc.s3.us-east-1.amazonaws.com.example.com/abc", ""),
+		Entry("CloudFront issuer URL", "https://d3gt1gce2zmg3d.cloudfront.net/abc", ""),
+		Entry("invalid URL", "://", ""),
+	)
+})
+
+var _ = Describe("PermissionsBoundaryValidator", func() {
+	It("accepts an empty value", func() {
+		Expect(aws.PermissionsBoundaryValidator("")).To(Succeed())
+	})
+
+	It("accepts the ARN of a policy", func() {
+		Expect(aws.PermissionsBoundaryValidator(
+			"arn:aws:iam::123456789012:policy/perm-boundary")).To(Succeed())
+		Expect(aws.PermissionsBoundaryValidator(
+			"arn:aws:iam::123456789012:policy/boundaries/perm-boundary")).To(Succeed())
+	})
+
+	It("rejects the ARN of a role", func() {
+		err := aws.PermissionsBoundaryValidator("arn:aws:iam::123456789012:role/my-role")
+		Expect(err).To(MatchError(ContainSubstring("Expected the ARN of an IAM policy")))
+	})
+
+	It("rejects the ARN of a resource of another service", func() {
+		err := aws.PermissionsBoundaryValidator("arn:aws:s3:::policy/my-bucket")
+		Expect(err).To(MatchError(ContainSubstring("Expected the ARN of an IAM policy")))
+	})
+
+	It("rejects invalid ARNs", func() {
+		err := aws.PermissionsBoundaryValidator("perm-boundary")
+		Expect(err).To(MatchError(ContainSubstring("Invalid ARN")))
+	})
+})
+
+var _ = Describe("ParseUserTags", func() {
+	It("returns an empty map when there are no tags", func() {
+		Expect(aws.ParseUserTags(nil)).To(BeEmpty())
+	})
+
+	It("indexes the tags by key", func() {
+		Expect(aws.ParseUserTags([]string{"foo:bar", "team:sre"})).To(Equal(map[string]string{
+			"foo":  "bar",
+			"team": "sre",
+		}))
+	})
+
+	It("rejects duplicate keys", func() {
+		_, err := aws.ParseUserTags([]string{"foo:bar", "foo:baz"})
+		Expect(err).To(MatchError(ContainSubstring("duplicate key 'foo' found")))
+	})
+
+	It("rejects tags without a value", func() {
+		_, err := aws.ParseUserTags([]string{"foo"})
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("MergeUserTags", func() {
+	It("gives precedence to the tags of rosa", func() {
+		merged := aws.MergeUserTags(
+			map[string]string{"foo": "bar", "red-hat-managed": "false"},
+			map[string]string{"red-hat-managed": "true"},
+		)
+		Expect(merged).To(Equal(map[string]string{
+			"foo":             "bar",
+			"red-hat-managed": "true",
+		}))
+	})
+
+	It("doesn't modify the given maps", func() {
+		userTags := map[string]string{"foo": "bar"}
+		merged := aws.MergeUserTags(userTags, map[string]string{"red-hat-managed": "true"})
+		merged["baz"] = "qux"
+		Expect(userTags).To(Equal(map[string]string{"foo": "bar"}))
+	})
+})
+
+var _ = Describe("GetObjectTagging", func() {
+	It("encodes the tags as query parameters", func() {
+		Expect(aws.GetObjectTagging(map[string]string{
+			"red-hat-managed": "true",
+			"cost center":     "a&b",
+		})).To(Equal("cost+center=a%26b&red-hat-managed=true"))
+	})
+})
+
+var _ = Describe("GetOIDCProviderURLFromARN", func() {
+	It("returns the issuer of the provider", func() {
+		Expect(aws.GetOIDCProviderURLFromARN("arn:aws:iam::123456789012:oidc-provider/oidc.example.com/abc")).
+			To(Equal("oidc.example.com/abc"))
+	})
+
+	It("returns an empty string for other resources", func() {
+		Expect(aws.GetOIDCProviderURLFromARN("arn:aws:iam::123456789012:role/abc")).To(BeEmpty())
+	})
+})
@@ -17,8 +17,14 @@ limitations under the License.
 package aws
 
 import (
+	"bytes"
+	"crypto/sha1" //#nosec GSC-G505 -- Import blacklist: crypto/sha1
+	"encoding/hex"
 	"fmt"
+	"net/http"
 	"net/url"
+	"sort"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
@@ -31,20 +37,15 @@ const (
 	OIDCClientIDSTSAWS    = "sts.amazonaws.com"
 )
 
-func (c *awsClient) CreateOpenIDConnectProvider(providerURL string, thumbprint string, clusterID string) (
-	string, error) {
-	iamTags := []*iam.Tag{
-		{
-			Key:   aws.String(tags.RedHatManaged),
-			Value: aws.String(tags.True),
-		},
+func (c *awsClient) CreateOpenIDConnectProvider(providerURL string, thumbprint string, clusterID string,
+	userTags map[string]string) (string, error) {
+	rosaTags := map[string]string{
+		tags.RedHatManaged: tags.True,
 	}
 	if clusterID != "" {
-		iamTags = append(iamTags, &iam.Tag{
-			Key:   aws.String(tags.ClusterID),
-			Value: aws.String(clusterID),
-		})
+		rosaTags[tags.ClusterID] = clusterID
 	}
+	iamTags := getTags(MergeUserTags(userTags, rosaTags))
 	output, err := c.iamClient.CreateOpenIDConnectProvider(&iam.CreateOpenIDConnectProviderInput{
 		ClientIDList: []*string{
 			aws.String(OIDCClientIDOpenShift),
@@ -104,3 +105,110 @@ func (c *awsClient) DeleteOpenIDConnectProvider(oidcProviderARN string) error {
 	}
 	return nil
 }
+
+// OIDCProvider is the IAM OIDC provider that the operator roles of STS clusters trust
+type OIDCProvider struct {
+	ARN         string    `json:"arn"`
+	URL         string    `json:"url"`
+	ClientIDs   []string  `json:"client_ids,omitempty"`
+	Thumbprints []string  `json:"thumbprints,omitempty"`
+	ClusterID   string    `json:"cluster_id,omitempty"`
+	CreateDate  time.Time `json:"create_date"`
+}
+
+func newOIDCProvider(providerARN string, output *iam.GetOpenIDConnectProviderOutput) *OIDCProvider {
+	return &OIDCProvider{
+		ARN:         providerARN,
+		URL:         aws.StringValue(output.Url),
+		ClientIDs:   aws.StringValueSlice(output.ClientIDList),
+		Thumbprints: aws.StringValueSlice(output.ThumbprintList),
+		ClusterID:   getTagValue(output.Tags, tags.ClusterID),
+		CreateDate:  aws.TimeValue(output.CreateDate),
+	}
+}
+
+// GetOpenIDConnectProvider returns the IAM OIDC provider of the issuer URL, or nil if it doesn't exist
+func (c *awsClient) GetOpenIDConnectProvider(issuerURL string, accountID string) (*OIDCProvider, error) {
+	parsedIssuerURL, err := url.ParseRequestURI(issuerURL)
+	if err != nil {
+		return nil, err
+	}
+	providerURL := fmt.Sprintf("%s%s", parsedIssuerURL.Host, parsedIssuerURL.Path)
+
+	oidcProviderARN := GetOIDCProviderARN(accountID, providerURL)
+	output, err := c.iamClient.GetOpenIDConnectProvider(&iam.GetOpenIDConnectProviderInput{
+		OpenIDConnectProviderArn: aws.String(oidcProviderARN),
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == iam.ErrCodeNoSuchEntityException {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return newOIDCProvider(oidcProviderARN, output), nil
+}
+
+// ListROSAOpenIDConnectProviders returns the IAM OIDC providers of the account that were created by
+// ROSA, sorted by URL
+func (c *awsClient) ListROSAOpenIDConnectProviders() ([]*OIDCProvider, error) {
+	providers, err := c.iamClient.ListOpenIDConnectProviders(&iam.ListOpenIDConnectProvidersInput{})
+	if err != nil {
+		return nil, err
+	}
+	result := []*OIDCProvider{}
+	for _, provider := range providers.OpenIDConnectProviderList {
+		output, err := c.iamClient.GetOpenIDConnectProvider(&iam.GetOpenIDConnectProviderInput{
+			OpenIDConnectProviderArn: provider.Arn,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if getTagValue(output.Tags, tags.RedHatManaged) != tags.True {
+			continue
+		}
+		result = append(result, newOIDCProvider(aws.StringValue(provider.Arn), output))
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].URL < result[j].URL
+	})
+	return result, nil
+}
+
+// GetThumbprint returns the thumbprint of the root CA that signs the certificate of the OIDC
+// endpoint, as expected by IAM OIDC providers
+func GetThumbprint(oidcEndpointURL string) (string, error) {
+	connect, err := url.ParseRequestURI(oidcEndpointURL)
+	if err != nil {
+		return "", err
+	}
+
+	response, err := http.Get(fmt.Sprintf("https://%s:443", connect.Host))
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+
+	certChain := response.TLS.PeerCertificates
+
+	// Grab the CA in the chain
+	for _, cert := range certChain {
+		if cert.IsCA {
+			if bytes.Equal(cert.RawIssuer, cert.RawSubject) {
+				return sha1Hash(cert.Raw), nil
+			}
+		}
+	}
+
+	// Fall back to using the last certficiate in the chain
+	cert := certChain[len(certChain)-1]
+	return sha1Hash(cert.Raw), nil
+}
+
+// sha1Hash computes the SHA1 of the byte array and returns the hex encoding as a string.
+func sha1Hash(data []byte) string {
+	// nolint:gosec
+	hasher := sha1.New()
+	hasher.Write(data)
+	hashed := hasher.Sum(nil)
+	return hex.EncodeToString(hashed)
+}
@@ -0,0 +1,175 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions used to find out where instance types are offered and how much
+// they and the other resources used by clusters cost.
+
+package aws
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/pricing"
+)
+
+// The price list API is only available in a few regions, and they all return the prices of every
+// region.
+const pricingRegion = "us-east-1"
+
+// GetInstanceTypeZones returns the availability zones of the region where each instance type is
+// offered.
+func (c *awsClient) GetInstanceTypeZones() (map[string][]string, error) {
+	zones := map[string][]string{}
+	err := c.ec2Client.DescribeInstanceTypeOfferingsPages(&ec2.DescribeInstanceTypeOfferingsInput{
+		LocationType: aws.String(ec2.LocationTypeAvailabilityZone),
+	}, func(page *ec2.DescribeInstanceTypeOfferingsOutput, lastPage bool) bool {
+		for _, offering := range page.InstanceTypeOfferings {
+			instanceType := aws.StringValue(offering.InstanceType)
+			zones[instanceType] = append(zones[instanceType], aws.StringValue(offering.Location))
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to describe instance type offerings: %v", err)
+	}
+	for _, instanceTypeZones := range zones {
+		sort.Strings(instanceTypeZones)
+	}
+	return zones, nil
+}
+
+// GetOnDemandPrices returns the hourly on-demand price in USD of the Linux instance types of the
+// region.
+func (c *awsClient) GetOnDemandPrices() (map[string]float64, error) {
+	prices := map[string]float64{}
+	err := c.getProducts(map[string]string{
+		"operatingSystem": "Linux",
+		"tenancy":         "Shared",
+		"preInstalledSw":  "NA",
+		"capacitystatus":  "Used",
+	}, func(item aws.JSONValue) {
+		instanceType, price, ok := parseOnDemandPrice(item)
+		if ok {
+			prices[instanceType] = price
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get instance type prices: %v", err)
+	}
+	return prices, nil
+}
+
+// GetVolumePrice returns the monthly price in USD of a GB of EBS volume of the given type in the
+// region.
+func (c *awsClient) GetVolumePrice(volumeType string) (float64, error) {
+	return c.getProductPrice(map[string]string{
+		"productFamily": "Storage",
+		"volumeApiName": volumeType,
+	}, "GB-Mo")
+}
+
+// GetNATGatewayPrice returns the hourly price in USD of a NAT gateway in the region, not including
+// the processed data.
+func (c *awsClient) GetNATGatewayPrice() (float64, error) {
+	return c.getProductPrice(map[string]string{
+		"productFamily": "NAT Gateway",
+	}, "Hrs")
+}
+
+func (c *awsClient) getProductPrice(filters map[string]string, unit string) (float64, error) {
+	var price float64
+	found := false
+	err := c.getProducts(filters, func(item aws.JSONValue) {
+		if value, ok := parsePrice(item, unit); ok && !found {
+			price = value
+			found = true
+		}
+	})
+	if err != nil {
+		return 0, fmt.Errorf("Failed to get prices: %v", err)
+	}
+	if !found {
+		return 0, fmt.Errorf("No price found for %v in region '%s'", filters, c.GetRegion())
+	}
+	return price, nil
+}
+
+// getProducts calls the given function for each item of the EC2 price list of the region that
+// matches the filters.
+func (c *awsClient) getProducts(filters map[string]string, fn func(item aws.JSONValue)) error {
+	client := pricing.New(c.awsSession, &aws.Config{Region: aws.String(pricingRegion)})
+	input := &pricing.GetProductsInput{
+		ServiceCode: aws.String("AmazonEC2"),
+		Filters: []*pricing.Filter{{
+			Type:  aws.String(pricing.FilterTypeTermMatch),
+			Field: aws.String("regionCode"),
+			Value: aws.String(c.GetRegion()),
+		}},
+	}
+	for field, value := range filters {
+		input.Filters = append(input.Filters, &pricing.Filter{
+			Type:  aws.String(pricing.FilterTypeTermMatch),
+			Field: aws.String(field),
+			Value: aws.String(value),
+		})
+	}
+	return client.GetProductsPages(input, func(page *pricing.GetProductsOutput, lastPage bool) bool {
+		for _, item := range page.PriceList {
+			fn(item)
+		}
+		return true
+	})
+}
+
+// parseOnDemandPrice extracts the instance type and its hourly on-demand price from an item of the
+// price list.
+func parseOnDemandPrice(item aws.JSONValue) (string, float64, bool) {
+	product, _ := item["product"].(map[string]interface{})
+	attributes, _ := product["attributes"].(map[string]interface{})
+	instanceType, _ := attributes["instanceType"].(string)
+	if instanceType == "" {
+		return "", 0, false
+	}
+	price, ok := parsePrice(item, "Hrs")
+	return instanceType, price, ok
+}
+
+// parsePrice extracts the on-demand price in USD for the given unit from an item of the price list.
+func parsePrice(item aws.JSONValue, unit string) (float64, bool) {
+	terms, _ := item["terms"].(map[string]interface{})
+	onDemand, _ := terms["OnDemand"].(map[string]interface{})
+	for _, term := range onDemand {
+		term, _ := term.(map[string]interface{})
+		dimensions, _ := term["priceDimensions"].(map[string]interface{})
+		for _, dimension := range dimensions {
+			dimension, _ := dimension.(map[string]interface{})
+			if dimensionUnit, _ := dimension["unit"].(string); dimensionUnit != unit {
+				continue
+			}
+			pricePerUnit, _ := dimension["pricePerUnit"].(map[string]interface{})
+			usd, _ := pricePerUnit["USD"].(string)
+			price, err := strconv.ParseFloat(usd, 64)
+			if err == nil {
+				return price, true
+			}
+		}
+	}
+	return 0, false
+}
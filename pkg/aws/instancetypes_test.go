@@ -0,0 +1,55 @@
+package aws
+
+import (
+	"encoding/json"
+
+	"github.com/aws/aws-sdk-go/aws"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Instance type prices", func() {
+	parse := func(document string) aws.JSONValue {
+		item := aws.JSONValue{}
+		Expect(json.Unmarshal([]byte(document), &item)).To(Succeed())
+		return item
+	}
+
+	It("extracts the hourly on-demand price", func() {
+		instanceType, price, ok := parseOnDemandPrice(parse(`{
+			"product": {"attributes": {"instanceType": "m5.xlarge", "regionCode": "us-east-1"}},
+			"terms": {"OnDemand": {"ABC.JRTCKXETXF": {"priceDimensions": {
+				"ABC.JRTCKXETXF.6YS6EN2CT7": {"unit": "Hrs", "pricePerUnit": {"USD": "0.1920000000"}}
+			}}}}
+		}`))
+		Expect(ok).To(BeTrue())
+		Expect(instanceType).To(Equal("m5.xlarge"))
+		Expect(price).To(Equal(0.192))
+	})
+
+	It("ignores items without an hourly price", func() {
+		_, _, ok := parseOnDemandPrice(parse(`{
+			"product": {"attributes": {"instanceType": "m5.xlarge"}},
+			"terms": {"OnDemand": {"ABC": {"priceDimensions": {
+				"ABC.1": {"unit": "Quantity", "pricePerUnit": {"USD": "100"}}
+			}}}}
+		}`))
+		Expect(ok).To(BeFalse())
+	})
+
+	It("ignores items that are not instance types", func() {
+		_, _, ok := parseOnDemandPrice(parse(`{"product": {"attributes": {"usagetype": "EBS:VolumeUsage"}}}`))
+		Expect(ok).To(BeFalse())
+	})
+
+	It("extracts the price of other units", func() {
+		price, ok := parsePrice(parse(`{
+			"product": {"attributes": {"volumeApiName": "gp3"}},
+			"terms": {"OnDemand": {"ABC": {"priceDimensions": {
+				"ABC.1": {"unit": "GB-Mo", "pricePerUnit": {"USD": "0.0800000000"}}
+			}}}}
+		}`), "GB-Mo")
+		Expect(ok).To(BeTrue())
+		Expect(price).To(Equal(0.08))
+	})
+})
@@ -0,0 +1,156 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions used to check that customer managed KMS keys can be used by the
+// roles of a cluster before creating it.
+
+package aws
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/service/kms"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+// defaultKeyPolicyName is the only name that KMS supports for key policies.
+const defaultKeyPolicyName = "default"
+
+// KMSKeyUsageActions are the actions that the roles of a cluster need to encrypt and decrypt data
+// with a customer managed key. They are the concrete actions, so that policies granting them one by
+// one are accepted as well as policies using wildcards like 'kms:GenerateDataKey*'.
+var KMSKeyUsageActions = []string{
+	"kms:Decrypt",
+	"kms:DescribeKey",
+	"kms:Encrypt",
+	"kms:GenerateDataKey",
+	"kms:GenerateDataKeyWithoutPlaintext",
+	"kms:ReEncryptFrom",
+	"kms:ReEncryptTo",
+}
+
+// KMSKeyGrantActions are the actions that the roles of a cluster need to let AWS services, like
+// EC2 for encrypted volumes, use a customer managed key on their behalf.
+var KMSKeyGrantActions = []string{
+	"kms:CreateGrant",
+	"kms:ListGrants",
+	"kms:RevokeGrant",
+}
+
+// KMSKeyGrant is a set of actions that a role needs to be allowed to perform with a key.
+type KMSKeyGrant struct {
+	RoleARN string
+	Actions []string
+}
+
+// ValidateKMSKeyPolicy checks that the policy of the key allows each role to perform the actions
+// of its grant. It returns an aggregate with one error per role that is missing actions.
+func (c *awsClient) ValidateKMSKeyPolicy(keyARN string, grants []KMSKeyGrant) error {
+	config := &aws.Config{}
+	parsedARN, err := arn.Parse(keyARN)
+	if err == nil {
+		config.Region = aws.String(parsedARN.Region)
+	}
+	output, err := kms.New(c.awsSession, config).GetKeyPolicy(&kms.GetKeyPolicyInput{
+		KeyId:      aws.String(keyARN),
+		PolicyName: aws.String(defaultKeyPolicyName),
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to get the policy of KMS key '%s': %v", keyARN, err)
+	}
+	policy, err := ParsePolicyDocument(aws.StringValue(output.Policy))
+	if err != nil {
+		return fmt.Errorf("Failed to parse the policy of KMS key '%s': %v", keyARN, err)
+	}
+	return validateKMSKeyPolicy(keyARN, policy, grants)
+}
+
+func validateKMSKeyPolicy(keyARN string, policy *PolicyDocument, grants []KMSKeyGrant) error {
+	errs := []error{}
+	for _, grant := range grants {
+		missing := []string{}
+		for _, action := range grant.Actions {
+			if !keyPolicyAllows(policy, grant.RoleARN, action) {
+				missing = append(missing, action)
+			}
+		}
+		if len(missing) > 0 {
+			sort.Strings(missing)
+			errs = append(errs, fmt.Errorf("Policy of KMS key '%s' doesn't allow role '%s' to perform: %s",
+				keyARN, grant.RoleARN, strings.Join(missing, ", ")))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// keyPolicyAllows returns true if an Allow statement of the key policy applies to the role and
+// matches the action, and no Deny statement does. A statement applies to the role when it names the
+// role, any principal, or the root of the account of the role, as the latter delegates the access
+// to the IAM policies of the account. Conditions can't be evaluated without the request, so Allow
+// statements with conditions are assumed to apply and Deny statements with conditions aren't.
+func keyPolicyAllows(policy *PolicyDocument, roleARN string, action string) bool {
+	allowed := false
+	for _, statement := range policy.Statement {
+		if statement.Principal == nil ||
+			!principalsContain(statement.GetAWSPrincipals(), roleARN) ||
+			!statementMatchesAction(statement, action) {
+			continue
+		}
+		switch statement.Effect {
+		case "Allow":
+			allowed = true
+		case "Deny":
+			if len(statement.Condition) == 0 {
+				return false
+			}
+		}
+	}
+	return allowed
+}
+
+func principalsContain(principals []string, roleARN string) bool {
+	accountID := ""
+	rootARN := ""
+	parsedARN, err := arn.Parse(roleARN)
+	if err == nil {
+		accountID = parsedARN.AccountID
+		rootARN = fmt.Sprintf("arn:%s:iam::%s:root", parsedARN.Partition, parsedARN.AccountID)
+	}
+	for _, principal := range principals {
+		if principal == "*" || principal == roleARN {
+			return true
+		}
+		if accountID != "" && (principal == accountID || principal == rootARN) {
+			return true
+		}
+	}
+	return false
+}
+
+// statementMatchesAction checks if any of the actions of the statement, that may contain wildcards,
+// matches the given action.
+func statementMatchesAction(statement PolicyStatement, action string) bool {
+	for _, pattern := range stringList(statement.Action) {
+		if actionMatches(pattern, action) {
+			return true
+		}
+	}
+	return false
+}
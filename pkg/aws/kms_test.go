@@ -0,0 +1,173 @@
+package aws
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("KMS key policy validation", func() {
+	const (
+		keyARN       = "arn:aws:kms:us-east-1:123456789012:key/mrk-0123456789abcdef0123456789abcdef"
+		installerARN = "arn:aws:iam::123456789012:role/ManagedOpenShift-Installer-Role"
+		workerARN    = "arn:aws:iam::123456789012:role/ManagedOpenShift-Worker-Role"
+	)
+
+	parse := func(document string) *PolicyDocument {
+		policy, err := ParsePolicyDocument(document)
+		Expect(err).ToNot(HaveOccurred())
+		return policy
+	}
+
+	It("accepts policies that allow the roles", func() {
+		policy := parse(`{
+			"Version": "2012-10-17",
+			"Statement": [{
+				"Effect": "Allow",
+				"Principal": {"AWS": ["` + installerARN + `", "` + workerARN + `"]},
+				"Action": ["kms:Encrypt", "kms:Decrypt", "kms:ReEncrypt*", "kms:GenerateDataKey*", "kms:DescribeKey"],
+				"Resource": "*"
+			}, {
+				"Effect": "Allow",
+				"Principal": {"AWS": "` + installerARN + `"},
+				"Action": "kms:*",
+				"Resource": "*"
+			}]
+		}`)
+		err := validateKMSKeyPolicy(keyARN, policy, []KMSKeyGrant{
+			{RoleARN: installerARN, Actions: append(KMSKeyUsageActions, KMSKeyGrantActions...)},
+			{RoleARN: workerARN, Actions: KMSKeyUsageActions},
+		})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("accepts policies that allow the concrete actions instead of wildcards", func() {
+		policy := parse(`{
+			"Version": "2012-10-17",
+			"Statement": [{
+				"Effect": "Allow",
+				"Principal": {"AWS": "` + workerARN + `"},
+				"Action": ["kms:Encrypt", "kms:Decrypt", "kms:DescribeKey", "kms:GenerateDataKey",
+					"kms:GenerateDataKeyWithoutPlaintext", "kms:ReEncryptFrom", "kms:ReEncryptTo"],
+				"Resource": "*"
+			}]
+		}`)
+		err := validateKMSKeyPolicy(keyARN, policy, []KMSKeyGrant{
+			{RoleARN: workerARN, Actions: KMSKeyUsageActions},
+		})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("reports the actions missing for each role", func() {
+		policy := parse(`{
+			"Version": "2012-10-17",
+			"Statement": [{
+				"Effect": "Allow",
+				"Principal": {"AWS": "` + installerARN + `"},
+				"Action": ["kms:Encrypt", "kms:Decrypt", "kms:ReEncrypt*", "kms:GenerateDataKey*", "kms:DescribeKey"],
+				"Resource": "*"
+			}]
+		}`)
+		err := validateKMSKeyPolicy(keyARN, policy, []KMSKeyGrant{
+			{RoleARN: installerARN, Actions: append(KMSKeyUsageActions, KMSKeyGrantActions...)},
+			{RoleARN: workerARN, Actions: []string{"kms:Decrypt"}},
+		})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("doesn't allow role '" + installerARN +
+			"' to perform: kms:CreateGrant, kms:ListGrants, kms:RevokeGrant"))
+		Expect(err.Error()).To(ContainSubstring("doesn't allow role '" + workerARN + "' to perform: kms:Decrypt"))
+	})
+
+	It("accepts policies that delegate to the account of the roles", func() {
+		policy := parse(`{
+			"Version": "2012-10-17",
+			"Statement": [{
+				"Effect": "Allow",
+				"Principal": {"AWS": "arn:aws:iam::123456789012:root"},
+				"Action": "kms:*",
+				"Resource": "*"
+			}]
+		}`)
+		err := validateKMSKeyPolicy(keyARN, policy, []KMSKeyGrant{
+			{RoleARN: installerARN, Actions: append(KMSKeyUsageActions, KMSKeyGrantActions...)},
+		})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("doesn't accept the root of other accounts", func() {
+		policy := parse(`{
+			"Version": "2012-10-17",
+			"Statement": [{
+				"Effect": "Allow",
+				"Principal": {"AWS": ["arn:aws:iam::210987654321:root", "210987654321"]},
+				"Action": "*",
+				"Resource": "*"
+			}]
+		}`)
+		err := validateKMSKeyPolicy(keyARN, policy, []KMSKeyGrant{
+			{RoleARN: workerARN, Actions: []string{"kms:Decrypt"}},
+		})
+		Expect(err).To(MatchError(ContainSubstring("doesn't allow role '" + workerARN + "'")))
+	})
+
+	It("rejects actions denied to the role", func() {
+		policy := parse(`{
+			"Version": "2012-10-17",
+			"Statement": [{
+				"Effect": "Allow",
+				"Principal": {"AWS": "123456789012"},
+				"Action": "kms:*",
+				"Resource": "*"
+			}, {
+				"Effect": "Deny",
+				"Principal": {"AWS": "` + workerARN + `"},
+				"Action": "kms:*",
+				"Resource": "*"
+			}]
+		}`)
+		err := validateKMSKeyPolicy(keyARN, policy, []KMSKeyGrant{
+			{RoleARN: installerARN, Actions: []string{"kms:Decrypt"}},
+			{RoleARN: workerARN, Actions: []string{"kms:Decrypt"}},
+		})
+		Expect(err).To(MatchError("Policy of KMS key '" + keyARN + "' doesn't allow role '" + workerARN +
+			"' to perform: kms:Decrypt"))
+	})
+
+	It("ignores the deny statements with conditions", func() {
+		policy := parse(`{
+			"Version": "2012-10-17",
+			"Statement": [{
+				"Effect": "Allow",
+				"Principal": {"AWS": "` + workerARN + `"},
+				"Action": "kms:CreateGrant",
+				"Resource": "*",
+				"Condition": {"Bool": {"kms:GrantIsForAWSResource": "true"}}
+			}, {
+				"Effect": "Deny",
+				"Principal": {"AWS": "*"},
+				"Action": "kms:*",
+				"Resource": "*",
+				"Condition": {"Bool": {"aws:SecureTransport": "false"}}
+			}]
+		}`)
+		err := validateKMSKeyPolicy(keyARN, policy, []KMSKeyGrant{
+			{RoleARN: workerARN, Actions: []string{"kms:CreateGrant"}},
+		})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("accepts statements allowing any principal", func() {
+		policy := parse(`{
+			"Version": "2012-10-17",
+			"Statement": [{
+				"Effect": "Allow",
+				"Principal": {"AWS": "*"},
+				"Action": "kms:Decrypt",
+				"Resource": "*"
+			}]
+		}`)
+		err := validateKMSKeyPolicy(keyARN, policy, []KMSKeyGrant{
+			{RoleARN: workerARN, Actions: []string{"kms:Decrypt"}},
+		})
+		Expect(err).ToNot(HaveOccurred())
+	})
+})
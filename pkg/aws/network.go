@@ -0,0 +1,216 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions used to manage the CloudFormation stacks that create VPCs ready
+// to install clusters.
+
+package aws
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+
+	"github.com/openshift/rosa/pkg/aws/tags"
+)
+
+const (
+	DefaultNetworkName = "rosa-network"
+
+	networkTemplatePath = "templates/cloudformation/rosa_network.json"
+
+	// A VPC contains a public and a private subnet for each of the three availability zones, so
+	// subnets are three bits longer than the VPC, and need enough addresses for the nodes:
+	networkSubnetBits    = 3
+	minNetworkPrefixSize = 16
+	maxNetworkPrefixSize = 22
+)
+
+// NetworkStackInput describes the VPC that will be created.
+type NetworkStackInput struct {
+	Name     string
+	VpcCIDR  net.IPNet
+	MultiAZ  bool
+	UserTags map[string]string
+}
+
+// NetworkStack describes the resources created by a network stack.
+type NetworkStack struct {
+	Name             string   `json:"name"`
+	VpcID            string   `json:"vpc_id"`
+	PublicSubnetIDs  []string `json:"public_subnet_ids"`
+	PrivateSubnetIDs []string `json:"private_subnet_ids"`
+}
+
+// SubnetIDs returns all the subnets of the network, as expected by the '--subnet-ids' option.
+func (n *NetworkStack) SubnetIDs() []string {
+	return append(append([]string{}, n.PublicSubnetIDs...), n.PrivateSubnetIDs...)
+}
+
+// GetNetworkSubnetCidrBits returns the number of host bits of the subnets of a VPC with the given
+// CIDR block.
+func GetNetworkSubnetCidrBits(vpcCIDR net.IPNet) (int, error) {
+	ones, bits := vpcCIDR.Mask.Size()
+	if bits != 32 {
+		return 0, fmt.Errorf("Expected an IPv4 CIDR block for the VPC, got '%s'", vpcCIDR.String())
+	}
+	if ones < minNetworkPrefixSize || ones > maxNetworkPrefixSize {
+		return 0, fmt.Errorf("The prefix size of the CIDR block of the VPC must be between /%d and /%d, got '%s'",
+			minNetworkPrefixSize, maxNetworkPrefixSize, vpcCIDR.String())
+	}
+	return bits - ones - networkSubnetBits, nil
+}
+
+// CreateNetworkStack creates the CloudFormation stack of the network and waits till it is complete.
+func (c *awsClient) CreateNetworkStack(input NetworkStackInput) (*NetworkStack, error) {
+	subnetCidrBits, err := GetNetworkSubnetCidrBits(input.VpcCIDR)
+	if err != nil {
+		return nil, err
+	}
+	cfTemplateBody, err := readCloudFormationTemplate(networkTemplatePath)
+	if err != nil {
+		return nil, err
+	}
+	stackTags := MergeUserTags(input.UserTags, map[string]string{
+		tags.RedHatManaged: tags.True,
+	})
+	cfTags := []*cloudformation.Tag{}
+	for _, key := range sortedKeys(stackTags) {
+		cfTags = append(cfTags, &cloudformation.Tag{
+			Key:   aws.String(key),
+			Value: aws.String(stackTags[key]),
+		})
+	}
+	_, err = c.cfClient.CreateStack(&cloudformation.CreateStackInput{
+		StackName:    aws.String(input.Name),
+		TemplateBody: aws.String(cfTemplateBody),
+		Parameters: []*cloudformation.Parameter{
+			{
+				ParameterKey:   aws.String("VpcCidr"),
+				ParameterValue: aws.String(input.VpcCIDR.String()),
+			},
+			{
+				ParameterKey:   aws.String("SubnetCidrBits"),
+				ParameterValue: aws.String(strconv.Itoa(subnetCidrBits)),
+			},
+			{
+				ParameterKey:   aws.String("MultiAZ"),
+				ParameterValue: aws.String(strconv.FormatBool(input.MultiAZ)),
+			},
+		},
+		Tags: cfTags,
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = c.cfClient.WaitUntilStackCreateComplete(&cloudformation.DescribeStacksInput{
+		StackName: aws.String(input.Name),
+	})
+	if err != nil {
+		reason := c.getStackFailureReason(input.Name)
+		if reason != "" {
+			return nil, fmt.Errorf("Failed to create stack '%s': %s", input.Name, reason)
+		}
+		return nil, err
+	}
+	return c.GetNetworkStack(input.Name)
+}
+
+// GetNetworkStack returns the resources created by the network stack with the given name.
+func (c *awsClient) GetNetworkStack(name string) (*NetworkStack, error) {
+	output, err := c.cfClient.DescribeStacks(&cloudformation.DescribeStacksInput{
+		StackName: aws.String(name),
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == "ValidationError" {
+			return nil, fmt.Errorf("Network stack '%s' doesn't exist", name)
+		}
+		return nil, err
+	}
+	if len(output.Stacks) == 0 {
+		return nil, fmt.Errorf("Network stack '%s' doesn't exist", name)
+	}
+	network := &NetworkStack{
+		Name: name,
+	}
+	for _, stackOutput := range output.Stacks[0].Outputs {
+		value := aws.StringValue(stackOutput.OutputValue)
+		switch aws.StringValue(stackOutput.OutputKey) {
+		case "VpcId":
+			network.VpcID = value
+		case "PublicSubnetIds":
+			network.PublicSubnetIDs = strings.Split(value, ",")
+		case "PrivateSubnetIds":
+			network.PrivateSubnetIDs = strings.Split(value, ",")
+		}
+	}
+	if network.VpcID == "" {
+		return nil, fmt.Errorf("Stack '%s' isn't a network stack created by rosa", name)
+	}
+	return network, nil
+}
+
+// DeleteNetworkStack deletes the CloudFormation stack of the network and waits till it is gone.
+func (c *awsClient) DeleteNetworkStack(name string) error {
+	_, err := c.GetNetworkStack(name)
+	if err != nil {
+		return err
+	}
+	_, err = c.cfClient.DeleteStack(&cloudformation.DeleteStackInput{
+		StackName: aws.String(name),
+	})
+	if err != nil {
+		return err
+	}
+	err = c.cfClient.WaitUntilStackDeleteComplete(&cloudformation.DescribeStacksInput{
+		StackName: aws.String(name),
+	})
+	if err != nil {
+		reason := c.getStackFailureReason(name)
+		if reason != "" {
+			return fmt.Errorf("Failed to delete stack '%s': %s", name, reason)
+		}
+		return err
+	}
+	return nil
+}
+
+// getStackFailureReason returns the reason of the first failed event of the stack, which is usually
+// the one that explains why the whole stack failed.
+func (c *awsClient) getStackFailureReason(name string) string {
+	output, err := c.cfClient.DescribeStackEvents(&cloudformation.DescribeStackEventsInput{
+		StackName: aws.String(name),
+	})
+	if err != nil {
+		return ""
+	}
+	reason := ""
+	// Events are returned in reverse chronological order:
+	for _, event := range output.StackEvents {
+		status := aws.StringValue(event.ResourceStatus)
+		if strings.HasSuffix(status, "_FAILED") && aws.StringValue(event.ResourceStatusReason) != "" &&
+			aws.StringValue(event.ResourceType) != "AWS::CloudFormation::Stack" {
+			reason = fmt.Sprintf("%s '%s': %s", aws.StringValue(event.ResourceType),
+				aws.StringValue(event.LogicalResourceId), aws.StringValue(event.ResourceStatusReason))
+		}
+	}
+	return reason
+}
@@ -0,0 +1,49 @@
+package aws_test
+
+import (
+	"encoding/json"
+	"net"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/rosa/assets"
+	"github.com/openshift/rosa/pkg/aws"
+)
+
+var _ = Describe("Network", func() {
+	DescribeTable("GetNetworkSubnetCidrBits",
+		func(vpcCIDR string, expected int, expectedErr string) {
+			_, ipNet, err := net.ParseCIDR(vpcCIDR)
+			Expect(err).NotTo(HaveOccurred())
+			bits, err := aws.GetNetworkSubnetCidrBits(*ipNet)
+			if expectedErr != "" {
+				Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+				return
+			}
+			Expect(err).NotTo(HaveOccurred())
+			Expect(bits).To(Equal(expected))
+		},
+		Entry("default VPC", "10.0.0.0/16", 13, ""),
+		Entry("smallest VPC", "10.0.0.0/22", 7, ""),
+		Entry("too large", "10.0.0.0/8", 0, "must be between /16 and /22"),
+		Entry("too small", "10.0.0.0/24", 0, "must be between /16 and /22"),
+		Entry("IPv6", "fd00::/48", 0, "Expected an IPv4 CIDR block"),
+	)
+
+	It("Embeds a valid template with the outputs used by rosa", func() {
+		data, err := assets.Asset("templates/cloudformation/rosa_network.json")
+		Expect(err).NotTo(HaveOccurred())
+		template := struct {
+			Parameters map[string]interface{}
+			Outputs    map[string]interface{}
+		}{}
+		Expect(json.Unmarshal(data, &template)).To(Succeed())
+		Expect(template.Parameters).To(HaveKey("VpcCidr"))
+		Expect(template.Parameters).To(HaveKey("SubnetCidrBits"))
+		Expect(template.Parameters).To(HaveKey("MultiAZ"))
+		Expect(template.Outputs).To(HaveKey("VpcId"))
+		Expect(template.Outputs).To(HaveKey("PublicSubnetIds"))
+		Expect(template.Outputs).To(HaveKey("PrivateSubnetIds"))
+	})
+})
@@ -0,0 +1,107 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path"
+
+	"github.com/openshift/rosa/pkg/helper"
+)
+
+const assumeRoleWithWebIdentity = "sts:AssumeRoleWithWebIdentity"
+
+// trustPolicyDocument models the parts of a role trust policy that bind it to an OIDC provider. The
+// condition isn't part of PolicyStatement, as it isn't needed anywhere else.
+type trustPolicyDocument struct {
+	Statement []struct {
+		Effect    string                            `json:"Effect"`
+		Principal *PolicyStatementPrincipal         `json:"Principal,omitempty"`
+		Action    interface{}                       `json:"Action,omitempty"`
+		Condition map[string]map[string]interface{} `json:"Condition,omitempty"`
+	} `json:"Statement"`
+}
+
+// VerifyOperatorRoleTrustPolicy checks that the trust policy of an operator role allows the given
+// service accounts to assume it using tokens issued by the OIDC provider.
+func VerifyOperatorRoleTrustPolicy(trustPolicy string, providerARN string, serviceAccounts []string) error {
+	unescaped, err := url.QueryUnescape(trustPolicy)
+	if err != nil {
+		return err
+	}
+	document := trustPolicyDocument{}
+	err = json.Unmarshal([]byte(unescaped), &document)
+	if err != nil {
+		return fmt.Errorf("Failed to parse trust policy: %v", err)
+	}
+	providerURL := GetOIDCProviderURLFromARN(providerARN)
+	subjectKey := providerURL + ":sub"
+
+	trusted := false
+	allowed := map[string]bool{}
+	for _, statement := range document.Statement {
+		if statement.Effect != "Allow" || statement.Principal == nil ||
+			statement.Principal.Federated != providerARN ||
+			!helper.Contains(stringList(statement.Action), assumeRoleWithWebIdentity) {
+			continue
+		}
+		trusted = true
+		for _, serviceAccount := range serviceAccounts {
+			if conditionAllows(statement.Condition, subjectKey, serviceAccount) {
+				allowed[serviceAccount] = true
+			}
+		}
+	}
+	if !trusted {
+		return fmt.Errorf("Trust policy doesn't allow '%s' with federated principal '%s'",
+			assumeRoleWithWebIdentity, providerARN)
+	}
+	for _, serviceAccount := range serviceAccounts {
+		if !allowed[serviceAccount] {
+			return fmt.Errorf("Trust policy doesn't allow subject '%s' of issuer '%s'", serviceAccount, providerURL)
+		}
+	}
+	return nil
+}
+
+// conditionAllows checks if the subject condition of a statement matches the service account. A
+// statement without a subject condition allows any service account.
+func conditionAllows(condition map[string]map[string]interface{}, subjectKey string, serviceAccount string) bool {
+	found := false
+	for operator, values := range condition {
+		subjects, ok := values[subjectKey]
+		if !ok {
+			continue
+		}
+		found = true
+		for _, subject := range stringList(subjects) {
+			switch operator {
+			case "StringEquals":
+				if subject == serviceAccount {
+					return true
+				}
+			case "StringLike":
+				if matched, _ := path.Match(subject, serviceAccount); matched {
+					return true
+				}
+			}
+		}
+	}
+	return !found
+}
@@ -0,0 +1,87 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"net/url"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Operator role trust policy", func() {
+	const (
+		providerARN = "arn:aws:iam::123456789012:oidc-provider/oidc.example.com/abc"
+		subject     = "system:serviceaccount:openshift-image-registry:registry"
+	)
+
+	trustPolicy := func(federated string, operator string, subjects string) string {
+		return `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Effect": "Allow",
+      "Principal": {
+        "Federated": "` + federated + `"
+      },
+      "Action": "sts:AssumeRoleWithWebIdentity",
+      "Condition": {
+        "` + operator + `": {
+          "oidc.example.com/abc:sub": ` + subjects + `
+        }
+      }
+    }
+  ]
+}`
+	}
+
+	It("accepts a policy that allows the service accounts", func() {
+		policy := trustPolicy(providerARN, "StringEquals",
+			`["`+subject+`", "system:serviceaccount:openshift-image-registry:other"]`)
+		Expect(VerifyOperatorRoleTrustPolicy(policy, providerARN, []string{subject})).To(Succeed())
+	})
+
+	It("accepts an URL encoded policy", func() {
+		policy := url.QueryEscape(trustPolicy(providerARN, "StringEquals", `"`+subject+`"`))
+		Expect(VerifyOperatorRoleTrustPolicy(policy, providerARN, []string{subject})).To(Succeed())
+	})
+
+	It("accepts wildcard subjects", func() {
+		policy := trustPolicy(providerARN, "StringLike", `"system:serviceaccount:openshift-image-registry:*"`)
+		Expect(VerifyOperatorRoleTrustPolicy(policy, providerARN, []string{subject})).To(Succeed())
+	})
+
+	It("rejects a policy that trusts another provider", func() {
+		policy := trustPolicy("arn:aws:iam::123456789012:oidc-provider/oidc.example.com/def", "StringEquals",
+			`"`+subject+`"`)
+		err := VerifyOperatorRoleTrustPolicy(policy, providerARN, []string{subject})
+		Expect(err).To(MatchError(ContainSubstring("federated principal '" + providerARN + "'")))
+	})
+
+	It("rejects a policy that doesn't allow the service account", func() {
+		policy := trustPolicy(providerARN, "StringEquals", `"system:serviceaccount:other:registry"`)
+		err := VerifyOperatorRoleTrustPolicy(policy, providerARN, []string{subject})
+		Expect(err).To(MatchError(ContainSubstring("doesn't allow subject '" + subject + "'")))
+	})
+
+	It("rejects a policy with a condition on another issuer", func() {
+		policy := trustPolicy(providerARN, "StringEquals", `"`+subject+`"`)
+		err := VerifyOperatorRoleTrustPolicy(policy,
+			"arn:aws:iam::123456789012:oidc-provider/oidc.example.com/abc/", []string{subject})
+		Expect(err).To(HaveOccurred())
+	})
+})
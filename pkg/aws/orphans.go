@@ -0,0 +1,107 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions used to find the IAM resources created for clusters, so that
+// the ones left behind by deleted clusters can be cleaned up.
+
+package aws
+
+import (
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/iam"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/aws/tags"
+)
+
+// ClusterResources contains the IAM resources tagged with the identifier of a cluster.
+type ClusterResources struct {
+	ClusterID     string   `json:"cluster_id"`
+	OperatorRoles []string `json:"operator_roles,omitempty"`
+	OIDCProviders []string `json:"oidc_providers,omitempty"`
+}
+
+// GetClusterTaggedResources returns the operator roles and OIDC providers of the account that
+// are tagged with a cluster identifier, grouped by cluster and sorted by cluster identifier.
+func (c *awsClient) GetClusterTaggedResources(
+	credRequests map[string]*cmv1.STSOperator) ([]*ClusterResources, error) {
+	resources := map[string]*ClusterResources{}
+	get := func(clusterID string) *ClusterResources {
+		if _, ok := resources[clusterID]; !ok {
+			resources[clusterID] = &ClusterResources{ClusterID: clusterID}
+		}
+		return resources[clusterID]
+	}
+
+	roles, err := c.ListRoles()
+	if err != nil {
+		return nil, err
+	}
+	for _, role := range roles {
+		if !checkIfROSAOperatorRole(role.RoleName, credRequests) {
+			continue
+		}
+		listRoleTagsOutput, err := c.iamClient.ListRoleTags(&iam.ListRoleTagsInput{
+			RoleName: role.RoleName,
+		})
+		if err != nil {
+			return nil, err
+		}
+		clusterID := getTagValue(listRoleTagsOutput.Tags, tags.ClusterID)
+		if clusterID != "" {
+			cluster := get(clusterID)
+			cluster.OperatorRoles = append(cluster.OperatorRoles, aws.StringValue(role.RoleName))
+		}
+	}
+
+	providers, err := c.iamClient.ListOpenIDConnectProviders(&iam.ListOpenIDConnectProvidersInput{})
+	if err != nil {
+		return nil, err
+	}
+	for _, provider := range providers.OpenIDConnectProviderList {
+		connectProvider, err := c.iamClient.GetOpenIDConnectProvider(&iam.GetOpenIDConnectProviderInput{
+			OpenIDConnectProviderArn: provider.Arn,
+		})
+		if err != nil {
+			return nil, err
+		}
+		clusterID := getTagValue(connectProvider.Tags, tags.ClusterID)
+		if clusterID != "" {
+			cluster := get(clusterID)
+			cluster.OIDCProviders = append(cluster.OIDCProviders, aws.StringValue(provider.Arn))
+		}
+	}
+
+	result := make([]*ClusterResources, 0, len(resources))
+	for _, cluster := range resources {
+		result = append(result, cluster)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ClusterID < result[j].ClusterID
+	})
+	return result, nil
+}
+
+func getTagValue(tagList []*iam.Tag, key string) string {
+	for _, tag := range tagList {
+		if aws.StringValue(tag.Key) == key {
+			return aws.StringValue(tag.Value)
+		}
+	}
+	return ""
+}
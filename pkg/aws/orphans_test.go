@@ -0,0 +1,96 @@
+package aws_test
+
+import (
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/aws/mocks"
+	"github.com/openshift/rosa/pkg/aws/tags"
+)
+
+var _ = Describe("Cluster tagged resources", func() {
+	var (
+		client     aws.Client
+		mockCtrl   *gomock.Controller
+		mockIamAPI *mocks.MockIAMAPI
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockIamAPI = mocks.NewMockIAMAPI(mockCtrl)
+		client = aws.New(
+			logrus.New(),
+			mockIamAPI,
+			mocks.NewMockEC2API(mockCtrl),
+			mocks.NewMockOrganizationsAPI(mockCtrl),
+			mocks.NewMockS3API(mockCtrl),
+			mocks.NewMockSecretsManagerAPI(mockCtrl),
+			mocks.NewMockSTSAPI(mockCtrl),
+			mocks.NewMockCloudFormationAPI(mockCtrl),
+			mocks.NewMockServiceQuotasAPI(mockCtrl),
+			&session.Session{},
+			&aws.AccessKey{},
+		)
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	It("Groups the operator roles and OIDC providers by cluster", func() {
+		operator, err := cmv1.NewSTSOperator().Namespace("openshift-ingress-operator").Build()
+		Expect(err).NotTo(HaveOccurred())
+		clusterTag := func(clusterID string) []*iam.Tag {
+			return []*iam.Tag{{Key: awssdk.String(tags.ClusterID), Value: awssdk.String(clusterID)}}
+		}
+
+		mockIamAPI.EXPECT().ListRolesPages(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ *iam.ListRolesInput, fn func(*iam.ListRolesOutput, bool) bool) error {
+				fn(&iam.ListRolesOutput{Roles: []*iam.Role{
+					{RoleName: awssdk.String("b-openshift-ingress-operator-cloud-credentials")},
+					{RoleName: awssdk.String("a-openshift-ingress-operator-cloud-credentials")},
+					{RoleName: awssdk.String("ManagedOpenShift-Installer-Role")},
+				}}, true)
+				return nil
+			})
+		mockIamAPI.EXPECT().ListRoleTags(&iam.ListRoleTagsInput{
+			RoleName: awssdk.String("b-openshift-ingress-operator-cloud-credentials"),
+		}).Return(&iam.ListRoleTagsOutput{Tags: clusterTag("cluster-b")}, nil)
+		mockIamAPI.EXPECT().ListRoleTags(&iam.ListRoleTagsInput{
+			RoleName: awssdk.String("a-openshift-ingress-operator-cloud-credentials"),
+		}).Return(&iam.ListRoleTagsOutput{Tags: clusterTag("cluster-a")}, nil)
+		mockIamAPI.EXPECT().ListOpenIDConnectProviders(gomock.Any()).Return(&iam.ListOpenIDConnectProvidersOutput{
+			OpenIDConnectProviderList: []*iam.OpenIDConnectProviderListEntry{
+				{Arn: awssdk.String("arn:aws:iam::123:oidc-provider/a")},
+				{Arn: awssdk.String("arn:aws:iam::123:oidc-provider/untagged")},
+			},
+		}, nil)
+		mockIamAPI.EXPECT().GetOpenIDConnectProvider(&iam.GetOpenIDConnectProviderInput{
+			OpenIDConnectProviderArn: awssdk.String("arn:aws:iam::123:oidc-provider/a"),
+		}).Return(&iam.GetOpenIDConnectProviderOutput{Tags: clusterTag("cluster-a")}, nil)
+		mockIamAPI.EXPECT().GetOpenIDConnectProvider(&iam.GetOpenIDConnectProviderInput{
+			OpenIDConnectProviderArn: awssdk.String("arn:aws:iam::123:oidc-provider/untagged"),
+		}).Return(&iam.GetOpenIDConnectProviderOutput{}, nil)
+
+		resources, err := client.GetClusterTaggedResources(map[string]*cmv1.STSOperator{"ingress": operator})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resources).To(Equal([]*aws.ClusterResources{
+			{
+				ClusterID:     "cluster-a",
+				OperatorRoles: []string{"a-openshift-ingress-operator-cloud-credentials"},
+				OIDCProviders: []string{"arn:aws:iam::123:oidc-provider/a"},
+			},
+			{
+				ClusterID:     "cluster-b",
+				OperatorRoles: []string{"b-openshift-ingress-operator-cloud-credentials"},
+			},
+		}))
+	})
+})
@@ -2,9 +2,13 @@ package aws
 
 import (
 	"fmt"
+	"path"
+	"strings"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/organizations"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 )
 
@@ -14,8 +18,35 @@ type SimulateParams struct {
 	Region string
 }
 
+// PermissionDenial describes an action that the simulated principal isn't allowed to perform on a
+// resource, and what denies it.
+type PermissionDenial struct {
+	Action   string `json:"action"`
+	Resource string `json:"resource"`
+	Decision string `json:"decision"`
+	DeniedBy string `json:"denied_by"`
+}
+
 // ValidateSCP attempts to validate SCP policies by ensuring we have the correct permissions
 func (c *awsClient) ValidateSCP(target *string, policies map[string]*cmv1.AWSSTSPolicy) (bool, error) {
+	denials, err := c.VerifySCP(target, policies)
+	if err != nil {
+		return false, err
+	}
+	if len(denials) > 0 {
+		var failedActions []string
+		for _, denial := range denials {
+			failedActions = append(failedActions, denial.Action)
+		}
+		return false, fmt.Errorf("Actions not allowed with tested credentials: %v", failedActions)
+	}
+	return true, nil
+}
+
+// VerifySCP simulates the actions of the OSD SCP policy for the target user, or for the caller if
+// the target is nil, and returns the ones that are denied. The simulation takes into account the
+// service control policies of the organization and the permissions boundary of the principal.
+func (c *awsClient) VerifySCP(target *string, policies map[string]*cmv1.AWSSTSPolicy) ([]*PermissionDenial, error) {
 	policyDetails := GetPolicyDetails(policies, "osd_scp_policy")
 
 	sParams := &SimulateParams{
@@ -24,13 +55,13 @@ func (c *awsClient) ValidateSCP(target *string, policies map[string]*cmv1.AWSSTS
 	// Read installer permissions and OSD SCP Policy permissions
 	osdPolicyDocument, err := ParsePolicyDocument(policyDetails)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 
 	// Get Creator details
 	creator, err := c.GetCreator()
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 
 	// Find target user
@@ -39,12 +70,12 @@ func (c *awsClient) ValidateSCP(target *string, policies map[string]*cmv1.AWSSTS
 		var err error
 		callerIdentity, _, err := getClientDetails(c)
 		if err != nil {
-			return false, fmt.Errorf("getClientDetails: %v\n"+
+			return nil, fmt.Errorf("getClientDetails: %v\n"+
 				"Run 'rosa init' and try again", err)
 		}
 		targetUserARN, err = arn.Parse(*callerIdentity.Arn)
 		if err != nil {
-			return false, fmt.Errorf("unable to parse caller ARN %v", err)
+			return nil, fmt.Errorf("unable to parse caller ARN %v", err)
 		}
 		// If the client is using STS credentials want to validate the role
 		// the user has assumed. GetCreator() resolves that for us and updates
@@ -52,29 +83,130 @@ func (c *awsClient) ValidateSCP(target *string, policies map[string]*cmv1.AWSSTS
 		if creator.IsSTS {
 			targetUserARN, err = arn.Parse(creator.ARN)
 			if err != nil {
-				return false, err
+				return nil, err
 			}
 		}
 	} else {
 		targetIAMOutput, err := c.iamClient.GetUser(&iam.GetUserInput{UserName: target})
 		if err != nil {
-			return false, fmt.Errorf("iamClient.GetUser: %v\n"+
+			return nil, fmt.Errorf("iamClient.GetUser: %v\n"+
 				"To reset the '%s' account, run 'rosa init --delete-stack' and try again", *target, err)
 		}
 		targetUserARN, err = arn.Parse(*targetIAMOutput.User.Arn)
 		if err != nil {
-			return false, fmt.Errorf("unable to parse caller ARN %v", err)
+			return nil, fmt.Errorf("unable to parse caller ARN %v", err)
 		}
 	}
 
 	// Validate permissions
-	hasPermissions, err := osdPolicyDocument.checkPermissionsUsingQueryClient(c, targetUserARN.String(), sParams)
+	denials, err := osdPolicyDocument.simulatePermissions(c, targetUserARN.String(), sParams)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
-	if !hasPermissions {
-		return false, err
+	if len(denials) > 0 {
+		// Reading the policies of the organization usually requires the management account, so
+		// when that isn't possible the denials just don't name the policy:
+		scps, err := c.getServiceControlPolicies(targetUserARN.AccountID)
+		if err != nil {
+			c.logger.Debugf("Unable to read the service control policies of account '%s': %v",
+				targetUserARN.AccountID, err)
+		}
+		for _, denial := range denials {
+			if denial.DeniedBy == deniedByOrganization {
+				if name := findDenyingPolicy(scps, denial.Action); name != "" {
+					denial.DeniedBy = fmt.Sprintf("%s '%s'", deniedByOrganization, name)
+				}
+			}
+		}
 	}
+	return denials, nil
+}
 
-	return true, nil
+const deniedByOrganization = "organization service control policy"
+
+// serviceControlPolicy is a service control policy that applies to an account, directly or through
+// one of the organizational units that contain it.
+type serviceControlPolicy struct {
+	Name     string
+	Document *PolicyDocument
+}
+
+// getServiceControlPolicies returns the service control policies that apply to the account,
+// including the ones attached to its organizational units and to the root of the organization.
+func (c *awsClient) getServiceControlPolicies(accountID string) ([]*serviceControlPolicy, error) {
+	var scps []*serviceControlPolicy
+	seen := map[string]bool{}
+	targetID := accountID
+	isRoot := false
+	for targetID != "" {
+		err := c.orgClient.ListPoliciesForTargetPages(&organizations.ListPoliciesForTargetInput{
+			TargetId: aws.String(targetID),
+			Filter:   aws.String(organizations.PolicyTypeServiceControlPolicy),
+		}, func(page *organizations.ListPoliciesForTargetOutput, lastPage bool) bool {
+			for _, summary := range page.Policies {
+				if !seen[aws.StringValue(summary.Id)] {
+					seen[aws.StringValue(summary.Id)] = true
+					scps = append(scps, &serviceControlPolicy{Name: aws.StringValue(summary.Id)})
+				}
+			}
+			return !lastPage
+		})
+		if err != nil {
+			return nil, err
+		}
+		// The root of the organization has no parents, and AWS rejects it as a child:
+		if isRoot {
+			break
+		}
+		parents, err := c.orgClient.ListParents(&organizations.ListParentsInput{
+			ChildId: aws.String(targetID),
+		})
+		if err != nil {
+			return nil, err
+		}
+		targetID = ""
+		if len(parents.Parents) > 0 {
+			targetID = aws.StringValue(parents.Parents[0].Id)
+			isRoot = aws.StringValue(parents.Parents[0].Type) == organizations.ParentTypeRoot
+		}
+	}
+	for _, scp := range scps {
+		output, err := c.orgClient.DescribePolicy(&organizations.DescribePolicyInput{
+			PolicyId: aws.String(scp.Name),
+		})
+		if err != nil {
+			return nil, err
+		}
+		scp.Name = aws.StringValue(output.Policy.PolicySummary.Name)
+		scp.Document, err = ParsePolicyDocument(aws.StringValue(output.Policy.Content))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return scps, nil
+}
+
+// findDenyingPolicy returns the name of the first policy with a statement that explicitly denies
+// the action.
+func findDenyingPolicy(scps []*serviceControlPolicy, action string) string {
+	for _, scp := range scps {
+		for _, statement := range scp.Document.Statement {
+			if statement.Effect != "Deny" {
+				continue
+			}
+			for _, pattern := range stringList(statement.Action) {
+				if actionMatches(pattern, action) {
+					return scp.Name
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// actionMatches checks if the action matches a policy action pattern, which can contain the '*'
+// and '?' wildcards and is case insensitive.
+func actionMatches(pattern string, action string) bool {
+	matched, err := path.Match(strings.ToLower(pattern), strings.ToLower(action))
+	return err == nil && matched
 }
@@ -0,0 +1,175 @@
+package aws
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/organizations"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/rosa/pkg/aws/mocks"
+)
+
+var _ = Describe("Permission simulation", func() {
+	It("Groups the allowed actions by their concrete resources", func() {
+		document, err := ParsePolicyDocument(`{"Statement": [
+			{"Effect": "Allow", "Action": ["ec2:RunInstances", "ec2:CreateTags"], "Resource": "*"},
+			{"Effect": "Allow", "Action": "s3:GetObject", "Resource": ["arn:aws:s3:::bucket/key"]},
+			{"Effect": "Allow", "Action": "iam:GetRole", "Resource": "arn:aws:iam::*:role/*"},
+			{"Effect": "Deny", "Action": "iam:DeleteRole", "Resource": "*"}
+		]}`)
+		Expect(err).NotTo(HaveOccurred())
+		groups := document.groupAllowedActionsByResource()
+		Expect(groups).To(HaveLen(2))
+		Expect(groups[0].resources).To(BeEmpty())
+		Expect(groups[0].actions).To(Equal([]string{"ec2:RunInstances", "ec2:CreateTags", "iam:GetRole"}))
+		Expect(groups[1].resources).To(Equal([]string{"arn:aws:s3:::bucket/key"}))
+		Expect(groups[1].actions).To(Equal([]string{"s3:GetObject"}))
+	})
+
+	It("Explains what denies each action", func() {
+		Expect(explainDenials(&iam.EvaluationResult{
+			EvalActionName:   aws.String("ec2:RunInstances"),
+			EvalResourceName: aws.String("*"),
+			EvalDecision:     aws.String(iam.PolicyEvaluationDecisionTypeAllowed),
+		})).To(BeEmpty())
+
+		Expect(explainDenials(&iam.EvaluationResult{
+			EvalActionName:   aws.String("ec2:RunInstances"),
+			EvalResourceName: aws.String("*"),
+			EvalDecision:     aws.String(iam.PolicyEvaluationDecisionTypeExplicitDeny),
+			OrganizationsDecisionDetail: &iam.OrganizationsDecisionDetail{
+				AllowedByOrganizations: aws.Bool(false),
+			},
+		})).To(Equal([]*PermissionDenial{{
+			Action:   "ec2:RunInstances",
+			Resource: "*",
+			Decision: iam.PolicyEvaluationDecisionTypeExplicitDeny,
+			DeniedBy: deniedByOrganization,
+		}}))
+
+		Expect(explainDenials(&iam.EvaluationResult{
+			EvalActionName:   aws.String("iam:CreateRole"),
+			EvalResourceName: aws.String("*"),
+			EvalDecision:     aws.String(iam.PolicyEvaluationDecisionTypeImplicitDeny),
+			PermissionsBoundaryDecisionDetail: &iam.PermissionsBoundaryDecisionDetail{
+				AllowedByPermissionsBoundary: aws.Bool(false),
+			},
+		})[0].DeniedBy).To(Equal("permissions boundary"))
+
+		denials := explainDenials(&iam.EvaluationResult{
+			EvalActionName: aws.String("s3:GetObject"),
+			EvalDecision:   aws.String(iam.PolicyEvaluationDecisionTypeExplicitDeny),
+			ResourceSpecificResults: []*iam.ResourceSpecificResult{
+				{
+					EvalResourceName:     aws.String("arn:aws:s3:::bucket/a"),
+					EvalResourceDecision: aws.String(iam.PolicyEvaluationDecisionTypeAllowed),
+				},
+				{
+					EvalResourceName:     aws.String("arn:aws:s3:::bucket/b"),
+					EvalResourceDecision: aws.String(iam.PolicyEvaluationDecisionTypeExplicitDeny),
+					MatchedStatements: []*iam.Statement{{
+						SourcePolicyId:   aws.String("deny-b"),
+						SourcePolicyType: aws.String(iam.PolicySourceTypeUser),
+					}},
+				},
+				{
+					EvalResourceName:     aws.String("arn:aws:s3:::bucket/c"),
+					EvalResourceDecision: aws.String(iam.PolicyEvaluationDecisionTypeImplicitDeny),
+				},
+			},
+		})
+		Expect(denials).To(HaveLen(2))
+		Expect(denials[0].Resource).To(Equal("arn:aws:s3:::bucket/b"))
+		Expect(denials[0].DeniedBy).To(Equal("explicit deny in user 'deny-b'"))
+		Expect(denials[1].DeniedBy).To(Equal("no policy allows it"))
+	})
+
+	It("Finds the service control policy that denies an action", func() {
+		document, err := ParsePolicyDocument(`{"Statement": [
+			{"Effect": "Deny", "Action": ["ec2:Run*", "iam:Create?ole"], "Resource": "*"}
+		]}`)
+		Expect(err).NotTo(HaveOccurred())
+		scps := []*serviceControlPolicy{{Name: "deny-compute", Document: document}}
+		Expect(findDenyingPolicy(scps, "ec2:RunInstances")).To(Equal("deny-compute"))
+		Expect(findDenyingPolicy(scps, "IAM:CreateRole")).To(Equal("deny-compute"))
+		Expect(findDenyingPolicy(scps, "ec2:CreateTags")).To(BeEmpty())
+	})
+
+	Context("getServiceControlPolicies", func() {
+		var (
+			mockCtrl   *gomock.Controller
+			mockOrgAPI *mocks.MockOrganizationsAPI
+			client     *awsClient
+		)
+
+		BeforeEach(func() {
+			mockCtrl = gomock.NewController(GinkgoT())
+			mockOrgAPI = mocks.NewMockOrganizationsAPI(mockCtrl)
+			client = &awsClient{
+				logger:    logrus.New(),
+				orgClient: mockOrgAPI,
+			}
+		})
+
+		AfterEach(func() {
+			mockCtrl.Finish()
+		})
+
+		It("Walks up to the root of the organization without asking for its parents", func() {
+			policies := map[string]string{
+				"123456789012":     "p-account",
+				"ou-abcd-12345678": "p-unit",
+				"r-abcd":           "p-root",
+			}
+			mockOrgAPI.EXPECT().ListPoliciesForTargetPages(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(input *organizations.ListPoliciesForTargetInput,
+					fn func(*organizations.ListPoliciesForTargetOutput, bool) bool) error {
+					fn(&organizations.ListPoliciesForTargetOutput{
+						Policies: []*organizations.PolicySummary{
+							{Id: aws.String(policies[aws.StringValue(input.TargetId)])},
+						},
+					}, true)
+					return nil
+				}).Times(3)
+			mockOrgAPI.EXPECT().ListParents(gomock.Any()).DoAndReturn(
+				func(input *organizations.ListParentsInput) (*organizations.ListParentsOutput, error) {
+					switch aws.StringValue(input.ChildId) {
+					case "123456789012":
+						return &organizations.ListParentsOutput{Parents: []*organizations.Parent{{
+							Id:   aws.String("ou-abcd-12345678"),
+							Type: aws.String(organizations.ParentTypeOrganizationalUnit),
+						}}}, nil
+					case "ou-abcd-12345678":
+						return &organizations.ListParentsOutput{Parents: []*organizations.Parent{{
+							Id:   aws.String("r-abcd"),
+							Type: aws.String(organizations.ParentTypeRoot),
+						}}}, nil
+					default:
+						return nil, fmt.Errorf("InvalidInputException: invalid child ID '%s'",
+							aws.StringValue(input.ChildId))
+					}
+				}).AnyTimes()
+			mockOrgAPI.EXPECT().DescribePolicy(gomock.Any()).DoAndReturn(
+				func(input *organizations.DescribePolicyInput) (*organizations.DescribePolicyOutput, error) {
+					return &organizations.DescribePolicyOutput{Policy: &organizations.Policy{
+						PolicySummary: &organizations.PolicySummary{Name: input.PolicyId},
+						Content:       aws.String(`{"Statement": [{"Effect": "Deny", "Action": "ec2:*"}]}`),
+					}}, nil
+				}).Times(3)
+
+			scps, err := client.getServiceControlPolicies("123456789012")
+			Expect(err).NotTo(HaveOccurred())
+			names := []string{}
+			for _, scp := range scps {
+				names = append(names, scp.Name)
+			}
+			Expect(names).To(Equal([]string{"p-account", "p-unit", "p-root"}))
+			Expect(findDenyingPolicy(scps, "ec2:RunInstances")).To(Equal("p-account"))
+		})
+	})
+})
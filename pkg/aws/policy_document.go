@@ -42,6 +42,9 @@ type PolicyStatement struct {
 	// you do not include this element, then the resource to which the action applies is the
 	// resource to which the policy is attached.
 	Resource interface{} `json:"Resource,omitempty"`
+	// Conditions under which the statement is in effect, indexed by condition operator and then by
+	// condition key (i.e. StringEquals and sts:ExternalId)
+	Condition map[string]map[string]interface{} `json:"Condition,omitempty"`
 }
 
 type PolicyStatementPrincipal struct {
@@ -87,6 +90,32 @@ func (p *PolicyStatement) GetAWSPrincipals() []string {
 	return awsArr
 }
 
+// TrustedExternalIDs returns the external IDs that the trust policy of a role requires in order to
+// assume it. The ocm-role requires the ID of the OCM organization it belongs to, and the user-role the
+// ID of the Red Hat account it belongs to.
+func (p *PolicyDocument) TrustedExternalIDs() []string {
+	var result []string
+	for _, statement := range p.Statement {
+		if statement.Effect != "Allow" {
+			continue
+		}
+		for _, operator := range []string{"StringEquals", "StringLike"} {
+			result = append(result, stringList(statement.Condition[operator]["sts:ExternalId"])...)
+		}
+	}
+	return result
+}
+
+// RoleTrustedExternalIDs returns the external IDs that the trust policy of the role requires in order
+// to assume it.
+func RoleTrustedExternalIDs(role *iam.Role) ([]string, error) {
+	policy, err := getPolicyDocument(role.AssumeRolePolicyDocument)
+	if err != nil {
+		return nil, err
+	}
+	return policy.TrustedExternalIDs(), nil
+}
+
 // AllowActions adds a statement to a policy allowing the provided actions for all Resources.
 // If you need a more compilex statement it is better to construct it manually.
 func (p *PolicyDocument) AllowActions(actions ...string) {
@@ -139,51 +168,159 @@ func (p *PolicyDocument) GetAllowedActions() []string {
 	return actions
 }
 
-// checkPermissionsUsingQueryClient will use queryClient to query whether the credentials in targetClient can perform
-// the actions listed in the statementEntries. queryClient will need
-// sts:GetCallerIdentity and iam:SimulatePrincipalPolicy
-func (p *PolicyDocument) checkPermissionsUsingQueryClient(queryClient *awsClient, targetUserARN string,
-	params *SimulateParams) (bool, error) {
+// simulatePermissions will use queryClient to simulate whether the target principal can perform
+// the actions allowed by the statements of the document on their resources, and returns the ones
+// that are denied. queryClient will need sts:GetCallerIdentity and iam:SimulatePrincipalPolicy
+func (p *PolicyDocument) simulatePermissions(queryClient *awsClient, targetUserARN string,
+	params *SimulateParams) ([]*PermissionDenial, error) {
 	// Ignoring isRoot here since we only warn the user that its not best practice to use it.
 	// TODO: Add a check for isRoot in the initialize
-	allowList := aws.StringSlice(p.GetAllowedActions())
-
-	input := &iam.SimulatePrincipalPolicyInput{
-		PolicySourceArn: aws.String(targetUserARN),
-		ActionNames:     allowList,
-		ContextEntries:  []*iam.ContextEntry{},
-	}
-
+	contextEntries := []*iam.ContextEntry{}
 	if params != nil && params.Region != "" {
-		input.ContextEntries = append(input.ContextEntries, &iam.ContextEntry{
+		contextEntries = append(contextEntries, &iam.ContextEntry{
 			ContextKeyName:   aws.String("aws:RequestedRegion"),
 			ContextKeyType:   aws.String("stringList"),
 			ContextKeyValues: []*string{aws.String(params.Region)},
 		})
 	}
 
-	// Collect all failed actions
-	var failedActions []string
-	err := queryClient.iamClient.SimulatePrincipalPolicyPages(input,
-		func(response *iam.SimulatePolicyResponse, lastPage bool) bool {
-			for _, result := range response.EvaluationResults {
-				if *result.EvalDecision != "allowed" {
-					// Don't bail out after the first failure, so we can log the full list
-					// of failed/denied actions
-					failedActions = append(failedActions, *result.EvalActionName)
+	// Collect all denied actions
+	denials := []*PermissionDenial{}
+	for _, group := range p.groupAllowedActionsByResource() {
+		input := &iam.SimulatePrincipalPolicyInput{
+			PolicySourceArn: aws.String(targetUserARN),
+			ActionNames:     aws.StringSlice(group.actions),
+			ContextEntries:  contextEntries,
+		}
+		if len(group.resources) > 0 {
+			input.ResourceArns = aws.StringSlice(group.resources)
+		}
+		err := queryClient.iamClient.SimulatePrincipalPolicyPages(input,
+			func(response *iam.SimulatePolicyResponse, lastPage bool) bool {
+				for _, result := range response.EvaluationResults {
+					// Don't bail out after the first failure, so we can report the full list
+					// of denied actions
+					denials = append(denials, explainDenials(result)...)
 				}
+				return !lastPage
+			})
+		if err != nil {
+			return nil, fmt.Errorf("Error simulating policy: %v", err)
+		}
+	}
+	return denials, nil
+}
+
+type actionGroup struct {
+	resources []string
+	actions   []string
+}
+
+// groupAllowedActionsByResource groups the allowed actions by the resources of their statements, so
+// that they can be simulated together. Resources with wildcards can't be simulated, so they are
+// replaced by the default of all the resources.
+func (p *PolicyDocument) groupAllowedActionsByResource() []*actionGroup {
+	var groups []*actionGroup
+	index := map[string]*actionGroup{}
+	for _, statement := range p.Statement {
+		if statement.Effect != "Allow" {
+			continue
+		}
+		var resources []string
+		for _, resource := range stringList(statement.Resource) {
+			if strings.ContainsAny(resource, "*?") {
+				resources = nil
+				break
 			}
-			return !lastPage
+			resources = append(resources, resource)
+		}
+		key := strings.Join(resources, ",")
+		group, ok := index[key]
+		if !ok {
+			group = &actionGroup{resources: resources}
+			index[key] = group
+			groups = append(groups, group)
+		}
+		group.actions = append(group.actions, stringList(statement.Action)...)
+	}
+	return groups
+}
+
+// explainDenials converts a simulation result into the denials of the action on each of the
+// resources, identifying what denies it: the service control policies of the organization, the
+// permissions boundary, an explicit deny statement or the lack of an allow statement.
+func explainDenials(result *iam.EvaluationResult) []*PermissionDenial {
+	action := aws.StringValue(result.EvalActionName)
+	if len(result.ResourceSpecificResults) == 0 {
+		if aws.StringValue(result.EvalDecision) == iam.PolicyEvaluationDecisionTypeAllowed {
+			return nil
+		}
+		return []*PermissionDenial{{
+			Action:   action,
+			Resource: aws.StringValue(result.EvalResourceName),
+			Decision: aws.StringValue(result.EvalDecision),
+			DeniedBy: deniedBy(aws.StringValue(result.EvalDecision), result.OrganizationsDecisionDetail,
+				result.PermissionsBoundaryDecisionDetail, result.MatchedStatements),
+		}}
+	}
+	var denials []*PermissionDenial
+	for _, resourceResult := range result.ResourceSpecificResults {
+		decision := aws.StringValue(resourceResult.EvalResourceDecision)
+		if decision == iam.PolicyEvaluationDecisionTypeAllowed {
+			continue
+		}
+		denials = append(denials, &PermissionDenial{
+			Action:   action,
+			Resource: aws.StringValue(resourceResult.EvalResourceName),
+			Decision: decision,
+			DeniedBy: deniedBy(decision, result.OrganizationsDecisionDetail,
+				resourceResult.PermissionsBoundaryDecisionDetail, resourceResult.MatchedStatements),
 		})
-	if err != nil {
-		return false, fmt.Errorf("Error simulating policy: %v", err)
 	}
+	return denials
+}
 
-	if len(failedActions) > 0 {
-		return false, fmt.Errorf("Actions not allowed with tested credentials: %v", failedActions)
+func deniedBy(decision string, organizations *iam.OrganizationsDecisionDetail,
+	boundary *iam.PermissionsBoundaryDecisionDetail, statements []*iam.Statement) string {
+	if organizations != nil && !aws.BoolValue(organizations.AllowedByOrganizations) {
+		return deniedByOrganization
 	}
+	if boundary != nil && !aws.BoolValue(boundary.AllowedByPermissionsBoundary) {
+		return "permissions boundary"
+	}
+	if decision == iam.PolicyEvaluationDecisionTypeExplicitDeny {
+		var sources []string
+		for _, statement := range statements {
+			sources = append(sources, fmt.Sprintf("%s '%s'",
+				strings.ToLower(aws.StringValue(statement.SourcePolicyType)),
+				aws.StringValue(statement.SourcePolicyId)))
+		}
+		if len(sources) > 0 {
+			return fmt.Sprintf("explicit deny in %s", strings.Join(sources, ", "))
+		}
+		return "explicit deny"
+	}
+	return "no policy allows it"
+}
 
-	return true, nil
+// stringList converts the value of an action or resource element, which can be a single string or
+// a list of strings, into a list.
+func stringList(value interface{}) []string {
+	switch value := value.(type) {
+	case string:
+		return []string{value}
+	case []string:
+		return value
+	case []interface{}:
+		var result []string
+		for _, item := range value {
+			if s, ok := item.(string); ok {
+				result = append(result, s)
+			}
+		}
+		return result
+	}
+	return nil
 }
 
 func (p PolicyDocument) String() string {
@@ -0,0 +1,51 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"net/url"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/iam"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Trusted external IDs", func() {
+	It("Returns the external IDs required by the trust policy", func() {
+		policy := `{
+			"Version": "2012-10-17",
+			"Statement": [{
+				"Effect": "Allow",
+				"Principal": {"AWS": ["arn:aws:iam::710019948333:role/RH-Managed-OpenShift-Installer"]},
+				"Action": ["sts:AssumeRole"],
+				"Condition": {"StringEquals": {"sts:ExternalId": "1VqrIUJmHGPeW7PbrAvxa4Hr01Y"}}
+			}]
+		}`
+		role := &iam.Role{AssumeRolePolicyDocument: aws.String(url.QueryEscape(policy))}
+		ids, err := RoleTrustedExternalIDs(role)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ids).To(Equal([]string{"1VqrIUJmHGPeW7PbrAvxa4Hr01Y"}))
+	})
+	It("Returns nothing when the trust policy has no conditions", func() {
+		policy := `{"Statement": [{"Effect": "Allow", "Principal": {"Service": ["ec2.amazonaws.com"]}}]}`
+		role := &iam.Role{AssumeRolePolicyDocument: aws.String(policy)}
+		ids, err := RoleTrustedExternalIDs(role)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ids).To(BeEmpty())
+	})
+})
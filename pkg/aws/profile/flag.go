@@ -30,10 +30,20 @@ func AddFlag(flags *pflag.FlagSet) {
 		&profile,
 		"profile",
 		"",
-		"Use a specific AWS profile from your credential file.",
+		"Use a specific AWS profile from your credential file. Can also be given as --aws-profile.",
 	)
 }
 
+// NormalizeFlagName accepts '--aws-profile' as an alias of the '--profile' flag. It must be set as the
+// global normalization function of the root command, so that it applies to the flags inherited from
+// the parent commands.
+func NormalizeFlagName(_ *pflag.FlagSet, name string) pflag.NormalizedName {
+	if name == "aws-profile" {
+		name = "profile"
+	}
+	return pflag.NormalizedName(name)
+}
+
 // Profile returns a string with the name of the AWS profile being used.
 func Profile() string {
 	if profile != "" {
@@ -2,9 +2,12 @@ package aws
 
 import (
 	"fmt"
+	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/servicequotas"
 )
 
@@ -86,6 +89,187 @@ var serviceQuotaServices = []quota{
 	},
 }
 
+// Running on-demand instances quotas, in vCPUs, that limit each instance family. Families that
+// aren't listed explicitly are looked up by their first letter.
+var instanceQuotas = map[string]quota{
+	"standard": {
+		ServiceCode: "ec2",
+		QuotaCode:   "L-1216C47A",
+		QuotaName:   "Running On-Demand Standard (A, C, D, H, I, M, R, T, Z) instances",
+	},
+	"dl": {
+		ServiceCode: "ec2",
+		QuotaCode:   "L-6E869C2A",
+		QuotaName:   "Running On-Demand DL instances",
+	},
+	"f": {
+		ServiceCode: "ec2",
+		QuotaCode:   "L-74FC7D96",
+		QuotaName:   "Running On-Demand F instances",
+	},
+	"g": {
+		ServiceCode: "ec2",
+		QuotaCode:   "L-DB2E81BA",
+		QuotaName:   "Running On-Demand G and VT instances",
+	},
+	"vt": {
+		ServiceCode: "ec2",
+		QuotaCode:   "L-DB2E81BA",
+		QuotaName:   "Running On-Demand G and VT instances",
+	},
+	"hpc": {
+		ServiceCode: "ec2",
+		QuotaCode:   "L-F7808C92",
+		QuotaName:   "Running On-Demand HPC instances",
+	},
+	"inf": {
+		ServiceCode: "ec2",
+		QuotaCode:   "L-1945791B",
+		QuotaName:   "Running On-Demand Inf instances",
+	},
+	"p": {
+		ServiceCode: "ec2",
+		QuotaCode:   "L-417A185B",
+		QuotaName:   "Running On-Demand P instances",
+	},
+	"trn": {
+		ServiceCode: "ec2",
+		QuotaCode:   "L-2C3B7624",
+		QuotaName:   "Running On-Demand Trn instances",
+	},
+	"x": {
+		ServiceCode: "ec2",
+		QuotaCode:   "L-7295265B",
+		QuotaName:   "Running On-Demand X instances",
+	},
+}
+
+// Instance families, by first letter, that are limited by the standard instances quota
+var standardInstanceFamilies = "acdhimrtz"
+
+var instanceFamilyRE = regexp.MustCompile(`^([a-z]+)\d`)
+
+// getInstanceQuota returns the vCPU quota that limits the instance type, if it is known
+func getInstanceQuota(instanceType string) (quota, bool) {
+	match := instanceFamilyRE.FindStringSubmatch(instanceType)
+	if match == nil {
+		return quota{}, false
+	}
+	family := match[1]
+	if instanceQuota, ok := instanceQuotas[family]; ok {
+		return instanceQuota, true
+	}
+	if instanceQuota, ok := instanceQuotas[family[:1]]; ok {
+		return instanceQuota, true
+	}
+	if strings.Contains(standardInstanceFamilies, family[:1]) {
+		return instanceQuotas["standard"], true
+	}
+	return quota{}, false
+}
+
+// GetQuotaIncreaseURL returns the page of the AWS console where an increase of the quota can be
+// requested
+func GetQuotaIncreaseURL(region string, serviceCode string, quotaCode string) string {
+	return fmt.Sprintf("https://%s.console.aws.amazon.com/servicequotas/home/services/%s/quotas/%s",
+		region, serviceCode, quotaCode)
+}
+
+// ValidateInstanceQuota checks that the on-demand vCPU quotas of the region allow running the
+// requested vCPUs, indexed by instance type, on top of the instances that are already running.
+// Requests for instance types limited by the same quota are added up. Instance types of unknown
+// families are ignored.
+func (c *awsClient) ValidateInstanceQuota(vCPUs map[string]int) error {
+	required := map[string]int{}
+	quotas := map[string]quota{}
+	instanceTypes := map[string][]string{}
+	for instanceType, count := range vCPUs {
+		instanceQuota, ok := getInstanceQuota(instanceType)
+		if !ok {
+			c.logger.Debug(fmt.Sprintf("No known service quota for instance type '%s'", instanceType))
+			continue
+		}
+		required[instanceQuota.QuotaCode] += count
+		quotas[instanceQuota.QuotaCode] = instanceQuota
+		instanceTypes[instanceQuota.QuotaCode] = append(instanceTypes[instanceQuota.QuotaCode], instanceType)
+	}
+	if len(required) == 0 {
+		return nil
+	}
+
+	used, err := c.getRunningInstanceVCPUs()
+	if err != nil {
+		return err
+	}
+
+	for quotaCode, instanceQuota := range quotas {
+		output, err := c.servicequotasClient.GetServiceQuota(&servicequotas.GetServiceQuotaInput{
+			ServiceCode: aws.String(instanceQuota.ServiceCode),
+			QuotaCode:   aws.String(instanceQuota.QuotaCode),
+		})
+		if err != nil {
+			return fmt.Errorf("Error getting AWS service quota %s: %v", quotaCode, err)
+		}
+		if output.Quota == nil || output.Quota.Value == nil {
+			return fmt.Errorf("Error getting AWS service quota %s: no value returned", quotaCode)
+		}
+		sort.Strings(instanceTypes[quotaCode])
+		err = validateInstanceQuota(instanceTypes[quotaCode], instanceQuota, *output.Quota.Value,
+			used[quotaCode], required[quotaCode], c.GetRegion())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getRunningInstanceVCPUs returns the vCPUs of the pending and running on-demand instances of the
+// region, indexed by the code of the quota that limits them. Spot instances are skipped, as they
+// are limited by separate quotas.
+func (c *awsClient) getRunningInstanceVCPUs() (map[string]int, error) {
+	used := map[string]int{}
+	err := c.ec2Client.DescribeInstancesPages(&ec2.DescribeInstancesInput{
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("instance-state-name"),
+				Values: aws.StringSlice([]string{"pending", "running"}),
+			},
+		},
+	}, func(page *ec2.DescribeInstancesOutput, lastPage bool) bool {
+		for _, reservation := range page.Reservations {
+			for _, instance := range reservation.Instances {
+				if aws.StringValue(instance.InstanceLifecycle) == ec2.InstanceLifecycleTypeSpot {
+					continue
+				}
+				instanceQuota, ok := getInstanceQuota(aws.StringValue(instance.InstanceType))
+				if !ok || instance.CpuOptions == nil {
+					continue
+				}
+				used[instanceQuota.QuotaCode] += int(aws.Int64Value(instance.CpuOptions.CoreCount) *
+					aws.Int64Value(instance.CpuOptions.ThreadsPerCore))
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Error listing running instances: %v", err)
+	}
+	return used, nil
+}
+
+func validateInstanceQuota(instanceTypes []string, instanceQuota quota, value float64, used int,
+	vCPUs int, region string) error {
+	if value-float64(used) < float64(vCPUs) {
+		return fmt.Errorf("Service quota '%s' (%s) allows %d vCPUs in region '%s' and %d are already in "+
+			"use, but %d more vCPUs of instance types '%s' are required. Request a quota increase at %s "+
+			"before creating the machines",
+			instanceQuota.QuotaName, instanceQuota.QuotaCode, int(value), region, used, vCPUs,
+			strings.Join(instanceTypes, "', '"),
+			GetQuotaIncreaseURL(region, instanceQuota.ServiceCode, instanceQuota.QuotaCode))
+	}
+	return nil
+}
+
 // ValidateQuota
 func (c *awsClient) ValidateQuota() (bool, error) {
 	var invalidQuotas []string
@@ -0,0 +1,151 @@
+package aws
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/servicequotas"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/rosa/pkg/aws/mocks"
+)
+
+var _ = Describe("Instance quota", func() {
+	DescribeTable("getInstanceQuota",
+		func(instanceType string, quotaCode string) {
+			instanceQuota, ok := getInstanceQuota(instanceType)
+			if quotaCode == "" {
+				Expect(ok).To(BeFalse())
+			} else {
+				Expect(ok).To(BeTrue())
+				Expect(instanceQuota.QuotaCode).To(Equal(quotaCode))
+			}
+		},
+		Entry("G family", "g4dn.xlarge", "L-DB2E81BA"),
+		Entry("graviton G family", "g5g.2xlarge", "L-DB2E81BA"),
+		Entry("VT family", "vt1.3xlarge", "L-DB2E81BA"),
+		Entry("P family", "p3.2xlarge", "L-417A185B"),
+		Entry("Inf family", "inf1.xlarge", "L-1945791B"),
+		Entry("Trn family", "trn1.2xlarge", "L-2C3B7624"),
+		Entry("DL family", "dl1.24xlarge", "L-6E869C2A"),
+		Entry("HPC family", "hpc6a.48xlarge", "L-F7808C92"),
+		Entry("X family", "x2idn.16xlarge", "L-7295265B"),
+		Entry("F family", "f1.2xlarge", "L-74FC7D96"),
+		Entry("general purpose", "m5.xlarge", "L-1216C47A"),
+		Entry("graviton general purpose", "m6g.xlarge", "L-1216C47A"),
+		Entry("memory optimized", "r5.xlarge", "L-1216C47A"),
+		Entry("storage optimized", "im4gn.large", "L-1216C47A"),
+		Entry("dense storage", "d3.xlarge", "L-1216C47A"),
+		Entry("high memory", "u-6tb1.metal", ""),
+		Entry("invalid", "xlarge", ""),
+	)
+
+	It("fails when the quota is lower than the required vCPUs", func() {
+		instanceQuota, _ := getInstanceQuota("p3.2xlarge")
+		err := validateInstanceQuota([]string{"p3.2xlarge"}, instanceQuota, 16, 0, 24, "us-east-1")
+		Expect(err).To(MatchError(ContainSubstring("allows 16 vCPUs in region 'us-east-1'")))
+		Expect(err).To(MatchError(ContainSubstring("24 more vCPUs")))
+		Expect(err).To(MatchError(ContainSubstring(
+			"https://us-east-1.console.aws.amazon.com/servicequotas/home/services/ec2/quotas/L-417A185B")))
+	})
+
+	It("fails when the vCPUs in use leave too little of the quota", func() {
+		instanceQuota, _ := getInstanceQuota("m5.xlarge")
+		err := validateInstanceQuota([]string{"m5.2xlarge", "m5.xlarge"}, instanceQuota, 100, 90, 16,
+			"us-east-1")
+		Expect(err).To(MatchError(ContainSubstring("90 are already in use")))
+		Expect(err).To(MatchError(ContainSubstring("'m5.2xlarge', 'm5.xlarge'")))
+	})
+
+	It("succeeds when the quota covers the required vCPUs", func() {
+		instanceQuota, _ := getInstanceQuota("g4dn.xlarge")
+		Expect(validateInstanceQuota([]string{"g4dn.xlarge"}, instanceQuota, 64, 8, 12, "us-east-1")).To(Succeed())
+	})
+
+	It("succeeds when the quota equals the required vCPUs", func() {
+		instanceQuota, _ := getInstanceQuota("m5.xlarge")
+		Expect(validateInstanceQuota([]string{"m5.xlarge"}, instanceQuota, 100, 20, 80, "us-east-1")).To(Succeed())
+	})
+
+	Context("ValidateInstanceQuota", func() {
+		var (
+			mockCtrl          *gomock.Controller
+			mockEC2API        *mocks.MockEC2API
+			mockQuotasAPI     *mocks.MockServiceQuotasAPI
+			client            *awsClient
+			runningInstances  []*ec2.Instance
+			standardQuotaCode = "L-1216C47A"
+		)
+
+		BeforeEach(func() {
+			mockCtrl = gomock.NewController(GinkgoT())
+			mockEC2API = mocks.NewMockEC2API(mockCtrl)
+			mockQuotasAPI = mocks.NewMockServiceQuotasAPI(mockCtrl)
+			client = &awsClient{
+				logger:              logrus.New(),
+				ec2Client:           mockEC2API,
+				servicequotasClient: mockQuotasAPI,
+				awsSession:          &session.Session{Config: &aws.Config{Region: aws.String("us-east-1")}},
+			}
+			runningInstances = []*ec2.Instance{
+				{
+					InstanceType: aws.String("m5.2xlarge"),
+					CpuOptions:   &ec2.CpuOptions{CoreCount: aws.Int64(4), ThreadsPerCore: aws.Int64(2)},
+				},
+				{
+					InstanceType: aws.String("p3.2xlarge"),
+					CpuOptions:   &ec2.CpuOptions{CoreCount: aws.Int64(4), ThreadsPerCore: aws.Int64(2)},
+				},
+			}
+			mockEC2API.EXPECT().DescribeInstancesPages(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ *ec2.DescribeInstancesInput, fn func(*ec2.DescribeInstancesOutput, bool) bool) error {
+					fn(&ec2.DescribeInstancesOutput{
+						Reservations: []*ec2.Reservation{{Instances: runningInstances}},
+					}, true)
+					return nil
+				}).AnyTimes()
+		})
+
+		AfterEach(func() {
+			mockCtrl.Finish()
+		})
+
+		expectQuota := func(value float64) {
+			mockQuotasAPI.EXPECT().GetServiceQuota(&servicequotas.GetServiceQuotaInput{
+				ServiceCode: aws.String("ec2"),
+				QuotaCode:   aws.String(standardQuotaCode),
+			}).Return(&servicequotas.GetServiceQuotaOutput{
+				Quota: &servicequotas.ServiceQuota{Value: aws.Float64(value)},
+			}, nil)
+		}
+
+		It("adds up the instance types of the same quota and subtracts the running instances", func() {
+			expectQuota(64)
+			err := client.ValidateInstanceQuota(map[string]int{"m5.2xlarge": 32, "r5.xlarge": 28})
+			Expect(err).To(MatchError(ContainSubstring("8 are already in use, but 60 more vCPUs")))
+		})
+
+		It("doesn't count the running spot instances", func() {
+			runningInstances = append(runningInstances, &ec2.Instance{
+				InstanceType:      aws.String("m5.4xlarge"),
+				InstanceLifecycle: aws.String(ec2.InstanceLifecycleTypeSpot),
+				CpuOptions:        &ec2.CpuOptions{CoreCount: aws.Int64(8), ThreadsPerCore: aws.Int64(2)},
+			})
+			expectQuota(64)
+			err := client.ValidateInstanceQuota(map[string]int{"m5.2xlarge": 32, "r5.xlarge": 28})
+			Expect(err).To(MatchError(ContainSubstring("8 are already in use, but 60 more vCPUs")))
+		})
+
+		It("succeeds when the remaining quota covers every instance type", func() {
+			expectQuota(100)
+			Expect(client.ValidateInstanceQuota(map[string]int{"m5.2xlarge": 32, "r5.xlarge": 28})).To(Succeed())
+		})
+
+		It("ignores instance types of unknown families", func() {
+			Expect(client.ValidateInstanceQuota(map[string]int{"u-6tb1.metal": 448})).To(Succeed())
+		})
+	})
+})
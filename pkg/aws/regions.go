@@ -0,0 +1,111 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions used to find out which regions are enabled for the account and
+// which Local Zones they have.
+
+package aws
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+
+	"github.com/openshift/rosa/pkg/helper"
+	"github.com/openshift/rosa/pkg/helper/parallel"
+)
+
+// RegionDetails contains the details of a region that depend on the account.
+type RegionDetails struct {
+	// OptInStatus is 'opt-in-not-required' for the regions enabled by default, and 'opted-in' or
+	// 'not-opted-in' for the ones that need to be enabled explicitly.
+	OptInStatus string
+	LocalZones  []string
+}
+
+// Enabled returns true if the region can be used by the account.
+func (d *RegionDetails) Enabled() bool {
+	return d.OptInStatus != ec2.AvailabilityZoneOptInStatusNotOptedIn
+}
+
+// GetRegionDetails returns the details of all the regions, indexed by region ID. The opt-in status is
+// obtained with a single request. Local Zones need a request to the EC2 API of each region, so they
+// are only returned for the given regions, and only when they are enabled for the account, as the
+// EC2 API of the others can't be called.
+func (c *awsClient) GetRegionDetails(localZoneRegions []string) (map[string]*RegionDetails, error) {
+	output, err := c.ec2Client.DescribeRegions(&ec2.DescribeRegionsInput{
+		AllRegions: aws.Bool(true),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to describe regions: %v", err)
+	}
+	details := map[string]*RegionDetails{}
+	tasks := []func() error{}
+	for _, region := range output.Regions {
+		regionID := aws.StringValue(region.RegionName)
+		regionDetails := &RegionDetails{
+			OptInStatus: aws.StringValue(region.OptInStatus),
+		}
+		details[regionID] = regionDetails
+		if !regionDetails.Enabled() || !helper.Contains(localZoneRegions, regionID) {
+			continue
+		}
+		tasks = append(tasks, func() error {
+			zones, err := c.describeLocalZones(regionID)
+			if err != nil {
+				return err
+			}
+			regionDetails.LocalZones = zones
+			return nil
+		})
+	}
+	err = parallel.FirstError(parallel.DefaultLimit, tasks...)
+	if err != nil {
+		return nil, err
+	}
+	return details, nil
+}
+
+// describeLocalZones returns the names of the Local Zones of the region, which the EC2 API only
+// returns to clients of that region.
+func (c *awsClient) describeLocalZones(region string) ([]string, error) {
+	client := c.ec2Client
+	if region != c.GetRegion() {
+		client = ec2.New(c.awsSession, aws.NewConfig().WithRegion(region))
+	}
+	output, err := client.DescribeAvailabilityZones(&ec2.DescribeAvailabilityZonesInput{
+		AllAvailabilityZones: aws.Bool(true),
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("zone-type"),
+				Values: []*string{aws.String(LocalZoneType)},
+			},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to describe Local Zones of region '%s': %v", region, err)
+	}
+	return zoneNames(output.AvailabilityZones), nil
+}
+
+func zoneNames(zones []*ec2.AvailabilityZone) []string {
+	names := make([]string, 0, len(zones))
+	for _, zone := range zones {
+		names = append(names, aws.StringValue(zone.ZoneName))
+	}
+	return names
+}
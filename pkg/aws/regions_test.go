@@ -0,0 +1,92 @@
+package aws_test
+
+import (
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/aws/mocks"
+)
+
+var _ = Describe("Region details", func() {
+	var (
+		client     aws.Client
+		mockCtrl   *gomock.Controller
+		mockEC2API *mocks.MockEC2API
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockEC2API = mocks.NewMockEC2API(mockCtrl)
+		sess, err := session.NewSession(&awssdk.Config{Region: awssdk.String("us-east-1")})
+		Expect(err).ToNot(HaveOccurred())
+		client = aws.New(
+			logrus.New(),
+			mocks.NewMockIAMAPI(mockCtrl),
+			mockEC2API,
+			mocks.NewMockOrganizationsAPI(mockCtrl),
+			mocks.NewMockS3API(mockCtrl),
+			mocks.NewMockSecretsManagerAPI(mockCtrl),
+			mocks.NewMockSTSAPI(mockCtrl),
+			mocks.NewMockCloudFormationAPI(mockCtrl),
+			mocks.NewMockServiceQuotasAPI(mockCtrl),
+			sess,
+			&aws.AccessKey{},
+		)
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	It("returns the opt-in status and the Local Zones of the requested enabled regions", func() {
+		mockEC2API.EXPECT().DescribeRegions(gomock.Any()).Return(&ec2.DescribeRegionsOutput{
+			Regions: []*ec2.Region{
+				{
+					RegionName:  awssdk.String("us-east-1"),
+					OptInStatus: awssdk.String("opt-in-not-required"),
+				},
+				{
+					RegionName:  awssdk.String("af-south-1"),
+					OptInStatus: awssdk.String("not-opted-in"),
+				},
+			},
+		}, nil)
+		mockEC2API.EXPECT().DescribeAvailabilityZones(gomock.Any()).Return(&ec2.DescribeAvailabilityZonesOutput{
+			AvailabilityZones: []*ec2.AvailabilityZone{
+				{ZoneName: awssdk.String("us-east-1-bos-1a")},
+				{ZoneName: awssdk.String("us-east-1-mia-1a")},
+			},
+		}, nil)
+
+		details, err := client.GetRegionDetails([]string{"us-east-1", "af-south-1"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(details).To(HaveLen(2))
+		Expect(details["us-east-1"].Enabled()).To(BeTrue())
+		Expect(details["us-east-1"].LocalZones).To(Equal([]string{"us-east-1-bos-1a", "us-east-1-mia-1a"}))
+		Expect(details["af-south-1"].Enabled()).To(BeFalse())
+		Expect(details["af-south-1"].LocalZones).To(BeEmpty())
+	})
+
+	It("doesn't describe the Local Zones of the regions that aren't requested", func() {
+		mockEC2API.EXPECT().DescribeRegions(gomock.Any()).Return(&ec2.DescribeRegionsOutput{
+			Regions: []*ec2.Region{
+				{
+					RegionName:  awssdk.String("us-east-1"),
+					OptInStatus: awssdk.String("opt-in-not-required"),
+				},
+			},
+		}, nil)
+		mockEC2API.EXPECT().DescribeAvailabilityZones(gomock.Any()).Times(0)
+
+		details, err := client.GetRegionDetails(nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(details["us-east-1"].OptInStatus).To(Equal("opt-in-not-required"))
+		Expect(details["us-east-1"].LocalZones).To(BeEmpty())
+	})
+})
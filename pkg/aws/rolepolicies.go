@@ -0,0 +1,171 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions used to retrieve the policies attached to a role, including
+// their documents, to help debugging permission issues.
+
+package aws
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/servicequotas"
+
+	"github.com/openshift/rosa/pkg/helper"
+)
+
+// RolePolicy describes a policy attached to a role.
+type RolePolicy struct {
+	PolicyName string `json:"policy_name"`
+	PolicyArn  string `json:"policy_arn,omitempty"`
+	PolicyType string `json:"policy_type"`
+	Version    string `json:"version,omitempty"`
+	Document   string `json:"document,omitempty"`
+}
+
+// GetRolePolicies returns the managed and inline policies of the given role along with the
+// default version of the managed policies. The documents of the policies are only retrieved
+// when requested, as this requires an additional call per policy.
+func (c *awsClient) GetRolePolicies(roleName string, withDocuments bool) ([]RolePolicy, error) {
+	details, err := c.GetAttachedPolicy(aws.String(roleName))
+	if err != nil {
+		return nil, err
+	}
+	policies := []RolePolicy{}
+	for _, detail := range details {
+		policy := RolePolicy{
+			PolicyName: detail.PolicyName,
+			PolicyArn:  detail.PolicyArn,
+			PolicyType: detail.PolicType,
+		}
+		if detail.PolicType == Attached {
+			policyOutput, err := c.IsPolicyExists(detail.PolicyArn)
+			if err != nil {
+				return nil, err
+			}
+			policy.Version = aws.StringValue(policyOutput.Policy.DefaultVersionId)
+			if withDocuments {
+				versionOutput, err := c.iamClient.GetPolicyVersion(&iam.GetPolicyVersionInput{
+					PolicyArn: aws.String(detail.PolicyArn),
+					VersionId: policyOutput.Policy.DefaultVersionId,
+				})
+				if err != nil {
+					return nil, err
+				}
+				policy.Document, err = url.QueryUnescape(aws.StringValue(versionOutput.PolicyVersion.Document))
+				if err != nil {
+					return nil, err
+				}
+			}
+		} else if withDocuments {
+			rolePolicyOutput, err := c.IsRolePolicyExists(roleName, detail.PolicyName)
+			if err != nil {
+				return nil, err
+			}
+			policy.Document, err = url.QueryUnescape(aws.StringValue(rolePolicyOutput.PolicyDocument))
+			if err != nil {
+				return nil, err
+			}
+		}
+		policies = append(policies, policy)
+	}
+	return policies, nil
+}
+
+// managedPoliciesPerRoleQuota is the IAM quota that limits the number of managed policies that can
+// be attached to a role.
+var managedPoliciesPerRoleQuota = quota{
+	ServiceCode: "iam",
+	QuotaCode:   "L-0DA4ABF3",
+	QuotaName:   "Managed policies per role",
+}
+
+// defaultManagedPoliciesPerRole is the default value of the managed policies per role quota, used
+// when the actual value can't be retrieved.
+const defaultManagedPoliciesPerRole = 10
+
+// ValidateAttachRolePolicies checks that the given policies exist and that attaching the ones that
+// aren't attached yet doesn't exceed the quota of managed policies per role.
+func (c *awsClient) ValidateAttachRolePolicies(roleName string, policyARNs []string) error {
+	for _, policyARN := range policyARNs {
+		_, err := c.IsPolicyExists(policyARN)
+		if err != nil {
+			return fmt.Errorf("Failed to find policy '%s': %v", policyARN, err)
+		}
+	}
+	attached, err := c.listAttachedRolePolicyARNs(roleName)
+	if err != nil {
+		return err
+	}
+	limit := defaultManagedPoliciesPerRole
+	output, err := c.servicequotasClient.GetServiceQuota(&servicequotas.GetServiceQuotaInput{
+		ServiceCode: aws.String(managedPoliciesPerRoleQuota.ServiceCode),
+		QuotaCode:   aws.String(managedPoliciesPerRoleQuota.QuotaCode),
+	})
+	if err == nil && output.Quota != nil && output.Quota.Value != nil {
+		limit = int(*output.Quota.Value)
+	} else {
+		c.logger.Debug(fmt.Sprintf("Using the default value %d of service quota %s: %v",
+			defaultManagedPoliciesPerRole, managedPoliciesPerRoleQuota.QuotaCode, err))
+	}
+	return validateRolePolicyQuota(roleName, attached, policyARNs, limit)
+}
+
+func validateRolePolicyQuota(roleName string, attached []string, policyARNs []string, limit int) error {
+	total := len(attached)
+	for _, policyARN := range policyARNs {
+		if !helper.Contains(attached, policyARN) {
+			total++
+		}
+	}
+	if total > limit {
+		return fmt.Errorf("Role '%s' has %d managed policies attached, attaching the requested policies "+
+			"would exceed the '%s' quota (%s) of %d. Request a quota increase or detach unused policies",
+			roleName, len(attached), managedPoliciesPerRoleQuota.QuotaName, managedPoliciesPerRoleQuota.QuotaCode,
+			limit)
+	}
+	return nil
+}
+
+func (c *awsClient) listAttachedRolePolicyARNs(roleName string) ([]string, error) {
+	policyARNs := []string{}
+	err := c.iamClient.ListAttachedRolePoliciesPages(&iam.ListAttachedRolePoliciesInput{
+		RoleName: aws.String(roleName),
+	}, func(page *iam.ListAttachedRolePoliciesOutput, lastPage bool) bool {
+		for _, policy := range page.AttachedPolicies {
+			policyARNs = append(policyARNs, aws.StringValue(policy.PolicyArn))
+		}
+		return !lastPage
+	})
+	return policyARNs, err
+}
+
+// IsRolePolicyAttached checks if the managed policy is attached to the role.
+func (c *awsClient) IsRolePolicyAttached(roleName string, policyARN string) (bool, error) {
+	attached, err := c.listAttachedRolePolicyARNs(roleName)
+	if err != nil {
+		return false, err
+	}
+	return helper.Contains(attached, policyARN), nil
+}
+
+// DetachRolePolicy detaches the managed policy from the role.
+func (c *awsClient) DetachRolePolicy(roleName string, policyARN string) error {
+	return c.detachRolePolicy(policyARN, roleName)
+}
@@ -0,0 +1,26 @@
+package aws
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Role policy quota", func() {
+	attached := []string{"arn:aws:iam::123:policy/a", "arn:aws:iam::123:policy/b"}
+
+	It("Allows attaching policies within the quota", func() {
+		err := validateRolePolicyQuota("role", attached, []string{"arn:aws:iam::123:policy/c"}, 3)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("Doesn't count policies that are already attached", func() {
+		err := validateRolePolicyQuota("role", attached, []string{"arn:aws:iam::123:policy/a"}, 2)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("Fails when the quota would be exceeded", func() {
+		err := validateRolePolicyQuota("role", attached,
+			[]string{"arn:aws:iam::123:policy/c", "arn:aws:iam::123:policy/d"}, 3)
+		Expect(err).To(MatchError(ContainSubstring("would exceed the 'Managed policies per role' quota")))
+	})
+})
@@ -0,0 +1,89 @@
+package aws_test
+
+import (
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/aws/mocks"
+)
+
+var _ = Describe("Role policies", func() {
+	var (
+		client     aws.Client
+		mockCtrl   *gomock.Controller
+		mockIamAPI *mocks.MockIAMAPI
+	)
+
+	const policyARN = "arn:aws:iam::123456789012:policy/ManagedOpenShift-Installer-Role-Policy"
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockIamAPI = mocks.NewMockIAMAPI(mockCtrl)
+		client = aws.New(
+			logrus.New(),
+			mockIamAPI,
+			mocks.NewMockEC2API(mockCtrl),
+			mocks.NewMockOrganizationsAPI(mockCtrl),
+			mocks.NewMockS3API(mockCtrl),
+			mocks.NewMockSecretsManagerAPI(mockCtrl),
+			mocks.NewMockSTSAPI(mockCtrl),
+			mocks.NewMockCloudFormationAPI(mockCtrl),
+			mocks.NewMockServiceQuotasAPI(mockCtrl),
+			&session.Session{},
+			&aws.AccessKey{},
+		)
+		mockIamAPI.EXPECT().ListAttachedRolePolicies(gomock.Any()).Return(&iam.ListAttachedRolePoliciesOutput{
+			AttachedPolicies: []*iam.AttachedPolicy{{
+				PolicyName: awssdk.String("ManagedOpenShift-Installer-Role-Policy"),
+				PolicyArn:  awssdk.String(policyARN),
+			}},
+		}, nil)
+		mockIamAPI.EXPECT().ListRolePolicies(gomock.Any()).Return(&iam.ListRolePoliciesOutput{
+			PolicyNames: []*string{awssdk.String("inline")},
+		}, nil)
+		mockIamAPI.EXPECT().GetPolicy(gomock.Any()).Return(&iam.GetPolicyOutput{
+			Policy: &iam.Policy{DefaultVersionId: awssdk.String("v3")},
+		}, nil)
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	It("Returns the policy names and versions", func() {
+		policies, err := client.GetRolePolicies("ManagedOpenShift-Installer-Role", false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(policies).To(Equal([]aws.RolePolicy{
+			{
+				PolicyName: "ManagedOpenShift-Installer-Role-Policy",
+				PolicyArn:  policyARN,
+				PolicyType: aws.Attached,
+				Version:    "v3",
+			},
+			{
+				PolicyName: "inline",
+				PolicyType: aws.Inline,
+			},
+		}))
+	})
+
+	It("Returns the decoded policy documents", func() {
+		mockIamAPI.EXPECT().GetPolicyVersion(gomock.Any()).Return(&iam.GetPolicyVersionOutput{
+			PolicyVersion: &iam.PolicyVersion{Document: awssdk.String("%7B%22Version%22%3A%222012-10-17%22%7D")},
+		}, nil)
+		mockIamAPI.EXPECT().GetRolePolicy(gomock.Any()).Return(&iam.GetRolePolicyOutput{
+			PolicyDocument: awssdk.String("%7B%7D"),
+		}, nil)
+		policies, err := client.GetRolePolicies("ManagedOpenShift-Installer-Role", true)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(policies).To(HaveLen(2))
+		Expect(policies[0].Document).To(Equal(`{"Version":"2012-10-17"}`))
+		Expect(policies[1].Document).To(Equal("{}"))
+	})
+})
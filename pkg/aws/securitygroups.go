@@ -0,0 +1,122 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions used to validate the additional security groups that are
+// attached to the nodes of clusters installed in existing VPCs.
+
+package aws
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+// MaxAdditionalSecurityGroups is the maximum number of additional security groups that can be
+// attached to the nodes, as the network interfaces also need the default security groups.
+const MaxAdditionalSecurityGroups = 10
+
+var securityGroupIDRE = regexp.MustCompile(`^sg-[0-9a-f]{8}([0-9a-f]{9})?$`)
+
+// SecurityGroupIDsValidator checks that the input is a comma separated list, or a slice, of
+// security group IDs without duplicates.
+func SecurityGroupIDsValidator(input interface{}) error {
+	var ids []string
+	switch typed := input.(type) {
+	case string:
+		if typed == "" {
+			return nil
+		}
+		ids = strings.Split(typed, ",")
+	case []string:
+		ids = typed
+	default:
+		return fmt.Errorf("can only validate strings, got %v", input)
+	}
+	if len(ids) > MaxAdditionalSecurityGroups {
+		return fmt.Errorf("Expected at most %d additional security groups, got %d",
+			MaxAdditionalSecurityGroups, len(ids))
+	}
+	seen := map[string]bool{}
+	for _, id := range ids {
+		id = strings.TrimSpace(id)
+		if !securityGroupIDRE.MatchString(id) {
+			return fmt.Errorf("Invalid security group ID '%s'", id)
+		}
+		if seen[id] {
+			return fmt.Errorf("Security group '%s' is duplicated", id)
+		}
+		seen[id] = true
+	}
+	return nil
+}
+
+// ParseSecurityGroupIDs splits a comma separated list of security group IDs, as entered in the
+// interactive mode, removing the spaces around them and the empty items.
+func ParseSecurityGroupIDs(input string) []string {
+	ids := []string{}
+	for _, id := range strings.Split(input, ",") {
+		id = strings.TrimSpace(id)
+		if id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// ValidateSecurityGroups checks that the security groups exist and belong to the VPC.
+func (c *awsClient) ValidateSecurityGroups(vpcID string, ids []string) error {
+	groups := []*ec2.SecurityGroup{}
+	err := c.ec2Client.DescribeSecurityGroupsPages(&ec2.DescribeSecurityGroupsInput{
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("group-id"),
+				Values: aws.StringSlice(ids),
+			},
+		},
+	}, func(page *ec2.DescribeSecurityGroupsOutput, lastPage bool) bool {
+		groups = append(groups, page.SecurityGroups...)
+		return true
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to describe security groups: %v", err)
+	}
+	return validateSecurityGroups(vpcID, ids, groups)
+}
+
+func validateSecurityGroups(vpcID string, ids []string, groups []*ec2.SecurityGroup) error {
+	found := map[string]*ec2.SecurityGroup{}
+	for _, group := range groups {
+		found[aws.StringValue(group.GroupId)] = group
+	}
+	errs := []error{}
+	for _, id := range ids {
+		group, ok := found[id]
+		if !ok {
+			errs = append(errs, fmt.Errorf("Security group '%s' doesn't exist", id))
+			continue
+		}
+		if aws.StringValue(group.VpcId) != vpcID {
+			errs = append(errs, fmt.Errorf("Security group '%s' belongs to VPC '%s' instead of VPC '%s'",
+				id, aws.StringValue(group.VpcId), vpcID))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
@@ -0,0 +1,49 @@
+package aws
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Security groups", func() {
+	DescribeTable("SecurityGroupIDsValidator",
+		func(input interface{}, valid bool) {
+			err := SecurityGroupIDsValidator(input)
+			if valid {
+				Expect(err).ToNot(HaveOccurred())
+			} else {
+				Expect(err).To(HaveOccurred())
+			}
+		},
+		Entry("empty", "", true),
+		Entry("comma separated", "sg-0123456789abcdef0,sg-01234567", true),
+		Entry("slice", []string{"sg-0123456789abcdef0"}, true),
+		Entry("invalid ID", "sg-xyz", false),
+		Entry("duplicated", []string{"sg-01234567", "sg-01234567"}, false),
+		Entry("too many", []string{
+			"sg-00000000", "sg-00000001", "sg-00000002", "sg-00000003", "sg-00000004", "sg-00000005",
+			"sg-00000006", "sg-00000007", "sg-00000008", "sg-00000009", "sg-0000000a",
+		}, false),
+	)
+
+	It("parses interactive lists of security group IDs", func() {
+		Expect(ParseSecurityGroupIDs(" sg-00000001 , ,sg-00000002")).To(Equal([]string{"sg-00000001", "sg-00000002"}))
+		Expect(ParseSecurityGroupIDs("")).To(BeEmpty())
+	})
+
+	It("reports missing security groups and groups of other VPCs", func() {
+		groups := []*ec2.SecurityGroup{
+			{GroupId: aws.String("sg-00000001"), VpcId: aws.String("vpc-1")},
+			{GroupId: aws.String("sg-00000002"), VpcId: aws.String("vpc-2")},
+		}
+		Expect(validateSecurityGroups("vpc-1", []string{"sg-00000001"}, groups)).To(Succeed())
+
+		err := validateSecurityGroups("vpc-1", []string{"sg-00000001", "sg-00000002", "sg-00000003"}, groups)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(
+			"Security group 'sg-00000002' belongs to VPC 'vpc-2' instead of VPC 'vpc-1'"))
+		Expect(err.Error()).To(ContainSubstring("Security group 'sg-00000003' doesn't exist"))
+	})
+})
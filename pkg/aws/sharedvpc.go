@@ -0,0 +1,187 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions used to install clusters in a VPC that is shared by another AWS
+// account, where the private hosted zone of the cluster lives in that account too.
+
+package aws
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/route53"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+const (
+	sharedVPCPolicyPrefix = "shared-vpc"
+
+	// Maximum length of the name of an inline policy:
+	maxInlinePolicyNameLength = 128
+)
+
+// SharedVPCRoleValidator checks that the input is the ARN of an IAM role, as the shared VPC
+// configuration is done by assuming a role in the account that owns the VPC.
+func SharedVPCRoleValidator(input interface{}) error {
+	str, ok := input.(string)
+	if !ok {
+		return fmt.Errorf("can only validate strings, got %v", input)
+	}
+	if str == "" {
+		return nil
+	}
+	parsedARN, err := arn.Parse(str)
+	if err != nil {
+		return fmt.Errorf("Invalid ARN: %s", err)
+	}
+	if parsedARN.Service != iam.ServiceName || !strings.HasPrefix(parsedARN.Resource, "role/") {
+		return fmt.Errorf("Expected the ARN of an IAM role, got '%s'", str)
+	}
+	return nil
+}
+
+// GetSharedVPCPolicyName returns the name of the inline policy that allows a role to assume the
+// shared VPC role. The name contains the account and the name of the shared VPC role, so that
+// roles used by clusters installed in different shared VPCs keep one policy per shared VPC role.
+func GetSharedVPCPolicyName(sharedVPCRoleARN string) (string, error) {
+	parsedARN, err := arn.Parse(sharedVPCRoleARN)
+	if err != nil {
+		return "", err
+	}
+	roleName, err := GetResourceIdFromARN(sharedVPCRoleARN)
+	if err != nil {
+		return "", err
+	}
+	name := fmt.Sprintf("%s-%s-%s", sharedVPCPolicyPrefix, parsedARN.AccountID, roleName)
+	if len(name) > maxInlinePolicyNameLength {
+		name = name[:maxInlinePolicyNameLength]
+	}
+	return name, nil
+}
+
+// GetSharedVPCPolicy returns the inline policy document that allows a role to assume the shared VPC
+// role.
+func GetSharedVPCPolicy(sharedVPCRoleARN string) (string, error) {
+	document := NewPolicyDocument()
+	document.Statement = []PolicyStatement{
+		{
+			Effect:   "Allow",
+			Action:   "sts:AssumeRole",
+			Resource: sharedVPCRoleARN,
+		},
+	}
+	policy, err := json.Marshal(document)
+	if err != nil {
+		return "", err
+	}
+	return string(policy), nil
+}
+
+// ValidateSharedVPC assumes the shared VPC role and checks that the account that owns the VPC has a
+// private hosted zone for the base domain that is associated with the VPC. It returns the
+// identifier of the hosted zone.
+func (c *awsClient) ValidateSharedVPC(sharedVPCRoleARN string, baseDomain string,
+	vpcID string) (string, error) {
+	route53Client := route53.New(c.awsSession, &aws.Config{
+		Credentials: stscreds.NewCredentials(c.awsSession, sharedVPCRoleARN),
+	})
+
+	zones := []*route53.HostedZone{}
+	input := &route53.ListHostedZonesByNameInput{
+		DNSName: aws.String(baseDomain),
+	}
+	for {
+		output, err := route53Client.ListHostedZonesByName(input)
+		if err != nil {
+			return "", fmt.Errorf("Failed to list hosted zones using role '%s': %v", sharedVPCRoleARN, err)
+		}
+		zones = append(zones, output.HostedZones...)
+		if !aws.BoolValue(output.IsTruncated) {
+			break
+		}
+		input.DNSName = output.NextDNSName
+		input.HostedZoneId = output.NextHostedZoneId
+	}
+	zone := findPrivateHostedZone(zones, baseDomain)
+	if zone == nil {
+		return "", fmt.Errorf("No private hosted zone found for base domain '%s' using role '%s'",
+			baseDomain, sharedVPCRoleARN)
+	}
+	zoneID := strings.TrimPrefix(aws.StringValue(zone.Id), "/hostedzone/")
+
+	output, err := route53Client.GetHostedZone(&route53.GetHostedZoneInput{
+		Id: aws.String(zoneID),
+	})
+	if err != nil {
+		return "", fmt.Errorf("Failed to get hosted zone '%s': %v", zoneID, err)
+	}
+	for _, vpc := range output.VPCs {
+		if aws.StringValue(vpc.VPCId) == vpcID {
+			return zoneID, nil
+		}
+	}
+	return "", fmt.Errorf("Private hosted zone '%s' of base domain '%s' isn't associated with VPC '%s'",
+		zoneID, baseDomain, vpcID)
+}
+
+// findPrivateHostedZone returns the private hosted zone whose name is the base domain, if any.
+func findPrivateHostedZone(zones []*route53.HostedZone, baseDomain string) *route53.HostedZone {
+	name := strings.TrimSuffix(strings.ToLower(baseDomain), ".") + "."
+	for _, zone := range zones {
+		if strings.ToLower(aws.StringValue(zone.Name)) != name {
+			continue
+		}
+		if zone.Config == nil || !aws.BoolValue(zone.Config.PrivateZone) {
+			continue
+		}
+		return zone
+	}
+	return nil
+}
+
+// ingressOperatorNamespace is the namespace of the operator that manages the records of the private
+// hosted zone, and therefore needs to assume the shared VPC role.
+const ingressOperatorNamespace = "openshift-ingress-operator"
+
+// IsSharedVPCOperator returns true if the operator needs to assume the shared VPC role.
+func IsSharedVPCOperator(operator *cmv1.STSOperator) bool {
+	return operator.Namespace() == ingressOperatorNamespace
+}
+
+var baseDomainRE = regexp.MustCompile(`^([a-z0-9]([-a-z0-9]*[a-z0-9])?\.)+[a-z]{2,}$`)
+
+// BaseDomainValidator checks that the input is a valid DNS domain that can be used as the base
+// domain of a cluster.
+func BaseDomainValidator(input interface{}) error {
+	str, ok := input.(string)
+	if !ok {
+		return fmt.Errorf("can only validate strings, got %v", input)
+	}
+	if str == "" {
+		return nil
+	}
+	if !baseDomainRE.MatchString(str) {
+		return fmt.Errorf("Expected a valid DNS domain, got '%s'", str)
+	}
+	return nil
+}
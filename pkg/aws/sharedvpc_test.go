@@ -0,0 +1,86 @@
+package aws
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/route53"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Shared VPC", func() {
+	const roleARN = "arn:aws:iam::123456789012:role/shared-vpc"
+
+	DescribeTable("SharedVPCRoleValidator",
+		func(input string, valid bool) {
+			err := SharedVPCRoleValidator(input)
+			if valid {
+				Expect(err).ToNot(HaveOccurred())
+			} else {
+				Expect(err).To(HaveOccurred())
+			}
+		},
+		Entry("empty", "", true),
+		Entry("role", roleARN, true),
+		Entry("policy", "arn:aws:iam::123456789012:policy/shared-vpc", false),
+		Entry("not an ARN", "shared-vpc", false),
+	)
+
+	DescribeTable("BaseDomainValidator",
+		func(input string, valid bool) {
+			err := BaseDomainValidator(input)
+			if valid {
+				Expect(err).ToNot(HaveOccurred())
+			} else {
+				Expect(err).To(HaveOccurred())
+			}
+		},
+		Entry("empty", "", true),
+		Entry("domain", "example.com", true),
+		Entry("subdomain", "my-clusters.example.com", true),
+		Entry("single label", "example", false),
+		Entry("upper case", "Example.com", false),
+		Entry("trailing dash", "example-.com", false),
+	)
+
+	It("names the policy after the shared VPC role", func() {
+		name, err := GetSharedVPCPolicyName(roleARN)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(name).To(Equal("shared-vpc-123456789012-shared-vpc"))
+
+		name, err = GetSharedVPCPolicyName("arn:aws:iam::123456789012:role/" + strings.Repeat("a", 200))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(name).To(HaveLen(maxInlinePolicyNameLength))
+	})
+
+	It("allows assuming the shared VPC role", func() {
+		policy, err := GetSharedVPCPolicy(roleARN)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(policy).To(MatchJSON(`{
+			"Version": "2012-10-17",
+			"Statement": [{
+				"Effect": "Allow",
+				"Action": "sts:AssumeRole",
+				"Resource": "` + roleARN + `"
+			}]
+		}`))
+	})
+
+	It("finds the private hosted zone of the base domain", func() {
+		zone := func(id string, name string, private bool) *route53.HostedZone {
+			return &route53.HostedZone{
+				Id:     aws.String(id),
+				Name:   aws.String(name),
+				Config: &route53.HostedZoneConfig{PrivateZone: aws.Bool(private)},
+			}
+		}
+		zones := []*route53.HostedZone{
+			zone("public", "example.com.", false),
+			zone("other", "other.example.com.", true),
+			zone("private", "example.com.", true),
+		}
+		Expect(aws.StringValue(findPrivateHostedZone(zones, "example.com").Id)).To(Equal("private"))
+		Expect(findPrivateHostedZone(zones, "missing.com")).To(BeNil())
+	})
+})
@@ -0,0 +1,277 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the support for AWS profiles that obtain their credentials from an AWS IAM
+// Identity Center (SSO) session defined in an 'sso-session' section of the AWS config file. The AWS
+// SDK only supports the legacy format, where the SSO settings are part of the profile.
+
+package aws
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/ssocreds"
+	"github.com/aws/aws-sdk-go/service/sso"
+	"github.com/aws/aws-sdk-go/service/sso/ssoiface"
+	"github.com/aws/aws-sdk-go/service/ssooidc"
+	"github.com/aws/aws-sdk-go/service/ssooidc/ssooidciface"
+)
+
+const ssoSessionProviderName = "SSOSessionProvider"
+
+// ssoProfile is the configuration of an AWS profile that uses an SSO session.
+type ssoProfile struct {
+	Name        string
+	SessionName string
+	StartURL    string
+	SSORegion   string
+	AccountID   string
+	RoleName    string
+	Region      string
+}
+
+// sharedConfigFile returns the location of the AWS config file.
+func sharedConfigFile() string {
+	if file := os.Getenv("AWS_CONFIG_FILE"); file != "" {
+		return file
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".aws", "config")
+}
+
+// parseSharedConfig returns the sections of the AWS config file indexed by their names, for example
+// 'default', 'profile dev' or 'sso-session my-sso'.
+func parseSharedConfig(data string) map[string]map[string]string {
+	sections := map[string]map[string]string{}
+	var section map[string]string
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			name := strings.Join(strings.Fields(line[1:len(line)-1]), " ")
+			section = map[string]string{}
+			sections[name] = section
+			continue
+		}
+		key, value, found := strings.Cut(line, "=")
+		if !found || section == nil {
+			continue
+		}
+		section[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	return sections
+}
+
+// loadSSOProfile returns the configuration of the given profile if it uses an SSO session, or nil
+// if it doesn't.
+func loadSSOProfile(configFile string, name string) (*ssoProfile, error) {
+	if configFile == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(configFile)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read AWS config file '%s': %v", configFile, err)
+	}
+	if name == "" {
+		name = "default"
+	}
+	sections := parseSharedConfig(string(data))
+	section, ok := sections["profile "+name]
+	if !ok && name == "default" {
+		section = sections[name]
+	}
+	sessionName := section["sso_session"]
+	if sessionName == "" {
+		return nil, nil
+	}
+	session, ok := sections["sso-session "+sessionName]
+	if !ok {
+		return nil, fmt.Errorf("AWS profile '%s' uses SSO session '%s', which isn't defined in '%s'",
+			name, sessionName, configFile)
+	}
+	profile := &ssoProfile{
+		Name:        name,
+		SessionName: sessionName,
+		StartURL:    session["sso_start_url"],
+		SSORegion:   session["sso_region"],
+		AccountID:   section["sso_account_id"],
+		RoleName:    section["sso_role_name"],
+		Region:      section["region"],
+	}
+	var missing []string
+	for key, value := range map[string]string{
+		"sso_start_url":  profile.StartURL,
+		"sso_region":     profile.SSORegion,
+		"sso_account_id": profile.AccountID,
+		"sso_role_name":  profile.RoleName,
+	} {
+		if value == "" {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("AWS profile '%s' is configured to use SSO session '%s' but is missing "+
+			"'%s'", name, sessionName, strings.Join(missing, "', '"))
+	}
+	return profile, nil
+}
+
+// ssoTokenFile returns the location where 'aws sso login' caches the token of the SSO session.
+func ssoTokenFile(sessionName string) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	hash := sha1.Sum([]byte(sessionName))
+	return filepath.Join(home, ".aws", "sso", "cache", hex.EncodeToString(hash[:])+".json"), nil
+}
+
+// ssoToken is the cached token of an SSO session.
+type ssoToken struct {
+	AccessToken  string `json:"accessToken"`
+	ExpiresAt    string `json:"expiresAt"`
+	RefreshToken string `json:"refreshToken,omitempty"`
+	ClientID     string `json:"clientId,omitempty"`
+	ClientSecret string `json:"clientSecret,omitempty"`
+}
+
+// ssoSessionProvider obtains the credentials of the role of an SSO profile using the token of its
+// SSO session, refreshing the token when it has expired, the same way as the AWS CLI does.
+type ssoSessionProvider struct {
+	credentials.Expiry
+
+	profile    *ssoProfile
+	tokenFile  string
+	ssoClient  ssoiface.SSOAPI
+	oidcClient ssooidciface.SSOOIDCAPI
+	now        func() time.Time
+}
+
+func (p *ssoSessionProvider) Retrieve() (credentials.Value, error) {
+	accessToken, err := p.accessToken()
+	if err != nil {
+		return credentials.Value{ProviderName: ssoSessionProviderName}, err
+	}
+	output, err := p.ssoClient.GetRoleCredentials(&sso.GetRoleCredentialsInput{
+		AccessToken: aws.String(accessToken),
+		AccountId:   aws.String(p.profile.AccountID),
+		RoleName:    aws.String(p.profile.RoleName),
+	})
+	if err != nil {
+		return credentials.Value{ProviderName: ssoSessionProviderName}, err
+	}
+	roleCredentials := output.RoleCredentials
+	p.SetExpiration(time.Unix(0, aws.Int64Value(roleCredentials.Expiration)*int64(time.Millisecond)), time.Minute)
+	return credentials.Value{
+		AccessKeyID:     aws.StringValue(roleCredentials.AccessKeyId),
+		SecretAccessKey: aws.StringValue(roleCredentials.SecretAccessKey),
+		SessionToken:    aws.StringValue(roleCredentials.SessionToken),
+		ProviderName:    ssoSessionProviderName,
+	}, nil
+}
+
+// accessToken returns the access token of the SSO session, refreshing it if it has expired.
+func (p *ssoSessionProvider) accessToken() (string, error) {
+	data, err := os.ReadFile(p.tokenFile)
+	if err != nil {
+		return "", awserr.New(ssocreds.ErrCodeSSOProviderInvalidToken, "the SSO session has no cached token", err)
+	}
+	token := ssoToken{}
+	err = json.Unmarshal(data, &token)
+	if err != nil {
+		return "", awserr.New(ssocreds.ErrCodeSSOProviderInvalidToken, "the SSO session token is invalid", err)
+	}
+	expiresAt, err := time.Parse(time.RFC3339, token.ExpiresAt)
+	if token.AccessToken != "" && err == nil && p.now().Before(expiresAt) {
+		return token.AccessToken, nil
+	}
+	if token.RefreshToken == "" || token.ClientID == "" || token.ClientSecret == "" {
+		return "", awserr.New(ssocreds.ErrCodeSSOProviderInvalidToken, "the SSO session has expired", nil)
+	}
+	output, err := p.oidcClient.CreateToken(&ssooidc.CreateTokenInput{
+		ClientId:     aws.String(token.ClientID),
+		ClientSecret: aws.String(token.ClientSecret),
+		GrantType:    aws.String("refresh_token"),
+		RefreshToken: aws.String(token.RefreshToken),
+	})
+	if err != nil {
+		return "", awserr.New(ssocreds.ErrCodeSSOProviderInvalidToken, "failed to refresh the SSO session", err)
+	}
+
+	// Save the refreshed token, keeping the fields that the AWS CLI stores, so that later commands
+	// use it too. Failing to save it doesn't prevent using it now:
+	cached := map[string]interface{}{}
+	err = json.Unmarshal(data, &cached)
+	if err == nil {
+		cached["accessToken"] = aws.StringValue(output.AccessToken)
+		cached["expiresAt"] = p.now().Add(time.Duration(aws.Int64Value(output.ExpiresIn)) * time.Second).
+			UTC().Format(time.RFC3339)
+		if output.RefreshToken != nil {
+			cached["refreshToken"] = aws.StringValue(output.RefreshToken)
+		}
+		data, err = json.Marshal(cached)
+		if err == nil {
+			os.WriteFile(p.tokenFile, data, 0600)
+		}
+	}
+	return aws.StringValue(output.AccessToken), nil
+}
+
+// isSSOLoginError checks if the error was caused by an SSO session that has expired or that hasn't
+// been started with 'aws sso login'.
+func isSSOLoginError(err error) bool {
+	for err != nil {
+		awsErr, ok := err.(awserr.Error)
+		if !ok {
+			return false
+		}
+		switch awsErr.Code() {
+		case ssocreds.ErrCodeSSOProviderInvalidToken, sso.ErrCodeUnauthorizedException:
+			return true
+		}
+		err = awsErr.OrigErr()
+	}
+	return false
+}
+
+// ssoLoginError explains how to start a new SSO session for the given profile.
+func ssoLoginError(profileName string) error {
+	command := "aws sso login"
+	if profileName != "" {
+		command = fmt.Sprintf("aws sso login --profile %s", profileName)
+	}
+	return fmt.Errorf("The AWS SSO session has expired or hasn't been started. Run '%s' and try again", command)
+}
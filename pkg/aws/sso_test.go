@@ -0,0 +1,193 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sso"
+	"github.com/aws/aws-sdk-go/service/sso/ssoiface"
+	"github.com/aws/aws-sdk-go/service/ssooidc"
+	"github.com/aws/aws-sdk-go/service/ssooidc/ssooidciface"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type fakeSSOClient struct {
+	ssoiface.SSOAPI
+	accessToken string
+}
+
+func (c *fakeSSOClient) GetRoleCredentials(input *sso.GetRoleCredentialsInput) (*sso.GetRoleCredentialsOutput,
+	error) {
+	c.accessToken = aws.StringValue(input.AccessToken)
+	return &sso.GetRoleCredentialsOutput{
+		RoleCredentials: &sso.RoleCredentials{
+			AccessKeyId:     aws.String("AKIAEXAMPLE"),
+			SecretAccessKey: aws.String("secret"),
+			SessionToken:    aws.String("session"),
+			Expiration:      aws.Int64(time.Date(2023, 5, 1, 11, 0, 0, 0, time.UTC).UnixMilli()),
+		},
+	}, nil
+}
+
+type fakeSSOOIDCClient struct {
+	ssooidciface.SSOOIDCAPI
+	refreshToken string
+}
+
+func (c *fakeSSOOIDCClient) CreateToken(input *ssooidc.CreateTokenInput) (*ssooidc.CreateTokenOutput, error) {
+	c.refreshToken = aws.StringValue(input.RefreshToken)
+	return &ssooidc.CreateTokenOutput{
+		AccessToken:  aws.String("refreshed-token"),
+		ExpiresIn:    aws.Int64(3600),
+		RefreshToken: aws.String("new-refresh-token"),
+	}, nil
+}
+
+var _ = Describe("SSO sessions", func() {
+	var dir string
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+	})
+
+	Context("Loading profiles", func() {
+		writeConfig := func(data string) string {
+			file := filepath.Join(dir, "config")
+			Expect(os.WriteFile(file, []byte(data), 0600)).To(Succeed())
+			return file
+		}
+
+		It("Loads profiles that use an SSO session", func() {
+			file := writeConfig(`
+[profile dev]
+sso_session = corp
+sso_account_id = 123456789012
+sso_role_name = Admin
+region = us-east-2
+
+# The session shared by all the profiles
+[sso-session corp]
+sso_start_url = https://corp.awsapps.com/start
+sso_region = us-east-1
+`)
+			profile, err := loadSSOProfile(file, "dev")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(profile).To(Equal(&ssoProfile{
+				Name:        "dev",
+				SessionName: "corp",
+				StartURL:    "https://corp.awsapps.com/start",
+				SSORegion:   "us-east-1",
+				AccountID:   "123456789012",
+				RoleName:    "Admin",
+				Region:      "us-east-2",
+			}))
+		})
+		It("Ignores other profiles", func() {
+			file := writeConfig("[default]\nregion = us-east-1\n")
+			profile, err := loadSSOProfile(file, "")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(profile).To(BeNil())
+			profile, err = loadSSOProfile(filepath.Join(dir, "missing"), "dev")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(profile).To(BeNil())
+		})
+		It("Rejects incomplete profiles", func() {
+			file := writeConfig("[default]\nsso_session = corp\n[sso-session corp]\nsso_region = us-east-1\n")
+			_, err := loadSSOProfile(file, "default")
+			Expect(err).To(MatchError(ContainSubstring("is missing")))
+			file = writeConfig("[profile dev]\nsso_session = corp\n")
+			_, err = loadSSOProfile(file, "dev")
+			Expect(err).To(MatchError(ContainSubstring("isn't defined")))
+		})
+	})
+
+	Context("Retrieving credentials", func() {
+		now := time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)
+		var tokenFile string
+		var ssoClient *fakeSSOClient
+		var oidcClient *fakeSSOOIDCClient
+		var provider *ssoSessionProvider
+
+		writeToken := func(token map[string]interface{}) {
+			data, err := json.Marshal(token)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(os.WriteFile(tokenFile, data, 0600)).To(Succeed())
+		}
+
+		BeforeEach(func() {
+			tokenFile = filepath.Join(dir, "token.json")
+			ssoClient = &fakeSSOClient{}
+			oidcClient = &fakeSSOOIDCClient{}
+			provider = &ssoSessionProvider{
+				profile:    &ssoProfile{AccountID: "123456789012", RoleName: "Admin"},
+				tokenFile:  tokenFile,
+				ssoClient:  ssoClient,
+				oidcClient: oidcClient,
+				now:        func() time.Time { return now },
+			}
+		})
+
+		It("Uses the cached token", func() {
+			writeToken(map[string]interface{}{
+				"accessToken": "cached-token",
+				"expiresAt":   "2023-05-01T12:00:00Z",
+			})
+			value, err := provider.Retrieve()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value.AccessKeyID).To(Equal("AKIAEXAMPLE"))
+			Expect(ssoClient.accessToken).To(Equal("cached-token"))
+		})
+		It("Refreshes expired tokens and caches them", func() {
+			writeToken(map[string]interface{}{
+				"accessToken":  "expired-token",
+				"expiresAt":    "2023-05-01T09:00:00Z",
+				"refreshToken": "refresh-token",
+				"clientId":     "client",
+				"clientSecret": "secret",
+				"startUrl":     "https://corp.awsapps.com/start",
+			})
+			_, err := provider.Retrieve()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(oidcClient.refreshToken).To(Equal("refresh-token"))
+			Expect(ssoClient.accessToken).To(Equal("refreshed-token"))
+			data, err := os.ReadFile(tokenFile)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(data)).To(ContainSubstring(`"expiresAt":"2023-05-01T11:00:00Z"`))
+			Expect(string(data)).To(ContainSubstring(`"refreshToken":"new-refresh-token"`))
+			Expect(string(data)).To(ContainSubstring(`"startUrl":"https://corp.awsapps.com/start"`))
+		})
+		It("Asks to log in when the session has expired", func() {
+			writeToken(map[string]interface{}{
+				"accessToken": "expired-token",
+				"expiresAt":   "2023-05-01T09:00:00Z",
+			})
+			_, err := provider.Retrieve()
+			Expect(isSSOLoginError(err)).To(BeTrue())
+			Expect(ssoLoginError("dev")).To(MatchError(ContainSubstring("aws sso login --profile dev")))
+		})
+		It("Asks to log in when there is no session", func() {
+			_, err := provider.Retrieve()
+			Expect(isSSOLoginError(err)).To(BeTrue())
+		})
+	})
+})
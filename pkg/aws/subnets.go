@@ -0,0 +1,304 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions used to check, before submitting the cluster to OCM, that the
+// subnets of an existing VPC are configured as the installer expects. Problems found here would
+// otherwise only be reported after a long wait, when the installation fails.
+
+package aws
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"k8s.io/apimachinery/pkg/util/errors"
+)
+
+const defaultRouteCIDR = "0.0.0.0/0"
+
+// SubnetValidationInput describes the cluster that will be installed into the subnets.
+type SubnetValidationInput struct {
+	SubnetIDs   []string
+	MultiAZ     bool
+	PrivateLink bool
+	HostedCP    bool
+	Proxy       bool
+	// SharedVPC indicates that the VPC is owned by another account. The route tables of a shared
+	// VPC aren't visible from the participant account, so they aren't checked.
+	SharedVPC   bool
+	MachineCIDR net.IPNet
+	ServiceCIDR net.IPNet
+	PodCIDR     net.IPNet
+}
+
+// ValidateSubnets checks the availability zones, route tables and CIDR blocks of the given
+// subnets. The returned error aggregates all the problems found, so that they can be fixed at once.
+func (c *awsClient) ValidateSubnets(input SubnetValidationInput) error {
+	if len(input.SubnetIDs) == 0 {
+		return nil
+	}
+	subnets, err := c.getSubnetIDs(&ec2.DescribeSubnetsInput{
+		SubnetIds: aws.StringSlice(input.SubnetIDs),
+	})
+	if err != nil {
+		return err
+	}
+	vpcIDs := map[string]bool{}
+	for _, subnet := range subnets {
+		vpcIDs[aws.StringValue(subnet.VpcId)] = true
+	}
+	if len(vpcIDs) > 1 {
+		return fmt.Errorf("All the subnets must belong to the same VPC, but they belong to %s",
+			strings.Join(sortedSetKeys(vpcIDs), ", "))
+	}
+	if input.SharedVPC {
+		return validateSubnets(input, subnets, nil)
+	}
+	routeTables, err := c.ec2Client.DescribeRouteTables(&ec2.DescribeRouteTablesInput{
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("vpc-id"),
+				Values: []*string{subnets[0].VpcId},
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return validateSubnets(input, subnets, routeTables.RouteTables)
+}
+
+func validateSubnets(input SubnetValidationInput, subnets []*ec2.Subnet, routeTables []*ec2.RouteTable) error {
+	if input.SharedVPC {
+		// Without the route tables the public and private subnets can't be told apart, so only the
+		// CIDR blocks can be checked:
+		return errors.NewAggregate(validateSubnetCIDRs(input, subnets))
+	}
+
+	var errs []error
+
+	publicByAZ := map[string][]string{}
+	privateByAZ := map[string][]string{}
+	for _, subnet := range subnets {
+		subnetID := aws.StringValue(subnet.SubnetId)
+		availabilityZone := aws.StringValue(subnet.AvailabilityZone)
+		routeTable := findSubnetRouteTable(subnetID, routeTables)
+		if routeTable == nil {
+			errs = append(errs, fmt.Errorf("Subnet '%s' isn't associated with a route table, and the VPC "+
+				"doesn't have a main route table: associate it with a route table", subnetID))
+			continue
+		}
+		routeTableID := aws.StringValue(routeTable.RouteTableId)
+		defaultRoute := findDefaultRoute(routeTable)
+		if defaultRoute != nil && aws.StringValue(defaultRoute.State) == ec2.RouteStateBlackhole {
+			errs = append(errs, fmt.Errorf("The default route of subnet '%s' in route table '%s' points to "+
+				"'%s', which no longer exists: replace it with a route to an existing gateway",
+				subnetID, routeTableID, routeTarget(defaultRoute)))
+			continue
+		}
+		if hasInternetGatewayRoute(routeTable) {
+			publicByAZ[availabilityZone] = append(publicByAZ[availabilityZone], subnetID)
+			continue
+		}
+		privateByAZ[availabilityZone] = append(privateByAZ[availabilityZone], subnetID)
+		if defaultRoute == nil && !input.PrivateLink && !input.Proxy {
+			errs = append(errs, fmt.Errorf("Private subnet '%s' doesn't have a default route in route table "+
+				"'%s', so the cluster nodes can't reach the internet: add a route for '%s' to a NAT "+
+				"gateway, or use '--private-link' or a cluster-wide proxy if egress is provided otherwise",
+				subnetID, routeTableID, defaultRouteCIDR))
+		}
+	}
+
+	errs = append(errs, validateAvailabilityZones(input, publicByAZ, privateByAZ)...)
+	errs = append(errs, validateSubnetCIDRs(input, subnets)...)
+	return errors.NewAggregate(errs)
+}
+
+// validateAvailabilityZones checks that the subnets span the number of availability zones required
+// by the cluster, with the expected public and private subnets in each of them. Hosted clusters
+// don't require a particular layout, as the count of subnets is validated separately.
+func validateAvailabilityZones(input SubnetValidationInput, publicByAZ map[string][]string,
+	privateByAZ map[string][]string) []error {
+	if input.HostedCP {
+		return nil
+	}
+	var errs []error
+	availabilityZones := map[string]bool{}
+	for availabilityZone := range publicByAZ {
+		availabilityZones[availabilityZone] = true
+	}
+	for availabilityZone := range privateByAZ {
+		availabilityZones[availabilityZone] = true
+	}
+	requiredAZs := 1
+	if input.MultiAZ {
+		requiredAZs = 3
+	}
+	if len(availabilityZones) != requiredAZs {
+		errs = append(errs, fmt.Errorf("The subnets span %d availability zones (%s), but the cluster requires "+
+			"exactly %d: select subnets in %d different availability zones", len(availabilityZones),
+			strings.Join(sortedSetKeys(availabilityZones), ", "), requiredAZs, requiredAZs))
+	}
+	for _, availabilityZone := range sortedSetKeys(availabilityZones) {
+		public := publicByAZ[availabilityZone]
+		private := privateByAZ[availabilityZone]
+		if input.PrivateLink {
+			if len(public) > 0 {
+				errs = append(errs, fmt.Errorf("Subnets %s in availability zone '%s' are public, but "+
+					"PrivateLink clusters only use private subnets: remove them from '--subnet-ids'",
+					strings.Join(public, ", "), availabilityZone))
+			}
+			if len(private) > 1 {
+				errs = append(errs, fmt.Errorf("There are several private subnets (%s) in availability zone "+
+					"'%s': select only one of them", strings.Join(private, ", "), availabilityZone))
+			}
+			continue
+		}
+		if len(public) != 1 || len(private) != 1 {
+			errs = append(errs, fmt.Errorf("Availability zone '%s' needs exactly one public and one private "+
+				"subnet, but has %d public and %d private: public subnets need a default route to an "+
+				"internet gateway, private subnets a default route to a NAT gateway",
+				availabilityZone, len(public), len(private)))
+		}
+	}
+	return errs
+}
+
+// validateSubnetCIDRs checks that the subnets are part of the machine CIDR, and that they don't
+// overlap with the ranges used for services and pods, or with each other.
+func validateSubnetCIDRs(input SubnetValidationInput, subnets []*ec2.Subnet) []error {
+	var errs []error
+	cidrs := map[string]*net.IPNet{}
+	for _, subnet := range subnets {
+		subnetID := aws.StringValue(subnet.SubnetId)
+		cidrBlock := aws.StringValue(subnet.CidrBlock)
+		_, cidr, err := net.ParseCIDR(cidrBlock)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("Subnet '%s' has an invalid CIDR block '%s'", subnetID, cidrBlock))
+			continue
+		}
+		if !isEmptyIPNet(input.MachineCIDR) && !containsIPNet(&input.MachineCIDR, cidr) {
+			errs = append(errs, fmt.Errorf("The CIDR block '%s' of subnet '%s' isn't part of the machine CIDR "+
+				"'%s': use '--machine-cidr' to select a range that contains all the subnets",
+				cidr, subnetID, input.MachineCIDR.String()))
+		}
+		if !isEmptyIPNet(input.ServiceCIDR) && overlapsIPNet(&input.ServiceCIDR, cidr) {
+			errs = append(errs, fmt.Errorf("The CIDR block '%s' of subnet '%s' overlaps with the service CIDR "+
+				"'%s': use '--service-cidr' to select a different range", cidr, subnetID,
+				input.ServiceCIDR.String()))
+		}
+		if !isEmptyIPNet(input.PodCIDR) && overlapsIPNet(&input.PodCIDR, cidr) {
+			errs = append(errs, fmt.Errorf("The CIDR block '%s' of subnet '%s' overlaps with the pod CIDR "+
+				"'%s': use '--pod-cidr' to select a different range", cidr, subnetID, input.PodCIDR.String()))
+		}
+		for _, otherID := range sortedIPNetKeys(cidrs) {
+			if overlapsIPNet(cidrs[otherID], cidr) {
+				errs = append(errs, fmt.Errorf("The CIDR blocks of subnets '%s' and '%s' overlap",
+					otherID, subnetID))
+			}
+		}
+		cidrs[subnetID] = cidr
+	}
+	return errs
+}
+
+// findSubnetRouteTable returns the route table explicitly associated with the subnet, or else the
+// main route table of the VPC.
+func findSubnetRouteTable(subnetID string, routeTables []*ec2.RouteTable) *ec2.RouteTable {
+	for _, routeTable := range routeTables {
+		for _, association := range routeTable.Associations {
+			if aws.StringValue(association.SubnetId) == subnetID {
+				return routeTable
+			}
+		}
+	}
+	for _, routeTable := range routeTables {
+		for _, association := range routeTable.Associations {
+			if aws.BoolValue(association.Main) {
+				return routeTable
+			}
+		}
+	}
+	return nil
+}
+
+func findDefaultRoute(routeTable *ec2.RouteTable) *ec2.Route {
+	for _, route := range routeTable.Routes {
+		if aws.StringValue(route.DestinationCidrBlock) == defaultRouteCIDR {
+			return route
+		}
+	}
+	return nil
+}
+
+// hasInternetGatewayRoute checks if the route table has a route to an internet gateway, which is
+// what makes the associated subnets public.
+func hasInternetGatewayRoute(routeTable *ec2.RouteTable) bool {
+	for _, route := range routeTable.Routes {
+		if strings.Contains(aws.StringValue(route.GatewayId), "igw") {
+			return true
+		}
+	}
+	return false
+}
+
+func routeTarget(route *ec2.Route) string {
+	for _, target := range []*string{
+		route.NatGatewayId, route.GatewayId, route.TransitGatewayId, route.NetworkInterfaceId,
+		route.InstanceId, route.VpcPeeringConnectionId,
+	} {
+		if aws.StringValue(target) != "" {
+			return aws.StringValue(target)
+		}
+	}
+	return "unknown"
+}
+
+func isEmptyIPNet(ipNet net.IPNet) bool {
+	return ipNet.IP == nil
+}
+
+func containsIPNet(outer *net.IPNet, inner *net.IPNet) bool {
+	outerOnes, _ := outer.Mask.Size()
+	innerOnes, _ := inner.Mask.Size()
+	return outer.Contains(inner.IP) && outerOnes <= innerOnes
+}
+
+func overlapsIPNet(a *net.IPNet, b *net.IPNet) bool {
+	return a.Contains(b.IP) || b.Contains(a.IP)
+}
+
+func sortedSetKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedIPNetKeys(m map[string]*net.IPNet) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,214 @@
+package aws
+
+import (
+	"net"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Subnet validation", func() {
+	subnet := func(id string, az string, cidr string) *ec2.Subnet {
+		return &ec2.Subnet{
+			SubnetId:         aws.String(id),
+			AvailabilityZone: aws.String(az),
+			CidrBlock:        aws.String(cidr),
+			VpcId:            aws.String("vpc-1"),
+		}
+	}
+	routeTable := func(id string, route *ec2.Route, subnetIDs ...string) *ec2.RouteTable {
+		table := &ec2.RouteTable{RouteTableId: aws.String(id)}
+		if route != nil {
+			table.Routes = []*ec2.Route{route}
+		}
+		for _, subnetID := range subnetIDs {
+			table.Associations = append(table.Associations, &ec2.RouteTableAssociation{
+				SubnetId: aws.String(subnetID),
+			})
+		}
+		return table
+	}
+	igwRoute := &ec2.Route{
+		DestinationCidrBlock: aws.String(defaultRouteCIDR),
+		GatewayId:            aws.String("igw-1"),
+		State:                aws.String(ec2.RouteStateActive),
+	}
+	natRoute := &ec2.Route{
+		DestinationCidrBlock: aws.String(defaultRouteCIDR),
+		NatGatewayId:         aws.String("nat-1"),
+		State:                aws.String(ec2.RouteStateActive),
+	}
+	cidr := func(value string) net.IPNet {
+		_, result, _ := net.ParseCIDR(value)
+		return *result
+	}
+	input := func(subnetIDs ...string) SubnetValidationInput {
+		return SubnetValidationInput{
+			SubnetIDs:   subnetIDs,
+			MachineCIDR: cidr("10.0.0.0/16"),
+			ServiceCIDR: cidr("172.30.0.0/16"),
+			PodCIDR:     cidr("10.128.0.0/14"),
+		}
+	}
+
+	It("Accepts a public and a private subnet in a single availability zone", func() {
+		err := validateSubnets(input("subnet-pub", "subnet-priv"),
+			[]*ec2.Subnet{
+				subnet("subnet-pub", "us-east-1a", "10.0.0.0/24"),
+				subnet("subnet-priv", "us-east-1a", "10.0.1.0/24"),
+			},
+			[]*ec2.RouteTable{
+				routeTable("rtb-pub", igwRoute, "subnet-pub"),
+				routeTable("rtb-priv", natRoute, "subnet-priv"),
+			})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("Requires three availability zones for multi-AZ clusters", func() {
+		validationInput := input("subnet-pub", "subnet-priv")
+		validationInput.MultiAZ = true
+		err := validateSubnets(validationInput,
+			[]*ec2.Subnet{
+				subnet("subnet-pub", "us-east-1a", "10.0.0.0/24"),
+				subnet("subnet-priv", "us-east-1a", "10.0.1.0/24"),
+			},
+			[]*ec2.RouteTable{
+				routeTable("rtb-pub", igwRoute, "subnet-pub"),
+				routeTable("rtb-priv", natRoute, "subnet-priv"),
+			})
+		Expect(err).To(MatchError(ContainSubstring("span 1 availability zones (us-east-1a)")))
+	})
+
+	It("Requires a default route for private subnets", func() {
+		err := validateSubnets(input("subnet-pub", "subnet-priv"),
+			[]*ec2.Subnet{
+				subnet("subnet-pub", "us-east-1a", "10.0.0.0/24"),
+				subnet("subnet-priv", "us-east-1a", "10.0.1.0/24"),
+			},
+			[]*ec2.RouteTable{
+				routeTable("rtb-pub", igwRoute, "subnet-pub"),
+				routeTable("rtb-priv", nil, "subnet-priv"),
+			})
+		Expect(err).To(MatchError(ContainSubstring(
+			"Private subnet 'subnet-priv' doesn't have a default route in route table 'rtb-priv'")))
+	})
+
+	It("Doesn't check the route tables of a shared VPC", func() {
+		validationInput := input("subnet-pub", "subnet-priv")
+		validationInput.SharedVPC = true
+		err := validateSubnets(validationInput,
+			[]*ec2.Subnet{
+				subnet("subnet-pub", "us-east-1a", "10.0.0.0/24"),
+				subnet("subnet-priv", "us-east-1a", "10.0.1.0/24"),
+			},
+			nil)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("Checks the CIDR blocks of a shared VPC", func() {
+		validationInput := input("subnet-priv")
+		validationInput.SharedVPC = true
+		err := validateSubnets(validationInput,
+			[]*ec2.Subnet{
+				subnet("subnet-priv", "us-east-1a", "172.30.1.0/24"),
+			},
+			nil)
+		Expect(err).To(MatchError(ContainSubstring("overlaps with the service CIDR")))
+	})
+
+	It("Doesn't require a default route for PrivateLink clusters", func() {
+		validationInput := input("subnet-priv")
+		validationInput.PrivateLink = true
+		err := validateSubnets(validationInput,
+			[]*ec2.Subnet{
+				subnet("subnet-priv", "us-east-1a", "10.0.1.0/24"),
+			},
+			[]*ec2.RouteTable{
+				routeTable("rtb-priv", nil, "subnet-priv"),
+			})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("Rejects public subnets for PrivateLink clusters", func() {
+		validationInput := input("subnet-pub", "subnet-priv")
+		validationInput.PrivateLink = true
+		err := validateSubnets(validationInput,
+			[]*ec2.Subnet{
+				subnet("subnet-pub", "us-east-1a", "10.0.0.0/24"),
+				subnet("subnet-priv", "us-east-1a", "10.0.1.0/24"),
+			},
+			[]*ec2.RouteTable{
+				routeTable("rtb-pub", igwRoute, "subnet-pub"),
+				routeTable("rtb-priv", natRoute, "subnet-priv"),
+			})
+		Expect(err).To(MatchError(ContainSubstring("Subnets subnet-pub in availability zone 'us-east-1a' are public")))
+	})
+
+	It("Reports routes to deleted gateways", func() {
+		err := validateSubnets(input("subnet-pub", "subnet-priv"),
+			[]*ec2.Subnet{
+				subnet("subnet-pub", "us-east-1a", "10.0.0.0/24"),
+				subnet("subnet-priv", "us-east-1a", "10.0.1.0/24"),
+			},
+			[]*ec2.RouteTable{
+				routeTable("rtb-pub", igwRoute, "subnet-pub"),
+				routeTable("rtb-priv", &ec2.Route{
+					DestinationCidrBlock: aws.String(defaultRouteCIDR),
+					NatGatewayId:         aws.String("nat-deleted"),
+					State:                aws.String(ec2.RouteStateBlackhole),
+				}, "subnet-priv"),
+			})
+		Expect(err).To(MatchError(ContainSubstring("points to 'nat-deleted', which no longer exists")))
+	})
+
+	It("Uses the main route table for subnets without an explicit association", func() {
+		mainTable := routeTable("rtb-main", natRoute)
+		mainTable.Associations = []*ec2.RouteTableAssociation{{Main: aws.Bool(true)}}
+		err := validateSubnets(input("subnet-pub", "subnet-priv"),
+			[]*ec2.Subnet{
+				subnet("subnet-pub", "us-east-1a", "10.0.0.0/24"),
+				subnet("subnet-priv", "us-east-1a", "10.0.1.0/24"),
+			},
+			[]*ec2.RouteTable{
+				routeTable("rtb-pub", igwRoute, "subnet-pub"),
+				mainTable,
+			})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("Checks the CIDR blocks against the cluster networks", func() {
+		err := validateSubnets(input("subnet-pub", "subnet-priv"),
+			[]*ec2.Subnet{
+				subnet("subnet-pub", "us-east-1a", "10.0.0.0/24"),
+				subnet("subnet-priv", "us-east-1a", "172.30.1.0/24"),
+			},
+			[]*ec2.RouteTable{
+				routeTable("rtb-pub", igwRoute, "subnet-pub"),
+				routeTable("rtb-priv", natRoute, "subnet-priv"),
+			})
+		Expect(err).To(MatchError(ContainSubstring(
+			"The CIDR block '172.30.1.0/24' of subnet 'subnet-priv' overlaps with the service CIDR")))
+		Expect(err).To(MatchError(ContainSubstring(
+			"The CIDR block '172.30.1.0/24' of subnet 'subnet-priv' isn't part of the machine CIDR")))
+		Expect(err).NotTo(MatchError(ContainSubstring("subnet 'subnet-pub'")))
+	})
+
+	It("Rejects a machine CIDR smaller than the subnet", func() {
+		validationInput := input("subnet-pub", "subnet-priv")
+		validationInput.MachineCIDR = cidr("10.0.0.0/25")
+		err := validateSubnets(validationInput,
+			[]*ec2.Subnet{
+				subnet("subnet-pub", "us-east-1a", "10.0.0.0/24"),
+				subnet("subnet-priv", "us-east-1a", "10.0.0.0/25"),
+			},
+			[]*ec2.RouteTable{
+				routeTable("rtb-pub", igwRoute, "subnet-pub"),
+				routeTable("rtb-priv", natRoute, "subnet-priv"),
+			})
+		Expect(err).To(MatchError(ContainSubstring(
+			"The CIDR block '10.0.0.0/24' of subnet 'subnet-pub' isn't part of the machine CIDR")))
+		Expect(err).To(MatchError(ContainSubstring("The CIDR blocks of subnets 'subnet-pub' and 'subnet-priv' overlap")))
+	})
+})
@@ -0,0 +1,520 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the command line options shared by the commands that create and edit the
+// cluster autoscaler of a classic cluster.
+
+package clusterautoscaler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"github.com/openshift/rosa/pkg/helper"
+	"github.com/openshift/rosa/pkg/interactive"
+	"github.com/openshift/rosa/pkg/ocm"
+)
+
+const (
+	balanceSimilarNodeGroupsFlag     = "balance-similar-node-groups"
+	skipNodesWithLocalStorageFlag    = "skip-nodes-with-local-storage"
+	logVerbosityFlag                 = "log-verbosity"
+	balancingIgnoredLabelsFlag       = "balancing-ignored-labels"
+	ignoreDaemonsetsUtilizationFlag  = "ignore-daemonsets-utilization"
+	maxNodeProvisionTimeFlag         = "max-node-provision-time"
+	maxPodGracePeriodFlag            = "max-pod-grace-period"
+	podPriorityThresholdFlag         = "pod-priority-threshold"
+	maxNodesTotalFlag                = "max-nodes-total"
+	minCoresFlag                     = "min-cores"
+	maxCoresFlag                     = "max-cores"
+	minMemoryFlag                    = "min-memory"
+	maxMemoryFlag                    = "max-memory"
+	gpuLimitFlag                     = "gpu-limit"
+	scaleDownEnabledFlag             = "scale-down-enabled"
+	scaleDownUnneededTimeFlag        = "scale-down-unneeded-time"
+	scaleDownUtilizationThresholdFlg = "scale-down-utilization-threshold"
+	scaleDownDelayAfterAddFlag       = "scale-down-delay-after-add"
+	scaleDownDelayAfterDeleteFlag    = "scale-down-delay-after-delete"
+	scaleDownDelayAfterFailureFlag   = "scale-down-delay-after-failure"
+)
+
+// Default resource limits, the same as the ones of the cluster autoscaler of OpenShift, so that the
+// autoscaler isn't capped at zero when the limits aren't given.
+const (
+	DefaultMaxNodesTotal = 180
+	DefaultMinCores      = 0
+	DefaultMaxCores      = 11520
+	DefaultMinMemory     = 0
+	DefaultMaxMemory     = 230400
+)
+
+var flagNames = []string{
+	balanceSimilarNodeGroupsFlag, skipNodesWithLocalStorageFlag, logVerbosityFlag,
+	balancingIgnoredLabelsFlag, ignoreDaemonsetsUtilizationFlag, maxNodeProvisionTimeFlag,
+	maxPodGracePeriodFlag, podPriorityThresholdFlag, maxNodesTotalFlag, minCoresFlag, maxCoresFlag,
+	minMemoryFlag, maxMemoryFlag, gpuLimitFlag, scaleDownEnabledFlag, scaleDownUnneededTimeFlag,
+	scaleDownUtilizationThresholdFlg, scaleDownDelayAfterAddFlag, scaleDownDelayAfterDeleteFlag,
+	scaleDownDelayAfterFailureFlag,
+}
+
+// AutoscalerArgs contains the values of the cluster autoscaler command line options.
+type AutoscalerArgs struct {
+	BalanceSimilarNodeGroups    bool
+	SkipNodesWithLocalStorage   bool
+	LogVerbosity                int
+	BalancingIgnoredLabels      []string
+	IgnoreDaemonsetsUtilization bool
+	MaxNodeProvisionTime        string
+	MaxPodGracePeriod           int
+	PodPriorityThreshold        int
+
+	MaxNodesTotal int
+	MinCores      int
+	MaxCores      int
+	MinMemory     int
+	MaxMemory     int
+	GPULimits     []string
+
+	ScaleDownEnabled              bool
+	ScaleDownUnneededTime         string
+	ScaleDownUtilizationThreshold float64
+	ScaleDownDelayAfterAdd        string
+	ScaleDownDelayAfterDelete     string
+	ScaleDownDelayAfterFailure    string
+}
+
+// AddFlags adds the cluster autoscaler options to the given set of command line flags.
+func AddFlags(flags *pflag.FlagSet) *AutoscalerArgs {
+	args := &AutoscalerArgs{}
+
+	flags.BoolVar(&args.BalanceSimilarNodeGroups, balanceSimilarNodeGroupsFlag, false,
+		"Identify node groups with the same instance type and label set, and aim to balance "+
+			"respective sizes of those node groups.")
+	flags.BoolVar(&args.SkipNodesWithLocalStorage, skipNodesWithLocalStorageFlag, false,
+		"If true cluster autoscaler will never delete nodes with pods with local storage, "+
+			"e.g. EmptyDir or HostPath.")
+	flags.IntVar(&args.LogVerbosity, logVerbosityFlag, 1,
+		"Autoscaler log level.")
+	flags.StringSliceVar(&args.BalancingIgnoredLabels, balancingIgnoredLabelsFlag, nil,
+		"A comma-separated list of label keys that cluster autoscaler should ignore when "+
+			"considering node group similarity.")
+	flags.BoolVar(&args.IgnoreDaemonsetsUtilization, ignoreDaemonsetsUtilizationFlag, false,
+		"Should cluster-autoscaler ignore DaemonSet pods when calculating resource utilization "+
+			"for scaling down.")
+	flags.StringVar(&args.MaxNodeProvisionTime, maxNodeProvisionTimeFlag, "",
+		"Maximum time cluster-autoscaler waits for node to be provisioned. "+
+			"Expects string comprised of an integer and time unit (ns|us|µs|ms|s|m|h), examples: 20m, 1h.")
+	flags.IntVar(&args.MaxPodGracePeriod, maxPodGracePeriodFlag, 0,
+		"Gives pods graceful termination time before scaling down, measured in seconds.")
+	flags.IntVar(&args.PodPriorityThreshold, podPriorityThresholdFlag, 0,
+		"The priority that a pod must exceed to cause the cluster autoscaler to deploy additional "+
+			"nodes. Expects an integer, can be negative.")
+
+	flags.IntVar(&args.MaxNodesTotal, maxNodesTotalFlag, DefaultMaxNodesTotal,
+		"Total amount of nodes that can exist in the cluster, including non-scaled nodes.")
+	flags.IntVar(&args.MinCores, minCoresFlag, DefaultMinCores,
+		"Minimum limit for the amount of cores to deploy in the cluster.")
+	flags.IntVar(&args.MaxCores, maxCoresFlag, DefaultMaxCores,
+		"Maximum limit for the amount of cores to deploy in the cluster.")
+	flags.IntVar(&args.MinMemory, minMemoryFlag, DefaultMinMemory,
+		"Minimum limit for the amount of memory, in GiB, in the cluster.")
+	flags.IntVar(&args.MaxMemory, maxMemoryFlag, DefaultMaxMemory,
+		"Maximum limit for the amount of memory, in GiB, in the cluster.")
+	flags.StringArrayVar(&args.GPULimits, gpuLimitFlag, nil,
+		"Limit for the amount of GPUs of a type in the cluster, in the format 'type,min,max', "+
+			"for example 'nvidia.com/gpu,0,10'. Can be repeated for several GPU types. The types should "+
+			"be provided by the instance types of the machine pools, for example 'nvidia.com/gpu', "+
+			"'amd.com/gpu', 'habana.ai/gaudi' or 'aws.amazon.com/neuron'.")
+
+	flags.BoolVar(&args.ScaleDownEnabled, scaleDownEnabledFlag, false,
+		"Should cluster-autoscaler be able to scale down the cluster.")
+	flags.StringVar(&args.ScaleDownUnneededTime, scaleDownUnneededTimeFlag, "",
+		"How long a node should be unneeded before it is eligible for scale down.")
+	flags.Float64Var(&args.ScaleDownUtilizationThreshold, scaleDownUtilizationThresholdFlg, 0.5,
+		"Node utilization level, defined as sum of requested resources divided by capacity, "+
+			"below which a node can be considered for scale down. Value should be between 0 and 1.")
+	flags.StringVar(&args.ScaleDownDelayAfterAdd, scaleDownDelayAfterAddFlag, "",
+		"How long after scale up that scale down evaluation resumes.")
+	flags.StringVar(&args.ScaleDownDelayAfterDelete, scaleDownDelayAfterDeleteFlag, "",
+		"How long after node deletion that scale down evaluation resumes.")
+	flags.StringVar(&args.ScaleDownDelayAfterFailure, scaleDownDelayAfterFailureFlag, "",
+		"How long after scale down failure that scale down evaluation resumes.")
+
+	return args
+}
+
+// AnyChanged checks if any of the cluster autoscaler options was given in the command line.
+func AnyChanged(flags *pflag.FlagSet) bool {
+	for _, name := range flagNames {
+		if flags.Changed(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// GPULimitsChanged checks if the GPU limits were given in the command line.
+func GPULimitsChanged(flags *pflag.FlagSet) bool {
+	return flags.Changed(gpuLimitFlag)
+}
+
+// SetDefaultsFrom sets the values of the options that weren't given in the command line from the
+// given existing autoscaler, so that editing only changes what the user asked for.
+func (a *AutoscalerArgs) SetDefaultsFrom(flags *pflag.FlagSet, autoscaler *ocm.ClusterAutoscaler) {
+	unchanged := func(name string) bool {
+		return !flags.Changed(name)
+	}
+	if unchanged(balanceSimilarNodeGroupsFlag) {
+		a.BalanceSimilarNodeGroups = autoscaler.BalanceSimilarNodeGroups
+	}
+	if unchanged(skipNodesWithLocalStorageFlag) {
+		a.SkipNodesWithLocalStorage = autoscaler.SkipNodesWithLocalStorage
+	}
+	if unchanged(logVerbosityFlag) {
+		a.LogVerbosity = autoscaler.LogVerbosity
+	}
+	if unchanged(balancingIgnoredLabelsFlag) {
+		a.BalancingIgnoredLabels = autoscaler.BalancingIgnoredLabels
+	}
+	if unchanged(ignoreDaemonsetsUtilizationFlag) {
+		a.IgnoreDaemonsetsUtilization = autoscaler.IgnoreDaemonsetsUtilization
+	}
+	if unchanged(maxNodeProvisionTimeFlag) {
+		a.MaxNodeProvisionTime = autoscaler.MaxNodeProvisionTime
+	}
+	if unchanged(maxPodGracePeriodFlag) {
+		a.MaxPodGracePeriod = autoscaler.MaxPodGracePeriod
+	}
+	if unchanged(podPriorityThresholdFlag) {
+		a.PodPriorityThreshold = autoscaler.PodPriorityThreshold
+	}
+	limits := autoscaler.ResourceLimits
+	if unchanged(maxNodesTotalFlag) {
+		a.MaxNodesTotal = limits.MaxNodesTotal
+	}
+	if unchanged(minCoresFlag) {
+		a.MinCores = limits.Cores.Min
+	}
+	if unchanged(maxCoresFlag) {
+		a.MaxCores = limits.Cores.Max
+	}
+	if unchanged(minMemoryFlag) {
+		a.MinMemory = limits.Memory.Min
+	}
+	if unchanged(maxMemoryFlag) {
+		a.MaxMemory = limits.Memory.Max
+	}
+	if unchanged(gpuLimitFlag) {
+		a.GPULimits = FormatGPULimits(limits.GPUS)
+	}
+	scaleDown := autoscaler.ScaleDown
+	if unchanged(scaleDownEnabledFlag) {
+		a.ScaleDownEnabled = scaleDown.Enabled
+	}
+	if unchanged(scaleDownUnneededTimeFlag) {
+		a.ScaleDownUnneededTime = scaleDown.UnneededTime
+	}
+	if unchanged(scaleDownUtilizationThresholdFlg) && scaleDown.UtilizationThreshold != "" {
+		threshold, err := strconv.ParseFloat(scaleDown.UtilizationThreshold, 64)
+		if err == nil {
+			a.ScaleDownUtilizationThreshold = threshold
+		}
+	}
+	if unchanged(scaleDownDelayAfterAddFlag) {
+		a.ScaleDownDelayAfterAdd = scaleDown.DelayAfterAdd
+	}
+	if unchanged(scaleDownDelayAfterDeleteFlag) {
+		a.ScaleDownDelayAfterDelete = scaleDown.DelayAfterDelete
+	}
+	if unchanged(scaleDownDelayAfterFailureFlag) {
+		a.ScaleDownDelayAfterFailure = scaleDown.DelayAfterFailure
+	}
+}
+
+// Prompt asks the user for the values of the options that weren't given in the command line,
+// using the current values as defaults. The limits of the given GPU types, which are usually the
+// ones provided by the machine pools of the cluster, are asked one by one.
+func (a *AutoscalerArgs) Prompt(flags *pflag.FlagSet, gpuTypes []string) (err error) {
+	promptBool := func(name string, question string, value *bool) {
+		if err != nil || flags.Changed(name) {
+			return
+		}
+		*value, err = interactive.GetBool(interactive.Input{
+			Question: question,
+			Help:     flags.Lookup(name).Usage,
+			Default:  *value,
+		})
+	}
+	promptInt := func(name string, question string, value *int) {
+		if err != nil || flags.Changed(name) {
+			return
+		}
+		*value, err = interactive.GetInt(interactive.Input{
+			Question: question,
+			Help:     flags.Lookup(name).Usage,
+			Default:  *value,
+		})
+	}
+	promptDuration := func(name string, question string, value *string) {
+		if err != nil || flags.Changed(name) {
+			return
+		}
+		*value, err = interactive.GetString(interactive.Input{
+			Question:   question,
+			Help:       flags.Lookup(name).Usage,
+			Default:    *value,
+			Validators: []interactive.Validator{durationValidator},
+		})
+	}
+
+	promptBool(balanceSimilarNodeGroupsFlag, "Balance similar node groups", &a.BalanceSimilarNodeGroups)
+	promptBool(skipNodesWithLocalStorageFlag, "Skip nodes with local storage", &a.SkipNodesWithLocalStorage)
+	promptInt(logVerbosityFlag, "Log verbosity", &a.LogVerbosity)
+	if err == nil && !flags.Changed(balancingIgnoredLabelsFlag) {
+		var labels string
+		labels, err = interactive.GetString(interactive.Input{
+			Question: "Labels that cluster autoscaler should ignore when considering node group similarity",
+			Help:     flags.Lookup(balancingIgnoredLabelsFlag).Usage,
+			Default:  strings.Join(a.BalancingIgnoredLabels, ","),
+		})
+		a.BalancingIgnoredLabels = splitList(labels)
+	}
+	promptBool(ignoreDaemonsetsUtilizationFlag, "Ignore DaemonSets utilization",
+		&a.IgnoreDaemonsetsUtilization)
+	promptDuration(maxNodeProvisionTimeFlag, "Maximum node provision time", &a.MaxNodeProvisionTime)
+	promptInt(maxPodGracePeriodFlag, "Maximum pod grace period", &a.MaxPodGracePeriod)
+	promptInt(podPriorityThresholdFlag, "Pod priority threshold", &a.PodPriorityThreshold)
+
+	promptInt(maxNodesTotalFlag, "Maximum amount of nodes in the cluster", &a.MaxNodesTotal)
+	promptInt(minCoresFlag, "Minimum number of cores to deploy", &a.MinCores)
+	promptInt(maxCoresFlag, "Maximum number of cores to deploy", &a.MaxCores)
+	promptInt(minMemoryFlag, "Minimum amount of memory to deploy", &a.MinMemory)
+	promptInt(maxMemoryFlag, "Maximum amount of memory to deploy", &a.MaxMemory)
+	if err == nil && !flags.Changed(gpuLimitFlag) {
+		err = a.promptGPULimits(flags, gpuTypes)
+	}
+
+	promptBool(scaleDownEnabledFlag, "Enable scale down", &a.ScaleDownEnabled)
+	if a.ScaleDownEnabled {
+		promptDuration(scaleDownUnneededTimeFlag, "Scale down unneeded time", &a.ScaleDownUnneededTime)
+		if err == nil && !flags.Changed(scaleDownUtilizationThresholdFlg) {
+			a.ScaleDownUtilizationThreshold, err = interactive.GetFloat(interactive.Input{
+				Question: "Scale down utilization threshold",
+				Help:     flags.Lookup(scaleDownUtilizationThresholdFlg).Usage,
+				Default:  a.ScaleDownUtilizationThreshold,
+			})
+		}
+		promptDuration(scaleDownDelayAfterAddFlag, "Scale down delay after add", &a.ScaleDownDelayAfterAdd)
+		promptDuration(scaleDownDelayAfterDeleteFlag, "Scale down delay after delete",
+			&a.ScaleDownDelayAfterDelete)
+		promptDuration(scaleDownDelayAfterFailureFlag, "Scale down delay after failure",
+			&a.ScaleDownDelayAfterFailure)
+	}
+	return
+}
+
+// promptGPULimits asks for the minimum and maximum of each of the given GPU types and of the types
+// that already have limits. Types with a zero maximum are left without limits.
+func (a *AutoscalerArgs) promptGPULimits(flags *pflag.FlagSet, gpuTypes []string) error {
+	current, err := ParseGPULimits(a.GPULimits)
+	if err != nil {
+		return err
+	}
+	ranges := map[string]ocm.ResourceRange{}
+	types := append([]string{}, gpuTypes...)
+	for _, limit := range current {
+		ranges[limit.Type] = limit.Range
+		if !helper.Contains(types, limit.Type) {
+			types = append(types, limit.Type)
+		}
+	}
+	limits := []string{}
+	for _, gpuType := range types {
+		limit := ranges[gpuType]
+		limit.Min, err = interactive.GetInt(interactive.Input{
+			Question: fmt.Sprintf("Minimum number of '%s' GPUs", gpuType),
+			Help:     flags.Lookup(gpuLimitFlag).Usage,
+			Default:  limit.Min,
+		})
+		if err != nil {
+			return err
+		}
+		limit.Max, err = interactive.GetInt(interactive.Input{
+			Question: fmt.Sprintf("Maximum number of '%s' GPUs", gpuType),
+			Help:     flags.Lookup(gpuLimitFlag).Usage + " A zero maximum removes the limit.",
+			Default:  limit.Max,
+		})
+		if err != nil {
+			return err
+		}
+		if limit.Max > 0 {
+			limits = append(limits, fmt.Sprintf("%s,%d,%d", gpuType, limit.Min, limit.Max))
+		}
+	}
+	a.GPULimits = limits
+	return nil
+}
+
+// Validate checks the values of the options.
+func (a *AutoscalerArgs) Validate() error {
+	if a.LogVerbosity < 0 {
+		return fmt.Errorf("Log verbosity must be a non-negative integer")
+	}
+	if a.MaxPodGracePeriod < 0 {
+		return fmt.Errorf("Maximum pod grace period must be a non-negative integer")
+	}
+	if a.MaxNodesTotal < 0 {
+		return fmt.Errorf("Maximum amount of nodes must be a non-negative integer")
+	}
+	if err := validateRange("cores", a.MinCores, a.MaxCores); err != nil {
+		return err
+	}
+	if err := validateRange("memory", a.MinMemory, a.MaxMemory); err != nil {
+		return err
+	}
+	if _, err := ParseGPULimits(a.GPULimits); err != nil {
+		return err
+	}
+	if a.ScaleDownUtilizationThreshold < 0 || a.ScaleDownUtilizationThreshold > 1 {
+		return fmt.Errorf("Scale down utilization threshold must be a number between 0 and 1")
+	}
+	durations := map[string]string{
+		maxNodeProvisionTimeFlag:       a.MaxNodeProvisionTime,
+		scaleDownUnneededTimeFlag:      a.ScaleDownUnneededTime,
+		scaleDownDelayAfterAddFlag:     a.ScaleDownDelayAfterAdd,
+		scaleDownDelayAfterDeleteFlag:  a.ScaleDownDelayAfterDelete,
+		scaleDownDelayAfterFailureFlag: a.ScaleDownDelayAfterFailure,
+	}
+	for name, value := range durations {
+		if err := durationValidator(value); err != nil {
+			return fmt.Errorf("Invalid value for '%s': %v", name, err)
+		}
+	}
+	return nil
+}
+
+// Build creates the autoscaler configuration from the values of the options, which must have been
+// validated.
+func (a *AutoscalerArgs) Build() *ocm.ClusterAutoscaler {
+	gpus, _ := ParseGPULimits(a.GPULimits)
+	labels := a.BalancingIgnoredLabels
+	if labels == nil {
+		labels = []string{}
+	}
+	return &ocm.ClusterAutoscaler{
+		BalanceSimilarNodeGroups:    a.BalanceSimilarNodeGroups,
+		SkipNodesWithLocalStorage:   a.SkipNodesWithLocalStorage,
+		LogVerbosity:                a.LogVerbosity,
+		BalancingIgnoredLabels:      labels,
+		IgnoreDaemonsetsUtilization: a.IgnoreDaemonsetsUtilization,
+		MaxNodeProvisionTime:        a.MaxNodeProvisionTime,
+		MaxPodGracePeriod:           a.MaxPodGracePeriod,
+		PodPriorityThreshold:        a.PodPriorityThreshold,
+		ResourceLimits: ocm.ClusterAutoscalerResourceLimits{
+			MaxNodesTotal: a.MaxNodesTotal,
+			Cores:         ocm.ResourceRange{Min: a.MinCores, Max: a.MaxCores},
+			Memory:        ocm.ResourceRange{Min: a.MinMemory, Max: a.MaxMemory},
+			GPUS:          gpus,
+		},
+		ScaleDown: ocm.ClusterAutoscalerScaleDown{
+			Enabled:              a.ScaleDownEnabled,
+			UnneededTime:         a.ScaleDownUnneededTime,
+			UtilizationThreshold: strconv.FormatFloat(a.ScaleDownUtilizationThreshold, 'f', -1, 64),
+			DelayAfterAdd:        a.ScaleDownDelayAfterAdd,
+			DelayAfterDelete:     a.ScaleDownDelayAfterDelete,
+			DelayAfterFailure:    a.ScaleDownDelayAfterFailure,
+		},
+	}
+}
+
+// ParseGPULimits parses GPU limits in the 'type,min,max' format.
+func ParseGPULimits(values []string) ([]ocm.ClusterAutoscalerGPULimit, error) {
+	limits := []ocm.ClusterAutoscalerGPULimit{}
+	types := map[string]bool{}
+	for _, value := range values {
+		parts := strings.Split(value, ",")
+		if len(parts) != 3 || strings.TrimSpace(parts[0]) == "" {
+			return nil, fmt.Errorf("Invalid GPU limit '%s', expected format is 'type,min,max'", value)
+		}
+		gpuType := strings.TrimSpace(parts[0])
+		if types[gpuType] {
+			return nil, fmt.Errorf("GPU type '%s' has more than one limit", gpuType)
+		}
+		types[gpuType] = true
+		min, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+		if err != nil {
+			return nil, fmt.Errorf("Invalid minimum in GPU limit '%s': %v", value, err)
+		}
+		max, err := strconv.Atoi(strings.TrimSpace(parts[2]))
+		if err != nil {
+			return nil, fmt.Errorf("Invalid maximum in GPU limit '%s': %v", value, err)
+		}
+		if err := validateRange(fmt.Sprintf("GPU type '%s'", gpuType), min, max); err != nil {
+			return nil, err
+		}
+		limits = append(limits, ocm.ClusterAutoscalerGPULimit{
+			Type:  gpuType,
+			Range: ocm.ResourceRange{Min: min, Max: max},
+		})
+	}
+	return limits, nil
+}
+
+// FormatGPULimits is the inverse of ParseGPULimits.
+func FormatGPULimits(limits []ocm.ClusterAutoscalerGPULimit) []string {
+	values := []string{}
+	for _, limit := range limits {
+		values = append(values, fmt.Sprintf("%s,%d,%d", limit.Type, limit.Range.Min, limit.Range.Max))
+	}
+	return values
+}
+
+func validateRange(name string, min int, max int) error {
+	if min < 0 || max < 0 {
+		return fmt.Errorf("Limits for %s must be non-negative integers", name)
+	}
+	if min > max {
+		return fmt.Errorf("Maximum limit for %s must be greater or equal to the minimum", name)
+	}
+	return nil
+}
+
+func durationValidator(val interface{}) error {
+	value := fmt.Sprintf("%v", val)
+	if value == "" {
+		return nil
+	}
+	_, err := time.ParseDuration(value)
+	if err != nil {
+		return fmt.Errorf("Expected a duration like '20m' or '1h'")
+	}
+	return nil
+}
+
+func splitList(value string) []string {
+	items := []string{}
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,118 @@
+package clusterautoscaler
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/pflag"
+
+	"github.com/openshift/rosa/pkg/ocm"
+)
+
+var _ = Describe("Cluster autoscaler options", func() {
+	var flags *pflag.FlagSet
+	var args *AutoscalerArgs
+
+	BeforeEach(func() {
+		flags = pflag.NewFlagSet("test", pflag.ContinueOnError)
+		args = AddFlags(flags)
+	})
+
+	It("Builds the autoscaler from the options", func() {
+		Expect(flags.Parse([]string{
+			"--max-nodes-total=10", "--min-cores=2", "--max-cores=8",
+			"--gpu-limit=nvidia.com/gpu,0,4", "--scale-down-enabled",
+			"--scale-down-unneeded-time=5m", "--scale-down-utilization-threshold=0.6",
+		})).To(Succeed())
+		Expect(args.Validate()).To(Succeed())
+		autoscaler := args.Build()
+		Expect(autoscaler.LogVerbosity).To(Equal(1))
+		Expect(autoscaler.ResourceLimits.MaxNodesTotal).To(Equal(10))
+		Expect(autoscaler.ResourceLimits.Cores).To(Equal(ocm.ResourceRange{Min: 2, Max: 8}))
+		Expect(autoscaler.ResourceLimits.GPUS).To(Equal([]ocm.ClusterAutoscalerGPULimit{
+			{Type: "nvidia.com/gpu", Range: ocm.ResourceRange{Min: 0, Max: 4}},
+		}))
+		Expect(autoscaler.ScaleDown.Enabled).To(BeTrue())
+		Expect(autoscaler.ScaleDown.UnneededTime).To(Equal("5m"))
+		Expect(autoscaler.ScaleDown.UtilizationThreshold).To(Equal("0.6"))
+	})
+
+	It("Uses the default resource limits when they aren't given", func() {
+		Expect(flags.Parse([]string{"--scale-down-enabled"})).To(Succeed())
+		Expect(args.Validate()).To(Succeed())
+		limits := args.Build().ResourceLimits
+		Expect(limits.MaxNodesTotal).To(Equal(DefaultMaxNodesTotal))
+		Expect(limits.Cores).To(Equal(ocm.ResourceRange{Min: DefaultMinCores, Max: DefaultMaxCores}))
+		Expect(limits.Memory).To(Equal(ocm.ResourceRange{Min: DefaultMinMemory, Max: DefaultMaxMemory}))
+	})
+
+	It("Keeps the existing values of the options that weren't given", func() {
+		Expect(flags.Parse([]string{"--max-cores=16"})).To(Succeed())
+		Expect(AnyChanged(flags)).To(BeTrue())
+		args.SetDefaultsFrom(flags, &ocm.ClusterAutoscaler{
+			LogVerbosity: 3,
+			ResourceLimits: ocm.ClusterAutoscalerResourceLimits{
+				Cores: ocm.ResourceRange{Min: 4, Max: 8},
+				GPUS: []ocm.ClusterAutoscalerGPULimit{
+					{Type: "nvidia.com/gpu", Range: ocm.ResourceRange{Min: 1, Max: 2}},
+				},
+			},
+			ScaleDown: ocm.ClusterAutoscalerScaleDown{
+				Enabled:              true,
+				UtilizationThreshold: "0.3",
+			},
+		})
+		Expect(args.LogVerbosity).To(Equal(3))
+		Expect(args.MinCores).To(Equal(4))
+		Expect(args.MaxCores).To(Equal(16))
+		Expect(args.GPULimits).To(Equal([]string{"nvidia.com/gpu,1,2"}))
+		Expect(args.ScaleDownEnabled).To(BeTrue())
+		Expect(args.ScaleDownUtilizationThreshold).To(Equal(0.3))
+	})
+
+	DescribeTable("Validation",
+		func(argv []string, message string) {
+			Expect(flags.Parse(argv)).To(Succeed())
+			err := args.Validate()
+			if message == "" {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(err).To(MatchError(ContainSubstring(message)))
+			}
+		},
+		Entry("defaults", []string{}, ""),
+		Entry("negative log verbosity", []string{"--log-verbosity=-1"}, "non-negative"),
+		Entry("inverted cores", []string{"--min-cores=4", "--max-cores=2"}, "greater or equal"),
+		Entry("inverted memory", []string{"--min-memory=4", "--max-memory=2"}, "greater or equal"),
+		Entry("threshold above one", []string{"--scale-down-utilization-threshold=1.5"}, "between 0 and 1"),
+		Entry("invalid duration", []string{"--scale-down-delay-after-add=10"}, "scale-down-delay-after-add"),
+		Entry("malformed GPU limit", []string{"--gpu-limit=nvidia.com/gpu,1"}, "'type,min,max'"),
+		Entry("duplicated GPU type", []string{"--gpu-limit=a,0,1", "--gpu-limit=a,0,2"}, "more than one limit"),
+		Entry("inverted GPU limit", []string{"--gpu-limit=a,3,1"}, "greater or equal"),
+	)
+})
+
+var _ = Describe("GPU types", func() {
+	It("Finds the GPU types provided by the instance types", func() {
+		Expect(GPUTypes([]string{"m5.xlarge", "g4dn.xlarge", "p3.2xlarge", "g4ad.4xlarge"})).To(Equal(
+			[]string{AMDGPUType, NvidiaGPUType}))
+		Expect(GPUTypes([]string{"m5.xlarge"})).To(BeEmpty())
+	})
+
+	DescribeTable("Validates the GPU limits against the instance types",
+		func(limits []string, instanceTypes []string, message string) {
+			args := &AutoscalerArgs{GPULimits: limits}
+			err := args.ValidateGPUTypes(instanceTypes)
+			if message == "" {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(err).To(MatchError(ContainSubstring(message)))
+			}
+		},
+		Entry("no limits", nil, []string{"m5.xlarge"}, ""),
+		Entry("provided type", []string{"nvidia.com/gpu,0,4"}, []string{"m5.xlarge", "g5.xlarge"}, ""),
+		Entry("missing type", []string{"amd.com/gpu,0,4"}, []string{"g5.xlarge"},
+			"the known types are 'nvidia.com/gpu'"),
+		Entry("no GPUs", []string{"nvidia.com/gpu,0,4"}, []string{"m5.xlarge"},
+			"none of the instance types 'm5.xlarge' is known to have GPUs"),
+	)
+})
@@ -0,0 +1,98 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the validation of the GPU limits against the instance types of the machine
+// pools of the cluster.
+
+package clusterautoscaler
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/openshift/rosa/pkg/helper"
+)
+
+// GPU types, as used in the resource limits of the autoscaler.
+const (
+	NvidiaGPUType = "nvidia.com/gpu"
+	AMDGPUType    = "amd.com/gpu"
+	HabanaGPUType = "habana.ai/gaudi"
+	NeuronGPUType = "aws.amazon.com/neuron"
+)
+
+// gpuTypesByFamily contains the GPU type provided by each family of accelerated instance types. It
+// is a static list, so new families may be missing, and the checks that use it only warn.
+var gpuTypesByFamily = map[string]string{
+	"p2":   NvidiaGPUType,
+	"p3":   NvidiaGPUType,
+	"p3dn": NvidiaGPUType,
+	"p4d":  NvidiaGPUType,
+	"p4de": NvidiaGPUType,
+	"p5":   NvidiaGPUType,
+	"g3":   NvidiaGPUType,
+	"g3s":  NvidiaGPUType,
+	"g4dn": NvidiaGPUType,
+	"g5":   NvidiaGPUType,
+	"g5g":  NvidiaGPUType,
+	"g6":   NvidiaGPUType,
+	"g6e":  NvidiaGPUType,
+	"gr6":  NvidiaGPUType,
+	"g4ad": AMDGPUType,
+	"dl1":  HabanaGPUType,
+	"inf1": NeuronGPUType,
+	"inf2": NeuronGPUType,
+	"trn1": NeuronGPUType,
+}
+
+// GPUTypes returns the sorted GPU types provided by the given instance types, for example
+// 'nvidia.com/gpu' for 'g4dn.xlarge'.
+func GPUTypes(instanceTypes []string) []string {
+	types := []string{}
+	for _, instanceType := range instanceTypes {
+		family := strings.SplitN(instanceType, ".", 2)[0]
+		gpuType, ok := gpuTypesByFamily[family]
+		if ok && !helper.Contains(types, gpuType) {
+			types = append(types, gpuType)
+		}
+	}
+	sort.Strings(types)
+	return types
+}
+
+// ValidateGPUTypes checks that the GPU types of the limits are provided by some of the given
+// instance types, as limits for other types would never apply. The returned error should be
+// reported as a warning, as the instance type families known to have GPUs may be outdated.
+func (a *AutoscalerArgs) ValidateGPUTypes(instanceTypes []string) error {
+	limits, err := ParseGPULimits(a.GPULimits)
+	if err != nil {
+		return err
+	}
+	available := GPUTypes(instanceTypes)
+	for _, limit := range limits {
+		if helper.Contains(available, limit.Type) {
+			continue
+		}
+		if len(available) == 0 {
+			return fmt.Errorf("GPU type '%s' doesn't seem to be provided by any machine pool, none of "+
+				"the instance types '%s' is known to have GPUs", limit.Type, strings.Join(instanceTypes, "', '"))
+		}
+		return fmt.Errorf("GPU type '%s' doesn't seem to be provided by any machine pool, the known "+
+			"types are '%s'", limit.Type, strings.Join(available, "', '"))
+	}
+	return nil
+}
@@ -24,6 +24,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v4"
@@ -31,6 +32,7 @@ import (
 	sdk "github.com/openshift-online/ocm-sdk-go"
 
 	"github.com/openshift/rosa/pkg/debug"
+	"github.com/openshift/rosa/pkg/retry"
 )
 
 // Config is the type used to store the configuration of the client.
@@ -44,8 +46,25 @@ type Config struct {
 	TokenURL     string   `json:"token_url,omitempty"`
 	URL          string   `json:"url,omitempty"`
 	FedRAMP      bool     `json:"fedramp,omitempty"`
+	AWSProfile   string   `json:"aws_profile,omitempty"`
+	AWSRegion    string   `json:"aws_region,omitempty"`
+	AuthMethod   string   `json:"auth_method,omitempty"`
+	Output       string   `json:"output,omitempty"`
+
+	// Retry policy of the requests to the OCM and AWS APIs, used when the '--max-retries' and
+	// '--request-timeout' command line options aren't given:
+	MaxRetries     *int   `json:"max_retries,omitempty"`
+	RequestTimeout string `json:"request_timeout,omitempty"`
 }
 
+// Authentication methods stored in the configuration. Configurations written before the method was
+// stored don't have it, and use tokens.
+const (
+	AuthMethodToken             = "token"
+	AuthMethodClientCredentials = "client_credentials"
+	AuthMethodDeviceCode        = "device_code"
+)
+
 // Load loads the configuration from the configuration file. If the configuration file doesn't exist
 // it will return an empty configuration object.
 func Load() (cfg *Config, err error) {
@@ -119,8 +138,20 @@ func Remove() error {
 
 // Location returns the location of the configuration file. If a configuration file
 // already exists in the HOME directory, it uses that, otherwise it prefers to
-// use the XDG config directory.
+// use the XDG config directory. When a configuration profile is selected the file
+// of that profile is used instead.
 func Location() (path string, err error) {
+	path, err = defaultLocation()
+	if err != nil {
+		return
+	}
+	if name := Profile(); name != "" {
+		return profileLocation(path, name)
+	}
+	return
+}
+
+func defaultLocation() (path string, err error) {
 	// Use env variable
 	if ocmconfig := os.Getenv("OCM_CONFIG"); ocmconfig != "" {
 		return ocmconfig, nil
@@ -179,6 +210,55 @@ func (c *Config) GetData(key string) (value string, err error) {
 	return
 }
 
+// ApplyRetryPolicy sets the retry policy stored in the given configuration as the default for this
+// execution. Invalid settings are ignored, so that the built-in defaults are used for them, and
+// returned as an error that callers should report as a warning.
+func ApplyRetryPolicy(cfg *Config) error {
+	if cfg == nil {
+		return nil
+	}
+	var errs []string
+	retries := -1
+	if cfg.MaxRetries != nil {
+		if *cfg.MaxRetries < 0 {
+			errs = append(errs, fmt.Sprintf("invalid max retries %d, it can't be negative", *cfg.MaxRetries))
+		} else {
+			retries = *cfg.MaxRetries
+		}
+	}
+	var timeout time.Duration
+	if cfg.RequestTimeout != "" {
+		var err error
+		timeout, err = time.ParseDuration(cfg.RequestTimeout)
+		if err != nil || timeout < 0 {
+			errs = append(errs, fmt.Sprintf("invalid request timeout '%s'", cfg.RequestTimeout))
+			timeout = 0
+		}
+	}
+	retry.SetDefaults(retries, timeout)
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+// Username returns the name of the user that the access token was issued to. Tokens issued to
+// service accounts with the client credentials grant don't have the 'username' claim, so the
+// 'preferred_username' claim is used for them.
+func (c *Config) Username() (username string, err error) {
+	username, err = c.GetData("username")
+	if err == nil || c.AuthMethod != AuthMethodClientCredentials {
+		return
+	}
+	return c.GetData("preferred_username")
+}
+
+// hasCredentials checks if the configuration contains any tokens or credentials, regardless of
+// whether they are still valid.
+func (c *Config) hasCredentials() bool {
+	return c.AccessToken != "" || c.RefreshToken != "" || c.ClientID != "" || c.ClientSecret != ""
+}
+
 // Armed checks if the configuration contains either credentials or tokens that haven't expired, so
 // that it can be used to perform authenticated requests.
 func (c *Config) Armed() (armed bool, err error) {
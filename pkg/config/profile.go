@@ -0,0 +1,88 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains functions used to implement the '--config-profile' command line option,
+// which selects one of several named configurations.
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/pflag"
+)
+
+const (
+	ProfileFlag = "config-profile"
+	ProfileEnv  = "ROSA_CONFIG_PROFILE"
+)
+
+var profileRE = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_-]*$`)
+
+// profile is the name of the configuration profile selected in the command line.
+var profile string
+
+// AddProfileFlag adds the '--config-profile' flag to the given set of command line flags.
+func AddProfileFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&profile,
+		ProfileFlag,
+		"",
+		fmt.Sprintf("Use a named configuration profile, overriding the %s environment variable. "+
+			"Each profile keeps its own OCM credentials, API URL, AWS profile and AWS region.",
+			ProfileEnv),
+	)
+}
+
+// Profile returns the name of the selected configuration profile, or an empty string if the
+// default configuration is used.
+func Profile() string {
+	if profile != "" {
+		return profile
+	}
+	return os.Getenv(ProfileEnv)
+}
+
+// profileLocation returns the location of the configuration file of the given profile, which is
+// stored next to the default one, for example '~/.config/ocm/ocm.staging.json'.
+func profileLocation(path string, name string) (string, error) {
+	if !profileRE.MatchString(name) {
+		return "", fmt.Errorf("Invalid configuration profile name '%s'. Names must consist of "+
+			"alphanumeric characters, '-' or '_'", name)
+	}
+	ext := filepath.Ext(path)
+	return fmt.Sprintf("%s.%s%s", strings.TrimSuffix(path, ext), name, ext), nil
+}
+
+// ApplyProfile sets the AWS profile and region stored in the given configuration, loaded from the
+// selected configuration profile or from the default configuration, as the defaults for this
+// execution. Values given explicitly in the environment take precedence, and so do the '--profile'
+// and '--region' command line options.
+func ApplyProfile(cfg *Config) {
+	if cfg == nil {
+		return
+	}
+	if cfg.AWSProfile != "" && os.Getenv("AWS_PROFILE") == "" {
+		os.Setenv("AWS_PROFILE", cfg.AWSProfile)
+	}
+	if cfg.AWSRegion != "" && os.Getenv("AWS_REGION") == "" {
+		os.Setenv("AWS_REGION", cfg.AWSRegion)
+	}
+}
@@ -0,0 +1,219 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the settings of the configuration file that can be read and written with the
+// 'rosa config' commands.
+
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"github.com/openshift/rosa/pkg/helper"
+)
+
+// OutputFormats are the output formats that can be stored as the default of the '--output' flag.
+var OutputFormats = []string{"json", "yaml", "wide"}
+
+// Setting is a setting of the configuration file that can be read and written individually.
+type Setting struct {
+	Name        string
+	Description string
+	get         func(cfg *Config) string
+	set         func(cfg *Config, value string) error
+	unset       func(cfg *Config)
+
+	// environment indicates that the setting selects the OCM environment, so the tokens and
+	// credentials obtained for the previous one can't be used any longer when it changes.
+	environment bool
+}
+
+// Settings are the settings that can be read and written with the 'rosa config' commands. Tokens
+// and credentials aren't included, they are managed with 'rosa login' and 'rosa logout'.
+var Settings = []*Setting{
+	{
+		Name:        "region",
+		Description: "Default AWS region, used when the '--region' flag and AWS_REGION aren't given.",
+		get:         func(cfg *Config) string { return cfg.AWSRegion },
+		set: func(cfg *Config, value string) error {
+			cfg.AWSRegion = value
+			return nil
+		},
+		unset: func(cfg *Config) { cfg.AWSRegion = "" },
+	},
+	{
+		Name:        "aws-profile",
+		Description: "Default AWS profile, used when the '--profile' flag and AWS_PROFILE aren't given.",
+		get:         func(cfg *Config) string { return cfg.AWSProfile },
+		set: func(cfg *Config, value string) error {
+			cfg.AWSProfile = value
+			return nil
+		},
+		unset: func(cfg *Config) { cfg.AWSProfile = "" },
+	},
+	{
+		Name: "output",
+		Description: fmt.Sprintf("Default output format of the commands that support the '--output' flag. "+
+			"Allowed formats are %s.", OutputFormats),
+		get: func(cfg *Config) string { return cfg.Output },
+		set: func(cfg *Config, value string) error {
+			if !helper.Contains(OutputFormats, value) {
+				return fmt.Errorf("Invalid output format '%s', allowed formats are %s", value, OutputFormats)
+			}
+			cfg.Output = value
+			return nil
+		},
+		unset: func(cfg *Config) { cfg.Output = "" },
+	},
+	{
+		Name:        "url",
+		Description: "URL of the OCM API.",
+		get:         func(cfg *Config) string { return cfg.URL },
+		set: func(cfg *Config, value string) error {
+			parsed, err := url.ParseRequestURI(value)
+			if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") {
+				return fmt.Errorf("Invalid URL '%s', expected an HTTP or HTTPS URL", value)
+			}
+			cfg.URL = value
+			return nil
+		},
+		unset:       func(cfg *Config) { cfg.URL = "" },
+		environment: true,
+	},
+	{
+		Name:        "fedramp",
+		Description: "Use the FedRAMP environment.",
+		get:         func(cfg *Config) string { return strconv.FormatBool(cfg.FedRAMP) },
+		set: func(cfg *Config, value string) error {
+			fedramp, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("Invalid value '%s', expected 'true' or 'false'", value)
+			}
+			cfg.FedRAMP = fedramp
+			return nil
+		},
+		unset:       func(cfg *Config) { cfg.FedRAMP = false },
+		environment: true,
+	},
+	{
+		Name:        "max-retries",
+		Description: "Maximum number of retries of the requests to the OCM and AWS APIs.",
+		get: func(cfg *Config) string {
+			if cfg.MaxRetries == nil {
+				return ""
+			}
+			return strconv.Itoa(*cfg.MaxRetries)
+		},
+		set: func(cfg *Config, value string) error {
+			retries, err := strconv.Atoi(value)
+			if err != nil || retries < 0 {
+				return fmt.Errorf("Invalid number of retries '%s', expected a non negative integer", value)
+			}
+			cfg.MaxRetries = &retries
+			return nil
+		},
+		unset: func(cfg *Config) { cfg.MaxRetries = nil },
+	},
+	{
+		Name:        "request-timeout",
+		Description: "Maximum duration of each request to the OCM and AWS APIs, for example '30s'.",
+		get:         func(cfg *Config) string { return cfg.RequestTimeout },
+		set: func(cfg *Config, value string) error {
+			_, err := time.ParseDuration(value)
+			if err != nil {
+				return fmt.Errorf("Invalid request timeout '%s': %v", value, err)
+			}
+			cfg.RequestTimeout = value
+			return nil
+		},
+		unset: func(cfg *Config) { cfg.RequestTimeout = "" },
+	},
+}
+
+// FindSetting returns the setting with the given name.
+func FindSetting(name string) (*Setting, error) {
+	names := make([]string, len(Settings))
+	for i, setting := range Settings {
+		if setting.Name == name {
+			return setting, nil
+		}
+		names[i] = setting.Name
+	}
+	return nil, fmt.Errorf("Unknown setting '%s', expected one of %s", name, strings.Join(names, ", "))
+}
+
+// Get returns the value of the setting in the given configuration.
+func (s *Setting) Get(cfg *Config) string {
+	return s.get(cfg)
+}
+
+// Set checks the value and stores it in the given configuration. If the setting selects a different
+// OCM environment the tokens and credentials are removed, and the returned flag is true, as they
+// can't be used with the new environment.
+func (s *Setting) Set(cfg *Config, value string) (loggedOut bool, err error) {
+	if value == "" {
+		err = fmt.Errorf("Expected a value for setting '%s', use 'rosa config unset %s' to remove it",
+			s.Name, s.Name)
+		return
+	}
+	previous := s.get(cfg)
+	err = s.set(cfg, value)
+	if err != nil {
+		return
+	}
+	loggedOut = s.changeEnvironment(cfg, previous)
+	return
+}
+
+// Unset removes the setting from the given configuration. As with Set, the tokens and credentials
+// are removed if that selects a different OCM environment.
+func (s *Setting) Unset(cfg *Config) (loggedOut bool) {
+	previous := s.get(cfg)
+	s.unset(cfg)
+	return s.changeEnvironment(cfg, previous)
+}
+
+// changeEnvironment removes the tokens and credentials if the setting selects the OCM environment
+// and its value is different to the previous one. It returns true if anything was removed.
+func (s *Setting) changeEnvironment(cfg *Config, previous string) bool {
+	if !s.environment || s.get(cfg) == previous || !cfg.hasCredentials() {
+		return false
+	}
+	cfg.AccessToken = ""
+	cfg.RefreshToken = ""
+	cfg.ClientID = ""
+	cfg.ClientSecret = ""
+	cfg.Scopes = nil
+	cfg.TokenURL = ""
+	cfg.AuthMethod = ""
+	return true
+}
+
+// ApplyOutputFormat sets the output format stored in the given configuration as the value of the
+// '--output' flag of the command, when it has one and it wasn't given explicitly.
+func ApplyOutputFormat(cfg *Config, flags *pflag.FlagSet) error {
+	flag := flags.Lookup("output")
+	if flag == nil || flag.Changed || cfg == nil || cfg.Output == "" {
+		return nil
+	}
+	return flag.Value.Set(cfg.Output)
+}
@@ -62,6 +62,19 @@ func ParseToken(textToken string) (token *jwt.Token, err error) {
 	return token, nil
 }
 
+// TokenClaims returns the claims of the given token, without verifying its signature.
+func TokenClaims(textToken string) (jwt.MapClaims, error) {
+	token, err := ParseToken(textToken)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse token: %v", err)
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, fmt.Errorf("Expected map claims but got %T", token.Claims)
+	}
+	return claims, nil
+}
+
 // GetTokenExpiry determines if the given token expires, and the time that remains till it expires.
 func getTokenExpiry(token *jwt.Token, now time.Time) (expires bool, left time.Duration, err error) {
 	claims, ok := token.Claims.(jwt.MapClaims)
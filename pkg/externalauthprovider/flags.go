@@ -0,0 +1,345 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the command line options shared by the commands that create and edit the
+// external authentication providers of a Hosted Control Plane cluster.
+
+package externalauthprovider
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/pflag"
+
+	"github.com/openshift/rosa/pkg/interactive"
+	"github.com/openshift/rosa/pkg/ocm"
+)
+
+const (
+	issuerURLFlag           = "issuer-url"
+	issuerAudiencesFlag     = "issuer-audiences"
+	issuerCAFileFlag        = "issuer-ca-file"
+	usernameClaimFlag       = "claim-mapping-username-claim"
+	usernamePrefixFlag      = "claim-mapping-username-prefix"
+	groupsClaimFlag         = "claim-mapping-groups-claim"
+	groupsPrefixFlag        = "claim-mapping-groups-prefix"
+	claimValidationRuleFlag = "claim-validation-rule"
+	consoleClientIDFlag     = "console-client-id"
+	consoleClientSecretFlag = "console-client-secret"
+	cliClientIDFlag         = "cli-client-id"
+)
+
+const (
+	maxIssuerAudiences       = 10
+	defaultUsernameClaim     = "email"
+	claimValidationRuleSplit = ":"
+)
+
+var flagNames = []string{
+	issuerURLFlag, issuerAudiencesFlag, issuerCAFileFlag, usernameClaimFlag, usernamePrefixFlag,
+	groupsClaimFlag, groupsPrefixFlag, claimValidationRuleFlag, consoleClientIDFlag,
+	consoleClientSecretFlag, cliClientIDFlag,
+}
+
+var clientFlagNames = []string{consoleClientIDFlag, consoleClientSecretFlag, cliClientIDFlag}
+
+// Args contains the values of the external authentication provider command line options.
+type Args struct {
+	IssuerURL       string
+	IssuerAudiences []string
+	IssuerCAFile    string
+
+	UsernameClaim        string
+	UsernamePrefix       string
+	GroupsClaim          string
+	GroupsPrefix         string
+	ClaimValidationRules []string
+
+	ConsoleClientID     string
+	ConsoleClientSecret string
+	CLIClientID         string
+}
+
+// AddFlags adds the external authentication provider options to the given set of command line
+// flags.
+func AddFlags(flags *pflag.FlagSet) *Args {
+	args := &Args{}
+
+	flags.StringVar(&args.IssuerURL, issuerURLFlag, "",
+		"HTTPS URL of the OIDC issuer. Its discovery document must be reachable.")
+	flags.StringSliceVar(&args.IssuerAudiences, issuerAudiencesFlag, nil,
+		fmt.Sprintf("Comma-separated list of at most %d audiences that the tokens must be issued for.",
+			maxIssuerAudiences))
+	flags.StringVar(&args.IssuerCAFile, issuerCAFileFlag, "",
+		"Path of a PEM file with the certificate authorities used to verify the TLS certificate of the issuer.")
+	flags.StringVar(&args.UsernameClaim, usernameClaimFlag, defaultUsernameClaim,
+		"Claim of the tokens used as the name of the user.")
+	flags.StringVar(&args.UsernamePrefix, usernamePrefixFlag, "",
+		"Prefix added to the name of the users to avoid conflicts with other authentication methods.")
+	flags.StringVar(&args.GroupsClaim, groupsClaimFlag, "",
+		"Claim of the tokens used as the groups of the user.")
+	flags.StringVar(&args.GroupsPrefix, groupsPrefixFlag, "",
+		"Prefix added to the groups of the users.")
+	flags.StringArrayVar(&args.ClaimValidationRules, claimValidationRuleFlag, nil,
+		"Rule in the format 'claim:value' that requires the claim of the tokens to have the value. "+
+			"Can be repeated.")
+	flags.StringVar(&args.ConsoleClientID, consoleClientIDFlag, "",
+		"Identifier of the OIDC client used by the web console.")
+	flags.StringVar(&args.ConsoleClientSecret, consoleClientSecretFlag, "",
+		"Secret of the OIDC client used by the web console.")
+	flags.StringVar(&args.CLIClientID, cliClientIDFlag, "",
+		"Identifier of the public OIDC client used by the 'oc' command line tool.")
+
+	return args
+}
+
+// AnyChanged checks if any of the external authentication provider options was given in the
+// command line.
+func AnyChanged(flags *pflag.FlagSet) bool {
+	for _, name := range flagNames {
+		if flags.Changed(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// ClientsChanged checks if any of the OIDC client options was given in the command line. The
+// secrets of the existing clients aren't returned by the service, so the clients are only sent
+// when they change.
+func ClientsChanged(flags *pflag.FlagSet) bool {
+	for _, name := range clientFlagNames {
+		if flags.Changed(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// SetDefaultsFrom sets the values of the options that weren't given in the command line from the
+// given existing provider, so that editing only changes what the user asked for.
+func (a *Args) SetDefaultsFrom(flags *pflag.FlagSet, externalAuth *ocm.ExternalAuth) {
+	unchanged := func(name string) bool {
+		return !flags.Changed(name)
+	}
+	if issuer := externalAuth.Issuer; issuer != nil {
+		if unchanged(issuerURLFlag) {
+			a.IssuerURL = issuer.URL
+		}
+		if unchanged(issuerAudiencesFlag) {
+			a.IssuerAudiences = issuer.Audiences
+		}
+	}
+	if claim := externalAuth.Claim; claim != nil {
+		if mappings := claim.Mappings; mappings != nil {
+			if mappings.UserName != nil && unchanged(usernameClaimFlag) {
+				a.UsernameClaim = mappings.UserName.Claim
+			}
+			if mappings.UserName != nil && unchanged(usernamePrefixFlag) {
+				a.UsernamePrefix = mappings.UserName.Prefix
+			}
+			if mappings.Groups != nil && unchanged(groupsClaimFlag) {
+				a.GroupsClaim = mappings.Groups.Claim
+			}
+			if mappings.Groups != nil && unchanged(groupsPrefixFlag) {
+				a.GroupsPrefix = mappings.Groups.Prefix
+			}
+		}
+		if unchanged(claimValidationRuleFlag) {
+			a.ClaimValidationRules = FormatClaimValidationRules(claim.ValidationRules)
+		}
+	}
+	if console := externalAuth.FindClient(ocm.ExternalAuthConsoleComponent); console != nil &&
+		unchanged(consoleClientIDFlag) {
+		a.ConsoleClientID = console.ID
+	}
+	if cli := externalAuth.FindClient(ocm.ExternalAuthCLIComponent); cli != nil && unchanged(cliClientIDFlag) {
+		a.CLIClientID = cli.ID
+	}
+}
+
+// Prompt asks the user for the values of the options that weren't given in the command line,
+// using the current values as defaults.
+func (a *Args) Prompt(flags *pflag.FlagSet) (err error) {
+	promptString := func(name string, question string, required bool, value *string) {
+		if err != nil || flags.Changed(name) {
+			return
+		}
+		*value, err = interactive.GetString(interactive.Input{
+			Question: question,
+			Help:     flags.Lookup(name).Usage,
+			Default:  *value,
+			Required: required,
+		})
+	}
+	promptString(issuerURLFlag, "Issuer URL", true, &a.IssuerURL)
+	if err == nil && !flags.Changed(issuerAudiencesFlag) {
+		var audiences string
+		audiences, err = interactive.GetString(interactive.Input{
+			Question: "Issuer audiences",
+			Help:     flags.Lookup(issuerAudiencesFlag).Usage,
+			Default:  strings.Join(a.IssuerAudiences, ","),
+			Required: true,
+		})
+		a.IssuerAudiences = splitList(audiences)
+	}
+	promptString(usernameClaimFlag, "Username claim", true, &a.UsernameClaim)
+	promptString(usernamePrefixFlag, "Username prefix", false, &a.UsernamePrefix)
+	promptString(groupsClaimFlag, "Groups claim", false, &a.GroupsClaim)
+	promptString(groupsPrefixFlag, "Groups prefix", false, &a.GroupsPrefix)
+	promptString(consoleClientIDFlag, "Console client ID", false, &a.ConsoleClientID)
+	if a.ConsoleClientID != "" {
+		promptString(consoleClientSecretFlag, "Console client secret", true, &a.ConsoleClientSecret)
+	}
+	promptString(cliClientIDFlag, "CLI client ID", false, &a.CLIClientID)
+	return err
+}
+
+// Validate checks the values of the options. The console client secret is only required when the
+// clients are sent.
+func (a *Args) Validate(withClients bool) error {
+	issuerURL, err := url.ParseRequestURI(a.IssuerURL)
+	if err != nil || issuerURL.Host == "" {
+		return fmt.Errorf("Expected a valid issuer URL, got '%s'", a.IssuerURL)
+	}
+	if issuerURL.Scheme != "https" {
+		return fmt.Errorf("Issuer URL '%s' must use HTTPS", a.IssuerURL)
+	}
+	if len(a.IssuerAudiences) == 0 {
+		return fmt.Errorf("At least one issuer audience is required")
+	}
+	if len(a.IssuerAudiences) > maxIssuerAudiences {
+		return fmt.Errorf("At most %d issuer audiences are allowed", maxIssuerAudiences)
+	}
+	if a.UsernameClaim == "" {
+		return fmt.Errorf("Username claim is required")
+	}
+	if a.GroupsPrefix != "" && a.GroupsClaim == "" {
+		return fmt.Errorf("Groups prefix requires a groups claim")
+	}
+	if _, err := ParseClaimValidationRules(a.ClaimValidationRules); err != nil {
+		return err
+	}
+	if withClients {
+		if a.ConsoleClientID != "" && a.ConsoleClientSecret == "" {
+			return fmt.Errorf("Console client '%s' requires a secret", a.ConsoleClientID)
+		}
+		if a.ConsoleClientID == "" && a.ConsoleClientSecret != "" {
+			return fmt.Errorf("Console client secret requires a console client ID")
+		}
+	}
+	return nil
+}
+
+// Build creates the external authentication provider from the values of the options, which must
+// have been validated. The clients are only included when requested.
+func (a *Args) Build(id string, withClients bool) (*ocm.ExternalAuth, error) {
+	rules, _ := ParseClaimValidationRules(a.ClaimValidationRules)
+	externalAuth := &ocm.ExternalAuth{
+		ID: id,
+		Issuer: &ocm.ExternalAuthIssuer{
+			URL:       a.IssuerURL,
+			Audiences: a.IssuerAudiences,
+		},
+		Claim: &ocm.ExternalAuthClaim{
+			Mappings: &ocm.ExternalAuthClaimMappings{
+				UserName: &ocm.ExternalAuthClaimMapping{
+					Claim:  a.UsernameClaim,
+					Prefix: a.UsernamePrefix,
+				},
+			},
+			ValidationRules: rules,
+		},
+	}
+	if a.GroupsClaim != "" {
+		externalAuth.Claim.Mappings.Groups = &ocm.ExternalAuthClaimMapping{
+			Claim:  a.GroupsClaim,
+			Prefix: a.GroupsPrefix,
+		}
+	}
+	if a.IssuerCAFile != "" {
+		ca, err := os.ReadFile(a.IssuerCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to read issuer CA file '%s': %v", a.IssuerCAFile, err)
+		}
+		externalAuth.Issuer.CA = string(ca)
+	}
+	if withClients {
+		externalAuth.Clients = []*ocm.ExternalAuthClient{}
+		if a.ConsoleClientID != "" {
+			externalAuth.Clients = append(externalAuth.Clients,
+				ocm.NewExternalAuthClient(ocm.ExternalAuthConsoleComponent, a.ConsoleClientID, a.ConsoleClientSecret))
+		}
+		if a.CLIClientID != "" {
+			externalAuth.Clients = append(externalAuth.Clients,
+				ocm.NewExternalAuthClient(ocm.ExternalAuthCLIComponent, a.CLIClientID, ""))
+		}
+	}
+	return externalAuth, nil
+}
+
+// ParseClaimValidationRules parses claim validation rules in the 'claim:value' format.
+func ParseClaimValidationRules(values []string) ([]*ocm.ExternalAuthClaimValidationRule, error) {
+	rules := []*ocm.ExternalAuthClaimValidationRule{}
+	for _, value := range values {
+		parts := strings.SplitN(value, claimValidationRuleSplit, 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" || strings.TrimSpace(parts[1]) == "" {
+			return nil, fmt.Errorf("Invalid claim validation rule '%s', expected format is 'claim:value'", value)
+		}
+		rules = append(rules, &ocm.ExternalAuthClaimValidationRule{
+			Claim:         strings.TrimSpace(parts[0]),
+			RequiredValue: strings.TrimSpace(parts[1]),
+		})
+	}
+	return rules, nil
+}
+
+// FormatClaimValidationRules is the inverse of ParseClaimValidationRules.
+func FormatClaimValidationRules(rules []*ocm.ExternalAuthClaimValidationRule) []string {
+	values := []string{}
+	for _, rule := range rules {
+		values = append(values, rule.Claim+claimValidationRuleSplit+rule.RequiredValue)
+	}
+	return values
+}
+
+func splitList(value string) []string {
+	items := []string{}
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+var nameRE = regexp.MustCompile(`^[a-z]([-a-z0-9]*[a-z0-9])?$`)
+
+const maxNameLength = 15
+
+// ValidateName checks the name of an external authentication provider.
+func ValidateName(name string) error {
+	if !nameRE.MatchString(name) || len(name) > maxNameLength {
+		return fmt.Errorf("Name '%s' isn't valid: it must start with a lowercase letter, contain only "+
+			"lowercase letters, digits and '-', and be at most %d characters long", name, maxNameLength)
+	}
+	return nil
+}
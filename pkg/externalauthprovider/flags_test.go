@@ -0,0 +1,115 @@
+package externalauthprovider
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/pflag"
+
+	"github.com/openshift/rosa/pkg/ocm"
+)
+
+var _ = Describe("External authentication provider options", func() {
+	var flags *pflag.FlagSet
+	var args *Args
+
+	BeforeEach(func() {
+		flags = pflag.NewFlagSet("test", pflag.ContinueOnError)
+		args = AddFlags(flags)
+	})
+
+	It("Builds the provider from the options", func() {
+		Expect(flags.Parse([]string{
+			"--issuer-url", "https://login.example.com",
+			"--issuer-audiences", "a1,a2",
+			"--claim-mapping-groups-claim", "groups",
+			"--claim-validation-rule", "tenant:abc",
+			"--console-client-id", "console",
+			"--console-client-secret", "secret",
+			"--cli-client-id", "cli",
+		})).To(Succeed())
+		Expect(args.Validate(true)).To(Succeed())
+		externalAuth, err := args.Build("myprovider", true)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(externalAuth.ID).To(Equal("myprovider"))
+		Expect(externalAuth.Issuer.URL).To(Equal("https://login.example.com"))
+		Expect(externalAuth.Issuer.Audiences).To(ConsistOf("a1", "a2"))
+		Expect(externalAuth.Claim.Mappings.UserName.Claim).To(Equal("email"))
+		Expect(externalAuth.Claim.Mappings.Groups.Claim).To(Equal("groups"))
+		Expect(externalAuth.Claim.ValidationRules).To(HaveLen(1))
+		Expect(externalAuth.FindClient(ocm.ExternalAuthConsoleComponent).Secret).To(Equal("secret"))
+		Expect(externalAuth.FindClient(ocm.ExternalAuthCLIComponent).ID).To(Equal("cli"))
+	})
+
+	It("Only sends the clients when requested", func() {
+		Expect(flags.Parse([]string{
+			"--issuer-url", "https://login.example.com",
+			"--issuer-audiences", "a1",
+			"--console-client-id", "console",
+		})).To(Succeed())
+		Expect(args.Validate(false)).To(Succeed())
+		externalAuth, err := args.Build("myprovider", false)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(externalAuth.Clients).To(BeNil())
+	})
+
+	It("Keeps the existing values of the options that aren't given", func() {
+		Expect(flags.Parse([]string{"--issuer-audiences", "a3"})).To(Succeed())
+		args.SetDefaultsFrom(flags, &ocm.ExternalAuth{
+			ID: "myprovider",
+			Issuer: &ocm.ExternalAuthIssuer{
+				URL:       "https://login.example.com",
+				Audiences: []string{"a1", "a2"},
+			},
+			Claim: &ocm.ExternalAuthClaim{
+				Mappings: &ocm.ExternalAuthClaimMappings{
+					UserName: &ocm.ExternalAuthClaimMapping{Claim: "sub"},
+				},
+				ValidationRules: []*ocm.ExternalAuthClaimValidationRule{
+					{Claim: "tenant", RequiredValue: "abc"},
+				},
+			},
+			Clients: []*ocm.ExternalAuthClient{
+				ocm.NewExternalAuthClient(ocm.ExternalAuthConsoleComponent, "console", ""),
+			},
+		})
+		Expect(args.IssuerURL).To(Equal("https://login.example.com"))
+		Expect(args.IssuerAudiences).To(ConsistOf("a3"))
+		Expect(args.UsernameClaim).To(Equal("sub"))
+		Expect(args.ClaimValidationRules).To(ConsistOf("tenant:abc"))
+		Expect(args.ConsoleClientID).To(Equal("console"))
+		Expect(ClientsChanged(flags)).To(BeFalse())
+	})
+
+	DescribeTable("Validates the options",
+		func(options []string, message string) {
+			Expect(flags.Parse(options)).To(Succeed())
+			Expect(args.Validate(true)).To(MatchError(ContainSubstring(message)))
+		},
+		Entry("missing issuer", []string{"--issuer-audiences", "a1"}, "valid issuer URL"),
+		Entry("HTTP issuer", []string{"--issuer-url", "http://login.example.com", "--issuer-audiences", "a1"},
+			"must use HTTPS"),
+		Entry("missing audiences", []string{"--issuer-url", "https://login.example.com"}, "audience"),
+		Entry("too many audiences", []string{"--issuer-url", "https://login.example.com",
+			"--issuer-audiences", "1,2,3,4,5,6,7,8,9,10,11"}, "At most 10"),
+		Entry("empty username claim", []string{"--issuer-url", "https://login.example.com",
+			"--issuer-audiences", "a1", "--claim-mapping-username-claim", ""}, "Username claim"),
+		Entry("invalid rule", []string{"--issuer-url", "https://login.example.com",
+			"--issuer-audiences", "a1", "--claim-validation-rule", "tenant"}, "claim:value"),
+		Entry("console client without secret", []string{"--issuer-url", "https://login.example.com",
+			"--issuer-audiences", "a1", "--console-client-id", "console"}, "requires a secret"),
+	)
+
+	DescribeTable("Validates names",
+		func(name string, valid bool) {
+			if valid {
+				Expect(ValidateName(name)).To(Succeed())
+			} else {
+				Expect(ValidateName(name)).ToNot(Succeed())
+			}
+		},
+		Entry("valid", "my-provider", true),
+		Entry("uppercase", "MyProvider", false),
+		Entry("leading digit", "1provider", false),
+		Entry("too long", "provider-with-a-long-name", false),
+	)
+})
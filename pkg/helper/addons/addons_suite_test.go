@@ -0,0 +1,13 @@
+package addons
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestAddons(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Addons Suite")
+}
@@ -0,0 +1,124 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the selection of the billing of the add-on installations, shared by the
+// 'rosa install addon' and 'rosa edit addon' commands.
+
+package addons
+
+import (
+	"fmt"
+
+	amv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/openshift/rosa/pkg/helper"
+	"github.com/openshift/rosa/pkg/interactive"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+const (
+	BillingModelFlag          = "billing-model"
+	BillingModelAccountIDFlag = "billing-model-account-id"
+)
+
+// GetBilling returns the billing of an add-on installation. The billing model is selected among the
+// ones the organization has quota for, asking for it and for the account to bill when interactive
+// mode is enabled and they weren't given as flags. When editing an installation its current billing
+// model is given, as the quota it already consumes can be reused.
+func GetBilling(r *rosa.Runtime, cmd *cobra.Command, addOn *cmv1.AddOn, billing ocm.AddOnBilling,
+	installedBillingModel string) (ocm.AddOnBilling, error) {
+	models, err := r.OCMClient.GetAddOnBillingModels(addOn, installedBillingModel)
+	if err != nil {
+		return billing, fmt.Errorf("Failed to get billing models of add-on '%s': %v", addOn.ID(), err)
+	}
+	return selectBilling(cmd.Flags(), addOn.ID(), models, billing, func() []string {
+		return getBillingAccountOptions(r)
+	})
+}
+
+// selectBilling selects the billing among the given billing models. The accounts function returns
+// the accounts that can be chosen for the AWS marketplace billing model.
+func selectBilling(flags *pflag.FlagSet, addOnID string, models []string, billing ocm.AddOnBilling,
+	accounts func() []string) (ocm.AddOnBilling, error) {
+	var err error
+	if len(models) == 0 {
+		return billing, fmt.Errorf("The organization has no quota to bill add-on '%s'", addOnID)
+	}
+
+	if interactive.Enabled() && !flags.Changed(BillingModelFlag) {
+		dflt := billing.BillingModel
+		if !helper.Contains(models, dflt) {
+			dflt = models[0]
+		}
+		billing.BillingModel, err = interactive.GetOption(interactive.Input{
+			Question: "Billing Model",
+			Help:     flags.Lookup(BillingModelFlag).Usage,
+			Default:  dflt,
+			Options:  models,
+			Required: true,
+		})
+		if err != nil {
+			return billing, fmt.Errorf("Expected a valid billing model: %s", err)
+		}
+	}
+
+	if billing.BillingModel == string(amv1.BillingModelStandard) {
+		billing.BillingAccountID = ""
+	} else if !flags.Changed(BillingModelAccountIDFlag) &&
+		(interactive.Enabled() || billing.BillingAccountID == "") {
+		input := interactive.Input{
+			Question: "Billing Account ID",
+			Help:     flags.Lookup(BillingModelAccountIDFlag).Usage,
+			Default:  billing.BillingAccountID,
+			Required: true,
+		}
+		if billing.BillingModel == string(amv1.BillingModelMarketplaceAWS) {
+			input.Options = accounts()
+		}
+		if len(input.Options) > 0 {
+			if !helper.Contains(input.Options, billing.BillingAccountID) {
+				input.Default = input.Options[0]
+			}
+			billing.BillingAccountID, err = interactive.GetOption(input)
+		} else {
+			billing.BillingAccountID, err = interactive.GetString(input)
+		}
+		if err != nil {
+			return billing, fmt.Errorf("Expected a valid account id: %s", err)
+		}
+	}
+
+	return billing, ocm.ValidateAddOnBilling(billing, models)
+}
+
+// getBillingAccountOptions returns the AWS accounts linked to the organization, so that the user can
+// choose one of them instead of typing it. The user can still type the account if this fails.
+func getBillingAccountOptions(r *rosa.Runtime) []string {
+	accounts, err := r.OCMClient.GetBillingAccounts()
+	if err != nil {
+		r.Reporter.Debugf("Failed to get billing accounts: %v", err)
+		return nil
+	}
+	options := []string{}
+	for _, account := range accounts {
+		options = append(options, account.CloudAccountID)
+	}
+	return options
+}
@@ -0,0 +1,57 @@
+package addons
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/pflag"
+
+	"github.com/openshift/rosa/pkg/ocm"
+)
+
+var _ = Describe("Select billing", func() {
+	var flags *pflag.FlagSet
+	noAccounts := func() []string { return nil }
+
+	BeforeEach(func() {
+		flags = pflag.NewFlagSet("addon", pflag.ContinueOnError)
+		flags.String(BillingModelFlag, "standard", "")
+		flags.String(BillingModelAccountIDFlag, "", "")
+	})
+
+	It("fails when the organization has no quota", func() {
+		_, err := selectBilling(flags, "rhoam", nil, ocm.AddOnBilling{BillingModel: "standard"}, noAccounts)
+		Expect(err).To(MatchError(ContainSubstring("has no quota")))
+	})
+
+	It("clears the account of the standard billing model", func() {
+		billing, err := selectBilling(flags, "rhoam", []string{"standard"}, ocm.AddOnBilling{
+			BillingModel:     "standard",
+			BillingAccountID: "123456789012",
+		}, noAccounts)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(billing).To(Equal(ocm.AddOnBilling{BillingModel: "standard"}))
+	})
+
+	It("keeps the account given for the AWS marketplace billing model", func() {
+		Expect(flags.Set(BillingModelFlag, "marketplace-aws")).To(Succeed())
+		Expect(flags.Set(BillingModelAccountIDFlag, "123456789012")).To(Succeed())
+		requested := ocm.AddOnBilling{
+			BillingModel:     "marketplace-aws",
+			BillingAccountID: "123456789012",
+		}
+		billing, err := selectBilling(flags, "rhoam", []string{"standard", "marketplace-aws"}, requested,
+			noAccounts)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(billing).To(Equal(requested))
+	})
+
+	It("fails when the billing model isn't available", func() {
+		Expect(flags.Set(BillingModelFlag, "marketplace-aws")).To(Succeed())
+		Expect(flags.Set(BillingModelAccountIDFlag, "123456789012")).To(Succeed())
+		_, err := selectBilling(flags, "rhoam", []string{"standard"}, ocm.AddOnBilling{
+			BillingModel:     "marketplace-aws",
+			BillingAccountID: "123456789012",
+		}, noAccounts)
+		Expect(err).To(MatchError(ContainSubstring("isn't available for the add-on")))
+	})
+})
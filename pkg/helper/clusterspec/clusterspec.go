@@ -0,0 +1,262 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the types and functions used to implement the '--spec-file' command line
+// option, which allows describing a cluster in a single YAML or JSON document.
+
+package clusterspec
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/spf13/pflag"
+)
+
+const FlagName = "spec-file"
+
+// File is the declarative description of a cluster. Every field maps to one of the command line
+// options of 'rosa create cluster', so that a spec file and flags can be freely combined.
+type File struct {
+	Name         string `json:"name,omitempty"`
+	Region       string `json:"region,omitempty"`
+	Version      string `json:"version,omitempty"`
+	ChannelGroup string `json:"channel_group,omitempty"`
+	MultiAZ      *bool  `json:"multi_az,omitempty"`
+	HostedCP     *bool  `json:"hosted_cp,omitempty"`
+
+	FIPS                 *bool  `json:"fips,omitempty"`
+	EtcdEncryption       *bool  `json:"etcd_encryption,omitempty"`
+	EtcdEncryptionKMSArn string `json:"etcd_encryption_kms_arn,omitempty"`
+	KMSKeyArn            string `json:"kms_key_arn,omitempty"`
+
+	DisableWorkloadMonitoring *bool             `json:"disable_workload_monitoring,omitempty"`
+	DisableSCPChecks          *bool             `json:"disable_scp_checks,omitempty"`
+	Tags                      map[string]string `json:"tags,omitempty"`
+
+	IAM         *IAM         `json:"iam,omitempty"`
+	Network     *Network     `json:"network,omitempty"`
+	Proxy       *Proxy       `json:"proxy,omitempty"`
+	MachinePool *MachinePool `json:"machine_pool,omitempty"`
+}
+
+// IAM contains the account and operator role settings of an STS cluster.
+type IAM struct {
+	STS                 *bool  `json:"sts,omitempty"`
+	Mode                string `json:"mode,omitempty"`
+	RoleARN             string `json:"role_arn,omitempty"`
+	ExternalID          string `json:"external_id,omitempty"`
+	SupportRoleARN      string `json:"support_role_arn,omitempty"`
+	ControlPlaneRoleARN string `json:"controlplane_role_arn,omitempty"`
+	WorkerRoleARN       string `json:"worker_role_arn,omitempty"`
+	OperatorRolesPrefix string `json:"operator_roles_prefix,omitempty"`
+	OidcConfigID        string `json:"oidc_config_id,omitempty"`
+	PermissionsBoundary string `json:"permissions_boundary,omitempty"`
+}
+
+// Network contains the VPC and networking settings of the cluster.
+type Network struct {
+	Private           *bool    `json:"private,omitempty"`
+	PrivateLink       *bool    `json:"private_link,omitempty"`
+	SubnetIDs         []string `json:"subnet_ids,omitempty"`
+	AvailabilityZones []string `json:"availability_zones,omitempty"`
+	Type              string   `json:"type,omitempty"`
+	MachineCIDR       string   `json:"machine_cidr,omitempty"`
+	ServiceCIDR       string   `json:"service_cidr,omitempty"`
+	PodCIDR           string   `json:"pod_cidr,omitempty"`
+	HostPrefix        int      `json:"host_prefix,omitempty"`
+}
+
+// Proxy contains the cluster-wide proxy settings.
+type Proxy struct {
+	HTTPProxy                 string   `json:"http_proxy,omitempty"`
+	HTTPSProxy                string   `json:"https_proxy,omitempty"`
+	NoProxy                   []string `json:"no_proxy,omitempty"`
+	AdditionalTrustBundleFile string   `json:"additional_trust_bundle_file,omitempty"`
+}
+
+// MachinePool contains the settings of the default machine pool of the cluster.
+type MachinePool struct {
+	InstanceType string            `json:"instance_type,omitempty"`
+	Replicas     *int              `json:"replicas,omitempty"`
+	Autoscaling  *Autoscaling      `json:"autoscaling,omitempty"`
+	Labels       map[string]string `json:"labels,omitempty"`
+}
+
+type Autoscaling struct {
+	MinReplicas int `json:"min_replicas,omitempty"`
+	MaxReplicas int `json:"max_replicas,omitempty"`
+}
+
+// AddFlag adds the '--spec-file' flag to the given set of command line flags.
+func AddFlag(flags *pflag.FlagSet, value *string) {
+	flags.StringVar(
+		value,
+		FlagName,
+		"",
+		"Path to a YAML or JSON file describing the cluster. Values given on the command line "+
+			"take precedence over the ones in the file.",
+	)
+}
+
+// Load reads and parses the spec file located at the given path.
+func Load(path string) (*File, error) {
+	// #nosec G304
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read spec file '%s': %v", path, err)
+	}
+	// Convert to JSON first so that unknown fields can be rejected, as they are most likely typos:
+	data, err = yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse spec file '%s': %v", path, err)
+	}
+	spec := new(File)
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	err = decoder.Decode(spec)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse spec file '%s': %v", path, err)
+	}
+	return spec, nil
+}
+
+// FlagValues returns the values of the spec file indexed by the name of the command line option
+// that they correspond to. Fields that aren't set in the file are not included.
+func (f *File) FlagValues() map[string]string {
+	values := map[string]string{}
+	setString := func(name, value string) {
+		if value != "" {
+			values[name] = value
+		}
+	}
+	setBool := func(name string, value *bool) {
+		if value != nil {
+			values[name] = strconv.FormatBool(*value)
+		}
+	}
+	setInt := func(name string, value int) {
+		if value != 0 {
+			values[name] = strconv.Itoa(value)
+		}
+	}
+
+	setString("cluster-name", f.Name)
+	setString("region", f.Region)
+	setString("version", f.Version)
+	setString("channel-group", f.ChannelGroup)
+	setBool("multi-az", f.MultiAZ)
+	setBool("hosted-cp", f.HostedCP)
+	setBool("fips", f.FIPS)
+	setBool("etcd-encryption", f.EtcdEncryption)
+	setString("etcd-encryption-kms-arn", f.EtcdEncryptionKMSArn)
+	setString("kms-key-arn", f.KMSKeyArn)
+	setBool("disable-workload-monitoring", f.DisableWorkloadMonitoring)
+	setBool("disable-scp-checks", f.DisableSCPChecks)
+	setString("tags", joinMap(f.Tags, ":"))
+
+	if f.IAM != nil {
+		setBool("sts", f.IAM.STS)
+		setString("mode", f.IAM.Mode)
+		setString("role-arn", f.IAM.RoleARN)
+		setString("external-id", f.IAM.ExternalID)
+		setString("support-role-arn", f.IAM.SupportRoleARN)
+		setString("controlplane-iam-role", f.IAM.ControlPlaneRoleARN)
+		setString("worker-iam-role", f.IAM.WorkerRoleARN)
+		setString("operator-roles-prefix", f.IAM.OperatorRolesPrefix)
+		setString("oidc-config-id", f.IAM.OidcConfigID)
+		setString("permissions-boundary", f.IAM.PermissionsBoundary)
+	}
+
+	if f.Network != nil {
+		setBool("private", f.Network.Private)
+		setBool("private-link", f.Network.PrivateLink)
+		setString("subnet-ids", strings.Join(f.Network.SubnetIDs, ","))
+		setString("availability-zones", strings.Join(f.Network.AvailabilityZones, ","))
+		setString("network-type", f.Network.Type)
+		setString("machine-cidr", f.Network.MachineCIDR)
+		setString("service-cidr", f.Network.ServiceCIDR)
+		setString("pod-cidr", f.Network.PodCIDR)
+		setInt("host-prefix", f.Network.HostPrefix)
+	}
+
+	if f.Proxy != nil {
+		setString("http-proxy", f.Proxy.HTTPProxy)
+		setString("https-proxy", f.Proxy.HTTPSProxy)
+		setString("no-proxy", strings.Join(f.Proxy.NoProxy, ","))
+		setString("additional-trust-bundle-file", f.Proxy.AdditionalTrustBundleFile)
+	}
+
+	if f.MachinePool != nil {
+		setString("compute-machine-type", f.MachinePool.InstanceType)
+		if f.MachinePool.Replicas != nil {
+			values["replicas"] = strconv.Itoa(*f.MachinePool.Replicas)
+		}
+		if f.MachinePool.Autoscaling != nil {
+			values["enable-autoscaling"] = "true"
+			setInt("min-replicas", f.MachinePool.Autoscaling.MinReplicas)
+			setInt("max-replicas", f.MachinePool.Autoscaling.MaxReplicas)
+		}
+		setString("default-mp-labels", joinMap(f.MachinePool.Labels, "="))
+	}
+
+	return values
+}
+
+// Apply sets the command line options of the given flag set from the values of the spec file.
+// Options that were explicitly set by the user are left untouched, and so are the values that
+// don't correspond to an option of the command.
+func (f *File) Apply(flags *pflag.FlagSet) error {
+	return ApplyValues(flags, f.FlagValues())
+}
+
+// ApplyValues sets the command line options of the given flag set from the given values, indexed
+// by option name, following the same rules as Apply.
+func ApplyValues(flags *pflag.FlagSet, values map[string]string) error {
+	for name, value := range values {
+		flag := flags.Lookup(name)
+		if flag == nil || flag.Changed {
+			continue
+		}
+		err := flags.Set(name, value)
+		if err != nil {
+			return fmt.Errorf("Invalid value '%s' for '%s' in spec file: %v", value, name, err)
+		}
+	}
+	return nil
+}
+
+func joinMap(values map[string]string, separator string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, fmt.Sprintf("%s%s%s", key, separator, values[key]))
+	}
+	return strings.Join(pairs, ",")
+}
@@ -0,0 +1,13 @@
+package clusterspec
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestClusterSpec(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "ClusterSpec Suite")
+}
@@ -0,0 +1,116 @@
+package clusterspec
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/pflag"
+)
+
+var _ = Describe("Cluster spec file", func() {
+	var dir string
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+	})
+
+	write := func(content string) string {
+		path := filepath.Join(dir, "cluster.yaml")
+		Expect(os.WriteFile(path, []byte(content), 0600)).To(Succeed())
+		return path
+	}
+
+	It("Loads a YAML document", func() {
+		spec, err := Load(write(`
+name: mycluster
+region: us-east-2
+multi_az: true
+tags:
+  team: sre
+  env: prod
+iam:
+  sts: true
+  role_arn: arn:aws:iam::123456789012:role/ManagedOpenShift-Installer-Role
+network:
+  subnet_ids: [subnet-1, subnet-2]
+  host_prefix: 23
+machine_pool:
+  instance_type: m5.xlarge
+  autoscaling:
+    min_replicas: 3
+    max_replicas: 6
+  labels:
+    foo: bar
+`))
+		Expect(err).ToNot(HaveOccurred())
+		values := spec.FlagValues()
+		Expect(values).To(HaveKeyWithValue("cluster-name", "mycluster"))
+		Expect(values).To(HaveKeyWithValue("multi-az", "true"))
+		Expect(values).To(HaveKeyWithValue("tags", "env:prod,team:sre"))
+		Expect(values).To(HaveKeyWithValue("sts", "true"))
+		Expect(values).To(HaveKeyWithValue("subnet-ids", "subnet-1,subnet-2"))
+		Expect(values).To(HaveKeyWithValue("host-prefix", "23"))
+		Expect(values).To(HaveKeyWithValue("enable-autoscaling", "true"))
+		Expect(values).To(HaveKeyWithValue("max-replicas", "6"))
+		Expect(values).To(HaveKeyWithValue("default-mp-labels", "foo=bar"))
+		Expect(values).ToNot(HaveKey("replicas"))
+	})
+
+	It("Rejects unknown fields", func() {
+		_, err := Load(write("name: mycluster\nregoin: us-east-2\n"))
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("regoin"))
+	})
+
+	It("Does not override options given on the command line", func() {
+		var name, region string
+		var multiAZ bool
+		flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		flags.StringVar(&name, "cluster-name", "", "")
+		flags.StringVar(&region, "region", "", "")
+		flags.BoolVar(&multiAZ, "multi-az", false, "")
+		Expect(flags.Parse([]string{"--region", "eu-west-1"})).To(Succeed())
+
+		spec, err := Load(write("name: mycluster\nregion: us-east-2\nmulti_az: true\nversion: 4.12.1\n"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(spec.Apply(flags)).To(Succeed())
+		Expect(name).To(Equal("mycluster"))
+		Expect(region).To(Equal("eu-west-1"))
+		Expect(multiAZ).To(BeTrue())
+		Expect(flags.Changed("multi-az")).To(BeTrue())
+	})
+
+	It("Computes the differences with an existing cluster", func() {
+		cluster, err := cmv1.NewCluster().
+			Name("mycluster").
+			Region(cmv1.NewCloudRegion().ID("us-east-2")).
+			API(cmv1.NewClusterAPI().Listening(cmv1.ListeningMethodExternal)).
+			AWS(cmv1.NewAWS().SubnetIDs("subnet-1", "subnet-2")).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+
+		var private bool
+		var region string
+		flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		flags.BoolVar(&private, "private", false, "")
+		// The region is a global flag, so it is present in the flag set of 'rosa edit cluster':
+		flags.StringVar(&region, "region", "", "")
+
+		spec, err := Load(write(`
+name: mycluster
+region: eu-west-1
+network:
+  private: true
+  subnet_ids: [subnet-2, subnet-1]
+`))
+		Expect(err).ToNot(HaveOccurred())
+		changes := spec.Diff(cluster, flags)
+		Expect(changes).To(Equal([]Change{
+			{Option: "private", Current: "false", Desired: "true", Mutable: true},
+			{Option: "region", Current: "us-east-2", Desired: "eu-west-1", Mutable: false},
+		}))
+	})
+})
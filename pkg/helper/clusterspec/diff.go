@@ -0,0 +1,140 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions used to compare a spec file with an existing cluster.
+
+package clusterspec
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/pflag"
+)
+
+// Options whose values are unordered comma-separated lists.
+var listOptions = map[string]bool{
+	"tags":               true,
+	"subnet-ids":         true,
+	"availability-zones": true,
+	"no-proxy":           true,
+	"default-mp-labels":  true,
+}
+
+// Options that can't be modified on an existing cluster even if the flag set contains them, for
+// example because they are global flags of the command.
+var immutableOptions = map[string]bool{
+	"region": true,
+}
+
+// Change describes an option whose value in the spec file differs from the one of the cluster.
+type Change struct {
+	Option  string
+	Current string
+	Desired string
+	// Mutable indicates that the option can be modified on an existing cluster.
+	Mutable bool
+}
+
+// Diff compares the spec file with the given cluster and returns the options that differ, sorted
+// by name. Options are considered mutable when the given flag set, which is usually the one of
+// 'rosa edit cluster', contains them and they aren't in the list of immutable options. Options
+// whose current value can't be determined from the cluster are ignored.
+func (f *File) Diff(cluster *cmv1.Cluster, flags *pflag.FlagSet) []Change {
+	current := CurrentValues(cluster)
+	changes := []Change{}
+	for option, desired := range f.FlagValues() {
+		value, ok := current[option]
+		if !ok || normalize(option, value) == normalize(option, desired) {
+			continue
+		}
+		changes = append(changes, Change{
+			Option:  option,
+			Current: value,
+			Desired: desired,
+			Mutable: !immutableOptions[option] && flags.Lookup(option) != nil,
+		})
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Option < changes[j].Option
+	})
+	return changes
+}
+
+// CurrentValues returns the values of the given cluster indexed by the name of the command line
+// option that they correspond to.
+func CurrentValues(cluster *cmv1.Cluster) map[string]string {
+	values := map[string]string{
+		"cluster-name":                cluster.Name(),
+		"region":                      cluster.Region().ID(),
+		"version":                     cluster.Version().RawID(),
+		"channel-group":               cluster.Version().ChannelGroup(),
+		"multi-az":                    strconv.FormatBool(cluster.MultiAZ()),
+		"hosted-cp":                   strconv.FormatBool(cluster.Hypershift().Enabled()),
+		"fips":                        strconv.FormatBool(cluster.FIPS()),
+		"etcd-encryption":             strconv.FormatBool(cluster.EtcdEncryption()),
+		"etcd-encryption-kms-arn":     cluster.AWS().EtcdEncryption().KMSKeyARN(),
+		"kms-key-arn":                 cluster.AWS().KMSKeyArn(),
+		"disable-workload-monitoring": strconv.FormatBool(cluster.DisableUserWorkloadMonitoring()),
+		"tags":                        joinMap(cluster.AWS().Tags(), ":"),
+		"sts":                         strconv.FormatBool(cluster.AWS().STS().RoleARN() != ""),
+		"role-arn":                    cluster.AWS().STS().RoleARN(),
+		"external-id":                 cluster.AWS().STS().ExternalID(),
+		"support-role-arn":            cluster.AWS().STS().SupportRoleARN(),
+		"controlplane-iam-role":       cluster.AWS().STS().InstanceIAMRoles().MasterRoleARN(),
+		"worker-iam-role":             cluster.AWS().STS().InstanceIAMRoles().WorkerRoleARN(),
+		"oidc-config-id":              cluster.AWS().STS().OidcConfig().ID(),
+		"private":                     strconv.FormatBool(cluster.API().Listening() == cmv1.ListeningMethodInternal),
+		"private-link":                strconv.FormatBool(cluster.AWS().PrivateLink()),
+		"subnet-ids":                  strings.Join(cluster.AWS().SubnetIDs(), ","),
+		"availability-zones":          strings.Join(cluster.Nodes().AvailabilityZones(), ","),
+		"network-type":                cluster.Network().Type(),
+		"machine-cidr":                cluster.Network().MachineCIDR(),
+		"service-cidr":                cluster.Network().ServiceCIDR(),
+		"pod-cidr":                    cluster.Network().PodCIDR(),
+		"host-prefix":                 strconv.Itoa(cluster.Network().HostPrefix()),
+		"http-proxy":                  cluster.Proxy().HTTPProxy(),
+		"https-proxy":                 cluster.Proxy().HTTPSProxy(),
+		"no-proxy":                    cluster.Proxy().NoProxy(),
+		"compute-machine-type":        cluster.Nodes().ComputeMachineType().ID(),
+		"default-mp-labels":           joinMap(cluster.Nodes().ComputeLabels(), "="),
+	}
+	// The contents of the trust bundle are never returned, so any file given is a change:
+	values["additional-trust-bundle-file"] = cluster.AdditionalTrustBundle()
+	if autoscaling, ok := cluster.Nodes().GetAutoscaleCompute(); ok {
+		values["enable-autoscaling"] = "true"
+		values["min-replicas"] = strconv.Itoa(autoscaling.MinReplicas())
+		values["max-replicas"] = strconv.Itoa(autoscaling.MaxReplicas())
+	} else {
+		values["enable-autoscaling"] = "false"
+		values["replicas"] = strconv.Itoa(cluster.Nodes().Compute())
+	}
+	return values
+}
+
+func normalize(option string, value string) string {
+	if !listOptions[option] {
+		return value
+	}
+	items := strings.Split(value, ",")
+	for i := range items {
+		items[i] = strings.TrimSpace(items[i])
+	}
+	sort.Strings(items)
+	return strings.Join(items, ",")
+}
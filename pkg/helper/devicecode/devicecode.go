@@ -0,0 +1,179 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains an implementation of the OAuth 2.0 device authorization grant (RFC 8628),
+// used to log in from hosts that don't have a browser.
+
+package devicecode
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultClientID is the SSO client that is allowed to use the device authorization grant.
+	DefaultClientID = "ocm-cli"
+
+	grantType       = "urn:ietf:params:oauth:grant-type:device_code"
+	defaultInterval = 5 * time.Second
+)
+
+// Flow contains the settings used to request the authorization of a device.
+type Flow struct {
+	// TokenURL is the URL of the token endpoint of the SSO server. The device authorization
+	// endpoint is derived from it.
+	TokenURL string
+	ClientID string
+	Scopes   []string
+	Client   *http.Client
+
+	// sleep waits between polls of the token endpoint, it is replaced in tests.
+	sleep func(context.Context, time.Duration) error
+}
+
+// Authorization is the response of the device authorization endpoint.
+type Authorization struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval"`
+}
+
+type tokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	RefreshToken     string `json:"refresh_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// DeviceAuthorizationURL returns the URL of the device authorization endpoint that corresponds to
+// the given token endpoint.
+func DeviceAuthorizationURL(tokenURL string) string {
+	return strings.TrimSuffix(tokenURL, "/token") + "/auth/device"
+}
+
+// Authorize requests a user code that the user needs to enter at the verification URI.
+func (f *Flow) Authorize(ctx context.Context) (*Authorization, error) {
+	form := url.Values{
+		"client_id": {f.ClientID},
+		"scope":     {strings.Join(f.Scopes, " ")},
+	}
+	authorization := new(Authorization)
+	response, err := f.post(ctx, DeviceAuthorizationURL(f.TokenURL), form, authorization)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to request device authorization: %v", err)
+	}
+	if response.StatusCode != http.StatusOK || authorization.DeviceCode == "" {
+		return nil, fmt.Errorf("Failed to request device authorization: unexpected status '%s'",
+			response.Status)
+	}
+	return authorization, nil
+}
+
+// Wait polls the token endpoint until the user approves or denies the authorization, or until it
+// expires. It returns the access and refresh tokens issued to the device.
+func (f *Flow) Wait(ctx context.Context, authorization *Authorization) (access, refresh string, err error) {
+	if authorization.ExpiresIn > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(authorization.ExpiresIn)*time.Second)
+		defer cancel()
+	}
+	interval := time.Duration(authorization.Interval) * time.Second
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+	sleep := f.sleep
+	if sleep == nil {
+		sleep = sleepContext
+	}
+	form := url.Values{
+		"grant_type":  {grantType},
+		"client_id":   {f.ClientID},
+		"device_code": {authorization.DeviceCode},
+	}
+	for {
+		err = sleep(ctx, interval)
+		if err != nil {
+			return "", "", fmt.Errorf("The device authorization expired before it was approved")
+		}
+		tokens := new(tokenResponse)
+		_, err = f.post(ctx, f.TokenURL, form, tokens)
+		if err != nil {
+			return "", "", fmt.Errorf("Failed to request tokens: %v", err)
+		}
+		switch tokens.Error {
+		case "":
+			if tokens.AccessToken == "" {
+				return "", "", fmt.Errorf("Failed to request tokens: the response has no access token")
+			}
+			return tokens.AccessToken, tokens.RefreshToken, nil
+		case "authorization_pending":
+		case "slow_down":
+			interval += defaultInterval
+		case "access_denied":
+			return "", "", fmt.Errorf("The device authorization was denied")
+		case "expired_token":
+			return "", "", fmt.Errorf("The device authorization expired before it was approved")
+		default:
+			return "", "", fmt.Errorf("Failed to request tokens: %s: %s", tokens.Error,
+				tokens.ErrorDescription)
+		}
+	}
+}
+
+func (f *Flow) post(ctx context.Context, address string, form url.Values,
+	body interface{}) (*http.Response, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, address,
+		strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.Header.Set("Accept", "application/json")
+	client := f.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	err = json.NewDecoder(response.Body).Decode(body)
+	if err != nil {
+		return response, fmt.Errorf("unexpected response with status '%s': %v", response.Status, err)
+	}
+	return response, nil
+}
+
+func sleepContext(ctx context.Context, duration time.Duration) error {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,96 @@
+package devicecode
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Device code flow", func() {
+	var server *httptest.Server
+	var responses []map[string]string
+	var flow *Flow
+
+	BeforeEach(func() {
+		responses = nil
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			Expect(r.ParseForm()).To(Succeed())
+			Expect(r.Form.Get("client_id")).To(Equal("test-client"))
+			switch r.URL.Path {
+			case "/protocol/openid-connect/auth/device":
+				Expect(r.Form.Get("scope")).To(Equal("openid offline_access"))
+				json.NewEncoder(w).Encode(Authorization{
+					DeviceCode:      "device",
+					UserCode:        "ABCD-EFGH",
+					VerificationURI: "https://sso.example.com/device",
+					ExpiresIn:       600,
+					Interval:        5,
+				})
+			case "/protocol/openid-connect/token":
+				Expect(r.Form.Get("grant_type")).To(Equal(grantType))
+				Expect(r.Form.Get("device_code")).To(Equal("device"))
+				Expect(responses).ToNot(BeEmpty())
+				response := responses[0]
+				responses = responses[1:]
+				if response["error"] != "" {
+					w.WriteHeader(http.StatusBadRequest)
+				}
+				json.NewEncoder(w).Encode(response)
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		flow = &Flow{
+			TokenURL: server.URL + "/protocol/openid-connect/token",
+			ClientID: "test-client",
+			Scopes:   []string{"openid", "offline_access"},
+			sleep: func(ctx context.Context, _ time.Duration) error {
+				return ctx.Err()
+			},
+		}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("derives the device authorization endpoint from the token endpoint", func() {
+		Expect(DeviceAuthorizationURL("https://sso.redhat.com/auth/realms/redhat-external/" +
+			"protocol/openid-connect/token")).To(Equal("https://sso.redhat.com/auth/realms/" +
+			"redhat-external/protocol/openid-connect/auth/device"))
+	})
+
+	It("returns the tokens once the user approves the device", func() {
+		authorization, err := flow.Authorize(context.Background())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(authorization.UserCode).To(Equal("ABCD-EFGH"))
+
+		responses = []map[string]string{
+			{"error": "authorization_pending"},
+			{"error": "slow_down"},
+			{"access_token": "access", "refresh_token": "refresh"},
+		}
+		access, refresh, err := flow.Wait(context.Background(), authorization)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(access).To(Equal("access"))
+		Expect(refresh).To(Equal("refresh"))
+		Expect(responses).To(BeEmpty())
+	})
+
+	It("fails when the user denies the device", func() {
+		responses = []map[string]string{{"error": "access_denied"}}
+		_, _, err := flow.Wait(context.Background(), &Authorization{DeviceCode: "device"})
+		Expect(err).To(MatchError("The device authorization was denied"))
+	})
+
+	It("fails when the authorization expires", func() {
+		responses = []map[string]string{{"error": "expired_token"}}
+		_, _, err := flow.Wait(context.Background(), &Authorization{DeviceCode: "device"})
+		Expect(err).To(MatchError(ContainSubstring("expired")))
+	})
+})
@@ -0,0 +1,170 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions used to verify the files downloaded from the mirror with the
+// checksums and signatures published next to them.
+
+package helper
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	checksumsFile = "sha256sum.txt"
+	signatureFile = "sha256sum.txt.sig"
+
+	// RedHatReleaseKeyURL is the location of the public Red Hat release key used to sign the
+	// checksums of the mirror.
+	RedHatReleaseKeyURL = "https://access.redhat.com/security/data/fd431d51.txt"
+
+	// RedHatReleaseKeyFingerprint is the pinned fingerprint of the Red Hat release key. The key
+	// downloaded from RedHatReleaseKeyURL is only trusted when it has this fingerprint.
+	RedHatReleaseKeyFingerprint = "567E347AD0044ADE55BA8A5F199E2F91FD431D51"
+)
+
+// GetChecksums returns the content of the checksums file of the given folder of the mirror.
+func GetChecksums(folder string) (string, error) {
+	data, err := get(folder + checksumsFile)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// GetChecksumsSignature returns the detached signature of the checksums file of the given folder of
+// the mirror.
+func GetChecksumsSignature(folder string) ([]byte, error) {
+	return get(folder + signatureFile)
+}
+
+// FindChecksum finds the sum of the given file in the content of a checksums file.
+func FindChecksum(data string, filename string) (string, error) {
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == filename {
+			return fields[0], nil
+		}
+	}
+	return "", fmt.Errorf("Failed to find the checksum of '%s' in the mirror", filename)
+}
+
+// VerifyChecksum checks that the SHA-256 sum of the file is the expected one.
+func VerifyChecksum(filename string, expected string) error {
+	file, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	hash := sha256.New()
+	if _, err = io.Copy(hash, file); err != nil {
+		return err
+	}
+	actual := hex.EncodeToString(hash.Sum(nil))
+	if !strings.EqualFold(actual, expected) {
+		return fmt.Errorf("Checksum of '%s' is '%s' but expected '%s'", filename, actual, expected)
+	}
+	return nil
+}
+
+// VerifySignature checks with 'gpg' that the detached signature of the checksums was made with the
+// Red Hat release key. The key is imported into a temporary keyring, so that neither the keys of
+// the user nor their trust settings are used, and the signature is only accepted when it was made
+// with the key that has the pinned fingerprint.
+func VerifySignature(checksums string, signature []byte) error {
+	gpg, err := exec.LookPath("gpg")
+	if err != nil {
+		return fmt.Errorf("Failed to find 'gpg' to verify the signature of the checksums: %v", err)
+	}
+	key, err := get(RedHatReleaseKeyURL)
+	if err != nil {
+		return fmt.Errorf("Failed to get the Red Hat release key: %v", err)
+	}
+	dir, err := os.MkdirTemp("", "rosa-signature")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+	home := filepath.Join(dir, "gnupg")
+	if err = os.Mkdir(home, 0700); err != nil {
+		return err
+	}
+	keyPath := filepath.Join(dir, "release.key")
+	dataPath := filepath.Join(dir, checksumsFile)
+	signaturePath := filepath.Join(dir, signatureFile)
+	if err = os.WriteFile(keyPath, key, 0600); err != nil {
+		return err
+	}
+	if err = os.WriteFile(dataPath, []byte(checksums), 0600); err != nil {
+		return err
+	}
+	if err = os.WriteFile(signaturePath, signature, 0600); err != nil {
+		return err
+	}
+	// nolint:gosec
+	output, err := exec.Command(gpg, "--homedir", home, "--batch", "--import", keyPath).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("Failed to import the Red Hat release key: %s", strings.TrimSpace(string(output)))
+	}
+	// nolint:gosec
+	status, err := exec.Command(gpg, "--homedir", home, "--batch", "--status-fd", "1",
+		"--verify", signaturePath, dataPath).Output()
+	if err != nil {
+		return fmt.Errorf("Failed to verify the signature of the checksums with the Red Hat release key")
+	}
+	return checkSignatureStatus(string(status), RedHatReleaseKeyFingerprint)
+}
+
+// checkSignatureStatus checks that the machine readable status of 'gpg --verify' reports a valid
+// signature made with the key that has the given fingerprint.
+func checkSignatureStatus(status string, fingerprint string) error {
+	scanner := bufio.NewScanner(strings.NewReader(status))
+	for scanner.Scan() {
+		// The fingerprint of the primary key is the last field of the 'VALIDSIG' line:
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 3 || fields[0] != "[GNUPG:]" || fields[1] != "VALIDSIG" {
+			continue
+		}
+		if strings.EqualFold(fields[2], fingerprint) || strings.EqualFold(fields[len(fields)-1], fingerprint) {
+			return nil
+		}
+	}
+	return fmt.Errorf("The checksums aren't signed with the Red Hat release key '%s'", fingerprint)
+}
+
+// get returns the content of the given URL of the mirror.
+func get(url string) ([]byte, error) {
+	// nolint:gosec
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Failed to get '%s': %s", url, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
@@ -30,6 +30,11 @@ func Download(url string, filename string) error {
 		return err
 	}
 	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		out.Close()
+		os.Remove(filename + ".tmp")
+		return fmt.Errorf("Failed to download '%s': %s", url, resp.Status)
+	}
 
 	// Create our progress reporter and pass it to be used alongside our writer
 	counter := &WriteCounter{}
@@ -80,3 +85,20 @@ func (wc WriteCounter) PrintProgress() {
 	// We use the humanize package to print the bytes in a meaningful way (e.g. 10 MB)
 	fmt.Printf("\rDownloading... %s complete", humanize.Bytes(wc.Total))
 }
+
+// GetROSAArchiveName returns the name of the archive of the mirror containing the rosa binary for
+// the given operating system and architecture.
+func GetROSAArchiveName(goos string, goarch string) string {
+	platform := goos
+	if goos == "darwin" {
+		platform = "macosx"
+	}
+	if goarch == "arm64" {
+		platform += "-arm64"
+	}
+	extension := "tar.gz"
+	if goos == "windows" {
+		extension = "zip"
+	}
+	return fmt.Sprintf("rosa-%s.%s", platform, extension)
+}
@@ -0,0 +1,36 @@
+package helper
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ROSA client tools", func() {
+	It("Names the archive of each platform", func() {
+		Expect(GetROSAArchiveName("linux", "amd64")).To(Equal("rosa-linux.tar.gz"))
+		Expect(GetROSAArchiveName("linux", "arm64")).To(Equal("rosa-linux-arm64.tar.gz"))
+		Expect(GetROSAArchiveName("darwin", "amd64")).To(Equal("rosa-macosx.tar.gz"))
+		Expect(GetROSAArchiveName("darwin", "arm64")).To(Equal("rosa-macosx-arm64.tar.gz"))
+		Expect(GetROSAArchiveName("windows", "amd64")).To(Equal("rosa-windows.zip"))
+	})
+})
+
+var _ = Describe("Signature status", func() {
+	It("Accepts a valid signature made with the pinned key", func() {
+		status := "[GNUPG:] GOODSIG 199E2F91FD431D51 Red Hat, Inc.\n" +
+			"[GNUPG:] VALIDSIG 567E347AD0044ADE55BA8A5F199E2F91FD431D51 2023-01-01 1672531200 0 4 0 1 8 00 " +
+			"567E347AD0044ADE55BA8A5F199E2F91FD431D51\n"
+		Expect(checkSignatureStatus(status, RedHatReleaseKeyFingerprint)).To(Succeed())
+	})
+
+	It("Rejects a valid signature made with another key", func() {
+		status := "[GNUPG:] VALIDSIG 0123456789ABCDEF0123456789ABCDEF01234567 2023-01-01 1672531200 0 4 0 1 8 00 " +
+			"0123456789ABCDEF0123456789ABCDEF01234567\n"
+		Expect(checkSignatureStatus(status, RedHatReleaseKeyFingerprint)).ToNot(Succeed())
+	})
+
+	It("Rejects a status without a valid signature", func() {
+		status := "[GNUPG:] BADSIG 199E2F91FD431D51 Red Hat, Inc.\n"
+		Expect(checkSignatureStatus(status, RedHatReleaseKeyFingerprint)).ToNot(Succeed())
+	})
+})
@@ -0,0 +1,192 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions used to find and install the latest OpenShift client tools
+// published in the mirror.
+
+package helper
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const OCLatestMirrorFolder = "https://mirror.openshift.com/pub/openshift-v4/clients/ocp/latest/"
+
+// GetOCArchiveName returns the name of the archive of the mirror containing the oc binary for the
+// given operating system and architecture.
+func GetOCArchiveName(goos string, goarch string) string {
+	platform := goos
+	if goos == "darwin" {
+		platform = "mac"
+	}
+	if goarch == "arm64" {
+		platform += "-arm64"
+	}
+	extension := "tar.gz"
+	if goos == "windows" {
+		extension = "zip"
+	}
+	return fmt.Sprintf("openshift-client-%s.%s", platform, extension)
+}
+
+// GetOCBinaryName returns the name of the oc binary for the given operating system.
+func GetOCBinaryName(goos string) string {
+	if goos == "windows" {
+		return "oc.exe"
+	}
+	return "oc"
+}
+
+// GetLatestOCVersion returns the version of the latest OpenShift client tools of the mirror.
+func GetLatestOCVersion() (string, error) {
+	data, err := get(OCLatestMirrorFolder + "release.txt")
+	if err != nil {
+		return "", err
+	}
+	return parseReleaseVersion(string(data))
+}
+
+// parseReleaseVersion extracts the version from the 'Name:' line of the release notes of the mirror.
+func parseReleaseVersion(data string) (string, error) {
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && fields[0] == "Name:" {
+			return fields[1], nil
+		}
+	}
+	return "", fmt.Errorf("Failed to find the version in the release notes of the mirror")
+}
+
+// GetOCChecksum returns the SHA-256 sum published in the mirror for the given archive.
+func GetOCChecksum(filename string) (string, error) {
+	data, err := GetChecksums(OCLatestMirrorFolder)
+	if err != nil {
+		return "", err
+	}
+	return FindChecksum(data, filename)
+}
+
+// DownloadToTempDir downloads the given URL to a file with the given name in a new private
+// temporary directory. The returned function removes the directory and must be called once the
+// file is no longer needed.
+func DownloadToTempDir(url string, filename string) (string, func(), error) {
+	dir, err := os.MkdirTemp("", "rosa-download-")
+	if err != nil {
+		return "", nil, err
+	}
+	cleanup := func() {
+		os.RemoveAll(dir)
+	}
+	path := filepath.Join(dir, filename)
+	err = Download(url, path)
+	if err != nil {
+		cleanup()
+		return "", nil, err
+	}
+	return path, cleanup, nil
+}
+
+// ExtractBinary extracts the binary with the given name from the tar.gz or zip archive and writes
+// it with executable permissions to the destination, replacing any existing file.
+func ExtractBinary(archive string, binary string, destination string) error {
+	// The binary is extracted to a private temporary directory next to the destination, so that
+	// it can be moved in place atomically:
+	dir, err := os.MkdirTemp(filepath.Dir(destination), ".rosa-extract-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+	tmp := filepath.Join(dir, binary)
+	if strings.HasSuffix(archive, ".zip") {
+		err = extractFromZip(archive, binary, tmp)
+	} else {
+		err = extractFromTarGz(archive, binary, tmp)
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp, destination)
+}
+
+func extractFromTarGz(archive string, binary string, destination string) error {
+	file, err := os.Open(archive)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+	reader := tar.NewReader(gz)
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if header.Typeflag == tar.TypeReg && filepath.Base(header.Name) == binary {
+			return writeExecutable(reader, destination)
+		}
+	}
+	return fmt.Errorf("Failed to find '%s' in '%s'", binary, archive)
+}
+
+func extractFromZip(archive string, binary string, destination string) error {
+	reader, err := zip.OpenReader(archive)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	for _, file := range reader.File {
+		if file.FileInfo().IsDir() || filepath.Base(file.Name) != binary {
+			continue
+		}
+		content, err := file.Open()
+		if err != nil {
+			return err
+		}
+		defer content.Close()
+		return writeExecutable(content, destination)
+	}
+	return fmt.Errorf("Failed to find '%s' in '%s'", binary, archive)
+}
+
+func writeExecutable(content io.Reader, destination string) error {
+	// nolint:gosec
+	out, err := os.OpenFile(destination, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0755)
+	if err != nil {
+		return err
+	}
+	// nolint:gosec
+	if _, err = io.Copy(out, content); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,71 @@
+package helper
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("OpenShift client tools", func() {
+	It("Names the archive of each platform", func() {
+		Expect(GetOCArchiveName("linux", "amd64")).To(Equal("openshift-client-linux.tar.gz"))
+		Expect(GetOCArchiveName("linux", "arm64")).To(Equal("openshift-client-linux-arm64.tar.gz"))
+		Expect(GetOCArchiveName("darwin", "arm64")).To(Equal("openshift-client-mac-arm64.tar.gz"))
+		Expect(GetOCArchiveName("windows", "amd64")).To(Equal("openshift-client-windows.zip"))
+	})
+
+	It("Parses the version of the release notes", func() {
+		version, err := parseReleaseVersion("Client tools for OpenShift\n---\n\n" +
+			"Name:      4.13.4\nDigest:    sha256:abc\n")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(version).To(Equal("4.13.4"))
+	})
+
+	It("Finds the checksum of an archive", func() {
+		data := "aaa  openshift-client-linux.tar.gz\nbbb  openshift-client-mac.tar.gz\n"
+		checksum, err := FindChecksum(data, "openshift-client-mac.tar.gz")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(checksum).To(Equal("bbb"))
+		_, err = FindChecksum(data, "openshift-client-windows.zip")
+		Expect(err).To(MatchError("Failed to find the checksum of 'openshift-client-windows.zip' in the mirror"))
+	})
+
+	It("Verifies the checksum and extracts the binary of an archive", func() {
+		dir := GinkgoT().TempDir()
+		archive := filepath.Join(dir, "openshift-client-linux.tar.gz")
+		file, err := os.Create(archive)
+		Expect(err).ToNot(HaveOccurred())
+		gz := gzip.NewWriter(file)
+		writer := tar.NewWriter(gz)
+		for _, name := range []string{"README.md", "oc"} {
+			content := []byte("content of " + name)
+			Expect(writer.WriteHeader(&tar.Header{
+				Name: name, Mode: 0755, Size: int64(len(content)), Typeflag: tar.TypeReg,
+			})).To(Succeed())
+			_, err = writer.Write(content)
+			Expect(err).ToNot(HaveOccurred())
+		}
+		Expect(writer.Close()).To(Succeed())
+		Expect(gz.Close()).To(Succeed())
+		Expect(file.Close()).To(Succeed())
+
+		data, err := os.ReadFile(archive)
+		Expect(err).ToNot(HaveOccurred())
+		sum := sha256.Sum256(data)
+		Expect(VerifyChecksum(archive, hex.EncodeToString(sum[:]))).To(Succeed())
+		Expect(VerifyChecksum(archive, "0000")).ToNot(Succeed())
+
+		destination := filepath.Join(dir, "bin-oc")
+		Expect(ExtractBinary(archive, "oc", destination)).To(Succeed())
+		content, err := os.ReadFile(destination)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(content)).To(Equal("content of oc"))
+		Expect(ExtractBinary(archive, "kubectl", destination)).ToNot(Succeed())
+	})
+})
@@ -0,0 +1,147 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the calculation of the estimated monthly cost of a cluster from the on-demand
+// prices of the AWS resources it uses and the ROSA service fees.
+
+package estimate
+
+import (
+	"fmt"
+
+	"github.com/openshift/rosa/pkg/ocm"
+)
+
+const (
+	// HoursPerMonth is the average number of hours in a month used by AWS to compute monthly prices.
+	HoursPerMonth = 730
+
+	// Published ROSA service fees in USD. The worker fee is charged per vCPU of the compute nodes.
+	ClassicClusterFeePerHour  = 0.03
+	HostedCPClusterFeePerHour = 0.25
+	WorkerFeePerVCPUHour      = 0.171 / 4
+
+	// Root volumes of the nodes of classic clusters.
+	ControlPlaneVolumeSize = 350
+	InfraVolumeSize        = 300
+	ComputeVolumeSize      = 300
+)
+
+// Cluster describes the cluster whose cost is estimated.
+type Cluster struct {
+	MultiAZ            bool
+	HostedCP           bool
+	BYOVPC             bool
+	ComputeMachineType string
+	ComputeVCPUs       int
+	ComputeNodes       int
+}
+
+// Prices are the on-demand prices in USD of the AWS resources used by clusters in a region.
+type Prices struct {
+	Instances         map[string]float64
+	VolumePerGBMonth  float64
+	NATGatewayPerHour float64
+}
+
+// Item is one line of the estimate.
+type Item struct {
+	Name     string  `json:"name"`
+	Quantity int     `json:"quantity"`
+	Monthly  float64 `json:"monthly_cost"`
+}
+
+// Estimate is the estimated monthly cost of a cluster in USD.
+type Estimate struct {
+	Items []Item  `json:"items"`
+	Total float64 `json:"total_monthly_cost"`
+}
+
+// Summary is the estimate of a cluster as printed by the commands. For autoscaling clusters the
+// estimate is computed for the minimum number of compute nodes, and Maximum for MaxReplicas.
+type Summary struct {
+	*Estimate
+	MaxReplicas int       `json:"max_replicas,omitempty"`
+	Maximum     *Estimate `json:"autoscaling_maximum,omitempty"`
+}
+
+// Compute returns the estimated monthly cost of the cluster.
+func Compute(cluster Cluster, prices Prices) (*Estimate, error) {
+	estimate := &Estimate{}
+	add := func(name string, quantity int, hourly float64) {
+		monthly := float64(quantity) * hourly * HoursPerMonth
+		estimate.Items = append(estimate.Items, Item{Name: name, Quantity: quantity, Monthly: monthly})
+		estimate.Total += monthly
+	}
+	instancePrice := func(instanceType string) (float64, error) {
+		price, ok := prices.Instances[instanceType]
+		if !ok {
+			return 0, fmt.Errorf("No on-demand price found for instance type '%s'", instanceType)
+		}
+		return price, nil
+	}
+	volumeHourly := func(size int) float64 {
+		return float64(size) * prices.VolumePerGBMonth / HoursPerMonth
+	}
+
+	zones := 1
+	if cluster.MultiAZ {
+		zones = 3
+	}
+
+	computePrice, err := instancePrice(cluster.ComputeMachineType)
+	if err != nil {
+		return nil, err
+	}
+	if !cluster.HostedCP {
+		// The control plane and infra nodes are sized by the service according to the number of
+		// compute nodes. The bootstrap node is deleted once the cluster is installed.
+		for _, managedNodes := range ocm.GetClassicManagedNodes(cluster.ComputeNodes, cluster.MultiAZ) {
+			var name string
+			var volumeSize int
+			switch managedNodes.Role {
+			case "control plane":
+				name, volumeSize = "Control plane", ControlPlaneVolumeSize
+			case "infra":
+				name, volumeSize = "Infrastructure", InfraVolumeSize
+			default:
+				continue
+			}
+			price, err := instancePrice(managedNodes.MachineType)
+			if err != nil {
+				return nil, err
+			}
+			add(fmt.Sprintf("%s nodes (%s)", name, managedNodes.MachineType), managedNodes.Count, price)
+			add(fmt.Sprintf("%s volumes (%d GiB)", name, volumeSize), managedNodes.Count,
+				volumeHourly(volumeSize))
+		}
+	}
+	add(fmt.Sprintf("Compute nodes of machine pool 'worker' (%s)", cluster.ComputeMachineType),
+		cluster.ComputeNodes, computePrice)
+	add(fmt.Sprintf("Compute volumes of machine pool 'worker' (%d GiB)", ComputeVolumeSize),
+		cluster.ComputeNodes, volumeHourly(ComputeVolumeSize))
+	if !cluster.BYOVPC {
+		add("NAT gateways", zones, prices.NATGatewayPerHour)
+	}
+	if cluster.HostedCP {
+		add("ROSA hosted control plane fee", 1, HostedCPClusterFeePerHour)
+	} else {
+		add("ROSA cluster fee", 1, ClassicClusterFeePerHour)
+	}
+	add("ROSA worker fee (per vCPU)", cluster.ComputeNodes*cluster.ComputeVCPUs, WorkerFeePerVCPUHour)
+
+	return estimate, nil
+}
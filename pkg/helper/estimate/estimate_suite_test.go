@@ -0,0 +1,13 @@
+package estimate
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestEstimate(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Estimate Suite")
+}
@@ -0,0 +1,104 @@
+package estimate
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Compute", func() {
+	prices := Prices{
+		Instances: map[string]float64{
+			"m5.xlarge":  0.192,
+			"m5.2xlarge": 0.384,
+			"r5.xlarge":  0.252,
+			"m5.4xlarge": 0.768,
+			"r5.2xlarge": 0.504,
+		},
+		VolumePerGBMonth:  0.08,
+		NATGatewayPerHour: 0.045,
+	}
+
+	quantities := func(estimate *Estimate) map[string]int {
+		result := map[string]int{}
+		for _, item := range estimate.Items {
+			result[item.Name] = item.Quantity
+		}
+		return result
+	}
+
+	It("includes the Red Hat managed nodes of classic clusters", func() {
+		estimate, err := Compute(Cluster{
+			MultiAZ:            true,
+			ComputeMachineType: "m5.xlarge",
+			ComputeVCPUs:       4,
+			ComputeNodes:       3,
+		}, prices)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(quantities(estimate)).To(Equal(map[string]int{
+			"Control plane nodes (m5.2xlarge)":                   3,
+			"Control plane volumes (350 GiB)":                    3,
+			"Infrastructure nodes (r5.xlarge)":                   3,
+			"Infrastructure volumes (300 GiB)":                   3,
+			"Compute nodes of machine pool 'worker' (m5.xlarge)": 3,
+			"Compute volumes of machine pool 'worker' (300 GiB)": 3,
+			"NAT gateways":               3,
+			"ROSA cluster fee":           1,
+			"ROSA worker fee (per vCPU)": 12,
+		}))
+	})
+
+	It("sizes the Red Hat managed nodes of classic clusters according to the compute nodes", func() {
+		estimate, err := Compute(Cluster{
+			ComputeMachineType: "m5.xlarge",
+			ComputeVCPUs:       4,
+			ComputeNodes:       30,
+		}, prices)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(quantities(estimate)).To(HaveKeyWithValue("Control plane nodes (m5.4xlarge)", 3))
+		Expect(quantities(estimate)).To(HaveKeyWithValue("Infrastructure nodes (r5.2xlarge)", 2))
+		Expect(quantities(estimate)).ToNot(HaveKey("Control plane nodes (m5.2xlarge)"))
+	})
+
+	It("only includes the compute nodes of hosted control plane clusters", func() {
+		estimate, err := Compute(Cluster{
+			HostedCP:           true,
+			BYOVPC:             true,
+			ComputeMachineType: "m5.xlarge",
+			ComputeVCPUs:       4,
+			ComputeNodes:       2,
+		}, prices)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(quantities(estimate)).To(Equal(map[string]int{
+			"Compute nodes of machine pool 'worker' (m5.xlarge)": 2,
+			"Compute volumes of machine pool 'worker' (300 GiB)": 2,
+			"ROSA hosted control plane fee":                      1,
+			"ROSA worker fee (per vCPU)":                         8,
+		}))
+		// 2 nodes, 2 volumes of 300 GiB, the hosted control plane fee and 8 vCPUs of worker fee:
+		expected := 2*0.192*HoursPerMonth + 2*300*0.08 + HostedCPClusterFeePerHour*HoursPerMonth +
+			8*WorkerFeePerVCPUHour*HoursPerMonth
+		Expect(estimate.Total).To(BeNumerically("~", expected, 0.001))
+	})
+
+	It("fails when an instance type has no price", func() {
+		_, err := Compute(Cluster{ComputeMachineType: "x9.huge", ComputeNodes: 2}, prices)
+		Expect(err).To(MatchError(ContainSubstring("'x9.huge'")))
+	})
+
+	It("includes the autoscaling maximum in the report", func() {
+		data, err := json.Marshal(&Summary{
+			Estimate:    &Estimate{Total: 100},
+			MaxReplicas: 6,
+			Maximum:     &Estimate{Total: 250},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(data).To(MatchJSON(`{
+			"items": null,
+			"total_monthly_cost": 100,
+			"max_replicas": 6,
+			"autoscaling_maximum": {"items": null, "total_monthly_cost": 250}
+		}`))
+	})
+})
@@ -0,0 +1,188 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains a minimal GitHub API client used to check the settings of a GitHub identity
+// provider before the identity provider is created.
+
+package github
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/openshift/rosa/pkg/helper"
+)
+
+const DefaultTimeout = 10 * time.Second
+
+// Check contains the settings of the GitHub identity provider that are checked against the GitHub
+// API.
+type Check struct {
+	// Hostname of the GitHub Enterprise instance, empty for github.com.
+	Hostname string
+	CA       string
+	// Token is optional and is only required to check teams.
+	Token         string
+	Organizations []string
+	Teams         []string
+	ClientID      string
+	CallbackURL   string
+	Timeout       time.Duration
+}
+
+// Run checks that the organizations and teams exist and that the OAuth application with the client
+// ID redirects to the callback URL of the cluster. Teams are only checked when there is a token, as
+// the GitHub API doesn't return them to anonymous requests; only their organization is checked
+// otherwise.
+func (c *Check) Run() error {
+	client, err := c.httpClient()
+	if err != nil {
+		return err
+	}
+	apiURL, webURL := c.urls()
+
+	organizations := []string{}
+	organizations = append(organizations, c.Organizations...)
+	for _, team := range c.Teams {
+		org, slug, found := strings.Cut(team, "/")
+		if !found {
+			return fmt.Errorf("Expected a GitHub team to follow the form '<org>/<team>', got '%s'", team)
+		}
+		if !helper.Contains(organizations, org) {
+			organizations = append(organizations, org)
+		}
+		if c.Token == "" {
+			continue
+		}
+		exists, err := c.exists(client, fmt.Sprintf("%s/orgs/%s/teams/%s", apiURL,
+			url.PathEscape(org), url.PathEscape(slug)))
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return fmt.Errorf("GitHub team '%s' doesn't exist or isn't visible with the given token", team)
+		}
+	}
+	for _, org := range organizations {
+		exists, err := c.exists(client, fmt.Sprintf("%s/orgs/%s", apiURL, url.PathEscape(org)))
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return fmt.Errorf("GitHub organization '%s' doesn't exist", org)
+		}
+	}
+
+	if c.ClientID == "" || c.CallbackURL == "" {
+		return nil
+	}
+	return c.checkCallbackURL(client, webURL)
+}
+
+// checkCallbackURL starts an authorization with the callback URL of the cluster. GitHub answers
+// with a '404 Not Found' for unknown client IDs, and redirects to the registered callback URL with
+// a 'redirect_uri_mismatch' error when it doesn't match.
+func (c *Check) checkCallbackURL(client *http.Client, webURL string) error {
+	query := url.Values{}
+	query.Set("client_id", c.ClientID)
+	query.Set("redirect_uri", c.CallbackURL)
+	client.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	response, err := client.Get(fmt.Sprintf("%s/login/oauth/authorize?%s", webURL, query.Encode()))
+	if err != nil {
+		return fmt.Errorf("Failed to check the GitHub OAuth application: %v", err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("GitHub OAuth application with client ID '%s' doesn't exist", c.ClientID)
+	}
+	location, err := response.Location()
+	if err == nil && location.Query().Get("error") == "redirect_uri_mismatch" {
+		return fmt.Errorf("Callback URL of the GitHub OAuth application with client ID '%s' doesn't "+
+			"match the cluster, it must be '%s'", c.ClientID, c.CallbackURL)
+	}
+	return nil
+}
+
+// exists checks if the given API resource exists.
+func (c *Check) exists(client *http.Client, resourceURL string) (bool, error) {
+	request, err := http.NewRequest(http.MethodGet, resourceURL, nil)
+	if err != nil {
+		return false, err
+	}
+	request.Header.Set("Accept", "application/vnd.github+json")
+	if c.Token != "" {
+		request.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		return false, fmt.Errorf("Failed to call the GitHub API: %v", err)
+	}
+	defer response.Body.Close()
+	switch response.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	case http.StatusUnauthorized:
+		return false, errors.New("GitHub API rejected the token")
+	default:
+		return false, fmt.Errorf("GitHub API returned an unexpected status for '%s': %s",
+			resourceURL, response.Status)
+	}
+}
+
+// urls returns the base URLs of the API and of the web interface.
+func (c *Check) urls() (apiURL string, webURL string) {
+	if c.Hostname == "" {
+		return "https://api.github.com", "https://github.com"
+	}
+	host := c.Hostname
+	if parsed, err := url.Parse(host); err == nil && parsed.Host != "" {
+		host = parsed.Host
+	}
+	webURL = "https://" + host
+	return webURL + "/api/v3", webURL
+}
+
+func (c *Check) httpClient() (*http.Client, error) {
+	timeout := c.Timeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if c.CA != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(c.CA)) {
+			return nil, errors.New("Expected a valid certificate bundle")
+		}
+		transport.TLSClientConfig = &tls.Config{
+			RootCAs:    pool,
+			MinVersion: tls.VersionTLS12,
+		}
+	}
+	return &http.Client{
+		Transport: transport,
+		Timeout:   timeout,
+	}, nil
+}
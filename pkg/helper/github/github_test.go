@@ -0,0 +1,101 @@
+package github
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("GitHub check", func() {
+	var (
+		server      *httptest.Server
+		check       *Check
+		callbackURL = "https://oauth.example.com/oauth2callback/github-1"
+	)
+
+	BeforeEach(func() {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/api/v3/orgs/myorg", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+		mux.HandleFunc("/api/v3/orgs/myorg/teams/myteam", func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer mytoken" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		})
+		mux.HandleFunc("/login/oauth/authorize", func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("client_id") != "myclient" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			if r.URL.Query().Get("redirect_uri") != callbackURL {
+				http.Redirect(w, r, "https://other.example.com/callback?error=redirect_uri_mismatch",
+					http.StatusFound)
+				return
+			}
+			http.Redirect(w, r, "/login", http.StatusFound)
+		})
+		mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		})
+		server = httptest.NewTLSServer(mux)
+		ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+		check = &Check{
+			Hostname:      server.URL,
+			CA:            string(ca),
+			Organizations: []string{"myorg"},
+			ClientID:      "myclient",
+			CallbackURL:   callbackURL,
+		}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("succeeds when the organization exists and the callback URL matches", func() {
+		Expect(check.Run()).To(Succeed())
+	})
+
+	It("fails when the organization doesn't exist", func() {
+		check.Organizations = []string{"otherorg"}
+		Expect(check.Run()).To(MatchError("GitHub organization 'otherorg' doesn't exist"))
+	})
+
+	It("checks teams when there is a token", func() {
+		check.Organizations = nil
+		check.Teams = []string{"myorg/myteam"}
+		check.Token = "mytoken"
+		Expect(check.Run()).To(Succeed())
+		check.Teams = []string{"myorg/otherteam"}
+		Expect(check.Run()).To(MatchError(ContainSubstring("GitHub team 'myorg/otherteam' doesn't exist")))
+	})
+
+	It("only checks the organization of teams when there is no token", func() {
+		check.Organizations = nil
+		check.Teams = []string{"myorg/otherteam"}
+		Expect(check.Run()).To(Succeed())
+		check.Teams = []string{"otherorg/myteam"}
+		Expect(check.Run()).To(MatchError("GitHub organization 'otherorg' doesn't exist"))
+	})
+
+	It("fails when the client ID doesn't exist", func() {
+		check.ClientID = "otherclient"
+		Expect(check.Run()).To(MatchError(ContainSubstring("client ID 'otherclient' doesn't exist")))
+	})
+
+	It("fails when the callback URL doesn't match", func() {
+		check.CallbackURL = "https://oauth.example.com/oauth2callback/github-2"
+		Expect(check.Run()).To(MatchError(ContainSubstring("doesn't match the cluster")))
+	})
+
+	It("fails when the certificate isn't trusted", func() {
+		check.CA = ""
+		Expect(check.Run()).To(MatchError(ContainSubstring("Failed to call the GitHub API")))
+	})
+})
@@ -0,0 +1,270 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	mpHelpers "github.com/openshift/rosa/pkg/helper/machinepools"
+	"github.com/openshift/rosa/pkg/ocm"
+)
+
+// Options contains the settings of an ingress given in the command line. Settings that are nil or
+// empty are left unchanged.
+type Options struct {
+	RouteSelectors           map[string]string
+	ExcludedNamespaces       []string
+	NamespaceOwnershipPolicy string
+	WildcardPolicy           string
+	LoadBalancerType         string
+	ComponentRoutes          map[string]*ocm.ComponentRoute
+}
+
+// GetRouteSelector parses the comma-separated list of 'key=value' route selectors given with the
+// '--label-match' option.
+func GetRouteSelector(labelMatch string) (map[string]string, error) {
+	routeSelectors := make(map[string]string)
+	if strings.TrimSpace(labelMatch) == "" {
+		return routeSelectors, nil
+	}
+	for _, labelMatch := range strings.Split(labelMatch, ",") {
+		if !strings.Contains(labelMatch, "=") {
+			return nil, fmt.Errorf("Expected key=value format for label-match")
+		}
+		tokens := strings.SplitN(labelMatch, "=", 2)
+		key := strings.TrimSpace(tokens[0])
+		value := strings.TrimSpace(tokens[1])
+		err := mpHelpers.ValidateLabelKeyValuePair(key, value)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid label-match: %v", err)
+		}
+		if _, exists := routeSelectors[key]; exists {
+			return nil, fmt.Errorf("Duplicated label-match key '%s' used", key)
+		}
+		routeSelectors[key] = value
+	}
+	return routeSelectors, nil
+}
+
+// LabelMatchValidator checks the route selectors entered in interactive mode.
+func LabelMatchValidator(val interface{}) error {
+	if labelMatch, ok := val.(string); ok {
+		_, err := GetRouteSelector(labelMatch)
+		return err
+	}
+	return fmt.Errorf("can only validate strings, got %v", val)
+}
+
+// GetExcludedNamespaces parses the comma-separated list of namespaces given with the
+// '--excluded-namespaces' option.
+func GetExcludedNamespaces(excludedNamespaces string) ([]string, error) {
+	namespaces := []string{}
+	if strings.TrimSpace(excludedNamespaces) == "" {
+		return namespaces, nil
+	}
+	seen := map[string]bool{}
+	for _, namespace := range strings.Split(excludedNamespaces, ",") {
+		namespace = strings.TrimSpace(namespace)
+		if errs := validation.IsDNS1123Label(namespace); len(errs) != 0 {
+			return nil, fmt.Errorf("Invalid excluded namespace '%s': %s", namespace, strings.Join(errs, "; "))
+		}
+		if seen[namespace] {
+			return nil, fmt.Errorf("Duplicated excluded namespace '%s'", namespace)
+		}
+		seen[namespace] = true
+		namespaces = append(namespaces, namespace)
+	}
+	return namespaces, nil
+}
+
+// ExcludedNamespacesValidator checks the excluded namespaces entered in interactive mode.
+func ExcludedNamespacesValidator(val interface{}) error {
+	if excludedNamespaces, ok := val.(string); ok {
+		_, err := GetExcludedNamespaces(excludedNamespaces)
+		return err
+	}
+	return fmt.Errorf("can only validate strings, got %v", val)
+}
+
+// GetComponentRoutes parses the component routes given with the '--component-routes' option, in
+// the 'name: hostname=value;tlsSecretRef=value' format separated by commas. Empty values restore
+// the default route of the component.
+func GetComponentRoutes(componentRoutes string) (map[string]*ocm.ComponentRoute, error) {
+	routes := map[string]*ocm.ComponentRoute{}
+	if strings.TrimSpace(componentRoutes) == "" {
+		return routes, nil
+	}
+	for _, componentRoute := range strings.Split(componentRoutes, ",") {
+		tokens := strings.SplitN(componentRoute, ":", 2)
+		if len(tokens) != 2 {
+			return nil, fmt.Errorf("Expected 'name: hostname=value;tlsSecretRef=value' format for "+
+				"component route '%s'", strings.TrimSpace(componentRoute))
+		}
+		name := strings.TrimSpace(tokens[0])
+		if !isComponentRouteName(name) {
+			return nil, fmt.Errorf("Unknown component route '%s', expected one of %s",
+				name, strings.Join(ocm.ComponentRouteNames, ", "))
+		}
+		if _, exists := routes[name]; exists {
+			return nil, fmt.Errorf("Duplicated component route '%s'", name)
+		}
+		route := new(ocm.ComponentRoute)
+		seen := map[string]bool{}
+		for _, parameter := range strings.Split(tokens[1], ";") {
+			parameterTokens := strings.SplitN(parameter, "=", 2)
+			if len(parameterTokens) != 2 {
+				return nil, fmt.Errorf("Expected key=value format for the parameters of component route '%s'",
+					name)
+			}
+			key := strings.TrimSpace(parameterTokens[0])
+			value := strings.TrimSpace(parameterTokens[1])
+			if seen[key] {
+				return nil, fmt.Errorf("Duplicated parameter '%s' of component route '%s'", key, name)
+			}
+			seen[key] = true
+			switch key {
+			case "hostname":
+				route.Hostname = value
+			case "tlsSecretRef":
+				route.TLSSecretRef = value
+			default:
+				return nil, fmt.Errorf("Unknown parameter '%s' of component route '%s', expected "+
+					"'hostname' and 'tlsSecretRef'", key, name)
+			}
+		}
+		if !seen["hostname"] || !seen["tlsSecretRef"] {
+			return nil, fmt.Errorf("Component route '%s' must have both the 'hostname' and the "+
+				"'tlsSecretRef' parameters", name)
+		}
+		routes[name] = route
+	}
+	return routes, nil
+}
+
+func isComponentRouteName(name string) bool {
+	for _, item := range ocm.ComponentRouteNames {
+		if item == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateOption checks that the value of an option is one of the accepted ones, ignoring empty
+// values that leave the setting unchanged.
+func ValidateOption(flag string, value string, options []string) error {
+	if value == "" {
+		return nil
+	}
+	for _, option := range options {
+		if value == option {
+			return nil
+		}
+	}
+	return fmt.Errorf("Invalid value '%s' for '--%s', expected one of %s",
+		value, flag, strings.Join(options, ", "))
+}
+
+// Validate checks the values of the options and the combinations of options that can't be used
+// together for the given ingress.
+func (o *Options) Validate(isDefault bool) error {
+	err := ValidateOption("namespace-ownership-policy", o.NamespaceOwnershipPolicy,
+		ocm.NamespaceOwnershipPolicies)
+	if err != nil {
+		return err
+	}
+	err = ValidateOption("wildcard-policy", o.WildcardPolicy, ocm.WildcardPolicies)
+	if err != nil {
+		return err
+	}
+	err = ValidateOption("lb-type", o.LoadBalancerType, ocm.LoadBalancerTypes)
+	if err != nil {
+		return err
+	}
+
+	if len(o.ComponentRoutes) > 0 && !isDefault {
+		return fmt.Errorf("Component routes can only be customized on the default ingress")
+	}
+	hostnames := map[string]string{}
+	for _, name := range sortedRouteNames(o.ComponentRoutes) {
+		route := o.ComponentRoutes[name]
+		if (route.Hostname == "") != (route.TLSSecretRef == "") {
+			return fmt.Errorf("The 'hostname' and 'tlsSecretRef' parameters of component route '%s' "+
+				"must be both set, or both empty to restore the default route", name)
+		}
+		if route.Hostname == "" {
+			continue
+		}
+		if errs := validation.IsDNS1123Subdomain(route.Hostname); len(errs) != 0 {
+			return fmt.Errorf("Invalid hostname '%s' of component route '%s': %s",
+				route.Hostname, name, strings.Join(errs, "; "))
+		}
+		if other, exists := hostnames[route.Hostname]; exists {
+			return fmt.Errorf("Component routes '%s' and '%s' can't use the same hostname '%s'",
+				other, name, route.Hostname)
+		}
+		hostnames[route.Hostname] = name
+	}
+	return nil
+}
+
+// Fields returns the settings of the options that the typed client of the SDK doesn't support,
+// indexed by their dotted path in the ingress.
+func (o *Options) Fields() map[string]interface{} {
+	fields := map[string]interface{}{}
+	if o.ExcludedNamespaces != nil {
+		fields["excluded_namespaces"] = o.ExcludedNamespaces
+	}
+	if o.NamespaceOwnershipPolicy != "" {
+		fields["route_namespace_ownership_policy"] = o.NamespaceOwnershipPolicy
+	}
+	if o.WildcardPolicy != "" {
+		fields["route_wildcard_policy"] = o.WildcardPolicy
+	}
+	if o.LoadBalancerType != "" {
+		fields["load_balancer_type"] = o.LoadBalancerType
+	}
+	if len(o.ComponentRoutes) > 0 {
+		fields["component_routes"] = o.ComponentRoutes
+	}
+	return fields
+}
+
+// FormatComponentRoutes returns the component routes in the format of the '--component-routes'
+// option.
+func FormatComponentRoutes(routes map[string]*ocm.ComponentRoute) string {
+	items := []string{}
+	for _, name := range sortedRouteNames(routes) {
+		route := routes[name]
+		items = append(items, fmt.Sprintf("%s: hostname=%s;tlsSecretRef=%s",
+			name, route.Hostname, route.TLSSecretRef))
+	}
+	return strings.Join(items, ",")
+}
+
+func sortedRouteNames(routes map[string]*ocm.ComponentRoute) []string {
+	names := make([]string, 0, len(routes))
+	for name := range routes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
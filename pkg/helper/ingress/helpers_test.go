@@ -0,0 +1,152 @@
+package ingress
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/rosa/pkg/ocm"
+)
+
+var _ = Describe("Ingress helpers", func() {
+	Context("GetRouteSelector", func() {
+		It("Parses route selectors", func() {
+			selectors, err := GetRouteSelector("foo=bar, bar=baz")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(selectors).To(Equal(map[string]string{"foo": "bar", "bar": "baz"}))
+		})
+
+		It("Returns no route selectors for an empty value", func() {
+			selectors, err := GetRouteSelector("")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(selectors).To(BeEmpty())
+		})
+
+		DescribeTable("Rejects invalid route selectors",
+			func(labelMatch string, message string) {
+				_, err := GetRouteSelector(labelMatch)
+				Expect(err).To(MatchError(ContainSubstring(message)))
+			},
+			Entry("without value", "foo", "Expected key=value format"),
+			Entry("invalid key", "-foo=bar", "Invalid label-match"),
+			Entry("duplicated key", "foo=bar,foo=baz", "Duplicated label-match key 'foo'"),
+		)
+	})
+
+	Context("GetExcludedNamespaces", func() {
+		It("Parses excluded namespaces", func() {
+			namespaces, err := GetExcludedNamespaces("stage, dev")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(namespaces).To(Equal([]string{"stage", "dev"}))
+		})
+
+		It("Returns an empty list to remove the exclusions", func() {
+			namespaces, err := GetExcludedNamespaces("")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(namespaces).ToNot(BeNil())
+			Expect(namespaces).To(BeEmpty())
+		})
+
+		It("Rejects invalid and duplicated namespaces", func() {
+			_, err := GetExcludedNamespaces("Stage")
+			Expect(err).To(MatchError(ContainSubstring("Invalid excluded namespace 'Stage'")))
+			_, err = GetExcludedNamespaces("dev,dev")
+			Expect(err).To(MatchError(ContainSubstring("Duplicated excluded namespace 'dev'")))
+		})
+	})
+
+	Context("GetComponentRoutes", func() {
+		It("Parses component routes", func() {
+			routes, err := GetComponentRoutes("console: hostname=console.example.com;tlsSecretRef=console-tls," +
+				"oauth: hostname=;tlsSecretRef=")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(routes).To(HaveLen(2))
+			Expect(routes[ocm.ComponentRouteConsole]).To(Equal(&ocm.ComponentRoute{
+				Hostname:     "console.example.com",
+				TLSSecretRef: "console-tls",
+			}))
+			Expect(routes[ocm.ComponentRouteOAuth]).To(Equal(&ocm.ComponentRoute{}))
+		})
+
+		DescribeTable("Rejects invalid component routes",
+			func(componentRoutes string, message string) {
+				_, err := GetComponentRoutes(componentRoutes)
+				Expect(err).To(MatchError(ContainSubstring(message)))
+			},
+			Entry("without parameters", "console", "Expected 'name: hostname=value;tlsSecretRef=value'"),
+			Entry("unknown component", "api: hostname=a;tlsSecretRef=b", "Unknown component route 'api'"),
+			Entry("duplicated component", "console: hostname=a;tlsSecretRef=b,console: hostname=c;tlsSecretRef=d",
+				"Duplicated component route 'console'"),
+			Entry("unknown parameter", "console: hostname=a;secret=b", "Unknown parameter 'secret'"),
+			Entry("missing parameter", "console: hostname=a", "must have both"),
+		)
+
+		It("Formats component routes in the format of the option", func() {
+			routes := map[string]*ocm.ComponentRoute{
+				ocm.ComponentRouteOAuth:   {Hostname: "oauth.example.com", TLSSecretRef: "oauth-tls"},
+				ocm.ComponentRouteConsole: {Hostname: "console.example.com", TLSSecretRef: "console-tls"},
+			}
+			formatted := FormatComponentRoutes(routes)
+			Expect(formatted).To(Equal("console: hostname=console.example.com;tlsSecretRef=console-tls," +
+				"oauth: hostname=oauth.example.com;tlsSecretRef=oauth-tls"))
+			parsed, err := GetComponentRoutes(formatted)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(parsed).To(Equal(routes))
+		})
+	})
+
+	Context("Validate", func() {
+		It("Accepts valid options", func() {
+			options := &Options{
+				ExcludedNamespaces:       []string{"dev"},
+				NamespaceOwnershipPolicy: ocm.NamespaceOwnershipPolicyInterNamespaceAllowed,
+				WildcardPolicy:           ocm.WildcardPolicyWildcardsAllowed,
+				LoadBalancerType:         ocm.LoadBalancerTypeNLB,
+				ComponentRoutes: map[string]*ocm.ComponentRoute{
+					ocm.ComponentRouteConsole: {Hostname: "console.example.com", TLSSecretRef: "console-tls"},
+					ocm.ComponentRouteOAuth:   {},
+				},
+			}
+			Expect(options.Validate(true)).To(Succeed())
+		})
+
+		DescribeTable("Rejects invalid options",
+			func(options *Options, isDefault bool, message string) {
+				Expect(options.Validate(isDefault)).To(MatchError(ContainSubstring(message)))
+			},
+			Entry("unknown namespace ownership policy", &Options{NamespaceOwnershipPolicy: "strict"}, true,
+				"Invalid value 'strict' for '--namespace-ownership-policy'"),
+			Entry("unknown wildcard policy", &Options{WildcardPolicy: "Allowed"}, true,
+				"Invalid value 'Allowed' for '--wildcard-policy'"),
+			Entry("unknown load balancer type", &Options{LoadBalancerType: "alb"}, false,
+				"Invalid value 'alb' for '--lb-type'"),
+			Entry("component routes on additional ingress", &Options{
+				ComponentRoutes: map[string]*ocm.ComponentRoute{
+					ocm.ComponentRouteConsole: {Hostname: "console.example.com", TLSSecretRef: "console-tls"},
+				},
+			}, false, "only be customized on the default ingress"),
+			Entry("component route without secret", &Options{
+				ComponentRoutes: map[string]*ocm.ComponentRoute{
+					ocm.ComponentRouteConsole: {Hostname: "console.example.com"},
+				},
+			}, true, "must be both set"),
+			Entry("component routes with the same hostname", &Options{
+				ComponentRoutes: map[string]*ocm.ComponentRoute{
+					ocm.ComponentRouteConsole:   {Hostname: "apps.example.com", TLSSecretRef: "a"},
+					ocm.ComponentRouteDownloads: {Hostname: "apps.example.com", TLSSecretRef: "b"},
+				},
+			}, true, "can't use the same hostname"),
+		)
+	})
+
+	It("Returns the raw fields of the changed options", func() {
+		options := &Options{
+			RouteSelectors:     map[string]string{"foo": "bar"},
+			ExcludedNamespaces: []string{},
+			LoadBalancerType:   ocm.LoadBalancerTypeNLB,
+		}
+		Expect(options.Fields()).To(Equal(map[string]interface{}{
+			"excluded_namespaces": []string{},
+			"load_balancer_type":  ocm.LoadBalancerTypeNLB,
+		}))
+	})
+})
@@ -0,0 +1,320 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains a minimal LDAP client used to check that the settings of an LDAP identity
+// provider allow binding to the server before the identity provider is created.
+
+package ldap
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"time"
+)
+
+const (
+	DefaultTimeout = 10 * time.Second
+
+	startTLSOID = "1.3.6.1.4.1.1466.20037"
+
+	// Bind and StartTLS responses are small, so longer elements are rejected instead of allocating
+	// whatever length the server sends
+	maxElementLength = 64 * 1024
+
+	tagInteger     = 0x02
+	tagOctetString = 0x04
+	tagEnumerated  = 0x0a
+	tagSequence    = 0x30
+
+	tagBindRequest      = 0x60
+	tagBindResponse     = 0x61
+	tagExtendedRequest  = 0x77
+	tagExtendedResponse = 0x78
+	tagSimpleAuth       = 0x80
+	tagRequestName      = 0x80
+	tagReferral         = 0xa3
+
+	resultSuccess            = 0
+	resultReferral           = 10
+	resultInvalidCredentials = 49
+)
+
+// Connection contains the settings of the LDAP identity provider that are used to connect to the
+// server.
+type Connection struct {
+	URL          string
+	Insecure     bool
+	CA           string
+	BindDN       string
+	BindPassword string
+	Timeout      time.Duration
+}
+
+// Test connects to the LDAP server, upgrading the connection to TLS the same way the cluster does,
+// and binds with the configured DN and password, or anonymously when there is no bind DN.
+func (c *Connection) Test() error {
+	parsedURL, err := url.Parse(c.URL)
+	if err != nil {
+		return fmt.Errorf("Expected a valid LDAP URL: %v", err)
+	}
+	host := parsedURL.Hostname()
+	port := parsedURL.Port()
+	if port == "" {
+		port = "389"
+		if parsedURL.Scheme == "ldaps" {
+			port = "636"
+		}
+	}
+	timeout := c.Timeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+
+	conn, err := net.DialTimeout("tcp", net.JoinHostPort(host, port), timeout)
+	if err != nil {
+		return fmt.Errorf("Failed to connect to LDAP server '%s': %v", c.URL, err)
+	}
+	defer conn.Close()
+	err = conn.SetDeadline(time.Now().Add(timeout))
+	if err != nil {
+		return err
+	}
+
+	var tlsConfig *tls.Config
+	if parsedURL.Scheme == "ldaps" || !c.Insecure {
+		tlsConfig, err = c.tlsConfig(host)
+		if err != nil {
+			return err
+		}
+	}
+	if parsedURL.Scheme == "ldaps" {
+		tlsConn := tls.Client(conn, tlsConfig)
+		err = tlsConn.Handshake()
+		if err != nil {
+			return fmt.Errorf("TLS handshake with LDAP server '%s' failed: %v", c.URL, err)
+		}
+		conn = tlsConn
+	} else if !c.Insecure {
+		code, message, _, err := roundTrip(conn, 1, extendedRequest(startTLSOID), tagExtendedResponse)
+		if err != nil {
+			return fmt.Errorf("Failed to start TLS with LDAP server '%s': %v", c.URL, err)
+		}
+		if code != resultSuccess {
+			return fmt.Errorf("LDAP server '%s' refused to start TLS (result code %d): %s. "+
+				"Use an ldaps:// URL or the insecure option", c.URL, code, message)
+		}
+		tlsConn := tls.Client(conn, tlsConfig)
+		err = tlsConn.Handshake()
+		if err != nil {
+			return fmt.Errorf("TLS handshake with LDAP server '%s' failed: %v", c.URL, err)
+		}
+		conn = tlsConn
+	}
+
+	code, message, referrals, err := roundTrip(conn, 2, bindRequest(c.BindDN, c.BindPassword), tagBindResponse)
+	if err != nil {
+		return fmt.Errorf("Failed to bind to LDAP server '%s': %v", c.URL, err)
+	}
+	switch code {
+	case resultSuccess:
+		return nil
+	case resultReferral:
+		return fmt.Errorf("LDAP server '%s' returned a referral to %v, use the URL of the referred server",
+			c.URL, referrals)
+	case resultInvalidCredentials:
+		return fmt.Errorf("LDAP server '%s' rejected the bind DN or password: %s", c.URL, message)
+	default:
+		return fmt.Errorf("Failed to bind to LDAP server '%s' (result code %d): %s", c.URL, code, message)
+	}
+}
+
+func (c *Connection) tlsConfig(host string) (*tls.Config, error) {
+	config := &tls.Config{
+		ServerName: host,
+		MinVersion: tls.VersionTLS12,
+	}
+	if c.CA != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(c.CA)) {
+			return nil, errors.New("Expected a valid certificate bundle")
+		}
+		config.RootCAs = pool
+	}
+	return config, nil
+}
+
+// roundTrip sends a request and reads the result of the response with the expected tag.
+func roundTrip(conn net.Conn, messageID int, request []byte,
+	responseTag byte) (code int, message string, referrals []string, err error) {
+	_, err = conn.Write(encode(tagSequence, encodeInteger(tagInteger, messageID), request))
+	if err != nil {
+		return
+	}
+	tag, content, err := readElement(bufio.NewReader(conn))
+	if err != nil {
+		return
+	}
+	if tag != tagSequence {
+		err = fmt.Errorf("unexpected response tag 0x%x", tag)
+		return
+	}
+	return parseResult(content, responseTag)
+}
+
+// parseResult parses the content of an LDAP message holding an LDAPResult.
+func parseResult(content []byte, responseTag byte) (code int, message string, referrals []string, err error) {
+	elements, err := parseElements(content)
+	if err != nil {
+		return
+	}
+	if len(elements) < 2 || elements[1].tag != responseTag {
+		err = errors.New("unexpected response from server")
+		return
+	}
+	result, err := parseElements(elements[1].content)
+	if err != nil {
+		return
+	}
+	if len(result) < 3 || result[0].tag != tagEnumerated {
+		err = errors.New("malformed result from server")
+		return
+	}
+	for _, b := range result[0].content {
+		code = code<<8 | int(b)
+	}
+	message = string(result[2].content)
+	if len(result) > 3 && result[3].tag == tagReferral {
+		var uris []element
+		uris, err = parseElements(result[3].content)
+		if err != nil {
+			return
+		}
+		for _, uri := range uris {
+			referrals = append(referrals, string(uri.content))
+		}
+	}
+	return
+}
+
+func bindRequest(dn string, password string) []byte {
+	return encode(tagBindRequest,
+		encodeInteger(tagInteger, 3),
+		encode(tagOctetString, []byte(dn)),
+		encode(tagSimpleAuth, []byte(password)),
+	)
+}
+
+func extendedRequest(oid string) []byte {
+	return encode(tagExtendedRequest, encode(tagRequestName, []byte(oid)))
+}
+
+type element struct {
+	tag     byte
+	content []byte
+}
+
+func encode(tag byte, contents ...[]byte) []byte {
+	var content []byte
+	for _, c := range contents {
+		content = append(content, c...)
+	}
+	result := []byte{tag}
+	length := len(content)
+	if length < 0x80 {
+		result = append(result, byte(length))
+	} else {
+		var lengthBytes []byte
+		for length > 0 {
+			lengthBytes = append([]byte{byte(length)}, lengthBytes...)
+			length >>= 8
+		}
+		result = append(result, 0x80|byte(len(lengthBytes)))
+		result = append(result, lengthBytes...)
+	}
+	return append(result, content...)
+}
+
+func encodeInteger(tag byte, value int) []byte {
+	var content []byte
+	for {
+		content = append([]byte{byte(value)}, content...)
+		value >>= 8
+		if value == 0 && content[0] < 0x80 {
+			break
+		}
+	}
+	return encode(tag, content)
+}
+
+func readElement(reader io.ByteReader) (tag byte, content []byte, err error) {
+	tag, err = reader.ReadByte()
+	if err != nil {
+		return
+	}
+	first, err := reader.ReadByte()
+	if err != nil {
+		return
+	}
+	length := int(first)
+	if first&0x80 != 0 {
+		count := int(first & 0x7f)
+		if count == 0 || count > 4 {
+			err = errors.New("unsupported response length")
+			return
+		}
+		length = 0
+		for i := 0; i < count; i++ {
+			var b byte
+			b, err = reader.ReadByte()
+			if err != nil {
+				return
+			}
+			length = length<<8 | int(b)
+		}
+	}
+	if length > maxElementLength {
+		err = fmt.Errorf("response length %d exceeds the maximum of %d bytes", length, maxElementLength)
+		return
+	}
+	content = make([]byte, length)
+	for i := range content {
+		content[i], err = reader.ReadByte()
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+func parseElements(data []byte) ([]element, error) {
+	var elements []element
+	reader := bytes.NewReader(data)
+	for reader.Len() > 0 {
+		tag, content, err := readElement(reader)
+		if err != nil {
+			return nil, err
+		}
+		elements = append(elements, element{tag: tag, content: content})
+	}
+	return elements, nil
+}
@@ -0,0 +1,112 @@
+package ldap
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// serve answers the first request received by the listener with the given response operation.
+func serve(listener net.Listener, response []byte) {
+	defer GinkgoRecover()
+	conn, err := listener.Accept()
+	Expect(err).ToNot(HaveOccurred())
+	defer conn.Close()
+	tag, content, err := readElement(bufio.NewReader(conn))
+	Expect(err).ToNot(HaveOccurred())
+	Expect(tag).To(Equal(byte(tagSequence)))
+	request, err := parseElements(content)
+	Expect(err).ToNot(HaveOccurred())
+	_, err = conn.Write(encode(tagSequence, encode(tagInteger, request[0].content), response))
+	Expect(err).ToNot(HaveOccurred())
+}
+
+func result(tag byte, code int, message string, extra ...[]byte) []byte {
+	contents := [][]byte{
+		encodeInteger(tagEnumerated, code),
+		encode(tagOctetString, nil),
+		encode(tagOctetString, []byte(message)),
+	}
+	return encode(tag, append(contents, extra...)...)
+}
+
+var _ = Describe("LDAP connection test", func() {
+	var listener net.Listener
+
+	BeforeEach(func() {
+		var err error
+		listener, err = net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		listener.Close()
+	})
+
+	connection := func(insecure bool) *Connection {
+		return &Connection{
+			URL:          fmt.Sprintf("ldap://%s/ou=users,dc=example,dc=com", listener.Addr()),
+			Insecure:     insecure,
+			BindDN:       "cn=admin,dc=example,dc=com",
+			BindPassword: "secret",
+		}
+	}
+
+	It("succeeds when the bind succeeds", func() {
+		go serve(listener, result(tagBindResponse, resultSuccess, ""))
+		Expect(connection(true).Test()).To(Succeed())
+	})
+
+	It("reports invalid credentials", func() {
+		go serve(listener, result(tagBindResponse, resultInvalidCredentials, "invalid password"))
+		Expect(connection(true).Test()).To(MatchError(ContainSubstring("rejected the bind DN or password")))
+	})
+
+	It("reports referrals", func() {
+		referral := encode(tagReferral, encode(tagOctetString, []byte("ldap://other.example.com")))
+		go serve(listener, result(tagBindResponse, resultReferral, "", referral))
+		Expect(connection(true).Test()).To(MatchError(ContainSubstring("referral to [ldap://other.example.com]")))
+	})
+
+	It("reports servers that refuse to start TLS", func() {
+		go serve(listener, result(tagExtendedResponse, 2, "unsupported extended operation"))
+		Expect(connection(false).Test()).To(MatchError(ContainSubstring("refused to start TLS")))
+	})
+
+	It("reports unreachable servers", func() {
+		conn := connection(true)
+		listener.Close()
+		Expect(conn.Test()).To(MatchError(ContainSubstring("Failed to connect")))
+	})
+})
+
+var _ = Describe("BER encoding", func() {
+	It("encodes bind requests", func() {
+		Expect(bindRequest("cn=a", "pw")).To(Equal([]byte{
+			0x60, 0x0d,
+			0x02, 0x01, 0x03,
+			0x04, 0x04, 'c', 'n', '=', 'a',
+			0x80, 0x02, 'p', 'w',
+		}))
+	})
+
+	It("round trips long elements", func() {
+		content := make([]byte, 300)
+		elements, err := parseElements(encode(tagOctetString, content))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(elements).To(HaveLen(1))
+		Expect(elements[0].content).To(HaveLen(300))
+	})
+
+	It("rejects elements longer than the maximum length", func() {
+		_, err := parseElements([]byte{tagOctetString, 0x84, 0x7f, 0xff, 0xff, 0xff})
+		Expect(err).To(MatchError(ContainSubstring("exceeds the maximum")))
+	})
+
+	It("encodes integers with a leading zero when needed", func() {
+		Expect(encodeInteger(tagInteger, 128)).To(Equal([]byte{0x02, 0x02, 0x00, 0x80}))
+	})
+})
@@ -0,0 +1,117 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the filter applied to the cluster installation and uninstallation logs.
+
+package logs
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// timestampREs match the timestamp of a log line, either in the 'time="..."' field written by the
+// installer or at the beginning of the line.
+var timestampREs = []*regexp.Regexp{
+	regexp.MustCompile(`\btime="([^"]+)"`),
+	regexp.MustCompile(`^(\d{4}-\d{2}-\d{2}T\S+)`),
+}
+
+// Filter selects the log lines written after a given time and matching a regular expression. Lines
+// without a timestamp belong to the previous line that has one.
+type Filter struct {
+	since   time.Time
+	pattern *regexp.Regexp
+	recent  bool
+}
+
+// NewFilter creates a filter from the values of the '--since' and '--grep' flags. Empty values
+// disable the corresponding check.
+func NewFilter(since string, grep string, now time.Time) (*Filter, error) {
+	filter := &Filter{recent: true}
+	if since != "" {
+		sinceTime, err := ParseSince(since, now)
+		if err != nil {
+			return nil, err
+		}
+		filter.since = sinceTime
+		filter.recent = false
+	}
+	if grep != "" {
+		pattern, err := regexp.Compile(grep)
+		if err != nil {
+			return nil, fmt.Errorf("Expected a valid regular expression for grep: %v", err)
+		}
+		filter.pattern = pattern
+	}
+	return filter, nil
+}
+
+// ParseSince parses either a duration relative to now, like '30m', or an RFC 3339 timestamp.
+func ParseSince(value string, now time.Time) (time.Time, error) {
+	duration, err := time.ParseDuration(value)
+	if err == nil {
+		if duration < 0 {
+			return time.Time{}, fmt.Errorf("Expected a positive duration for since, got '%s'", value)
+		}
+		return now.Add(-duration), nil
+	}
+	timestamp, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("Expected since to be a duration like '30m' or an RFC 3339 "+
+			"timestamp like '2006-01-02T15:04:05Z', got '%s'", value)
+	}
+	return timestamp, nil
+}
+
+// Apply returns the lines of the text that pass the filter. A nil filter keeps all the lines.
+func (f *Filter) Apply(text string) string {
+	if f == nil || text == "" {
+		return text
+	}
+	var result []string
+	for _, line := range strings.Split(text, "\n") {
+		if !f.since.IsZero() {
+			if timestamp, ok := lineTimestamp(line); ok {
+				f.recent = !timestamp.Before(f.since)
+			}
+		}
+		if !f.recent {
+			continue
+		}
+		if f.pattern != nil && !f.pattern.MatchString(line) {
+			continue
+		}
+		result = append(result, line)
+	}
+	return strings.Join(result, "\n")
+}
+
+func lineTimestamp(line string) (time.Time, bool) {
+	for _, re := range timestampREs {
+		match := re.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		timestamp, err := time.Parse(time.RFC3339Nano, match[1])
+		if err == nil {
+			return timestamp, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,66 @@
+package logs
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Filter", func() {
+	now := time.Date(2023, 5, 10, 12, 0, 0, 0, time.UTC)
+	text := `time="2023-05-10T11:00:00Z" level=info msg="Creating infrastructure resources..."
+time="2023-05-10T11:40:00Z" level=info msg="Waiting up to 20m0s for the Kubernetes API"
+time="2023-05-10T11:50:00Z" level=error msg="Cluster operator ingress is degraded"
+details of the ingress error
+time="2023-05-10T11:55:00Z" level=info msg="Install complete!"`
+
+	DescribeTable("ParseSince",
+		func(value string, expected time.Time, expectedError string) {
+			since, err := ParseSince(value, now)
+			if expectedError == "" {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(since).To(Equal(expected))
+			} else {
+				Expect(err).To(MatchError(ContainSubstring(expectedError)))
+			}
+		},
+		Entry("duration", "30m", now.Add(-30*time.Minute), ""),
+		Entry("timestamp", "2023-05-10T11:00:00Z", time.Date(2023, 5, 10, 11, 0, 0, 0, time.UTC), ""),
+		Entry("negative duration", "-5m", time.Time{}, "positive duration"),
+		Entry("invalid", "yesterday", time.Time{}, "RFC 3339"),
+	)
+
+	It("keeps everything without criteria", func() {
+		filter, err := NewFilter("", "", now)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(filter.Apply(text)).To(Equal(text))
+	})
+
+	It("keeps the lines written since the given time with their continuation lines", func() {
+		filter, err := NewFilter("15m", "", now)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(filter.Apply(text)).To(Equal(`time="2023-05-10T11:50:00Z" level=error msg="Cluster operator ingress is degraded"
+details of the ingress error
+time="2023-05-10T11:55:00Z" level=info msg="Install complete!"`))
+	})
+
+	It("keeps the lines matching the regular expression", func() {
+		filter, err := NewFilter("", "level=(error|warning)", now)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(filter.Apply(text)).To(Equal(
+			`time="2023-05-10T11:50:00Z" level=error msg="Cluster operator ingress is degraded"`))
+	})
+
+	It("combines both criteria", func() {
+		filter, err := NewFilter("2023-05-10T11:30:00Z", "Kubernetes", now)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(filter.Apply(text)).To(Equal(
+			`time="2023-05-10T11:40:00Z" level=info msg="Waiting up to 20m0s for the Kubernetes API"`))
+	})
+
+	It("rejects invalid regular expressions", func() {
+		_, err := NewFilter("", "level=(", now)
+		Expect(err).To(MatchError(ContainSubstring("valid regular expression")))
+	})
+})
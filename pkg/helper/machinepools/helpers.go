@@ -201,3 +201,97 @@ func LabelValidator(val interface{}) error {
 	}
 	return fmt.Errorf("can only validate strings, got %v", val)
 }
+
+// ParseSpotMaxPrice parses the value of the '--spot-max-price' option. It returns nil when the
+// on-demand price should be used.
+func ParseSpotMaxPrice(spotMaxPrice string) (*float64, error) {
+	spotMaxPrice = strings.TrimSpace(spotMaxPrice)
+	if spotMaxPrice == "" || spotMaxPrice == "on-demand" {
+		return nil, nil
+	}
+	price, err := strconv.ParseFloat(spotMaxPrice, 64)
+	if err != nil {
+		return nil, fmt.Errorf("Expected a numeric value for spot max price")
+	}
+	if price <= 0 {
+		return nil, fmt.Errorf("Spot max price must be positive")
+	}
+	return &price, nil
+}
+
+func SpotMaxPriceValidator(val interface{}) error {
+	_, err := ParseSpotMaxPrice(fmt.Sprintf("%v", val))
+	return err
+}
+
+const (
+	// MinRootDiskSize is the smallest root volume, in GiB, accepted for the nodes of a machine pool.
+	MinRootDiskSize = 75
+	// MaxRootDiskSize is the largest root volume, in GiB, accepted for the nodes of a machine pool.
+	MaxRootDiskSize = 16384
+)
+
+// diskSizeUnits are the bytes of each of the units accepted by ParseDiskSizeToGigibyte.
+var diskSizeUnits = map[string]float64{
+	"g":   1e9,
+	"gb":  1e9,
+	"gi":  1 << 30,
+	"gib": 1 << 30,
+	"t":   1e12,
+	"tb":  1e12,
+	"ti":  1 << 40,
+	"tib": 1 << 40,
+}
+
+// ParseDiskSizeToGigibyte parses the value of the '--disk-size' option, for example '300GiB' or
+// '1 TB', and returns it in GiB, rounded down. An empty value returns zero.
+func ParseDiskSizeToGigibyte(size string) (int, error) {
+	size = strings.TrimSpace(size)
+	if size == "" {
+		return 0, nil
+	}
+	digits := strings.IndexFunc(size, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if digits == -1 {
+		return 0, fmt.Errorf("Expected the disk size '%s' to have a unit, one of G, GB, Gi, GiB, T, TB, "+
+			"Ti or TiB", size)
+	}
+	value, err := strconv.ParseFloat(size[:digits], 64)
+	if err != nil {
+		return 0, fmt.Errorf("Expected a valid disk size, for example '300GiB', got '%s'", size)
+	}
+	unit, ok := diskSizeUnits[strings.ToLower(strings.TrimSpace(size[digits:]))]
+	if !ok {
+		return 0, fmt.Errorf("Expected the unit of the disk size '%s' to be one of G, GB, Gi, GiB, T, TB, "+
+			"Ti or TiB", size)
+	}
+	return int(value * unit / (1 << 30)), nil
+}
+
+// ValidateRootDiskSize checks that the given root volume size, in GiB, is in the range accepted for
+// the nodes of a machine pool.
+func ValidateRootDiskSize(size int) error {
+	if size < MinRootDiskSize || size > MaxRootDiskSize {
+		return fmt.Errorf("Expected the disk size to be between %d GiB and %d GiB, got %d GiB",
+			MinRootDiskSize, MaxRootDiskSize, size)
+	}
+	return nil
+}
+
+// MachinePoolRootDiskSizeValidator validates the root disk size entered in interactive mode. Empty
+// values are accepted and keep the current size.
+func MachinePoolRootDiskSizeValidator(val interface{}) error {
+	str, ok := val.(string)
+	if !ok {
+		return fmt.Errorf("can only validate strings, got %v", val)
+	}
+	if strings.TrimSpace(str) == "" {
+		return nil
+	}
+	size, err := ParseDiskSizeToGigibyte(str)
+	if err != nil {
+		return err
+	}
+	return ValidateRootDiskSize(size)
+}
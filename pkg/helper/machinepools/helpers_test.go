@@ -47,4 +47,64 @@ var _ = Describe("MachinePool", func() {
 			"key=node-role.kubernetes.io/infra:NoEffect",
 			"Invalid label value 'node-role.kubernetes.io/infra': at key: 'key'", 0),
 	)
+
+	DescribeTable("ParseSpotMaxPrice validation",
+		func(value, expectedError string, expectedPrice *float64) {
+			price, err := ParseSpotMaxPrice(value)
+			if expectedError == "" {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(price).To(Equal(expectedPrice))
+			} else {
+				Expect(err).To(MatchError(expectedError))
+			}
+		},
+		Entry("On-demand price", "on-demand", "", nil),
+		Entry("Empty price", "", "", nil),
+		Entry("Numeric price", "0.5", "", func() *float64 { p := 0.5; return &p }()),
+		Entry("Non numeric price -> KO", "cheap", "Expected a numeric value for spot max price", nil),
+		Entry("Negative price -> KO", "-1", "Spot max price must be positive", nil),
+		Entry("Zero price -> KO", "0", "Spot max price must be positive", nil),
+	)
+})
+
+var _ = Describe("Root disk size", func() {
+	DescribeTable("ParseDiskSizeToGigibyte",
+		func(size string, expected int, expectedError string) {
+			parsed, err := ParseDiskSizeToGigibyte(size)
+			if expectedError == "" {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(parsed).To(Equal(expected))
+			} else {
+				Expect(err).To(MatchError(ContainSubstring(expectedError)))
+			}
+		},
+		Entry("empty", "", 0, ""),
+		Entry("gibibytes", "300GiB", 300, ""),
+		Entry("short gibibytes", "300Gi", 300, ""),
+		Entry("gigabytes", "300 GB", 279, ""),
+		Entry("lowercase", "300g", 279, ""),
+		Entry("tebibytes", "1TiB", 1024, ""),
+		Entry("fractional terabytes", "1.5T", 1396, ""),
+		Entry("no unit", "300", 0, "to have a unit"),
+		Entry("unknown unit", "300MiB", 0, "to be one of"),
+		Entry("no number", "GiB", 0, "Expected a valid disk size"),
+	)
+
+	DescribeTable("MachinePoolRootDiskSizeValidator",
+		func(size interface{}, expectedError string) {
+			err := MachinePoolRootDiskSizeValidator(size)
+			if expectedError == "" {
+				Expect(err).ToNot(HaveOccurred())
+			} else {
+				Expect(err).To(MatchError(ContainSubstring(expectedError)))
+			}
+		},
+		Entry("empty", "", ""),
+		Entry("in range", "300GiB", ""),
+		Entry("maximum", "16TiB", ""),
+		Entry("too small", "50GiB", "between 75 GiB and 16384 GiB, got 50 GiB"),
+		Entry("too large", "17TiB", "between 75 GiB and 16384 GiB, got 17408 GiB"),
+		Entry("invalid", "big", "Expected a valid disk size"),
+		Entry("not a string", 300, "can only validate strings"),
+	)
 })
@@ -0,0 +1,207 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the types and functions used to describe several machine pools in a single
+// YAML or JSON manifest file.
+
+package machinepools
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"k8s.io/apimachinery/pkg/util/errors"
+)
+
+var MachinePoolKeyRE = regexp.MustCompile(`^[a-z]([-a-z0-9]*[a-z0-9])?$`)
+
+// Manifest is the content of a machine pool manifest file.
+type Manifest struct {
+	MachinePools []*ManifestEntry `json:"machine_pools"`
+}
+
+// ManifestEntry describes a single machine pool of a manifest file.
+type ManifestEntry struct {
+	Name             string             `json:"name"`
+	InstanceType     string             `json:"instance_type,omitempty"`
+	Replicas         *int               `json:"replicas,omitempty"`
+	Autoscaling      *ManifestAutoscale `json:"autoscaling,omitempty"`
+	Labels           map[string]string  `json:"labels,omitempty"`
+	Taints           []string           `json:"taints,omitempty"`
+	AvailabilityZone string             `json:"availability_zone,omitempty"`
+	Subnet           string             `json:"subnet,omitempty"`
+	UseSpotInstances bool               `json:"use_spot_instances,omitempty"`
+	SpotMaxPrice     string             `json:"spot_max_price,omitempty"`
+	Version          string             `json:"version,omitempty"`
+	Autorepair       *bool              `json:"autorepair,omitempty"`
+}
+
+type ManifestAutoscale struct {
+	MinReplicas int `json:"min_replicas"`
+	MaxReplicas int `json:"max_replicas"`
+}
+
+// LoadManifest reads and parses the machine pool manifest located at the given path.
+func LoadManifest(path string) (*Manifest, error) {
+	// #nosec G304
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read manifest file '%s': %v", path, err)
+	}
+	data, err = yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse manifest file '%s': %v", path, err)
+	}
+	manifest := new(Manifest)
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	err = decoder.Decode(manifest)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse manifest file '%s': %v", path, err)
+	}
+	if len(manifest.MachinePools) == 0 {
+		return nil, fmt.Errorf("Manifest file '%s' doesn't contain any machine pool", path)
+	}
+	return manifest, nil
+}
+
+// Validate checks all the entries of the manifest, so that nothing is submitted when any of them
+// is wrong. The returned error aggregates the problems found in all the entries.
+func (m *Manifest) Validate(hostedCP bool, multiAZ bool) error {
+	var errs []error
+	names := map[string]bool{}
+	for i, entry := range m.MachinePools {
+		if entry == nil {
+			errs = append(errs, fmt.Errorf("Machine pool #%d: expected a machine pool", i+1))
+			continue
+		}
+		if names[entry.Name] {
+			errs = append(errs, fmt.Errorf("Machine pool '%s' is defined more than once", entry.Name))
+		}
+		names[entry.Name] = true
+		err := entry.Validate(hostedCP, multiAZ)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("Machine pool #%d: %v", i+1, err))
+		}
+	}
+	return errors.NewAggregate(errs)
+}
+
+// Validate checks the values of a single entry of the manifest.
+func (e *ManifestEntry) Validate(hostedCP bool, multiAZ bool) error {
+	if !MachinePoolKeyRE.MatchString(e.Name) {
+		return fmt.Errorf("Expected a valid name for the machine pool, got '%s'", e.Name)
+	}
+
+	// Autoscaling:
+	multiAZMachinePool := multiAZ && !hostedCP && e.AvailabilityZone == "" && e.Subnet == ""
+	if e.Autoscaling != nil {
+		if e.Replicas != nil {
+			return fmt.Errorf("Replicas can't be set when autoscaling is enabled")
+		}
+		minValidator := minReplicaValidator(hostedCP, multiAZMachinePool)
+		if err := minValidator(e.Autoscaling.MinReplicas); err != nil {
+			return err
+		}
+		if err := maxReplicaValidator(e.Autoscaling.MinReplicas, multiAZMachinePool)(
+			e.Autoscaling.MaxReplicas); err != nil {
+			return err
+		}
+	} else {
+		if e.Replicas == nil {
+			return fmt.Errorf("Expected either replicas or autoscaling to be set")
+		}
+		if err := minReplicaValidator(hostedCP, multiAZMachinePool)(*e.Replicas); err != nil {
+			return err
+		}
+	}
+
+	// Labels and taints:
+	for key, value := range e.Labels {
+		if err := ValidateLabelKeyValuePair(key, value); err != nil {
+			return err
+		}
+	}
+	if _, err := ParseTaints(strings.Join(e.Taints, ",")); err != nil {
+		return err
+	}
+
+	// Options that only apply to one of the cluster topologies:
+	if e.AvailabilityZone != "" && e.Subnet != "" {
+		return fmt.Errorf("Setting both 'subnet' and 'availability_zone' is not supported")
+	}
+	if hostedCP {
+		if e.UseSpotInstances || e.SpotMaxPrice != "" {
+			return fmt.Errorf("Spot instances are not supported for Hosted Control Plane machine pools")
+		}
+	} else {
+		if e.Version != "" {
+			return fmt.Errorf("Setting 'version' is not supported on classic rosa clusters")
+		}
+		if e.Autorepair != nil {
+			return fmt.Errorf("Setting 'autorepair' is only supported for hosted clusters")
+		}
+		if e.AvailabilityZone != "" && !multiAZ {
+			return fmt.Errorf("Setting 'availability_zone' is only allowed for multi-AZ clusters")
+		}
+		if e.SpotMaxPrice != "" && !e.UseSpotInstances {
+			return fmt.Errorf("Can't set max price when not using spot instances")
+		}
+		if _, err := ParseSpotMaxPrice(e.SpotMaxPrice); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// TaintBuilders returns the builders of the taints of the entry, which must have been validated.
+func (e *ManifestEntry) TaintBuilders() []*cmv1.TaintBuilder {
+	taints, _ := ParseTaints(strings.Join(e.Taints, ","))
+	return taints
+}
+
+func minReplicaValidator(hostedCP bool, multiAZMachinePool bool) func(int) error {
+	return func(replicas int) error {
+		if hostedCP {
+			return MinNodePoolReplicaValidator()(replicas)
+		}
+		if replicas < 0 {
+			return fmt.Errorf("min-replicas must be a non-negative integer")
+		}
+		if multiAZMachinePool && replicas%3 != 0 {
+			return fmt.Errorf("Multi AZ clusters require that the replicas be a multiple of 3")
+		}
+		return nil
+	}
+}
+
+func maxReplicaValidator(minReplicas int, multiAZMachinePool bool) func(int) error {
+	return func(maxReplicas int) error {
+		if minReplicas > maxReplicas {
+			return fmt.Errorf("max-replicas must be greater or equal to min-replicas")
+		}
+		if multiAZMachinePool && maxReplicas%3 != 0 {
+			return fmt.Errorf("Multi AZ clusters require that the replicas be a multiple of 3")
+		}
+		return nil
+	}
+}
@@ -0,0 +1,107 @@
+package machinepools
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Machine pool manifest", func() {
+	var dir string
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+	})
+
+	write := func(content string) string {
+		path := filepath.Join(dir, "pools.yaml")
+		Expect(os.WriteFile(path, []byte(content), 0600)).To(Succeed())
+		return path
+	}
+
+	It("Loads and validates a manifest", func() {
+		manifest, err := LoadManifest(write(`
+machine_pools:
+- name: infra
+  instance_type: r5.xlarge
+  replicas: 3
+  labels:
+    node-role.kubernetes.io/infra: ""
+  taints:
+  - node-role.kubernetes.io/infra=reserved:NoSchedule
+- name: workers
+  autoscaling:
+    min_replicas: 3
+    max_replicas: 9
+`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(manifest.MachinePools).To(HaveLen(2))
+		Expect(*manifest.MachinePools[0].Replicas).To(Equal(3))
+		Expect(manifest.MachinePools[0].TaintBuilders()).To(HaveLen(1))
+		Expect(manifest.MachinePools[1].Autoscaling.MaxReplicas).To(Equal(9))
+		Expect(manifest.Validate(false, true)).To(Succeed())
+	})
+
+	It("Rejects unknown fields", func() {
+		_, err := LoadManifest(write(`
+machine_pools:
+- name: infra
+  replica: 3
+`))
+		Expect(err).To(MatchError(ContainSubstring(`unknown field "replica"`)))
+	})
+
+	It("Reports the problems of all entries", func() {
+		manifest, err := LoadManifest(write(`
+machine_pools:
+- name: a
+  replicas: 2
+- name: b
+  replicas: 1
+  autoscaling:
+    min_replicas: 1
+    max_replicas: 2
+- name: a
+  autoscaling:
+    min_replicas: 3
+    max_replicas: 1
+`))
+		Expect(err).NotTo(HaveOccurred())
+		err = manifest.Validate(false, true)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("Machine pool #1: Multi AZ clusters require"))
+		Expect(err.Error()).To(ContainSubstring("Machine pool #2: Replicas can't be set"))
+		Expect(err.Error()).To(ContainSubstring("Machine pool 'a' is defined more than once"))
+		Expect(err.Error()).To(ContainSubstring("Machine pool #3: max-replicas must be greater"))
+	})
+
+	DescribeTable("Entry validation",
+		func(entry ManifestEntry, hostedCP bool, expectedError string) {
+			err := entry.Validate(hostedCP, false)
+			if expectedError == "" {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(err).To(MatchError(ContainSubstring(expectedError)))
+			}
+		},
+		Entry("Valid classic pool", ManifestEntry{Name: "mp", Replicas: intPtr(0)}, false, ""),
+		Entry("Invalid name", ManifestEntry{Name: "MP", Replicas: intPtr(1)}, false, "valid name"),
+		Entry("Missing replicas", ManifestEntry{Name: "mp"}, false, "either replicas or autoscaling"),
+		Entry("Zero replicas on hosted", ManifestEntry{Name: "mp", Replicas: intPtr(0)}, true,
+			"greater than zero"),
+		Entry("Spot on hosted", ManifestEntry{Name: "mp", Replicas: intPtr(1), UseSpotInstances: true}, true,
+			"Spot instances are not supported"),
+		Entry("Spot price without spot", ManifestEntry{Name: "mp", Replicas: intPtr(1), SpotMaxPrice: "0.5"},
+			false, "Can't set max price"),
+		Entry("Version on classic", ManifestEntry{Name: "mp", Replicas: intPtr(1), Version: "4.12.4"}, false,
+			"not supported on classic"),
+		Entry("Invalid taint", ManifestEntry{Name: "mp", Replicas: intPtr(1), Taints: []string{"foo"}}, false,
+			"Expected key=value:scheduleType"),
+	)
+})
+
+func intPtr(value int) *int {
+	return &value
+}
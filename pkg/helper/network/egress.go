@@ -0,0 +1,172 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the egress endpoints that the subnets of a cluster must be able to reach. They
+// are a static copy of the lists that the network verifier checks for the commercial AWS partition,
+// as the verifier runs in OCM and doesn't publish them, so keep them in sync with it:
+//
+//	https://github.com/openshift/osd-network-verifier
+//
+// GovCloud and the other partitions use different endpoints that aren't included.
+
+package network
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+)
+
+// egressPartition is the AWS partition whose endpoints are included.
+const egressPartition = endpoints.AwsPartitionID
+
+// regionPlaceholder is replaced with the region of the cluster in the host of the endpoints.
+const regionPlaceholder = "{region}"
+
+// Categories of the egress endpoints, describing what they are used for.
+const (
+	CategoryInstall   = "install"
+	CategoryTelemetry = "telemetry"
+	CategoryAWS       = "aws"
+	CategorySRE       = "sre"
+)
+
+// EgressEndpoint is a destination that the subnets of a cluster must be able to reach.
+type EgressEndpoint struct {
+	Host     string `json:"host"`
+	Port     int    `json:"port"`
+	Protocol string `json:"protocol"`
+	Category string `json:"category"`
+}
+
+// classicEndpoints are the endpoints needed by classic clusters, where the control plane runs in the
+// subnets of the cluster.
+var classicEndpoints = []*EgressEndpoint{
+	{Host: "registry.redhat.io", Port: 443, Protocol: "tcp", Category: CategoryInstall},
+	{Host: "quay.io", Port: 443, Protocol: "tcp", Category: CategoryInstall},
+	{Host: "cdn01.quay.io", Port: 443, Protocol: "tcp", Category: CategoryInstall},
+	{Host: "cdn02.quay.io", Port: 443, Protocol: "tcp", Category: CategoryInstall},
+	{Host: "cdn03.quay.io", Port: 443, Protocol: "tcp", Category: CategoryInstall},
+	{Host: "sso.redhat.com", Port: 443, Protocol: "tcp", Category: CategoryInstall},
+	{Host: "quay-registry.s3.amazonaws.com", Port: 443, Protocol: "tcp", Category: CategoryInstall},
+	{Host: "ocm-quay-production-s3.s3.amazonaws.com", Port: 443, Protocol: "tcp", Category: CategoryInstall},
+	{Host: "quayio-production-s3.s3.amazonaws.com", Port: 443, Protocol: "tcp", Category: CategoryInstall},
+	{Host: "cart-rhcos-ci.s3.amazonaws.com", Port: 443, Protocol: "tcp", Category: CategoryInstall},
+	{Host: "registry.access.redhat.com", Port: 443, Protocol: "tcp", Category: CategoryInstall},
+	{Host: "console.redhat.com", Port: 443, Protocol: "tcp", Category: CategoryInstall},
+	{Host: "pull.q1w2.quay.rhcloud.com", Port: 443, Protocol: "tcp", Category: CategoryInstall},
+	{Host: "mirror.openshift.com", Port: 443, Protocol: "tcp", Category: CategoryInstall},
+	{Host: "storage.googleapis.com", Port: 443, Protocol: "tcp", Category: CategoryInstall},
+	{Host: "api.openshift.com", Port: 443, Protocol: "tcp", Category: CategoryInstall},
+	{Host: "cert-api.access.redhat.com", Port: 443, Protocol: "tcp", Category: CategoryTelemetry},
+	{Host: "api.access.redhat.com", Port: 443, Protocol: "tcp", Category: CategoryTelemetry},
+	{Host: "infogw.api.openshift.com", Port: 443, Protocol: "tcp", Category: CategoryTelemetry},
+	{Host: "observatorium.api.openshift.com", Port: 443, Protocol: "tcp", Category: CategoryTelemetry},
+	{Host: "observatorium-mst.api.openshift.com", Port: 443, Protocol: "tcp", Category: CategoryTelemetry},
+	{Host: "ec2.amazonaws.com", Port: 443, Protocol: "tcp", Category: CategoryAWS},
+	{Host: "ec2.{region}.amazonaws.com", Port: 443, Protocol: "tcp", Category: CategoryAWS},
+	{Host: "events.{region}.amazonaws.com", Port: 443, Protocol: "tcp", Category: CategoryAWS},
+	{Host: "elasticloadbalancing.{region}.amazonaws.com", Port: 443, Protocol: "tcp", Category: CategoryAWS},
+	{Host: "iam.amazonaws.com", Port: 443, Protocol: "tcp", Category: CategoryAWS},
+	{Host: "route53.amazonaws.com", Port: 443, Protocol: "tcp", Category: CategoryAWS},
+	{Host: "sts.amazonaws.com", Port: 443, Protocol: "tcp", Category: CategoryAWS},
+	{Host: "sts.{region}.amazonaws.com", Port: 443, Protocol: "tcp", Category: CategoryAWS},
+	{Host: "tagging.us-east-1.amazonaws.com", Port: 443, Protocol: "tcp", Category: CategoryAWS},
+	{Host: "api.pagerduty.com", Port: 443, Protocol: "tcp", Category: CategorySRE},
+	{Host: "events.pagerduty.com", Port: 443, Protocol: "tcp", Category: CategorySRE},
+	{Host: "api.deadmanssnitch.com", Port: 443, Protocol: "tcp", Category: CategorySRE},
+	{Host: "nosnch.in", Port: 443, Protocol: "tcp", Category: CategorySRE},
+	{Host: "http-inputs-osdsecuritylogs.splunkcloud.com", Port: 443, Protocol: "tcp", Category: CategorySRE},
+	{Host: "sftp.access.redhat.com", Port: 22, Protocol: "tcp", Category: CategorySRE},
+}
+
+// hostedCPEndpoints are the endpoints needed by hosted clusters, where only the workers run in the
+// subnets of the cluster.
+var hostedCPEndpoints = []*EgressEndpoint{
+	{Host: "registry.redhat.io", Port: 443, Protocol: "tcp", Category: CategoryInstall},
+	{Host: "quay.io", Port: 443, Protocol: "tcp", Category: CategoryInstall},
+	{Host: "cdn01.quay.io", Port: 443, Protocol: "tcp", Category: CategoryInstall},
+	{Host: "cdn02.quay.io", Port: 443, Protocol: "tcp", Category: CategoryInstall},
+	{Host: "cdn03.quay.io", Port: 443, Protocol: "tcp", Category: CategoryInstall},
+	{Host: "quayio-production-s3.s3.amazonaws.com", Port: 443, Protocol: "tcp", Category: CategoryInstall},
+	{Host: "registry.access.redhat.com", Port: 443, Protocol: "tcp", Category: CategoryInstall},
+	{Host: "access.redhat.com", Port: 443, Protocol: "tcp", Category: CategoryInstall},
+	{Host: "sso.redhat.com", Port: 443, Protocol: "tcp", Category: CategoryInstall},
+	{Host: "api.openshift.com", Port: 443, Protocol: "tcp", Category: CategoryInstall},
+	{Host: "mirror.openshift.com", Port: 443, Protocol: "tcp", Category: CategoryInstall},
+	{Host: "cert-api.access.redhat.com", Port: 443, Protocol: "tcp", Category: CategoryTelemetry},
+	{Host: "api.access.redhat.com", Port: 443, Protocol: "tcp", Category: CategoryTelemetry},
+	{Host: "infogw.api.openshift.com", Port: 443, Protocol: "tcp", Category: CategoryTelemetry},
+	{Host: "console.redhat.com", Port: 443, Protocol: "tcp", Category: CategoryTelemetry},
+	{Host: "sts.{region}.amazonaws.com", Port: 443, Protocol: "tcp", Category: CategoryAWS},
+}
+
+// EgressEndpoints returns the endpoints that the subnets of a cluster in the given region must be
+// able to reach, sorted by category and host. It fails for the regions of the partitions whose
+// endpoints aren't included, like GovCloud.
+func EgressEndpoints(region string, hostedCP bool) ([]*EgressEndpoint, error) {
+	partition, ok := endpoints.PartitionForRegion(endpoints.DefaultPartitions(), region)
+	if !ok {
+		return nil, fmt.Errorf("Region '%s' isn't a valid AWS region", region)
+	}
+	if partition.ID() != egressPartition {
+		return nil, fmt.Errorf("The egress endpoints of region '%s' aren't known, only the ones of "+
+			"the commercial AWS regions are included, not the ones of the '%s' partition",
+			region, partition.ID())
+	}
+	list := classicEndpoints
+	if hostedCP {
+		list = hostedCPEndpoints
+	}
+	result := make([]*EgressEndpoint, len(list))
+	for i, endpoint := range list {
+		result[i] = &EgressEndpoint{
+			Host:     strings.ReplaceAll(endpoint.Host, regionPlaceholder, region),
+			Port:     endpoint.Port,
+			Protocol: endpoint.Protocol,
+			Category: endpoint.Category,
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Category != result[j].Category {
+			return result[i].Category < result[j].Category
+		}
+		return result[i].Host < result[j].Host
+	})
+	return result, nil
+}
+
+// WriteEgressCSV writes the endpoints as CSV with a header, one firewall rule per line.
+func WriteEgressCSV(writer io.Writer, endpoints []*EgressEndpoint) error {
+	csvWriter := csv.NewWriter(writer)
+	err := csvWriter.Write([]string{"host", "port", "protocol", "category"})
+	if err != nil {
+		return err
+	}
+	for _, endpoint := range endpoints {
+		err = csvWriter.Write([]string{endpoint.Host, fmt.Sprint(endpoint.Port), endpoint.Protocol,
+			endpoint.Category})
+		if err != nil {
+			return err
+		}
+	}
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
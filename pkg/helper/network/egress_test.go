@@ -0,0 +1,49 @@
+package network
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Egress endpoints", func() {
+	It("Replaces the region in the hosts", func() {
+		endpoints, err := EgressEndpoints("eu-west-1", true)
+		Expect(err).ToNot(HaveOccurred())
+		hosts := []string{}
+		for _, endpoint := range endpoints {
+			Expect(endpoint.Host).ToNot(ContainSubstring(regionPlaceholder))
+			hosts = append(hosts, endpoint.Host)
+		}
+		Expect(hosts).To(ContainElement("sts.eu-west-1.amazonaws.com"))
+		Expect(hostedCPEndpoints[len(hostedCPEndpoints)-1].Host).To(Equal("sts.{region}.amazonaws.com"))
+	})
+	It("Sorts the endpoints by category", func() {
+		endpoints, err := EgressEndpoints("us-east-1", false)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(endpoints[0].Category).To(Equal(CategoryAWS))
+		Expect(endpoints[len(endpoints)-1].Category).To(Equal(CategoryTelemetry))
+		Expect(len(endpoints)).To(BeNumerically(">", len(hostedCPEndpoints)))
+	})
+	DescribeTable("Rejects the regions whose endpoints aren't known",
+		func(region string, message string) {
+			_, err := EgressEndpoints(region, false)
+			Expect(err).To(MatchError(ContainSubstring(message)))
+		},
+		Entry("GovCloud", "us-gov-west-1", "not the ones of the 'aws-us-gov' partition"),
+		Entry("China", "cn-north-1", "not the ones of the 'aws-cn' partition"),
+		Entry("invalid", "mars-1", "isn't a valid AWS region"),
+	)
+	It("Writes CSV firewall rules", func() {
+		var buffer bytes.Buffer
+		err := WriteEgressCSV(&buffer, []*EgressEndpoint{
+			{Host: "quay.io", Port: 443, Protocol: "tcp", Category: CategoryInstall},
+			{Host: "sftp.access.redhat.com", Port: 22, Protocol: "tcp", Category: CategorySRE},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(buffer.String()).To(Equal("host,port,protocol,category\n" +
+			"quay.io,443,tcp,install\n" +
+			"sftp.access.redhat.com,22,tcp,sre\n"))
+	})
+})
@@ -0,0 +1,133 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions used to verify the network of several subnets concurrently.
+
+package network
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openshift/rosa/pkg/helper/parallel"
+	"github.com/openshift/rosa/pkg/ocm"
+)
+
+// SubnetResult is the outcome of the verification of a subnet.
+type SubnetResult struct {
+	SubnetID string            `json:"subnet_id"`
+	State    string            `json:"state"`
+	Latency  string            `json:"latency"`
+	Failures []*EndpointResult `json:"failures,omitempty"`
+	Error    string            `json:"error,omitempty"`
+}
+
+// EndpointResult describes an egress endpoint that couldn't be reached from a subnet.
+type EndpointResult struct {
+	Endpoint string `json:"endpoint,omitempty"`
+	Reason   string `json:"reason"`
+}
+
+// Passed checks if the subnet can reach all the required endpoints.
+func (r *SubnetResult) Passed() bool {
+	return r.State == ocm.NetworkVerificationPassed
+}
+
+// Run calls the check function for each of the subnets, using the given number of concurrent
+// workers. The report function, if not nil, is called with every result as soon as it is ready,
+// never concurrently. The returned results keep the order of the subnets.
+func Run(subnetIDs []string, concurrency int, check func(string) *SubnetResult,
+	report func(*SubnetResult)) []*SubnetResult {
+	results := make([]*SubnetResult, len(subnetIDs))
+	var lock sync.Mutex
+	tasks := make([]func() error, len(subnetIDs))
+	for i := range subnetIDs {
+		index := i
+		tasks[index] = func() error {
+			result := check(subnetIDs[index])
+			lock.Lock()
+			defer lock.Unlock()
+			results[index] = result
+			if report != nil {
+				report(result)
+			}
+			return nil
+		}
+	}
+	parallel.Run(concurrency, tasks...)
+	return results
+}
+
+// Poller returns a check function that polls the state of the verification of a subnet until it
+// finishes or the timeout expires. The latency is the time it took to get the final state.
+func Poller(get func(string) (*ocm.SubnetNetworkVerification, error), interval time.Duration,
+	timeout time.Duration) func(string) *SubnetResult {
+	return func(subnetID string) *SubnetResult {
+		start := time.Now()
+		result := &SubnetResult{SubnetID: subnetID}
+		for {
+			verification, err := get(subnetID)
+			if err != nil {
+				result.State = ocm.NetworkVerificationFailed
+				result.Error = err.Error()
+				break
+			}
+			result.State = verification.State
+			if verification.State == ocm.NetworkVerificationPassed ||
+				verification.State == ocm.NetworkVerificationFailed {
+				result.Failures = ParseDetails(verification.Details)
+				break
+			}
+			if time.Since(start) >= timeout {
+				result.Error = "timed out waiting for the verification to finish"
+				break
+			}
+			time.Sleep(interval)
+		}
+		result.Latency = time.Since(start).Round(time.Millisecond).String()
+		return result
+	}
+}
+
+// The verifier reports unreachable endpoints like 'egressURL error: https://host:443 (reason)':
+var endpointDetailRE = regexp.MustCompile(`^(?:egressURL error:\s*)?(\S+://\S+|\S+:\d+)\s*(?:\((.*)\))?\s*$`)
+
+// ParseDetails splits the details of a failed verification into the endpoints and the reasons why
+// they couldn't be reached. Details that don't name an endpoint are kept as reasons.
+func ParseDetails(details []string) []*EndpointResult {
+	results := []*EndpointResult{}
+	for _, detail := range details {
+		for _, line := range strings.Split(detail, "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" {
+				continue
+			}
+			match := endpointDetailRE.FindStringSubmatch(line)
+			if match == nil {
+				results = append(results, &EndpointResult{Reason: line})
+				continue
+			}
+			reason := match[2]
+			if reason == "" {
+				reason = "unreachable"
+			}
+			results = append(results, &EndpointResult{Endpoint: match[1], Reason: reason})
+		}
+	}
+	return results
+}
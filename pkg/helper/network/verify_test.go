@@ -0,0 +1,87 @@
+package network
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/rosa/pkg/ocm"
+)
+
+var _ = Describe("Network verification", func() {
+	It("Checks the subnets concurrently and keeps their order", func() {
+		var running, maxRunning int32
+		check := func(subnetID string) *SubnetResult {
+			current := atomic.AddInt32(&running, 1)
+			for {
+				max := atomic.LoadInt32(&maxRunning)
+				if current <= max || atomic.CompareAndSwapInt32(&maxRunning, max, current) {
+					break
+				}
+			}
+			time.Sleep(20 * time.Millisecond)
+			atomic.AddInt32(&running, -1)
+			return &SubnetResult{SubnetID: subnetID, State: ocm.NetworkVerificationPassed}
+		}
+		reported := 0
+		subnets := []string{"subnet-1", "subnet-2", "subnet-3", "subnet-4", "subnet-5"}
+		results := Run(subnets, 3, check, func(*SubnetResult) { reported++ })
+		Expect(reported).To(Equal(len(subnets)))
+		Expect(maxRunning).To(BeNumerically(">", 1))
+		Expect(maxRunning).To(BeNumerically("<=", 3))
+		for i, result := range results {
+			Expect(result.SubnetID).To(Equal(subnets[i]))
+		}
+	})
+
+	It("Polls until the verification finishes", func() {
+		calls := 0
+		get := func(subnetID string) (*ocm.SubnetNetworkVerification, error) {
+			calls++
+			if calls < 3 {
+				return &ocm.SubnetNetworkVerification{ID: subnetID, State: ocm.NetworkVerificationRunning}, nil
+			}
+			return &ocm.SubnetNetworkVerification{
+				ID:      subnetID,
+				State:   ocm.NetworkVerificationFailed,
+				Details: []string{"egressURL error: https://registry.redhat.io:443 (timeout)"},
+			}, nil
+		}
+		result := Poller(get, time.Millisecond, time.Minute)("subnet-1")
+		Expect(calls).To(Equal(3))
+		Expect(result.Passed()).To(BeFalse())
+		Expect(result.Latency).NotTo(BeEmpty())
+		Expect(result.Failures).To(Equal([]*EndpointResult{
+			{Endpoint: "https://registry.redhat.io:443", Reason: "timeout"},
+		}))
+	})
+
+	It("Reports errors and timeouts", func() {
+		result := Poller(func(string) (*ocm.SubnetNetworkVerification, error) {
+			return nil, fmt.Errorf("not found")
+		}, time.Millisecond, time.Minute)("subnet-1")
+		Expect(result.State).To(Equal(ocm.NetworkVerificationFailed))
+		Expect(result.Error).To(Equal("not found"))
+
+		result = Poller(func(subnetID string) (*ocm.SubnetNetworkVerification, error) {
+			return &ocm.SubnetNetworkVerification{ID: subnetID, State: ocm.NetworkVerificationPending}, nil
+		}, time.Millisecond, 5*time.Millisecond)("subnet-1")
+		Expect(result.State).To(Equal(ocm.NetworkVerificationPending))
+		Expect(result.Error).To(ContainSubstring("timed out"))
+	})
+
+	It("Parses the failure details", func() {
+		Expect(ParseDetails([]string{
+			"egressURL error: https://api.openshift.com:443 (connection refused)\n" +
+				"egressURL error: quay.io:443",
+			"internal error",
+		})).To(Equal([]*EndpointResult{
+			{Endpoint: "https://api.openshift.com:443", Reason: "connection refused"},
+			{Endpoint: "quay.io:443", Reason: "unreachable"},
+			{Reason: "internal error"},
+		}))
+	})
+})
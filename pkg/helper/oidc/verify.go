@@ -0,0 +1,258 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oidc
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/helper"
+)
+
+const discoveryPath = "/.well-known/openid-configuration"
+
+// Names of the checks, as reported in the results
+const (
+	CheckIssuer       = "issuer"
+	CheckProvider     = "provider"
+	CheckOperatorRole = "operator-role"
+)
+
+// OperatorRole is an operator role that should trust the OIDC provider
+type OperatorRole struct {
+	Namespace       string
+	Name            string
+	RoleARN         string
+	ServiceAccounts []string
+}
+
+// NewOperatorRole returns the operator role with the service accounts of the matching operator
+// credential request. When there is no matching request the role is only checked to trust the
+// provider.
+func NewOperatorRole(namespace string, name string, roleARN string,
+	credRequests map[string]*cmv1.STSOperator) OperatorRole {
+	role := OperatorRole{
+		Namespace: namespace,
+		Name:      name,
+		RoleARN:   roleARN,
+	}
+	for _, operator := range credRequests {
+		if operator.Namespace() == namespace && operator.Name() == name {
+			role.ServiceAccounts = operator.ServiceAccounts()
+			break
+		}
+	}
+	return role
+}
+
+// Result is the outcome of one of the checks. Error is empty when the check passed, and Warning
+// describes a problem that doesn't make the check fail.
+type Result struct {
+	Check   string `json:"check"`
+	Target  string `json:"target"`
+	Error   string `json:"error,omitempty"`
+	Warning string `json:"warning,omitempty"`
+}
+
+// Warning is an error returned by the checks for problems that don't prevent the OIDC
+// configuration from working.
+type Warning struct {
+	message string
+}
+
+func (w *Warning) Error() string {
+	return w.message
+}
+
+// IsWarning checks if the error returned by a check is only a warning.
+func IsWarning(err error) bool {
+	var warning *Warning
+	return errors.As(err, &warning)
+}
+
+var httpClient = &http.Client{
+	Timeout: 30 * time.Second,
+}
+
+// Verify checks that the issuer URL is reachable, that the IAM OIDC provider of the account
+// matches it, and that each operator role trusts the provider for the service accounts of the
+// operator.
+func Verify(awsClient aws.Client, accountID string, issuerURL string, roles []OperatorRole) []*Result {
+	results := []*Result{}
+	add := func(check string, target string, err error) {
+		result := &Result{Check: check, Target: target}
+		if IsWarning(err) {
+			result.Warning = err.Error()
+		} else if err != nil {
+			result.Error = err.Error()
+		}
+		results = append(results, result)
+	}
+
+	add(CheckIssuer, issuerURL, VerifyIssuer(issuerURL))
+
+	provider, err := awsClient.GetOpenIDConnectProvider(issuerURL, accountID)
+	if err == nil {
+		err = VerifyProvider(provider, issuerURL)
+	}
+	if provider == nil {
+		add(CheckProvider, issuerURL, err)
+		return results
+	}
+	add(CheckProvider, provider.ARN, err)
+
+	for _, role := range roles {
+		add(CheckOperatorRole, role.RoleARN, VerifyOperatorRole(awsClient, provider, role))
+	}
+	return results
+}
+
+// Failed returns the results of the checks that didn't pass
+func Failed(results []*Result) []*Result {
+	failed := []*Result{}
+	for _, result := range results {
+		if result.Error != "" {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// VerifyIssuer checks that the discovery document of the issuer can be retrieved and that it
+// describes the issuer.
+func VerifyIssuer(issuerURL string) error {
+	return verifyIssuer(httpClient, issuerURL)
+}
+
+// VerifyIssuerWithCA is like VerifyIssuer, but trusts the certificate authorities of the given PEM
+// bundle, if not empty, instead of the ones of the system.
+func VerifyIssuerWithCA(issuerURL string, ca []byte) error {
+	if len(ca) == 0 {
+		return VerifyIssuer(issuerURL)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return fmt.Errorf("Issuer CA bundle doesn't contain any valid PEM certificate")
+	}
+	client := &http.Client{
+		Timeout: httpClient.Timeout,
+		Transport: &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12},
+		},
+	}
+	return verifyIssuer(client, issuerURL)
+}
+
+func verifyIssuer(client *http.Client, issuerURL string) error {
+	discoveryURL := strings.TrimSuffix(issuerURL, "/") + discoveryPath
+	response, err := client.Get(discoveryURL)
+	if err != nil {
+		return fmt.Errorf("Issuer URL isn't reachable: %v", err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("Failed to get '%s': %s", discoveryURL, response.Status)
+	}
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("Failed to read '%s': %v", discoveryURL, err)
+	}
+	return checkDiscoveryDocument(issuerURL, body)
+}
+
+func checkDiscoveryDocument(issuerURL string, body []byte) error {
+	var document struct {
+		Issuer  string `json:"issuer"`
+		JWKSURI string `json:"jwks_uri"`
+	}
+	err := json.Unmarshal(body, &document)
+	if err != nil {
+		return fmt.Errorf("Failed to parse discovery document: %v", err)
+	}
+	if strings.TrimSuffix(document.Issuer, "/") != strings.TrimSuffix(issuerURL, "/") {
+		return fmt.Errorf("Discovery document is for issuer '%s' instead of '%s'", document.Issuer, issuerURL)
+	}
+	if document.JWKSURI == "" {
+		return fmt.Errorf("Discovery document doesn't have a JWKS URI")
+	}
+	return nil
+}
+
+// VerifyProvider checks that the IAM OIDC provider exists, accepts the audiences of the operator
+// tokens and has the thumbprint of the certificate currently served by the issuer. AWS doesn't use
+// the thumbprint for issuers whose certificate is signed by a trusted certificate authority, so a
+// mismatch is only returned as a Warning.
+func VerifyProvider(provider *aws.OIDCProvider, issuerURL string) error {
+	if provider == nil {
+		return fmt.Errorf("OIDC provider doesn't exist. Run 'rosa create oidc-provider "+
+			"--oidc-endpoint-url %s' to create it", issuerURL)
+	}
+	thumbprint, err := aws.GetThumbprint(issuerURL)
+	if err != nil {
+		return fmt.Errorf("Failed to get the thumbprint of the issuer: %v", err)
+	}
+	return checkProvider(provider, thumbprint)
+}
+
+func checkProvider(provider *aws.OIDCProvider, thumbprint string) error {
+	for _, clientID := range []string{aws.OIDCClientIDOpenShift, aws.OIDCClientIDSTSAWS} {
+		if !helper.Contains(provider.ClientIDs, clientID) {
+			return fmt.Errorf("OIDC provider doesn't have client ID '%s'", clientID)
+		}
+	}
+	for _, providerThumbprint := range provider.Thumbprints {
+		if strings.EqualFold(providerThumbprint, thumbprint) {
+			return nil
+		}
+	}
+	return &Warning{
+		message: fmt.Sprintf("OIDC provider thumbprints [%s] don't include the thumbprint '%s' of the "+
+			"issuer certificate", strings.Join(provider.Thumbprints, ", "), thumbprint),
+	}
+}
+
+// VerifyOperatorRole checks that the trust policy of the operator role allows its service accounts
+// to assume it with tokens of the OIDC provider.
+func VerifyOperatorRole(awsClient aws.Client, provider *aws.OIDCProvider, role OperatorRole) error {
+	iamRole, err := awsClient.GetRoleByARN(role.RoleARN)
+	if err != nil {
+		return err
+	}
+	return aws.VerifyOperatorRoleTrustPolicy(awssdk.StringValue(iamRole.AssumeRolePolicyDocument), provider.ARN,
+		ServiceAccountSubjects(role.Namespace, role.ServiceAccounts))
+}
+
+// ServiceAccountSubjects returns the subjects of the tokens of the service accounts
+func ServiceAccountSubjects(namespace string, serviceAccounts []string) []string {
+	subjects := make([]string, len(serviceAccounts))
+	for i, serviceAccount := range serviceAccounts {
+		subjects[i] = fmt.Sprintf("system:serviceaccount:%s:%s", namespace, serviceAccount)
+	}
+	return subjects
+}
@@ -0,0 +1,149 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oidc
+
+import (
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/aws"
+)
+
+var _ = Describe("Discovery document", func() {
+	const issuerURL = "https://oidc.example.com/abc"
+
+	It("accepts the document of the issuer", func() {
+		body := `{"issuer": "https://oidc.example.com/abc", "jwks_uri": "https://oidc.example.com/abc/keys.json"}`
+		Expect(checkDiscoveryDocument(issuerURL, []byte(body))).To(Succeed())
+	})
+
+	It("rejects the document of another issuer", func() {
+		body := `{"issuer": "https://oidc.example.com/def", "jwks_uri": "https://oidc.example.com/def/keys.json"}`
+		Expect(checkDiscoveryDocument(issuerURL, []byte(body))).To(
+			MatchError(ContainSubstring("is for issuer 'https://oidc.example.com/def'")))
+	})
+
+	It("rejects a document without keys", func() {
+		body := `{"issuer": "https://oidc.example.com/abc"}`
+		Expect(checkDiscoveryDocument(issuerURL, []byte(body))).To(MatchError(ContainSubstring("JWKS URI")))
+	})
+
+	It("rejects an invalid document", func() {
+		Expect(checkDiscoveryDocument(issuerURL, []byte("<html>"))).To(HaveOccurred())
+	})
+
+	Context("served with a private certificate authority", func() {
+		var server *httptest.Server
+
+		BeforeEach(func() {
+			server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				Expect(r.URL.Path).To(Equal(discoveryPath))
+				fmt.Fprintf(w, `{"issuer": "https://%s", "jwks_uri": "https://%s/keys.json"}`, r.Host, r.Host)
+			}))
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("accepts the issuer when the certificate authority is given", func() {
+			ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+			Expect(VerifyIssuerWithCA(server.URL, ca)).To(Succeed())
+		})
+
+		It("rejects the issuer with the certificate authorities of the system", func() {
+			Expect(VerifyIssuerWithCA(server.URL, nil)).To(MatchError(ContainSubstring("isn't reachable")))
+		})
+
+		It("rejects a bundle without certificates", func() {
+			Expect(VerifyIssuerWithCA(server.URL, []byte("junk"))).To(
+				MatchError(ContainSubstring("doesn't contain any valid PEM certificate")))
+		})
+	})
+})
+
+var _ = Describe("Provider", func() {
+	provider := func(clientIDs ...string) *aws.OIDCProvider {
+		return &aws.OIDCProvider{
+			ARN:         "arn:aws:iam::123456789012:oidc-provider/oidc.example.com/abc",
+			ClientIDs:   clientIDs,
+			Thumbprints: []string{"0123ABCD"},
+		}
+	}
+
+	It("accepts a provider with the client IDs and the thumbprint", func() {
+		Expect(checkProvider(provider("openshift", "sts.amazonaws.com"), "0123abcd")).To(Succeed())
+	})
+
+	It("rejects a provider without the STS client ID", func() {
+		Expect(checkProvider(provider("openshift"), "0123abcd")).To(
+			MatchError(ContainSubstring("client ID 'sts.amazonaws.com'")))
+	})
+
+	It("warns about a provider with an outdated thumbprint", func() {
+		err := checkProvider(provider("openshift", "sts.amazonaws.com"), "4567ef")
+		Expect(err).To(MatchError(ContainSubstring("don't include the thumbprint '4567ef'")))
+		Expect(IsWarning(err)).To(BeTrue())
+	})
+
+	It("doesn't consider the missing client IDs a warning", func() {
+		Expect(IsWarning(checkProvider(provider("openshift"), "0123abcd"))).To(BeFalse())
+	})
+
+	It("rejects a provider that doesn't exist", func() {
+		Expect(VerifyProvider(nil, "https://oidc.example.com/abc")).To(
+			MatchError(ContainSubstring("rosa create oidc-provider --oidc-endpoint-url https://oidc.example.com/abc")))
+	})
+})
+
+var _ = Describe("Operator roles", func() {
+	It("uses the service accounts of the matching credential request", func() {
+		operator, err := cmv1.NewSTSOperator().
+			Namespace("openshift-image-registry").
+			Name("installer-cloud-credentials").
+			ServiceAccounts("cluster-image-registry-operator", "registry").
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		credRequests := map[string]*cmv1.STSOperator{"image_registry": operator}
+
+		role := NewOperatorRole("openshift-image-registry", "installer-cloud-credentials",
+			"arn:aws:iam::123456789012:role/prefix-openshift-image-registry-installer-cloud-credential",
+			credRequests)
+		Expect(ServiceAccountSubjects(role.Namespace, role.ServiceAccounts)).To(Equal([]string{
+			"system:serviceaccount:openshift-image-registry:cluster-image-registry-operator",
+			"system:serviceaccount:openshift-image-registry:registry",
+		}))
+
+		role = NewOperatorRole("openshift-ingress-operator", "cloud-credentials", "", credRequests)
+		Expect(role.ServiceAccounts).To(BeEmpty())
+	})
+
+	It("reports the failed checks", func() {
+		results := []*Result{
+			{Check: CheckIssuer, Target: "https://oidc.example.com/abc"},
+			{Check: CheckProvider, Target: "arn", Error: "missing"},
+			{Check: CheckOperatorRole, Target: "arn", Warning: "thumbprint"},
+		}
+		Expect(Failed(results)).To(Equal(results[1:2]))
+	})
+})
@@ -0,0 +1,64 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions used to run independent checks concurrently.
+
+package parallel
+
+import (
+	"sync"
+)
+
+// DefaultLimit is the number of checks that run at the same time, low enough to stay clear of the
+// request rate limits of the AWS and OCM APIs.
+const DefaultLimit = 4
+
+// Run calls the given tasks using at most limit concurrent workers, and waits for all of them to
+// finish. The returned errors keep the order of the tasks, with nil for the tasks that succeeded.
+func Run(limit int, tasks ...func() error) []error {
+	if limit < 1 {
+		limit = 1
+	}
+	errs := make([]error, len(tasks))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < limit && i < len(tasks); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range indexes {
+				errs[index] = tasks[index]()
+			}
+		}()
+	}
+	for i := range tasks {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	return errs
+}
+
+// FirstError runs the given tasks like Run, and returns the error of the first task in order that
+// failed, so that the reported error doesn't depend on which check finished first.
+func FirstError(limit int, tasks ...func() error) error {
+	for _, err := range Run(limit, tasks...) {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package parallel
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Run", func() {
+	It("never runs more tasks than the limit at the same time", func() {
+		var running, maximum int32
+		tasks := make([]func() error, 10)
+		for i := range tasks {
+			tasks[i] = func() error {
+				current := atomic.AddInt32(&running, 1)
+				for {
+					previous := atomic.LoadInt32(&maximum)
+					if current <= previous || atomic.CompareAndSwapInt32(&maximum, previous, current) {
+						break
+					}
+				}
+				time.Sleep(10 * time.Millisecond)
+				atomic.AddInt32(&running, -1)
+				return nil
+			}
+		}
+		Expect(Run(3, tasks...)).To(Equal(make([]error, 10)))
+		Expect(maximum).To(BeNumerically("<=", 3))
+		Expect(maximum).To(BeNumerically(">", 1))
+	})
+
+	It("keeps the errors in the order of the tasks", func() {
+		errs := Run(2,
+			func() error {
+				time.Sleep(20 * time.Millisecond)
+				return fmt.Errorf("slow")
+			},
+			func() error { return nil },
+			func() error { return fmt.Errorf("fast") },
+		)
+		Expect(errs).To(HaveLen(3))
+		Expect(errs[0]).To(MatchError("slow"))
+		Expect(errs[1]).ToNot(HaveOccurred())
+		Expect(errs[2]).To(MatchError("fast"))
+	})
+})
+
+var _ = Describe("FirstError", func() {
+	It("returns the error of the first failed task in order", func() {
+		err := FirstError(DefaultLimit,
+			func() error { return nil },
+			func() error {
+				time.Sleep(20 * time.Millisecond)
+				return fmt.Errorf("second")
+			},
+			func() error { return fmt.Errorf("third") },
+		)
+		Expect(err).To(MatchError("second"))
+	})
+
+	It("succeeds without tasks", func() {
+		Expect(FirstError(DefaultLimit)).To(Succeed())
+	})
+})
@@ -0,0 +1,84 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package roles
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+// DetectDrift compares the roles with their expected configuration, and prints the differences
+// together with the commands that fix them. The process exits with a non-zero code when any role
+// drifted, so that the check can be used in scripts. The missingHint is shown for roles that don't
+// exist.
+func DetectDrift(r *rosa.Runtime, expectedRoles []*aws.ExpectedRole, missingHint string) {
+	drifts := []*aws.RoleDrift{}
+	drifted := false
+	for _, expectedRole := range expectedRoles {
+		r.Reporter.Debugf("Checking drift of role '%s'", expectedRole.RoleName)
+		drift, err := r.AWSClient.DetectRoleDrift(expectedRole)
+		if err != nil {
+			r.Reporter.Errorf("Failed to check drift of role '%s': %v", expectedRole.RoleName, err)
+			os.Exit(1)
+		}
+		if drift.Missing || len(drift.Drifts) > 0 {
+			drifted = true
+		}
+		drifts = append(drifts, drift)
+	}
+
+	if output.HasFlag() {
+		err := output.Print(drifts)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+	} else {
+		printDrifts(r, drifts, missingHint)
+	}
+	if drifted {
+		os.Exit(1)
+	}
+}
+
+func printDrifts(r *rosa.Runtime, drifts []*aws.RoleDrift, missingHint string) {
+	for _, roleDrift := range drifts {
+		switch {
+		case roleDrift.Missing:
+			r.Reporter.Warnf("Role '%s' doesn't exist. %s", roleDrift.RoleName, missingHint)
+			continue
+		case len(roleDrift.Drifts) == 0:
+			r.Reporter.Infof("Role '%s' matches its expected configuration", roleDrift.RoleName)
+			continue
+		}
+		r.Reporter.Warnf("Role '%s' has drifted from its expected configuration:", roleDrift.RoleName)
+		for _, drift := range roleDrift.Drifts {
+			fmt.Printf("\n%s '%s': %s\n", drift.Kind, drift.Name, drift.Description)
+			if drift.Diff != "" {
+				fmt.Printf("%s\n", drift.Diff)
+			}
+			if drift.Remediation != "" {
+				fmt.Printf("To fix it, run:\n%s\n", drift.Remediation)
+			}
+		}
+		fmt.Println()
+	}
+}
@@ -11,6 +11,7 @@ import (
 	awscbRoles "github.com/openshift/rosa/pkg/aws/commandbuilder/helper/roles"
 	"github.com/openshift/rosa/pkg/aws/tags"
 	"github.com/openshift/rosa/pkg/helper"
+	"github.com/openshift/rosa/pkg/helper/parallel"
 	"github.com/openshift/rosa/pkg/interactive/confirm"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/rosa"
@@ -103,29 +104,34 @@ func ValidateAccountRolesManagedPolicies(r *rosa.Runtime, prefix string, hostedC
 }
 
 func ValidateUnmanagedAccountRoles(roleARNs []string, awsClient aws.Client, version string) error {
-	// iterate and validate role arns against openshift version
+	// validate role arns against openshift version concurrently, as each role needs a request
+	var tasks []func() error
 	for _, ARN := range roleARNs {
 		if ARN == "" {
 			continue
 		}
-		// get role from arn
-		role, err := awsClient.GetRoleByARN(ARN)
-		if err != nil {
-			return fmt.Errorf("Could not get Role '%s' : %v", ARN, err)
-		}
+		ARN := ARN
+		tasks = append(tasks, func() error {
+			// get role from arn
+			role, err := awsClient.GetRoleByARN(ARN)
+			if err != nil {
+				return fmt.Errorf("Could not get Role '%s' : %v", ARN, err)
+			}
 
-		validVersion, err := awsClient.HasCompatibleVersionTags(role.Tags, ocm.GetVersionMinor(version))
-		if err != nil {
-			return fmt.Errorf("Could not validate Role '%s' : %v", ARN, err)
-		}
-		if !validVersion {
-			return fmt.Errorf("Account role '%s' is not compatible with version %s. "+
-				"Run 'rosa create account-roles' to create compatible roles and try again.",
-				ARN, version)
-		}
+			validVersion, err := awsClient.HasCompatibleVersionTags(role.Tags, ocm.GetVersionMinor(version))
+			if err != nil {
+				return fmt.Errorf("Could not validate Role '%s' : %v", ARN, err)
+			}
+			if !validVersion {
+				return fmt.Errorf("Account role '%s' is not compatible with version %s. "+
+					"Run 'rosa create account-roles' to create compatible roles and try again.",
+					ARN, version)
+			}
+			return nil
+		})
 	}
 
-	return nil
+	return parallel.FirstError(parallel.DefaultLimit, tasks...)
 }
 
 func ValidateOperatorRolesManagedPolicies(r *rosa.Runtime, cluster *cmv1.Cluster,
@@ -0,0 +1,356 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions used to render an existing cluster as the configuration of the
+// terraform provider, so that it can be adopted by terraform using import blocks.
+
+package terraform
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+const (
+	ProviderSource = "terraform-redhat/rhcs"
+
+	clusterResourceName = "cluster"
+
+	// Machine pool created together with classic clusters, which is part of the cluster resource:
+	defaultMachinePool = "worker"
+)
+
+// ClusterExport contains the cluster and the resources that belong to it.
+type ClusterExport struct {
+	Cluster      *cmv1.Cluster
+	MachinePools []*cmv1.MachinePool
+	NodePools    []*cmv1.NodePool
+	// IdentityProviders are the identity providers of the cluster. The users of the htpasswd
+	// identity providers are indexed by the ID of the identity provider.
+	IdentityProviders []*cmv1.IdentityProvider
+	HTPasswdUsers     map[string][]string
+}
+
+// Render returns the terraform configuration of the cluster. Secrets can't be read back from the
+// API, so they are replaced by sensitive variables that must be set before applying.
+func (e *ClusterExport) Render() string {
+	blocks := []*Block{
+		NewBlock("terraform").Add(NewBlock("required_providers").
+			Set("rhcs", Expression(fmt.Sprintf("{\n    source = %q\n  }", ProviderSource)))),
+		NewBlock("provider", "rhcs"),
+	}
+	variables := []*Block{}
+	resources := []*Block{}
+	imports := []*Block{}
+
+	clusterType := e.clusterResourceType()
+	clusterAddress := fmt.Sprintf("%s.%s", clusterType, clusterResourceName)
+	resources = append(resources, e.renderCluster(clusterType))
+	imports = append(imports, importBlock(clusterAddress, e.Cluster.ID()))
+	clusterRef := Expression(clusterAddress + ".id")
+
+	names := map[string]bool{}
+	if e.Cluster.Hypershift().Enabled() {
+		for _, nodePool := range e.NodePools {
+			name := resourceName(nodePool.ID(), names)
+			resources = append(resources, renderNodePool(name, clusterRef, nodePool))
+			imports = append(imports, importBlock("rhcs_hcp_machine_pool."+name,
+				fmt.Sprintf("%s,%s", e.Cluster.ID(), nodePool.ID())))
+		}
+	} else {
+		for _, machinePool := range e.MachinePools {
+			if machinePool.ID() == defaultMachinePool {
+				continue
+			}
+			name := resourceName(machinePool.ID(), names)
+			resources = append(resources, renderMachinePool(name, clusterRef, machinePool))
+			imports = append(imports, importBlock("rhcs_machine_pool."+name,
+				fmt.Sprintf("%s,%s", e.Cluster.ID(), machinePool.ID())))
+		}
+	}
+
+	for _, idp := range e.IdentityProviders {
+		name := resourceName(idp.Name(), names)
+		resource, idpVariables := e.renderIdentityProvider(name, clusterRef, idp)
+		resources = append(resources, resource)
+		variables = append(variables, idpVariables...)
+		imports = append(imports, importBlock("rhcs_identity_provider."+name,
+			fmt.Sprintf("%s,%s", e.Cluster.ID(), idp.Name())))
+	}
+
+	blocks = append(blocks, variables...)
+	blocks = append(blocks, resources...)
+	blocks = append(blocks, imports...)
+	rendered := make([]string, len(blocks))
+	for i, block := range blocks {
+		rendered[i] = block.Render()
+	}
+	return strings.Join(rendered, "\n")
+}
+
+func (e *ClusterExport) clusterResourceType() string {
+	if e.Cluster.Hypershift().Enabled() {
+		return "rhcs_cluster_rosa_hcp"
+	}
+	return "rhcs_cluster_rosa_classic"
+}
+
+func (e *ClusterExport) renderCluster(clusterType string) *Block {
+	cluster := e.Cluster
+	block := NewBlock("resource", clusterType, clusterResourceName).
+		Set("name", cluster.Name()).
+		Set("cloud_region", cluster.Region().ID()).
+		Set("aws_account_id", cluster.AWS().AccountID()).
+		Set("version", cluster.Version().RawID()).
+		Set("channel_group", cluster.Version().ChannelGroup())
+	if !cluster.Hypershift().Enabled() {
+		block.SetAlways("multi_az", cluster.MultiAZ())
+	}
+	block.Set("availability_zones", cluster.Nodes().AvailabilityZones()).
+		Set("compute_machine_type", cluster.Nodes().ComputeMachineType().ID())
+	autoscaling, ok := cluster.Nodes().GetAutoscaleCompute()
+	if ok {
+		block.SetAlways("autoscaling_enabled", true).
+			SetAlways("min_replicas", autoscaling.MinReplicas()).
+			SetAlways("max_replicas", autoscaling.MaxReplicas())
+	} else {
+		block.SetAlways("replicas", cluster.Nodes().Compute())
+	}
+	block.Set("aws_subnet_ids", cluster.AWS().SubnetIDs())
+	if cluster.AWS().PrivateLink() {
+		block.SetAlways("aws_private_link", true)
+	}
+	if cluster.API().Listening() == cmv1.ListeningMethodInternal {
+		block.SetAlways("private", true)
+	}
+	block.Set("machine_cidr", cluster.Network().MachineCIDR()).
+		Set("service_cidr", cluster.Network().ServiceCIDR()).
+		Set("pod_cidr", cluster.Network().PodCIDR())
+	if cluster.Network().HostPrefix() != 0 {
+		block.SetAlways("host_prefix", cluster.Network().HostPrefix())
+	}
+	if cluster.FIPS() {
+		block.SetAlways("fips", true)
+	}
+	if cluster.EtcdEncryption() {
+		block.SetAlways("etcd_encryption", true)
+	}
+	block.Set("kms_key_arn", cluster.AWS().KMSKeyArn())
+	if cluster.DisableUserWorkloadMonitoring() {
+		block.SetAlways("disable_workload_monitoring", true)
+	}
+	block.Set("tags", cluster.AWS().Tags())
+	creatorARN := cluster.Properties()["rosa_creator_arn"]
+	if creatorARN != "" {
+		block.Set("properties", map[string]string{
+			"rosa_creator_arn": creatorARN,
+		})
+	}
+	block.Add(NewBlock("proxy").
+		Set("http_proxy", cluster.Proxy().HTTPProxy()).
+		Set("https_proxy", cluster.Proxy().HTTPSProxy()).
+		Set("no_proxy", cluster.Proxy().NoProxy()))
+
+	sts := cluster.AWS().STS()
+	stsBlock := NewBlock("sts").
+		Set("role_arn", sts.RoleARN()).
+		Set("support_role_arn", sts.SupportRoleARN()).
+		Set("operator_role_prefix", sts.OperatorRolePrefix()).
+		Set("oidc_config_id", sts.OidcConfig().ID()).
+		Add(NewBlock("instance_iam_roles").
+			Set("master_role_arn", sts.InstanceIAMRoles().MasterRoleARN()).
+			Set("worker_role_arn", sts.InstanceIAMRoles().WorkerRoleARN()))
+	operatorRoles := []string{}
+	for _, operatorRole := range sts.OperatorIAMRoles() {
+		operatorRoles = append(operatorRoles, fmt.Sprintf("  %s/%s: %s",
+			operatorRole.Namespace(), operatorRole.Name(), operatorRole.RoleARN()))
+	}
+	if len(operatorRoles) > 0 {
+		sort.Strings(operatorRoles)
+		stsBlock.Comment = "Operator roles:\n" + strings.Join(operatorRoles, "\n")
+	}
+	block.Add(stsBlock)
+	return block
+}
+
+func renderMachinePool(name string, clusterRef Expression, machinePool *cmv1.MachinePool) *Block {
+	block := NewBlock("resource", "rhcs_machine_pool", name).
+		Set("cluster", clusterRef).
+		Set("name", machinePool.ID()).
+		Set("machine_type", machinePool.InstanceType())
+	autoscaling, ok := machinePool.GetAutoscaling()
+	if ok {
+		block.SetAlways("autoscaling_enabled", true).
+			SetAlways("min_replicas", autoscaling.MinReplicas()).
+			SetAlways("max_replicas", autoscaling.MaxReplicas())
+	} else {
+		block.SetAlways("replicas", machinePool.Replicas())
+	}
+	spot, ok := machinePool.AWS().GetSpotMarketOptions()
+	if ok {
+		block.SetAlways("use_spot_instances", true)
+		maxPrice, ok := spot.GetMaxPrice()
+		if ok {
+			block.SetAlways("max_spot_price", maxPrice)
+		}
+	}
+	if len(machinePool.AvailabilityZones()) == 1 {
+		block.Set("availability_zone", machinePool.AvailabilityZones()[0])
+	}
+	if len(machinePool.Subnets()) == 1 {
+		block.Set("subnet_id", machinePool.Subnets()[0])
+	}
+	block.Set("labels", machinePool.Labels()).
+		Set("taints", renderTaints(machinePool.Taints()))
+	return block
+}
+
+func renderNodePool(name string, clusterRef Expression, nodePool *cmv1.NodePool) *Block {
+	block := NewBlock("resource", "rhcs_hcp_machine_pool", name).
+		Set("cluster", clusterRef).
+		Set("name", nodePool.ID()).
+		Set("subnet_id", nodePool.Subnet())
+	autoscaling, ok := nodePool.GetAutoscaling()
+	if ok {
+		block.Add(NewBlock("autoscaling").
+			SetAlways("enabled", true).
+			SetAlways("min_replicas", autoscaling.MinReplica()).
+			SetAlways("max_replicas", autoscaling.MaxReplica()))
+	} else {
+		block.SetAlways("replicas", nodePool.Replicas()).
+			Add(NewBlock("autoscaling").SetAlways("enabled", false))
+	}
+	block.Add(NewBlock("aws_node_pool").
+		Set("instance_type", nodePool.AWSNodePool().InstanceType()))
+	block.SetAlways("auto_repair", nodePool.AutoRepair()).
+		Set("version", nodePool.Version().RawID()).
+		Set("labels", nodePool.Labels()).
+		Set("taints", renderTaints(nodePool.Taints()))
+	return block
+}
+
+func renderTaints(taints []*cmv1.Taint) []map[string]interface{} {
+	result := []map[string]interface{}{}
+	for _, taint := range taints {
+		result = append(result, map[string]interface{}{
+			"key":           taint.Key(),
+			"value":         taint.Value(),
+			"schedule_type": taint.Effect(),
+		})
+	}
+	return result
+}
+
+func (e *ClusterExport) renderIdentityProvider(name string, clusterRef Expression,
+	idp *cmv1.IdentityProvider) (*Block, []*Block) {
+	block := NewBlock("resource", "rhcs_identity_provider", name).
+		Set("cluster", clusterRef).
+		Set("name", idp.Name()).
+		Set("mapping_method", string(idp.MappingMethod()))
+	variables := []*Block{}
+	secret := func(suffix string, description string) Expression {
+		variableName := fmt.Sprintf("%s_%s", name, suffix)
+		variables = append(variables, NewBlock("variable", variableName).
+			Set("description", fmt.Sprintf("%s of identity provider '%s'", description, idp.Name())).
+			Set("type", Expression("string")).
+			SetAlways("sensitive", true))
+		return Expression("var." + variableName)
+	}
+	switch idp.Type() {
+	case cmv1.IdentityProviderTypeGithub:
+		block.Add(NewBlock("github").
+			Set("client_id", idp.Github().ClientID()).
+			Set("client_secret", secret("client_secret", "Client secret")).
+			Set("organizations", idp.Github().Organizations()).
+			Set("teams", idp.Github().Teams()).
+			Set("hostname", idp.Github().Hostname()))
+	case cmv1.IdentityProviderTypeGitlab:
+		block.Add(NewBlock("gitlab").
+			Set("client_id", idp.Gitlab().ClientID()).
+			Set("client_secret", secret("client_secret", "Client secret")).
+			Set("url", idp.Gitlab().URL()))
+	case cmv1.IdentityProviderTypeGoogle:
+		block.Add(NewBlock("google").
+			Set("client_id", idp.Google().ClientID()).
+			Set("client_secret", secret("client_secret", "Client secret")).
+			Set("hosted_domain", idp.Google().HostedDomain()))
+	case cmv1.IdentityProviderTypeLDAP:
+		ldap := idp.LDAP()
+		ldapBlock := NewBlock("ldap").
+			Set("url", ldap.URL()).
+			Set("bind_dn", ldap.BindDN())
+		if ldap.BindDN() != "" {
+			ldapBlock.Set("bind_password", secret("bind_password", "Bind password"))
+		}
+		ldapBlock.SetAlways("insecure", ldap.Insecure()).
+			Add(NewBlock("attributes").
+				Set("id", ldap.Attributes().ID()).
+				Set("email", ldap.Attributes().Email()).
+				Set("name", ldap.Attributes().Name()).
+				Set("preferred_username", ldap.Attributes().PreferredUsername()))
+		block.Add(ldapBlock)
+	case cmv1.IdentityProviderTypeOpenID:
+		openID := idp.OpenID()
+		block.Add(NewBlock("openid").
+			Set("client_id", openID.ClientID()).
+			Set("client_secret", secret("client_secret", "Client secret")).
+			Set("issuer", openID.Issuer()).
+			Set("extra_scopes", openID.ExtraScopes()).
+			Add(NewBlock("claims").
+				Set("email", openID.Claims().Email()).
+				Set("name", openID.Claims().Name()).
+				Set("preferred_username", openID.Claims().PreferredUsername()).
+				Set("groups", openID.Claims().Groups())))
+	case cmv1.IdentityProviderTypeHtpasswd:
+		users := []map[string]interface{}{}
+		for _, username := range e.HTPasswdUsers[idp.ID()] {
+			users = append(users, map[string]interface{}{
+				"username": username,
+				"password": secret(resourceName(username, map[string]bool{})+"_password",
+					fmt.Sprintf("Password of user '%s'", username)),
+			})
+		}
+		block.Add(NewBlock("htpasswd").Set("users", users))
+	}
+	return block, variables
+}
+
+func importBlock(address string, id string) *Block {
+	return NewBlock("import").
+		Set("to", Expression(address)).
+		Set("id", id)
+}
+
+var invalidNameCharsRE = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+
+// resourceName converts the given name into a valid terraform identifier that isn't used yet.
+func resourceName(name string, used map[string]bool) string {
+	result := invalidNameCharsRE.ReplaceAllString(name, "_")
+	result = strings.ReplaceAll(result, "-", "_")
+	if result == "" || (result[0] >= '0' && result[0] <= '9') {
+		result = "r_" + result
+	}
+	candidate := result
+	for i := 2; used[candidate]; i++ {
+		candidate = fmt.Sprintf("%s_%d", result, i)
+	}
+	used[candidate] = true
+	return candidate
+}
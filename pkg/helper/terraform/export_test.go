@@ -0,0 +1,133 @@
+package terraform
+
+import (
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Block", func() {
+	It("aligns attributes and skips empty values", func() {
+		block := NewBlock("resource", "rhcs_machine_pool", "infra").
+			Set("name", "infra").
+			Set("machine_type", "").
+			SetAlways("replicas", 3).
+			Set("cluster", Expression("rhcs_cluster_rosa_classic.cluster.id")).
+			Add(NewBlock("empty"))
+		Expect(block.Render()).To(Equal(`resource "rhcs_machine_pool" "infra" {
+  name     = "infra"
+  replicas = 3
+  cluster  = rhcs_cluster_rosa_classic.cluster.id
+}
+`))
+	})
+
+	It("escapes template sequences in strings", func() {
+		Expect(quote("${foo} %{bar}")).To(Equal(`"$${foo} %%{bar}"`))
+	})
+
+	It("uses the HCL escape sequences in strings", func() {
+		Expect(quote("a\"b\\c\nd\te\x07f\u00e9")).To(Equal(`"a\"b\\c\nd\te\u0007fé"`))
+	})
+
+	It("doesn't escape template sequences in labels", func() {
+		Expect(NewBlock("resource", "type", "${name}").Render()).To(Equal(`resource "type" "${name}" {
+}
+`))
+	})
+})
+
+var _ = DescribeTable("resourceName",
+	func(name string, used []string, expected string) {
+		usedNames := map[string]bool{}
+		for _, item := range used {
+			usedNames[item] = true
+		}
+		Expect(resourceName(name, usedNames)).To(Equal(expected))
+	},
+	Entry("keeps valid names", "infra", nil, "infra"),
+	Entry("replaces invalid characters", "my-pool.a", nil, "my_pool_a"),
+	Entry("prefixes names starting with digits", "1pool", nil, "r_1pool"),
+	Entry("avoids duplicated names", "infra", []string{"infra"}, "infra_2"),
+)
+
+var _ = Describe("ClusterExport", func() {
+	It("renders classic clusters with machine pools, identity providers and imports", func() {
+		cluster, err := cmv1.NewCluster().
+			ID("123").
+			Name("mycluster").
+			Region(cmv1.NewCloudRegion().ID("us-east-1")).
+			MultiAZ(false).
+			Version(cmv1.NewVersion().RawID("4.12.1").ChannelGroup("stable")).
+			Nodes(cmv1.NewClusterNodes().
+				Compute(2).
+				ComputeMachineType(cmv1.NewMachineType().ID("m5.xlarge"))).
+			AWS(cmv1.NewAWS().
+				AccountID("123456789012").
+				STS(cmv1.NewSTS().
+					RoleARN("arn:aws:iam::123456789012:role/Installer").
+					SupportRoleARN("arn:aws:iam::123456789012:role/Support"))).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		worker, err := cmv1.NewMachinePool().ID("worker").InstanceType("m5.xlarge").Replicas(2).Build()
+		Expect(err).ToNot(HaveOccurred())
+		infra, err := cmv1.NewMachinePool().ID("infra").InstanceType("r5.xlarge").Replicas(3).
+			Labels(map[string]string{"role": "infra"}).Build()
+		Expect(err).ToNot(HaveOccurred())
+		idp, err := cmv1.NewIdentityProvider().ID("idp").Name("github-1").
+			Type(cmv1.IdentityProviderTypeGithub).
+			MappingMethod(cmv1.IdentityProviderMappingMethodClaim).
+			Github(cmv1.NewGithubIdentityProvider().ClientID("abc").Organizations("org")).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+
+		export := &ClusterExport{
+			Cluster:           cluster,
+			MachinePools:      []*cmv1.MachinePool{worker, infra},
+			IdentityProviders: []*cmv1.IdentityProvider{idp},
+		}
+		rendered := export.Render()
+		Expect(rendered).To(ContainSubstring(`resource "rhcs_cluster_rosa_classic" "cluster" {`))
+		Expect(rendered).To(ContainSubstring(`replicas             = 2`))
+		Expect(rendered).ToNot(ContainSubstring(`resource "rhcs_machine_pool" "worker"`))
+		Expect(rendered).To(ContainSubstring(`resource "rhcs_machine_pool" "infra" {`))
+		Expect(rendered).To(ContainSubstring(`variable "github_1_client_secret" {`))
+		Expect(rendered).To(ContainSubstring(`client_secret = var.github_1_client_secret`))
+		Expect(rendered).To(ContainSubstring(`import {
+  to = rhcs_cluster_rosa_classic.cluster
+  id = "123"
+}`))
+		Expect(rendered).To(ContainSubstring(`import {
+  to = rhcs_machine_pool.infra
+  id = "123,infra"
+}`))
+		Expect(rendered).To(ContainSubstring(`import {
+  to = rhcs_identity_provider.github_1
+  id = "123,github-1"
+}`))
+	})
+
+	It("renders hosted control plane clusters with node pools", func() {
+		cluster, err := cmv1.NewCluster().
+			ID("123").
+			Name("hcp").
+			Hypershift(cmv1.NewHypershift().Enabled(true)).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		nodePool, err := cmv1.NewNodePool().ID("workers-0").Subnet("subnet-1").
+			Autoscaling(cmv1.NewNodePoolAutoscaling().MinReplica(2).MaxReplica(4)).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+
+		rendered := (&ClusterExport{
+			Cluster:   cluster,
+			NodePools: []*cmv1.NodePool{nodePool},
+		}).Render()
+		Expect(rendered).To(ContainSubstring(`resource "rhcs_cluster_rosa_hcp" "cluster" {`))
+		Expect(rendered).ToNot(ContainSubstring("multi_az"))
+		Expect(rendered).To(ContainSubstring(`resource "rhcs_hcp_machine_pool" "workers_0" {`))
+		Expect(rendered).To(MatchRegexp(`cluster += rhcs_cluster_rosa_hcp\.cluster\.id`))
+		Expect(rendered).To(ContainSubstring(`id = "123,workers-0"`))
+	})
+})
@@ -0,0 +1,233 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains a minimal writer of the HCL syntax used by terraform, enough to render the
+// resources of a cluster without depending on the terraform libraries.
+
+package terraform
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Expression is a value that is rendered verbatim, like a reference to another resource or to a
+// variable.
+type Expression string
+
+// Block is a terraform block, like a resource or a nested block of a resource.
+type Block struct {
+	Type    string
+	Labels  []string
+	Comment string
+	items   []blockItem
+}
+
+type blockItem struct {
+	name  string
+	value interface{}
+	block *Block
+}
+
+// NewBlock creates a block with the given type and labels.
+func NewBlock(blockType string, labels ...string) *Block {
+	return &Block{
+		Type:   blockType,
+		Labels: labels,
+	}
+}
+
+// Set adds an attribute to the block. Values that are empty are skipped, so that optional
+// settings of the cluster don't need to be checked by the caller.
+func (b *Block) Set(name string, value interface{}) *Block {
+	if isEmptyValue(value) {
+		return b
+	}
+	b.items = append(b.items, blockItem{name: name, value: value})
+	return b
+}
+
+// SetAlways adds an attribute to the block even if its value is empty.
+func (b *Block) SetAlways(name string, value interface{}) *Block {
+	b.items = append(b.items, blockItem{name: name, value: value})
+	return b
+}
+
+// Add adds a nested block. Nested blocks without attributes are skipped.
+func (b *Block) Add(block *Block) *Block {
+	if len(block.items) == 0 {
+		return b
+	}
+	b.items = append(b.items, blockItem{block: block})
+	return b
+}
+
+// Render returns the HCL representation of the block.
+func (b *Block) Render() string {
+	var builder strings.Builder
+	b.render(&builder, 0)
+	return builder.String()
+}
+
+func (b *Block) render(builder *strings.Builder, level int) {
+	indent := strings.Repeat("  ", level)
+	if b.Comment != "" {
+		for _, line := range strings.Split(b.Comment, "\n") {
+			builder.WriteString(fmt.Sprintf("%s# %s\n", indent, line))
+		}
+	}
+	builder.WriteString(indent + b.Type)
+	for _, label := range b.Labels {
+		builder.WriteString(" " + quoteLiteral(label))
+	}
+	builder.WriteString(" {\n")
+	width := 0
+	for _, item := range b.items {
+		if item.block == nil && len(item.name) > width {
+			width = len(item.name)
+		}
+	}
+	for i, item := range b.items {
+		if item.block != nil {
+			if i > 0 {
+				builder.WriteString("\n")
+			}
+			item.block.render(builder, level+1)
+			continue
+		}
+		builder.WriteString(fmt.Sprintf("%s  %-*s = %s\n", indent, width, item.name,
+			renderValue(item.value, level+1)))
+	}
+	builder.WriteString(indent + "}\n")
+}
+
+func renderValue(value interface{}, level int) string {
+	switch typed := value.(type) {
+	case Expression:
+		return string(typed)
+	case string:
+		return quote(typed)
+	case bool:
+		return strconv.FormatBool(typed)
+	case int:
+		return strconv.Itoa(typed)
+	case float64:
+		return strconv.FormatFloat(typed, 'f', -1, 64)
+	case []string:
+		values := make([]string, len(typed))
+		for i, item := range typed {
+			values[i] = quote(item)
+		}
+		return "[" + strings.Join(values, ", ") + "]"
+	case map[string]string:
+		indent := strings.Repeat("  ", level)
+		keys := make([]string, 0, len(typed))
+		width := 0
+		for key := range typed {
+			keys = append(keys, key)
+			if len(quote(key)) > width {
+				width = len(quote(key))
+			}
+		}
+		sort.Strings(keys)
+		lines := []string{"{"}
+		for _, key := range keys {
+			lines = append(lines, fmt.Sprintf("%s  %-*s = %s", indent, width, quote(key), quote(typed[key])))
+		}
+		lines = append(lines, indent+"}")
+		return strings.Join(lines, "\n")
+	case []map[string]interface{}:
+		indent := strings.Repeat("  ", level)
+		lines := []string{"["}
+		for _, item := range typed {
+			keys := make([]string, 0, len(item))
+			for key := range item {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			fields := make([]string, len(keys))
+			for i, key := range keys {
+				fields[i] = fmt.Sprintf("%s = %s", key, renderValue(item[key], level+1))
+			}
+			lines = append(lines, fmt.Sprintf("%s  { %s },", indent, strings.Join(fields, ", ")))
+		}
+		lines = append(lines, indent+"]")
+		return strings.Join(lines, "\n")
+	}
+	return quote(fmt.Sprintf("%v", value))
+}
+
+// quote returns the value as an HCL string, escaping the template sequences that terraform would
+// otherwise interpolate.
+func quote(value string) string {
+	quoted := quoteLiteral(value)
+	quoted = strings.ReplaceAll(quoted, "${", "$${")
+	quoted = strings.ReplaceAll(quoted, "%{", "%%{")
+	return quoted
+}
+
+// quoteLiteral returns the value as an HCL quoted string. It can't use strconv.Quote because HCL
+// only supports some of the escape sequences of Go, for example it doesn't support '\x' or '\a'.
+func quoteLiteral(value string) string {
+	var builder strings.Builder
+	builder.WriteByte('"')
+	for _, char := range value {
+		switch char {
+		case '"':
+			builder.WriteString(`\"`)
+		case '\\':
+			builder.WriteString(`\\`)
+		case '\n':
+			builder.WriteString(`\n`)
+		case '\r':
+			builder.WriteString(`\r`)
+		case '\t':
+			builder.WriteString(`\t`)
+		default:
+			switch {
+			case unicode.IsPrint(char):
+				builder.WriteRune(char)
+			case char > 0xffff:
+				builder.WriteString(fmt.Sprintf(`\U%08X`, char))
+			default:
+				builder.WriteString(fmt.Sprintf(`\u%04X`, char))
+			}
+		}
+	}
+	builder.WriteByte('"')
+	return builder.String()
+}
+
+func isEmptyValue(value interface{}) bool {
+	switch typed := value.(type) {
+	case nil:
+		return true
+	case Expression:
+		return typed == ""
+	case string:
+		return typed == ""
+	case []string:
+		return len(typed) == 0
+	case map[string]string:
+		return len(typed) == 0
+	case []map[string]interface{}:
+		return len(typed) == 0
+	}
+	return false
+}
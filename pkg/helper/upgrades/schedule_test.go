@@ -0,0 +1,42 @@
+package upgrades
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Upgrade schedules", func() {
+	// Wednesday:
+	now := time.Date(2023, time.March, 1, 10, 30, 15, 0, time.UTC)
+
+	DescribeTable("Next occurrence",
+		func(expression string, expected time.Time) {
+			schedule, err := ParseSchedule(expression)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(schedule.Next(now)).To(Equal(expected))
+		},
+		Entry("every minute", "* * * * *", time.Date(2023, time.March, 1, 10, 31, 0, 0, time.UTC)),
+		Entry("weekly on Sunday", "0 2 * * 0", time.Date(2023, time.March, 5, 2, 0, 0, 0, time.UTC)),
+		Entry("Sunday as 7", "0 2 * * 7", time.Date(2023, time.March, 5, 2, 0, 0, 0, time.UTC)),
+		Entry("later today", "45 10,22 * * *", time.Date(2023, time.March, 1, 10, 45, 0, 0, time.UTC)),
+		Entry("steps", "*/20 */6 * * *", time.Date(2023, time.March, 1, 12, 0, 0, 0, time.UTC)),
+		Entry("ranges", "0 1 * * 1-5", time.Date(2023, time.March, 2, 1, 0, 0, 0, time.UTC)),
+		Entry("day of month or week", "0 0 15 * 5", time.Date(2023, time.March, 3, 0, 0, 0, 0, time.UTC)),
+		Entry("next year", "0 0 1 1 *", time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)),
+		Entry("impossible date", "0 0 31 2 *", time.Time{}),
+	)
+
+	DescribeTable("Invalid expressions",
+		func(expression string, message string) {
+			_, err := ParseSchedule(expression)
+			Expect(err).To(MatchError(ContainSubstring(message)))
+		},
+		Entry("too few fields", "0 2 * *", "expected five fields"),
+		Entry("minute out of range", "60 2 * * *", "minute '60' is out of range 0-59"),
+		Entry("inverted range", "0 5-2 * * *", "out of range"),
+		Entry("invalid step", "*/0 * * * *", "invalid step"),
+		Entry("not a number", "0 2 * * sun", "invalid day of week"),
+	)
+})
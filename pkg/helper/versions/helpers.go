@@ -9,7 +9,7 @@ import (
 )
 
 const (
-	MinorVersionsSupported = 2
+	MinorVersionsSupported = ocm.HostedMachinePoolMinorVersionSkew
 )
 
 func GetVersionList(r *rosa.Runtime, channelGroup string, isSTS bool, isHostedCP bool) (versionList []string,
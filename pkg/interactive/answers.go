@@ -0,0 +1,231 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains functions used to implement the '--record-answers' and '--answers-file'
+// command line options.
+
+package interactive
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/AlecAivazis/survey/v2/core"
+	"github.com/ghodss/yaml"
+)
+
+// recordFile is the file where the answers given to the interactive prompts are saved.
+var recordFile string
+
+// answersFile is the file containing the answers replayed instead of prompting the user.
+var answersFile string
+
+// answerOverrides are answers given in the command line that take precedence over the
+// ones read from the answers file.
+var answerOverrides []string
+
+var (
+	answersOnce sync.Once
+	answersErr  error
+	answers     map[string]interface{}
+	recorded    map[string]interface{}
+	asked       map[string]int
+)
+
+// Replaying returns a boolean flag that indicates if the answers to the prompts are read
+// from an answers file instead of asking the user.
+func Replaying() bool {
+	return answersFile != "" || len(answerOverrides) > 0
+}
+
+func loadAnswers() error {
+	answersOnce.Do(func() {
+		answers = map[string]interface{}{}
+		if answersFile != "" {
+			data, err := os.ReadFile(answersFile)
+			if err != nil {
+				answersErr = fmt.Errorf("Failed to read answers file '%s': %v", answersFile, err)
+				return
+			}
+			err = yaml.Unmarshal(data, &answers)
+			if err != nil {
+				answersErr = fmt.Errorf("Failed to parse answers file '%s': %v", answersFile, err)
+				return
+			}
+		}
+		for _, override := range answerOverrides {
+			parts := strings.SplitN(override, "=", 2)
+			if len(parts) != 2 {
+				answersErr = fmt.Errorf("Expected answer in the form 'question=value', got '%s'", override)
+				return
+			}
+			answers[strings.TrimSpace(parts[0])] = parts[1]
+		}
+	})
+	return answersErr
+}
+
+// answerKey returns the key of the answer to the given question in the answers file. The same
+// question can be asked more than once in a command, for example when the values are reviewed
+// before creating a cluster, so the second and later occurrences get the number of the occurrence
+// appended in parentheses, like 'Min replicas (2)'.
+func answerKey(question string) string {
+	if asked == nil {
+		asked = map[string]int{}
+	}
+	asked[question]++
+	if asked[question] == 1 {
+		return question
+	}
+	return fmt.Sprintf("%s (%d)", question, asked[question])
+}
+
+// askOne asks the given question, or answers it from the answers file when replaying, and
+// saves the answer when recording is enabled.
+func askOne(question string, prompt survey.Prompt, response interface{}, opts ...survey.AskOpt) error {
+	question = answerKey(question)
+	if Replaying() {
+		err := replayAnswer(question, prompt, response, opts...)
+		if err != nil {
+			return err
+		}
+	} else {
+		err := survey.AskOne(prompt, response, opts...)
+		if err != nil {
+			return err
+		}
+	}
+	if _, ok := prompt.(*survey.Password); ok {
+		// Never write secrets to disk
+		return nil
+	}
+	return recordAnswer(question, response)
+}
+
+func replayAnswer(question string, prompt survey.Prompt, response interface{}, opts ...survey.AskOpt) error {
+	err := loadAnswers()
+	if err != nil {
+		return err
+	}
+	answer, ok := answers[question]
+	if !ok {
+		if _, ok := prompt.(*survey.Password); ok {
+			return fmt.Errorf("No answer for '%s', passwords aren't saved in the answers file, "+
+				"use '--answer \"%s=...\"'", question, question)
+		}
+		return fmt.Errorf("No answer for '%s' in the answers file, add it or use '--answer'", question)
+	}
+	value, err := answerValue(answer, response)
+	if err != nil {
+		return fmt.Errorf("Invalid answer for '%s': %v", question, err)
+	}
+	if p, ok := prompt.(*survey.Select); ok && !containsString(p.Options, value.(string)) {
+		return fmt.Errorf("Invalid answer for '%s': '%s' is not one of the available options", question, value)
+	}
+	options := &survey.AskOptions{}
+	for _, opt := range opts {
+		err = opt(options)
+		if err != nil {
+			return err
+		}
+	}
+	for _, validator := range options.Validators {
+		err = validator(value)
+		if err != nil {
+			return fmt.Errorf("Invalid answer for '%s': %v", question, err)
+		}
+	}
+	return core.WriteAnswer(response, "", value)
+}
+
+// answerValue converts an answer read from the answers file to the type expected by the prompt.
+func answerValue(answer interface{}, response interface{}) (interface{}, error) {
+	switch response.(type) {
+	case *string:
+		if answer == nil {
+			return "", nil
+		}
+		return fmt.Sprintf("%v", answer), nil
+	case *bool:
+		switch a := answer.(type) {
+		case nil:
+			return false, nil
+		case bool:
+			return a, nil
+		case string:
+			switch strings.ToLower(a) {
+			case "true", "yes", "y":
+				return true, nil
+			case "false", "no", "n", "":
+				return false, nil
+			}
+		}
+		return nil, fmt.Errorf("expected a boolean, got '%v'", answer)
+	case *[]string:
+		switch a := answer.(type) {
+		case nil:
+			return []string{}, nil
+		case []string:
+			return a, nil
+		case []interface{}:
+			values := make([]string, len(a))
+			for i, v := range a {
+				values[i] = fmt.Sprintf("%v", v)
+			}
+			return values, nil
+		case string:
+			if a == "" {
+				return []string{}, nil
+			}
+			return strings.Split(a, ","), nil
+		}
+		return nil, fmt.Errorf("expected a list, got '%v'", answer)
+	}
+	return answer, nil
+}
+
+func recordAnswer(question string, response interface{}) error {
+	if recordFile == "" {
+		return nil
+	}
+	if recorded == nil {
+		recorded = map[string]interface{}{}
+	}
+	switch r := response.(type) {
+	case *string:
+		recorded[question] = *r
+	case *bool:
+		recorded[question] = *r
+	case *[]string:
+		recorded[question] = *r
+	default:
+		recorded[question] = response
+	}
+	// The file is rewritten after every answer so that it is complete even if the command
+	// exits before finishing.
+	data, err := yaml.Marshal(recorded)
+	if err != nil {
+		return err
+	}
+	err = os.WriteFile(recordFile, data, 0600)
+	if err != nil {
+		return fmt.Errorf("Failed to save answers to '%s': %v", recordFile, err)
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package interactive
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func Test_answerValue(t *testing.T) {
+	var str string
+	var b bool
+	var list []string
+	tests := []struct {
+		name     string
+		answer   interface{}
+		response interface{}
+		want     interface{}
+		wantErr  bool
+	}{
+		{"string", "foo", &str, "foo", false},
+		{"number as string", float64(3), &str, "3", false},
+		{"missing string", nil, &str, "", false},
+		{"bool", true, &b, true, false},
+		{"bool as string", "yes", &b, true, false},
+		{"invalid bool", "maybe", &b, nil, true},
+		{"list", []interface{}{"a", "b"}, &list, []string{"a", "b"}, false},
+		{"comma separated list", "a,b", &list, []string{"a", "b"}, false},
+		{"invalid list", true, &list, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := answerValue(tt.answer, tt.response)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("answerValue() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("answerValue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_replayAndRecordAnswers(t *testing.T) {
+	dir := t.TempDir()
+	answersFile = filepath.Join(dir, "answers.yaml")
+	recordFile = filepath.Join(dir, "recorded.yaml")
+	answerOverrides = []string{"Compute nodes=6"}
+	defer func() {
+		answersFile = ""
+		recordFile = ""
+		answerOverrides = nil
+		recorded = nil
+		asked = nil
+	}()
+	err := os.WriteFile(answersFile, []byte("Cluster name: mycluster\nCompute nodes: 3\nRegion: us-west-2\n"+
+		"Min replicas: 2\nMin replicas (2): 4\n"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	name, err := GetString(Input{Question: "Cluster name", Required: true})
+	if err != nil || name != "mycluster" {
+		t.Errorf("GetString() = %v, %v, want mycluster", name, err)
+	}
+	nodes, err := GetInt(Input{Question: "Compute nodes"})
+	if err != nil || nodes != 6 {
+		t.Errorf("GetInt() = %v, %v, want the override 6", nodes, err)
+	}
+	_, err = GetBool(Input{Question: "Multiple availability zones", Default: true})
+	if err == nil {
+		t.Errorf("GetBool() expected an error for a question missing from the answers file")
+	}
+	_, err = GetPassword(Input{Question: "Password"})
+	if err == nil {
+		t.Errorf("GetPassword() expected an error for a password missing from the answers file")
+	}
+	for _, want := range []int{2, 4} {
+		replicas, err := GetInt(Input{Question: "Min replicas"})
+		if err != nil || replicas != want {
+			t.Errorf("GetInt() = %v, %v, want %d", replicas, err, want)
+		}
+	}
+	_, err = GetOption(Input{Question: "Region", Options: []string{"us-east-1"}})
+	if err == nil {
+		t.Errorf("GetOption() expected an error for an answer that is not an option")
+	}
+
+	data, err := os.ReadFile(recordFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "Cluster name: mycluster\nCompute nodes: \"6\"\nMin replicas: \"2\"\nMin replicas (2): \"4\"\n"
+	if string(data) != want {
+		t.Errorf("recorded answers = %q, want %q", data, want)
+	}
+}
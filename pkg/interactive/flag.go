@@ -31,11 +31,31 @@ func AddFlag(flags *pflag.FlagSet) {
 		false,
 		"Enable interactive mode.",
 	)
+	flags.StringVar(
+		&recordFile,
+		"record-answers",
+		"",
+		"Save the answers given in interactive mode to the given YAML file.",
+	)
+	flags.StringVar(
+		&answersFile,
+		"answers-file",
+		"",
+		"Replay the answers saved in the given YAML file instead of prompting. "+
+			"Questions missing from the file are reported as errors.",
+	)
+	flags.StringArrayVar(
+		&answerOverrides,
+		"answer",
+		nil,
+		"Answer a question instead of prompting, in the form 'question=value'. "+
+			"Takes precedence over the answers file. Can be repeated.",
+	)
 }
 
 // Enabled returns a boolean flag that indicates if the interactive mode is enabled.
 func Enabled() bool {
-	return enabled
+	return enabled || Replaying()
 }
 
 // Enable enables the interactive mode
@@ -97,3 +97,48 @@ func GetInstallerRoleArn(r *rosa.Runtime, cmd *cobra.Command,
 	}
 	return roleARN
 }
+
+// GetUserTags asks for the user defined tags when running in interactive mode, using the given
+// tags as default, and returns them indexed by key. Entering a set of double quotes ("") removes
+// all the tags.
+func GetUserTags(r *rosa.Runtime, cmd *cobra.Command, userTags []string) map[string]string {
+	if Enabled() {
+		tagsInput, err := GetString(Input{
+			Question: "Tags",
+			Help: cmd.Flags().Lookup("tags").Usage + " " +
+				"To remove all the tags, enter a set of double quotes (\"\").",
+			Default: strings.Join(userTags, ","),
+			Validators: []Validator{
+				userTagsValidator,
+			},
+		})
+		if err != nil {
+			r.Reporter.Errorf("Expected a valid set of tags: %s", err)
+			os.Exit(1)
+		}
+		if tagsInput == doubleQuotesToRemove {
+			userTags = nil
+		} else if len(tagsInput) > 0 {
+			userTags = strings.Split(tagsInput, ",")
+		}
+	}
+	tagsList, err := aws.ParseUserTags(userTags)
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+	return tagsList
+}
+
+// userTagsValidator validates the tags entered interactively, accepting a set of double quotes
+// to remove all the tags.
+func userTagsValidator(input interface{}) error {
+	if str, ok := input.(string); ok && str == doubleQuotesToRemove {
+		return nil
+	}
+	err := aws.UserTagValidator(input)
+	if err != nil {
+		return err
+	}
+	return aws.UserTagDuplicateValidator(input)
+}
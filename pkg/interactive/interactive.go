@@ -21,6 +21,9 @@ import (
 	"net"
 	"os"
 	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/AlecAivazis/survey/v2/core"
@@ -59,7 +62,7 @@ func GetString(input Input) (a string, err error) {
 	if input.Required {
 		input.Validators = append([]Validator{required}, input.Validators...)
 	}
-	err = survey.AskOne(prompt, &a, survey.WithValidator(compose(input.Validators)))
+	err = askOne(input.Question, prompt, &a, survey.WithValidator(compose(input.Validators)))
 	a = transformer(a).(string)
 	return
 }
@@ -88,7 +91,7 @@ func GetInt(input Input) (a int, err error) {
 	if input.Required {
 		input.Validators = append([]Validator{required}, input.Validators...)
 	}
-	err = survey.AskOne(prompt, &str, survey.WithValidator(compose(input.Validators)))
+	err = askOne(input.Question, prompt, &str, survey.WithValidator(compose(input.Validators)))
 	if err != nil {
 		return
 	}
@@ -128,7 +131,7 @@ func GetFloat(input Input) (a float64, err error) {
 	if input.Required {
 		input.Validators = append([]Validator{required}, input.Validators...)
 	}
-	err = survey.AskOne(prompt, &str, survey.WithValidator(compose(input.Validators)))
+	err = askOne(input.Question, prompt, &str, survey.WithValidator(compose(input.Validators)))
 	if err != nil {
 		return
 	}
@@ -164,7 +167,8 @@ func GetMultipleOptions(input Input) ([]string, error) {
 	if input.Required {
 		input.Validators = append([]Validator{required}, input.Validators...)
 	}
-	err = survey.AskOne(prompt, &res, survey.WithValidator(compose(input.Validators)))
+	err = askOne(input.Question, prompt, &res, survey.WithValidator(compose(input.Validators)),
+		survey.WithFilter(fuzzyFilter), survey.WithPageSize(pageSize))
 	return res, err
 }
 
@@ -192,10 +196,32 @@ func GetOption(input Input) (a string, err error) {
 	if input.Required {
 		input.Validators = append([]Validator{required}, input.Validators...)
 	}
-	err = survey.AskOne(prompt, &a, survey.WithValidator(compose(input.Validators)))
+	err = askOne(input.Question, prompt, &a, survey.WithValidator(compose(input.Validators)),
+		survey.WithFilter(fuzzyFilter), survey.WithPageSize(pageSize))
 	return
 }
 
+// pageSize is the number of options displayed at once by select prompts.
+const pageSize = 15
+
+// fuzzyFilter matches the options that contain the characters typed by the user in the same order,
+// ignoring case, so that for example 'm52x' matches 'm5.2xlarge' in long lists of options.
+func fuzzyFilter(filter string, value string, _ int) bool {
+	filter = strings.ToLower(filter)
+	value = strings.ToLower(value)
+	for _, r := range filter {
+		if unicode.IsSpace(r) {
+			continue
+		}
+		index := strings.IndexRune(value, r)
+		if index < 0 {
+			return false
+		}
+		value = value[index+utf8.RuneLen(r):]
+	}
+	return true
+}
+
 // containsString checks is a string is present inside a slice
 func containsString(s []string, input string) bool {
 	for _, a := range s {
@@ -225,7 +251,7 @@ func GetBool(input Input) (a bool, err error) {
 	if input.Required {
 		input.Validators = append([]Validator{required}, input.Validators...)
 	}
-	err = survey.AskOne(prompt, &a, survey.WithValidator(compose(input.Validators)))
+	err = askOne(input.Question, prompt, &a, survey.WithValidator(compose(input.Validators)))
 	return
 }
 
@@ -253,7 +279,7 @@ func GetIPNet(input Input) (a net.IPNet, err error) {
 	if input.Required {
 		input.Validators = append([]Validator{required}, input.Validators...)
 	}
-	err = survey.AskOne(prompt, &str, survey.WithValidator(compose(input.Validators)), survey.WithValidator(IsCIDR))
+	err = askOne(input.Question, prompt, &str, survey.WithValidator(compose(input.Validators)), survey.WithValidator(IsCIDR))
 	if err != nil {
 		return
 	}
@@ -284,7 +310,7 @@ func GetPassword(input Input) (a string, err error) {
 	if input.Required {
 		input.Validators = append([]Validator{required}, input.Validators...)
 	}
-	err = survey.AskOne(prompt, &a, survey.WithValidator(compose(input.Validators)))
+	err = askOne(input.Question, prompt, &a, survey.WithValidator(compose(input.Validators)))
 	return
 }
 
@@ -307,7 +333,7 @@ func GetCert(input Input) (a string, err error) {
 	if input.Required {
 		input.Validators = append([]Validator{required}, input.Validators...)
 	}
-	err = survey.AskOne(prompt, &a, survey.WithValidator(compose(input.Validators)), survey.WithValidator(IsCert))
+	err = askOne(input.Question, prompt, &a, survey.WithValidator(compose(input.Validators)), survey.WithValidator(IsCert))
 	return
 }
 
@@ -25,3 +25,49 @@ func Test_containsString(t *testing.T) {
 		})
 	}
 }
+
+func Test_fuzzyFilter(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter string
+		value  string
+		want   bool
+	}{
+		{"empty filter", "", "m5.xlarge", true},
+		{"substring", "xlarge", "m5.xlarge", true},
+		{"characters in order", "m52x", "m5.2xlarge", true},
+		{"ignores case", "M5X", "m5.xlarge", true},
+		{"ignores spaces", "m5 x", "m5.xlarge", true},
+		{"characters out of order", "x5m", "m5.xlarge", false},
+		{"missing character", "m6", "m5.xlarge", false},
+		{"repeated character", "ll", "m5.large", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fuzzyFilter(tt.filter, tt.value, 0); got != tt.want {
+				t.Errorf("fuzzyFilter(%q, %q) = %v, want %v", tt.filter, tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_userTagsValidator(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{"valid", "foo:bar,bar:baz", false},
+		{"empty", "", false},
+		{"remove", "\"\"", false},
+		{"invalid", "foo", true},
+		{"duplicate", "foo:bar,foo:baz", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := userTagsValidator(tt.input); (err != nil) != tt.wantErr {
+				t.Errorf("userTagsValidator() %s error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+		})
+	}
+}
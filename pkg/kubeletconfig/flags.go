@@ -0,0 +1,113 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the command line options shared by the commands that manage the kubelet
+// configurations of a cluster.
+
+package kubeletconfig
+
+import (
+	"fmt"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/pflag"
+
+	"github.com/openshift/rosa/pkg/interactive"
+	"github.com/openshift/rosa/pkg/ocm"
+)
+
+const (
+	NameFlag         = "name"
+	PodPidsLimitFlag = "pod-pids-limit"
+
+	// KubeletConfigsFlag is the machine pool option that selects the kubelet configs of its nodes.
+	KubeletConfigsFlag = "kubelet-configs"
+)
+
+// AddNameFlag adds the option that selects the kubelet configuration of a hosted cluster.
+func AddNameFlag(flags *pflag.FlagSet, name *string) {
+	flags.StringVar(
+		name,
+		NameFlag,
+		"",
+		"Name of the kubelet config. Required for Hosted Control Plane clusters, that can have several "+
+			"kubelet configs referenced by their machine pools. Not supported for classic clusters, that "+
+			"have a single kubelet config for all their nodes.",
+	)
+}
+
+// AddPodPidsLimitFlag adds the option that sets the PIDs limit of the pods.
+func AddPodPidsLimitFlag(flags *pflag.FlagSet, limit *int) {
+	flags.IntVar(
+		limit,
+		PodPidsLimitFlag,
+		0,
+		fmt.Sprintf("Maximum number of PIDs of each pod of the nodes, between %d and %d.",
+			ocm.MinPodPidsLimit, ocm.MaxPodPidsLimit),
+	)
+}
+
+// AddKubeletConfigsFlag adds the machine pool option that selects the kubelet configs used by its
+// nodes.
+func AddKubeletConfigsFlag(flags *pflag.FlagSet, names *[]string) {
+	flags.StringSliceVar(
+		names,
+		KubeletConfigsFlag,
+		nil,
+		"Names of the kubelet configs used by the nodes of a machine pool of a Hosted Control Plane "+
+			"cluster. Format should be a comma-separated list. The kubelet configs must exist.",
+	)
+}
+
+// ValidateName checks that the name of the kubelet config is given for hosted clusters, and only for
+// them.
+func ValidateName(cluster *cmv1.Cluster, name string) error {
+	if cluster.Hypershift().Enabled() && name == "" {
+		return fmt.Errorf("The '--%s' option is required for Hosted Control Plane clusters", NameFlag)
+	}
+	if !cluster.Hypershift().Enabled() && name != "" {
+		return fmt.Errorf("The '--%s' option is only supported for Hosted Control Plane clusters", NameFlag)
+	}
+	return nil
+}
+
+// GetPodPidsLimit returns the PIDs limit of the pods given in the command line, asking for it when
+// it wasn't given or interactive mode is enabled, and checks that it is in the accepted range.
+func GetPodPidsLimit(flags *pflag.FlagSet, limit int) (int, error) {
+	var err error
+	if interactive.Enabled() || !flags.Changed(PodPidsLimitFlag) {
+		limit, err = interactive.GetInt(interactive.Input{
+			Question: "Pod PIDs limit",
+			Help:     flags.Lookup(PodPidsLimitFlag).Usage,
+			Default:  limit,
+			Required: true,
+			Validators: []interactive.Validator{
+				func(val interface{}) error {
+					var limit int
+					_, err := fmt.Sscanf(fmt.Sprintf("%v", val), "%d", &limit)
+					if err != nil {
+						return fmt.Errorf("Expected an integer")
+					}
+					return ocm.ValidatePodPidsLimit(limit)
+				},
+			},
+		})
+		if err != nil {
+			return 0, fmt.Errorf("Expected a valid pod PIDs limit: %v", err)
+		}
+	}
+	return limit, ocm.ValidatePodPidsLimit(limit)
+}
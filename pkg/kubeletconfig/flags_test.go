@@ -0,0 +1,26 @@
+package kubeletconfig
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+var _ = Describe("ValidateName", func() {
+	DescribeTable("ValidateName",
+		func(hostedCP bool, name string, valid bool) {
+			cluster, err := cmv1.NewCluster().Hypershift(cmv1.NewHypershift().Enabled(hostedCP)).Build()
+			Expect(err).ToNot(HaveOccurred())
+			err = ValidateName(cluster, name)
+			if valid {
+				Expect(err).ToNot(HaveOccurred())
+			} else {
+				Expect(err).To(HaveOccurred())
+			}
+		},
+		Entry("classic without name", false, "", true),
+		Entry("classic with name", false, "high-pids", false),
+		Entry("hosted with name", true, "high-pids", true),
+		Entry("hosted without name", true, "", false),
+	)
+})
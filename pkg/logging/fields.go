@@ -0,0 +1,67 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the structured fields that are added to all the messages sent to the log, so
+// that they can be correlated when ingested into a centralized logging system.
+
+package logging
+
+import (
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	CommandField   = "command"
+	ClusterIDField = "cluster-id"
+
+	// RequestIDField isn't set with SetField, as it is only relevant for the messages of the
+	// response to the request, not for the messages that follow it.
+	RequestIDField = "request-id"
+)
+
+var fieldsLock sync.Mutex
+var fields = logrus.Fields{}
+
+// SetField sets a field that is added to all the messages sent to the log from now on, by all the
+// loggers of the project.
+func SetField(key string, value interface{}) {
+	fieldsLock.Lock()
+	defer fieldsLock.Unlock()
+	fields[key] = value
+}
+
+// fieldsHook is a logrus hook that adds the fields set with SetField to the messages that don't
+// already have them.
+type fieldsHook struct{}
+
+var _ logrus.Hook = fieldsHook{}
+
+func (fieldsHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (fieldsHook) Fire(entry *logrus.Entry) error {
+	fieldsLock.Lock()
+	defer fieldsLock.Unlock()
+	for key, value := range fields {
+		if _, ok := entry.Data[key]; !ok {
+			entry.Data[key] = value
+		}
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions used to implement the '--log-format' and '--log-level' command
+// line options.
+
+package logging
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/pflag"
+
+	"github.com/openshift/rosa/pkg/debug"
+)
+
+const (
+	TextFormat = "text"
+	JSONFormat = "json"
+
+	formatEnv = "ROSA_LOG_FORMAT"
+	levelEnv  = "ROSA_LOG_LEVEL"
+)
+
+var formats = []string{TextFormat, JSONFormat}
+
+var format string
+var level string
+
+// AddFlags adds the log format and level flags to the given set of command line flags.
+func AddFlags(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&format,
+		"log-format",
+		"",
+		fmt.Sprintf("Format of the log messages, one of %s. With '%s' the messages of the commands "+
+			"are also sent to the log, to the standard error stream. Can also be set with the '%s' "+
+			"environment variable. The default is '%s'.", strings.Join(formats, ", "), JSONFormat,
+			formatEnv, TextFormat),
+	)
+	flags.StringVar(
+		&level,
+		"log-level",
+		"",
+		fmt.Sprintf("Level of the log messages, one of debug, info, warn or error. The details of the "+
+			"OCM and AWS API requests and responses are sent at the debug level. Can also be set "+
+			"with the '%s' environment variable. The default is 'debug' when '--debug' is used and "+
+			"'info' otherwise.", levelEnv),
+	)
+}
+
+// Format returns the format of the log messages given in the command line or the environment.
+func Format() string {
+	if format != "" {
+		return format
+	}
+	if value := os.Getenv(formatEnv); value != "" {
+		return value
+	}
+	return TextFormat
+}
+
+// Level returns the level of the log messages given in the command line or the environment.
+func Level() (logrus.Level, error) {
+	value := level
+	if value == "" {
+		value = os.Getenv(levelEnv)
+	}
+	if value == "" {
+		if debug.Enabled() {
+			return logrus.DebugLevel, nil
+		}
+		return logrus.InfoLevel, nil
+	}
+	switch strings.ToLower(value) {
+	case "debug", "info", "warn", "warning", "error":
+		return logrus.ParseLevel(value)
+	}
+	return logrus.InfoLevel, fmt.Errorf("Invalid log level '%s', valid values are debug, info, warn and error",
+		value)
+}
+
+// Validate checks the log format and level given in the command line or the environment.
+func Validate() error {
+	valid := false
+	for _, value := range formats {
+		if Format() == value {
+			valid = true
+		}
+	}
+	if !valid {
+		return fmt.Errorf("Invalid log format '%s', valid values are %s", Format(), strings.Join(formats, " and "))
+	}
+	_, err := Level()
+	return err
+}
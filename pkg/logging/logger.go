@@ -20,24 +20,29 @@ package logging
 
 import (
 	"github.com/sirupsen/logrus"
-
-	"github.com/openshift/rosa/pkg/debug"
 )
 
-// NewLogger creates a new logger with the default config for the project
+// NewLogger creates a new logger with the default config for the project, using the format and level
+// given with the '--log-format' and '--log-level' flags.
 func NewLogger() (result *logrus.Logger) {
 	// Create the logger:
 	result = logrus.New()
-	result.SetFormatter(&logrus.TextFormatter{
-		DisableColors: true,
-		DisableQuote:  true,
-		FullTimestamp: true,
-	})
-
-	// Enable the debug level if needed:
-	if debug.Enabled() {
-		result.SetLevel(logrus.DebugLevel)
+	if Format() == JSONFormat {
+		result.SetFormatter(&logrus.JSONFormatter{})
+	} else {
+		result.SetFormatter(&logrus.TextFormatter{
+			DisableColors: true,
+			DisableQuote:  true,
+			FullTimestamp: true,
+		})
 	}
 
+	// Set the level, invalid levels are reported when the flags are validated:
+	level, _ := Level()
+	result.SetLevel(level)
+
+	// Add the structured fields to all the messages:
+	result.AddHook(fieldsHook{})
+
 	return
 }
@@ -0,0 +1,104 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sirupsen/logrus"
+)
+
+var _ = Describe("Logger", func() {
+	AfterEach(func() {
+		format = ""
+		level = ""
+	})
+
+	It("Uses the level and format of the environment", func() {
+		GinkgoT().Setenv(levelEnv, "warn")
+		GinkgoT().Setenv(formatEnv, JSONFormat)
+		Expect(Validate()).To(Succeed())
+		logger := NewLogger()
+		Expect(logger.GetLevel()).To(Equal(logrus.WarnLevel))
+		Expect(logger.Formatter).To(BeAssignableToTypeOf(&logrus.JSONFormatter{}))
+	})
+
+	It("Gives precedence to the flags over the environment", func() {
+		GinkgoT().Setenv(levelEnv, "warn")
+		level = "debug"
+		Expect(NewLogger().GetLevel()).To(Equal(logrus.DebugLevel))
+	})
+
+	It("Rejects invalid formats and levels", func() {
+		format = "xml"
+		Expect(Validate()).To(MatchError("Invalid log format 'xml', valid values are text and json"))
+		format = ""
+		level = "trace"
+		Expect(Validate()).To(MatchError(
+			"Invalid log level 'trace', valid values are debug, info, warn and error"))
+	})
+
+	It("Adds the structured fields to the messages", func() {
+		format = JSONFormat
+		SetField(CommandField, "rosa describe cluster")
+		SetField(ClusterIDField, "123")
+		defer func() {
+			fields = logrus.Fields{}
+		}()
+		logger := NewLogger()
+		var out bytes.Buffer
+		logger.SetOutput(&out)
+		logger.WithField(ClusterIDField, "456").Info("Hello")
+		message := map[string]interface{}{}
+		Expect(json.Unmarshal(out.Bytes(), &message)).To(Succeed())
+		Expect(message).To(HaveKeyWithValue("msg", "Hello"))
+		Expect(message).To(HaveKeyWithValue(CommandField, "rosa describe cluster"))
+		Expect(message).To(HaveKeyWithValue(ClusterIDField, "456"))
+	})
+
+	It("Only adds the request identifier to the messages of the response", func() {
+		format = JSONFormat
+		level = "debug"
+		logger := NewLogger()
+		var out bytes.Buffer
+		logger.SetOutput(&out)
+		roundTripper, err := NewRoundTripper().
+			Logger(logger).
+			Next(roundTripperFunc(func(request *http.Request) (*http.Response, error) {
+				return &http.Response{
+					Status:     "200 OK",
+					StatusCode: http.StatusOK,
+					Header:     http.Header{"X-Request-Id": []string{"abc"}},
+					Body:       io.NopCloser(strings.NewReader("")),
+				}, nil
+			})).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		request, err := http.NewRequest(http.MethodGet, "https://api.openshift.com", nil)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = roundTripper.RoundTrip(request)
+		Expect(err).ToNot(HaveOccurred())
+		logger.Info("Done")
+
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		for _, line := range lines {
+			message := map[string]interface{}{}
+			Expect(json.Unmarshal([]byte(line), &message)).To(Succeed())
+			if strings.HasPrefix(message["msg"].(string), "Response") {
+				Expect(message).To(HaveKeyWithValue(RequestIDField, "abc"))
+			} else {
+				Expect(message).ToNot(HaveKey(RequestIDField))
+			}
+		}
+	})
+})
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
@@ -27,6 +27,7 @@ import (
 	"mime"
 	"net/http"
 	"net/url"
+	"regexp"
 	"sort"
 	"strings"
 
@@ -161,8 +162,9 @@ func (d *RoundTripper) RoundTrip(request *http.Request) (response *http.Response
 
 // dumpRequest dumps to the log, in debug level, the details of the given HTTP request.
 func (d *RoundTripper) dumpRequest(request *http.Request, body []byte) {
-	d.logger.Debugf("Request method is %s", request.Method)
-	d.logger.Debugf("Request URL is '%s'", request.URL)
+	log := logrus.NewEntry(d.logger)
+	log.Debugf("Request method is %s", request.Method)
+	log.Debugf("Request URL is '%s'", request.URL)
 	header := request.Header
 	names := make([]string, len(header))
 	i := 0
@@ -174,21 +176,26 @@ func (d *RoundTripper) dumpRequest(request *http.Request, body []byte) {
 	for _, name := range names {
 		values := header[name]
 		for _, value := range values {
-			if strings.ToLower(name) == "authorization" {
-				d.logger.Debugf("Request header '%s' is omitted", name)
+			if sensitiveHeaders[strings.ToLower(name)] {
+				log.Debugf("Request header '%s' is omitted", name)
 			} else {
-				d.logger.Debugf("Request header '%s' is '%s'", name, value)
+				log.Debugf("Request header '%s' is '%s'", name, value)
 			}
 		}
 	}
 	if body != nil {
-		d.dumpBody("Request", header, body)
+		d.dumpBody(log, "Request", header, body)
 	}
 }
 
-// dumpResponse dumps to the log, in debug level, the details of the given HTTP response.
+// dumpResponse dumps to the log, in debug level, the details of the given HTTP response. The
+// identifier that the server assigned to the request is only added to these messages.
 func (d *RoundTripper) dumpResponse(response *http.Response, body []byte) {
-	d.logger.Debugf("Response status is '%s'", response.Status)
+	log := logrus.NewEntry(d.logger)
+	if requestID := getRequestID(response.Header); requestID != "" {
+		log = log.WithField(RequestIDField, requestID)
+	}
+	log.Debugf("Response status is '%s'", response.Status)
 	header := response.Header
 	names := make([]string, len(header))
 	i := 0
@@ -200,17 +207,32 @@ func (d *RoundTripper) dumpResponse(response *http.Response, body []byte) {
 	for _, name := range names {
 		values := header[name]
 		for _, value := range values {
-			d.logger.Debugf("Response header '%s' is '%s'", name, value)
+			if sensitiveHeaders[strings.ToLower(name)] {
+				log.Debugf("Response header '%s' is omitted", name)
+			} else {
+				log.Debugf("Response header '%s' is '%s'", name, value)
+			}
 		}
 	}
 	if body != nil {
-		d.dumpBody("Response", header, body)
+		d.dumpBody(log, "Response", header, body)
+	}
+}
+
+// getRequestID returns the identifier that the server assigned to the request, from the headers
+// used by the OCM and AWS APIs.
+func getRequestID(header http.Header) string {
+	for _, name := range []string{"X-Operation-Id", "X-Request-Id", "X-Amzn-Requestid", "X-Amz-Request-Id"} {
+		if value := header.Get(name); value != "" {
+			return value
+		}
 	}
+	return ""
 }
 
 // dumpBody checks the content type used in the given header and then it dumps the given body in a
 // format suitable for that content type.
-func (d *RoundTripper) dumpBody(what string, header http.Header, body []byte) {
+func (d *RoundTripper) dumpBody(log *logrus.Entry, what string, header http.Header, body []byte) {
 	// Try to parse the content type:
 	var mediaType string
 	contentType := header.Get("Content-Type")
@@ -218,7 +240,7 @@ func (d *RoundTripper) dumpBody(what string, header http.Header, body []byte) {
 		var err error
 		mediaType, _, err = mime.ParseMediaType(contentType)
 		if err != nil {
-			d.logger.Errorf("Failed to parse content type '%s': %v", contentType, err)
+			log.Errorf("Failed to parse content type '%s': %v", contentType, err)
 		}
 	} else {
 		mediaType = contentType
@@ -227,21 +249,24 @@ func (d *RoundTripper) dumpBody(what string, header http.Header, body []byte) {
 	// Dump the body according to the content type:
 	switch mediaType {
 	case "application/x-www-form-urlencoded":
-		d.dumpForm(what, body)
+		d.dumpForm(log, what, body)
 	case "application/json", "application/x-amz-json-1.0", "application/x-amz-json-1.1":
-		d.dumpJSON(what, body)
+		d.dumpJSON(log, what, body)
+	case "text/xml", "application/xml":
+		d.dumpBytes(log, what, sensitiveXMLRE.ReplaceAll(body, []byte("<$1>"+redactedReplacement+"</")))
 	default:
-		d.dumpBytes(what, body)
+		d.dumpBytes(log, what, body)
 	}
 }
 
 // dumpForm sends to the log the contents of the given form data, excluding security sensitive
 // fields.
-func (d *RoundTripper) dumpForm(what string, data []byte) {
+func (d *RoundTripper) dumpForm(log *logrus.Entry, what string, data []byte) {
 	// Parse the form:
 	form, err := url.ParseQuery(string(data))
 	if err != nil {
-		d.dumpBytes(what, data)
+		// The body can't be redacted if it can't be parsed, so it isn't sent to the log:
+		log.Debugf("%s body can't be parsed as a form, it is omitted", what)
 		return
 	}
 
@@ -271,10 +296,10 @@ func (d *RoundTripper) dumpForm(what string, data []byte) {
 			var redacted string
 			if d.redact[name] {
 				redacted = redactedReplacement
-				d.logger.Debugf("%s field '%s' is redacted", what, name)
+				log.Debugf("%s field '%s' is redacted", what, name)
 			} else {
 				redacted = url.QueryEscape(value)
-				d.logger.Debugf("%s field '%s' is '%s'", what, name, value)
+				log.Debugf("%s field '%s' is '%s'", what, name, value)
 			}
 			if buffer.Len() > 0 {
 				buffer.WriteByte('&') // #nosec G104
@@ -286,34 +311,40 @@ func (d *RoundTripper) dumpForm(what string, data []byte) {
 	}
 
 	// Send the redactedReplacement data to the log:
-	d.dumpBytes(what, buffer.Bytes())
+	d.dumpBytes(log, what, buffer.Bytes())
 }
 
 // dumpJSON tries to parse the given data as a JSON document. If that works, then it dumps it
-// indented, otherwise dumps it as is.
-func (d *RoundTripper) dumpJSON(what string, data []byte) {
+// indented and with the sensitive fields redacted, otherwise it omits it, as it can't be redacted.
+func (d *RoundTripper) dumpJSON(log *logrus.Entry, what string, data []byte) {
 	parsed := ordered.NewOrderedMap()
 	err := json.Unmarshal(data, parsed)
 	if err != nil {
-		d.logger.Debugf("%s", data)
-	} else {
-		// remove sensitive information
-		d.redactSensitive(parsed)
+		log.Debugf("%s body can't be parsed as a JSON object, it is omitted", what)
+		return
+	}
 
-		indented, err := json.MarshalIndent(parsed, "", "  ")
-		if err != nil {
-			d.dumpBytes(what, data)
-		} else {
-			d.dumpBytes(what, indented)
-		}
+	// remove sensitive information
+	d.redactSensitive(parsed)
+
+	indented, err := json.MarshalIndent(parsed, "", "  ")
+	if err != nil {
+		log.Debugf("%s body can't be formatted, it is omitted", what)
+		return
 	}
+	d.dumpBytes(log, what, indented)
 }
 
 // dumpBytes dump the given data as an array of bytes.
-func (d *RoundTripper) dumpBytes(what string, data []byte) {
+func (d *RoundTripper) dumpBytes(log *logrus.Entry, what string, data []byte) {
 	size := len(data)
+	if size > 0 && Format() == JSONFormat {
+		// Raw bodies would break the one document per line format, so send them as a field:
+		log.WithField("body", string(data)).Debugf("%s body", what)
+		return
+	}
 	if size > 0 {
-		d.logger.Debugf("%s body follows", what)
+		log.Debugf("%s body follows", what)
 		d.logger.Out.Write(data)
 		last := data[size-1]
 		if last != '\n' {
@@ -322,7 +353,8 @@ func (d *RoundTripper) dumpBytes(what string, data []byte) {
 	}
 }
 
-// redactSensitive replaces sensitive fields within a response with redactionStr.
+// redactSensitive replaces sensitive fields within a response with redactionStr, including the
+// fields of nested objects and of the objects inside arrays.
 func (d *RoundTripper) redactSensitive(body *ordered.OrderedMap) {
 	iterator := body.EntriesIter()
 	for {
@@ -332,9 +364,35 @@ func (d *RoundTripper) redactSensitive(body *ordered.OrderedMap) {
 		}
 		if d.redact[pair.Key] {
 			body.Set(pair.Key, redactedReplacement)
+			continue
+		}
+		d.redactValue(pair.Value)
+	}
+}
+
+// redactValue redacts the sensitive fields of the objects contained in the given value.
+func (d *RoundTripper) redactValue(value interface{}) {
+	switch typed := value.(type) {
+	case *ordered.OrderedMap:
+		d.redactSensitive(typed)
+	case []interface{}:
+		for _, item := range typed {
+			d.redactValue(item)
 		}
 	}
 }
 
 // String that replaces redactedReplacement fields in messages sent to the log:
 const redactedReplacement = "***"
+
+// Headers whose values are omitted from the messages sent to the log:
+var sensitiveHeaders = map[string]bool{
+	"authorization":        true,
+	"cookie":               true,
+	"set-cookie":           true,
+	"x-amz-security-token": true,
+}
+
+// Regular expression that matches the values of the elements of the XML responses of the AWS API
+// that contain credentials:
+var sensitiveXMLRE = regexp.MustCompile(`<(SecretAccessKey|SessionToken|Password)>[^<]*</`)
@@ -0,0 +1,106 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions used to implement the '--debug-http-trace' command line option,
+// that records the HTTP requests and responses sent to the OCM and AWS APIs so that they can be
+// attached to support cases.
+
+package logging
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/pflag"
+)
+
+// traceRedactedFields are the fields of forms and JSON documents that are removed from the trace,
+// at any level of nesting, for example the password of the users of an htpasswd identity provider,
+// the credentials returned by AWS SSO, the secret of the clients of an external authentication
+// provider or the OIDC private key stored in AWS Secrets Manager.
+var traceRedactedFields = []string{
+	"access_token",
+	"bind_password",
+	"client_secret",
+	"id_token",
+	"kubeconfig",
+	"password",
+	"refresh_token",
+	"secret",
+	"SecretString",
+	"secretAccessKey",
+	"secret_access_key",
+	"sessionToken",
+	"token",
+}
+
+var traceFile string
+var traceLogger *logrus.Logger
+
+// AddTraceFlag adds the HTTP trace flag to the given set of command line flags.
+func AddTraceFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&traceFile,
+		"debug-http-trace",
+		"",
+		"Record the HTTP requests sent to the OCM and AWS APIs and their responses to the given file, "+
+			"with tokens and credentials redacted, so that it can be attached to support cases.",
+	)
+}
+
+// OpenTrace opens the file where the HTTP trace is recorded, if the '--debug-http-trace' flag was
+// used. New traces are appended to the file.
+func OpenTrace() error {
+	if traceFile == "" || traceLogger != nil {
+		return nil
+	}
+	file, err := os.OpenFile(traceFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("Failed to open HTTP trace file '%s': %v", traceFile, err)
+	}
+	logger := logrus.New()
+	logger.SetOutput(file)
+	logger.SetLevel(logrus.DebugLevel)
+	logger.SetFormatter(&logrus.TextFormatter{
+		DisableColors: true,
+		DisableQuote:  true,
+		FullTimestamp: true,
+	})
+	logger.AddHook(fieldsHook{})
+	traceLogger = logger
+	return nil
+}
+
+// TraceTransport wraps the given transport so that the requests and responses are recorded in the
+// HTTP trace file. The transport is returned unchanged if the trace isn't enabled.
+func TraceTransport(next http.RoundTripper) http.RoundTripper {
+	if traceLogger == nil {
+		return next
+	}
+	builder := NewRoundTripper().
+		Logger(traceLogger).
+		Next(next)
+	for _, field := range traceRedactedFields {
+		builder.Redact(field)
+	}
+	tracer, err := builder.Build()
+	if err != nil {
+		return next
+	}
+	return tracer
+}
@@ -0,0 +1,157 @@
+package logging
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sirupsen/logrus"
+)
+
+type fakeTransport struct {
+	header http.Header
+	body   string
+}
+
+func (t *fakeTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	return &http.Response{
+		Status:     "200 OK",
+		StatusCode: http.StatusOK,
+		Header:     t.header,
+		Body:       io.NopCloser(strings.NewReader(t.body)),
+		Request:    request,
+	}, nil
+}
+
+var _ = Describe("HTTP trace", func() {
+	AfterEach(func() {
+		traceFile = ""
+		traceLogger = nil
+		fields = logrus.Fields{}
+	})
+
+	send := func(transport http.RoundTripper, contentType string, body string) {
+		request, err := http.NewRequest(http.MethodPost, "https://api.example.com/token",
+			strings.NewReader(body))
+		Expect(err).ToNot(HaveOccurred())
+		request.Header.Set("Content-Type", contentType)
+		request.Header.Set("Authorization", "Bearer secret-bearer")
+		request.Header.Set("X-Amz-Security-Token", "secret-session")
+		response, err := transport.RoundTrip(request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(response.Body.Close()).To(Succeed())
+	}
+
+	It("Doesn't wrap the transport when the trace isn't enabled", func() {
+		next := &fakeTransport{}
+		Expect(OpenTrace()).To(Succeed())
+		Expect(TraceTransport(next)).To(BeIdenticalTo(next))
+	})
+
+	It("Records requests and responses with credentials redacted", func() {
+		traceFile = filepath.Join(GinkgoT().TempDir(), "trace.log")
+		Expect(OpenTrace()).To(Succeed())
+
+		transport := TraceTransport(&fakeTransport{
+			header: http.Header{
+				"Content-Type": []string{"application/json"},
+				"X-Request-Id": []string{"req-1"},
+			},
+			body: `{"access_token":"secret-access","expires_in":300}`,
+		})
+		send(transport, "application/x-www-form-urlencoded",
+			"grant_type=refresh_token&refresh_token=secret-refresh")
+
+		transport = TraceTransport(&fakeTransport{
+			header: http.Header{"Content-Type": []string{"text/xml"}},
+			body: "<Credentials><AccessKeyId>AKIA</AccessKeyId>" +
+				"<SecretAccessKey>secret-key</SecretAccessKey></Credentials>",
+		})
+		send(transport, "application/x-www-form-urlencoded", "Action=AssumeRole")
+
+		data, err := os.ReadFile(traceFile)
+		Expect(err).ToNot(HaveOccurred())
+		trace := string(data)
+		Expect(trace).To(ContainSubstring("Request URL is 'https://api.example.com/token'"))
+		Expect(trace).To(ContainSubstring("request-id=req-1"))
+		Expect(trace).To(ContainSubstring("expires_in"))
+		Expect(trace).To(ContainSubstring("<AccessKeyId>AKIA</AccessKeyId>"))
+		for _, secret := range []string{
+			"secret-bearer", "secret-session", "secret-access", "secret-refresh", "secret-key",
+		} {
+			Expect(trace).ToNot(ContainSubstring(secret))
+		}
+	})
+
+	It("Redacts the credentials of nested objects and arrays", func() {
+		traceFile = filepath.Join(GinkgoT().TempDir(), "trace.log")
+		Expect(OpenTrace()).To(Succeed())
+
+		transport := TraceTransport(&fakeTransport{
+			header: http.Header{"Content-Type": []string{"application/json"}},
+			body: `{"roleCredentials":{"accessKeyId":"ASIA","secretAccessKey":"secret-sso-key",` +
+				`"sessionToken":"secret-sso-session"}}`,
+		})
+		send(transport, "application/json",
+			`{"aws":{"access_key_id":"AKIA","secret_access_key":"secret-cluster-key"},`+
+				`"htpasswd":{"users":{"items":[{"username":"admin","password":"secret-user"}]}},`+
+				`"github":{"client_id":"id","client_secret":"secret-client"},`+
+				`"ldap":{"bind_password":"secret-bind"},"kubeconfig":"secret-kubeconfig"}`)
+
+		data, err := os.ReadFile(traceFile)
+		Expect(err).ToNot(HaveOccurred())
+		trace := string(data)
+		Expect(trace).To(ContainSubstring("access_key_id"))
+		Expect(trace).To(ContainSubstring("admin"))
+		Expect(trace).To(ContainSubstring("accessKeyId"))
+		for _, secret := range []string{
+			"secret-sso-key", "secret-sso-session", "secret-cluster-key", "secret-user", "secret-client",
+			"secret-bind", "secret-kubeconfig",
+		} {
+			Expect(trace).ToNot(ContainSubstring(secret))
+		}
+	})
+
+	It("Redacts the OIDC private key and the external authentication client secrets", func() {
+		traceFile = filepath.Join(GinkgoT().TempDir(), "trace.log")
+		Expect(OpenTrace()).To(Succeed())
+
+		transport := TraceTransport(&fakeTransport{
+			header: http.Header{"Content-Type": []string{"application/json"}},
+			body:   `{"id":"my-auth","clients":[{"id":"console","secret":"secret-external-auth"}]}`,
+		})
+		send(transport, "application/x-amz-json-1.1",
+			`{"Name":"my-oidc-private-key","SecretString":"secret-private-key"}`)
+
+		data, err := os.ReadFile(traceFile)
+		Expect(err).ToNot(HaveOccurred())
+		trace := string(data)
+		Expect(trace).To(ContainSubstring("my-oidc-private-key"))
+		Expect(trace).To(ContainSubstring("console"))
+		Expect(trace).ToNot(ContainSubstring("secret-private-key"))
+		Expect(trace).ToNot(ContainSubstring("secret-external-auth"))
+	})
+
+	It("Omits the bodies that can't be parsed", func() {
+		traceFile = filepath.Join(GinkgoT().TempDir(), "trace.log")
+		Expect(OpenTrace()).To(Succeed())
+
+		transport := TraceTransport(&fakeTransport{
+			header: http.Header{"Content-Type": []string{"application/json"}},
+			body:   `{"password":"secret-truncated"`,
+		})
+		send(transport, "application/x-www-form-urlencoded", "password=secret-form;%zz")
+
+		data, err := os.ReadFile(traceFile)
+		Expect(err).ToNot(HaveOccurred())
+		trace := string(data)
+		Expect(trace).To(ContainSubstring("Response body can't be parsed as a JSON object, it is omitted"))
+		Expect(trace).To(ContainSubstring("Request body can't be parsed as a form, it is omitted"))
+		Expect(trace).ToNot(ContainSubstring("secret-truncated"))
+		Expect(trace).ToNot(ContainSubstring("secret-form"))
+	})
+})
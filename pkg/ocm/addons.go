@@ -18,9 +18,12 @@ package ocm
 
 import (
 	"fmt"
+	"strings"
 
 	amsv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/helper"
 )
 
 type AddOnBilling struct {
@@ -61,6 +64,29 @@ type ClusterAddOn struct {
 	State string
 }
 
+// ValidateAddOnBilling checks that the billing model is one of the given allowed models and that an
+// account to bill is given when the model isn't the standard one.
+func ValidateAddOnBilling(billing AddOnBilling, allowedModels []string) error {
+	if !helper.Contains(allowedModels, billing.BillingModel) {
+		return fmt.Errorf("Billing model '%s' isn't available for the add-on, valid options are: %s",
+			billing.BillingModel, strings.Join(allowedModels, ", "))
+	}
+	if billing.BillingModel != string(amsv1.BillingModelStandard) && billing.BillingAccountID == "" {
+		return fmt.Errorf("Billing model '%s' requires the ID of the account to bill", billing.BillingModel)
+	}
+	return nil
+}
+
+func addOnBillingBuilder(billing AddOnBilling) (*cmv1.AddOnInstallationBillingBuilder, error) {
+	billingModel, exists := BillingModels[billing.BillingModel]
+	if !exists {
+		return nil, fmt.Errorf("'%s' is not an valid billing model", billing.BillingModel)
+	}
+	return cmv1.NewAddOnInstallationBilling().
+		BillingModel(billingModel).
+		BillingMarketplaceAccount(billing.BillingAccountID), nil
+}
+
 func (c *Client) InstallAddOn(clusterID, addOnID string, params []AddOnParam, billing AddOnBilling) error {
 	addOnInstallationBuilder := cmv1.NewAddOnInstallation().
 		Addon(cmv1.NewAddOn().ID(addOnID))
@@ -74,13 +100,10 @@ func (c *Client) InstallAddOn(clusterID, addOnID string, params []AddOnParam, bi
 			Parameters(cmv1.NewAddOnInstallationParameterList().Items(addOnParamList...))
 	}
 
-	billingModel, exists := BillingModels[billing.BillingModel]
-	if !exists {
-		return fmt.Errorf("'%s' is not an valid billing model", billing.BillingModel)
+	billingBuilder, err := addOnBillingBuilder(billing)
+	if err != nil {
+		return err
 	}
-	billingBuilder := cmv1.NewAddOnInstallationBilling().
-		BillingModel(billingModel).
-		BillingMarketplaceAccount(billing.BillingAccountID)
 	addOnInstallationBuilder.Billing(billingBuilder)
 
 	addOnInstallation, err := addOnInstallationBuilder.Build()
@@ -132,7 +155,10 @@ func (c *Client) GetAddOnInstallation(clusterID, addOnID string) (*cmv1.AddOnIns
 	return response.Body(), nil
 }
 
-func (c *Client) UpdateAddOnInstallation(clusterID, addOnID string, params []AddOnParam) error {
+// UpdateAddOnInstallation updates the parameters of the add-on installation and, when the billing
+// isn't nil, its billing model.
+func (c *Client) UpdateAddOnInstallation(clusterID, addOnID string, params []AddOnParam,
+	billing *AddOnBilling) error {
 	addOnInstallationBuilder := cmv1.NewAddOnInstallation().
 		Addon(cmv1.NewAddOn().ID(addOnID))
 
@@ -145,6 +171,14 @@ func (c *Client) UpdateAddOnInstallation(clusterID, addOnID string, params []Add
 			Parameters(cmv1.NewAddOnInstallationParameterList().Items(addOnParamList...))
 	}
 
+	if billing != nil {
+		billingBuilder, err := addOnBillingBuilder(*billing)
+		if err != nil {
+			return err
+		}
+		addOnInstallationBuilder.Billing(billingBuilder)
+	}
+
 	addOnInstallation, err := addOnInstallationBuilder.Build()
 	if err != nil {
 		return err
@@ -169,8 +203,8 @@ func (c *Client) GetAddOnParameters(clusterID, addOnID string) (*cmv1.AddOnParam
 	return response.Body().Parameters(), nil
 }
 
-// Get complete list of available add-ons for the current organization
-func (c *Client) GetAvailableAddOns() ([]*AddOnResource, error) {
+// getAddOnQuotaCosts returns the add-on quotas of the organization of the current user.
+func (c *Client) getAddOnQuotaCosts() (*amsv1.QuotaCostList, error) {
 	// Get organization ID (used to get add-on quotas)
 	acctResponse, err := c.ocm.AccountsMgmt().V1().CurrentAccount().
 		Get().
@@ -193,7 +227,15 @@ func (c *Client) GetAvailableAddOns() ([]*AddOnResource, error) {
 	if err != nil {
 		return nil, handleErr(quotaCostResponse.Error(), err)
 	}
-	quotaCosts := quotaCostResponse.Items()
+	return quotaCostResponse.Items(), nil
+}
+
+// Get complete list of available add-ons for the current organization
+func (c *Client) GetAvailableAddOns() ([]*AddOnResource, error) {
+	quotaCosts, err := c.getAddOnQuotaCosts()
+	if err != nil {
+		return nil, err
+	}
 
 	// Get complete list of enabled add-ons
 	addOnsResponse, err := c.ocm.ClustersMgmt().V1().Addons().
@@ -247,6 +289,53 @@ func (c *Client) GetAvailableAddOns() ([]*AddOnResource, error) {
 	return addOns, nil
 }
 
+// GetAddOnBillingModels returns the billing models that the organization of the current user has
+// quota to install the add-on with. For an existing installation its billing model is given, and
+// the quota it already consumes is counted as available, as it would be released when changing it.
+func (c *Client) GetAddOnBillingModels(addOn *cmv1.AddOn, installedBillingModel string) ([]string, error) {
+	quotaCosts, err := c.getAddOnQuotaCosts()
+	if err != nil {
+		return nil, err
+	}
+	return addOnBillingModels(addOn, quotaCosts.Slice(), installedBillingModel), nil
+}
+
+func addOnBillingModels(addOn *cmv1.AddOn, quotaCosts []*amsv1.QuotaCost,
+	installedBillingModel string) []string {
+	// Free add-ons don't consume quota, so they are always billed with the standard model
+	if addOn.ResourceCost() == 0 {
+		return []string{string(amsv1.BillingModelStandard)}
+	}
+	allowed := map[string]bool{}
+	for _, quotaCost := range quotaCosts {
+		for _, relatedResource := range quotaCost.RelatedResources() {
+			if addOn.ResourceName() != relatedResource.ResourceName() || !isCompatible(relatedResource) {
+				continue
+			}
+			billingModel := relatedResource.BillingModel()
+			if billingModel == "" || billingModel == ANY {
+				billingModel = string(amsv1.BillingModelStandard)
+			}
+			available := quotaCost.Allowed() - quotaCost.Consumed()
+			if billingModel == installedBillingModel {
+				available += relatedResource.Cost()
+			}
+			if available < relatedResource.Cost() {
+				continue
+			}
+			allowed[billingModel] = true
+		}
+	}
+	// Keep the order of the billing options, so that the prompts are stable
+	models := []string{}
+	for _, option := range BillingOptions {
+		if allowed[option] {
+			models = append(models, option)
+		}
+	}
+	return models
+}
+
 func (c *Client) GetAddOn(id string) (*cmv1.AddOn, error) {
 	response, err := c.ocm.ClustersMgmt().V1().Addons().Addon(id).Get().Send()
 	if err != nil {
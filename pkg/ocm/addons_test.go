@@ -0,0 +1,77 @@
+package ocm
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	amsv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+var _ = Describe("Add-on billing", func() {
+	quotaCost := func(allowed, consumed int, resourceName, billingModel string) *amsv1.QuotaCost {
+		quotaCost, err := amsv1.NewQuotaCost().
+			QuotaID("add-on|" + resourceName).
+			Allowed(allowed).
+			Consumed(consumed).
+			RelatedResources(amsv1.NewRelatedResource().
+				Product("ROSA").
+				CloudProvider("aws").
+				BYOC("byoc").
+				ResourceName(resourceName).
+				BillingModel(billingModel).
+				Cost(1)).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		return quotaCost
+	}
+	addOn := func(resourceCost float64) *cmv1.AddOn {
+		addOn, err := cmv1.NewAddOn().ID("rhoam").ResourceName("addon-rhoam").ResourceCost(resourceCost).Build()
+		Expect(err).ToNot(HaveOccurred())
+		return addOn
+	}
+
+	It("bills free add-ons with the standard model", func() {
+		Expect(addOnBillingModels(addOn(0), nil, "")).To(Equal([]string{"standard"}))
+	})
+
+	It("returns the billing models with quota left for the add-on", func() {
+		models := addOnBillingModels(addOn(1), []*amsv1.QuotaCost{
+			quotaCost(5, 1, "addon-rhoam", "marketplace-aws"),
+			quotaCost(5, 5, "addon-rhoam", "standard"),
+			quotaCost(5, 0, "addon-other", "marketplace"),
+			quotaCost(5, 0, "addon-rhoam", "any"),
+		}, "")
+		Expect(models).To(Equal([]string{"standard", "marketplace-aws"}))
+	})
+
+	It("returns no billing models when there is no quota for the add-on", func() {
+		models := addOnBillingModels(addOn(1), []*amsv1.QuotaCost{
+			quotaCost(5, 5, "addon-rhoam", "marketplace-aws"),
+		}, "")
+		Expect(models).To(BeEmpty())
+	})
+
+	It("counts the quota consumed by the installation as available for its billing model", func() {
+		quotaCosts := []*amsv1.QuotaCost{
+			quotaCost(5, 5, "addon-rhoam", "marketplace-aws"),
+			quotaCost(5, 5, "addon-rhoam", "standard"),
+		}
+		Expect(addOnBillingModels(addOn(1), quotaCosts, "marketplace-aws")).To(Equal(
+			[]string{"marketplace-aws"}))
+		Expect(addOnBillingModels(addOn(1), quotaCosts, "standard")).To(Equal([]string{"standard"}))
+	})
+
+	It("validates the billing model and account", func() {
+		models := []string{"standard", "marketplace-aws"}
+		Expect(ValidateAddOnBilling(AddOnBilling{BillingModel: "standard"}, models)).To(Succeed())
+		Expect(ValidateAddOnBilling(AddOnBilling{
+			BillingModel:     "marketplace-aws",
+			BillingAccountID: "123456789012",
+		}, models)).To(Succeed())
+		Expect(ValidateAddOnBilling(AddOnBilling{BillingModel: "marketplace-aws"}, models)).To(MatchError(
+			"Billing model 'marketplace-aws' requires the ID of the account to bill"))
+		Expect(ValidateAddOnBilling(AddOnBilling{BillingModel: "marketplace-rhm"}, models)).To(MatchError(
+			"Billing model 'marketplace-rhm' isn't available for the add-on, valid options are: " +
+				"standard, marketplace-aws"))
+	})
+})
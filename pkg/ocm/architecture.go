@@ -0,0 +1,102 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions used to find out the CPU architecture of instance types and to
+// check that clusters can run machine pools of a different architecture than their control plane.
+
+package ocm
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+const (
+	ArchitectureAMD64 = "amd64"
+	ArchitectureARM64 = "arm64"
+
+	// MinimumMultiArchVersion is the first OpenShift version with multi-architecture payloads.
+	MinimumMultiArchVersion = "4.12"
+
+	multiArchSuffix = "-multi"
+)
+
+// Architectures are the CPU architectures of the supported instance types.
+var Architectures = []string{ArchitectureAMD64, ArchitectureARM64}
+
+// The name of an instance type is the family, the generation and the additional capabilities
+// before the size, for example 'm6gd.xlarge'. The 'g' capability means AWS Graviton processors.
+var instanceTypeNameRE = regexp.MustCompile(`^([a-z]+)(\d+)([a-z-]*)\.`)
+
+// GetMachineTypeArchitecture returns the CPU architecture of the instance type.
+func GetMachineTypeArchitecture(machineType string) string {
+	if strings.HasPrefix(machineType, "a1.") {
+		return ArchitectureARM64
+	}
+	matches := instanceTypeNameRE.FindStringSubmatch(machineType)
+	if matches != nil && strings.Contains(matches[3], "g") {
+		return ArchitectureARM64
+	}
+	return ArchitectureAMD64
+}
+
+// Architecture returns the CPU architecture of the machine type.
+func (mt MachineType) Architecture() string {
+	return GetMachineTypeArchitecture(mt.MachineType.ID())
+}
+
+// IsMultiArchVersion returns true if the version uses a multi-architecture payload, which can run
+// nodes of any architecture regardless of the architecture of the control plane.
+func IsMultiArchVersion(version *cmv1.Version) bool {
+	return strings.HasSuffix(version.RawID(), multiArchSuffix) ||
+		strings.HasSuffix(version.ID(), multiArchSuffix) ||
+		strings.HasSuffix(version.ReleaseImage(), multiArchSuffix)
+}
+
+// ValidateMachineTypeArchitecture checks that the cluster can run a machine pool of the given
+// instance type. Instance types of the architecture of the default compute nodes are always
+// accepted, others require a multi-architecture payload.
+func ValidateMachineTypeArchitecture(cluster *cmv1.Cluster, machineType string) error {
+	architecture := GetMachineTypeArchitecture(machineType)
+	clusterArchitecture := ArchitectureAMD64
+	if cluster.Nodes().ComputeMachineType().ID() != "" {
+		clusterArchitecture = GetMachineTypeArchitecture(cluster.Nodes().ComputeMachineType().ID())
+	}
+	if architecture == clusterArchitecture {
+		return nil
+	}
+	version := cluster.Version()
+	supported, err := CheckSupportedVersion(GetVersionMinor(version.RawID()), MinimumMultiArchVersion)
+	if err != nil {
+		return fmt.Errorf("Failed to check multi-architecture support of version '%s': %v", version.RawID(), err)
+	}
+	if !supported || !IsMultiArchVersion(version) {
+		return fmt.Errorf("Instance type '%s' is %s while the cluster nodes are %s. Mixing architectures "+
+			"requires a multi-architecture version %s or later, and cluster version is '%s'",
+			machineType, architecture, clusterArchitecture, MinimumMultiArchVersion, version.RawID())
+	}
+	return nil
+}
+
+// FilterByArchitecture returns the machine types of the given CPU architecture.
+func (mtl *MachineTypeList) FilterByArchitecture(architecture string) MachineTypeList {
+	return mtl.Filter(func(mt *MachineType) bool {
+		return mt.Architecture() == architecture
+	})
+}
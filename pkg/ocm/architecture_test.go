@@ -0,0 +1,54 @@
+package ocm
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+var _ = Describe("Architecture", func() {
+	DescribeTable("GetMachineTypeArchitecture",
+		func(machineType string, architecture string) {
+			Expect(GetMachineTypeArchitecture(machineType)).To(Equal(architecture))
+		},
+		Entry("general purpose", "m5.xlarge", ArchitectureAMD64),
+		Entry("graviton", "m6g.xlarge", ArchitectureARM64),
+		Entry("graviton with local storage", "r6gd.2xlarge", ArchitectureARM64),
+		Entry("graviton burstable", "t4g.large", ArchitectureARM64),
+		Entry("graviton accelerated", "g5g.xlarge", ArchitectureARM64),
+		Entry("first generation graviton", "a1.large", ArchitectureARM64),
+		Entry("accelerated", "g4dn.xlarge", ArchitectureAMD64),
+		Entry("amd", "m5a.xlarge", ArchitectureAMD64),
+		Entry("high memory", "u-6tb1.metal", ArchitectureAMD64),
+	)
+
+	Context("ValidateMachineTypeArchitecture", func() {
+		cluster := func(computeType string, version string) *cmv1.Cluster {
+			cluster, err := cmv1.NewCluster().
+				Nodes(cmv1.NewClusterNodes().ComputeMachineType(cmv1.NewMachineType().ID(computeType))).
+				Version(cmv1.NewVersion().RawID(version)).
+				Build()
+			Expect(err).ToNot(HaveOccurred())
+			return cluster
+		}
+
+		It("accepts machine pools of the cluster architecture", func() {
+			Expect(ValidateMachineTypeArchitecture(cluster("m5.xlarge", "4.11.3"), "r5.xlarge")).To(Succeed())
+		})
+
+		It("accepts mixed architectures with multi-architecture versions", func() {
+			Expect(ValidateMachineTypeArchitecture(cluster("m5.xlarge", "4.13.0-multi"), "m6g.xlarge")).
+				To(Succeed())
+		})
+
+		It("rejects mixed architectures with single architecture versions", func() {
+			Expect(ValidateMachineTypeArchitecture(cluster("m5.xlarge", "4.13.0"), "m6g.xlarge")).
+				To(MatchError(ContainSubstring("is arm64 while the cluster nodes are amd64")))
+		})
+
+		It("rejects mixed architectures with old versions", func() {
+			Expect(ValidateMachineTypeArchitecture(cluster("m5.xlarge", "4.11.0-multi"), "m6g.xlarge")).
+				To(HaveOccurred())
+		})
+	})
+})
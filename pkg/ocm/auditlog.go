@@ -0,0 +1,51 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ocm
+
+import (
+	"fmt"
+)
+
+// UpdateClusterAuditLogRoleARN changes the role used to forward the audit logs of the API server of
+// the hosted cluster to CloudWatch. An empty role disables the forwarding.
+func (c *Client) UpdateClusterAuditLogRoleARN(clusterID string, roleARN string) error {
+	body := map[string]interface{}{
+		"aws": map[string]interface{}{
+			"audit_log": map[string]interface{}{
+				"role_arn": roleARN,
+			},
+		},
+	}
+	return sendRaw(c.ocm.Patch().Path(fmt.Sprintf("%s/clusters/%s", clustersMgmtPath, clusterID)), body, nil)
+}
+
+// GetClusterAuditLogRoleARN returns the role used to forward the audit logs of the API server of the
+// hosted cluster to CloudWatch, or an empty string if the forwarding isn't enabled.
+func (c *Client) GetClusterAuditLogRoleARN(clusterID string) (string, error) {
+	var cluster struct {
+		AWS struct {
+			AuditLog struct {
+				RoleARN string `json:"role_arn"`
+			} `json:"audit_log"`
+		} `json:"aws"`
+	}
+	err := sendRaw(c.ocm.Get().Path(fmt.Sprintf("%s/clusters/%s", clustersMgmtPath, clusterID)), nil, &cluster)
+	if err != nil {
+		return "", err
+	}
+	return cluster.AWS.AuditLog.RoleARN, nil
+}
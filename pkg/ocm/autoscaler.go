@@ -0,0 +1,105 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ocm
+
+import (
+	"fmt"
+)
+
+// ClusterAutoscaler is the cluster-wide autoscaler configuration of a classic cluster, stored in
+// the autoscaler sub-resource of the cluster, that the SDK doesn't model, see raw.go.
+type ClusterAutoscaler struct {
+	Kind                        string                          `json:"kind,omitempty"`
+	HREF                        string                          `json:"href,omitempty"`
+	BalanceSimilarNodeGroups    bool                            `json:"balance_similar_node_groups"`
+	SkipNodesWithLocalStorage   bool                            `json:"skip_nodes_with_local_storage"`
+	LogVerbosity                int                             `json:"log_verbosity"`
+	BalancingIgnoredLabels      []string                        `json:"balancing_ignored_labels"`
+	IgnoreDaemonsetsUtilization bool                            `json:"ignore_daemonsets_utilization"`
+	MaxNodeProvisionTime        string                          `json:"max_node_provision_time,omitempty"`
+	MaxPodGracePeriod           int                             `json:"max_pod_grace_period"`
+	PodPriorityThreshold        int                             `json:"pod_priority_threshold"`
+	ResourceLimits              ClusterAutoscalerResourceLimits `json:"resource_limits"`
+	ScaleDown                   ClusterAutoscalerScaleDown      `json:"scale_down"`
+}
+
+type ClusterAutoscalerResourceLimits struct {
+	MaxNodesTotal int                         `json:"max_nodes_total"`
+	Cores         ResourceRange               `json:"cores"`
+	Memory        ResourceRange               `json:"memory"`
+	GPUS          []ClusterAutoscalerGPULimit `json:"gpus"`
+}
+
+type ClusterAutoscalerGPULimit struct {
+	Type  string        `json:"type"`
+	Range ResourceRange `json:"range"`
+}
+
+type ResourceRange struct {
+	Min int `json:"min"`
+	Max int `json:"max"`
+}
+
+type ClusterAutoscalerScaleDown struct {
+	Enabled              bool   `json:"enabled"`
+	UnneededTime         string `json:"unneeded_time,omitempty"`
+	UtilizationThreshold string `json:"utilization_threshold,omitempty"`
+	DelayAfterAdd        string `json:"delay_after_add,omitempty"`
+	DelayAfterDelete     string `json:"delay_after_delete,omitempty"`
+	DelayAfterFailure    string `json:"delay_after_failure,omitempty"`
+}
+
+func clusterAutoscalerPath(clusterID string) string {
+	return fmt.Sprintf("%s/clusters/%s/autoscaler", clustersMgmtPath, clusterID)
+}
+
+// GetClusterAutoscaler returns the autoscaler of the cluster, or nil if it doesn't have one.
+func (c *Client) GetClusterAutoscaler(clusterID string) (*ClusterAutoscaler, error) {
+	autoscaler := new(ClusterAutoscaler)
+	err := sendRaw(c.ocm.Get().Path(clusterAutoscalerPath(clusterID)), nil, autoscaler)
+	if isNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return autoscaler, nil
+}
+
+func (c *Client) CreateClusterAutoscaler(clusterID string,
+	autoscaler *ClusterAutoscaler) (*ClusterAutoscaler, error) {
+	created := new(ClusterAutoscaler)
+	err := sendRaw(c.ocm.Post().Path(clusterAutoscalerPath(clusterID)), autoscaler, created)
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func (c *Client) UpdateClusterAutoscaler(clusterID string,
+	autoscaler *ClusterAutoscaler) (*ClusterAutoscaler, error) {
+	updated := new(ClusterAutoscaler)
+	err := sendRaw(c.ocm.Patch().Path(clusterAutoscalerPath(clusterID)), autoscaler, updated)
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func (c *Client) DeleteClusterAutoscaler(clusterID string) error {
+	return sendRaw(c.ocm.Delete().Path(clusterAutoscalerPath(clusterID)), nil, nil)
+}
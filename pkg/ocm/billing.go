@@ -0,0 +1,158 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions used to select the AWS account that is billed for the usage of
+// Hosted Control Plane clusters. The typed clients of the SDK don't support contracts nor the
+// billing account of clusters yet, so they are read and written as raw JSON.
+
+package ocm
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"time"
+)
+
+const accountsMgmtPath = "/api/accounts_mgmt/v1"
+
+// hcpBillingQuota selects the quota of the Hosted Control Plane clusters billed through the AWS
+// marketplace, whose cloud accounts are the billing accounts linked to the organization.
+const hcpBillingQuota = "quota_id='cluster|byoc|moa|marketplace'"
+
+var billingAccountRE = regexp.MustCompile(`^[0-9]{12}$`)
+
+// BillingAccount is an AWS account linked to the organization, through the AWS marketplace, that
+// can be billed for the usage of Hosted Control Plane clusters.
+type BillingAccount struct {
+	CloudAccountID  string      `json:"cloud_account_id"`
+	CloudProviderID string      `json:"cloud_provider_id"`
+	Contracts       []*Contract `json:"contracts,omitempty"`
+}
+
+// Contract is an agreement purchased in the AWS marketplace for a billing account.
+type Contract struct {
+	StartDate  time.Time            `json:"start_date"`
+	EndDate    time.Time            `json:"end_date"`
+	Dimensions []*ContractDimension `json:"dimensions,omitempty"`
+}
+
+// ContractDimension is an amount of resources included in a contract.
+type ContractDimension struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// Active checks if the contract applies at the given time.
+func (c *Contract) Active(now time.Time) bool {
+	return !now.Before(c.StartDate) && now.Before(c.EndDate)
+}
+
+// ContractStatus returns 'Active' if the billing account has an active contract, 'Expired' if all its
+// contracts have ended or not started yet, and 'None' if it has no contracts, in which case it is
+// billed on demand.
+func (a *BillingAccount) ContractStatus(now time.Time) string {
+	if len(a.Contracts) == 0 {
+		return "None"
+	}
+	for _, contract := range a.Contracts {
+		if contract.Active(now) {
+			return "Active"
+		}
+	}
+	return "Expired"
+}
+
+// GetBillingAccounts returns the AWS accounts that can be billed for Hosted Control Plane clusters of
+// the organization of the current user.
+func (c *Client) GetBillingAccounts() ([]*BillingAccount, error) {
+	organizationID, _, err := c.GetCurrentOrganization()
+	if err != nil {
+		return nil, err
+	}
+	var list struct {
+		Items []struct {
+			CloudAccounts []*BillingAccount `json:"cloud_accounts"`
+		} `json:"items"`
+	}
+	err = sendRaw(c.ocm.Get().
+		Path(fmt.Sprintf("%s/organizations/%s/quota_cost", accountsMgmtPath, url.PathEscape(organizationID))).
+		Parameter("fetchCloudAccounts", true).
+		Parameter("search", hcpBillingQuota).
+		Parameter("size", -1), nil, &list)
+	if err != nil {
+		return nil, err
+	}
+	accounts := []*BillingAccount{}
+	seen := map[string]bool{}
+	for _, item := range list.Items {
+		for _, account := range item.CloudAccounts {
+			if account.CloudProviderID != "aws" || seen[account.CloudAccountID] {
+				continue
+			}
+			seen[account.CloudAccountID] = true
+			accounts = append(accounts, account)
+		}
+	}
+	return accounts, nil
+}
+
+// ValidateBillingAccount checks that the given AWS account is one of the billing accounts of the
+// organization and that its ROSA with HCP contract, if it has one, is active.
+func ValidateBillingAccount(accounts []*BillingAccount, accountID string, now time.Time) error {
+	if !billingAccountRE.MatchString(accountID) {
+		return fmt.Errorf("Billing account '%s' isn't valid, expected an AWS account ID of 12 digits",
+			accountID)
+	}
+	for _, account := range accounts {
+		if account.CloudAccountID != accountID {
+			continue
+		}
+		if account.ContractStatus(now) == "Expired" {
+			return fmt.Errorf("The ROSA with HCP contract of billing account '%s' isn't active, renew it "+
+				"in the AWS marketplace or use another billing account", accountID)
+		}
+		return nil
+	}
+	return fmt.Errorf("AWS account '%s' isn't linked to your organization as a billing account for "+
+		"ROSA with HCP. Enable ROSA with HCP in the AWS console of that account, then run "+
+		"'rosa list billing-accounts' to check it", accountID)
+}
+
+// UpdateClusterBillingAccount changes the AWS account that is billed for the usage of the cluster.
+func (c *Client) UpdateClusterBillingAccount(clusterID string, accountID string) error {
+	body := map[string]interface{}{
+		"aws": map[string]interface{}{
+			"billing_account_id": accountID,
+		},
+	}
+	return sendRaw(c.ocm.Patch().Path(fmt.Sprintf("%s/clusters/%s", clustersMgmtPath, clusterID)), body, nil)
+}
+
+// GetClusterBillingAccount returns the AWS account that is billed for the usage of the cluster, or
+// an empty string if it isn't set.
+func (c *Client) GetClusterBillingAccount(clusterID string) (string, error) {
+	var cluster struct {
+		AWS struct {
+			BillingAccountID string `json:"billing_account_id"`
+		} `json:"aws"`
+	}
+	err := sendRaw(c.ocm.Get().Path(fmt.Sprintf("%s/clusters/%s", clustersMgmtPath, clusterID)), nil, &cluster)
+	if err != nil {
+		return "", err
+	}
+	return cluster.AWS.BillingAccountID, nil
+}
@@ -0,0 +1,47 @@
+package ocm
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Billing accounts", func() {
+	now := time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)
+	active := &Contract{
+		StartDate: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	expired := &Contract{
+		StartDate: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	accounts := []*BillingAccount{
+		{CloudAccountID: "123456789012", CloudProviderID: "aws"},
+		{CloudAccountID: "210987654321", CloudProviderID: "aws", Contracts: []*Contract{expired, active}},
+		{CloudAccountID: "111111111111", CloudProviderID: "aws", Contracts: []*Contract{expired}},
+	}
+
+	It("Reports the contract status", func() {
+		Expect(accounts[0].ContractStatus(now)).To(Equal("None"))
+		Expect(accounts[1].ContractStatus(now)).To(Equal("Active"))
+		Expect(accounts[2].ContractStatus(now)).To(Equal("Expired"))
+	})
+	It("Accepts linked accounts without expired contracts", func() {
+		Expect(ValidateBillingAccount(accounts, "123456789012", now)).To(Succeed())
+		Expect(ValidateBillingAccount(accounts, "210987654321", now)).To(Succeed())
+	})
+	It("Rejects malformed account IDs", func() {
+		err := ValidateBillingAccount(accounts, "12345", now)
+		Expect(err).To(MatchError(ContainSubstring("12 digits")))
+	})
+	It("Rejects accounts that aren't linked", func() {
+		err := ValidateBillingAccount(accounts, "999999999999", now)
+		Expect(err).To(MatchError(ContainSubstring("isn't linked")))
+	})
+	It("Rejects accounts with expired contracts", func() {
+		err := ValidateBillingAccount(accounts, "111111111111", now)
+		Expect(err).To(MatchError(ContainSubstring("isn't active")))
+	})
+})
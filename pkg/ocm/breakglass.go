@@ -0,0 +1,131 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ocm
+
+import (
+	"fmt"
+	"time"
+)
+
+// Statuses of break glass credentials.
+const (
+	BreakGlassCredentialStatusCreated            = "created"
+	BreakGlassCredentialStatusIssued             = "issued"
+	BreakGlassCredentialStatusFailed             = "failed"
+	BreakGlassCredentialStatusExpired            = "expired"
+	BreakGlassCredentialStatusAwaitingRevocation = "awaiting_revocation"
+	BreakGlassCredentialStatusRevoked            = "revoked"
+)
+
+// Range of expirations of break glass credentials accepted by the service.
+const (
+	BreakGlassCredentialMinExpiration     = 10 * time.Minute
+	BreakGlassCredentialMaxExpiration     = 24 * time.Hour
+	BreakGlassCredentialDefaultExpiration = 24 * time.Hour
+)
+
+const breakGlassCredentialUsernameMaxLength = 35
+
+// BreakGlassCredential is a short lived kubeconfig that gives emergency access to a Hosted Control
+// Plane cluster that uses external authentication, when the external OIDC provider isn't available.
+// There is no break_glass_credentials resource in the SDK, see raw.go.
+type BreakGlassCredential struct {
+	Kind                string     `json:"kind,omitempty"`
+	ID                  string     `json:"id,omitempty"`
+	HREF                string     `json:"href,omitempty"`
+	Username            string     `json:"username,omitempty"`
+	ExpirationTimestamp *time.Time `json:"expiration_timestamp,omitempty"`
+	RevocationTimestamp *time.Time `json:"revocation_timestamp,omitempty"`
+	Status              string     `json:"status,omitempty"`
+	Kubeconfig          string     `json:"kubeconfig,omitempty"`
+}
+
+func breakGlassCredentialsPath(clusterID string) string {
+	return fmt.Sprintf("%s/clusters/%s/break_glass_credentials", clustersMgmtPath, clusterID)
+}
+
+// ValidateBreakGlassCredentialExpiration checks that the expiration of a break glass credential is
+// within the range accepted by the service.
+func ValidateBreakGlassCredentialExpiration(expiration time.Duration) error {
+	if expiration < BreakGlassCredentialMinExpiration || expiration > BreakGlassCredentialMaxExpiration {
+		return fmt.Errorf("Expiration must be between %s and %s, got '%s'",
+			BreakGlassCredentialMinExpiration, BreakGlassCredentialMaxExpiration, expiration)
+	}
+	return nil
+}
+
+// ValidateBreakGlassCredentialUsername checks the optional user name of a break glass credential.
+func ValidateBreakGlassCredentialUsername(username string) error {
+	if len(username) > breakGlassCredentialUsernameMaxLength {
+		return fmt.Errorf("Username must be at most %d characters long", breakGlassCredentialUsernameMaxLength)
+	}
+	for _, char := range username {
+		if !(char >= 'a' && char <= 'z' || char >= '0' && char <= '9' || char == '-' || char == '_') {
+			return fmt.Errorf("Username '%s' may only contain lowercase letters, digits, '-' and '_'", username)
+		}
+	}
+	return nil
+}
+
+// CreateBreakGlassCredential requests a new break glass credential for the cluster. The username is
+// generated by the service when empty. The kubeconfig of the credential is available once its
+// status is 'issued'.
+func (c *Client) CreateBreakGlassCredential(clusterID string, username string,
+	expiration time.Time) (*BreakGlassCredential, error) {
+	body := &BreakGlassCredential{
+		Username:            username,
+		ExpirationTimestamp: &expiration,
+	}
+	created := new(BreakGlassCredential)
+	err := sendRaw(c.ocm.Post().Path(breakGlassCredentialsPath(clusterID)), body, created)
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// GetBreakGlassCredentials returns the break glass credentials of the cluster.
+func (c *Client) GetBreakGlassCredentials(clusterID string) ([]*BreakGlassCredential, error) {
+	var list struct {
+		Items []*BreakGlassCredential `json:"items"`
+	}
+	err := sendRaw(c.ocm.Get().Path(breakGlassCredentialsPath(clusterID)).Parameter("size", -1), nil, &list)
+	if err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+// GetBreakGlassCredential returns the break glass credential, or nil if it doesn't exist.
+func (c *Client) GetBreakGlassCredential(clusterID string, id string) (*BreakGlassCredential, error) {
+	credential := new(BreakGlassCredential)
+	err := sendRaw(c.ocm.Get().Path(fmt.Sprintf("%s/%s", breakGlassCredentialsPath(clusterID), id)),
+		nil, credential)
+	if isNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return credential, nil
+}
+
+// RevokeBreakGlassCredentials revokes all the break glass credentials of the cluster. The service
+// doesn't support revoking a single credential.
+func (c *Client) RevokeBreakGlassCredentials(clusterID string) error {
+	return sendRaw(c.ocm.Delete().Path(breakGlassCredentialsPath(clusterID)), nil, nil)
+}
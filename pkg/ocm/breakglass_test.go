@@ -0,0 +1,122 @@
+package ocm
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	"github.com/openshift-online/ocm-sdk-go/logging"
+	. "github.com/openshift-online/ocm-sdk-go/testing"
+)
+
+var _ = Describe("Break glass credentials", func() {
+	DescribeTable("ValidateBreakGlassCredentialExpiration",
+		func(expiration time.Duration, valid bool) {
+			err := ValidateBreakGlassCredentialExpiration(expiration)
+			if valid {
+				Expect(err).ToNot(HaveOccurred())
+			} else {
+				Expect(err).To(HaveOccurred())
+			}
+		},
+		Entry("minimum", 10*time.Minute, true),
+		Entry("maximum", 24*time.Hour, true),
+		Entry("too short", 5*time.Minute, false),
+		Entry("too long", 25*time.Hour, false),
+	)
+
+	DescribeTable("ValidateBreakGlassCredentialUsername",
+		func(username string, valid bool) {
+			err := ValidateBreakGlassCredentialUsername(username)
+			if valid {
+				Expect(err).ToNot(HaveOccurred())
+			} else {
+				Expect(err).To(HaveOccurred())
+			}
+		},
+		Entry("generated", "", true),
+		Entry("valid", "emergency_admin-1", true),
+		Entry("uppercase", "Admin", false),
+		Entry("too long", "a123456789012345678901234567890123456", false),
+	)
+
+	Context("API", func() {
+		const credentialsPath = "/api/clusters_mgmt/v1/clusters/123/break_glass_credentials"
+
+		var apiServer *ghttp.Server
+		var ocmClient *Client
+
+		BeforeEach(func() {
+			apiServer = MakeTCPServer()
+			accessToken := MakeTokenString("Bearer", 15*time.Minute)
+			logger, err := logging.NewGoLoggerBuilder().Build()
+			Expect(err).To(BeNil())
+			connection, err := sdk.NewConnectionBuilder().
+				Logger(logger).
+				Tokens(accessToken).
+				URL(apiServer.URL()).
+				Build()
+			Expect(err).To(BeNil())
+			ocmClient = &Client{ocm: connection}
+		})
+
+		AfterEach(func() {
+			apiServer.Close()
+			Expect(ocmClient.Close()).To(Succeed())
+		})
+
+		It("Sends the username and the expiration of new credentials", func() {
+			var sent map[string]interface{}
+			apiServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPost, credentialsPath),
+					func(w http.ResponseWriter, req *http.Request) {
+						data, err := io.ReadAll(req.Body)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(json.Unmarshal(data, &sent)).To(Succeed())
+					},
+					RespondWithJSON(http.StatusCreated, `{
+						"kind": "BreakGlassCredential",
+						"id": "abc",
+						"username": "admin",
+						"status": "created"
+					}`),
+				),
+			)
+			expiration := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+			credential, err := ocmClient.CreateBreakGlassCredential("123", "admin", expiration)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(credential.ID).To(Equal("abc"))
+			Expect(credential.Status).To(Equal(BreakGlassCredentialStatusCreated))
+			Expect(sent).To(HaveKeyWithValue("username", "admin"))
+			Expect(sent).To(HaveKeyWithValue("expiration_timestamp", "2024-01-02T03:04:05Z"))
+		})
+
+		It("Returns nil for credentials that don't exist", func() {
+			apiServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, credentialsPath+"/missing"),
+					RespondWithJSON(http.StatusNotFound, `{"kind": "Error", "reason": "not found"}`),
+				),
+			)
+			credential, err := ocmClient.GetBreakGlassCredential("123", "missing")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(credential).To(BeNil())
+		})
+
+		It("Revokes all the credentials of the cluster", func() {
+			apiServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodDelete, credentialsPath),
+					RespondWithJSON(http.StatusNoContent, ""),
+				),
+			)
+			Expect(ocmClient.RevokeBreakGlassCredentials("123")).To(Succeed())
+		})
+	})
+})
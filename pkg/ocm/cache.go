@@ -0,0 +1,299 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the on-disk cache of the catalog data of the API, like versions, machine
+// types and regions, that changes rarely but is needed by most interactive commands.
+
+package ocm
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/config"
+)
+
+const (
+	// CatalogCacheTTL is the time during which cached catalog data is used without checking with the
+	// server if it changed. After that it is revalidated using its entity tag.
+	CatalogCacheTTL = time.Hour
+
+	// DisableCacheEnv is the environment variable that disables the catalog cache when set to 'true'.
+	DisableCacheEnv = "ROSA_DISABLE_CACHE"
+)
+
+type catalogCache struct {
+	dir string
+	ttl time.Duration
+	now func() time.Time
+
+	// scope identifies the user, organization and configuration profile that the cached data was
+	// fetched for, as the API returns different catalogs to different users and organizations.
+	scope string
+}
+
+type catalogCacheEntry struct {
+	ETag string    `json:"etag,omitempty"`
+	Time time.Time `json:"time"`
+	Body []byte    `json:"body"`
+}
+
+// catalogFetcher requests the data from the server, with the given entity tag of the cached data if
+// there is one. It returns the status, the entity tag and the body of the response.
+type catalogFetcher func(etag string) (status int, newETag string, body []byte, err error)
+
+// newCatalogCache returns the cache stored in the cache directory of the user, or nil if the cache
+// is disabled or the directory can't be determined. The entries are scoped to the user and the
+// organization of the given access token, and to the selected configuration profile.
+func newCatalogCache(accessToken string) *catalogCache {
+	if strings.EqualFold(os.Getenv(DisableCacheEnv), "true") {
+		return nil
+	}
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return nil
+	}
+	return &catalogCache{
+		dir:   filepath.Join(dir, "rosa", "catalog"),
+		ttl:   CatalogCacheTTL,
+		now:   time.Now,
+		scope: catalogCacheScope(accessToken, config.Profile()),
+	}
+}
+
+// catalogCacheScope returns the scope of the cache entries for the given access token and
+// configuration profile.
+func catalogCacheScope(accessToken string, profile string) string {
+	var user, org string
+	token, _, err := new(jwt.Parser).ParseUnverified(accessToken, jwt.MapClaims{})
+	if err == nil {
+		if claims, ok := token.Claims.(jwt.MapClaims); ok {
+			for _, name := range []string{"username", "preferred_username", "sub"} {
+				if user, _ = claims[name].(string); user != "" {
+					break
+				}
+			}
+			org, _ = claims["org_id"].(string)
+			if organization, ok := claims["organization"].(map[string]interface{}); ok && org == "" {
+				org, _ = organization["id"].(string)
+			}
+		}
+	}
+	return fmt.Sprintf("user=%s&org=%s&profile=%s", user, org, profile)
+}
+
+// get returns the cached data for the given key if it is fresh or hasn't changed on the server,
+// otherwise it returns the data fetched from the server and caches it. Failures to read or write
+// the cache aren't errors, the data is fetched from the server instead.
+func (cc *catalogCache) get(key string, fetch catalogFetcher) ([]byte, error) {
+	if cc == nil {
+		_, _, body, err := fetch("")
+		return body, err
+	}
+	path := filepath.Join(cc.dir, cacheFileName(cc.scope+"&"+key))
+	entry := cc.read(path)
+	if entry != nil && cc.now().Sub(entry.Time) < cc.ttl {
+		return entry.Body, nil
+	}
+	etag := ""
+	if entry != nil {
+		etag = entry.ETag
+	}
+	status, etag, body, err := fetch(etag)
+	if err != nil {
+		return nil, err
+	}
+	if status == http.StatusNotModified && entry != nil {
+		entry.Time = cc.now()
+		cc.write(path, entry)
+		return entry.Body, nil
+	}
+	cc.write(path, &catalogCacheEntry{
+		ETag: etag,
+		Time: cc.now(),
+		Body: body,
+	})
+	return body, nil
+}
+
+func (cc *catalogCache) read(path string) *catalogCacheEntry {
+	// #nosec G304
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	entry := new(catalogCacheEntry)
+	err = json.Unmarshal(data, entry)
+	if err != nil {
+		return nil
+	}
+	return entry
+}
+
+func (cc *catalogCache) write(path string, entry *catalogCacheEntry) {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	err = os.MkdirAll(cc.dir, 0700)
+	if err != nil {
+		return
+	}
+	// Write to a temporary file first, so that concurrent commands never read partial entries:
+	tmp, err := os.CreateTemp(cc.dir, filepath.Base(path)+".*")
+	if err != nil {
+		return
+	}
+	_, err = tmp.Write(data)
+	closeErr := tmp.Close()
+	if err != nil || closeErr != nil {
+		os.Remove(tmp.Name())
+		return
+	}
+	err = os.Rename(tmp.Name(), path)
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+}
+
+func cacheFileName(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:]) + ".json"
+}
+
+// catalogPage is a page of the response of a list request.
+type catalogPage struct {
+	Size  int             `json:"size"`
+	Items json.RawMessage `json:"items"`
+}
+
+// getCatalogPage sends a GET request for the given path and parameters, using the catalog cache,
+// and returns the resulting page.
+func (c *Client) getCatalogPage(path string, params map[string]interface{}) (*catalogPage, error) {
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	key := c.ocm.URL() + path
+	for _, name := range names {
+		key += fmt.Sprintf("&%s=%v", name, params[name])
+	}
+
+	body, err := c.cache.get(key, func(etag string) (int, string, []byte, error) {
+		request := c.ocm.Get().Path(path)
+		for _, name := range names {
+			request = request.Parameter(name, params[name])
+		}
+		if etag != "" {
+			request = request.Header("If-None-Match", etag)
+		}
+		response, err := request.Send()
+		if err != nil {
+			return 0, "", nil, err
+		}
+		if response.Status() >= http.StatusBadRequest {
+			return 0, "", nil, responseError(response)
+		}
+		return response.Status(), response.Header("ETag"), response.Bytes(), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	page := new(catalogPage)
+	err = json.Unmarshal(body, page)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse response: %v", err)
+	}
+	return page, nil
+}
+
+// getCatalogList fetches all the pages of the given list endpoint using the catalog cache, and
+// calls the given function with the items of each page.
+func (c *Client) getCatalogList(path string, params map[string]interface{},
+	fn func(items []byte) error) error {
+	size := 100
+	for page := 1; ; page++ {
+		pageParams := map[string]interface{}{
+			"page": page,
+			"size": size,
+		}
+		for name, value := range params {
+			pageParams[name] = value
+		}
+		result, err := c.getCatalogPage(path, pageParams)
+		if err != nil {
+			return err
+		}
+		if len(result.Items) > 0 {
+			err = fn(result.Items)
+			if err != nil {
+				return fmt.Errorf("Failed to parse response: %v", err)
+			}
+		}
+		if result.Size < size {
+			return nil
+		}
+	}
+}
+
+// getCachedVersions returns the versions matching the given search, using the catalog cache.
+func (c *Client) getCachedVersions(search string) (versions []*cmv1.Version, err error) {
+	err = c.getCatalogList(clustersMgmtPath+"/versions", map[string]interface{}{"search": search},
+		func(items []byte) error {
+			page, err := cmv1.UnmarshalVersionList(items)
+			versions = append(versions, page...)
+			return err
+		})
+	return
+}
+
+// getCachedMachineTypes returns the machine types matching the given search, using the catalog
+// cache.
+func (c *Client) getCachedMachineTypes(search string, order string) (machineTypes []*cmv1.MachineType,
+	err error) {
+	err = c.getCatalogList(clustersMgmtPath+"/machine_types",
+		map[string]interface{}{"search": search, "order": order},
+		func(items []byte) error {
+			page, err := cmv1.UnmarshalMachineTypeList(items)
+			machineTypes = append(machineTypes, page...)
+			return err
+		})
+	return
+}
+
+// getCachedCloudRegions returns the regions of the given cloud provider, using the catalog cache.
+func (c *Client) getCachedCloudRegions(cloudProvider string) (regions []*cmv1.CloudRegion, err error) {
+	err = c.getCatalogList(fmt.Sprintf("%s/cloud_providers/%s/regions", clustersMgmtPath, cloudProvider),
+		nil,
+		func(items []byte) error {
+			page, err := cmv1.UnmarshalCloudRegionList(items)
+			regions = append(regions, page...)
+			return err
+		})
+	return
+}
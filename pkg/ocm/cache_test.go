@@ -0,0 +1,119 @@
+package ocm
+
+import (
+	"net/http"
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/openshift-online/ocm-sdk-go/testing"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+var _ = Describe("Catalog cache", func() {
+	var cache *catalogCache
+	var now time.Time
+	var requests []string
+
+	fetcher := func(status int, etag string, body string) catalogFetcher {
+		return func(requestETag string) (int, string, []byte, error) {
+			requests = append(requests, requestETag)
+			return status, etag, []byte(body), nil
+		}
+	}
+
+	BeforeEach(func() {
+		dir, err := os.MkdirTemp("", "rosa-cache-")
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(os.RemoveAll, dir)
+		now = time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+		cache = &catalogCache{
+			dir: dir,
+			ttl: time.Hour,
+			now: func() time.Time { return now },
+		}
+		requests = nil
+	})
+
+	It("uses fresh entries without requesting the server", func() {
+		body, err := cache.get("versions", fetcher(http.StatusOK, `"v1"`, "first"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(body)).To(Equal("first"))
+
+		now = now.Add(30 * time.Minute)
+		body, err = cache.get("versions", fetcher(http.StatusOK, `"v2"`, "second"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(body)).To(Equal("first"))
+		Expect(requests).To(Equal([]string{""}))
+	})
+
+	It("revalidates stale entries with their entity tag", func() {
+		_, err := cache.get("versions", fetcher(http.StatusOK, `"v1"`, "first"))
+		Expect(err).ToNot(HaveOccurred())
+
+		now = now.Add(2 * time.Hour)
+		body, err := cache.get("versions", fetcher(http.StatusNotModified, "", ""))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(body)).To(Equal("first"))
+
+		now = now.Add(2 * time.Hour)
+		body, err = cache.get("versions", fetcher(http.StatusOK, `"v2"`, "second"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(body)).To(Equal("second"))
+		Expect(requests).To(Equal([]string{"", `"v1"`, `"v1"`}))
+	})
+
+	It("keeps entries of different keys apart", func() {
+		_, err := cache.get("versions", fetcher(http.StatusOK, "", "versions"))
+		Expect(err).ToNot(HaveOccurred())
+		body, err := cache.get("regions", fetcher(http.StatusOK, "", "regions"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(body)).To(Equal("regions"))
+	})
+
+	It("always requests the server when disabled", func() {
+		var disabled *catalogCache
+		for i := 0; i < 2; i++ {
+			body, err := disabled.get("versions", fetcher(http.StatusOK, `"v1"`, "first"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(body)).To(Equal("first"))
+		}
+		Expect(requests).To(HaveLen(2))
+	})
+
+	It("keeps entries of different scopes apart", func() {
+		_, err := cache.get("versions", fetcher(http.StatusOK, "", "first user"))
+		Expect(err).ToNot(HaveOccurred())
+
+		other := *cache
+		other.scope = "user=other"
+		body, err := other.get("versions", fetcher(http.StatusOK, "", "second user"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(body)).To(Equal("second user"))
+
+		body, err = cache.get("versions", fetcher(http.StatusOK, "", "not requested"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(body)).To(Equal("first user"))
+	})
+
+	DescribeTable("catalogCacheScope",
+		func(claims jwt.MapClaims, profile string, expected string) {
+			Expect(catalogCacheScope(MakeTokenObject(claims).Raw, profile)).To(Equal(expected))
+		},
+		Entry("user and organization",
+			jwt.MapClaims{"username": "alice", "org_id": "123"}, "",
+			"user=alice&org=123&profile="),
+		Entry("nested organization and profile",
+			jwt.MapClaims{"username": "alice", "organization": map[string]interface{}{"id": "456"}}, "staging",
+			"user=alice&org=456&profile=staging"),
+		Entry("service account",
+			jwt.MapClaims{"preferred_username": "service-account-1"}, "",
+			"user=service-account-1&org=&profile="),
+	)
+
+	It("doesn't fail on tokens that can't be parsed", func() {
+		Expect(catalogCacheScope("", "staging")).To(Equal("user=&org=&profile=staging"))
+	})
+})
@@ -17,7 +17,9 @@ limitations under the License.
 package ocm
 
 import (
+	"crypto/tls"
 	"fmt"
+	"net/http"
 	"os"
 	"strings"
 	"time"
@@ -30,10 +32,12 @@ import (
 	"github.com/openshift/rosa/pkg/info"
 	"github.com/openshift/rosa/pkg/logging"
 	"github.com/openshift/rosa/pkg/reporter"
+	"github.com/openshift/rosa/pkg/retry"
 )
 
 type Client struct {
-	ocm *sdk.Connection
+	ocm   *sdk.Connection
+	cache *catalogCache
 }
 
 // ClientBuilder contains the information and logic needed to build a connection to OCM. Don't
@@ -135,13 +139,20 @@ func (b *ClientBuilder) Build() (result *Client, err error) {
 		builder.Tokens(tokens...)
 	}
 	builder.Insecure(b.cfg.Insecure)
+	if retries := retry.MaxRetries(-1); retries >= 0 {
+		builder.RetryLimit(retries)
+	}
+	if retry.RequestTimeout() > 0 {
+		builder.TransportWrapper(retry.Transport)
+	}
+	builder.TransportWrapper(logging.TraceTransport)
 
 	// Create the connection:
 	conn, err := builder.Build()
 	if err != nil {
 		return
 	}
-	_, _, err = conn.Tokens(10 * time.Minute)
+	accessToken, _, err := conn.Tokens(10 * time.Minute)
 	if err != nil {
 		if strings.Contains(err.Error(), "invalid_grant") {
 			return nil, fmt.Errorf("your authorization token needs to be updated. " +
@@ -150,10 +161,25 @@ func (b *ClientBuilder) Build() (result *Client, err error) {
 		return nil, fmt.Errorf("error creating connection. Not able to get authentication token: %s", err)
 	}
 	return &Client{
-		ocm: conn,
+		ocm:   conn,
+		cache: newCatalogCache(accessToken),
 	}, nil
 }
 
+// NewHTTPClient returns a client for the requests that are sent to the SSO server outside of the
+// OCM connection, like the device code login. It uses the same transport settings as the OCM
+// connection: the insecure mode, the request timeout and the HTTP trace.
+func NewHTTPClient(insecure bool) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if insecure {
+		// #nosec G402 -- explicitly requested by the user with the '--insecure' option
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	}
+	return &http.Client{
+		Transport: logging.TraceTransport(retry.Transport(transport)),
+	}
+}
+
 func (c *Client) Close() error {
 	return c.ocm.Close()
 }
@@ -162,6 +188,8 @@ func (c *Client) GetConnectionURL() string {
 	return c.ocm.URL()
 }
 
-func (c *Client) GetConnectionTokens() (string, string, error) {
-	return c.ocm.Tokens()
+// GetConnectionTokens returns the tokens of the connection, requesting new ones when the access
+// token expires in less than the optional given duration.
+func (c *Client) GetConnectionTokens(expiresIn ...time.Duration) (string, string, error) {
+	return c.ocm.Tokens(expiresIn...)
 }
@@ -0,0 +1,35 @@
+package ocm
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NewHTTPClient", func() {
+	var server *httptest.Server
+
+	BeforeEach(func() {
+		server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("Verifies the certificate of the server by default", func() {
+		_, err := NewHTTPClient(false).Get(server.URL)
+		Expect(err).To(MatchError(ContainSubstring("certificate")))
+	})
+
+	It("Skips the verification of the certificate in insecure mode", func() {
+		response, err := NewHTTPClient(true).Get(server.URL)
+		Expect(err).ToNot(HaveOccurred())
+		response.Body.Close()
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+	})
+})
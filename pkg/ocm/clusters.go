@@ -21,6 +21,7 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"strings"
 	"time"
 
 	amv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
@@ -32,6 +33,7 @@ import (
 	"github.com/openshift/rosa/pkg/logging"
 	"github.com/openshift/rosa/pkg/properties"
 	rprtr "github.com/openshift/rosa/pkg/reporter"
+	"github.com/openshift/rosa/pkg/wait"
 	errors "github.com/zgalor/weberr"
 )
 
@@ -62,6 +64,11 @@ type Spec struct {
 	MaxReplicas        int
 	ComputeLabels      map[string]string
 
+	// Additional security groups of the compute, infra and control plane nodes
+	AdditionalComputeSecurityGroupIds      []string
+	AdditionalInfraSecurityGroupIds        []string
+	AdditionalControlPlaneSecurityGroupIds []string
+
 	// SubnetIDs
 	SubnetIds []string
 
@@ -77,6 +84,11 @@ type Spec struct {
 	Private     *bool
 	PrivateLink *bool
 
+	// Shared VPC config
+	SharedVPCRoleARN    string
+	BaseDomain          string
+	PrivateHostedZoneID string
+
 	// Properties
 	CustomProperties map[string]string
 
@@ -109,6 +121,21 @@ type Spec struct {
 	AdditionalTrustBundleFile *string
 	AdditionalTrustBundle     *string
 
+	// Use of IMDSv2 by the nodes, one of Ec2MetadataHttpTokensValues
+	Ec2MetadataHttpTokens string
+
+	// Image registry configuration of hosted clusters
+	RegistryConfig *RegistryConfig
+
+	// AWS account billed for the usage of hosted clusters
+	BillingAccount string
+
+	// Authentication of the users of hosted clusters with external OIDC providers
+	ExternalAuthEnabled bool
+
+	// Role used to forward the audit logs of hosted clusters to CloudWatch
+	AuditLogRoleARN string
+
 	// HyperShift options:
 	Hypershift Hypershift
 }
@@ -126,12 +153,7 @@ type Hypershift struct {
 
 // Generate a query that filters clusters running on the current AWS session account
 func getClusterFilter(creator *aws.Creator) string {
-	return fmt.Sprintf(
-		"product.id = 'rosa' AND (properties.%s LIKE '%%:%s:%%' OR aws.sts.role_arn LIKE '%%:%s:%%')",
-		properties.CreatorARN,
-		creator.AccountID,
-		creator.AccountID,
-	)
+	return getClusterListFilter(creator, ClusterListOptions{})
 }
 
 func (c *Client) HasClusters(creator *aws.Creator) (bool, error) {
@@ -164,16 +186,22 @@ func (c *Client) CreateCluster(config Spec) (*cmv1.Cluster, error) {
 		return nil, fmt.Errorf("Unable to create cluster spec: %v", err)
 	}
 
+	if needsRawCreate(config) {
+		return c.createClusterRaw(spec, config, config.DryRun != nil && *config.DryRun)
+	}
+
 	cluster, err := c.ocm.ClustersMgmt().V1().Clusters().
 		Add().
-		Parameter("dryRun", *config.DryRun).
+		Parameter("dryRun", config.DryRun != nil && *config.DryRun).
 		Body(spec).
 		Send()
+	// A successful dry run doesn't create anything, so return the request that would have been
+	// sent instead:
 	if config.DryRun != nil && *config.DryRun {
-		if cluster.Error() != nil {
+		if err != nil {
 			return nil, handleErr(cluster.Error(), err)
 		}
-		return nil, nil
+		return spec, nil
 	}
 	if err != nil {
 		return nil, handleErr(cluster.Error(), err)
@@ -215,6 +243,149 @@ func (c *Client) GetClusters(creator *aws.Creator, count int) (clusters []*cmv1.
 	return clusters, nil
 }
 
+// ClusterListOptions contains the server side filters and pagination used to list clusters.
+type ClusterListOptions struct {
+	State   string
+	Region  string
+	Product string
+	Search  string
+	Page    int
+	Size    int
+}
+
+// ListClusters returns the clusters matching the given options, along with the total number
+// of matching clusters. When no page size is given all the matching clusters are returned.
+func (c *Client) ListClusters(creator *aws.Creator,
+	options ClusterListOptions) (clusters []*cmv1.Cluster, total int, err error) {
+	query := getClusterListFilter(creator, options)
+	request := c.ocm.ClustersMgmt().V1().Clusters().List().Search(query)
+	if options.Size > 0 {
+		page := options.Page
+		if page < 1 {
+			page = 1
+		}
+		response, err := request.Page(page).Size(options.Size).Send()
+		if err != nil {
+			return nil, 0, handleErr(response.Error(), err)
+		}
+		return response.Items().Slice(), response.Total(), nil
+	}
+	size := 100
+	page := 1
+	for {
+		response, err := request.Page(page).Size(size).Send()
+		if err != nil {
+			return nil, 0, handleErr(response.Error(), err)
+		}
+		clusters = append(clusters, response.Items().Slice()...)
+		total = response.Total()
+		if response.Size() < size {
+			break
+		}
+		page++
+	}
+	return clusters, total, nil
+}
+
+func getClusterListFilter(creator *aws.Creator, options ClusterListOptions) string {
+	product := options.Product
+	if product == "" {
+		product = "rosa"
+	}
+	query := fmt.Sprintf(
+		"product.id = '%s' AND (properties.%s LIKE '%%:%s:%%' OR aws.sts.role_arn LIKE '%%:%s:%%')",
+		escapeSearchValue(product),
+		properties.CreatorARN,
+		creator.AccountID,
+		creator.AccountID,
+	)
+	if options.State != "" {
+		query = fmt.Sprintf("%s AND state = '%s'", query, escapeSearchValue(options.State))
+	}
+	if options.Region != "" {
+		query = fmt.Sprintf("%s AND region.id = '%s'", query, escapeSearchValue(options.Region))
+	}
+	if options.Search != "" {
+		query = fmt.Sprintf("%s AND (%s)", query, options.Search)
+	}
+	return query
+}
+
+// escapeSearchValue escapes single quotes so that the value can be used as a string literal
+// in a search query.
+func escapeSearchValue(value string) string {
+	return strings.ReplaceAll(value, "'", "''")
+}
+
+// GetDeletedClusterIDs returns the subset of the given cluster identifiers that belong to clusters
+// whose subscription is deprovisioned or archived. Clusters that aren't known to the current
+// environment and organization aren't considered deleted.
+func (c *Client) GetDeletedClusterIDs(clusterIDs []string) (map[string]bool, error) {
+	const batchSize = 100
+	deleted := map[string]bool{}
+	for start := 0; start < len(clusterIDs); start += batchSize {
+		end := start + batchSize
+		if end > len(clusterIDs) {
+			end = len(clusterIDs)
+		}
+		quoted := []string{}
+		for _, clusterID := range clusterIDs[start:end] {
+			quoted = append(quoted, fmt.Sprintf("'%s'", escapeSearchValue(clusterID)))
+		}
+		query := fmt.Sprintf("cluster_id in (%s) AND status in ('Deprovisioned', 'Archived')",
+			strings.Join(quoted, ", "))
+		for page := 1; ; page++ {
+			response, err := c.ocm.AccountsMgmt().V1().Subscriptions().List().
+				Search(query).
+				Page(page).
+				Size(batchSize).
+				Send()
+			if err != nil {
+				return nil, handleErr(response.Error(), err)
+			}
+			response.Items().Each(func(subscription *amv1.Subscription) bool {
+				deleted[subscription.ClusterID()] = true
+				return true
+			})
+			if response.Size() < batchSize {
+				break
+			}
+		}
+	}
+	if len(deleted) == 0 {
+		return deleted, nil
+	}
+
+	// A cluster that is still known to clusters management hasn't been deleted, whatever the
+	// state of its subscriptions:
+	ids := make([]string, 0, len(deleted))
+	for clusterID := range deleted {
+		ids = append(ids, clusterID)
+	}
+	for start := 0; start < len(ids); start += batchSize {
+		end := start + batchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+		quoted := []string{}
+		for _, clusterID := range ids[start:end] {
+			quoted = append(quoted, fmt.Sprintf("'%s'", escapeSearchValue(clusterID)))
+		}
+		response, err := c.ocm.ClustersMgmt().V1().Clusters().List().
+			Search(fmt.Sprintf("id in (%s)", strings.Join(quoted, ", "))).
+			Size(batchSize).
+			Send()
+		if err != nil {
+			return nil, handleErr(response.Error(), err)
+		}
+		response.Items().Each(func(cluster *cmv1.Cluster) bool {
+			delete(deleted, cluster.ID())
+			return true
+		})
+	}
+	return deleted, nil
+}
+
 func (c *Client) GetAllClusters(creator *aws.Creator) (clusters []*cmv1.Cluster, err error) {
 	query := getClusterFilter(creator)
 	request := c.ocm.ClustersMgmt().V1().Clusters().List().Search(query)
@@ -265,6 +436,20 @@ func (c *Client) getCluster(clusterKey string, creator *aws.Creator) (*cmv1.Clus
 	}
 }
 
+// IsClusterNameTaken checks if a cluster of the organization of the user already has the given
+// name, as names have to be unique.
+func (c *Client) IsClusterNameTaken(name string) (bool, error) {
+	response, err := c.ocm.ClustersMgmt().V1().Clusters().List().
+		Search(fmt.Sprintf("name = '%s'", name)).
+		Page(1).
+		Size(1).
+		Send()
+	if err != nil {
+		return false, handleErr(response.Error(), err)
+	}
+	return response.Total() > 0, nil
+}
+
 func (c *Client) getSubscriptionByExternalID(externalID string) (*amv1.Subscription, bool, error) {
 	query := fmt.Sprintf("external_cluster_id = '%s'", externalID)
 	response, err := c.ocm.AccountsMgmt().V1().Subscriptions().List().
@@ -411,6 +596,41 @@ func (c *Client) HasAClusterUsingOidcEndpointUrl(issuerUrl string) (bool, error)
 	return false, nil
 }
 
+// GetClustersUsingOidcEndpointUrls returns the clusters that use each of the given OIDC endpoint
+// URLs. URLs that aren't used by any cluster aren't included in the result.
+func (c *Client) GetClustersUsingOidcEndpointUrls(issuerUrls []string) (map[string][]*cmv1.Cluster, error) {
+	const batchSize = 100
+	result := map[string][]*cmv1.Cluster{}
+	for start := 0; start < len(issuerUrls); start += batchSize {
+		end := start + batchSize
+		if end > len(issuerUrls) {
+			end = len(issuerUrls)
+		}
+		quoted := []string{}
+		for _, issuerUrl := range issuerUrls[start:end] {
+			quoted = append(quoted, fmt.Sprintf("'%s'", escapeSearchValue(issuerUrl)))
+		}
+		request := c.ocm.ClustersMgmt().V1().Clusters().List().
+			Search(fmt.Sprintf("aws.sts.oidc_endpoint_url in (%s)", strings.Join(quoted, ", "))).
+			Size(batchSize)
+		for page := 1; ; page++ {
+			response, err := request.Page(page).Send()
+			if err != nil {
+				return nil, handleErr(response.Error(), err)
+			}
+			response.Items().Each(func(cluster *cmv1.Cluster) bool {
+				issuerUrl := cluster.AWS().STS().OIDCEndpointURL()
+				result[issuerUrl] = append(result[issuerUrl], cluster)
+				return true
+			})
+			if response.Size() < batchSize {
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
 func (c *Client) IsSTSClusterExists(creator *aws.Creator, count int, roleARN string) (exists bool, err error) {
 	if count < 1 {
 		err = errors.Errorf("Cannot fetch fewer than 1 cluster")
@@ -545,6 +765,10 @@ func (c *Client) UpdateCluster(clusterKey string, creator *aws.Creator, config S
 		clusterBuilder = clusterBuilder.AdditionalTrustBundle(*config.AdditionalTrustBundle)
 	}
 
+	if config.Tags != nil {
+		clusterBuilder = clusterBuilder.AWS(cmv1.NewAWS().Tags(config.Tags))
+	}
+
 	if config.Hypershift.Enabled {
 		hyperShiftBuilder := cmv1.NewHypershift().Enabled(true)
 		clusterBuilder.Hypershift(hyperShiftBuilder)
@@ -567,6 +791,24 @@ func (c *Client) UpdateCluster(clusterKey string, creator *aws.Creator, config S
 	return nil
 }
 
+// UpdateDeleteProtection enables or disables the protection of the cluster against deletion.
+func (c *Client) UpdateDeleteProtection(clusterID string, enabled bool) error {
+	deleteProtection, err := cmv1.NewDeleteProtection().Enabled(enabled).Build()
+	if err != nil {
+		return err
+	}
+	response, err := c.ocm.ClustersMgmt().V1().Clusters().
+		Cluster(clusterID).
+		DeleteProtection().
+		Update().
+		Body(deleteProtection).
+		Send()
+	if err != nil {
+		return handleErr(response.Error(), err)
+	}
+	return nil
+}
+
 func (c *Client) DeleteCluster(clusterKey string, creator *aws.Creator) (*cmv1.Cluster, error) {
 	cluster, err := c.GetCluster(clusterKey, creator)
 	if err != nil {
@@ -655,6 +897,14 @@ func (c *Client) createClusterSpec(config Spec, awsClient aws.Client) (*cmv1.Clu
 		return nil, fmt.Errorf("Custom properties key %s collides with a property needed by rosa", properties.CLIVersion)
 	}
 
+	if config.SharedVPCRoleARN != "" {
+		if _, present := clusterProperties[properties.SharedVPCRoleARN]; present {
+			return nil, fmt.Errorf("Custom properties key %s collides with a property needed by rosa",
+				properties.SharedVPCRoleARN)
+		}
+		clusterProperties[properties.SharedVPCRoleARN] = config.SharedVPCRoleARN
+	}
+
 	clusterProperties[properties.CreatorARN] = awsCreator.ARN
 	clusterProperties[properties.CLIVersion] = info.Version
 
@@ -702,13 +952,18 @@ func (c *Client) createClusterSpec(config Spec, awsClient aws.Client) (*cmv1.Clu
 		clusterBuilder = clusterBuilder.ExpirationTimestamp(config.Expiration)
 	}
 
+	if config.BaseDomain != "" {
+		clusterBuilder = clusterBuilder.DNS(cmv1.NewDNS().BaseDomain(config.BaseDomain))
+		reporter.Debugf("Using base domain '%s'", config.BaseDomain)
+	}
+
 	if config.Hypershift.Enabled {
 		hyperShiftBuilder := cmv1.NewHypershift().Enabled(true)
 		clusterBuilder.Hypershift(hyperShiftBuilder)
 	}
 
 	if config.ComputeMachineType != "" || config.ComputeNodes != 0 || len(config.AvailabilityZones) > 0 ||
-		config.Autoscaling || len(config.ComputeLabels) > 0 {
+		config.Autoscaling || len(config.ComputeLabels) > 0 || len(config.AdditionalComputeSecurityGroupIds) > 0 {
 		clusterNodesBuilder := cmv1.NewClusterNodes()
 		if config.ComputeMachineType != "" {
 			clusterNodesBuilder = clusterNodesBuilder.ComputeMachineType(
@@ -731,6 +986,10 @@ func (c *Client) createClusterSpec(config Spec, awsClient aws.Client) (*cmv1.Clu
 		if len(config.ComputeLabels) > 0 {
 			clusterNodesBuilder = clusterNodesBuilder.ComputeLabels(config.ComputeLabels)
 		}
+		if len(config.AdditionalComputeSecurityGroupIds) > 0 {
+			clusterNodesBuilder = clusterNodesBuilder.SecurityGroupFilters(
+				SecurityGroupFilters(config.AdditionalComputeSecurityGroupIds)...)
+		}
 		clusterBuilder = clusterBuilder.Nodes(clusterNodesBuilder)
 	}
 
@@ -877,14 +1136,7 @@ func (c *Client) createClusterSpec(config Spec, awsClient aws.Client) (*cmv1.Clu
 }
 
 func (c *Client) HibernateCluster(clusterID string) error {
-	enabled, err := c.IsCapabilityEnabled(HibernateCapability)
-	if err != nil {
-		return err
-	}
-	if !enabled {
-		return fmt.Errorf("The '%s' capability is not set for current org", HibernateCapability)
-	}
-	_, err = c.ocm.ClustersMgmt().V1().Clusters().Cluster(clusterID).Hibernate().Send()
+	_, err := c.ocm.ClustersMgmt().V1().Clusters().Cluster(clusterID).Hibernate().Send()
 	if err != nil {
 		return fmt.Errorf("Failed to hibernate the cluster: %v", err)
 	}
@@ -893,19 +1145,77 @@ func (c *Client) HibernateCluster(clusterID string) error {
 }
 
 func (c *Client) ResumeCluster(clusterID string) error {
+	_, err := c.ocm.ClustersMgmt().V1().Clusters().Cluster(clusterID).Resume().Send()
+	if err != nil {
+		return fmt.Errorf("Failed to resume the cluster: %v", err)
+	}
+
+	return nil
+}
+
+// ValidateHibernation checks that the cluster is eligible for hibernation, which stops the nodes of
+// classic ROSA clusters while keeping their data, and that it is in the state required to hibernate
+// or resume it.
+func ValidateHibernation(cluster *cmv1.Cluster, state cmv1.ClusterState) error {
+	if cluster.Hypershift().Enabled() {
+		return fmt.Errorf("Hibernation is not supported for Hosted Control Plane clusters")
+	}
+	product := cluster.Product().ID()
+	if product != "rosa" && product != "moa" {
+		return fmt.Errorf("Hibernation is only supported for ROSA clusters, cluster '%s' is a '%s' cluster",
+			cluster.Name(), product)
+	}
+	if cluster.State() != state {
+		action := "Hibernating"
+		if state == cmv1.ClusterStateHibernating {
+			action = "Resuming"
+		}
+		return fmt.Errorf("%s a cluster is only supported for '%s' clusters. Cluster '%s' is in '%s' state",
+			action, state, cluster.Name(), cluster.State())
+	}
+	return nil
+}
+
+// ValidateHibernationEntitlement checks that the organization of the current user is entitled to
+// hibernate and resume clusters.
+func (c *Client) ValidateHibernationEntitlement() error {
 	enabled, err := c.IsCapabilityEnabled(HibernateCapability)
 	if err != nil {
-		return err
+		return fmt.Errorf("Failed to check if the organization can hibernate clusters: %v", err)
 	}
 	if !enabled {
-		return fmt.Errorf("The '%s' capability is not set for current org", HibernateCapability)
+		return fmt.Errorf("The organization isn't entitled to hibernate clusters, the '%s' capability "+
+			"is not set for current org", HibernateCapability)
 	}
-	_, err = c.ocm.ClustersMgmt().V1().Clusters().Cluster(clusterID).Resume().Send()
-	if err != nil {
-		return fmt.Errorf("Failed to resume the cluster: %v", err)
+	return nil
+}
+
+// WaitForClusterState polls the state of the cluster until it reaches the given state. It fails when
+// the cluster reaches the error state or when the timeout expires, in which case the error is a
+// wait.TimeoutError.
+func (c *Client) WaitForClusterState(clusterID string, state cmv1.ClusterState,
+	interval time.Duration, timeout time.Duration) error {
+	var current cmv1.ClusterState
+	err := wait.PollWithTimeout(interval, timeout, func() (bool, error) {
+		var err error
+		current, err = c.GetClusterState(clusterID)
+		if err != nil {
+			return false, wait.Transient(err)
+		}
+		if current == cmv1.ClusterStateError && state != cmv1.ClusterStateError {
+			return false, fmt.Errorf("Cluster is in '%s' state", current)
+		}
+		return current == state, nil
+	})
+	if wait.IsTimeout(err) {
+		return fmt.Errorf("%w waiting for cluster to be '%s', it is '%s'", err, state, current)
 	}
+	return err
+}
 
-	return nil
+// FindClusterByID returns the cluster with the given identifier, and false if it doesn't exist.
+func (c *Client) FindClusterByID(clusterID string) (*cmv1.Cluster, bool, error) {
+	return c.getClusterByID(clusterID)
 }
 
 func IsConsoleAvailable(cluster *cmv1.Cluster) bool {
@@ -0,0 +1,31 @@
+package ocm
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/rosa/pkg/aws"
+)
+
+var _ = Describe("Cluster list filter", func() {
+	creator := &aws.Creator{AccountID: "123456789012"}
+	base := "product.id = 'rosa' AND (properties.rosa_creator_arn LIKE '%:123456789012:%' " +
+		"OR aws.sts.role_arn LIKE '%:123456789012:%')"
+
+	It("only filters by product and account by default", func() {
+		Expect(getClusterListFilter(creator, ClusterListOptions{})).To(Equal(base))
+	})
+	It("adds the state, region and search filters", func() {
+		query := getClusterListFilter(creator, ClusterListOptions{
+			State:  "ready",
+			Region: "us-east-1",
+			Search: "name like 'prod-%'",
+		})
+		Expect(query).To(Equal(base + " AND state = 'ready' AND region.id = 'us-east-1'" +
+			" AND (name like 'prod-%')"))
+	})
+	It("escapes quotes in filter values", func() {
+		query := getClusterListFilter(creator, ClusterListOptions{Product: "os'd"})
+		Expect(query).To(HavePrefix("product.id = 'os''d' AND"))
+	})
+})
@@ -0,0 +1,117 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ocm
+
+import (
+	"fmt"
+	"time"
+)
+
+// DNSDomainClusterArchHCP is the architecture of the clusters that can use a DNS domain reserved
+// for hosted control planes.
+const DNSDomainClusterArchHCP = "hcp"
+
+// DNSDomain is a base DNS domain reserved by the organization, that can be given to a cluster
+// instead of a random one. The SDK has no dns_domains resource, see raw.go.
+type DNSDomain struct {
+	ID           string         `json:"id"`
+	ClusterArch  string         `json:"cluster_arch,omitempty"`
+	UserDefined  bool           `json:"user_defined,omitempty"`
+	ReservedAt   time.Time      `json:"reserved_at,omitempty"`
+	Cluster      *DNSDomainLink `json:"cluster,omitempty"`
+	Organization *DNSDomainLink `json:"organization,omitempty"`
+}
+
+type DNSDomainLink struct {
+	ID   string `json:"id"`
+	HREF string `json:"href,omitempty"`
+}
+
+// ClusterID returns the identifier of the cluster that uses the domain, if any.
+func (d *DNSDomain) ClusterID() string {
+	if d.Cluster == nil {
+		return ""
+	}
+	return d.Cluster.ID
+}
+
+func dnsDomainsPath() string {
+	return clustersMgmtPath + "/dns_domains"
+}
+
+func dnsDomainPath(id string) string {
+	return fmt.Sprintf("%s/%s", dnsDomainsPath(), id)
+}
+
+// CreateDNSDomain reserves a new base DNS domain for hosted control plane clusters of the
+// organization.
+func (c *Client) CreateDNSDomain() (*DNSDomain, error) {
+	domain := new(DNSDomain)
+	err := sendRaw(c.ocm.Post().Path(dnsDomainsPath()), &DNSDomain{ClusterArch: DNSDomainClusterArchHCP}, domain)
+	if err != nil {
+		return nil, err
+	}
+	return domain, nil
+}
+
+// ListDNSDomains returns the DNS domains reserved by the organization.
+func (c *Client) ListDNSDomains() ([]*DNSDomain, error) {
+	var list struct {
+		Items []*DNSDomain `json:"items"`
+	}
+	err := sendRaw(c.ocm.Get().Path(dnsDomainsPath()).
+		Parameter("search", "user_defined = 'true'").
+		Parameter("size", -1), nil, &list)
+	if err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+// GetDNSDomain returns the DNS domain, or nil if it doesn't exist.
+func (c *Client) GetDNSDomain(id string) (*DNSDomain, error) {
+	domain := new(DNSDomain)
+	err := sendRaw(c.ocm.Get().Path(dnsDomainPath(id)), nil, domain)
+	if isNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return domain, nil
+}
+
+// DeleteDNSDomain releases a DNS domain reserved by the organization.
+func (c *Client) DeleteDNSDomain(id string) error {
+	return sendRaw(c.ocm.Delete().Path(dnsDomainPath(id)), nil, nil)
+}
+
+// ValidateHostedCPBaseDomain checks that the base domain of a hosted control plane cluster has been
+// reserved by the organization and isn't used by another cluster.
+func ValidateHostedCPBaseDomain(domain *DNSDomain, baseDomain string) error {
+	if domain == nil || !domain.UserDefined {
+		return fmt.Errorf("Base domain '%s' isn't reserved by the organization. "+
+			"Run 'rosa create dns-domain' to reserve one", baseDomain)
+	}
+	if domain.ClusterArch != "" && domain.ClusterArch != DNSDomainClusterArchHCP {
+		return fmt.Errorf("Base domain '%s' is reserved for classic clusters", baseDomain)
+	}
+	if domain.ClusterID() != "" {
+		return fmt.Errorf("Base domain '%s' is already used by cluster '%s'", baseDomain, domain.ClusterID())
+	}
+	return nil
+}
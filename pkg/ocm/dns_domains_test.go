@@ -0,0 +1,42 @@
+package ocm
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DNS domains", func() {
+	parse := func(data string) *DNSDomain {
+		domain := new(DNSDomain)
+		Expect(json.Unmarshal([]byte(data), domain)).To(Succeed())
+		return domain
+	}
+
+	It("Reads the cluster that uses the domain", func() {
+		domain := parse(`{"id":"a1b2.p3.openshiftapps.com","user_defined":true,"cluster_arch":"hcp",` +
+			`"cluster":{"id":"123","href":"/api/clusters_mgmt/v1/clusters/123"}}`)
+		Expect(domain.ClusterID()).To(Equal("123"))
+		Expect(ValidateHostedCPBaseDomain(domain, domain.ID)).To(
+			MatchError("Base domain 'a1b2.p3.openshiftapps.com' is already used by cluster '123'"))
+	})
+
+	It("Accepts an unused domain reserved for hosted control planes", func() {
+		domain := parse(`{"id":"a1b2.p3.openshiftapps.com","user_defined":true,"cluster_arch":"hcp"}`)
+		Expect(domain.ClusterID()).To(BeEmpty())
+		Expect(ValidateHostedCPBaseDomain(domain, domain.ID)).To(Succeed())
+	})
+
+	It("Rejects a domain reserved for classic clusters", func() {
+		domain := parse(`{"id":"a1b2.p3.openshiftapps.com","user_defined":true,"cluster_arch":"classic"}`)
+		Expect(ValidateHostedCPBaseDomain(domain, domain.ID)).To(MatchError(ContainSubstring("classic clusters")))
+	})
+
+	It("Rejects a domain that isn't reserved", func() {
+		Expect(ValidateHostedCPBaseDomain(nil, "example.com")).To(
+			MatchError(ContainSubstring("Run 'rosa create dns-domain' to reserve one")))
+		domain := parse(`{"id":"a1b2.p3.openshiftapps.com"}`)
+		Expect(ValidateHostedCPBaseDomain(domain, domain.ID)).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,87 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the helpers used to set the time when a cluster is automatically deleted.
+
+package ocm
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/openshift/rosa/pkg/fedramp"
+)
+
+const (
+	// ExpirationFlag is the name of the option that sets the expiration relative to the current time.
+	ExpirationFlag = "expiration"
+
+	// ExpirationTimeFlag is the name of the option that sets the expiration to a fixed time.
+	ExpirationTimeFlag = "expiration-time"
+)
+
+// ExpirationHelp describes the expiration options, for use in their help.
+const ExpirationHelp = "The cluster is deleted automatically when it expires. Expiration isn't " +
+	"supported in the production environments."
+
+// ValidateExpirationSupported checks that the OCM environment of the connection supports cluster
+// expiration, which is only the case of the non production ones.
+func (c *Client) ValidateExpirationSupported() error {
+	url := strings.TrimSuffix(c.GetConnectionURL(), "/")
+	if url == URLAliases[Production] || url == fedramp.URLAliases[Production] {
+		return fmt.Errorf("Cluster expiration isn't supported in the production environment, the "+
+			"'%s' and '%s' options can only be used in the staging and integration environments",
+			ExpirationFlag, ExpirationTimeFlag)
+	}
+	return nil
+}
+
+// ParseExpiration returns the time when the cluster expires, given either as an RFC 3339 time or as
+// a duration relative to the given current time. It returns the zero time if neither is given.
+func ParseExpiration(expirationTime string, duration time.Duration, now time.Time) (time.Time, error) {
+	if expirationTime != "" && duration != 0 {
+		return time.Time{}, fmt.Errorf("At most one of '%s' or '%s' may be specified",
+			ExpirationTimeFlag, ExpirationFlag)
+	}
+	var expiration time.Time
+	if expirationTime != "" {
+		var err error
+		expiration, err = parseRFC3339(expirationTime)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("Failed to parse %s: %s", ExpirationTimeFlag, err)
+		}
+	}
+	if duration != 0 {
+		if duration < 0 {
+			return time.Time{}, fmt.Errorf("Expiration must be a positive duration, for example '72h'")
+		}
+		// round up to the nearest second
+		expiration = now.Add(duration).Round(time.Second)
+	}
+	if !expiration.IsZero() && !expiration.After(now) {
+		return time.Time{}, fmt.Errorf("Expiration time '%s' is in the past", expiration.Format(time.RFC3339))
+	}
+	return expiration, nil
+}
+
+// parseRFC3339 parses an RFC3339 date in either RFC3339Nano or RFC3339 format.
+func parseRFC3339(s string) (time.Time, error) {
+	if t, timeErr := time.Parse(time.RFC3339Nano, s); timeErr == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
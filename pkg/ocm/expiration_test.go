@@ -0,0 +1,69 @@
+package ocm
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	"github.com/openshift-online/ocm-sdk-go/logging"
+	. "github.com/openshift-online/ocm-sdk-go/testing"
+)
+
+var _ = Describe("Expiration", func() {
+	now := time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	It("Is zero when not set", func() {
+		expiration, err := ParseExpiration("", 0, now)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(expiration.IsZero()).To(BeTrue())
+	})
+	It("Is relative to the current time", func() {
+		expiration, err := ParseExpiration("", 72*time.Hour, now)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(expiration).To(Equal(time.Date(2023, 5, 4, 10, 0, 0, 0, time.UTC)))
+	})
+	It("Parses fixed times", func() {
+		expiration, err := ParseExpiration("2023-05-02T08:30:00Z", 0, now)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(expiration).To(Equal(time.Date(2023, 5, 2, 8, 30, 0, 0, time.UTC)))
+	})
+	It("Rejects both options", func() {
+		_, err := ParseExpiration("2023-05-02T08:30:00Z", time.Hour, now)
+		Expect(err).To(MatchError(ContainSubstring("At most one")))
+	})
+	It("Rejects times in the past", func() {
+		_, err := ParseExpiration("2023-04-30T08:30:00Z", 0, now)
+		Expect(err).To(MatchError(ContainSubstring("in the past")))
+		_, err = ParseExpiration("", -time.Hour, now)
+		Expect(err).To(MatchError(ContainSubstring("positive duration")))
+	})
+	It("Rejects malformed times", func() {
+		_, err := ParseExpiration("tomorrow", 0, now)
+		Expect(err).To(MatchError(ContainSubstring("Failed to parse expiration-time")))
+	})
+})
+
+var _ = DescribeTable("Expiration support",
+	func(url string, supported bool) {
+		logger, err := logging.NewGoLoggerBuilder().Build()
+		Expect(err).ToNot(HaveOccurred())
+		connection, err := sdk.NewConnectionBuilder().
+			Logger(logger).
+			Tokens(MakeTokenString("Bearer", 15*time.Minute)).
+			URL(url).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		defer connection.Close()
+		err = (&Client{ocm: connection}).ValidateExpirationSupported()
+		if supported {
+			Expect(err).ToNot(HaveOccurred())
+		} else {
+			Expect(err).To(MatchError(ContainSubstring("isn't supported in the production environment")))
+		}
+	},
+	Entry("production", "https://api.openshift.com", false),
+	Entry("FedRAMP production", "https://api.openshiftusgov.com", false),
+	Entry("staging", "https://api.stage.openshift.com", true),
+	Entry("integration", "https://api.integration.openshift.com", true),
+)
@@ -0,0 +1,191 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the helpers for the external authentication of Hosted Control Plane
+// clusters, where users are authenticated by an external OIDC provider instead of the built-in
+// OpenShift OAuth server. The external_auth_config attribute and the external_auths collection
+// aren't part of the SDK, see raw.go.
+
+package ocm
+
+import (
+	"fmt"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+// IsExternalAuthEnabled checks if the cluster authenticates its users with external OIDC providers
+// instead of the built-in OpenShift OAuth server.
+func (c *Client) IsExternalAuthEnabled(clusterID string) (bool, error) {
+	var cluster struct {
+		ExternalAuthConfig struct {
+			Enabled bool `json:"enabled"`
+		} `json:"external_auth_config"`
+	}
+	err := sendRaw(c.ocm.Get().Path(fmt.Sprintf("%s/clusters/%s", clustersMgmtPath, clusterID)), nil, &cluster)
+	if err != nil {
+		return false, err
+	}
+	return cluster.ExternalAuthConfig.Enabled, nil
+}
+
+// ValidateExternalAuthCluster checks that the cluster is a Hosted Control Plane cluster that uses
+// external authentication.
+func (c *Client) ValidateExternalAuthCluster(cluster *cmv1.Cluster) error {
+	if !cluster.Hypershift().Enabled() {
+		return fmt.Errorf("External authentication is only supported for Hosted Control Plane clusters")
+	}
+	enabled, err := c.IsExternalAuthEnabled(cluster.ID())
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return fmt.Errorf("External authentication isn't enabled for cluster '%s'", cluster.Name())
+	}
+	return nil
+}
+
+// Components of the cluster that can use an OIDC client of an external authentication provider.
+const (
+	ExternalAuthConsoleComponent = "console"
+	ExternalAuthCLIComponent     = "cli"
+	externalAuthClientNamespace  = "openshift-console"
+)
+
+// ExternalAuth is an external OIDC provider that authenticates the users of a Hosted Control Plane
+// cluster.
+type ExternalAuth struct {
+	Kind    string                `json:"kind,omitempty"`
+	ID      string                `json:"id,omitempty"`
+	HREF    string                `json:"href,omitempty"`
+	Issuer  *ExternalAuthIssuer   `json:"issuer,omitempty"`
+	Claim   *ExternalAuthClaim    `json:"claim,omitempty"`
+	Clients []*ExternalAuthClient `json:"clients,omitempty"`
+}
+
+type ExternalAuthIssuer struct {
+	URL       string   `json:"url,omitempty"`
+	Audiences []string `json:"audiences,omitempty"`
+	CA        string   `json:"ca,omitempty"`
+}
+
+type ExternalAuthClaim struct {
+	Mappings        *ExternalAuthClaimMappings         `json:"mappings,omitempty"`
+	ValidationRules []*ExternalAuthClaimValidationRule `json:"validation_rules,omitempty"`
+}
+
+type ExternalAuthClaimMappings struct {
+	UserName *ExternalAuthClaimMapping `json:"username,omitempty"`
+	Groups   *ExternalAuthClaimMapping `json:"groups,omitempty"`
+}
+
+type ExternalAuthClaimMapping struct {
+	Claim  string `json:"claim,omitempty"`
+	Prefix string `json:"prefix,omitempty"`
+}
+
+type ExternalAuthClaimValidationRule struct {
+	Claim         string `json:"claim"`
+	RequiredValue string `json:"required_value"`
+}
+
+type ExternalAuthClient struct {
+	Component ExternalAuthClientComponent `json:"component"`
+	ID        string                      `json:"id"`
+	Secret    string                      `json:"secret,omitempty"`
+}
+
+type ExternalAuthClientComponent struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
+
+// NewExternalAuthClient returns the OIDC client used by the given component of the cluster.
+func NewExternalAuthClient(component string, id string, secret string) *ExternalAuthClient {
+	return &ExternalAuthClient{
+		Component: ExternalAuthClientComponent{
+			Name:      component,
+			Namespace: externalAuthClientNamespace,
+		},
+		ID:     id,
+		Secret: secret,
+	}
+}
+
+// FindClient returns the OIDC client used by the given component of the cluster, or nil if there is
+// none.
+func (e *ExternalAuth) FindClient(component string) *ExternalAuthClient {
+	for _, client := range e.Clients {
+		if client.Component.Name == component {
+			return client
+		}
+	}
+	return nil
+}
+
+func externalAuthsPath(clusterID string) string {
+	return fmt.Sprintf("%s/clusters/%s/external_auth_config/external_auths", clustersMgmtPath, clusterID)
+}
+
+func externalAuthPath(clusterID string, id string) string {
+	return fmt.Sprintf("%s/%s", externalAuthsPath(clusterID), id)
+}
+
+func (c *Client) CreateExternalAuth(clusterID string, externalAuth *ExternalAuth) (*ExternalAuth, error) {
+	created := new(ExternalAuth)
+	err := sendRaw(c.ocm.Post().Path(externalAuthsPath(clusterID)), externalAuth, created)
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func (c *Client) UpdateExternalAuth(clusterID string, externalAuth *ExternalAuth) (*ExternalAuth, error) {
+	updated := new(ExternalAuth)
+	err := sendRaw(c.ocm.Patch().Path(externalAuthPath(clusterID, externalAuth.ID)), externalAuth, updated)
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func (c *Client) GetExternalAuths(clusterID string) ([]*ExternalAuth, error) {
+	var list struct {
+		Items []*ExternalAuth `json:"items"`
+	}
+	err := sendRaw(c.ocm.Get().Path(externalAuthsPath(clusterID)).Parameter("size", -1), nil, &list)
+	if err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+// GetExternalAuth returns the external authentication provider, or nil if it doesn't exist.
+func (c *Client) GetExternalAuth(clusterID string, id string) (*ExternalAuth, error) {
+	externalAuth := new(ExternalAuth)
+	err := sendRaw(c.ocm.Get().Path(externalAuthPath(clusterID, id)), nil, externalAuth)
+	if isNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return externalAuth, nil
+}
+
+func (c *Client) DeleteExternalAuth(clusterID string, id string) error {
+	return sendRaw(c.ocm.Delete().Path(externalAuthPath(clusterID, id)), nil, nil)
+}
@@ -0,0 +1,84 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the support matrix of the instance types that can be used by the node pools of
+// hosted control plane clusters, so that unsupported ones are rejected before the request is sent.
+
+package ocm
+
+import (
+	"fmt"
+	"strings"
+)
+
+// hostedCPExclusion is a rule of the support matrix, excluding the instance types that it matches.
+type hostedCPExclusion struct {
+	matches func(family string, size string) bool
+	reason  string
+}
+
+// xenFamilies are the current families of instance types built on the Xen hypervisor instead of the
+// Nitro system.
+var xenFamilies = []string{"c4", "d2", "h1", "i3", "m4", "r4", "x1", "x1e"}
+
+var hostedCPExclusions = []hostedCPExclusion{
+	{
+		matches: func(_ string, size string) bool {
+			return strings.HasPrefix(size, "metal")
+		},
+		reason: "bare metal instance types aren't supported",
+	},
+	{
+		matches: func(family string, _ string) bool {
+			return strings.HasPrefix(family, "t") && len(family) > 1 && family[1] >= '0' && family[1] <= '9'
+		},
+		reason: "burstable performance instance types aren't supported, as running out of CPU credits " +
+			"throttles the nodes",
+	},
+	{
+		matches: func(family string, _ string) bool {
+			for _, xenFamily := range xenFamilies {
+				if family == xenFamily {
+					return true
+				}
+			}
+			return false
+		},
+		reason: "instance types built on the Xen hypervisor aren't supported, use one built on the " +
+			"Nitro system instead",
+	},
+}
+
+// ValidateHostedCPMachineType checks that the instance type can be used by the node pools of hosted
+// control plane clusters, returning the reason when it can't.
+func ValidateHostedCPMachineType(machineType string) error {
+	family, size, _ := strings.Cut(machineType, ".")
+	for _, exclusion := range hostedCPExclusions {
+		if exclusion.matches(family, size) {
+			return fmt.Errorf("Instance type '%s' can't be used by hosted control plane clusters: %s",
+				machineType, exclusion.reason)
+		}
+	}
+	return nil
+}
+
+// FilterHostedCPSupported returns the machine types that can be used by the node pools of hosted
+// control plane clusters.
+func (mtl *MachineTypeList) FilterHostedCPSupported() MachineTypeList {
+	return mtl.Filter(func(mt *MachineType) bool {
+		return ValidateHostedCPMachineType(mt.MachineType.ID()) == nil
+	})
+}
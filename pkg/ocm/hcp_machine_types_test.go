@@ -0,0 +1,42 @@
+package ocm
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+var _ = Describe("Hosted control plane machine types", func() {
+	DescribeTable("ValidateHostedCPMachineType",
+		func(machineType string, reason string) {
+			err := ValidateHostedCPMachineType(machineType)
+			if reason == "" {
+				Expect(err).ToNot(HaveOccurred())
+			} else {
+				Expect(err).To(MatchError(ContainSubstring(reason)))
+			}
+		},
+		Entry("general purpose", "m5.xlarge", ""),
+		Entry("graviton", "m6g.xlarge", ""),
+		Entry("accelerated", "g4dn.xlarge", ""),
+		Entry("trainium", "trn1.2xlarge", ""),
+		Entry("bare metal", "m5.metal", "bare metal"),
+		Entry("bare metal with size", "c6i.metal-24xl", "bare metal"),
+		Entry("high memory bare metal", "u-6tb1.metal", "bare metal"),
+		Entry("burstable", "t3.xlarge", "burstable"),
+		Entry("graviton burstable", "t4g.large", "burstable"),
+		Entry("xen", "m4.xlarge", "Xen"),
+		Entry("xen memory optimized", "x1e.xlarge", "Xen"),
+	)
+
+	It("filters out unsupported machine types", func() {
+		list := MachineTypeList{}
+		for _, id := range []string{"m5.xlarge", "t3.xlarge", "m5.metal", "r5.xlarge"} {
+			machineType, err := cmv1.NewMachineType().ID(id).Build()
+			Expect(err).ToNot(HaveOccurred())
+			list = append(list, &MachineType{MachineType: machineType})
+		}
+		filtered := list.FilterHostedCPSupported()
+		Expect(filtered.IDs()).To(Equal([]string{"m5.xlarge", "r5.xlarge"}))
+	})
+})
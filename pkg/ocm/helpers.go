@@ -17,7 +17,6 @@ limitations under the License.
 package ocm
 
 import (
-	"crypto/x509"
 	"fmt"
 	"net"
 	"net/http"
@@ -119,15 +118,7 @@ func ValidateAdditionalTrustBundle(val interface{}) error {
 		if err != nil {
 			return err
 		}
-		additionalTrustBundle := string(cert)
-		if additionalTrustBundle == "" {
-			return errors.Errorf("%s", "Trust bundle file is empty")
-		}
-		additionalTrustBundleBytes := []byte(additionalTrustBundle)
-		if !x509.NewCertPool().AppendCertsFromPEM(additionalTrustBundleBytes) {
-			return errors.Errorf("%s", "Failed to parse additional trust bundle")
-		}
-		return nil
+		return ValidateTrustBundle(cert)
 	}
 	return fmt.Errorf("can only validate strings, got %v", val)
 }
@@ -164,7 +155,12 @@ func handleErr(res *ocmerrors.Error, err error) error {
 			"Once you accept the terms, you will need to retry the action that was blocked."
 	}
 	errType := errors.ErrorType(res.Status())
-	return errType.Set(errors.Errorf("%s", msg))
+	result := errType.Set(errors.Errorf("%s", msg))
+	// Keep the original error, so that its code and operation identifier can be reported:
+	if res != nil {
+		result = errType.AddDetails(result, res)
+	}
+	return result
 }
 
 func (c *Client) GetDefaultClusterFlavors(flavour string) (dMachinecidr *net.IPNet, dPodcidr *net.IPNet,
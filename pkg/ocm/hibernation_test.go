@@ -0,0 +1,98 @@
+package ocm
+
+import (
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/openshift-online/ocm-sdk-go/logging"
+	. "github.com/openshift-online/ocm-sdk-go/testing"
+)
+
+var _ = Describe("Hibernation", func() {
+	DescribeTable("ValidateHibernation",
+		func(hostedCP bool, product string, state cmv1.ClusterState, target cmv1.ClusterState, message string) {
+			cluster, err := cmv1.NewCluster().Name("mycluster").
+				Hypershift(cmv1.NewHypershift().Enabled(hostedCP)).
+				Product(cmv1.NewProduct().ID(product)).
+				State(state).
+				Build()
+			Expect(err).ToNot(HaveOccurred())
+			err = ValidateHibernation(cluster, target)
+			if message == "" {
+				Expect(err).ToNot(HaveOccurred())
+			} else {
+				Expect(err).To(MatchError(ContainSubstring(message)))
+			}
+		},
+		Entry("ready classic cluster", false, "rosa", cmv1.ClusterStateReady, cmv1.ClusterStateReady, ""),
+		Entry("hibernating classic cluster", false, "rosa", cmv1.ClusterStateHibernating,
+			cmv1.ClusterStateHibernating, ""),
+		Entry("hosted cluster", true, "rosa", cmv1.ClusterStateReady, cmv1.ClusterStateReady,
+			"not supported for Hosted Control Plane clusters"),
+		Entry("OSD cluster", false, "osd", cmv1.ClusterStateReady, cmv1.ClusterStateReady,
+			"only supported for ROSA clusters"),
+		Entry("hibernating an installing cluster", false, "rosa", cmv1.ClusterStateInstalling,
+			cmv1.ClusterStateReady, "Hibernating a cluster is only supported for 'ready' clusters"),
+		Entry("resuming a ready cluster", false, "rosa", cmv1.ClusterStateReady,
+			cmv1.ClusterStateHibernating, "Resuming a cluster is only supported for 'hibernating' clusters"),
+	)
+
+	Context("Entitlement", func() {
+		var apiServer *ghttp.Server
+		var ocmClient *Client
+
+		BeforeEach(func() {
+			apiServer = MakeTCPServer()
+			accessToken := MakeTokenString("Bearer", 15*time.Minute)
+			logger, err := logging.NewGoLoggerBuilder().Build()
+			Expect(err).To(BeNil())
+			connection, err := sdk.NewConnectionBuilder().
+				Logger(logger).
+				Tokens(accessToken).
+				URL(apiServer.URL()).
+				Build()
+			Expect(err).To(BeNil())
+			ocmClient = &Client{ocm: connection}
+		})
+
+		AfterEach(func() {
+			apiServer.Close()
+			Expect(ocmClient.Close()).To(Succeed())
+		})
+
+		respondWithCapability := func(value string) {
+			apiServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/api/accounts_mgmt/v1/current_account"),
+					RespondWithJSON(http.StatusOK, `{"kind": "Account", "organization": {"id": "org1"}}`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/api/accounts_mgmt/v1/organizations/org1"),
+					RespondWithJSON(http.StatusOK, `{
+						"kind": "Organization",
+						"id": "org1",
+						"capabilities": [
+							{"name": "`+HibernateCapability+`", "value": "`+value+`"}
+						]
+					}`),
+				),
+			)
+		}
+
+		It("Accepts organizations with the hibernation capability", func() {
+			respondWithCapability("true")
+			Expect(ocmClient.ValidateHibernationEntitlement()).To(Succeed())
+		})
+
+		It("Rejects organizations without the hibernation capability", func() {
+			respondWithCapability("false")
+			err := ocmClient.ValidateHibernationEntitlement()
+			Expect(err).To(MatchError(ContainSubstring("isn't entitled to hibernate clusters")))
+		})
+	})
+})
@@ -17,6 +17,7 @@ limitations under the License.
 package ocm
 
 import (
+	"encoding/json"
 	"fmt"
 	"net"
 	"net/http"
@@ -83,6 +84,34 @@ func (c *Client) AddHTPasswdUser(username, password, clusterID, idpID string) er
 	return nil
 }
 
+func (c *Client) UpdateHTPasswdUserPassword(username, password, clusterID, idpID string) error {
+	users, err := c.GetHTPasswdUserList(clusterID, idpID)
+	if err != nil {
+		return err
+	}
+	var userID string
+	users.Each(func(user *cmv1.HTPasswdUser) bool {
+		if user.Username() == username {
+			userID = user.ID()
+		}
+		return true
+	})
+	if userID == "" {
+		return fmt.Errorf("HTPasswd user named '%s' on cluster '%s' does not exist", username, clusterID)
+	}
+	htpasswdUser, err := cmv1.NewHTPasswdUser().Password(password).Build()
+	if err != nil {
+		return err
+	}
+	response, err := c.ocm.ClustersMgmt().V1().Clusters().Cluster(clusterID).
+		IdentityProviders().IdentityProvider(idpID).HtpasswdUsers().
+		HtpasswdUser(userID).Update().Body(htpasswdUser).Send()
+	if err != nil {
+		return handleErr(response.Error(), err)
+	}
+	return nil
+}
+
 func (c *Client) DeleteHTPasswdUser(username, clusterID string, htpasswdIDP *cmv1.IdentityProvider) error {
 	var userID string
 
@@ -197,3 +226,77 @@ func GetOAuthURL(cluster *cmv1.Cluster, idp *cmv1.IdentityProvider) (string, err
 	}
 	return fmt.Sprintf("%s/oauth2callback/%s", oauthURL, idp.Name()), nil
 }
+
+// GetIDPEndpointURL returns the URL of the handler that the OAuth server of the cluster registers
+// for the IDP: the callback URL for the IDPs that redirect to an external server, and the login
+// page for the others.
+func GetIDPEndpointURL(cluster *cmv1.Cluster, idp *cmv1.IdentityProvider) (string, error) {
+	if HasAuthURLSupport(idp) {
+		return GetOAuthURL(cluster, idp)
+	}
+	oauthURL, err := BuildOAuthURL(cluster, idp.Type())
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s/login/%s", oauthURL, idp.Name()), nil
+}
+
+// IsIdentityProviderActive checks if the OAuth server of the cluster serves the IDP, which happens
+// some minutes after it is created. An unreachable server or a server error means that the IDP
+// isn't active yet.
+func IsIdentityProviderActive(client *http.Client, cluster *cmv1.Cluster,
+	idp *cmv1.IdentityProvider) (bool, error) {
+	endpoint, err := GetIDPEndpointURL(cluster, idp)
+	if err != nil {
+		return false, err
+	}
+	// Don't follow the redirects to the external server, the handler answering is enough:
+	noRedirects := *client
+	noRedirects.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	response, err := noRedirects.Get(endpoint)
+	if err != nil {
+		return false, nil
+	}
+	response.Body.Close()
+	return response.StatusCode != http.StatusNotFound && response.StatusCode < http.StatusInternalServerError, nil
+}
+
+// HTPasswdUser is a user of an HTPasswd IDP, with either a clear text password or a hashed one as
+// read from an htpasswd file. The typed client of the SDK doesn't support hashed passwords yet, so
+// users are sent as raw JSON.
+type HTPasswdUser struct {
+	Username       string `json:"username"`
+	Password       string `json:"password,omitempty"`
+	HashedPassword string `json:"hashed_password,omitempty"`
+}
+
+type htpasswdUserList struct {
+	Items []HTPasswdUser `json:"items"`
+}
+
+// CreateHTPasswdIdentityProvider creates an HTPasswd IDP with the given users.
+func (c *Client) CreateHTPasswdIdentityProvider(clusterID string, name string,
+	users []HTPasswdUser) (*cmv1.IdentityProvider, error) {
+	body := map[string]interface{}{
+		"type": cmv1.IdentityProviderTypeHtpasswd,
+		"name": name,
+		"htpasswd": map[string]interface{}{
+			"users": htpasswdUserList{Items: users},
+		},
+	}
+	var created json.RawMessage
+	err := sendRaw(c.ocm.Post().Path(fmt.Sprintf("%s/clusters/%s/identity_providers", clustersMgmtPath, clusterID)),
+		body, &created)
+	if err != nil {
+		return nil, err
+	}
+	return cmv1.UnmarshalIdentityProvider([]byte(created))
+}
+
+// ImportHTPasswdUsers adds the given users to an existing HTPasswd IDP.
+func (c *Client) ImportHTPasswdUsers(clusterID string, idpID string, users []HTPasswdUser) error {
+	return sendRaw(c.ocm.Post().Path(fmt.Sprintf("%s/clusters/%s/identity_providers/%s/htpasswd_users/import",
+		clustersMgmtPath, clusterID, idpID)), htpasswdUserList{Items: users}, nil)
+}
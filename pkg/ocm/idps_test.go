@@ -1,9 +1,19 @@
 package ocm
 
 import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	sdk "github.com/openshift-online/ocm-sdk-go"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/openshift-online/ocm-sdk-go/logging"
+	. "github.com/openshift-online/ocm-sdk-go/testing"
 )
 
 var _ = Describe("IDPs", func() {
@@ -49,5 +59,119 @@ var _ = Describe("IDPs", func() {
 				Expect(err).To(BeNil())
 			})
 		})
+		Context("IsIdentityProviderActive", func() {
+			var server *httptest.Server
+			var cluster *cmv1.Cluster
+
+			BeforeEach(func() {
+				server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					switch r.URL.Path {
+					case "/oauth2callback/github-1":
+						http.Redirect(w, r, "https://github.com/login", http.StatusFound)
+					case "/login/htpasswd-1":
+						w.WriteHeader(http.StatusOK)
+					default:
+						http.NotFound(w, r)
+					}
+				}))
+				consoleURL := cmv1.NewClusterConsole().URL(server.URL)
+				var err error
+				cluster, err = cmv1.NewCluster().Name("cluster1").ID("id1").Console(consoleURL).Build()
+				Expect(err).To(BeNil())
+			})
+
+			AfterEach(func() {
+				server.Close()
+			})
+
+			It("Checks the callback of IDPs with an auth URL", func() {
+				idp, err := cmv1.NewIdentityProvider().Name("github-1").
+					Type(cmv1.IdentityProviderTypeGithub).Build()
+				Expect(err).To(BeNil())
+				active, err := IsIdentityProviderActive(server.Client(), cluster, idp)
+				Expect(err).To(BeNil())
+				Expect(active).To(BeTrue())
+			})
+			It("Checks the login page of the other IDPs", func() {
+				idp, err := cmv1.NewIdentityProvider().Name("htpasswd-1").
+					Type(cmv1.IdentityProviderTypeHtpasswd).Build()
+				Expect(err).To(BeNil())
+				active, err := IsIdentityProviderActive(server.Client(), cluster, idp)
+				Expect(err).To(BeNil())
+				Expect(active).To(BeTrue())
+			})
+			It("Isn't active until the OAuth server serves it", func() {
+				idp, err := cmv1.NewIdentityProvider().Name("ldap-1").
+					Type(cmv1.IdentityProviderTypeLDAP).Build()
+				Expect(err).To(BeNil())
+				active, err := IsIdentityProviderActive(server.Client(), cluster, idp)
+				Expect(err).To(BeNil())
+				Expect(active).To(BeFalse())
+			})
+		})
+	})
+})
+
+var _ = Describe("HTPasswd users", func() {
+	const usersPath = "/api/clusters_mgmt/v1/clusters/123/identity_providers/idp-1/htpasswd_users"
+
+	var apiServer *ghttp.Server
+	var ocmClient *Client
+
+	BeforeEach(func() {
+		apiServer = MakeTCPServer()
+		accessToken := MakeTokenString("Bearer", 15*time.Minute)
+		logger, err := logging.NewGoLoggerBuilder().Build()
+		Expect(err).To(BeNil())
+		connection, err := sdk.NewConnectionBuilder().
+			Logger(logger).
+			Tokens(accessToken).
+			URL(apiServer.URL()).
+			Build()
+		Expect(err).To(BeNil())
+		ocmClient = &Client{ocm: connection}
+		apiServer.AppendHandlers(
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest(http.MethodGet, usersPath),
+				RespondWithJSON(http.StatusOK, `{
+					"kind": "HTPasswdUserList",
+					"page": 1,
+					"size": 2,
+					"total": 2,
+					"items": [
+						{"kind": "HTPasswdUser", "id": "user-1", "username": "alice"},
+						{"kind": "HTPasswdUser", "id": "user-2", "username": "cluster-admin"}
+					]
+				}`),
+			),
+		)
+	})
+
+	AfterEach(func() {
+		apiServer.Close()
+		Expect(ocmClient.Close()).To(Succeed())
+	})
+
+	It("Updates the password of the user with the given name", func() {
+		var sent map[string]interface{}
+		apiServer.AppendHandlers(
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest(http.MethodPatch, usersPath+"/user-2"),
+				func(w http.ResponseWriter, req *http.Request) {
+					data, err := io.ReadAll(req.Body)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(json.Unmarshal(data, &sent)).To(Succeed())
+				},
+				RespondWithJSON(http.StatusOK, `{"kind": "HTPasswdUser", "id": "user-2"}`),
+			),
+		)
+		Expect(ocmClient.UpdateHTPasswdUserPassword("cluster-admin", "new-password", "123", "idp-1")).
+			To(Succeed())
+		Expect(sent).To(HaveKeyWithValue("password", "new-password"))
+	})
+
+	It("Fails when the user doesn't exist", func() {
+		err := ocmClient.UpdateHTPasswdUserPassword("bob", "new-password", "123", "idp-1")
+		Expect(err).To(MatchError(ContainSubstring("'bob'")))
 	})
 })
@@ -0,0 +1,61 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the validations of the EC2 instance metadata service settings of the nodes.
+
+package ocm
+
+import (
+	"fmt"
+)
+
+const (
+	// Ec2MetadataHttpTokensOptional allows the nodes to use both IMDSv1 and IMDSv2.
+	Ec2MetadataHttpTokensOptional = "optional"
+	// Ec2MetadataHttpTokensRequired forces the nodes to use IMDSv2.
+	Ec2MetadataHttpTokensRequired = "required"
+
+	// MinimumIMDSv2Version is the first OpenShift version whose components all support IMDSv2.
+	MinimumIMDSv2Version = "4.11"
+)
+
+// Ec2MetadataHttpTokensValues are the supported values of the EC2 metadata HTTP tokens setting.
+var Ec2MetadataHttpTokensValues = []string{Ec2MetadataHttpTokensOptional, Ec2MetadataHttpTokensRequired}
+
+// ValidateEc2MetadataHttpTokens checks that the value of the EC2 metadata HTTP tokens setting is
+// supported, and that IMDSv2 is only required for versions whose components support it.
+func ValidateEc2MetadataHttpTokens(httpTokens string, version string) error {
+	switch httpTokens {
+	case "", Ec2MetadataHttpTokensOptional:
+		return nil
+	case Ec2MetadataHttpTokensRequired:
+	default:
+		return fmt.Errorf("Expected a valid EC2 metadata HTTP tokens value, one of %v, got '%s'",
+			Ec2MetadataHttpTokensValues, httpTokens)
+	}
+	if version == "" {
+		return nil
+	}
+	supported, err := CheckSupportedVersion(GetVersionMinor(version), MinimumIMDSv2Version)
+	if err != nil {
+		return fmt.Errorf("Failed to check IMDSv2 support of version '%s': %v", version, err)
+	}
+	if !supported {
+		return fmt.Errorf("IMDSv2 can only be required for clusters with version %s or greater, got '%s'",
+			MinimumIMDSv2Version, version)
+	}
+	return nil
+}
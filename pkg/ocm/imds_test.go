@@ -0,0 +1,24 @@
+package ocm
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = DescribeTable("ValidateEc2MetadataHttpTokens",
+	func(httpTokens string, version string, valid bool) {
+		err := ValidateEc2MetadataHttpTokens(httpTokens, version)
+		if valid {
+			Expect(err).ToNot(HaveOccurred())
+		} else {
+			Expect(err).To(HaveOccurred())
+		}
+	},
+	Entry("default", "", "4.10.3", true),
+	Entry("optional on any version", Ec2MetadataHttpTokensOptional, "4.10.3", true),
+	Entry("required on supported version", Ec2MetadataHttpTokensRequired, "4.11.0", true),
+	Entry("required with version prefix", Ec2MetadataHttpTokensRequired, "openshift-v4.12.1", true),
+	Entry("required on old version", Ec2MetadataHttpTokensRequired, "4.10.3", false),
+	Entry("required without version", Ec2MetadataHttpTokensRequired, "", true),
+	Entry("unknown value", "always", "4.12.1", false),
+)
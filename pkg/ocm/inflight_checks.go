@@ -0,0 +1,69 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ocm
+
+import (
+	"encoding/json"
+	"fmt"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+func inflightChecksPath(clusterID string) string {
+	return fmt.Sprintf("%s/clusters/%s/inflight_checks", clustersMgmtPath, clusterID)
+}
+
+// GetInflightChecks returns the inflight checks of the cluster, which the service runs before
+// installing it, for example to verify its network configuration. The cluster client of the SDK
+// has no method returning the client of the checks, so it is created for their path.
+func (c *Client) GetInflightChecks(clusterID string) ([]*cmv1.InflightCheck, error) {
+	response, err := cmv1.NewInflightChecksClient(c.ocm, inflightChecksPath(clusterID)).
+		List().
+		Send()
+	if err != nil {
+		return nil, handleErr(response.Error(), err)
+	}
+	return response.Items().Slice(), nil
+}
+
+// FailedInflightChecks returns the inflight checks that failed.
+func FailedInflightChecks(checks []*cmv1.InflightCheck) []*cmv1.InflightCheck {
+	failed := []*cmv1.InflightCheck{}
+	for _, check := range checks {
+		if check.State() == cmv1.InflightCheckStateFailed {
+			failed = append(failed, check)
+		}
+	}
+	return failed
+}
+
+// RerunInflightChecks runs the inflight checks of the cluster again, so that the installation can
+// continue once the problems they found are fixed, and returns the checks that were started. The
+// typed client of the SDK has no method for the 'rerun' action, so it is sent as a raw request.
+func (c *Client) RerunInflightChecks(clusterID string) ([]*cmv1.InflightCheck, error) {
+	var list struct {
+		Items json.RawMessage `json:"items"`
+	}
+	err := sendRaw(c.ocm.Post().Path(inflightChecksPath(clusterID)+"/rerun"), nil, &list)
+	if err != nil {
+		return nil, err
+	}
+	if len(list.Items) == 0 {
+		return nil, nil
+	}
+	return cmv1.UnmarshalInflightCheckList([]byte(list.Items))
+}
@@ -0,0 +1,92 @@
+package ocm
+
+import (
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/openshift-online/ocm-sdk-go/logging"
+	. "github.com/openshift-online/ocm-sdk-go/testing"
+)
+
+var _ = Describe("Inflight checks", func() {
+	It("Returns only the failed checks", func() {
+		egress, err := cmv1.NewInflightCheck().Name("egress").State(cmv1.InflightCheckStateFailed).Build()
+		Expect(err).NotTo(HaveOccurred())
+		network, err := cmv1.NewInflightCheck().Name("network").State(cmv1.InflightCheckStatePassed).Build()
+		Expect(err).NotTo(HaveOccurred())
+		failed := FailedInflightChecks([]*cmv1.InflightCheck{egress, network})
+		Expect(failed).To(HaveLen(1))
+		Expect(failed[0].Name()).To(Equal("egress"))
+		Expect(FailedInflightChecks(nil)).To(BeEmpty())
+	})
+
+	When("calling the API", func() {
+		var apiServer *ghttp.Server
+		var ocmClient *Client
+
+		BeforeEach(func() {
+			apiServer = MakeTCPServer()
+			accessToken := MakeTokenString("Bearer", 15*time.Minute)
+			logger, err := logging.NewGoLoggerBuilder().Build()
+			Expect(err).To(BeNil())
+			connection, err := sdk.NewConnectionBuilder().
+				Logger(logger).
+				Tokens(accessToken).
+				URL(apiServer.URL()).
+				Build()
+			Expect(err).To(BeNil())
+			ocmClient = &Client{ocm: connection}
+		})
+
+		AfterEach(func() {
+			apiServer.Close()
+			Expect(ocmClient.Close()).To(Succeed())
+		})
+
+		It("Posts to the rerun endpoint and returns the started checks", func() {
+			apiServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPost,
+						"/api/clusters_mgmt/v1/clusters/123/inflight_checks/rerun"),
+					RespondWithJSON(http.StatusOK,
+						`{"items":[{"id":"abc","name":"egress","state":"running"}]}`),
+				),
+			)
+			checks, err := ocmClient.RerunInflightChecks("123")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(checks).To(HaveLen(1))
+			Expect(checks[0].Name()).To(Equal("egress"))
+			Expect(checks[0].State()).To(Equal(cmv1.InflightCheckStateRunning))
+		})
+
+		It("Lists the checks with the typed client", func() {
+			apiServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/inflight_checks"),
+					RespondWithJSON(http.StatusOK, `{"kind":"InflightCheckList","page":1,"size":1,"total":1,`+
+						`"items":[{"kind":"InflightCheck","id":"abc","name":"egress","state":"failed",`+
+						`"details":{"url":"quay.io"}}]}`),
+				),
+			)
+			checks, err := ocmClient.GetInflightChecks("123")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(checks).To(HaveLen(1))
+			Expect(checks[0].Name()).To(Equal("egress"))
+			Expect(checks[0].State()).To(Equal(cmv1.InflightCheckStateFailed))
+			Expect(checks[0].Details()).To(Equal(map[string]interface{}{"url": "quay.io"}))
+		})
+
+		It("Fails when the service rejects the request", func() {
+			apiServer.AppendHandlers(
+				RespondWithJSON(http.StatusBadRequest, `{"kind":"Error","reason":"Checks are running"}`),
+			)
+			_, err := ocmClient.RerunInflightChecks("123")
+			Expect(err).To(MatchError("Checks are running"))
+		})
+	})
+})
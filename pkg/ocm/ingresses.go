@@ -17,9 +17,71 @@ limitations under the License.
 package ocm
 
 import (
+	"encoding/json"
+	"fmt"
+	"io"
+
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 )
 
+// Types of the load balancers of the ingresses.
+const (
+	LoadBalancerTypeClassic = "classic"
+	LoadBalancerTypeNLB     = "nlb"
+)
+
+var LoadBalancerTypes = []string{LoadBalancerTypeClassic, LoadBalancerTypeNLB}
+
+// Policies that decide if routes of different namespaces can claim the same host name.
+const (
+	NamespaceOwnershipPolicyStrict                = "Strict"
+	NamespaceOwnershipPolicyInterNamespaceAllowed = "InterNamespaceAllowed"
+)
+
+var NamespaceOwnershipPolicies = []string{
+	NamespaceOwnershipPolicyStrict,
+	NamespaceOwnershipPolicyInterNamespaceAllowed,
+}
+
+// Policies that decide if routes with wildcard host names are admitted.
+const (
+	WildcardPolicyWildcardsDisallowed = "WildcardsDisallowed"
+	WildcardPolicyWildcardsAllowed    = "WildcardsAllowed"
+)
+
+var WildcardPolicies = []string{WildcardPolicyWildcardsDisallowed, WildcardPolicyWildcardsAllowed}
+
+// Names of the routes of the cluster components whose host name can be customized in the default
+// ingress.
+const (
+	ComponentRouteOAuth     = "oauth"
+	ComponentRouteConsole   = "console"
+	ComponentRouteDownloads = "downloads"
+)
+
+var ComponentRouteNames = []string{ComponentRouteOAuth, ComponentRouteConsole, ComponentRouteDownloads}
+
+// ComponentRoute is the custom host name of the route of a cluster component, with the secret in
+// the 'openshift-config' namespace containing its certificate.
+type ComponentRoute struct {
+	Hostname     string `json:"hostname"`
+	TLSSecretRef string `json:"tls_secret_ref"`
+}
+
+// IngressSettings contains the settings of an ingress that the typed client of the SDK doesn't
+// support yet, so they are read and written as raw JSON.
+type IngressSettings struct {
+	ExcludedNamespaces       []string                   `json:"excluded_namespaces,omitempty"`
+	NamespaceOwnershipPolicy string                     `json:"route_namespace_ownership_policy,omitempty"`
+	WildcardPolicy           string                     `json:"route_wildcard_policy,omitempty"`
+	LoadBalancerType         string                     `json:"load_balancer_type,omitempty"`
+	ComponentRoutes          map[string]*ComponentRoute `json:"component_routes,omitempty"`
+}
+
+func ingressesPath(clusterID string) string {
+	return fmt.Sprintf("%s/clusters/%s/ingresses", clustersMgmtPath, clusterID)
+}
+
 func (c *Client) GetIngresses(clusterID string) ([]*cmv1.Ingress, error) {
 	response, err := c.ocm.ClustersMgmt().V1().
 		Clusters().Cluster(clusterID).
@@ -33,6 +95,35 @@ func (c *Client) GetIngresses(clusterID string) ([]*cmv1.Ingress, error) {
 	return response.Items().Slice(), nil
 }
 
+// GetIngressesWithSettings returns the ingresses of the cluster and their raw settings indexed by
+// the identifier of the ingress, using a single request.
+func (c *Client) GetIngressesWithSettings(clusterID string) ([]*cmv1.Ingress, map[string]*IngressSettings,
+	error) {
+	var list struct {
+		Items []json.RawMessage `json:"items"`
+	}
+	err := sendRaw(c.ocm.Get().Path(ingressesPath(clusterID)).Parameter("size", -1), nil, &list)
+	if err != nil {
+		return nil, nil, err
+	}
+	ingresses := make([]*cmv1.Ingress, 0, len(list.Items))
+	settings := map[string]*IngressSettings{}
+	for _, item := range list.Items {
+		ingress, err := cmv1.UnmarshalIngress([]byte(item))
+		if err != nil {
+			return nil, nil, err
+		}
+		ingressSettings := new(IngressSettings)
+		err = json.Unmarshal(item, ingressSettings)
+		if err != nil {
+			return nil, nil, err
+		}
+		ingresses = append(ingresses, ingress)
+		settings[ingress.ID()] = ingressSettings
+	}
+	return ingresses, settings, nil
+}
+
 func (c *Client) CreateIngress(clusterID string, ingress *cmv1.Ingress) (*cmv1.Ingress, error) {
 	response, err := c.ocm.ClustersMgmt().V1().
 		Clusters().Cluster(clusterID).
@@ -45,6 +136,27 @@ func (c *Client) CreateIngress(clusterID string, ingress *cmv1.Ingress) (*cmv1.I
 	return response.Body(), nil
 }
 
+// CreateIngressWithFields creates the ingress adding to the request the given fields, that the typed
+// client of the SDK doesn't support, indexed by their dotted path.
+func (c *Client) CreateIngressWithFields(clusterID string, ingress *cmv1.Ingress,
+	fields map[string]interface{}) (*cmv1.Ingress, error) {
+	if len(fields) == 0 {
+		return c.CreateIngress(clusterID, ingress)
+	}
+	body, err := rawBody(func(writer io.Writer) error {
+		return cmv1.MarshalIngress(ingress, writer)
+	}, fields)
+	if err != nil {
+		return nil, err
+	}
+	var created json.RawMessage
+	err = sendRaw(c.ocm.Post().Path(ingressesPath(clusterID)), body, &created)
+	if err != nil {
+		return nil, err
+	}
+	return cmv1.UnmarshalIngress([]byte(created))
+}
+
 func (c *Client) UpdateIngress(clusterID string, ingress *cmv1.Ingress) (*cmv1.Ingress, error) {
 	response, err := c.ocm.ClustersMgmt().V1().
 		Clusters().Cluster(clusterID).
@@ -57,6 +169,28 @@ func (c *Client) UpdateIngress(clusterID string, ingress *cmv1.Ingress) (*cmv1.I
 	return response.Body(), nil
 }
 
+// UpdateIngressWithFields updates the ingress adding to the request the given fields, that the typed
+// client of the SDK doesn't support, indexed by their dotted path.
+func (c *Client) UpdateIngressWithFields(clusterID string, ingress *cmv1.Ingress,
+	fields map[string]interface{}) (*cmv1.Ingress, error) {
+	if len(fields) == 0 {
+		return c.UpdateIngress(clusterID, ingress)
+	}
+	body, err := rawBody(func(writer io.Writer) error {
+		return cmv1.MarshalIngress(ingress, writer)
+	}, fields)
+	if err != nil {
+		return nil, err
+	}
+	var updated json.RawMessage
+	path := fmt.Sprintf("%s/%s", ingressesPath(clusterID), ingress.ID())
+	err = sendRaw(c.ocm.Patch().Path(path), body, &updated)
+	if err != nil {
+		return nil, err
+	}
+	return cmv1.UnmarshalIngress([]byte(updated))
+}
+
 func (c *Client) DeleteIngress(clusterID string, ingressID string) error {
 	response, err := c.ocm.ClustersMgmt().V1().
 		Clusters().Cluster(clusterID).
@@ -0,0 +1,101 @@
+package ocm
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/openshift-online/ocm-sdk-go/logging"
+	. "github.com/openshift-online/ocm-sdk-go/testing"
+)
+
+var _ = Describe("Ingresses", func() {
+	const ingressesPath = "/api/clusters_mgmt/v1/clusters/123/ingresses"
+
+	var apiServer *ghttp.Server
+	var ocmClient *Client
+
+	BeforeEach(func() {
+		apiServer = MakeTCPServer()
+		accessToken := MakeTokenString("Bearer", 15*time.Minute)
+		logger, err := logging.NewGoLoggerBuilder().Build()
+		Expect(err).To(BeNil())
+		connection, err := sdk.NewConnectionBuilder().
+			Logger(logger).
+			Tokens(accessToken).
+			URL(apiServer.URL()).
+			Build()
+		Expect(err).To(BeNil())
+		ocmClient = &Client{ocm: connection}
+	})
+
+	AfterEach(func() {
+		apiServer.Close()
+		Expect(ocmClient.Close()).To(Succeed())
+	})
+
+	It("Reads the ingresses and their raw settings with a single request", func() {
+		apiServer.AppendHandlers(
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest(http.MethodGet, ingressesPath),
+				RespondWithJSON(http.StatusOK, `{
+					"items": [
+						{
+							"kind": "Ingress",
+							"id": "a1b2",
+							"default": true,
+							"listening": "external",
+							"excluded_namespaces": ["dev"],
+							"route_wildcard_policy": "WildcardsAllowed",
+							"load_balancer_type": "nlb",
+							"component_routes": {
+								"console": {"hostname": "console.example.com", "tls_secret_ref": "console-tls"}
+							}
+						}
+					]
+				}`),
+			),
+		)
+		ingresses, settings, err := ocmClient.GetIngressesWithSettings("123")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ingresses).To(HaveLen(1))
+		Expect(ingresses[0].Default()).To(BeTrue())
+		Expect(settings).To(HaveKey("a1b2"))
+		Expect(settings["a1b2"].ExcludedNamespaces).To(Equal([]string{"dev"}))
+		Expect(settings["a1b2"].WildcardPolicy).To(Equal(WildcardPolicyWildcardsAllowed))
+		Expect(settings["a1b2"].LoadBalancerType).To(Equal(LoadBalancerTypeNLB))
+		Expect(settings["a1b2"].ComponentRoutes).To(HaveKeyWithValue(ComponentRouteConsole,
+			&ComponentRoute{Hostname: "console.example.com", TLSSecretRef: "console-tls"}))
+	})
+
+	It("Sends the raw fields together with the typed ingress", func() {
+		var sent map[string]interface{}
+		apiServer.AppendHandlers(
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest(http.MethodPatch, ingressesPath+"/a1b2"),
+				func(w http.ResponseWriter, req *http.Request) {
+					data, err := io.ReadAll(req.Body)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(json.Unmarshal(data, &sent)).To(Succeed())
+				},
+				RespondWithJSON(http.StatusOK, `{"kind": "Ingress", "id": "a1b2"}`),
+			),
+		)
+		ingress, err := cmv1.NewIngress().ID("a1b2").Listening(cmv1.ListeningMethodInternal).Build()
+		Expect(err).ToNot(HaveOccurred())
+		_, err = ocmClient.UpdateIngressWithFields("123", ingress, map[string]interface{}{
+			"load_balancer_type":  LoadBalancerTypeNLB,
+			"excluded_namespaces": []string{"dev"},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sent).To(HaveKeyWithValue("listening", "internal"))
+		Expect(sent).To(HaveKeyWithValue("load_balancer_type", "nlb"))
+		Expect(sent).To(HaveKeyWithValue("excluded_namespaces", ConsistOf("dev")))
+	})
+})
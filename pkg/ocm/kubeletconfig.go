@@ -0,0 +1,155 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ocm
+
+import (
+	"fmt"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+// Range of the PIDs limit of the pods accepted by the service.
+const (
+	MinPodPidsLimit = 4096
+	MaxPodPidsLimit = 16384
+)
+
+// KubeletConfig is a custom configuration of the kubelet of the nodes of a cluster. Classic clusters
+// have at most one, that applies to all their nodes, and Hosted Control Plane clusters can have
+// several, identified by name, that apply to the machine pools that reference them. Neither the
+// kubelet_config resource nor the kubelet_configs collection is part of the SDK, see raw.go.
+type KubeletConfig struct {
+	Kind         string `json:"kind,omitempty"`
+	ID           string `json:"id,omitempty"`
+	HREF         string `json:"href,omitempty"`
+	Name         string `json:"name,omitempty"`
+	PodPidsLimit int    `json:"pod_pids_limit"`
+}
+
+// ValidatePodPidsLimit checks that the PIDs limit of the pods is within the range accepted by the
+// service.
+func ValidatePodPidsLimit(limit int) error {
+	if limit < MinPodPidsLimit || limit > MaxPodPidsLimit {
+		return fmt.Errorf("The pod PIDs limit must be between %d and %d, got %d",
+			MinPodPidsLimit, MaxPodPidsLimit, limit)
+	}
+	return nil
+}
+
+// classicKubeletConfigPath is the path of the single kubelet configuration of classic clusters.
+func classicKubeletConfigPath(clusterID string) string {
+	return fmt.Sprintf("%s/clusters/%s/kubelet_config", clustersMgmtPath, clusterID)
+}
+
+// kubeletConfigsPath is the path of the named kubelet configurations of hosted clusters.
+func kubeletConfigsPath(clusterID string) string {
+	return fmt.Sprintf("%s/clusters/%s/kubelet_configs", clustersMgmtPath, clusterID)
+}
+
+// GetKubeletConfigs returns the kubelet configurations of the cluster.
+func (c *Client) GetKubeletConfigs(cluster *cmv1.Cluster) ([]*KubeletConfig, error) {
+	if !cluster.Hypershift().Enabled() {
+		config := new(KubeletConfig)
+		err := sendRaw(c.ocm.Get().Path(classicKubeletConfigPath(cluster.ID())), nil, config)
+		if isNotFound(err) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		return []*KubeletConfig{config}, nil
+	}
+	var list struct {
+		Items []*KubeletConfig `json:"items"`
+	}
+	err := sendRaw(c.ocm.Get().Path(kubeletConfigsPath(cluster.ID())).Parameter("size", -1), nil, &list)
+	if err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+// FindKubeletConfig returns the kubelet configuration of the cluster with the given name, or nil if
+// it doesn't exist. The name is ignored for classic clusters, that have at most one configuration.
+func (c *Client) FindKubeletConfig(cluster *cmv1.Cluster, name string) (*KubeletConfig, error) {
+	configs, err := c.GetKubeletConfigs(cluster)
+	if err != nil {
+		return nil, err
+	}
+	for _, config := range configs {
+		if !cluster.Hypershift().Enabled() || config.Name == name {
+			return config, nil
+		}
+	}
+	return nil, nil
+}
+
+// CreateKubeletConfig creates the given kubelet configuration for the cluster.
+func (c *Client) CreateKubeletConfig(cluster *cmv1.Cluster, config *KubeletConfig) (*KubeletConfig, error) {
+	path := kubeletConfigsPath(cluster.ID())
+	if !cluster.Hypershift().Enabled() {
+		path = classicKubeletConfigPath(cluster.ID())
+	}
+	created := new(KubeletConfig)
+	err := sendRaw(c.ocm.Post().Path(path), config, created)
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// UpdateKubeletConfig updates the given existing kubelet configuration of the cluster.
+func (c *Client) UpdateKubeletConfig(cluster *cmv1.Cluster, config *KubeletConfig) (*KubeletConfig, error) {
+	path := fmt.Sprintf("%s/%s", kubeletConfigsPath(cluster.ID()), config.ID)
+	if !cluster.Hypershift().Enabled() {
+		path = classicKubeletConfigPath(cluster.ID())
+	}
+	updated := new(KubeletConfig)
+	err := sendRaw(c.ocm.Patch().Path(path), &KubeletConfig{PodPidsLimit: config.PodPidsLimit}, updated)
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// DeleteKubeletConfig deletes the given existing kubelet configuration of the cluster.
+func (c *Client) DeleteKubeletConfig(cluster *cmv1.Cluster, config *KubeletConfig) error {
+	path := fmt.Sprintf("%s/%s", kubeletConfigsPath(cluster.ID()), config.ID)
+	if !cluster.Hypershift().Enabled() {
+		path = classicKubeletConfigPath(cluster.ID())
+	}
+	return sendRaw(c.ocm.Delete().Path(path), nil, nil)
+}
+
+// ValidateKubeletConfigNames checks that the kubelet configurations referenced by a machine pool of
+// a hosted cluster exist.
+func ValidateKubeletConfigNames(configs []*KubeletConfig, names []string) error {
+	for _, name := range names {
+		found := false
+		for _, config := range configs {
+			if config.Name == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("Kubelet config '%s' doesn't exist, create it with "+
+				"'rosa create kubeletconfig --name %s'", name, name)
+		}
+	}
+	return nil
+}
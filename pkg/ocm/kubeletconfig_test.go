@@ -0,0 +1,126 @@
+package ocm
+
+import (
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/openshift-online/ocm-sdk-go/logging"
+	. "github.com/openshift-online/ocm-sdk-go/testing"
+)
+
+var _ = Describe("Kubelet configs", func() {
+	DescribeTable("ValidatePodPidsLimit",
+		func(limit int, valid bool) {
+			err := ValidatePodPidsLimit(limit)
+			if valid {
+				Expect(err).ToNot(HaveOccurred())
+			} else {
+				Expect(err).To(HaveOccurred())
+			}
+		},
+		Entry("minimum", MinPodPidsLimit, true),
+		Entry("maximum", MaxPodPidsLimit, true),
+		Entry("too low", MinPodPidsLimit-1, false),
+		Entry("too high", MaxPodPidsLimit+1, false),
+	)
+
+	It("Rejects machine pool kubelet configs that don't exist", func() {
+		configs := []*KubeletConfig{{Name: "high-pids"}, {Name: "low-pids"}}
+		Expect(ValidateKubeletConfigNames(configs, []string{"high-pids", "low-pids"})).To(Succeed())
+		Expect(ValidateKubeletConfigNames(configs, nil)).To(Succeed())
+		err := ValidateKubeletConfigNames(configs, []string{"high-pids", "other"})
+		Expect(err).To(MatchError(ContainSubstring("'other' doesn't exist")))
+	})
+
+	Context("API", func() {
+		var apiServer *ghttp.Server
+		var ocmClient *Client
+
+		BeforeEach(func() {
+			apiServer = MakeTCPServer()
+			accessToken := MakeTokenString("Bearer", 15*time.Minute)
+			logger, err := logging.NewGoLoggerBuilder().Build()
+			Expect(err).To(BeNil())
+			connection, err := sdk.NewConnectionBuilder().
+				Logger(logger).
+				Tokens(accessToken).
+				URL(apiServer.URL()).
+				Build()
+			Expect(err).To(BeNil())
+			ocmClient = &Client{ocm: connection}
+		})
+
+		AfterEach(func() {
+			apiServer.Close()
+			Expect(ocmClient.Close()).To(Succeed())
+		})
+
+		buildCluster := func(hostedCP bool) *cmv1.Cluster {
+			cluster, err := cmv1.NewCluster().ID("123").
+				Hypershift(cmv1.NewHypershift().Enabled(hostedCP)).
+				Build()
+			Expect(err).ToNot(HaveOccurred())
+			return cluster
+		}
+
+		It("Uses the single kubelet config of classic clusters", func() {
+			apiServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/kubelet_config"),
+					RespondWithJSON(http.StatusOK, `{"kind": "KubeletConfig", "pod_pids_limit": 10000}`),
+				),
+			)
+			config, err := ocmClient.FindKubeletConfig(buildCluster(false), "")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(config).ToNot(BeNil())
+			Expect(config.PodPidsLimit).To(Equal(10000))
+		})
+
+		It("Returns no kubelet config when classic clusters don't have one", func() {
+			apiServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/kubelet_config"),
+					RespondWithJSON(http.StatusNotFound, `{"kind": "Error", "reason": "not found"}`),
+				),
+			)
+			configs, err := ocmClient.GetKubeletConfigs(buildCluster(false))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(configs).To(BeEmpty())
+		})
+
+		It("Finds the named kubelet configs of hosted clusters", func() {
+			apiServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/kubelet_configs"),
+					RespondWithJSON(http.StatusOK, `{
+						"items": [
+							{"id": "a", "name": "low-pids", "pod_pids_limit": 4096},
+							{"id": "b", "name": "high-pids", "pod_pids_limit": 16384}
+						]
+					}`),
+				),
+			)
+			config, err := ocmClient.FindKubeletConfig(buildCluster(true), "high-pids")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(config).ToNot(BeNil())
+			Expect(config.ID).To(Equal("b"))
+			Expect(config.PodPidsLimit).To(Equal(16384))
+		})
+
+		It("Deletes the named kubelet config of hosted clusters", func() {
+			apiServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodDelete, "/api/clusters_mgmt/v1/clusters/123/kubelet_configs/b"),
+					RespondWithJSON(http.StatusNoContent, ""),
+				),
+			)
+			err := ocmClient.DeleteKubeletConfig(buildCluster(true), &KubeletConfig{ID: "b", Name: "high-pids"})
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+})
@@ -17,7 +17,13 @@ limitations under the License.
 package ocm
 
 import (
+	"encoding/json"
+	"fmt"
+	"io"
+
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/helper"
 )
 
 func (c *Client) GetMachinePools(clusterID string) ([]*cmv1.MachinePool, error) {
@@ -32,6 +38,50 @@ func (c *Client) GetMachinePools(clusterID string) ([]*cmv1.MachinePool, error)
 	return response.Items().Slice(), nil
 }
 
+// GetMachinePoolInstanceTypes returns the distinct instance types used by the machine pools of the
+// cluster.
+func (c *Client) GetMachinePoolInstanceTypes(clusterID string) ([]string, error) {
+	machinePools, err := c.GetMachinePools(clusterID)
+	if err != nil {
+		return nil, err
+	}
+	instanceTypes := []string{}
+	for _, machinePool := range machinePools {
+		if !helper.Contains(instanceTypes, machinePool.InstanceType()) {
+			instanceTypes = append(instanceTypes, machinePool.InstanceType())
+		}
+	}
+	return instanceTypes, nil
+}
+
+// GetMachinePoolDiskSizes returns the root volume size in GiB of each machine pool of the cluster,
+// indexed by machine pool identifier. The typed client of the SDK doesn't support the root volume
+// yet, so the list is read as raw JSON. Machine pools without an explicit size aren't included.
+func (c *Client) GetMachinePoolDiskSizes(clusterID string) (map[string]int, error) {
+	var list struct {
+		Items []struct {
+			ID         string `json:"id"`
+			RootVolume *struct {
+				AWS *struct {
+					Size int `json:"size"`
+				} `json:"aws"`
+			} `json:"root_volume"`
+		} `json:"items"`
+	}
+	path := fmt.Sprintf("%s/clusters/%s/machine_pools", clustersMgmtPath, clusterID)
+	err := sendRaw(c.ocm.Get().Path(path).Parameter("size", -1), nil, &list)
+	if err != nil {
+		return nil, err
+	}
+	sizes := map[string]int{}
+	for _, item := range list.Items {
+		if item.RootVolume != nil && item.RootVolume.AWS != nil && item.RootVolume.AWS.Size > 0 {
+			sizes[item.ID] = item.RootVolume.AWS.Size
+		}
+	}
+	return sizes, nil
+}
+
 func (c *Client) CreateMachinePool(clusterID string, machinePool *cmv1.MachinePool) (*cmv1.MachinePool, error) {
 	response, err := c.ocm.ClustersMgmt().V1().
 		Clusters().Cluster(clusterID).
@@ -43,6 +93,29 @@ func (c *Client) CreateMachinePool(clusterID string, machinePool *cmv1.MachinePo
 	}
 	return response.Body(), nil
 }
+
+// CreateMachinePoolWithFields creates the machine pool adding to the request the given fields, that
+// the typed client of the SDK doesn't support, indexed by their dotted path.
+func (c *Client) CreateMachinePoolWithFields(clusterID string, machinePool *cmv1.MachinePool,
+	fields map[string]interface{}) (*cmv1.MachinePool, error) {
+	if len(fields) == 0 {
+		return c.CreateMachinePool(clusterID, machinePool)
+	}
+	body, err := rawBody(func(writer io.Writer) error {
+		return cmv1.MarshalMachinePool(machinePool, writer)
+	}, fields)
+	if err != nil {
+		return nil, err
+	}
+	var created json.RawMessage
+	path := fmt.Sprintf("%s/clusters/%s/machine_pools", clustersMgmtPath, clusterID)
+	err = sendRaw(c.ocm.Post().Path(path), body, &created)
+	if err != nil {
+		return nil, err
+	}
+	return cmv1.UnmarshalMachinePool([]byte(created))
+}
+
 func (c *Client) UpdateMachinePool(clusterID string, machinePool *cmv1.MachinePool) (*cmv1.MachinePool, error) {
 	response, err := c.ocm.ClustersMgmt().V1().
 		Clusters().Cluster(clusterID).
@@ -66,3 +139,37 @@ func (c *Client) DeleteMachinePool(clusterID string, machinePoolID string) error
 	}
 	return nil
 }
+
+func (c *Client) GetMachinePool(clusterID string, machinePoolID string) (*cmv1.MachinePool, error) {
+	response, err := c.ocm.ClustersMgmt().V1().
+		Clusters().Cluster(clusterID).
+		MachinePools().
+		MachinePool(machinePoolID).
+		Get().
+		Send()
+	if err != nil {
+		return nil, handleErr(response.Error(), err)
+	}
+	return response.Body(), nil
+}
+
+// SecurityGroupFilters returns the filters that select the additional security groups, with the
+// given IDs, that are attached to the nodes.
+func SecurityGroupFilters(ids []string) []*cmv1.MachinePoolSecurityGroupFilterBuilder {
+	filters := make([]*cmv1.MachinePoolSecurityGroupFilterBuilder, len(ids))
+	for i, id := range ids {
+		filters[i] = cmv1.NewMachinePoolSecurityGroupFilter().Name("group-id").Value(id)
+	}
+	return filters
+}
+
+// GetSecurityGroupIDs returns the IDs of the additional security groups selected by the filters.
+func GetSecurityGroupIDs(filters []*cmv1.MachinePoolSecurityGroupFilter) []string {
+	ids := []string{}
+	for _, filter := range filters {
+		if filter.Name() == "group-id" {
+			ids = append(ids, filter.Value())
+		}
+	}
+	return ids
+}
@@ -17,7 +17,6 @@ limitations under the License.
 package ocm
 
 import (
-	"errors"
 	"fmt"
 	"strings"
 
@@ -62,38 +61,15 @@ func (c *Client) GetMachineTypesInRegion(cloudProviderData *cmv1.CloudProviderDa
 }
 
 func (c *Client) GetMachineTypes() (machineTypes MachineTypeList, err error) {
-	collection := c.ocm.ClustersMgmt().V1().MachineTypes()
-	page := 1
-	size := 100
-	for {
-		var response *cmv1.MachineTypesListResponse
-		response, err := collection.List().
-			Search("cloud_provider.id = 'aws'").
-			Order("category asc").
-			Page(page).
-			Size(size).
-			Send()
-		if err != nil {
-			errMsg := response.Error().Reason()
-			if errMsg == "" {
-				errMsg = err.Error()
-			}
-			return nil, errors.New(errMsg)
-		}
-
-		response.Items().Each(func(item *cmv1.MachineType) bool {
-			machineTypes = append(machineTypes, &MachineType{
-				MachineType: item,
-			})
-			return true
+	items, err := c.getCachedMachineTypes("cloud_provider.id = 'aws'", "category asc")
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		machineTypes = append(machineTypes, &MachineType{
+			MachineType: item,
 		})
-
-		if response.Size() < size {
-			break
-		}
-		page++
 	}
-
 	return
 }
 
@@ -115,6 +91,40 @@ func (mt MachineType) HasQuota(multiAZ bool) bool {
 	return mt.MachineType.Category() != AcceleratedComputing || mt.availableQuota > getDefaultNodes(multiAZ)
 }
 
+// CPUs returns the number of vCPUs of the machine type
+func (mt MachineType) CPUs() int {
+	return int(mt.MachineType.CPU().Value())
+}
+
+// ManagedNodes are the nodes of one machine type that Red Hat runs in the account of the customer
+type ManagedNodes struct {
+	Role        string
+	MachineType string
+	CPUs        int
+	Count       int
+}
+
+// GetClassicManagedNodes returns the control plane, infra and bootstrap nodes that the installation
+// of a classic cluster creates next to its compute nodes. Control plane and infra nodes are sized
+// by the service according to the number of compute nodes.
+func GetClassicManagedNodes(computeNodes int, multiAZ bool) []ManagedNodes {
+	controlPlane := ManagedNodes{Role: "control plane", MachineType: "m5.2xlarge", CPUs: 8, Count: 3}
+	infra := ManagedNodes{Role: "infra", MachineType: "r5.xlarge", CPUs: 4, Count: getDefaultNodes(multiAZ)}
+	switch {
+	case computeNodes > 180:
+		controlPlane.MachineType, controlPlane.CPUs = "m5.12xlarge", 48
+		infra.MachineType, infra.CPUs = "r5.4xlarge", 16
+	case computeNodes > 100:
+		controlPlane.MachineType, controlPlane.CPUs = "m5.8xlarge", 32
+		infra.MachineType, infra.CPUs = "r5.4xlarge", 16
+	case computeNodes > 25:
+		controlPlane.MachineType, controlPlane.CPUs = "m5.4xlarge", 16
+		infra.MachineType, infra.CPUs = "r5.2xlarge", 8
+	}
+	bootstrap := ManagedNodes{Role: "bootstrap", MachineType: "m5.large", CPUs: 2, Count: 1}
+	return []ManagedNodes{controlPlane, infra, bootstrap}
+}
+
 // GetAvailableMachineTypesInRegion get the supported machine type in the region.
 // The function triggers the 'api/clusters_mgmt/v1/aws_inquiries/machine_types'
 // and passes a role ARN for STS clusters or access keys for non-STS clusters.
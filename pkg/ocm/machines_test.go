@@ -0,0 +1,25 @@
+package ocm
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Classic managed nodes", func() {
+	DescribeTable("sizes the control plane and infra nodes by compute nodes",
+		func(computeNodes int, multiAZ bool, controlPlane string, infra string, infraCount int) {
+			nodes := GetClassicManagedNodes(computeNodes, multiAZ)
+			Expect(nodes).To(HaveLen(3))
+			Expect(nodes[0].MachineType).To(Equal(controlPlane))
+			Expect(nodes[0].Count).To(Equal(3))
+			Expect(nodes[1].MachineType).To(Equal(infra))
+			Expect(nodes[1].Count).To(Equal(infraCount))
+			Expect(nodes[2].Role).To(Equal("bootstrap"))
+		},
+		Entry("small single-AZ", 2, false, "m5.2xlarge", "r5.xlarge", 2),
+		Entry("small multi-AZ", 25, true, "m5.2xlarge", "r5.xlarge", 3),
+		Entry("medium", 26, true, "m5.4xlarge", "r5.2xlarge", 3),
+		Entry("large", 101, true, "m5.8xlarge", "r5.4xlarge", 3),
+		Entry("very large", 181, true, "m5.12xlarge", "r5.4xlarge", 3),
+	)
+})
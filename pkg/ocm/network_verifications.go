@@ -0,0 +1,106 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ocm
+
+import (
+	"fmt"
+)
+
+const (
+	NetworkVerificationPending = "pending"
+	NetworkVerificationRunning = "running"
+	NetworkVerificationPassed  = "passed"
+	NetworkVerificationFailed  = "failed"
+)
+
+// NetworkVerification is a request to verify that the subnets given directly, or those of a
+// cluster, can reach the endpoints needed by the cluster. The typed client of the SDK doesn't
+// support it yet, so it is sent as raw JSON.
+type NetworkVerification struct {
+	ClusterID         string                                `json:"cluster_id,omitempty"`
+	CloudProviderData *NetworkVerificationCloudProviderData `json:"cloud_provider_data,omitempty"`
+	Items             []*SubnetNetworkVerification          `json:"items,omitempty"`
+}
+
+type NetworkVerificationCloudProviderData struct {
+	AWS       *NetworkVerificationAWS `json:"aws,omitempty"`
+	Region    *NetworkVerificationID  `json:"region,omitempty"`
+	SubnetIDs []string                `json:"subnet_ids"`
+}
+
+type NetworkVerificationAWS struct {
+	STS *NetworkVerificationSTS `json:"sts,omitempty"`
+}
+
+type NetworkVerificationSTS struct {
+	RoleARN string `json:"role_arn"`
+}
+
+type NetworkVerificationID struct {
+	ID string `json:"id"`
+}
+
+// SubnetNetworkVerification is the state of the verification of a single subnet. The details
+// describe the endpoints that couldn't be reached.
+type SubnetNetworkVerification struct {
+	ID      string   `json:"id"`
+	State   string   `json:"state"`
+	Details []string `json:"details,omitempty"`
+}
+
+// VerifyNetworkSubnets starts the verification of the given subnets, using the given role to access
+// the AWS account.
+func (c *Client) VerifyNetworkSubnets(roleARN string, region string,
+	subnetIDs []string) ([]*SubnetNetworkVerification, error) {
+	return c.sendNetworkVerification(&NetworkVerification{
+		CloudProviderData: &NetworkVerificationCloudProviderData{
+			AWS: &NetworkVerificationAWS{
+				STS: &NetworkVerificationSTS{RoleARN: roleARN},
+			},
+			Region:    &NetworkVerificationID{ID: region},
+			SubnetIDs: subnetIDs,
+		},
+	})
+}
+
+// VerifyNetworkCluster starts the verification of the subnets of the given cluster.
+func (c *Client) VerifyNetworkCluster(clusterID string) ([]*SubnetNetworkVerification, error) {
+	return c.sendNetworkVerification(&NetworkVerification{
+		ClusterID: clusterID,
+	})
+}
+
+func (c *Client) sendNetworkVerification(verification *NetworkVerification) ([]*SubnetNetworkVerification,
+	error) {
+	response := new(NetworkVerification)
+	err := sendRaw(c.ocm.Post().Path(clustersMgmtPath+"/network_verifications"), verification, response)
+	if err != nil {
+		return nil, err
+	}
+	return response.Items, nil
+}
+
+// GetVerifyNetworkSubnet returns the state of the latest verification of the given subnet.
+func (c *Client) GetVerifyNetworkSubnet(subnetID string) (*SubnetNetworkVerification, error) {
+	verification := new(SubnetNetworkVerification)
+	err := sendRaw(c.ocm.Get().Path(fmt.Sprintf("%s/network_verifications/%s", clustersMgmtPath, subnetID)),
+		nil, verification)
+	if err != nil {
+		return nil, err
+	}
+	return verification, nil
+}
@@ -0,0 +1,145 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ocm
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/openshift/rosa/pkg/helper"
+)
+
+// NodeDrainGracePeriodOptions are the grace periods accepted by the API for evicting workloads
+// protected by pod disruption budgets.
+var NodeDrainGracePeriodOptions = []string{
+	"15 minutes",
+	"30 minutes",
+	"45 minutes",
+	"1 hour",
+	"2 hours",
+	"4 hours",
+	"8 hours",
+}
+
+// ParseNodeDrainGracePeriod returns the number of minutes of one of the node drain grace period
+// options.
+func ParseNodeDrainGracePeriod(value string) (float64, error) {
+	if !helper.Contains(NodeDrainGracePeriodOptions, value) {
+		return 0, fmt.Errorf("Expected a valid node drain grace period. Options are [%s]",
+			strings.Join(NodeDrainGracePeriodOptions, ", "))
+	}
+	parsed := strings.Split(value, " ")
+	minutes, err := strconv.ParseFloat(parsed[0], 64)
+	if err != nil {
+		return 0, fmt.Errorf("Expected a valid node drain grace period: %s", err)
+	}
+	if parsed[1] == "hours" || parsed[1] == "hour" {
+		minutes = minutes * 60
+	}
+	return minutes, nil
+}
+
+// FormatNodeDrainGracePeriod returns the node drain grace period of the cluster in the format of
+// the options, or an empty string if it isn't set.
+func FormatNodeDrainGracePeriod(cluster *cmv1.Cluster) string {
+	nd := cluster.NodeDrainGracePeriod()
+	if _, ok := nd.GetValue(); !ok {
+		return ""
+	}
+	// Convert larger times to hours, since the API only stores minutes
+	val := int(nd.Value())
+	unit := nd.Unit()
+	if val >= 60 {
+		val = val / 60
+		if val == 1 {
+			unit = "hour"
+		} else {
+			unit = "hours"
+		}
+	}
+	return fmt.Sprintf("%d %s", val, unit)
+}
+
+// MaxNodePoolNodeDrainGracePeriod is the longest node drain grace period, in minutes, accepted by the
+// API for the node pools of hosted clusters: one week.
+const MaxNodePoolNodeDrainGracePeriod = 10080
+
+// ParseNodePoolNodeDrainGracePeriod returns the number of minutes of the node drain grace period of a
+// node pool. The value is a number followed by an optional unit, minutes when omitted, for example
+// '30', '30 minutes', '30m', '1 hour' or '2h'. Zero means that workloads protected by pod disruption
+// budgets are never forcibly evicted.
+func ParseNodePoolNodeDrainGracePeriod(value string) (int, error) {
+	value = strings.TrimSpace(value)
+	digits := strings.IndexFunc(value, func(r rune) bool {
+		return r < '0' || r > '9'
+	})
+	if digits == -1 {
+		digits = len(value)
+	}
+	number := value[:digits]
+	unit := strings.TrimSpace(value[digits:])
+	amount, err := strconv.Atoi(number)
+	if err != nil {
+		return 0, fmt.Errorf("Expected a valid node drain grace period, for example '30 minutes' or '2 hours', "+
+			"got '%s'", value)
+	}
+	minutes := amount
+	switch unit {
+	case "", "m", "min", "minute", "minutes":
+	case "h", "hour", "hours":
+		minutes = amount * 60
+	default:
+		return 0, fmt.Errorf("Expected the unit of the node drain grace period to be minutes or hours, got '%s'",
+			unit)
+	}
+	if minutes > MaxNodePoolNodeDrainGracePeriod {
+		return 0, fmt.Errorf("Expected the node drain grace period to be at most %d minutes (1 week), got %d "+
+			"minutes", MaxNodePoolNodeDrainGracePeriod, minutes)
+	}
+	return minutes, nil
+}
+
+// NodePoolNodeDrainGracePeriodValidator validates the node drain grace period of a node pool entered
+// in interactive mode. Empty values are accepted and leave the grace period unset.
+func NodePoolNodeDrainGracePeriodValidator(input interface{}) error {
+	str, ok := input.(string)
+	if !ok {
+		return fmt.Errorf("can only validate strings, got %v", input)
+	}
+	if str == "" {
+		return nil
+	}
+	_, err := ParseNodePoolNodeDrainGracePeriod(str)
+	return err
+}
+
+// FormatNodePoolNodeDrainGracePeriod returns the given number of minutes in the format accepted by
+// ParseNodePoolNodeDrainGracePeriod, using hours when possible.
+func FormatNodePoolNodeDrainGracePeriod(minutes int) string {
+	switch {
+	case minutes == 60:
+		return "1 hour"
+	case minutes > 0 && minutes%60 == 0:
+		return fmt.Sprintf("%d hours", minutes/60)
+	case minutes == 1:
+		return "1 minute"
+	default:
+		return fmt.Sprintf("%d minutes", minutes)
+	}
+}
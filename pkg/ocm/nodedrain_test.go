@@ -0,0 +1,79 @@
+package ocm
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+var _ = Describe("Node drain grace period", func() {
+	DescribeTable("ParseNodeDrainGracePeriod",
+		func(value string, minutes float64, expectedError string) {
+			parsed, err := ParseNodeDrainGracePeriod(value)
+			if expectedError == "" {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(parsed).To(Equal(minutes))
+			} else {
+				Expect(err).To(MatchError(ContainSubstring(expectedError)))
+			}
+		},
+		Entry("minutes", "45 minutes", 45.0, ""),
+		Entry("one hour", "1 hour", 60.0, ""),
+		Entry("hours", "8 hours", 480.0, ""),
+		Entry("not an option", "3 hours", 0.0, "Options are"),
+		Entry("invalid", "forever", 0.0, "Options are"),
+	)
+
+	DescribeTable("FormatNodeDrainGracePeriod",
+		func(value *cmv1.ValueBuilder, expected string) {
+			builder := cmv1.NewCluster()
+			if value != nil {
+				builder = builder.NodeDrainGracePeriod(value)
+			}
+			cluster, err := builder.Build()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(FormatNodeDrainGracePeriod(cluster)).To(Equal(expected))
+		},
+		Entry("not set", nil, ""),
+		Entry("minutes", cmv1.NewValue().Value(30).Unit("minutes"), "30 minutes"),
+		Entry("one hour", cmv1.NewValue().Value(60).Unit("minutes"), "1 hour"),
+		Entry("hours", cmv1.NewValue().Value(240).Unit("minutes"), "4 hours"),
+	)
+
+	DescribeTable("ParseNodePoolNodeDrainGracePeriod",
+		func(value string, minutes int, expectedError string) {
+			parsed, err := ParseNodePoolNodeDrainGracePeriod(value)
+			if expectedError == "" {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(parsed).To(Equal(minutes))
+			} else {
+				Expect(err).To(MatchError(ContainSubstring(expectedError)))
+			}
+		},
+		Entry("zero", "0", 0, ""),
+		Entry("minutes without unit", "45", 45, ""),
+		Entry("minutes", "45 minutes", 45, ""),
+		Entry("short minutes", "45m", 45, ""),
+		Entry("one hour", "1 hour", 60, ""),
+		Entry("short hours", "3h", 180, ""),
+		Entry("one week", "168 hours", 10080, ""),
+		Entry("more than one week", "10081", 0, "at most 10080 minutes"),
+		Entry("days", "2 days", 0, "minutes or hours, got 'days'"),
+		Entry("negative", "-5 minutes", 0, "Expected a valid node drain grace period"),
+		Entry("no number", "forever", 0, "Expected a valid node drain grace period"),
+	)
+
+	DescribeTable("FormatNodePoolNodeDrainGracePeriod",
+		func(minutes int, expected string) {
+			Expect(FormatNodePoolNodeDrainGracePeriod(minutes)).To(Equal(expected))
+			parsed, err := ParseNodePoolNodeDrainGracePeriod(expected)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(parsed).To(Equal(minutes))
+		},
+		Entry("zero", 0, "0 minutes"),
+		Entry("one minute", 1, "1 minute"),
+		Entry("minutes", 90, "90 minutes"),
+		Entry("one hour", 60, "1 hour"),
+		Entry("hours", 120, "2 hours"),
+	)
+})
@@ -1,6 +1,13 @@
 package ocm
 
-import cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
 
 func (c *Client) CreateNodePool(clusterID string, nodePool *cmv1.NodePool) (*cmv1.NodePool, error) {
 	response, err := c.ocm.ClustersMgmt().V1().
@@ -14,6 +21,28 @@ func (c *Client) CreateNodePool(clusterID string, nodePool *cmv1.NodePool) (*cmv
 	return response.Body(), nil
 }
 
+// CreateNodePoolWithFields creates the node pool adding to the request the given fields, that the
+// typed client of the SDK doesn't support, indexed by their dotted path.
+func (c *Client) CreateNodePoolWithFields(clusterID string, nodePool *cmv1.NodePool,
+	fields map[string]interface{}) (*cmv1.NodePool, error) {
+	if len(fields) == 0 {
+		return c.CreateNodePool(clusterID, nodePool)
+	}
+	body, err := rawBody(func(writer io.Writer) error {
+		return cmv1.MarshalNodePool(nodePool, writer)
+	}, fields)
+	if err != nil {
+		return nil, err
+	}
+	var created json.RawMessage
+	path := fmt.Sprintf("%s/clusters/%s/node_pools", clustersMgmtPath, clusterID)
+	err = sendRaw(c.ocm.Post().Path(path), body, &created)
+	if err != nil {
+		return nil, err
+	}
+	return cmv1.UnmarshalNodePool([]byte(created))
+}
+
 func (c *Client) GetNodePools(clusterID string) ([]*cmv1.NodePool, error) {
 	response, err := c.ocm.ClustersMgmt().V1().
 		Clusters().Cluster(clusterID).
@@ -51,6 +80,27 @@ func (c *Client) UpdateNodePool(clusterID string, nodePool *cmv1.NodePool) (*cmv
 	return response.Body(), nil
 }
 
+// UpdateNodePoolWithFields updates the node pool adding to the request the given fields, that the
+// typed client of the SDK doesn't support, indexed by their dotted path.
+func (c *Client) UpdateNodePoolWithFields(clusterID string, nodePool *cmv1.NodePool,
+	fields map[string]interface{}) (*cmv1.NodePool, error) {
+	if len(fields) == 0 {
+		return c.UpdateNodePool(clusterID, nodePool)
+	}
+	body, err := rawBody(func(writer io.Writer) error {
+		return cmv1.MarshalNodePool(nodePool, writer)
+	}, fields)
+	if err != nil {
+		return nil, err
+	}
+	var updated json.RawMessage
+	err = sendRaw(c.ocm.Patch().Path(nodePoolPath(clusterID, nodePool.ID())), body, &updated)
+	if err != nil {
+		return nil, err
+	}
+	return cmv1.UnmarshalNodePool([]byte(updated))
+}
+
 func (c *Client) DeleteNodePool(clusterID string, nodePoolID string) error {
 	response, err := c.ocm.ClustersMgmt().V1().
 		Clusters().Cluster(clusterID).
@@ -62,3 +112,187 @@ func (c *Client) DeleteNodePool(clusterID string, nodePoolID string) error {
 	}
 	return nil
 }
+
+// NodePoolSettings contains the settings of a node pool that the typed client of the SDK doesn't
+// support yet, so they are read and written as raw JSON.
+type NodePoolSettings struct {
+	// DiskSize is the root volume size in GiB, zero when the node pool uses the default size.
+	DiskSize int
+	// KubeletConfigs are the names of the kubelet configs used by the nodes of the node pool.
+	KubeletConfigs []string
+	// TuningConfigs are the names of the tuning configs applied to the nodes of the node pool.
+	TuningConfigs []string
+	// NodeDrainGracePeriod is the number of minutes during which pod disruption budgets are respected
+	// when draining the nodes during upgrades, nil when it isn't set.
+	NodeDrainGracePeriod *int
+}
+
+type rawNodePool struct {
+	ID             string   `json:"id"`
+	KubeletConfigs []string `json:"kubelet_configs"`
+	TuningConfigs  []string `json:"tuning_configs"`
+	NodeDrain      *struct {
+		Value float64 `json:"value"`
+		Unit  string  `json:"unit"`
+	} `json:"node_drain_grace_period"`
+	AWSNodePool *struct {
+		RootVolume *struct {
+			Size int `json:"size"`
+		} `json:"root_volume"`
+	} `json:"aws_node_pool"`
+}
+
+func (n *rawNodePool) settings() *NodePoolSettings {
+	settings := &NodePoolSettings{
+		KubeletConfigs: n.KubeletConfigs,
+		TuningConfigs:  n.TuningConfigs,
+	}
+	if n.AWSNodePool != nil && n.AWSNodePool.RootVolume != nil {
+		settings.DiskSize = n.AWSNodePool.RootVolume.Size
+	}
+	if n.NodeDrain != nil {
+		minutes := int(n.NodeDrain.Value)
+		if n.NodeDrain.Unit == "hours" || n.NodeDrain.Unit == "hour" {
+			minutes *= 60
+		}
+		settings.NodeDrainGracePeriod = &minutes
+	}
+	return settings
+}
+
+func nodePoolPath(clusterID string, nodePoolID string) string {
+	return fmt.Sprintf("%s/clusters/%s/node_pools/%s", clustersMgmtPath, clusterID, nodePoolID)
+}
+
+// GetNodePoolsSettings returns the raw settings of each node pool of the hosted cluster, indexed by
+// node pool identifier.
+func (c *Client) GetNodePoolsSettings(clusterID string) (map[string]*NodePoolSettings, error) {
+	var list struct {
+		Items []*rawNodePool `json:"items"`
+	}
+	path := fmt.Sprintf("%s/clusters/%s/node_pools", clustersMgmtPath, clusterID)
+	err := sendRaw(c.ocm.Get().Path(path).Parameter("size", -1), nil, &list)
+	if err != nil {
+		return nil, err
+	}
+	settings := map[string]*NodePoolSettings{}
+	for _, item := range list.Items {
+		settings[item.ID] = item.settings()
+	}
+	return settings, nil
+}
+
+// GetNodePoolSettings returns the raw settings of the given node pool.
+func (c *Client) GetNodePoolSettings(clusterID string, nodePoolID string) (*NodePoolSettings, error) {
+	nodePool := new(rawNodePool)
+	err := sendRaw(c.ocm.Get().Path(nodePoolPath(clusterID, nodePoolID)), nil, nodePool)
+	if err != nil {
+		return nil, err
+	}
+	return nodePool.settings(), nil
+}
+
+// NodePoolUpgradePolicy is an upgrade of a hosted machine pool. The typed client of the SDK doesn't
+// support it yet, so it is sent as raw JSON.
+type NodePoolUpgradePolicy struct {
+	Kind         string                      `json:"kind,omitempty"`
+	ID           string                      `json:"id,omitempty"`
+	HREF         string                      `json:"href,omitempty"`
+	NodePoolID   string                      `json:"node_pool_id,omitempty"`
+	ScheduleType string                      `json:"schedule_type"`
+	UpgradeType  string                      `json:"upgrade_type"`
+	Version      string                      `json:"version"`
+	NextRun      time.Time                   `json:"next_run"`
+	State        *NodePoolUpgradePolicyState `json:"state,omitempty"`
+}
+
+type NodePoolUpgradePolicyState struct {
+	Value       string `json:"value"`
+	Description string `json:"description,omitempty"`
+}
+
+func nodePoolUpgradePoliciesPath(clusterID string, nodePoolID string) string {
+	return fmt.Sprintf("%s/clusters/%s/node_pools/%s/upgrade_policies", clustersMgmtPath, clusterID, nodePoolID)
+}
+
+func (c *Client) GetNodePoolUpgradePolicies(clusterID string, nodePoolID string) ([]*NodePoolUpgradePolicy, error) {
+	var list struct {
+		Items []*NodePoolUpgradePolicy `json:"items"`
+	}
+	err := sendRaw(c.ocm.Get().Path(nodePoolUpgradePoliciesPath(clusterID, nodePoolID)), nil, &list)
+	if err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+func (c *Client) ScheduleNodePoolUpgrade(clusterID string, nodePoolID string,
+	upgradePolicy *NodePoolUpgradePolicy) (*NodePoolUpgradePolicy, error) {
+	created := new(NodePoolUpgradePolicy)
+	err := sendRaw(c.ocm.Post().Path(nodePoolUpgradePoliciesPath(clusterID, nodePoolID)), upgradePolicy, created)
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// AutomaticUpgradeScheduleType is the schedule type of the upgrade policy that makes a node pool
+// automatically follow the z-stream upgrades of the control plane.
+const AutomaticUpgradeScheduleType = "automatic"
+
+// findAutomaticUpgradePolicy returns the automatic upgrade policy among the given ones, or nil if
+// there is none.
+func findAutomaticUpgradePolicy(upgradePolicies []*NodePoolUpgradePolicy) *NodePoolUpgradePolicy {
+	for _, upgradePolicy := range upgradePolicies {
+		if upgradePolicy.ScheduleType == AutomaticUpgradeScheduleType {
+			return upgradePolicy
+		}
+	}
+	return nil
+}
+
+// GetNodePoolAutoUpgrade checks if the node pool has an automatic upgrade policy.
+func (c *Client) GetNodePoolAutoUpgrade(clusterID string, nodePoolID string) (bool, error) {
+	upgradePolicies, err := c.GetNodePoolUpgradePolicies(clusterID, nodePoolID)
+	if err != nil {
+		return false, err
+	}
+	return findAutomaticUpgradePolicy(upgradePolicies) != nil, nil
+}
+
+// GetNodePoolsAutoUpgrade checks which of the given node pools have an automatic upgrade policy,
+// indexed by node pool identifier.
+func (c *Client) GetNodePoolsAutoUpgrade(clusterID string, nodePoolIDs []string) (map[string]bool, error) {
+	autoUpgrade := map[string]bool{}
+	for _, nodePoolID := range nodePoolIDs {
+		enabled, err := c.GetNodePoolAutoUpgrade(clusterID, nodePoolID)
+		if err != nil {
+			return nil, err
+		}
+		autoUpgrade[nodePoolID] = enabled
+	}
+	return autoUpgrade, nil
+}
+
+// UpdateNodePoolAutoUpgrade enables or disables the automatic z-stream upgrades of the node pool,
+// creating or deleting its automatic upgrade policy. Nothing is sent if it is already as requested.
+func (c *Client) UpdateNodePoolAutoUpgrade(clusterID string, nodePoolID string, enabled bool) error {
+	upgradePolicies, err := c.GetNodePoolUpgradePolicies(clusterID, nodePoolID)
+	if err != nil {
+		return err
+	}
+	automatic := findAutomaticUpgradePolicy(upgradePolicies)
+	if enabled == (automatic != nil) {
+		return nil
+	}
+	path := nodePoolUpgradePoliciesPath(clusterID, nodePoolID)
+	if !enabled {
+		return sendRaw(c.ocm.Delete().Path(path+"/"+automatic.ID), nil, nil)
+	}
+	body := map[string]interface{}{
+		"kind":          "NodePoolUpgradePolicy",
+		"schedule_type": AutomaticUpgradeScheduleType,
+		"upgrade_type":  "NodePool",
+	}
+	return sendRaw(c.ocm.Post().Path(path), body, nil)
+}
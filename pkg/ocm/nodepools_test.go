@@ -0,0 +1,117 @@
+package ocm
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	"github.com/openshift-online/ocm-sdk-go/logging"
+	. "github.com/openshift-online/ocm-sdk-go/testing"
+)
+
+var _ = Describe("Node pool settings", func() {
+	parse := func(data string) *NodePoolSettings {
+		nodePool := new(rawNodePool)
+		Expect(json.Unmarshal([]byte(data), nodePool)).To(Succeed())
+		return nodePool.settings()
+	}
+
+	It("Reads the disk size", func() {
+		settings := parse(`{"id":"np-1","aws_node_pool":{"root_volume":{"size":200}}}`)
+		Expect(settings.DiskSize).To(Equal(200))
+	})
+
+	It("Defaults to the default disk size", func() {
+		settings := parse(`{"id":"np-1","aws_node_pool":{"instance_type":"m5.xlarge"}}`)
+		Expect(settings.DiskSize).To(BeZero())
+	})
+})
+
+var _ = Describe("Node pool automatic upgrades", func() {
+	const policiesPath = "/api/clusters_mgmt/v1/clusters/123/node_pools/np-1/upgrade_policies"
+
+	var apiServer *ghttp.Server
+	var ocmClient *Client
+
+	BeforeEach(func() {
+		apiServer = MakeTCPServer()
+		accessToken := MakeTokenString("Bearer", 15*time.Minute)
+		logger, err := logging.NewGoLoggerBuilder().Build()
+		Expect(err).To(BeNil())
+		connection, err := sdk.NewConnectionBuilder().
+			Logger(logger).
+			Tokens(accessToken).
+			URL(apiServer.URL()).
+			Build()
+		Expect(err).To(BeNil())
+		ocmClient = &Client{ocm: connection}
+	})
+
+	AfterEach(func() {
+		apiServer.Close()
+		Expect(ocmClient.Close()).To(Succeed())
+	})
+
+	manualPolicy := `{"id":"manual-1","schedule_type":"manual","upgrade_type":"NodePool","version":"4.14.1"}`
+	automaticPolicy := `{"id":"auto-1","schedule_type":"automatic","upgrade_type":"NodePool"}`
+
+	It("Checks if the node pool has an automatic upgrade policy", func() {
+		apiServer.AppendHandlers(
+			RespondWithJSON(http.StatusOK, `{"items":[`+manualPolicy+`,`+automaticPolicy+`]}`),
+			RespondWithJSON(http.StatusOK, `{"items":[`+manualPolicy+`]}`),
+		)
+		Expect(ocmClient.GetNodePoolAutoUpgrade("123", "np-1")).To(BeTrue())
+		Expect(ocmClient.GetNodePoolAutoUpgrade("123", "np-1")).To(BeFalse())
+	})
+
+	It("Creates an automatic upgrade policy to enable automatic upgrades", func() {
+		var sent map[string]interface{}
+		apiServer.AppendHandlers(
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest(http.MethodGet, policiesPath),
+				RespondWithJSON(http.StatusOK, `{"items":[`+manualPolicy+`]}`),
+			),
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest(http.MethodPost, policiesPath),
+				func(w http.ResponseWriter, req *http.Request) {
+					data, err := io.ReadAll(req.Body)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(json.Unmarshal(data, &sent)).To(Succeed())
+				},
+				RespondWithJSON(http.StatusCreated, automaticPolicy),
+			),
+		)
+		Expect(ocmClient.UpdateNodePoolAutoUpgrade("123", "np-1", true)).To(Succeed())
+		Expect(sent).To(Equal(map[string]interface{}{
+			"kind":          "NodePoolUpgradePolicy",
+			"schedule_type": "automatic",
+			"upgrade_type":  "NodePool",
+		}))
+	})
+
+	It("Deletes the automatic upgrade policy to disable automatic upgrades", func() {
+		apiServer.AppendHandlers(
+			RespondWithJSON(http.StatusOK, `{"items":[`+automaticPolicy+`]}`),
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest(http.MethodDelete, policiesPath+"/auto-1"),
+				RespondWithJSON(http.StatusNoContent, ""),
+			),
+		)
+		Expect(ocmClient.UpdateNodePoolAutoUpgrade("123", "np-1", false)).To(Succeed())
+	})
+
+	It("Doesn't change anything when automatic upgrades are already as requested", func() {
+		apiServer.AppendHandlers(
+			RespondWithJSON(http.StatusOK, `{"items":[`+automaticPolicy+`]}`),
+			RespondWithJSON(http.StatusOK, `{"items":[`+manualPolicy+`]}`),
+		)
+		Expect(ocmClient.UpdateNodePoolAutoUpgrade("123", "np-1", true)).To(Succeed())
+		Expect(ocmClient.UpdateNodePoolAutoUpgrade("123", "np-1", false)).To(Succeed())
+		Expect(apiServer.ReceivedRequests()).To(HaveLen(2))
+	})
+})
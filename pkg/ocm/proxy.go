@@ -0,0 +1,141 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the validations of the cluster-wide proxy settings that are done before
+// sending them to OCM, as a wrong proxy is only detected once the cluster fails to install.
+
+package ocm
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"net/url"
+	"time"
+
+	errors "github.com/zgalor/weberr"
+)
+
+// proxyDialTimeout is the time to wait for a connection to the proxy before considering it
+// unreachable.
+const proxyDialTimeout = 5 * time.Second
+
+// ValidateHTTPSProxy checks that the HTTPS proxy is an URL with an http or https scheme.
+func ValidateHTTPSProxy(val interface{}) error {
+	httpsProxy, ok := val.(string)
+	if !ok {
+		return fmt.Errorf("can only validate strings, got %v", val)
+	}
+	if httpsProxy == "" {
+		return nil
+	}
+	proxyURL, err := url.ParseRequestURI(httpsProxy)
+	if err != nil || proxyURL.Host == "" {
+		return fmt.Errorf("Invalid https-proxy value '%s'", httpsProxy)
+	}
+	if proxyURL.Scheme != "http" && proxyURL.Scheme != "https" {
+		return errors.Errorf("%s", "Expected https-proxy to have an http:// or https:// scheme")
+	}
+	return nil
+}
+
+// ValidateProxyReachability checks that a connection can be opened to the host and port of the
+// proxy URL.
+func ValidateProxyReachability(proxy string) error {
+	proxyURL, err := url.ParseRequestURI(proxy)
+	if err != nil {
+		return fmt.Errorf("Invalid proxy value '%s'", proxy)
+	}
+	port := proxyURL.Port()
+	if port == "" {
+		port = "80"
+		if proxyURL.Scheme == "https" {
+			port = "443"
+		}
+	}
+	address := net.JoinHostPort(proxyURL.Hostname(), port)
+	connection, err := net.DialTimeout("tcp", address, proxyDialTimeout)
+	if err != nil {
+		return fmt.Errorf("Proxy '%s' isn't reachable: %v", proxy, err)
+	}
+	return connection.Close()
+}
+
+// ValidateTrustBundle checks that the trust bundle only contains PEM encoded X.509 certificates.
+// Certificates outside of their validity period aren't rejected, as bundles are often prepared
+// ahead of a rotation or still contain old certificates, use TrustBundleWarnings to report them.
+func ValidateTrustBundle(data []byte) error {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return errors.Errorf("%s", "Trust bundle file is empty")
+	}
+	rest := data
+	for index := 1; ; index++ {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			return fmt.Errorf("Expected only certificates in trust bundle, block %d is of type '%s'",
+				index, block.Type)
+		}
+		_, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return fmt.Errorf("Failed to parse certificate %d of trust bundle: %v", index, err)
+		}
+	}
+	if len(bytes.TrimSpace(rest)) > 0 {
+		if len(rest) == len(data) {
+			return errors.Errorf("%s", "Failed to parse additional trust bundle")
+		}
+		return errors.Errorf("%s", "Trust bundle contains data that isn't a PEM encoded certificate")
+	}
+	return nil
+}
+
+// TrustBundleWarnings returns a message for each certificate of the trust bundle that has expired
+// or that isn't valid yet. Blocks that can't be parsed are ignored, as they are reported by
+// ValidateTrustBundle.
+func TrustBundleWarnings(data []byte) []string {
+	var warnings []string
+	now := time.Now()
+	rest := data
+	for index := 1; ; index++ {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			continue
+		}
+		if now.After(certificate.NotAfter) {
+			warnings = append(warnings, fmt.Sprintf("Certificate %d of trust bundle ('%s') expired on %s",
+				index, certificate.Subject, certificate.NotAfter.Format(time.RFC3339)))
+		}
+		if now.Before(certificate.NotBefore) {
+			warnings = append(warnings, fmt.Sprintf("Certificate %d of trust bundle ('%s') isn't valid "+
+				"before %s", index, certificate.Subject, certificate.NotBefore.Format(time.RFC3339)))
+		}
+	}
+	return warnings
+}
@@ -0,0 +1,114 @@
+package ocm
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Proxy", func() {
+	Context("ValidateTrustBundle", func() {
+		certificate := func(notBefore time.Time, notAfter time.Time) []byte {
+			key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			Expect(err).ToNot(HaveOccurred())
+			template := &x509.Certificate{
+				SerialNumber: big.NewInt(1),
+				Subject:      pkix.Name{CommonName: "test-ca"},
+				NotBefore:    notBefore,
+				NotAfter:     notAfter,
+				IsCA:         true,
+			}
+			der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+			Expect(err).ToNot(HaveOccurred())
+			return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+		}
+		now := time.Now()
+
+		It("accepts bundles of valid certificates", func() {
+			bundle := append(certificate(now.Add(-time.Hour), now.Add(time.Hour)),
+				certificate(now.Add(-time.Hour), now.Add(time.Hour))...)
+			Expect(ValidateTrustBundle(bundle)).To(Succeed())
+		})
+
+		It("rejects empty bundles", func() {
+			Expect(ValidateTrustBundle([]byte("\n"))).To(MatchError("Trust bundle file is empty"))
+		})
+
+		It("rejects data that isn't PEM", func() {
+			Expect(ValidateTrustBundle([]byte("not a certificate"))).
+				To(MatchError("Failed to parse additional trust bundle"))
+		})
+
+		It("rejects trailing data after the certificates", func() {
+			bundle := append(certificate(now.Add(-time.Hour), now.Add(time.Hour)), []byte("garbage")...)
+			Expect(ValidateTrustBundle(bundle)).
+				To(MatchError("Trust bundle contains data that isn't a PEM encoded certificate"))
+		})
+
+		It("rejects blocks that aren't certificates", func() {
+			bundle := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("key")})
+			Expect(ValidateTrustBundle(bundle)).To(MatchError(ContainSubstring("block 1 is of type 'PRIVATE KEY'")))
+		})
+
+		It("accepts expired certificates and warns about them", func() {
+			bundle := append(certificate(now.Add(-time.Hour), now.Add(time.Hour)),
+				certificate(now.Add(-2*time.Hour), now.Add(-time.Hour))...)
+			Expect(ValidateTrustBundle(bundle)).To(Succeed())
+			warnings := TrustBundleWarnings(bundle)
+			Expect(warnings).To(HaveLen(1))
+			Expect(warnings[0]).To(HavePrefix("Certificate 2 of trust bundle ('CN=test-ca') expired on"))
+		})
+
+		It("warns about certificates that aren't valid yet", func() {
+			bundle := certificate(now.Add(time.Hour), now.Add(2*time.Hour))
+			Expect(ValidateTrustBundle(bundle)).To(Succeed())
+			Expect(TrustBundleWarnings(bundle)).To(ConsistOf(ContainSubstring("isn't valid before")))
+		})
+
+		It("doesn't warn about valid certificates", func() {
+			Expect(TrustBundleWarnings(certificate(now.Add(-time.Hour), now.Add(time.Hour)))).To(BeEmpty())
+		})
+	})
+
+	DescribeTable("ValidateHTTPSProxy",
+		func(value string, valid bool) {
+			err := ValidateHTTPSProxy(value)
+			if valid {
+				Expect(err).ToNot(HaveOccurred())
+			} else {
+				Expect(err).To(HaveOccurred())
+			}
+		},
+		Entry("empty", "", true),
+		Entry("http", "http://proxy.example.com:3128", true),
+		Entry("https", "https://proxy.example.com", true),
+		Entry("other scheme", "ftp://proxy.example.com", false),
+		Entry("not an URL", "proxy.example.com", false),
+	)
+
+	Context("ValidateProxyReachability", func() {
+		It("succeeds when the proxy accepts connections", func() {
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).ToNot(HaveOccurred())
+			defer listener.Close()
+			Expect(ValidateProxyReachability("http://" + listener.Addr().String())).To(Succeed())
+		})
+
+		It("fails when the proxy doesn't accept connections", func() {
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).ToNot(HaveOccurred())
+			address := listener.Addr().String()
+			listener.Close()
+			Expect(ValidateProxyReachability("http://" + address)).To(MatchError(ContainSubstring("isn't reachable")))
+		})
+	})
+})
@@ -0,0 +1,88 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ocm
+
+import (
+	amsv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/helper"
+)
+
+// Resource types of the quota that ROSA clusters consume.
+const (
+	QuotaResourceCluster     = "cluster"
+	QuotaResourceComputeNode = "compute.node"
+	QuotaResourceAddOn       = "add-on"
+)
+
+var quotaResourceTypes = []string{QuotaResourceCluster, QuotaResourceComputeNode, QuotaResourceAddOn}
+
+// QuotaSummary is the consumption of a quota of the organization by ROSA clusters.
+type QuotaSummary struct {
+	QuotaID      string `json:"quota_id"`
+	ResourceType string `json:"resource_type"`
+	Allowed      int    `json:"allowed"`
+	Consumed     int    `json:"consumed"`
+	Remaining    int    `json:"remaining"`
+}
+
+// GetROSAQuotaSummary returns the consumption of the cluster, compute node and add-on quotas of the
+// current organization that apply to ROSA clusters.
+func (c *Client) GetROSAQuotaSummary() ([]*QuotaSummary, error) {
+	acctResponse, err := c.ocm.AccountsMgmt().V1().CurrentAccount().
+		Get().
+		Send()
+	if err != nil {
+		return nil, handleErr(acctResponse.Error(), err)
+	}
+	organization := acctResponse.Body().Organization().ID()
+	quotaCostResponse, err := c.ocm.AccountsMgmt().V1().Organizations().
+		Organization(organization).
+		QuotaCost().
+		List().
+		Parameter("fetchRelatedResources", true).
+		Page(1).
+		Size(-1).
+		Send()
+	if err != nil {
+		return nil, handleErr(quotaCostResponse.Error(), err)
+	}
+	return summarizeQuotaCosts(quotaCostResponse.Items().Slice()), nil
+}
+
+func summarizeQuotaCosts(quotaCosts []*amsv1.QuotaCost) []*QuotaSummary {
+	var summaries []*QuotaSummary
+	for _, quotaCost := range quotaCosts {
+		if quotaCost.Allowed() == 0 && quotaCost.Consumed() == 0 {
+			continue
+		}
+		for _, relatedResource := range quotaCost.RelatedResources() {
+			if !isCompatible(relatedResource) || !helper.Contains(quotaResourceTypes, relatedResource.ResourceType()) {
+				continue
+			}
+			summaries = append(summaries, &QuotaSummary{
+				QuotaID:      quotaCost.QuotaID(),
+				ResourceType: relatedResource.ResourceType(),
+				Allowed:      quotaCost.Allowed(),
+				Consumed:     quotaCost.Consumed(),
+				Remaining:    quotaCost.Allowed() - quotaCost.Consumed(),
+			})
+			break
+		}
+	}
+	return summaries
+}
@@ -0,0 +1,43 @@
+package ocm
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	amsv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
+)
+
+var _ = Describe("Quota summary", func() {
+	quotaCost := func(id string, allowed, consumed int, product, resourceType string) *amsv1.QuotaCost {
+		quotaCost, err := amsv1.NewQuotaCost().
+			QuotaID(id).
+			Allowed(allowed).
+			Consumed(consumed).
+			RelatedResources(amsv1.NewRelatedResource().
+				Product(product).
+				CloudProvider("aws").
+				BYOC("byoc").
+				ResourceType(resourceType)).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		return quotaCost
+	}
+
+	It("keeps the ROSA cluster, compute node and add-on quotas", func() {
+		summaries := summarizeQuotaCosts([]*amsv1.QuotaCost{
+			quotaCost("cluster|byoc|moa|marketplace", 10, 3, "MOA", QuotaResourceCluster),
+			quotaCost("compute.node|cpu|byoc|moa", 100, 24, "ROSA", QuotaResourceComputeNode),
+			quotaCost("add-on|addon-cluster-logging", 5, 1, "any", QuotaResourceAddOn),
+			quotaCost("cluster|byoc|osd", 4, 1, "OSD", QuotaResourceCluster),
+			quotaCost("pull-secret", 1, 0, "any", "pull-secret"),
+			quotaCost("compute.node|gpu|byoc|moa", 0, 0, "ROSA", QuotaResourceComputeNode),
+		})
+		Expect(summaries).To(Equal([]*QuotaSummary{
+			{QuotaID: "cluster|byoc|moa|marketplace", ResourceType: "cluster", Allowed: 10, Consumed: 3,
+				Remaining: 7},
+			{QuotaID: "compute.node|cpu|byoc|moa", ResourceType: "compute.node", Allowed: 100, Consumed: 24,
+				Remaining: 76},
+			{QuotaID: "add-on|addon-cluster-logging", ResourceType: "add-on", Allowed: 5, Consumed: 1,
+				Remaining: 4},
+		}))
+	})
+})
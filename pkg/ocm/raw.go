@@ -0,0 +1,184 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains helpers to call endpoints of the API that aren't yet supported by the typed
+// clients of the SDK.
+
+package ocm
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	ocmerrors "github.com/openshift-online/ocm-sdk-go/errors"
+	errors "github.com/zgalor/weberr"
+)
+
+const clustersMgmtPath = "/api/clusters_mgmt/v1"
+
+// sendRaw sends the given request, with the JSON encoding of the body if it isn't nil, and decodes
+// the JSON response into the result if it isn't nil. Error responses are converted into errors the
+// same way as for the typed clients.
+func sendRaw(request *sdk.Request, body interface{}, result interface{}) error {
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		request = request.Header("Content-Type", "application/json").Bytes(data)
+	}
+	response, err := request.Send()
+	if err != nil {
+		return err
+	}
+	if response.Status() >= http.StatusBadRequest {
+		return responseError(response)
+	}
+	if result != nil && len(response.Bytes()) > 0 {
+		err = json.Unmarshal(response.Bytes(), result)
+		if err != nil {
+			return fmt.Errorf("Failed to parse response: %v", err)
+		}
+	}
+	return nil
+}
+
+// responseError converts an error response into an error the same way as the typed clients do.
+func responseError(response *sdk.Response) error {
+	ocmErr, err := ocmerrors.UnmarshalErrorStatus(response.Bytes(), response.Status())
+	if err != nil {
+		ocmErr, _ = ocmerrors.NewError().
+			Status(response.Status()).
+			Reason(http.StatusText(response.Status())).
+			Build()
+	}
+	return handleErr(ocmErr, fmt.Errorf("%s", ocmErr.Reason()))
+}
+
+// isNotFound checks if the given error was produced by a '404 Not Found' response.
+func isNotFound(err error) bool {
+	return err != nil && errors.GetType(err) == errors.NotFound
+}
+
+// needsRawCreate checks if the cluster uses settings that the typed client of the SDK doesn't
+// support, so that it has to be created with createClusterRaw.
+func needsRawCreate(config Spec) bool {
+	return config.RegistryConfig != nil ||
+		config.BillingAccount != "" ||
+		config.PrivateHostedZoneID != "" ||
+		len(config.AdditionalInfraSecurityGroupIds) > 0 ||
+		len(config.AdditionalControlPlaneSecurityGroupIds) > 0 ||
+		config.Ec2MetadataHttpTokens != "" ||
+		config.ExternalAuthEnabled ||
+		config.AuditLogRoleARN != ""
+}
+
+// createClusterRaw creates the cluster adding to the request the settings that the typed client of
+// the SDK doesn't support: the image registry configuration, the billing account, the private
+// hosted zone of a shared VPC, the additional security groups of the infra and control plane nodes,
+// the use of IMDSv2 by the nodes, external authentication and the audit log forwarding role.
+func (c *Client) createClusterRaw(spec *cmv1.Cluster, config Spec, dryRun bool) (*cmv1.Cluster, error) {
+	body, err := rawBody(func(writer io.Writer) error {
+		return cmv1.MarshalCluster(spec, writer)
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+	if config.RegistryConfig != nil {
+		body["registry_config"] = config.RegistryConfig
+	}
+	if config.BillingAccount != "" {
+		setRawField(body, "aws.billing_account_id", config.BillingAccount)
+	}
+	if config.PrivateHostedZoneID != "" {
+		setRawField(body, "aws.private_hosted_zone_id", config.PrivateHostedZoneID)
+		setRawField(body, "aws.private_hosted_zone_role_arn", config.SharedVPCRoleARN)
+	}
+	if len(config.AdditionalInfraSecurityGroupIds) > 0 {
+		setRawField(body, "aws.additional_infra_security_group_ids", config.AdditionalInfraSecurityGroupIds)
+	}
+	if len(config.AdditionalControlPlaneSecurityGroupIds) > 0 {
+		setRawField(body, "aws.additional_control_plane_security_group_ids",
+			config.AdditionalControlPlaneSecurityGroupIds)
+	}
+	if config.Ec2MetadataHttpTokens != "" {
+		setRawField(body, "aws.ec2_metadata_http_tokens", config.Ec2MetadataHttpTokens)
+	}
+	if config.ExternalAuthEnabled {
+		setRawField(body, "external_auth_config.enabled", true)
+	}
+	if config.AuditLogRoleARN != "" {
+		setRawField(body, "aws.audit_log.role_arn", config.AuditLogRoleARN)
+	}
+	var result json.RawMessage
+	err = sendRaw(c.ocm.Post().
+		Path(clustersMgmtPath+"/clusters").
+		Parameter("dryRun", dryRun), body, &result)
+	if err != nil {
+		return nil, err
+	}
+	if dryRun {
+		return spec, nil
+	}
+	return cmv1.UnmarshalCluster([]byte(result))
+}
+
+// rawBody returns the JSON representation of a typed object, written by the given marshal function,
+// with the given fields added. The fields are indexed by their dotted path, like
+// 'aws_node_pool.root_volume.size'.
+func rawBody(marshal func(io.Writer) error, fields map[string]interface{}) (map[string]interface{}, error) {
+	var buffer bytes.Buffer
+	err := marshal(&buffer)
+	if err != nil {
+		return nil, err
+	}
+	body := map[string]interface{}{}
+	err = json.Unmarshal(buffer.Bytes(), &body)
+	if err != nil {
+		return nil, err
+	}
+	for path, value := range fields {
+		setRawField(body, path, value)
+	}
+	return body, nil
+}
+
+// setRawField sets the field of the raw body with the given dotted path, adding the objects of the
+// path that don't exist yet.
+func setRawField(body map[string]interface{}, path string, value interface{}) {
+	names := strings.Split(path, ".")
+	for _, name := range names[:len(names)-1] {
+		body = rawObject(body, name)
+	}
+	body[names[len(names)-1]] = value
+}
+
+// rawObject returns the nested object of the raw body with the given name, adding it if it doesn't
+// exist yet.
+func rawObject(body map[string]interface{}, name string) map[string]interface{} {
+	object, ok := body[name].(map[string]interface{})
+	if !ok {
+		object = map[string]interface{}{}
+		body[name] = object
+	}
+	return object
+}
@@ -0,0 +1,143 @@
+package ocm
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/openshift-online/ocm-sdk-go/logging"
+	. "github.com/openshift-online/ocm-sdk-go/testing"
+)
+
+var _ = Describe("Raw cluster creation", func() {
+	var apiServer *ghttp.Server
+	var ocmClient *Client
+	var sent map[string]interface{}
+
+	BeforeEach(func() {
+		apiServer = MakeTCPServer()
+		accessToken := MakeTokenString("Bearer", 15*time.Minute)
+		logger, err := logging.NewGoLoggerBuilder().Build()
+		Expect(err).To(BeNil())
+		connection, err := sdk.NewConnectionBuilder().
+			Logger(logger).
+			Tokens(accessToken).
+			URL(apiServer.URL()).
+			Build()
+		Expect(err).To(BeNil())
+		ocmClient = &Client{ocm: connection}
+		sent = nil
+		apiServer.AppendHandlers(
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest(http.MethodPost, "/api/clusters_mgmt/v1/clusters"),
+				func(w http.ResponseWriter, req *http.Request) {
+					data, err := io.ReadAll(req.Body)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(json.Unmarshal(data, &sent)).To(Succeed())
+				},
+				RespondWithJSON(http.StatusCreated, `{"kind":"Cluster","id":"123","name":"mycluster"}`),
+			),
+		)
+	})
+
+	AfterEach(func() {
+		apiServer.Close()
+		Expect(ocmClient.Close()).To(Succeed())
+	})
+
+	It("Adds the private hosted zone of a shared VPC to the request", func() {
+		spec, err := cmv1.NewCluster().
+			Name("mycluster").
+			AWS(cmv1.NewAWS().SubnetIDs("subnet-1")).
+			Build()
+		Expect(err).NotTo(HaveOccurred())
+		config := Spec{
+			SharedVPCRoleARN:    "arn:aws:iam::123456789012:role/shared-vpc",
+			PrivateHostedZoneID: "Z123",
+		}
+		Expect(needsRawCreate(config)).To(BeTrue())
+		cluster, err := ocmClient.createClusterRaw(spec, config, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cluster.ID()).To(Equal("123"))
+		Expect(sent["aws"]).To(HaveKeyWithValue("private_hosted_zone_id", "Z123"))
+		Expect(sent["aws"]).To(HaveKeyWithValue("private_hosted_zone_role_arn",
+			"arn:aws:iam::123456789012:role/shared-vpc"))
+		Expect(sent["aws"]).To(HaveKeyWithValue("subnet_ids", ConsistOf("subnet-1")))
+	})
+
+	It("Adds the security groups of the infra and control plane nodes to the request", func() {
+		spec, err := cmv1.NewCluster().Name("mycluster").Build()
+		Expect(err).NotTo(HaveOccurred())
+		config := Spec{
+			AdditionalInfraSecurityGroupIds:        []string{"sg-00000001"},
+			AdditionalControlPlaneSecurityGroupIds: []string{"sg-00000002", "sg-00000003"},
+		}
+		Expect(needsRawCreate(config)).To(BeTrue())
+		_, err = ocmClient.createClusterRaw(spec, config, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sent["aws"]).To(HaveKeyWithValue("additional_infra_security_group_ids",
+			ConsistOf("sg-00000001")))
+		Expect(sent["aws"]).To(HaveKeyWithValue("additional_control_plane_security_group_ids",
+			ConsistOf("sg-00000002", "sg-00000003")))
+	})
+
+	It("Adds the EC2 metadata HTTP tokens setting to the request", func() {
+		spec, err := cmv1.NewCluster().Name("mycluster").Build()
+		Expect(err).NotTo(HaveOccurred())
+		config := Spec{Ec2MetadataHttpTokens: Ec2MetadataHttpTokensRequired}
+		Expect(needsRawCreate(config)).To(BeTrue())
+		_, err = ocmClient.createClusterRaw(spec, config, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sent["aws"]).To(HaveKeyWithValue("ec2_metadata_http_tokens", "required"))
+	})
+
+	It("Enables external authentication in the request", func() {
+		spec, err := cmv1.NewCluster().Name("mycluster").Build()
+		Expect(err).NotTo(HaveOccurred())
+		config := Spec{ExternalAuthEnabled: true}
+		Expect(needsRawCreate(config)).To(BeTrue())
+		_, err = ocmClient.createClusterRaw(spec, config, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sent["external_auth_config"]).To(HaveKeyWithValue("enabled", true))
+	})
+
+	It("Adds the audit log forwarding role to the request", func() {
+		spec, err := cmv1.NewCluster().Name("mycluster").Build()
+		Expect(err).NotTo(HaveOccurred())
+		config := Spec{AuditLogRoleARN: "arn:aws:iam::123456789012:role/audit-log"}
+		Expect(needsRawCreate(config)).To(BeTrue())
+		_, err = ocmClient.createClusterRaw(spec, config, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sent["aws"]).To(HaveKeyWithValue("audit_log",
+			HaveKeyWithValue("role_arn", "arn:aws:iam::123456789012:role/audit-log")))
+	})
+})
+
+var _ = Describe("Raw bodies", func() {
+	It("Adds the fields to the typed object", func() {
+		nodePool, err := cmv1.NewNodePool().
+			ID("np-1").
+			AWSNodePool(cmv1.NewAWSNodePool().InstanceType("m5.xlarge")).
+			Build()
+		Expect(err).NotTo(HaveOccurred())
+		body, err := rawBody(func(writer io.Writer) error {
+			return cmv1.MarshalNodePool(nodePool, writer)
+		}, map[string]interface{}{
+			"auto_repair":                            true,
+			"aws_node_pool.ec2_metadata_http_tokens": "required",
+			"aws_node_pool.root_volume.size":         300,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(body).To(HaveKeyWithValue("id", "np-1"))
+		Expect(body).To(HaveKeyWithValue("auto_repair", true))
+		Expect(body["aws_node_pool"]).To(HaveKeyWithValue("instance_type", "m5.xlarge"))
+		Expect(body["aws_node_pool"]).To(HaveKeyWithValue("ec2_metadata_http_tokens", "required"))
+		Expect(body["aws_node_pool"]).To(HaveKeyWithValue("root_volume", HaveKeyWithValue("size", 300)))
+	})
+})
@@ -173,14 +173,13 @@ func (c *Client) GetRegionList(multiAZ bool, roleARN string,
 }
 
 func (c *Client) GetDatabaseRegionList() ([]string, error) {
-	response, err := c.ocm.ClustersMgmt().V1().CloudProviders().CloudProvider("aws").Regions().List().Send()
+	regions, err := c.getCachedCloudRegions("aws")
 	if err != nil {
 		return []string{}, weberr.Errorf("Failed to get regions listing: %v", err)
 	}
 	supportedRegions := []string{}
-	response.Items().Range(func(index int, item *cmv1.CloudRegion) bool {
+	for _, item := range regions {
 		supportedRegions = append(supportedRegions, item.ID())
-		return true
-	})
+	}
 	return supportedRegions, nil
 }
@@ -0,0 +1,76 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ocm
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// RegistryConfig is the image registry configuration of a hosted cluster. cmv1.Cluster has no
+// registry_config attribute, see raw.go.
+type RegistryConfig struct {
+	RegistrySources *RegistrySources `json:"registry_sources,omitempty"`
+}
+
+// RegistrySources contains the registries that the cluster is allowed, or not, to pull images from.
+type RegistrySources struct {
+	AllowedRegistries  []string `json:"allowed_registries"`
+	BlockedRegistries  []string `json:"blocked_registries"`
+	InsecureRegistries []string `json:"insecure_registries"`
+}
+
+// registryRE matches a registry host name, optionally starting with a wildcard and followed by a
+// port and a repository path, for example '*.example.com', 'quay.io:443' or 'quay.io/myorg'.
+var registryRE = regexp.MustCompile(
+	`^(\*\.)?([a-zA-Z0-9]([a-zA-Z0-9-]*[a-zA-Z0-9])?\.)*[a-zA-Z0-9]([a-zA-Z0-9-]*[a-zA-Z0-9])?` +
+		`(:[0-9]{1,5})?(/[a-zA-Z0-9._-]+)*$`)
+
+// NewRegistryConfig validates the given registries and returns the corresponding registry
+// configuration, or nil if no registries are given.
+func NewRegistryConfig(allowed []string, blocked []string, insecure []string) (*RegistryConfig, error) {
+	if len(allowed) > 0 && len(blocked) > 0 {
+		return nil, fmt.Errorf("Allowed registries and blocked registries are mutually exclusive")
+	}
+	for _, registries := range [][]string{allowed, blocked, insecure} {
+		for _, registry := range registries {
+			if !registryRE.MatchString(registry) {
+				return nil, fmt.Errorf("Invalid registry '%s', expected a host name optionally starting "+
+					"with '*.' and followed by a port and a repository path", registry)
+			}
+		}
+	}
+	if len(allowed) == 0 && len(blocked) == 0 && len(insecure) == 0 {
+		return nil, nil
+	}
+	return &RegistryConfig{
+		RegistrySources: &RegistrySources{
+			AllowedRegistries:  allowed,
+			BlockedRegistries:  blocked,
+			InsecureRegistries: insecure,
+		},
+	}, nil
+}
+
+// UpdateClusterRegistryConfig replaces the image registry configuration of the cluster, registry
+// lists that are not set are cleared.
+func (c *Client) UpdateClusterRegistryConfig(clusterID string, config *RegistryConfig) error {
+	body := map[string]interface{}{
+		"registry_config": config,
+	}
+	return sendRaw(c.ocm.Patch().Path(fmt.Sprintf("%s/clusters/%s", clustersMgmtPath, clusterID)), body, nil)
+}
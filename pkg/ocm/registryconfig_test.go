@@ -0,0 +1,36 @@
+package ocm
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Registry config", func() {
+	It("Returns nothing when no registries are given", func() {
+		config, err := NewRegistryConfig(nil, nil, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(config).To(BeNil())
+	})
+
+	It("Accepts host names with wildcards, ports and repositories", func() {
+		config, err := NewRegistryConfig(
+			[]string{"quay.io", "*.example.com", "registry.example.com:5000/myorg/images"},
+			nil,
+			[]string{"localhost:5000"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(config.RegistrySources.AllowedRegistries).To(HaveLen(3))
+		Expect(config.RegistrySources.InsecureRegistries).To(Equal([]string{"localhost:5000"}))
+	})
+
+	It("Rejects allowed and blocked registries together", func() {
+		_, err := NewRegistryConfig([]string{"quay.io"}, []string{"docker.io"}, nil)
+		Expect(err).To(MatchError("Allowed registries and blocked registries are mutually exclusive"))
+	})
+
+	It("Rejects invalid registries", func() {
+		for _, registry := range []string{"https://quay.io", "quay.io:port", "-quay.io", "quay.*.io", ""} {
+			_, err := NewRegistryConfig(nil, []string{registry}, nil)
+			Expect(err).To(HaveOccurred(), registry)
+		}
+	})
+})
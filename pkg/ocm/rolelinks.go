@@ -0,0 +1,193 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions used to detect ocm-roles and user-roles that belong to another OCM
+// organization or Red Hat account, which is what makes linking them or creating clusters with them
+// fail.
+
+package ocm
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/arn"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/helper"
+)
+
+const (
+	OCMRoleType  = "ocm-role"
+	UserRoleType = "user-role"
+)
+
+// RoleOwnerError explains that a role trusts an OCM organization or Red Hat account other than the
+// one it is used with, and how to fix it.
+type RoleOwnerError struct {
+	RoleType  string
+	RoleARN   string
+	OwnerID   string
+	OwnerName string
+	CurrentID string
+}
+
+func (e *RoleOwnerError) Error() string {
+	owner := fmt.Sprintf("'%s'", e.OwnerID)
+	if e.OwnerName != "" {
+		owner = fmt.Sprintf("'%s' (%s)", e.OwnerID, e.OwnerName)
+	}
+	if e.RoleType == OCMRoleType {
+		return fmt.Sprintf("The ocm-role '%s' belongs to OCM organization %s, not to organization '%s'. "+
+			"To unlink it, log in to organization '%s' and run 'rosa unlink ocm-role --role-arn %s'. "+
+			"To create an ocm-role for organization '%s', run 'rosa create ocm-role'",
+			e.RoleARN, owner, e.CurrentID, e.OwnerID, e.RoleARN, e.CurrentID)
+	}
+	return fmt.Sprintf("The user-role '%s' belongs to Red Hat account %s, not to account '%s'. "+
+		"To unlink it, log in with account '%s' and run 'rosa unlink user-role --role-arn %s'. "+
+		"To create a user-role for account '%s', run 'rosa create user-role'",
+		e.RoleARN, owner, e.CurrentID, e.OwnerID, e.RoleARN, e.CurrentID)
+}
+
+// newRoleOwnerError returns the error explaining that the role belongs to another organization or
+// account, or nil if the external IDs trusted by the role include the current one, or if the role
+// doesn't trust any.
+func newRoleOwnerError(roleType string, roleARN string, externalIDs []string, currentID string) *RoleOwnerError {
+	if len(externalIDs) == 0 || helper.Contains(externalIDs, currentID) {
+		return nil
+	}
+	return &RoleOwnerError{
+		RoleType:  roleType,
+		RoleARN:   roleARN,
+		OwnerID:   externalIDs[0],
+		CurrentID: currentID,
+	}
+}
+
+// CheckOCMRoleOwner checks that the ocm-role belongs to the given OCM organization.
+func (c *Client) CheckOCMRoleOwner(awsClient aws.Client, roleARN string, orgID string) (*RoleOwnerError, error) {
+	ownerErr, err := c.checkRoleOwner(awsClient, OCMRoleType, roleARN, orgID)
+	if ownerErr != nil {
+		organization, err := c.ocm.AccountsMgmt().V1().Organizations().Organization(ownerErr.OwnerID).Get().Send()
+		if err == nil {
+			ownerErr.OwnerName = organization.Body().Name()
+		}
+	}
+	return ownerErr, err
+}
+
+// CheckUserRoleOwner checks that the user-role belongs to the given Red Hat account.
+func (c *Client) CheckUserRoleOwner(awsClient aws.Client, roleARN string, accountID string) (*RoleOwnerError, error) {
+	ownerErr, err := c.checkRoleOwner(awsClient, UserRoleType, roleARN, accountID)
+	if ownerErr != nil {
+		account, err := c.ocm.AccountsMgmt().V1().Accounts().Account(ownerErr.OwnerID).Get().Send()
+		if err == nil {
+			ownerErr.OwnerName = account.Body().Username()
+		}
+	}
+	return ownerErr, err
+}
+
+func (c *Client) checkRoleOwner(awsClient aws.Client, roleType string, roleARN string,
+	currentID string) (*RoleOwnerError, error) {
+	role, err := awsClient.GetRoleByARN(roleARN)
+	if err != nil {
+		return nil, err
+	}
+	externalIDs, err := aws.RoleTrustedExternalIDs(role)
+	if err != nil {
+		return nil, err
+	}
+	return newRoleOwnerError(roleType, roleARN, externalIDs, currentID), nil
+}
+
+// FindRoleOwnerErrors looks for the ocm-role and user-role of the AWS account that belong to another
+// OCM organization or Red Hat account than the ones of the current user. The linked roles are checked
+// and, when none is linked, the roles of the current user that exist in the AWS account.
+func (c *Client) FindRoleOwnerErrors(awsClient aws.Client, awsAccountID string) ([]*RoleOwnerError, error) {
+	orgID, _, err := c.GetCurrentOrganization()
+	if err != nil {
+		return nil, err
+	}
+	account, err := c.GetCurrentAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	var ocmRoleARNs []string
+	linked, _, selectedARN, err := c.CheckIfAWSAccountExists(orgID, awsAccountID)
+	if err != nil {
+		return nil, err
+	}
+	if linked {
+		ocmRoleARNs = []string{selectedARN}
+	} else {
+		roles, err := awsClient.ListOCMRoles()
+		if err != nil {
+			return nil, err
+		}
+		for _, role := range roles {
+			ocmRoleARNs = append(ocmRoleARNs, role.RoleARN)
+		}
+	}
+
+	var userRoleARNs []string
+	linkedUserRoles, err := c.GetAccountLinkedUserRoles(account.ID())
+	if err != nil {
+		return nil, err
+	}
+	for _, roleARN := range linkedUserRoles {
+		parsedARN, err := arn.Parse(roleARN)
+		if err == nil && parsedARN.AccountID == awsAccountID {
+			userRoleARNs = append(userRoleARNs, roleARN)
+		}
+	}
+	if len(userRoleARNs) == 0 {
+		// Other users of the organization have their own user-roles in the AWS account, so only the
+		// ones named after the current user are checked:
+		roles, err := awsClient.ListUserRoles()
+		if err != nil {
+			return nil, err
+		}
+		suffix := fmt.Sprintf("-%s-%s-Role", aws.OCMUserRole, account.Username())
+		for _, role := range roles {
+			if strings.HasSuffix(role.RoleName, suffix) {
+				userRoleARNs = append(userRoleARNs, role.RoleARN)
+			}
+		}
+	}
+
+	var result []*RoleOwnerError
+	for _, roleARN := range ocmRoleARNs {
+		ownerErr, err := c.CheckOCMRoleOwner(awsClient, roleARN, orgID)
+		if err != nil {
+			return nil, err
+		}
+		if ownerErr != nil {
+			result = append(result, ownerErr)
+		}
+	}
+	for _, roleARN := range userRoleARNs {
+		ownerErr, err := c.CheckUserRoleOwner(awsClient, roleARN, account.ID())
+		if err != nil {
+			return nil, err
+		}
+		if ownerErr != nil {
+			result = append(result, ownerErr)
+		}
+	}
+	return result, nil
+}
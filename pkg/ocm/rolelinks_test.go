@@ -0,0 +1,32 @@
+package ocm
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Role owners", func() {
+	roleARN := "arn:aws:iam::123456789012:role/ManagedOpenShift-OCM-Role-1234"
+
+	It("Accepts roles that trust the current organization", func() {
+		Expect(newRoleOwnerError(OCMRoleType, roleARN, []string{"org-a"}, "org-a")).To(BeNil())
+	})
+	It("Accepts roles that don't trust any external ID", func() {
+		Expect(newRoleOwnerError(OCMRoleType, roleARN, nil, "org-a")).To(BeNil())
+	})
+	It("Explains how to unlink ocm-roles of other organizations", func() {
+		ownerErr := newRoleOwnerError(OCMRoleType, roleARN, []string{"org-b"}, "org-a")
+		Expect(ownerErr).ToNot(BeNil())
+		ownerErr.OwnerName = "Other Org"
+		Expect(ownerErr.Error()).To(ContainSubstring("belongs to OCM organization 'org-b' (Other Org)"))
+		Expect(ownerErr.Error()).To(ContainSubstring("log in to organization 'org-b' and run " +
+			"'rosa unlink ocm-role --role-arn " + roleARN + "'"))
+	})
+	It("Explains how to unlink user-roles of other accounts", func() {
+		ownerErr := newRoleOwnerError(UserRoleType, roleARN, []string{"account-b"}, "account-a")
+		Expect(ownerErr).ToNot(BeNil())
+		Expect(ownerErr.Error()).To(ContainSubstring("belongs to Red Hat account 'account-b', not to account " +
+			"'account-a'"))
+		Expect(ownerErr.Error()).To(ContainSubstring("rosa unlink user-role"))
+	})
+})
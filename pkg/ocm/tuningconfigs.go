@@ -0,0 +1,133 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ocm
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// TuningConfig is a TuneD configuration of the nodes of a Hosted Control Plane cluster, identified by
+// name and applied to the machine pools that reference it. The typed client of the SDK doesn't
+// support it yet, so it is sent as raw JSON.
+type TuningConfig struct {
+	Kind string                 `json:"kind,omitempty"`
+	ID   string                 `json:"id,omitempty"`
+	HREF string                 `json:"href,omitempty"`
+	Name string                 `json:"name,omitempty"`
+	Spec map[string]interface{} `json:"spec,omitempty"`
+}
+
+// tuningConfigNameRE is the format of the names of the tuning configurations, that are used as the
+// names of Kubernetes objects.
+var tuningConfigNameRE = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// MaxTuningConfigNameLength is the maximum length of the name of a tuning configuration.
+const MaxTuningConfigNameLength = 63
+
+// ValidateTuningConfigName checks that the name of the tuning configuration is accepted by the
+// service.
+func ValidateTuningConfigName(name string) error {
+	if name == "" {
+		return fmt.Errorf("The name of the tuning config is required")
+	}
+	if len(name) > MaxTuningConfigNameLength || !tuningConfigNameRE.MatchString(name) {
+		return fmt.Errorf("The name of the tuning config must consist of at most %d lowercase "+
+			"alphanumeric characters or '-', and start and end with an alphanumeric character, got '%s'",
+			MaxTuningConfigNameLength, name)
+	}
+	return nil
+}
+
+// tuningConfigsPath is the path of the tuning configurations of hosted clusters.
+func tuningConfigsPath(clusterID string) string {
+	return fmt.Sprintf("%s/clusters/%s/tuning_configs", clustersMgmtPath, clusterID)
+}
+
+// GetTuningConfigs returns the tuning configurations of the hosted cluster.
+func (c *Client) GetTuningConfigs(clusterID string) ([]*TuningConfig, error) {
+	var list struct {
+		Items []*TuningConfig `json:"items"`
+	}
+	err := sendRaw(c.ocm.Get().Path(tuningConfigsPath(clusterID)).Parameter("size", -1), nil, &list)
+	if err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+// FindTuningConfig returns the tuning configuration of the hosted cluster with the given name, or
+// nil if it doesn't exist.
+func (c *Client) FindTuningConfig(clusterID string, name string) (*TuningConfig, error) {
+	configs, err := c.GetTuningConfigs(clusterID)
+	if err != nil {
+		return nil, err
+	}
+	for _, config := range configs {
+		if config.Name == name {
+			return config, nil
+		}
+	}
+	return nil, nil
+}
+
+// CreateTuningConfig creates the given tuning configuration for the hosted cluster.
+func (c *Client) CreateTuningConfig(clusterID string, config *TuningConfig) (*TuningConfig, error) {
+	created := new(TuningConfig)
+	err := sendRaw(c.ocm.Post().Path(tuningConfigsPath(clusterID)), config, created)
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// UpdateTuningConfig replaces the spec of the given existing tuning configuration of the hosted
+// cluster.
+func (c *Client) UpdateTuningConfig(clusterID string, config *TuningConfig) (*TuningConfig, error) {
+	path := fmt.Sprintf("%s/%s", tuningConfigsPath(clusterID), config.ID)
+	updated := new(TuningConfig)
+	err := sendRaw(c.ocm.Patch().Path(path), &TuningConfig{Spec: config.Spec}, updated)
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// DeleteTuningConfig deletes the given existing tuning configuration of the hosted cluster.
+func (c *Client) DeleteTuningConfig(clusterID string, config *TuningConfig) error {
+	path := fmt.Sprintf("%s/%s", tuningConfigsPath(clusterID), config.ID)
+	return sendRaw(c.ocm.Delete().Path(path), nil, nil)
+}
+
+// ValidateTuningConfigNames checks that the tuning configurations referenced by a machine pool of a
+// hosted cluster exist.
+func ValidateTuningConfigNames(configs []*TuningConfig, names []string) error {
+	for _, name := range names {
+		found := false
+		for _, config := range configs {
+			if config.Name == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("Tuning config '%s' doesn't exist, create it with "+
+				"'rosa create tuning-config --name %s'", name, name)
+		}
+	}
+	return nil
+}
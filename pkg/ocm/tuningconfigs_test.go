@@ -0,0 +1,131 @@
+package ocm
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	"github.com/openshift-online/ocm-sdk-go/logging"
+	. "github.com/openshift-online/ocm-sdk-go/testing"
+)
+
+var _ = Describe("Tuning configs", func() {
+	DescribeTable("ValidateTuningConfigName",
+		func(name string, valid bool) {
+			err := ValidateTuningConfigName(name)
+			if valid {
+				Expect(err).ToNot(HaveOccurred())
+			} else {
+				Expect(err).To(HaveOccurred())
+			}
+		},
+		Entry("valid", "hugepages-1", true),
+		Entry("empty", "", false),
+		Entry("uppercase", "HugePages", false),
+		Entry("trailing dash", "hugepages-", false),
+		Entry("too long", "a123456789012345678901234567890123456789012345678901234567890123", false),
+	)
+
+	It("Rejects machine pool tuning configs that don't exist", func() {
+		configs := []*TuningConfig{{Name: "hugepages"}}
+		Expect(ValidateTuningConfigNames(configs, []string{"hugepages"})).To(Succeed())
+		err := ValidateTuningConfigNames(configs, []string{"other"})
+		Expect(err).To(MatchError(ContainSubstring("'other' doesn't exist")))
+	})
+
+	Context("API", func() {
+		const tuningConfigsPath = "/api/clusters_mgmt/v1/clusters/123/tuning_configs"
+
+		var apiServer *ghttp.Server
+		var ocmClient *Client
+
+		BeforeEach(func() {
+			apiServer = MakeTCPServer()
+			accessToken := MakeTokenString("Bearer", 15*time.Minute)
+			logger, err := logging.NewGoLoggerBuilder().Build()
+			Expect(err).To(BeNil())
+			connection, err := sdk.NewConnectionBuilder().
+				Logger(logger).
+				Tokens(accessToken).
+				URL(apiServer.URL()).
+				Build()
+			Expect(err).To(BeNil())
+			ocmClient = &Client{ocm: connection}
+		})
+
+		AfterEach(func() {
+			apiServer.Close()
+			Expect(ocmClient.Close()).To(Succeed())
+		})
+
+		It("Sends the name and the spec of new tuning configs", func() {
+			var sent map[string]interface{}
+			apiServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPost, tuningConfigsPath),
+					func(w http.ResponseWriter, req *http.Request) {
+						data, err := io.ReadAll(req.Body)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(json.Unmarshal(data, &sent)).To(Succeed())
+					},
+					RespondWithJSON(http.StatusCreated, `{"id": "a", "name": "hugepages"}`),
+				),
+			)
+			config, err := ocmClient.CreateTuningConfig("123", &TuningConfig{
+				Name: "hugepages",
+				Spec: map[string]interface{}{"profile": []interface{}{}},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(config.ID).To(Equal("a"))
+			Expect(sent).To(HaveKeyWithValue("name", "hugepages"))
+			Expect(sent).To(HaveKey("spec"))
+		})
+
+		It("Replaces only the spec of existing tuning configs", func() {
+			var sent map[string]interface{}
+			apiServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodPatch, tuningConfigsPath+"/a"),
+					func(w http.ResponseWriter, req *http.Request) {
+						data, err := io.ReadAll(req.Body)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(json.Unmarshal(data, &sent)).To(Succeed())
+					},
+					RespondWithJSON(http.StatusOK, `{"id": "a", "name": "hugepages"}`),
+				),
+			)
+			_, err := ocmClient.UpdateTuningConfig("123", &TuningConfig{
+				ID:   "a",
+				Name: "hugepages",
+				Spec: map[string]interface{}{"profile": []interface{}{}},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sent).To(HaveKey("spec"))
+			Expect(sent).ToNot(HaveKey("name"))
+		})
+
+		It("Finds tuning configs by name", func() {
+			apiServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, tuningConfigsPath),
+					RespondWithJSON(http.StatusOK, `{"items": [{"id": "a", "name": "hugepages"}]}`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, tuningConfigsPath),
+					RespondWithJSON(http.StatusOK, `{"items": [{"id": "a", "name": "hugepages"}]}`),
+				),
+			)
+			config, err := ocmClient.FindTuningConfig("123", "hugepages")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(config.ID).To(Equal("a"))
+			config, err = ocmClient.FindTuningConfig("123", "other")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(config).To(BeNil())
+		})
+	})
+})
@@ -0,0 +1,125 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ocm
+
+import (
+	"sort"
+
+	ver "github.com/hashicorp/go-version"
+)
+
+// UpgradeGraph describes the versions that a cluster can reach, directly or through intermediate
+// upgrades, and the path recommended to reach the latest of them.
+type UpgradeGraph struct {
+	Current         string              `json:"current"`
+	Versions        []*UpgradeGraphNode `json:"versions"`
+	Edges           []*UpgradeGraphEdge `json:"edges"`
+	RecommendedPath []string            `json:"recommended_path"`
+}
+
+// UpgradeGraphNode is a version that the cluster can be upgraded to.
+type UpgradeGraphNode struct {
+	Version     string              `json:"version"`
+	Direct      bool                `json:"direct"`
+	Recommended bool                `json:"recommended"`
+	Scheduled   string              `json:"scheduled,omitempty"`
+	Gates       []*UpgradeGraphGate `json:"gates,omitempty"`
+}
+
+// UpgradeGraphEdge is an upgrade from one version to another.
+type UpgradeGraphEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// UpgradeGraphGate is a version gate that needs to be acknowledged before upgrading to a version.
+type UpgradeGraphGate struct {
+	ID               string `json:"id"`
+	Description      string `json:"description"`
+	DocumentationURL string `json:"documentation_url,omitempty"`
+}
+
+// GetUpgradeGraph explores the upgrades available from the given version. At every step it follows
+// the newest available version, which gives the recommended path through the intermediate minor
+// versions, and records all the upgrades seen on the way.
+func (c *Client) GetUpgradeGraph(current string, channelGroup string) (*UpgradeGraph, error) {
+	return buildUpgradeGraph(current, func(version string) ([]string, error) {
+		return c.GetAvailableUpgrades(CreateVersionID(version, channelGroup))
+	})
+}
+
+func buildUpgradeGraph(current string, getAvailableUpgrades func(string) ([]string, error)) (*UpgradeGraph,
+	error) {
+	graph := &UpgradeGraph{
+		Current:         current,
+		Versions:        []*UpgradeGraphNode{},
+		Edges:           []*UpgradeGraphEdge{},
+		RecommendedPath: []string{current},
+	}
+	nodes := map[string]*UpgradeGraphNode{}
+	from := current
+	for {
+		availableUpgrades, err := getAvailableUpgrades(from)
+		if err != nil {
+			return nil, err
+		}
+		next := ""
+		for _, version := range availableUpgrades {
+			graph.Edges = append(graph.Edges, &UpgradeGraphEdge{From: from, To: version})
+			node, ok := nodes[version]
+			if !ok {
+				node = &UpgradeGraphNode{
+					Version: version,
+					Direct:  from == current,
+				}
+				nodes[version] = node
+				graph.Versions = append(graph.Versions, node)
+			}
+			if next == "" || compareVersions(version, next) > 0 {
+				next = version
+			}
+		}
+		// Stop when there is nothing newer, which also protects against cycles:
+		if next == "" || compareVersions(next, from) <= 0 {
+			break
+		}
+		nodes[next].Recommended = true
+		graph.RecommendedPath = append(graph.RecommendedPath, next)
+		from = next
+	}
+	sort.SliceStable(graph.Versions, func(i, j int) bool {
+		return compareVersions(graph.Versions[i].Version, graph.Versions[j].Version) > 0
+	})
+	return graph, nil
+}
+
+// compareVersions compares two versions, falling back to comparing the strings when they can't be
+// parsed.
+func compareVersions(a string, b string) int {
+	va, erra := ver.NewVersion(a)
+	vb, errb := ver.NewVersion(b)
+	if erra != nil || errb != nil {
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		}
+		return 0
+	}
+	return va.Compare(vb)
+}
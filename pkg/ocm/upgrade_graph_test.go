@@ -0,0 +1,51 @@
+package ocm
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Upgrade graph", func() {
+	upgrades := map[string][]string{
+		"4.11.5":  {"4.11.30", "4.11.20", "4.12.20"},
+		"4.12.20": {"4.12.25", "4.13.4"},
+		"4.13.4":  {},
+	}
+	getAvailableUpgrades := func(version string) ([]string, error) {
+		available, ok := upgrades[version]
+		if !ok {
+			return nil, fmt.Errorf("unexpected version '%s'", version)
+		}
+		return available, nil
+	}
+
+	It("Follows the newest version through the intermediate minor versions", func() {
+		graph, err := buildUpgradeGraph("4.11.5", getAvailableUpgrades)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(graph.RecommendedPath).To(Equal([]string{"4.11.5", "4.12.20", "4.13.4"}))
+
+		versions := []string{}
+		for _, node := range graph.Versions {
+			versions = append(versions, fmt.Sprintf("%s direct=%t recommended=%t",
+				node.Version, node.Direct, node.Recommended))
+		}
+		Expect(versions).To(Equal([]string{
+			"4.13.4 direct=false recommended=true",
+			"4.12.25 direct=false recommended=false",
+			"4.12.20 direct=true recommended=true",
+			"4.11.30 direct=true recommended=false",
+			"4.11.20 direct=true recommended=false",
+		}))
+		Expect(graph.Edges).To(HaveLen(5))
+		Expect(graph.Edges).To(ContainElement(&UpgradeGraphEdge{From: "4.12.20", To: "4.13.4"}))
+	})
+
+	It("Returns an empty graph when there are no upgrades", func() {
+		graph, err := buildUpgradeGraph("4.13.4", getAvailableUpgrades)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(graph.Versions).To(BeEmpty())
+		Expect(graph.RecommendedPath).To(Equal([]string{"4.13.4"}))
+	})
+})
@@ -17,6 +17,7 @@ limitations under the License.
 package ocm
 
 import (
+	"fmt"
 	"net/http"
 
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
@@ -78,3 +79,53 @@ func (c *Client) DeleteUser(clusterID string, group string, username string) err
 	}
 	return nil
 }
+
+// GetGroups returns the groups of users that can be managed in the cluster, for example
+// 'cluster-admins', 'dedicated-admins' or 'dedicated-readers'.
+func (c *Client) GetGroups(clusterID string) ([]*cmv1.Group, error) {
+	response, err := c.ocm.ClustersMgmt().V1().
+		Clusters().Cluster(clusterID).
+		Groups().
+		List().Page(1).Size(-1).
+		Send()
+	if err != nil {
+		return nil, handleErr(response.Error(), err)
+	}
+
+	return response.Items().Slice(), nil
+}
+
+// FindGroup returns the ID of the group matching the given role, which can also be the singular
+// form of the group, for example 'dedicated-admin' for 'dedicated-admins'.
+func FindGroup(groups []*cmv1.Group, role string) (string, bool) {
+	for _, group := range groups {
+		if group.ID() == role || group.ID() == role+"s" {
+			return group.ID(), true
+		}
+	}
+	return "", false
+}
+
+// GroupIDs returns the IDs of the given groups.
+func GroupIDs(groups []*cmv1.Group) []string {
+	ids := make([]string, len(groups))
+	for i, group := range groups {
+		ids[i] = group.ID()
+	}
+	return ids
+}
+
+// GetGroupID returns the ID of the group of the cluster matching the given role, or an error listing
+// the groups of the cluster if there is none.
+func (c *Client) GetGroupID(clusterID string, role string) (string, error) {
+	groups, err := c.GetGroups(clusterID)
+	if err != nil {
+		return "", err
+	}
+	group, ok := FindGroup(groups, role)
+	if !ok {
+		return "", fmt.Errorf("Role '%s' isn't supported by the cluster, expected one of %s",
+			role, GroupIDs(groups))
+	}
+	return group, nil
+}
@@ -0,0 +1,43 @@
+package ocm
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+var _ = Describe("Users", func() {
+	Context("FindGroup", func() {
+		var groups []*cmv1.Group
+
+		BeforeEach(func() {
+			groups = []*cmv1.Group{}
+			for _, id := range []string{"cluster-admins", "dedicated-admins", "dedicated-readers"} {
+				group, err := cmv1.NewGroup().ID(id).Build()
+				Expect(err).ToNot(HaveOccurred())
+				groups = append(groups, group)
+			}
+		})
+
+		It("finds groups by ID", func() {
+			group, ok := FindGroup(groups, "dedicated-readers")
+			Expect(ok).To(BeTrue())
+			Expect(group).To(Equal("dedicated-readers"))
+		})
+
+		It("finds groups by their singular form", func() {
+			group, ok := FindGroup(groups, "cluster-admin")
+			Expect(ok).To(BeTrue())
+			Expect(group).To(Equal("cluster-admins"))
+		})
+
+		It("doesn't find groups that the cluster doesn't have", func() {
+			_, ok := FindGroup(groups, "machine-pool-admins")
+			Expect(ok).To(BeFalse())
+		})
+
+		It("lists the IDs of the groups", func() {
+			Expect(GroupIDs(groups)).To(Equal([]string{"cluster-admins", "dedicated-admins", "dedicated-readers"}))
+		})
+	})
+})
@@ -32,6 +32,9 @@ const (
 	LowestSTSSupport      = "4.7.11"
 	LowestSTSMinor        = "4.7"
 	LowestHostedCPSupport = "4.12.0-0.a" //TODO: Remove the 0.a once stable 4.12 builds are available
+
+	// Hosted machine pools can run up to this number of minor versions behind the control plane
+	HostedMachinePoolMinorVersionSkew = 2
 )
 
 func (c *Client) ManagedServiceVersionInquiry(serviceType string) (string, error) {
@@ -52,28 +55,13 @@ func (c *Client) ManagedServiceVersionInquiry(serviceType string) (string, error
 }
 
 func (c *Client) GetVersions(channelGroup string) (versions []*cmv1.Version, err error) {
-	collection := c.ocm.ClustersMgmt().V1().Versions()
-	page := 1
-	size := 100
 	filter := "enabled = 'true' AND rosa_enabled = 'true'"
 	if channelGroup != "" {
 		filter = fmt.Sprintf("%s AND channel_group = '%s'", filter, channelGroup)
 	}
-	for {
-		var response *cmv1.VersionsListResponse
-		response, err = collection.List().
-			Search(filter).
-			Page(page).
-			Size(size).
-			Send()
-		if err != nil {
-			return nil, handleErr(response.Error(), err)
-		}
-		versions = append(versions, response.Items().Slice()...)
-		if response.Size() < size {
-			break
-		}
-		page++
+	versions, err = c.getCachedVersions(filter)
+	if err != nil {
+		return nil, err
 	}
 
 	// Sort list in descending order
@@ -380,3 +368,37 @@ func (c *Client) ValidateVersion(version string, versionList []string, channelGr
 
 	return CreateVersionID(version, channelGroup), nil
 }
+
+// ValidateNodePoolUpgradeVersion checks that a hosted machine pool can be upgraded from the current
+// version to the target one: the target must be newer than the current version, mustn't be newer
+// than the control plane, and mustn't fall behind it by more than the supported minor version skew.
+func ValidateNodePoolUpgradeVersion(current string, target string, controlPlane string) error {
+	currentVersion, err := ver.NewVersion(current)
+	if err != nil {
+		return fmt.Errorf("error while parsing machine pool version '%s': %v", current, err)
+	}
+	targetVersion, err := ver.NewVersion(target)
+	if err != nil {
+		return fmt.Errorf("error while parsing version '%s': %v", target, err)
+	}
+	controlPlaneVersion, err := ver.NewVersion(controlPlane)
+	if err != nil {
+		return fmt.Errorf("error while parsing control plane version '%s': %v", controlPlane, err)
+	}
+	if !targetVersion.GreaterThan(currentVersion) {
+		return fmt.Errorf("version '%s' is not newer than the current machine pool version '%s'",
+			target, current)
+	}
+	if targetVersion.GreaterThan(controlPlaneVersion) {
+		return fmt.Errorf("version '%s' is newer than the control plane version '%s', "+
+			"upgrade the control plane first", target, controlPlane)
+	}
+	targetSegments := targetVersion.Segments()
+	controlPlaneSegments := controlPlaneVersion.Segments()
+	if targetSegments[0] != controlPlaneSegments[0] ||
+		controlPlaneSegments[1]-targetSegments[1] > HostedMachinePoolMinorVersionSkew {
+		return fmt.Errorf("version '%s' is more than %d minor versions behind the control plane version '%s'",
+			target, HostedMachinePoolMinorVersionSkew, controlPlane)
+	}
+	return nil
+}
@@ -55,6 +55,24 @@ var _ = Describe("Versions", Ordered, func() {
 			),
 		)
 	})
+
+	Context("when upgrading a hosted machine pool", func() {
+		DescribeTable("Should validate the version skew with the control plane",
+			func(current string, target string, controlPlane string, expectedErr string) {
+				err := ValidateNodePoolUpgradeVersion(current, target, controlPlane)
+				if expectedErr == "" {
+					Expect(err).NotTo(HaveOccurred())
+				} else {
+					Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+				}
+			},
+			Entry("OK: Same version as the control plane", "4.12.10", "4.12.14", "4.12.14", ""),
+			Entry("OK: Behind the control plane within the skew", "4.11.3", "4.12.1", "4.14.2", ""),
+			Entry("KO: Not newer than the current version", "4.12.10", "4.12.10", "4.12.14", "is not newer"),
+			Entry("KO: Newer than the control plane", "4.12.10", "4.13.0", "4.12.14", "upgrade the control plane"),
+			Entry("KO: Too far behind the control plane", "4.11.3", "4.11.9", "4.14.2", "minor versions behind"),
+		)
+	})
 })
 
 func validateVersion(version func() string, channelGroup func() string, hypershiftEnabled bool,
@@ -0,0 +1,107 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions used to check that a cluster can run Windows machine pools,
+// which are managed by the Windows Machine Config Operator.
+
+package ocm
+
+import (
+	"fmt"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+const (
+	OperatingSystemLinux   = "linux"
+	OperatingSystemWindows = "windows"
+
+	// MinimumWindowsVersion is the first OpenShift version where ROSA supports Windows nodes.
+	MinimumWindowsVersion = "4.10"
+
+	// WindowsNetworkType is the only network type with the hybrid overlay needed by Windows nodes.
+	WindowsNetworkType = "OVNKubernetes"
+
+	windowsOSLabel = "kubernetes.io/os"
+
+	// machinePoolOperatingSystemField is the field of a machine pool that selects the operating
+	// system of its nodes. cmv1.MachinePool has no such attribute, so it is added to the body by
+	// hand, see raw.go.
+	machinePoolOperatingSystemField = "operating_system"
+)
+
+// OperatingSystems are the operating systems that machine pools can run.
+var OperatingSystems = []string{OperatingSystemLinux, OperatingSystemWindows}
+
+// ValidateWindowsMachinePool checks that the cluster network and version support Windows nodes and
+// that the instance type can run Windows.
+func ValidateWindowsMachinePool(cluster *cmv1.Cluster, machineType string) error {
+	if cluster.Hypershift().Enabled() {
+		return fmt.Errorf("Windows machine pools are not supported on Hosted Control Plane clusters")
+	}
+	if cluster.Network().Type() != WindowsNetworkType {
+		return fmt.Errorf("Windows machine pools require the '%s' network type, and cluster uses '%s'",
+			WindowsNetworkType, cluster.Network().Type())
+	}
+	version := cluster.Version().RawID()
+	supported, err := CheckSupportedVersion(GetVersionMinor(version), MinimumWindowsVersion)
+	if err != nil {
+		return fmt.Errorf("Failed to check Windows support of version '%s': %v", version, err)
+	}
+	if !supported {
+		return fmt.Errorf("Windows machine pools require version %s or later, and cluster version is '%s'",
+			MinimumWindowsVersion, version)
+	}
+	if GetMachineTypeArchitecture(machineType) != ArchitectureAMD64 {
+		return fmt.Errorf("Instance type '%s' can't run Windows, only %s instance types are supported",
+			machineType, ArchitectureAMD64)
+	}
+	return nil
+}
+
+// WindowsTaint is applied to every Windows machine pool so that Linux workloads are not scheduled on
+// Windows nodes.
+func WindowsTaint() *cmv1.TaintBuilder {
+	return cmv1.NewTaint().Key("os").Value("Windows").Effect("NoSchedule")
+}
+
+// AddWindowsTaint returns the taints with the Windows taint appended, unless it is already present.
+func AddWindowsTaint(taints []*cmv1.TaintBuilder) []*cmv1.TaintBuilder {
+	for _, taint := range taints {
+		t, err := taint.Build()
+		if err == nil && t.Key() == "os" && t.Effect() == "NoSchedule" {
+			return taints
+		}
+	}
+	return append(taints, WindowsTaint())
+}
+
+// AddWindowsLabel returns the labels with the operating system label set to Windows.
+func AddWindowsLabel(labels map[string]string) map[string]string {
+	result := map[string]string{}
+	for k, v := range labels {
+		result[k] = v
+	}
+	result[windowsOSLabel] = OperatingSystemWindows
+	return result
+}
+
+// WindowsMachinePoolFields returns the raw fields that make the nodes of a machine pool run Windows.
+func WindowsMachinePoolFields() map[string]interface{} {
+	return map[string]interface{}{
+		machinePoolOperatingSystemField: OperatingSystemWindows,
+	}
+}
@@ -0,0 +1,51 @@
+package ocm
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+var _ = Describe("Windows", func() {
+	cluster := func(networkType string, version string, hypershift bool) *cmv1.Cluster {
+		cluster, err := cmv1.NewCluster().
+			Network(cmv1.NewNetwork().Type(networkType)).
+			Version(cmv1.NewVersion().RawID(version)).
+			Hypershift(cmv1.NewHypershift().Enabled(hypershift)).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		return cluster
+	}
+
+	DescribeTable("ValidateWindowsMachinePool",
+		func(cluster *cmv1.Cluster, machineType string, expectedError string) {
+			err := ValidateWindowsMachinePool(cluster, machineType)
+			if expectedError == "" {
+				Expect(err).ToNot(HaveOccurred())
+			} else {
+				Expect(err).To(MatchError(ContainSubstring(expectedError)))
+			}
+		},
+		Entry("supported cluster", cluster("OVNKubernetes", "4.12.3", false), "m5.xlarge", ""),
+		Entry("SDN network", cluster("OpenShiftSDN", "4.12.3", false), "m5.xlarge", "'OVNKubernetes' network type"),
+		Entry("old version", cluster("OVNKubernetes", "4.9.10", false), "m5.xlarge", "version 4.10 or later"),
+		Entry("hosted control plane", cluster("OVNKubernetes", "4.12.3", true), "m5.xlarge", "Hosted Control Plane"),
+		Entry("arm instance type", cluster("OVNKubernetes", "4.12.3", false), "m6g.xlarge", "can't run Windows"),
+	)
+
+	It("adds the Windows taint only once", func() {
+		taints := AddWindowsTaint([]*cmv1.TaintBuilder{cmv1.NewTaint().Key("foo").Value("bar").Effect("NoExecute")})
+		Expect(taints).To(HaveLen(2))
+		Expect(AddWindowsTaint(taints)).To(HaveLen(2))
+	})
+
+	It("sets the Windows label without changing the given labels", func() {
+		labels := map[string]string{"foo": "bar"}
+		Expect(AddWindowsLabel(labels)).To(Equal(map[string]string{"foo": "bar", "kubernetes.io/os": "windows"}))
+		Expect(labels).To(HaveLen(1))
+	})
+
+	It("selects the Windows operating system in the raw fields of the machine pool", func() {
+		Expect(WindowsMachinePoolFields()).To(Equal(map[string]interface{}{"operating_system": "windows"}))
+	})
+})
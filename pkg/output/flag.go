@@ -26,7 +26,10 @@ import (
 
 var o string
 
-var formats = []string{"json", "yaml"}
+// wide is the output format that commands printing tables can support to show additional columns.
+const wide = "wide"
+
+var formats = []string{"json", "yaml", customColumnsPrefix + "...", goTemplatePrefix + "..."}
 
 // AddFlag adds the interactive flag to the given set of command line flags.
 func AddFlag(cmd *cobra.Command) {
@@ -35,18 +38,28 @@ func AddFlag(cmd *cobra.Command) {
 		"output",
 		"o",
 		"",
-		fmt.Sprintf("Output format. Allowed formats are %s", formats),
+		fmt.Sprintf("Output format. Allowed formats are %s. Custom columns are given as "+
+			"'HEADER:.path' pairs separated by commas, for example "+
+			"'custom-columns=NAME:.name,REGION:.region.id'. With 'json' failures are also printed "+
+			"to the standard error stream as JSON objects with 'code', 'operation_id' and 'hint' fields", formats),
 	)
 
 	cmd.RegisterFlagCompletionFunc("output", completion)
 }
 
 func completion(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-	return formats, cobra.ShellCompDirectiveDefault
+	return []string{"json", "yaml", customColumnsPrefix, goTemplatePrefix}, cobra.ShellCompDirectiveNoSpace
 }
 
+// HasFlag returns true if the output should be printed using one of the structured formats. The
+// 'wide' format isn't included because it is a variant of the table printed by the command itself.
 func HasFlag() bool {
-	return o != ""
+	return o != "" && o != wide
+}
+
+// Wide returns true if the user asked for the table with additional columns.
+func Wide() bool {
+	return o == wide
 }
 
 // Enabled retursn a boolean flag that indicates if the interactive mode is enabled.
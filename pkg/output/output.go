@@ -24,9 +24,11 @@ import (
 	"fmt"
 	"io"
 	"reflect"
+	"strings"
 
 	"github.com/ghodss/yaml"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	msv1 "github.com/openshift-online/ocm-sdk-go/servicemgmt/v1"
 	"github.com/openshift/rosa/pkg/aws"
 	"gitlab.com/c0b/go-ordered-json"
 )
@@ -40,6 +42,14 @@ var emptyBuffer = []byte{91, 10, 32, 32, 10, 93}
 func Print(resource interface{}) error {
 	var b bytes.Buffer
 	switch reflect.TypeOf(resource).String() {
+	case "*v1.AddOn":
+		if addOn, ok := resource.(*cmv1.AddOn); ok {
+			cmv1.MarshalAddOn(addOn, &b)
+		}
+	case "*v1.AddOnInstallation":
+		if addOnInstallation, ok := resource.(*cmv1.AddOnInstallation); ok {
+			cmv1.MarshalAddOnInstallation(addOnInstallation, &b)
+		}
 	case "[]*v1.CloudRegion":
 		if cloudRegions, ok := resource.([]*cmv1.CloudRegion); ok {
 			cmv1.MarshalCloudRegionList(cloudRegions, &b)
@@ -52,6 +62,14 @@ func Print(resource interface{}) error {
 		if clusters, ok := resource.([]*cmv1.Cluster); ok {
 			cmv1.MarshalClusterList(clusters, &b)
 		}
+	case "[]*v1.ControlPlaneUpgradePolicy":
+		if upgradePolicies, ok := resource.([]*cmv1.ControlPlaneUpgradePolicy); ok {
+			cmv1.MarshalControlPlaneUpgradePolicyList(upgradePolicies, &b)
+		}
+	case "*v1.IdentityProvider":
+		if idp, ok := resource.(*cmv1.IdentityProvider); ok {
+			cmv1.MarshalIdentityProvider(idp, &b)
+		}
 	case "[]*v1.IdentityProvider":
 		if idps, ok := resource.([]*cmv1.IdentityProvider); ok {
 			cmv1.MarshalIdentityProviderList(idps, &b)
@@ -68,14 +86,26 @@ func Print(resource interface{}) error {
 		if machinePool, ok := resource.(*cmv1.MachinePool); ok {
 			cmv1.MarshalMachinePool(machinePool, &b)
 		}
+	case "*v1.ManagedService":
+		if service, ok := resource.(*msv1.ManagedService); ok {
+			msv1.MarshalManagedService(service, &b)
+		}
 	case "[]*v1.MachineType":
 		if machineTypes, ok := resource.([]*cmv1.MachineType); ok {
 			cmv1.MarshalMachineTypeList(machineTypes, &b)
 		}
+	case "[]*v1.NodePool":
+		if nodePools, ok := resource.([]*cmv1.NodePool); ok {
+			cmv1.MarshalNodePoolList(nodePools, &b)
+		}
 	case "*v1.NodePool":
 		if nodePool, ok := resource.(*cmv1.NodePool); ok {
 			cmv1.MarshalNodePool(nodePool, &b)
 		}
+	case "[]*v1.UpgradePolicy":
+		if upgradePolicies, ok := resource.([]*cmv1.UpgradePolicy); ok {
+			cmv1.MarshalUpgradePolicyList(upgradePolicies, &b)
+		}
 	case "[]*v1.Version":
 		if versions, ok := resource.([]*cmv1.Version); ok {
 			cmv1.MarshalVersionList(versions, &b)
@@ -84,6 +114,14 @@ func Print(resource interface{}) error {
 		if versionGate, ok := resource.(*cmv1.VersionGate); ok {
 			cmv1.MarshalVersionGate(versionGate, &b)
 		}
+	case "[]*v1.OperatorIAMRole":
+		if operatorRoles, ok := resource.([]*cmv1.OperatorIAMRole); ok {
+			cmv1.MarshalOperatorIAMRoleList(operatorRoles, &b)
+		}
+	case "*v1.OidcConfig":
+		if oidcConfig, ok := resource.(*cmv1.OidcConfig); ok {
+			cmv1.MarshalOidcConfig(oidcConfig, &b)
+		}
 	case "[]*v1.OidcConfig":
 		if oidcConfigs, ok := resource.([]*cmv1.OidcConfig); ok {
 			cmv1.MarshalOidcConfigList(oidcConfigs, &b)
@@ -106,7 +144,16 @@ func Print(resource interface{}) error {
 				}
 			}
 		}
-	case "object.Object", "map[string]interface {}":
+	case "object.Object", "map[string]interface {}", "[]map[string]interface {}", "*ocm.ClusterAutoscaler",
+		"*ocm.UpgradeGraph", "[]*ocm.BillingAccount", "[]*network.SubnetResult",
+		"[]*network.EgressEndpoint", "[]*aws.PermissionDenial", "[]*aws.RoleDrift", "*aws.NetworkStack",
+		"*estimate.Summary", "*ocm.BreakGlassCredential", "[]*ocm.BreakGlassCredential",
+		"*ocm.ExternalAuth", "[]*ocm.ExternalAuth",
+		"*ocm.KubeletConfig", "[]*ocm.KubeletConfig",
+		"*ocm.TuningConfig", "[]*ocm.TuningConfig",
+		"[]*oidc.Result",
+		"[]*oidcprovider.providerUsage",
+		"*ocm.DNSDomain", "[]*ocm.DNSDomain":
 		{
 			reqBodyBytes := new(bytes.Buffer)
 			json.NewEncoder(reqBodyBytes).Encode(resource)
@@ -142,6 +189,12 @@ func parseResource(body bytes.Buffer) (string, error) {
 		}
 		return string(out), nil
 	default:
+		if strings.HasPrefix(o, customColumnsPrefix) {
+			return renderColumns(strings.TrimPrefix(o, customColumnsPrefix), body.Bytes())
+		}
+		if strings.HasPrefix(o, goTemplatePrefix) {
+			return renderTemplate(strings.TrimPrefix(o, goTemplatePrefix), body.Bytes())
+		}
 		return "", fmt.Errorf("Unknown format '%s'. Valid formats are %s", o, formats)
 	}
 }
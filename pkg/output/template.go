@@ -0,0 +1,204 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions used to implement the 'custom-columns' and 'go-template'
+// output formats, which render the JSON representation of resources in a user defined way.
+
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+)
+
+const (
+	customColumnsPrefix = "custom-columns="
+	goTemplatePrefix    = "go-template="
+)
+
+// column is a single column of the 'custom-columns' output format.
+type column struct {
+	header string
+	path   []pathElement
+}
+
+// pathElement is a single step of a column path, like 'region' or 'subnets[0]'.
+type pathElement struct {
+	field string
+	index *int
+}
+
+// parseColumns parses a specification like 'NAME:.name,REGION:.region.id' into a list of columns.
+func parseColumns(spec string) ([]column, error) {
+	if spec == "" {
+		return nil, fmt.Errorf("Expected at least one column in '%s'", customColumnsPrefix)
+	}
+	columns := []column{}
+	for _, item := range strings.Split(spec, ",") {
+		parts := strings.SplitN(item, ":", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("Invalid column '%s', expected format is 'HEADER:.path'", item)
+		}
+		path, err := parsePath(parts[1])
+		if err != nil {
+			return nil, err
+		}
+		columns = append(columns, column{
+			header: parts[0],
+			path:   path,
+		})
+	}
+	return columns, nil
+}
+
+// parsePath parses a path like '.aws.subnet_ids[0]'. The JSONPath style braces and the leading
+// dot are optional.
+func parsePath(text string) ([]pathElement, error) {
+	text = strings.TrimSpace(text)
+	text = strings.TrimPrefix(text, "{")
+	text = strings.TrimSuffix(text, "}")
+	text = strings.TrimPrefix(text, ".")
+	if text == "" {
+		return nil, fmt.Errorf("Invalid empty column path")
+	}
+	path := []pathElement{}
+	for _, segment := range strings.Split(text, ".") {
+		element := pathElement{field: segment}
+		if start := strings.Index(segment, "["); start != -1 {
+			if !strings.HasSuffix(segment, "]") {
+				return nil, fmt.Errorf("Invalid column path '%s'", text)
+			}
+			index, err := strconv.Atoi(segment[start+1 : len(segment)-1])
+			if err != nil || index < 0 {
+				return nil, fmt.Errorf("Invalid index in column path '%s'", text)
+			}
+			element.field = segment[:start]
+			element.index = &index
+		}
+		if element.field == "" && element.index == nil {
+			return nil, fmt.Errorf("Invalid column path '%s'", text)
+		}
+		path = append(path, element)
+	}
+	return path, nil
+}
+
+// lookup returns the value found following the given path, or nil if there is no such value.
+func lookup(value interface{}, path []pathElement) interface{} {
+	for _, element := range path {
+		if element.field != "" {
+			object, ok := value.(map[string]interface{})
+			if !ok {
+				return nil
+			}
+			value = object[element.field]
+		}
+		if element.index != nil {
+			list, ok := value.([]interface{})
+			if !ok || *element.index >= len(list) {
+				return nil
+			}
+			value = list[*element.index]
+		}
+	}
+	return value
+}
+
+func formatValue(value interface{}) string {
+	switch typed := value.(type) {
+	case nil:
+		return "<none>"
+	case string:
+		return typed
+	case json.Number:
+		return typed.String()
+	case bool:
+		return fmt.Sprint(typed)
+	default:
+		data, err := json.Marshal(typed)
+		if err != nil {
+			return fmt.Sprint(typed)
+		}
+		return string(data)
+	}
+}
+
+// decodeJSON decodes the given JSON document keeping the numbers as they are written, so that large
+// values like sizes in bytes aren't printed in exponent notation.
+func decodeJSON(body []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var data interface{}
+	err := decoder.Decode(&data)
+	return data, err
+}
+
+// renderColumns renders the given JSON document as a table. Lists produce one row per item.
+func renderColumns(spec string, body []byte) (string, error) {
+	columns, err := parseColumns(spec)
+	if err != nil {
+		return "", err
+	}
+	data, err := decodeJSON(body)
+	if err != nil {
+		return "", err
+	}
+	rows, ok := data.([]interface{})
+	if !ok {
+		rows = []interface{}{data}
+	}
+
+	var out bytes.Buffer
+	writer := tabwriter.NewWriter(&out, 0, 0, 2, ' ', 0)
+	headers := make([]string, len(columns))
+	for i, column := range columns {
+		headers[i] = column.header
+	}
+	fmt.Fprintln(writer, strings.Join(headers, "\t"))
+	for _, row := range rows {
+		values := make([]string, len(columns))
+		for i, column := range columns {
+			values[i] = formatValue(lookup(row, column.path))
+		}
+		fmt.Fprintln(writer, strings.Join(values, "\t"))
+	}
+	writer.Flush()
+	return out.String(), nil
+}
+
+// renderTemplate executes the given Go template with the JSON document as data. Lists are passed
+// as is, so templates usually start with '{{range .}}'.
+func renderTemplate(text string, body []byte) (string, error) {
+	tmpl, err := template.New("output").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("Failed to parse template: %v", err)
+	}
+	data, err := decodeJSON(body)
+	if err != nil {
+		return "", err
+	}
+	var out bytes.Buffer
+	err = tmpl.Execute(&out, data)
+	if err != nil {
+		return "", fmt.Errorf("Failed to execute template: %v", err)
+	}
+	return out.String(), nil
+}
@@ -0,0 +1,55 @@
+package output
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Custom output formats", func() {
+	body := []byte(`[
+		{"id": "1", "name": "a", "region": {"id": "us-east-1"}, "subnet_ids": ["subnet-1", "subnet-2"]},
+		{"id": "2", "name": "b", "region": {"id": "eu-west-1"}}
+	]`)
+
+	Context("custom-columns", func() {
+		It("Renders one row per item", func() {
+			out, err := renderColumns("NAME:.name,REGION:{.region.id},SUBNET:.subnet_ids[1]", body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(Equal("NAME  REGION     SUBNET\n" +
+				"a     us-east-1  subnet-2\n" +
+				"b     eu-west-1  <none>\n"))
+		})
+
+		It("Renders a single object as a single row", func() {
+			out, err := renderColumns("ID:.id", []byte(`{"id": "1"}`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(Equal("ID\n1\n"))
+		})
+
+		It("Renders large numbers without exponent", func() {
+			out, err := renderColumns("SIZE:.size,RATIO:.ratio", []byte(`{"size": 16000000000, "ratio": 0.5}`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(Equal("SIZE         RATIO\n16000000000  0.5\n"))
+		})
+
+		It("Rejects invalid columns", func() {
+			_, err := renderColumns("NAME", body)
+			Expect(err).To(MatchError(ContainSubstring("expected format is 'HEADER:.path'")))
+			_, err = renderColumns("NAME:.subnet_ids[x]", body)
+			Expect(err).To(MatchError(ContainSubstring("Invalid index")))
+		})
+	})
+
+	Context("go-template", func() {
+		It("Executes the template with the resource", func() {
+			out, err := renderTemplate(`{{range .}}{{.name}}={{.region.id}} {{end}}`, body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(Equal("a=us-east-1 b=eu-west-1 "))
+		})
+
+		It("Reports template errors", func() {
+			_, err := renderTemplate(`{{range .}`, body)
+			Expect(err).To(MatchError(ContainSubstring("Failed to parse template")))
+		})
+	})
+})
@@ -0,0 +1,142 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions used to dispatch unknown subcommands to external plugins, which
+// are executables named 'rosa-<name>' available in the PATH.
+
+package plugin
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/config"
+)
+
+const Prefix = "rosa-"
+
+// Plugin is an external executable that handles a subcommand.
+type Plugin struct {
+	// Name is the name of the subcommand, for example 'cost-report' for 'rosa cost report'.
+	Name string
+	// Path is the location of the executable.
+	Path string
+	// Args are the command line arguments that are passed to the executable.
+	Args []string
+}
+
+// Find checks if the given command line arguments correspond to a plugin rather than to one of the
+// built-in subcommands. Global flags that precede the name of the plugin are parsed, so that they
+// apply to the environment of the plugin. As with kubectl, the longest matching name wins, so
+// 'rosa cost report' prefers 'rosa-cost-report' to 'rosa-cost'.
+func Find(root *cobra.Command, argv []string) (*Plugin, error) {
+	if len(argv) == 0 {
+		return nil, nil
+	}
+	_, _, err := root.Find(argv)
+	if err == nil {
+		return nil, nil
+	}
+
+	flags := root.PersistentFlags()
+	start := -1
+	for i := 0; i < len(argv); i++ {
+		arg := argv[i]
+		if !strings.HasPrefix(arg, "-") {
+			start = i
+			break
+		}
+		if strings.Contains(arg, "=") {
+			continue
+		}
+		flag := flags.Lookup(strings.TrimLeft(arg, "-"))
+		if flag != nil && flag.Value.Type() != "bool" {
+			i++
+		}
+	}
+	if start == -1 {
+		return nil, nil
+	}
+
+	words := []string{}
+	for _, arg := range argv[start:] {
+		if strings.HasPrefix(arg, "-") || strings.ContainsAny(arg, `/\`) {
+			break
+		}
+		words = append(words, arg)
+	}
+	for i := len(words); i > 0; i-- {
+		name := strings.Join(words[:i], "-")
+		path, err := exec.LookPath(Prefix + name)
+		if err != nil {
+			continue
+		}
+		err = flags.Parse(argv[:start])
+		if err != nil {
+			return nil, err
+		}
+		return &Plugin{
+			Name: name,
+			Path: path,
+			Args: argv[start+i:],
+		}, nil
+	}
+	return nil, nil
+}
+
+// Environment returns the environment variables for the plugin. The location of the configuration
+// file of the selected profile is passed in the OCM_CONFIG variable, and the AWS profile and region
+// of that profile in the usual AWS variables.
+func Environment() ([]string, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+	config.ApplyProfile(cfg)
+	location, err := config.Location()
+	if err != nil {
+		return nil, err
+	}
+	env := []string{}
+	for _, value := range os.Environ() {
+		// The profile is already resolved into the location of the configuration file:
+		if strings.HasPrefix(value, config.ProfileEnv+"=") {
+			continue
+		}
+		env = append(env, value)
+	}
+	env = append(env, fmt.Sprintf("OCM_CONFIG=%s", location))
+	return env, nil
+}
+
+// Execute runs the plugin connected to the standard input and output of the current process.
+func (p *Plugin) Execute() error {
+	env, err := Environment()
+	if err != nil {
+		return err
+	}
+	// #nosec G204
+	cmd := exec.Command(p.Path, p.Args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = env
+	return cmd.Run()
+}
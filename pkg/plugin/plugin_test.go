@@ -0,0 +1,62 @@
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+)
+
+var _ = Describe("Plugins", func() {
+	var root *cobra.Command
+	var dir string
+	var debug bool
+	var color string
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+		GinkgoT().Setenv("PATH", dir)
+		for _, name := range []string{"rosa-cost", "rosa-cost-report"} {
+			path := filepath.Join(dir, name)
+			Expect(os.WriteFile(path, []byte("#!/bin/sh\n"), 0700)).To(Succeed())
+		}
+
+		root = &cobra.Command{Use: "rosa"}
+		root.PersistentFlags().BoolVar(&debug, "debug", false, "")
+		root.PersistentFlags().StringVar(&color, "color", "auto", "")
+		root.AddCommand(&cobra.Command{Use: "list", Run: func(*cobra.Command, []string) {}})
+	})
+
+	It("Ignores built-in subcommands", func() {
+		p, err := Find(root, []string{"list", "clusters"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(p).To(BeNil())
+	})
+
+	It("Ignores unknown subcommands without plugin", func() {
+		p, err := Find(root, []string{"unknown"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(p).To(BeNil())
+	})
+
+	It("Prefers the longest matching name", func() {
+		p, err := Find(root, []string{"cost", "report", "--month", "5"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(p).NotTo(BeNil())
+		Expect(p.Name).To(Equal("cost-report"))
+		Expect(p.Path).To(Equal(filepath.Join(dir, "rosa-cost-report")))
+		Expect(p.Args).To(Equal([]string{"--month", "5"}))
+	})
+
+	It("Parses the global flags that precede the plugin name", func() {
+		p, err := Find(root, []string{"--debug", "--color", "never", "cost", "summary"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(p).NotTo(BeNil())
+		Expect(p.Name).To(Equal("cost"))
+		Expect(p.Args).To(Equal([]string{"summary"}))
+		Expect(debug).To(BeTrue())
+		Expect(color).To(Equal("never"))
+	})
+})
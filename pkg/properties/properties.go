@@ -34,3 +34,7 @@ const FakeCluster = "fake_cluster"
 const UseLocalCredentials = "use_local_credentials"
 
 const ProvisionShardId = "provision_shard_id"
+
+// SharedVPCRoleARN is the name of the property that contains the ARN of the role, in the account
+// that owns a shared VPC, that the cluster assumes to manage the records of its private hosted zone:
+const SharedVPCRoleARN = prefix + "shared_vpc_role_arn"
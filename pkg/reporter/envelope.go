@@ -0,0 +1,143 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the structured representation of errors that is used when the user asked for
+// JSON output, so that wrappers can parse the failures.
+
+package reporter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	ocmerrors "github.com/openshift-online/ocm-sdk-go/errors"
+	"github.com/zgalor/weberr"
+)
+
+// ErrorKind is the value of the 'kind' field of the error envelope.
+const ErrorKind = "Error"
+
+// GenericErrorCode is the code used for errors that don't come from the OCM API.
+const GenericErrorCode = "CLI-ERROR"
+
+// ErrorEnvelope is the JSON object printed to the standard error stream for failures when the
+// output format is JSON.
+type ErrorEnvelope struct {
+	Kind        string `json:"kind"`
+	Code        string `json:"code"`
+	Status      int    `json:"status,omitempty"`
+	OperationID string `json:"operation_id,omitempty"`
+	Reason      string `json:"reason"`
+	Hint        string `json:"hint,omitempty"`
+}
+
+// jsonErrors indicates that errors should be printed as JSON envelopes.
+var jsonErrors bool
+
+// SetJSONErrors enables or disables printing the errors as JSON envelopes.
+func SetJSONErrors(enabled bool) {
+	jsonErrors = enabled
+}
+
+// JSONErrors returns true if errors are printed as JSON envelopes.
+func JSONErrors() bool {
+	return jsonErrors
+}
+
+// NewErrorEnvelope creates the envelope for the given message. The code, HTTP status and operation
+// identifier are taken from the first OCM API error found in the arguments used to format it.
+func NewErrorEnvelope(message string, args ...interface{}) *ErrorEnvelope {
+	envelope := &ErrorEnvelope{
+		Kind:   ErrorKind,
+		Code:   GenericErrorCode,
+		Reason: message,
+	}
+	for _, arg := range args {
+		err, ok := arg.(error)
+		if !ok || err == nil {
+			continue
+		}
+		if ocmErr := findOCMError(err); ocmErr != nil {
+			if ocmErr.Code() != "" {
+				envelope.Code = ocmErr.Code()
+			}
+			envelope.Status = ocmErr.Status()
+			envelope.OperationID = ocmErr.OperationID()
+			break
+		}
+		if status := int(weberr.GetType(err)); status != 0 && envelope.Status == 0 {
+			envelope.Status = status
+		}
+	}
+	envelope.Hint = remediationHint(envelope.Code, envelope.Status)
+	return envelope
+}
+
+// Write writes the envelope as indented JSON to the given writer.
+func (e *ErrorEnvelope) Write(w io.Writer) error {
+	data, err := json.MarshalIndent(e, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%s\n", data)
+	return err
+}
+
+// findOCMError looks for an OCM API error in the chain of the given error, which is either the
+// error itself or one kept as detail by the errors returned by the OCM client.
+func findOCMError(err error) *ocmerrors.Error {
+	for err != nil {
+		if ocmErr, ok := err.(*ocmerrors.Error); ok {
+			return ocmErr
+		}
+		for _, detail := range weberr.GetDetails(err) {
+			if ocmErr, ok := detail.(*ocmerrors.Error); ok && ocmErr != nil {
+				return ocmErr
+			}
+		}
+		if causer, ok := err.(interface{ Cause() error }); ok {
+			err = causer.Cause()
+		} else {
+			err = errors.Unwrap(err)
+		}
+	}
+	return nil
+}
+
+// remediationHint returns a suggestion to fix the error with the given code and HTTP status, or an
+// empty string if there is none.
+func remediationHint(code string, status int) string {
+	if code == "CLUSTERS-MGMT-451" {
+		return "Accept the terms and conditions at " +
+			"https://www.redhat.com/wapps/tnc/ackrequired?site=ocm&event=register and retry."
+	}
+	switch {
+	case status == http.StatusUnauthorized:
+		return "Log in again with 'rosa login'."
+	case status == http.StatusForbidden:
+		return "Check that the account shown by 'rosa whoami' has the required permissions."
+	case status == http.StatusNotFound:
+		return "Check that the resource exists with the corresponding 'rosa list' command."
+	case status == http.StatusConflict:
+		return "The resource already exists or is being changed, check its state and retry."
+	case status == http.StatusTooManyRequests || status >= http.StatusInternalServerError:
+		return "Retry later, or increase the number of retries with '--max-retries'."
+	}
+	return ""
+}
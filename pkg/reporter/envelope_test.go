@@ -0,0 +1,55 @@
+package reporter
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	ocmerrors "github.com/openshift-online/ocm-sdk-go/errors"
+	"github.com/zgalor/weberr"
+)
+
+var _ = Describe("Error envelope", func() {
+	It("uses a generic code for errors that don't come from the API", func() {
+		envelope := NewErrorEnvelope("Failed to read file: boom", fmt.Errorf("boom"))
+		Expect(envelope.Kind).To(Equal(ErrorKind))
+		Expect(envelope.Code).To(Equal(GenericErrorCode))
+		Expect(envelope.Reason).To(Equal("Failed to read file: boom"))
+		Expect(envelope.OperationID).To(BeEmpty())
+		Expect(envelope.Hint).To(BeEmpty())
+	})
+
+	It("takes the code and operation identifier of API errors kept as details", func() {
+		ocmErr, err := ocmerrors.NewError().
+			Status(http.StatusNotFound).
+			Code("CLUSTERS-MGMT-404").
+			OperationID("0123").
+			Reason("Cluster 'mycluster' not found").
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		wrapped := weberr.NotFound.AddDetails(weberr.NotFound.Errorf("%s", ocmErr.Reason()), ocmErr)
+
+		envelope := NewErrorEnvelope("Failed to get cluster", wrapped)
+		Expect(envelope.Code).To(Equal("CLUSTERS-MGMT-404"))
+		Expect(envelope.Status).To(Equal(http.StatusNotFound))
+		Expect(envelope.OperationID).To(Equal("0123"))
+		Expect(envelope.Hint).To(ContainSubstring("rosa list"))
+	})
+
+	It("finds API errors wrapped by other errors", func() {
+		ocmErr, err := ocmerrors.NewError().Status(http.StatusUnauthorized).OperationID("4567").Build()
+		Expect(err).ToNot(HaveOccurred())
+
+		envelope := NewErrorEnvelope("Failed", fmt.Errorf("Failed to get token: %w", ocmErr))
+		Expect(envelope.OperationID).To(Equal("4567"))
+		Expect(envelope.Hint).To(ContainSubstring("rosa login"))
+	})
+
+	It("writes the envelope as JSON", func() {
+		var b bytes.Buffer
+		Expect(NewErrorEnvelope("Failed").Write(&b)).To(Succeed())
+		Expect(b.String()).To(MatchJSON(`{"kind":"Error","code":"CLI-ERROR","reason":"Failed"}`))
+	})
+})
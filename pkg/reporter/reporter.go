@@ -21,8 +21,11 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/sirupsen/logrus"
+
 	"github.com/openshift/rosa/pkg/color"
 	"github.com/openshift/rosa/pkg/debug"
+	"github.com/openshift/rosa/pkg/logging"
 )
 
 // Builder contains the information and logic needed to create a new reporter.
@@ -31,9 +34,10 @@ type Builder struct {
 }
 
 // Object is the reported object used by the tool. It prints the messages to the standard output or
-// error streams.
+// error streams, or sends them to the log when the JSON log format is used.
 type Object struct {
 	errors int
+	logger *logrus.Logger
 }
 
 // New creates a builder that can then be used to configure and build a reporter.
@@ -43,14 +47,22 @@ func New() *Builder {
 
 // Build uses the information contained in the builder to create a new reporter.
 func (b *Builder) Build() (result *Object, err error) {
-	// Create and populate the object:
+	// Create and populate the object. When the log format is JSON the messages are sent to the log
+	// as well, so that the output contains one JSON document per line:
 	result = &Object{}
+	if logging.Format() == logging.JSONFormat {
+		result.logger = logging.NewLogger()
+	}
 
 	return
 }
 
 // Debugf prints a debug message with the given format and arguments.
 func (r *Object) Debugf(format string, args ...interface{}) {
+	if r.logger != nil {
+		r.logger.Debugf(format, args...)
+		return
+	}
 	if !debug.Enabled() {
 		return
 	}
@@ -60,7 +72,9 @@ func (r *Object) Debugf(format string, args ...interface{}) {
 // Infof prints an informative message with the given format and arguments.
 func (r *Object) Infof(format string, args ...interface{}) {
 	message := fmt.Sprintf(format, args...)
-	if color.UseColor() {
+	if r.logger != nil {
+		r.logger.Info(message)
+	} else if color.UseColor() {
 		_, _ = fmt.Fprintf(os.Stdout, "%s%s\n", infoPrefix, message)
 	} else {
 		_, _ = fmt.Fprintf(os.Stdout, "%s%s\n", "INFO: ", message)
@@ -70,7 +84,9 @@ func (r *Object) Infof(format string, args ...interface{}) {
 // Warnf prints an warning message with the given format and arguments.
 func (r *Object) Warnf(format string, args ...interface{}) {
 	message := fmt.Sprintf(format, args...)
-	if color.UseColor() {
+	if r.logger != nil {
+		r.logger.Warn(message)
+	} else if color.UseColor() {
 		_, _ = fmt.Fprintf(os.Stderr, "%s%s\n", warnPrefix, message)
 	} else {
 		_, _ = fmt.Fprintf(os.Stderr, "%s%s\n", "WARN: ", message)
@@ -79,10 +95,15 @@ func (r *Object) Warnf(format string, args ...interface{}) {
 
 // Errorf prints an error message with the given format and arguments. It also return an error
 // containing the same information, which will be usually discarded, except when the caller needs to
-// report the error and also return it.
+// report the error and also return it. When JSON errors are enabled the message is printed as an
+// error envelope.
 func (r *Object) Errorf(format string, args ...interface{}) error {
 	message := fmt.Sprintf(format, args...)
-	if color.UseColor() {
+	if jsonErrors {
+		_ = NewErrorEnvelope(message, args...).Write(os.Stderr)
+	} else if r.logger != nil {
+		r.logger.Error(message)
+	} else if color.UseColor() {
 		_, _ = fmt.Fprintf(os.Stderr, "%s%s\n", errorPrefix, message)
 	} else {
 		_, _ = fmt.Fprintf(os.Stderr, "%s%s\n", "ERR: ", message)
@@ -0,0 +1,46 @@
+package reporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sirupsen/logrus"
+)
+
+var _ = Describe("Reporter", func() {
+	It("Sends the messages to the log when it has a logger", func() {
+		buffer := &bytes.Buffer{}
+		logger := logrus.New()
+		logger.SetFormatter(&logrus.JSONFormatter{})
+		logger.SetOutput(buffer)
+		logger.SetLevel(logrus.InfoLevel)
+		r := &Object{logger: logger}
+
+		r.Debugf("Hidden %d", 0)
+		r.Infof("Creating cluster '%s'", "mycluster")
+		r.Warnf("Quota is low")
+		err := r.Errorf("Failed to create cluster: %s", "boom")
+		Expect(err).To(MatchError("Failed to create cluster: boom"))
+		Expect(r.Errors()).To(Equal(1))
+
+		lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+		Expect(lines).To(HaveLen(3))
+		expected := []struct {
+			level string
+			msg   string
+		}{
+			{"info", "Creating cluster 'mycluster'"},
+			{"warning", "Quota is low"},
+			{"error", "Failed to create cluster: boom"},
+		}
+		for i, line := range lines {
+			var entry map[string]interface{}
+			Expect(json.Unmarshal([]byte(line), &entry)).To(Succeed())
+			Expect(entry["level"]).To(Equal(expected[i].level))
+			Expect(entry["msg"]).To(Equal(expected[i].msg))
+		}
+	})
+})
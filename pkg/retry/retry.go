@@ -0,0 +1,120 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains functions used to implement the '--max-retries' and '--request-timeout'
+// command line options, which control how requests to the OCM and AWS APIs are retried.
+
+package retry
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// AddFlags adds the retry flags to the given set of command line flags.
+func AddFlags(flags *pflag.FlagSet) {
+	flags.IntVar(
+		&maxRetries,
+		"max-retries",
+		-1,
+		"Maximum number of times that failed requests to the OCM and AWS APIs are retried, with "+
+			"exponential backoff and jitter. By default each API uses its own limit.",
+	)
+	flags.DurationVar(
+		&requestTimeout,
+		"request-timeout",
+		0,
+		"Maximum time that each request to the OCM and AWS APIs can take, for example '30s'. "+
+			"By default requests don't time out.",
+	)
+}
+
+// SetDefaults sets the values used when the command line options aren't given, typically loaded
+// from the configuration file. A negative number of retries and a zero timeout keep the defaults.
+func SetDefaults(retries int, timeout time.Duration) {
+	if maxRetries < 0 {
+		maxRetries = retries
+	}
+	if requestTimeout == 0 {
+		requestTimeout = timeout
+	}
+}
+
+// MaxRetries returns the maximum number of retries, or the given default if it hasn't been set.
+func MaxRetries(defaultValue int) int {
+	if maxRetries < 0 {
+		return defaultValue
+	}
+	return maxRetries
+}
+
+// RequestTimeout returns the maximum duration of each request, zero meaning no limit.
+func RequestTimeout() time.Duration {
+	return requestTimeout
+}
+
+// Transport returns a round tripper that cancels requests that take longer than the request
+// timeout, including the time needed to read the response body. It returns the given round
+// tripper unchanged if there is no timeout.
+func Transport(next http.RoundTripper) http.RoundTripper {
+	if requestTimeout <= 0 {
+		return next
+	}
+	return &timeoutTransport{
+		next:    next,
+		timeout: requestTimeout,
+	}
+}
+
+var (
+	maxRetries     = -1
+	requestTimeout time.Duration
+)
+
+type timeoutTransport struct {
+	next    http.RoundTripper
+	timeout time.Duration
+}
+
+func (t *timeoutTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(request.Context(), t.timeout)
+	response, err := t.next.RoundTrip(request.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	// The context can only be cancelled once the body has been read:
+	response.Body = &cancelBody{
+		ReadCloser: response.Body,
+		cancel:     cancel,
+	}
+	return response, nil
+}
+
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
@@ -0,0 +1,74 @@
+package retry
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Retry policy", func() {
+	AfterEach(func() {
+		maxRetries = -1
+		requestTimeout = 0
+	})
+
+	It("uses the defaults of each API unless set", func() {
+		Expect(MaxRetries(12)).To(Equal(12))
+		SetDefaults(3, time.Minute)
+		Expect(MaxRetries(12)).To(Equal(3))
+		Expect(RequestTimeout()).To(Equal(time.Minute))
+	})
+
+	It("gives precedence to the command line options over the configuration", func() {
+		maxRetries = 0
+		requestTimeout = time.Second
+		SetDefaults(3, time.Minute)
+		Expect(MaxRetries(12)).To(Equal(0))
+		Expect(RequestTimeout()).To(Equal(time.Second))
+	})
+
+	It("doesn't wrap the transport without timeout", func() {
+		Expect(Transport(http.DefaultTransport)).To(BeIdenticalTo(http.DefaultTransport))
+	})
+
+	Context("with a request timeout", func() {
+		var server *httptest.Server
+
+		BeforeEach(func() {
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/slow" {
+					select {
+					case <-time.After(time.Second):
+					case <-r.Context().Done():
+					}
+				}
+				w.Write([]byte("ok"))
+			}))
+			requestTimeout = 100 * time.Millisecond
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("reads the body of fast responses", func() {
+			client := &http.Client{Transport: Transport(http.DefaultTransport)}
+			response, err := client.Get(server.URL + "/fast")
+			Expect(err).ToNot(HaveOccurred())
+			defer response.Body.Close()
+			body, err := io.ReadAll(response.Body)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(body)).To(Equal("ok"))
+		})
+
+		It("cancels slow requests", func() {
+			client := &http.Client{Transport: Transport(http.DefaultTransport)}
+			_, err := client.Get(server.URL + "/slow")
+			Expect(err).To(MatchError(ContainSubstring("context deadline exceeded")))
+		})
+	})
+})
@@ -94,5 +94,6 @@ func (r *Runtime) FetchCluster() *cmv1.Cluster {
 		os.Exit(1)
 	}
 	r.Cluster = cluster
+	logging.SetField(logging.ClusterIDField, cluster.ID())
 	return cluster
 }
@@ -0,0 +1,189 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the command line options shared by the commands that manage the tuning
+// configurations of a hosted cluster, and the validation of their TuneD specs.
+
+package tuningconfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/pflag"
+
+	"github.com/openshift/rosa/pkg/interactive"
+)
+
+const (
+	NameFlag     = "name"
+	SpecPathFlag = "spec-path"
+
+	// TuningConfigsFlag is the machine pool option that selects the tuning configs of its nodes.
+	TuningConfigsFlag = "tuning-configs"
+)
+
+// AddNameFlag adds the option that selects the tuning config.
+func AddNameFlag(flags *pflag.FlagSet, name *string) {
+	flags.StringVar(
+		name,
+		NameFlag,
+		"",
+		"Name of the tuning config.",
+	)
+}
+
+// AddSpecPathFlag adds the option that gives the file containing the TuneD spec.
+func AddSpecPathFlag(flags *pflag.FlagSet, path *string) {
+	flags.StringVar(
+		path,
+		SpecPathFlag,
+		"",
+		"Path of a JSON file containing the spec of a TuneD object, with the 'profile' list of named "+
+			"TuneD profiles and the 'recommend' list that selects the profiles applied to the nodes.",
+	)
+}
+
+// AddTuningConfigsFlag adds the machine pool option that selects the tuning configs used by its
+// nodes.
+func AddTuningConfigsFlag(flags *pflag.FlagSet, names *[]string) {
+	flags.StringSliceVar(
+		names,
+		TuningConfigsFlag,
+		nil,
+		"Names of the tuning configs applied to the nodes of a machine pool of a Hosted Control Plane "+
+			"cluster. Format should be a comma-separated list. The tuning configs must exist.",
+	)
+}
+
+// ValidateCluster checks that the cluster supports tuning configs.
+func ValidateCluster(cluster *cmv1.Cluster) error {
+	if !cluster.Hypershift().Enabled() {
+		return fmt.Errorf("Tuning configs are only supported for Hosted Control Plane clusters")
+	}
+	return nil
+}
+
+// GetSpec returns the TuneD spec read from the file given in the command line, asking for the path
+// of the file when it wasn't given or interactive mode is enabled.
+func GetSpec(flags *pflag.FlagSet, path string) (map[string]interface{}, error) {
+	var err error
+	if interactive.Enabled() || !flags.Changed(SpecPathFlag) {
+		path, err = interactive.GetString(interactive.Input{
+			Question: "Spec path",
+			Help:     flags.Lookup(SpecPathFlag).Usage,
+			Default:  path,
+			Required: true,
+			Validators: []interactive.Validator{
+				func(val interface{}) error {
+					_, err := ReadSpec(fmt.Sprintf("%v", val))
+					return err
+				},
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("Expected a valid spec path: %v", err)
+		}
+	}
+	return ReadSpec(path)
+}
+
+// ReadSpec reads the TuneD spec from the given file and checks that it is valid.
+func ReadSpec(path string) (map[string]interface{}, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read tuning config spec: %v", err)
+	}
+	return ParseSpec(data)
+}
+
+// ParseSpec parses the given TuneD spec and checks it before it is sent, so that mistakes are
+// reported with the location of the problem instead of the generic error of the service.
+func ParseSpec(data []byte) (map[string]interface{}, error) {
+	var spec map[string]interface{}
+	err := json.Unmarshal(data, &spec)
+	if err != nil {
+		return nil, fmt.Errorf("The tuning config spec isn't a valid JSON object: %v", err)
+	}
+	for key := range spec {
+		if key != "profile" && key != "recommend" {
+			return nil, fmt.Errorf("The tuning config spec contains unknown field '%s', only 'profile' "+
+				"and 'recommend' are supported", key)
+		}
+	}
+
+	profiles, err := getObjectList(spec, "profile")
+	if err != nil {
+		return nil, err
+	}
+	names := map[string]bool{}
+	for i, profile := range profiles {
+		name, ok := profile["name"].(string)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("Entry %d of 'profile' of the tuning config spec must have a name", i)
+		}
+		if names[name] {
+			return nil, fmt.Errorf("Profile '%s' is defined several times in the tuning config spec", name)
+		}
+		names[name] = true
+		data, ok := profile["data"].(string)
+		if !ok || data == "" {
+			return nil, fmt.Errorf("Profile '%s' of the tuning config spec must have the TuneD "+
+				"profile in its 'data' field", name)
+		}
+	}
+
+	recommends, err := getObjectList(spec, "recommend")
+	if err != nil {
+		return nil, err
+	}
+	for i, recommend := range recommends {
+		name, ok := recommend["profile"].(string)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("Entry %d of 'recommend' of the tuning config spec must have a profile", i)
+		}
+		if !names[name] {
+			return nil, fmt.Errorf("Entry %d of 'recommend' of the tuning config spec references profile "+
+				"'%s', which isn't defined in 'profile'", i, name)
+		}
+		if priority, ok := recommend["priority"]; ok {
+			value, ok := priority.(float64)
+			if !ok || value < 0 || value != float64(int64(value)) {
+				return nil, fmt.Errorf("The priority of entry %d of 'recommend' of the tuning config spec "+
+					"must be a non-negative integer", i)
+			}
+		}
+	}
+	return spec, nil
+}
+
+// getObjectList returns the non-empty list of objects in the given field of the spec.
+func getObjectList(spec map[string]interface{}, field string) ([]map[string]interface{}, error) {
+	items, ok := spec[field].([]interface{})
+	if !ok || len(items) == 0 {
+		return nil, fmt.Errorf("The tuning config spec must contain a non-empty '%s' list", field)
+	}
+	result := make([]map[string]interface{}, len(items))
+	for i, item := range items {
+		result[i], ok = item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("Entry %d of '%s' of the tuning config spec must be an object", i, field)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,70 @@
+package tuningconfig
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+const validSpec = `{
+	"profile": [
+		{
+			"name": "hugepages",
+			"data": "[main]\nsummary=Boot time configuration for hugepages\ninclude=openshift-node\n"
+		}
+	],
+	"recommend": [
+		{
+			"priority": 20,
+			"profile": "hugepages"
+		}
+	]
+}`
+
+var _ = Describe("Tuning config spec", func() {
+	It("Accepts a valid TuneD spec", func() {
+		spec, err := ParseSpec([]byte(validSpec))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(spec).To(HaveKey("profile"))
+		Expect(spec).To(HaveKey("recommend"))
+	})
+
+	DescribeTable("Rejects invalid TuneD specs",
+		func(spec string, message string) {
+			_, err := ParseSpec([]byte(spec))
+			Expect(err).To(MatchError(ContainSubstring(message)))
+		},
+		Entry("not JSON", `profile: []`, "isn't a valid JSON object"),
+		Entry("unknown field", `{"profile": [], "recommend": [], "other": 1}`, "unknown field 'other'"),
+		Entry("no profiles", `{"profile": [], "recommend": [{"profile": "a"}]}`, "non-empty 'profile' list"),
+		Entry("profile without name", `{"profile": [{"data": "x"}], "recommend": [{"profile": "a"}]}`,
+			"must have a name"),
+		Entry("profile without data", `{"profile": [{"name": "a"}], "recommend": [{"profile": "a"}]}`,
+			"'data' field"),
+		Entry("duplicated profile",
+			`{"profile": [{"name": "a", "data": "x"}, {"name": "a", "data": "y"}], "recommend": [{"profile": "a"}]}`,
+			"defined several times"),
+		Entry("no recommend", `{"profile": [{"name": "a", "data": "x"}]}`, "non-empty 'recommend' list"),
+		Entry("unknown recommended profile",
+			`{"profile": [{"name": "a", "data": "x"}], "recommend": [{"profile": "b"}]}`,
+			"which isn't defined"),
+		Entry("negative priority",
+			`{"profile": [{"name": "a", "data": "x"}], "recommend": [{"profile": "a", "priority": -1}]}`,
+			"non-negative integer"),
+		Entry("fractional priority",
+			`{"profile": [{"name": "a", "data": "x"}], "recommend": [{"profile": "a", "priority": 1.5}]}`,
+			"non-negative integer"),
+	)
+
+	It("Reads the spec from a file", func() {
+		path := filepath.Join(GinkgoT().TempDir(), "tuned.json")
+		Expect(os.WriteFile(path, []byte(validSpec), 0600)).To(Succeed())
+		_, err := ReadSpec(path)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = ReadSpec(filepath.Join(GinkgoT().TempDir(), "missing.json"))
+		Expect(err).To(MatchError(ContainSubstring("Failed to read tuning config spec")))
+	})
+})
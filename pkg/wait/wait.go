@@ -0,0 +1,170 @@
+/*
+Copyright (c) 2023 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains functions used to implement the '--wait' and '--timeout' command line
+// options, and the exit codes that commands use to report the outcome of the wait.
+
+package wait
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// Exit codes of the commands that support the '--wait' option.
+const (
+	// ExitReady is used when the resource reached the desired state.
+	ExitReady = 0
+
+	// ExitFailed is used when the resource failed, or when the wait couldn't be completed.
+	ExitFailed = 1
+
+	// ExitTimeout is used when the timeout expired before the resource reached the desired state.
+	ExitTimeout = 2
+)
+
+// ExitCodesHelp describes the exit codes, for use in the long help of the commands.
+const ExitCodesHelp = "When '--wait' is used the command exits with code 0 when the resource is " +
+	"ready, 1 when it fails and 2 when the timeout expires."
+
+// MaxTransientErrors is the number of consecutive transient errors, for example failures to get the
+// state of the resource from the API, that are tolerated while polling.
+const MaxTransientErrors = 5
+
+// Options are the values of the '--wait' and '--timeout' flags of a command.
+type Options struct {
+	// Enabled is true if the user asked to wait for the outcome of the command.
+	Enabled bool
+
+	// Timeout is the maximum time to wait.
+	Timeout time.Duration
+}
+
+// AddFlags adds the '--wait' and '--timeout' flags to the given set of command line flags, and
+// returns the options where their values are stored. The target describes what the command waits
+// for, for example 'the cluster is ready'.
+func AddFlags(flags *pflag.FlagSet, target string, defaultTimeout time.Duration) *Options {
+	options := &Options{}
+	flags.BoolVar(
+		&options.Enabled,
+		"wait",
+		false,
+		fmt.Sprintf("Wait until %s. Exits with code %d when ready, %d when failed and %d when "+
+			"the timeout expires.", target, ExitReady, ExitFailed, ExitTimeout),
+	)
+	flags.DurationVar(
+		&options.Timeout,
+		"timeout",
+		defaultTimeout,
+		"Maximum time to wait when '--wait' is used, for example '90m'.",
+	)
+	return options
+}
+
+// TimeoutError is returned by Poll when the timeout expires.
+type TimeoutError struct {
+	Timeout time.Duration
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("Timed out after %s", e.Timeout)
+}
+
+// IsTimeout returns true if the given error is caused by an expired timeout.
+func IsTimeout(err error) bool {
+	var timeoutErr *TimeoutError
+	return errors.As(err, &timeoutErr)
+}
+
+// TransientError wraps an error of a check that doesn't mean that the resource failed, so polling
+// continues after it.
+type TransientError struct {
+	Err error
+}
+
+func (e *TransientError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *TransientError) Unwrap() error {
+	return e.Err
+}
+
+// Transient marks the given error of a check as transient.
+func Transient(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &TransientError{Err: err}
+}
+
+// IsTransient returns true if the given error is transient.
+func IsTransient(err error) bool {
+	var transientErr *TransientError
+	return errors.As(err, &transientErr)
+}
+
+// Poll calls the given check function every interval until it reports that it is done, it returns
+// an error or the timeout of the options expires.
+func (o *Options) Poll(interval time.Duration, check func() (bool, error)) error {
+	return PollWithTimeout(interval, o.Timeout, check)
+}
+
+// PollWithTimeout is like Poll but with an explicit timeout. Transient errors of the check are
+// ignored, unless there are more than MaxTransientErrors in a row or the timeout expires, in which
+// case the last one is returned.
+func PollWithTimeout(interval time.Duration, timeout time.Duration, check func() (bool, error)) error {
+	deadline := time.Now().Add(timeout)
+	transientErrors := 0
+	for {
+		done, err := check()
+		switch {
+		case IsTransient(err):
+			transientErrors++
+			if transientErrors > MaxTransientErrors {
+				return err
+			}
+		case err != nil:
+			return err
+		case done:
+			return nil
+		default:
+			transientErrors = 0
+		}
+		if time.Now().Add(interval).After(deadline) {
+			if err != nil {
+				return fmt.Errorf("%w, the last error was: %v", &TimeoutError{Timeout: timeout}, err)
+			}
+			return &TimeoutError{Timeout: timeout}
+		}
+		time.Sleep(interval)
+	}
+}
+
+// ExitCode returns the exit code that corresponds to the result of a wait.
+func ExitCode(err error) int {
+	switch {
+	case err == nil:
+		return ExitReady
+	case IsTimeout(err):
+		return ExitTimeout
+	default:
+		return ExitFailed
+	}
+}
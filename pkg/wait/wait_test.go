@@ -0,0 +1,91 @@
+package wait
+
+import (
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/pflag"
+)
+
+var _ = Describe("Poll", func() {
+	It("returns when the check is done", func() {
+		calls := 0
+		err := PollWithTimeout(time.Millisecond, time.Second, func() (bool, error) {
+			calls++
+			return calls == 3, nil
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(calls).To(Equal(3))
+		Expect(ExitCode(err)).To(Equal(ExitReady))
+	})
+
+	It("returns the error of the check", func() {
+		err := PollWithTimeout(time.Millisecond, time.Second, func() (bool, error) {
+			return false, fmt.Errorf("Cluster is in 'error' state")
+		})
+		Expect(err).To(MatchError("Cluster is in 'error' state"))
+		Expect(IsTimeout(err)).To(BeFalse())
+		Expect(ExitCode(err)).To(Equal(ExitFailed))
+	})
+
+	It("times out when the check is never done", func() {
+		err := PollWithTimeout(time.Millisecond, 5*time.Millisecond, func() (bool, error) {
+			return false, nil
+		})
+		Expect(IsTimeout(err)).To(BeTrue())
+		Expect(ExitCode(fmt.Errorf("Failed to wait: %w", err))).To(Equal(ExitTimeout))
+	})
+
+	It("ignores transient errors of the check", func() {
+		calls := 0
+		err := PollWithTimeout(time.Millisecond, time.Second, func() (bool, error) {
+			calls++
+			if calls%2 == 1 {
+				return false, Transient(fmt.Errorf("Connection reset by peer"))
+			}
+			return calls == 2*MaxTransientErrors+2, nil
+		})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("returns the last transient error when there are too many in a row", func() {
+		calls := 0
+		err := PollWithTimeout(time.Millisecond, time.Second, func() (bool, error) {
+			calls++
+			return false, Transient(fmt.Errorf("Service unavailable"))
+		})
+		Expect(err).To(MatchError("Service unavailable"))
+		Expect(calls).To(Equal(MaxTransientErrors + 1))
+		Expect(ExitCode(err)).To(Equal(ExitFailed))
+	})
+
+	It("times out when the last check had a transient error", func() {
+		err := PollWithTimeout(time.Millisecond, 5*time.Millisecond, func() (bool, error) {
+			time.Sleep(time.Millisecond)
+			return false, Transient(fmt.Errorf("Service unavailable"))
+		})
+		if !IsTimeout(err) {
+			// The transient errors may run out before the timeout on a busy machine:
+			Expect(err).To(MatchError("Service unavailable"))
+			return
+		}
+		Expect(err.Error()).To(ContainSubstring("Service unavailable"))
+		Expect(ExitCode(err)).To(Equal(ExitTimeout))
+	})
+})
+
+var _ = Describe("Flags", func() {
+	It("stores the values of each command separately", func() {
+		first := pflag.NewFlagSet("first", pflag.ContinueOnError)
+		second := pflag.NewFlagSet("second", pflag.ContinueOnError)
+		firstOptions := AddFlags(first, "the cluster is ready", time.Hour)
+		secondOptions := AddFlags(second, "the cluster is uninstalled", time.Minute)
+		Expect(first.Parse([]string{"--wait", "--timeout", "2h"})).To(Succeed())
+		Expect(firstOptions.Enabled).To(BeTrue())
+		Expect(firstOptions.Timeout).To(Equal(2 * time.Hour))
+		Expect(secondOptions.Enabled).To(BeFalse())
+		Expect(secondOptions.Timeout).To(Equal(time.Minute))
+	})
+})